@@ -18,8 +18,8 @@ import (
 	"mylittleprice/internal/config"
 	"mylittleprice/internal/container"
 	"mylittleprice/internal/handlers"
-	"mylittleprice/internal/jobs"
 	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/tracing"
 	"mylittleprice/internal/utils"
 )
 
@@ -43,9 +43,24 @@ func main() {
 		slog.String("loki_url", cfg.LokiURL),
 	)
 
+	// Tracing is set up before the container so Redis and database calls
+	// made while it starts use the configured provider
+	shutdownTracing, err := tracing.Init(ctx, cfg)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+	if cfg.TracingEnabled {
+		logger.Info("Tracing enabled",
+			slog.String("endpoint", cfg.TracingEndpoint),
+			slog.Float64("sample_ratio", cfg.TracingSampleRatio),
+		)
+	}
+
 	c, err := container.NewContainer(cfg)
 	if err != nil {
-		logger.Error("Failed to initialize container", err)
+		logger.Error("Failed to initialize container", slog.Any("error", err))
 		os.Exit(1)
 	}
 	defer c.Close()
@@ -56,12 +71,11 @@ func main() {
 	middleware.RegisterMetrics()
 	c.RegisterMetrics() // Register WebSocket and Session metrics from container
 
-	// Initialize and start cleanup job
-	cleanupJob := jobs.NewCleanupJob(c.SearchHistoryService)
-	cleanupJob.Start()
-	defer cleanupJob.Stop()
+	// Start scheduled jobs (data retention and price watches, see JOB_<NAME>_SCHEDULE)
+	c.Scheduler.Start()
+	defer c.Scheduler.Stop()
 
-	logger.Info("Cleanup job started")
+	logger.Info("Job scheduler started")
 
 	fiberApp := fiber.New(fiber.Config{
 		AppName:      "MyLittlePrice API",
@@ -74,6 +88,7 @@ func main() {
 		Format:     "${time} | ${status} | ${latency} | ${method} ${path}\n",
 		TimeFormat: "15:04:05",
 	}))
+	fiberApp.Use(middleware.Tracing())
 	fiberApp.Use(middleware.RequestContext())
 
 	// CORS configuration with dynamic origin validation
@@ -130,23 +145,28 @@ func main() {
 		<-quit
 		logger.Info("Shutting down server...")
 
-		// Stop cleanup job first
-		cleanupJob.Stop()
+		// Stop background jobs first
+		c.Scheduler.Stop()
 
 		if err := fiberApp.Shutdown(); err != nil {
 			utils.LogError(ctx, "Server shutdown error", err)
 		}
 
+		// Flush pending spans
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("Failed to flush traces", slog.Any("error", err))
+		}
+
 		// Close Loki writer to flush remaining logs
 		if err := utils.CloseLoki(); err != nil {
-			logger.Error("Failed to close Loki writer", err)
+			logger.Error("Failed to close Loki writer", slog.Any("error", err))
 		}
 
 		logger.Info("Server stopped gracefully")
 	}()
 
 	if err := fiberApp.Listen(fmt.Sprintf(":%s", port)); err != nil {
-		logger.Error("Failed to start server", err)
+		logger.Error("Failed to start server", slog.Any("error", err))
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,113 @@
+// Command eval scores the universal prompt against scripted conversations.
+//
+// Run it from the backend directory (prompts are loaded from
+// internal/services/prompts):
+//
+//	go run ./cmd/eval                       # live provider from .env
+//	FIXTURE_MODE=replay go run ./cmd/eval   # recorded responses only
+//
+// The report is written to <out>/<prompt hash>.json and the command exits
+// with status 1 if any script fails.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/eval"
+	"mylittleprice/internal/fixtures"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/utils"
+)
+
+func main() {
+	scriptsDir := flag.String("scripts", "evals", "Directory with YAML conversation scripts")
+	outDir := flag.String("out", "evals/reports", "Directory for reports")
+	run := flag.String("run", "", "Only run scripts whose name matches this regex")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	utils.InitLogger(cfg.LogLevel, cfg.LogFormat, false, "", cfg.LokiServiceName)
+
+	scripts, err := eval.LoadScripts(*scriptsDir)
+	if err != nil {
+		log.Fatalf("Failed to load scripts: %v", err)
+	}
+	if *run != "" {
+		scripts, err = filterScripts(scripts, *run)
+		if err != nil {
+			log.Fatalf("Invalid -run pattern: %v", err)
+		}
+	}
+	if len(scripts) == 0 {
+		log.Fatalf("No scripts match %q", *run)
+	}
+
+	// Key rotation state lives in Redis, like in the API server
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisURL,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	geminiKeys := utils.NewKeyRotator(ctx, "gemini", cfg.GeminiAPIKeys, redisClient, utils.KeyRotatorConfig{
+		ResetWindow:     cfg.GeminiKeyReset,
+		CircuitFailures: cfg.KeyCircuitFailures,
+		CircuitCooldown: cfg.KeyCircuitCooldown,
+	})
+
+	var cassettes *fixtures.Store
+	if mode := fixtures.ParseMode(cfg.FixtureMode); mode != fixtures.ModeOff {
+		cassettes = fixtures.NewStore(cfg.FixtureDir, mode)
+	}
+
+	llm, err := services.NewLLMRegistryFromConfig(cfg, geminiKeys, cassettes)
+	if err != nil {
+		log.Fatalf("Failed to initialize LLM providers: %v", err)
+	}
+
+	// No embedding service: category detection falls back to the model's answer
+	gemini := services.NewGeminiService(llm, cfg, nil)
+
+	fmt.Printf("🧪 Running %d eval scripts\n", len(scripts))
+	report := eval.NewRunner(gemini, llm, cfg).Run(scripts)
+
+	path, err := report.Write(*outDir)
+	if err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	report.PrintSummary(os.Stdout)
+	fmt.Printf("📝 Report: %s\n", path)
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func filterScripts(scripts []*eval.Script, pattern string) ([]*eval.Script, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*eval.Script, 0, len(scripts))
+	for _, script := range scripts {
+		if re.MatchString(script.Name) {
+			filtered = append(filtered, script)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,434 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/costrecord"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// CostRecordCreate is the builder for creating a CostRecord entity.
+type CostRecordCreate struct {
+	config
+	mutation *CostRecordMutation
+	hooks    []Hook
+}
+
+// SetRequestID sets the "request_id" field.
+func (_c *CostRecordCreate) SetRequestID(v string) *CostRecordCreate {
+	_c.mutation.SetRequestID(v)
+	return _c
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableRequestID(v *string) *CostRecordCreate {
+	if v != nil {
+		_c.SetRequestID(*v)
+	}
+	return _c
+}
+
+// SetSessionID sets the "session_id" field.
+func (_c *CostRecordCreate) SetSessionID(v string) *CostRecordCreate {
+	_c.mutation.SetSessionID(v)
+	return _c
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableSessionID(v *string) *CostRecordCreate {
+	if v != nil {
+		_c.SetSessionID(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *CostRecordCreate) SetUserID(v uuid.UUID) *CostRecordCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableUserID(v *uuid.UUID) *CostRecordCreate {
+	if v != nil {
+		_c.SetUserID(*v)
+	}
+	return _c
+}
+
+// SetProvider sets the "provider" field.
+func (_c *CostRecordCreate) SetProvider(v string) *CostRecordCreate {
+	_c.mutation.SetProvider(v)
+	return _c
+}
+
+// SetModel sets the "model" field.
+func (_c *CostRecordCreate) SetModel(v string) *CostRecordCreate {
+	_c.mutation.SetModel(v)
+	return _c
+}
+
+// SetPurpose sets the "purpose" field.
+func (_c *CostRecordCreate) SetPurpose(v string) *CostRecordCreate {
+	_c.mutation.SetPurpose(v)
+	return _c
+}
+
+// SetInputTokens sets the "input_tokens" field.
+func (_c *CostRecordCreate) SetInputTokens(v int) *CostRecordCreate {
+	_c.mutation.SetInputTokens(v)
+	return _c
+}
+
+// SetNillableInputTokens sets the "input_tokens" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableInputTokens(v *int) *CostRecordCreate {
+	if v != nil {
+		_c.SetInputTokens(*v)
+	}
+	return _c
+}
+
+// SetOutputTokens sets the "output_tokens" field.
+func (_c *CostRecordCreate) SetOutputTokens(v int) *CostRecordCreate {
+	_c.mutation.SetOutputTokens(v)
+	return _c
+}
+
+// SetNillableOutputTokens sets the "output_tokens" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableOutputTokens(v *int) *CostRecordCreate {
+	if v != nil {
+		_c.SetOutputTokens(*v)
+	}
+	return _c
+}
+
+// SetGrounded sets the "grounded" field.
+func (_c *CostRecordCreate) SetGrounded(v bool) *CostRecordCreate {
+	_c.mutation.SetGrounded(v)
+	return _c
+}
+
+// SetNillableGrounded sets the "grounded" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableGrounded(v *bool) *CostRecordCreate {
+	if v != nil {
+		_c.SetGrounded(*v)
+	}
+	return _c
+}
+
+// SetCostUsd sets the "cost_usd" field.
+func (_c *CostRecordCreate) SetCostUsd(v float64) *CostRecordCreate {
+	_c.mutation.SetCostUsd(v)
+	return _c
+}
+
+// SetNillableCostUsd sets the "cost_usd" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableCostUsd(v *float64) *CostRecordCreate {
+	if v != nil {
+		_c.SetCostUsd(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *CostRecordCreate) SetCreatedAt(v time.Time) *CostRecordCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableCreatedAt(v *time.Time) *CostRecordCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *CostRecordCreate) SetID(v uuid.UUID) *CostRecordCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *CostRecordCreate) SetNillableID(v *uuid.UUID) *CostRecordCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the CostRecordMutation object of the builder.
+func (_c *CostRecordCreate) Mutation() *CostRecordMutation {
+	return _c.mutation
+}
+
+// Save creates the CostRecord in the database.
+func (_c *CostRecordCreate) Save(ctx context.Context) (*CostRecord, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *CostRecordCreate) SaveX(ctx context.Context) *CostRecord {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CostRecordCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CostRecordCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *CostRecordCreate) defaults() {
+	if _, ok := _c.mutation.InputTokens(); !ok {
+		v := costrecord.DefaultInputTokens
+		_c.mutation.SetInputTokens(v)
+	}
+	if _, ok := _c.mutation.OutputTokens(); !ok {
+		v := costrecord.DefaultOutputTokens
+		_c.mutation.SetOutputTokens(v)
+	}
+	if _, ok := _c.mutation.Grounded(); !ok {
+		v := costrecord.DefaultGrounded
+		_c.mutation.SetGrounded(v)
+	}
+	if _, ok := _c.mutation.CostUsd(); !ok {
+		v := costrecord.DefaultCostUsd
+		_c.mutation.SetCostUsd(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := costrecord.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := costrecord.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *CostRecordCreate) check() error {
+	if _, ok := _c.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "CostRecord.provider"`)}
+	}
+	if v, ok := _c.mutation.Provider(); ok {
+		if err := costrecord.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "CostRecord.provider": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Model(); !ok {
+		return &ValidationError{Name: "model", err: errors.New(`ent: missing required field "CostRecord.model"`)}
+	}
+	if v, ok := _c.mutation.Model(); ok {
+		if err := costrecord.ModelValidator(v); err != nil {
+			return &ValidationError{Name: "model", err: fmt.Errorf(`ent: validator failed for field "CostRecord.model": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Purpose(); !ok {
+		return &ValidationError{Name: "purpose", err: errors.New(`ent: missing required field "CostRecord.purpose"`)}
+	}
+	if v, ok := _c.mutation.Purpose(); ok {
+		if err := costrecord.PurposeValidator(v); err != nil {
+			return &ValidationError{Name: "purpose", err: fmt.Errorf(`ent: validator failed for field "CostRecord.purpose": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.InputTokens(); !ok {
+		return &ValidationError{Name: "input_tokens", err: errors.New(`ent: missing required field "CostRecord.input_tokens"`)}
+	}
+	if _, ok := _c.mutation.OutputTokens(); !ok {
+		return &ValidationError{Name: "output_tokens", err: errors.New(`ent: missing required field "CostRecord.output_tokens"`)}
+	}
+	if _, ok := _c.mutation.Grounded(); !ok {
+		return &ValidationError{Name: "grounded", err: errors.New(`ent: missing required field "CostRecord.grounded"`)}
+	}
+	if _, ok := _c.mutation.CostUsd(); !ok {
+		return &ValidationError{Name: "cost_usd", err: errors.New(`ent: missing required field "CostRecord.cost_usd"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "CostRecord.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *CostRecordCreate) sqlSave(ctx context.Context) (*CostRecord, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *CostRecordCreate) createSpec() (*CostRecord, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CostRecord{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(costrecord.Table, sqlgraph.NewFieldSpec(costrecord.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.RequestID(); ok {
+		_spec.SetField(costrecord.FieldRequestID, field.TypeString, value)
+		_node.RequestID = value
+	}
+	if value, ok := _c.mutation.SessionID(); ok {
+		_spec.SetField(costrecord.FieldSessionID, field.TypeString, value)
+		_node.SessionID = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(costrecord.FieldUserID, field.TypeUUID, value)
+		_node.UserID = &value
+	}
+	if value, ok := _c.mutation.Provider(); ok {
+		_spec.SetField(costrecord.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := _c.mutation.Model(); ok {
+		_spec.SetField(costrecord.FieldModel, field.TypeString, value)
+		_node.Model = value
+	}
+	if value, ok := _c.mutation.Purpose(); ok {
+		_spec.SetField(costrecord.FieldPurpose, field.TypeString, value)
+		_node.Purpose = value
+	}
+	if value, ok := _c.mutation.InputTokens(); ok {
+		_spec.SetField(costrecord.FieldInputTokens, field.TypeInt, value)
+		_node.InputTokens = value
+	}
+	if value, ok := _c.mutation.OutputTokens(); ok {
+		_spec.SetField(costrecord.FieldOutputTokens, field.TypeInt, value)
+		_node.OutputTokens = value
+	}
+	if value, ok := _c.mutation.Grounded(); ok {
+		_spec.SetField(costrecord.FieldGrounded, field.TypeBool, value)
+		_node.Grounded = value
+	}
+	if value, ok := _c.mutation.CostUsd(); ok {
+		_spec.SetField(costrecord.FieldCostUsd, field.TypeFloat64, value)
+		_node.CostUsd = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(costrecord.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// CostRecordCreateBulk is the builder for creating many CostRecord entities in bulk.
+type CostRecordCreateBulk struct {
+	config
+	err      error
+	builders []*CostRecordCreate
+}
+
+// Save creates the CostRecord entities in the database.
+func (_c *CostRecordCreateBulk) Save(ctx context.Context) ([]*CostRecord, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*CostRecord, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CostRecordMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *CostRecordCreateBulk) SaveX(ctx context.Context) []*CostRecord {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CostRecordCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CostRecordCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,286 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// CostRecordUpdate is the builder for updating CostRecord entities.
+type CostRecordUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CostRecordMutation
+}
+
+// Where appends a list predicates to the CostRecordUpdate builder.
+func (_u *CostRecordUpdate) Where(ps ...predicate.CostRecord) *CostRecordUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *CostRecordUpdate) SetSessionID(v string) *CostRecordUpdate {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *CostRecordUpdate) SetNillableSessionID(v *string) *CostRecordUpdate {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *CostRecordUpdate) ClearSessionID() *CostRecordUpdate {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *CostRecordUpdate) SetUserID(v uuid.UUID) *CostRecordUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *CostRecordUpdate) SetNillableUserID(v *uuid.UUID) *CostRecordUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// ClearUserID clears the value of the "user_id" field.
+func (_u *CostRecordUpdate) ClearUserID() *CostRecordUpdate {
+	_u.mutation.ClearUserID()
+	return _u
+}
+
+// Mutation returns the CostRecordMutation object of the builder.
+func (_u *CostRecordUpdate) Mutation() *CostRecordMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *CostRecordUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CostRecordUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *CostRecordUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CostRecordUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *CostRecordUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(costrecord.Table, costrecord.Columns, sqlgraph.NewFieldSpec(costrecord.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.RequestIDCleared() {
+		_spec.ClearField(costrecord.FieldRequestID, field.TypeString)
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(costrecord.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(costrecord.FieldSessionID, field.TypeString)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(costrecord.FieldUserID, field.TypeUUID, value)
+	}
+	if _u.mutation.UserIDCleared() {
+		_spec.ClearField(costrecord.FieldUserID, field.TypeUUID)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{costrecord.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// CostRecordUpdateOne is the builder for updating a single CostRecord entity.
+type CostRecordUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CostRecordMutation
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *CostRecordUpdateOne) SetSessionID(v string) *CostRecordUpdateOne {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *CostRecordUpdateOne) SetNillableSessionID(v *string) *CostRecordUpdateOne {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *CostRecordUpdateOne) ClearSessionID() *CostRecordUpdateOne {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *CostRecordUpdateOne) SetUserID(v uuid.UUID) *CostRecordUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *CostRecordUpdateOne) SetNillableUserID(v *uuid.UUID) *CostRecordUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// ClearUserID clears the value of the "user_id" field.
+func (_u *CostRecordUpdateOne) ClearUserID() *CostRecordUpdateOne {
+	_u.mutation.ClearUserID()
+	return _u
+}
+
+// Mutation returns the CostRecordMutation object of the builder.
+func (_u *CostRecordUpdateOne) Mutation() *CostRecordMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the CostRecordUpdate builder.
+func (_u *CostRecordUpdateOne) Where(ps ...predicate.CostRecord) *CostRecordUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *CostRecordUpdateOne) Select(field string, fields ...string) *CostRecordUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated CostRecord entity.
+func (_u *CostRecordUpdateOne) Save(ctx context.Context) (*CostRecord, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CostRecordUpdateOne) SaveX(ctx context.Context) *CostRecord {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *CostRecordUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CostRecordUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *CostRecordUpdateOne) sqlSave(ctx context.Context) (_node *CostRecord, err error) {
+	_spec := sqlgraph.NewUpdateSpec(costrecord.Table, costrecord.Columns, sqlgraph.NewFieldSpec(costrecord.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CostRecord.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, costrecord.FieldID)
+		for _, f := range fields {
+			if !costrecord.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != costrecord.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.RequestIDCleared() {
+		_spec.ClearField(costrecord.FieldRequestID, field.TypeString)
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(costrecord.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(costrecord.FieldSessionID, field.TypeString)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(costrecord.FieldUserID, field.TypeUUID, value)
+	}
+	if _u.mutation.UserIDCleared() {
+		_spec.ClearField(costrecord.FieldUserID, field.TypeUUID)
+	}
+	_node = &CostRecord{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{costrecord.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,11 +6,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mylittleprice/ent/apikey"
+	"mylittleprice/ent/auditlog"
 	"mylittleprice/ent/chatsession"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/jobrun"
 	"mylittleprice/ent/message"
+	"mylittleprice/ent/priceobservation"
 	"mylittleprice/ent/searchhistory"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/ent/sessionsnapshot"
 	"mylittleprice/ent/user"
+	"mylittleprice/ent/useridentity"
 	"mylittleprice/ent/userpreference"
+	"mylittleprice/ent/watch"
 	"reflect"
 	"sync"
 
@@ -77,11 +86,20 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			chatsession.Table:    chatsession.ValidColumn,
-			message.Table:        message.ValidColumn,
-			searchhistory.Table:  searchhistory.ValidColumn,
-			user.Table:           user.ValidColumn,
-			userpreference.Table: userpreference.ValidColumn,
+			apikey.Table:             apikey.ValidColumn,
+			auditlog.Table:           auditlog.ValidColumn,
+			chatsession.Table:        chatsession.ValidColumn,
+			costrecord.Table:         costrecord.ValidColumn,
+			jobrun.Table:             jobrun.ValidColumn,
+			message.Table:            message.ValidColumn,
+			priceobservation.Table:   priceobservation.ValidColumn,
+			searchhistory.Table:      searchhistory.ValidColumn,
+			sessionparticipant.Table: sessionparticipant.ValidColumn,
+			sessionsnapshot.Table:    sessionsnapshot.ValidColumn,
+			user.Table:               user.ValidColumn,
+			useridentity.Table:       useridentity.ValidColumn,
+			userpreference.Table:     userpreference.ValidColumn,
+			watch.Table:              watch.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
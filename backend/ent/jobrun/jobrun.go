@@ -0,0 +1,141 @@
+// Code generated by ent, DO NOT EDIT.
+
+package jobrun
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the jobrun type in the database.
+	Label = "job_run"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldJob holds the string denoting the job field in the database.
+	FieldJob = "job"
+	// FieldTrigger holds the string denoting the trigger field in the database.
+	FieldTrigger = "trigger"
+	// FieldTriggeredBy holds the string denoting the triggered_by field in the database.
+	FieldTriggeredBy = "triggered_by"
+	// FieldInstance holds the string denoting the instance field in the database.
+	FieldInstance = "instance"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldRowsAffected holds the string denoting the rows_affected field in the database.
+	FieldRowsAffected = "rows_affected"
+	// FieldError holds the string denoting the error field in the database.
+	FieldError = "error"
+	// FieldDurationMs holds the string denoting the duration_ms field in the database.
+	FieldDurationMs = "duration_ms"
+	// FieldStartedAt holds the string denoting the started_at field in the database.
+	FieldStartedAt = "started_at"
+	// FieldFinishedAt holds the string denoting the finished_at field in the database.
+	FieldFinishedAt = "finished_at"
+	// Table holds the table name of the jobrun in the database.
+	Table = "job_runs"
+)
+
+// Columns holds all SQL columns for jobrun fields.
+var Columns = []string{
+	FieldID,
+	FieldJob,
+	FieldTrigger,
+	FieldTriggeredBy,
+	FieldInstance,
+	FieldStatus,
+	FieldRowsAffected,
+	FieldError,
+	FieldDurationMs,
+	FieldStartedAt,
+	FieldFinishedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// JobValidator is a validator for the "job" field. It is called by the builders before save.
+	JobValidator func(string) error
+	// TriggerValidator is a validator for the "trigger" field. It is called by the builders before save.
+	TriggerValidator func(string) error
+	// InstanceValidator is a validator for the "instance" field. It is called by the builders before save.
+	InstanceValidator func(string) error
+	// DefaultStatus holds the default value on creation for the "status" field.
+	DefaultStatus string
+	// DefaultRowsAffected holds the default value on creation for the "rows_affected" field.
+	DefaultRowsAffected int64
+	// DefaultDurationMs holds the default value on creation for the "duration_ms" field.
+	DefaultDurationMs int64
+	// DefaultStartedAt holds the default value on creation for the "started_at" field.
+	DefaultStartedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the JobRun queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByJob orders the results by the job field.
+func ByJob(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldJob, opts...).ToFunc()
+}
+
+// ByTrigger orders the results by the trigger field.
+func ByTrigger(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTrigger, opts...).ToFunc()
+}
+
+// ByTriggeredBy orders the results by the triggered_by field.
+func ByTriggeredBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTriggeredBy, opts...).ToFunc()
+}
+
+// ByInstance orders the results by the instance field.
+func ByInstance(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldInstance, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByRowsAffected orders the results by the rows_affected field.
+func ByRowsAffected(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRowsAffected, opts...).ToFunc()
+}
+
+// ByError orders the results by the error field.
+func ByError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldError, opts...).ToFunc()
+}
+
+// ByDurationMs orders the results by the duration_ms field.
+func ByDurationMs(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDurationMs, opts...).ToFunc()
+}
+
+// ByStartedAt orders the results by the started_at field.
+func ByStartedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStartedAt, opts...).ToFunc()
+}
+
+// ByFinishedAt orders the results by the finished_at field.
+func ByFinishedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFinishedAt, opts...).ToFunc()
+}
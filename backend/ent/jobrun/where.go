@@ -0,0 +1,701 @@
+// Code generated by ent, DO NOT EDIT.
+
+package jobrun
+
+import (
+	"mylittleprice/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldID, id))
+}
+
+// Job applies equality check predicate on the "job" field. It's identical to JobEQ.
+func Job(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldJob, v))
+}
+
+// Trigger applies equality check predicate on the "trigger" field. It's identical to TriggerEQ.
+func Trigger(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldTrigger, v))
+}
+
+// TriggeredBy applies equality check predicate on the "triggered_by" field. It's identical to TriggeredByEQ.
+func TriggeredBy(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldTriggeredBy, v))
+}
+
+// Instance applies equality check predicate on the "instance" field. It's identical to InstanceEQ.
+func Instance(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldInstance, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldStatus, v))
+}
+
+// RowsAffected applies equality check predicate on the "rows_affected" field. It's identical to RowsAffectedEQ.
+func RowsAffected(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldRowsAffected, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldError, v))
+}
+
+// DurationMs applies equality check predicate on the "duration_ms" field. It's identical to DurationMsEQ.
+func DurationMs(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldDurationMs, v))
+}
+
+// StartedAt applies equality check predicate on the "started_at" field. It's identical to StartedAtEQ.
+func StartedAt(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldStartedAt, v))
+}
+
+// FinishedAt applies equality check predicate on the "finished_at" field. It's identical to FinishedAtEQ.
+func FinishedAt(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldFinishedAt, v))
+}
+
+// JobEQ applies the EQ predicate on the "job" field.
+func JobEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldJob, v))
+}
+
+// JobNEQ applies the NEQ predicate on the "job" field.
+func JobNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldJob, v))
+}
+
+// JobIn applies the In predicate on the "job" field.
+func JobIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldJob, vs...))
+}
+
+// JobNotIn applies the NotIn predicate on the "job" field.
+func JobNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldJob, vs...))
+}
+
+// JobGT applies the GT predicate on the "job" field.
+func JobGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldJob, v))
+}
+
+// JobGTE applies the GTE predicate on the "job" field.
+func JobGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldJob, v))
+}
+
+// JobLT applies the LT predicate on the "job" field.
+func JobLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldJob, v))
+}
+
+// JobLTE applies the LTE predicate on the "job" field.
+func JobLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldJob, v))
+}
+
+// JobContains applies the Contains predicate on the "job" field.
+func JobContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldJob, v))
+}
+
+// JobHasPrefix applies the HasPrefix predicate on the "job" field.
+func JobHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldJob, v))
+}
+
+// JobHasSuffix applies the HasSuffix predicate on the "job" field.
+func JobHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldJob, v))
+}
+
+// JobEqualFold applies the EqualFold predicate on the "job" field.
+func JobEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldJob, v))
+}
+
+// JobContainsFold applies the ContainsFold predicate on the "job" field.
+func JobContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldJob, v))
+}
+
+// TriggerEQ applies the EQ predicate on the "trigger" field.
+func TriggerEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldTrigger, v))
+}
+
+// TriggerNEQ applies the NEQ predicate on the "trigger" field.
+func TriggerNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldTrigger, v))
+}
+
+// TriggerIn applies the In predicate on the "trigger" field.
+func TriggerIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldTrigger, vs...))
+}
+
+// TriggerNotIn applies the NotIn predicate on the "trigger" field.
+func TriggerNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldTrigger, vs...))
+}
+
+// TriggerGT applies the GT predicate on the "trigger" field.
+func TriggerGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldTrigger, v))
+}
+
+// TriggerGTE applies the GTE predicate on the "trigger" field.
+func TriggerGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldTrigger, v))
+}
+
+// TriggerLT applies the LT predicate on the "trigger" field.
+func TriggerLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldTrigger, v))
+}
+
+// TriggerLTE applies the LTE predicate on the "trigger" field.
+func TriggerLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldTrigger, v))
+}
+
+// TriggerContains applies the Contains predicate on the "trigger" field.
+func TriggerContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldTrigger, v))
+}
+
+// TriggerHasPrefix applies the HasPrefix predicate on the "trigger" field.
+func TriggerHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldTrigger, v))
+}
+
+// TriggerHasSuffix applies the HasSuffix predicate on the "trigger" field.
+func TriggerHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldTrigger, v))
+}
+
+// TriggerEqualFold applies the EqualFold predicate on the "trigger" field.
+func TriggerEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldTrigger, v))
+}
+
+// TriggerContainsFold applies the ContainsFold predicate on the "trigger" field.
+func TriggerContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldTrigger, v))
+}
+
+// TriggeredByEQ applies the EQ predicate on the "triggered_by" field.
+func TriggeredByEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldTriggeredBy, v))
+}
+
+// TriggeredByNEQ applies the NEQ predicate on the "triggered_by" field.
+func TriggeredByNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldTriggeredBy, v))
+}
+
+// TriggeredByIn applies the In predicate on the "triggered_by" field.
+func TriggeredByIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldTriggeredBy, vs...))
+}
+
+// TriggeredByNotIn applies the NotIn predicate on the "triggered_by" field.
+func TriggeredByNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldTriggeredBy, vs...))
+}
+
+// TriggeredByGT applies the GT predicate on the "triggered_by" field.
+func TriggeredByGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldTriggeredBy, v))
+}
+
+// TriggeredByGTE applies the GTE predicate on the "triggered_by" field.
+func TriggeredByGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldTriggeredBy, v))
+}
+
+// TriggeredByLT applies the LT predicate on the "triggered_by" field.
+func TriggeredByLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldTriggeredBy, v))
+}
+
+// TriggeredByLTE applies the LTE predicate on the "triggered_by" field.
+func TriggeredByLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldTriggeredBy, v))
+}
+
+// TriggeredByContains applies the Contains predicate on the "triggered_by" field.
+func TriggeredByContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldTriggeredBy, v))
+}
+
+// TriggeredByHasPrefix applies the HasPrefix predicate on the "triggered_by" field.
+func TriggeredByHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldTriggeredBy, v))
+}
+
+// TriggeredByHasSuffix applies the HasSuffix predicate on the "triggered_by" field.
+func TriggeredByHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldTriggeredBy, v))
+}
+
+// TriggeredByIsNil applies the IsNil predicate on the "triggered_by" field.
+func TriggeredByIsNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldIsNull(FieldTriggeredBy))
+}
+
+// TriggeredByNotNil applies the NotNil predicate on the "triggered_by" field.
+func TriggeredByNotNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotNull(FieldTriggeredBy))
+}
+
+// TriggeredByEqualFold applies the EqualFold predicate on the "triggered_by" field.
+func TriggeredByEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldTriggeredBy, v))
+}
+
+// TriggeredByContainsFold applies the ContainsFold predicate on the "triggered_by" field.
+func TriggeredByContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldTriggeredBy, v))
+}
+
+// InstanceEQ applies the EQ predicate on the "instance" field.
+func InstanceEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldInstance, v))
+}
+
+// InstanceNEQ applies the NEQ predicate on the "instance" field.
+func InstanceNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldInstance, v))
+}
+
+// InstanceIn applies the In predicate on the "instance" field.
+func InstanceIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldInstance, vs...))
+}
+
+// InstanceNotIn applies the NotIn predicate on the "instance" field.
+func InstanceNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldInstance, vs...))
+}
+
+// InstanceGT applies the GT predicate on the "instance" field.
+func InstanceGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldInstance, v))
+}
+
+// InstanceGTE applies the GTE predicate on the "instance" field.
+func InstanceGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldInstance, v))
+}
+
+// InstanceLT applies the LT predicate on the "instance" field.
+func InstanceLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldInstance, v))
+}
+
+// InstanceLTE applies the LTE predicate on the "instance" field.
+func InstanceLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldInstance, v))
+}
+
+// InstanceContains applies the Contains predicate on the "instance" field.
+func InstanceContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldInstance, v))
+}
+
+// InstanceHasPrefix applies the HasPrefix predicate on the "instance" field.
+func InstanceHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldInstance, v))
+}
+
+// InstanceHasSuffix applies the HasSuffix predicate on the "instance" field.
+func InstanceHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldInstance, v))
+}
+
+// InstanceEqualFold applies the EqualFold predicate on the "instance" field.
+func InstanceEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldInstance, v))
+}
+
+// InstanceContainsFold applies the ContainsFold predicate on the "instance" field.
+func InstanceContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldInstance, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldStatus, v))
+}
+
+// RowsAffectedEQ applies the EQ predicate on the "rows_affected" field.
+func RowsAffectedEQ(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldRowsAffected, v))
+}
+
+// RowsAffectedNEQ applies the NEQ predicate on the "rows_affected" field.
+func RowsAffectedNEQ(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldRowsAffected, v))
+}
+
+// RowsAffectedIn applies the In predicate on the "rows_affected" field.
+func RowsAffectedIn(vs ...int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldRowsAffected, vs...))
+}
+
+// RowsAffectedNotIn applies the NotIn predicate on the "rows_affected" field.
+func RowsAffectedNotIn(vs ...int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldRowsAffected, vs...))
+}
+
+// RowsAffectedGT applies the GT predicate on the "rows_affected" field.
+func RowsAffectedGT(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldRowsAffected, v))
+}
+
+// RowsAffectedGTE applies the GTE predicate on the "rows_affected" field.
+func RowsAffectedGTE(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldRowsAffected, v))
+}
+
+// RowsAffectedLT applies the LT predicate on the "rows_affected" field.
+func RowsAffectedLT(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldRowsAffected, v))
+}
+
+// RowsAffectedLTE applies the LTE predicate on the "rows_affected" field.
+func RowsAffectedLTE(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldRowsAffected, v))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.JobRun {
+	return predicate.JobRun(sql.FieldContainsFold(FieldError, v))
+}
+
+// DurationMsEQ applies the EQ predicate on the "duration_ms" field.
+func DurationMsEQ(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldDurationMs, v))
+}
+
+// DurationMsNEQ applies the NEQ predicate on the "duration_ms" field.
+func DurationMsNEQ(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldDurationMs, v))
+}
+
+// DurationMsIn applies the In predicate on the "duration_ms" field.
+func DurationMsIn(vs ...int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldDurationMs, vs...))
+}
+
+// DurationMsNotIn applies the NotIn predicate on the "duration_ms" field.
+func DurationMsNotIn(vs ...int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldDurationMs, vs...))
+}
+
+// DurationMsGT applies the GT predicate on the "duration_ms" field.
+func DurationMsGT(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldDurationMs, v))
+}
+
+// DurationMsGTE applies the GTE predicate on the "duration_ms" field.
+func DurationMsGTE(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldDurationMs, v))
+}
+
+// DurationMsLT applies the LT predicate on the "duration_ms" field.
+func DurationMsLT(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldDurationMs, v))
+}
+
+// DurationMsLTE applies the LTE predicate on the "duration_ms" field.
+func DurationMsLTE(v int64) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldDurationMs, v))
+}
+
+// StartedAtEQ applies the EQ predicate on the "started_at" field.
+func StartedAtEQ(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldStartedAt, v))
+}
+
+// StartedAtNEQ applies the NEQ predicate on the "started_at" field.
+func StartedAtNEQ(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldStartedAt, v))
+}
+
+// StartedAtIn applies the In predicate on the "started_at" field.
+func StartedAtIn(vs ...time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldStartedAt, vs...))
+}
+
+// StartedAtNotIn applies the NotIn predicate on the "started_at" field.
+func StartedAtNotIn(vs ...time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldStartedAt, vs...))
+}
+
+// StartedAtGT applies the GT predicate on the "started_at" field.
+func StartedAtGT(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldStartedAt, v))
+}
+
+// StartedAtGTE applies the GTE predicate on the "started_at" field.
+func StartedAtGTE(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldStartedAt, v))
+}
+
+// StartedAtLT applies the LT predicate on the "started_at" field.
+func StartedAtLT(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldStartedAt, v))
+}
+
+// StartedAtLTE applies the LTE predicate on the "started_at" field.
+func StartedAtLTE(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldStartedAt, v))
+}
+
+// FinishedAtEQ applies the EQ predicate on the "finished_at" field.
+func FinishedAtEQ(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldEQ(FieldFinishedAt, v))
+}
+
+// FinishedAtNEQ applies the NEQ predicate on the "finished_at" field.
+func FinishedAtNEQ(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNEQ(FieldFinishedAt, v))
+}
+
+// FinishedAtIn applies the In predicate on the "finished_at" field.
+func FinishedAtIn(vs ...time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldIn(FieldFinishedAt, vs...))
+}
+
+// FinishedAtNotIn applies the NotIn predicate on the "finished_at" field.
+func FinishedAtNotIn(vs ...time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotIn(FieldFinishedAt, vs...))
+}
+
+// FinishedAtGT applies the GT predicate on the "finished_at" field.
+func FinishedAtGT(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGT(FieldFinishedAt, v))
+}
+
+// FinishedAtGTE applies the GTE predicate on the "finished_at" field.
+func FinishedAtGTE(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldGTE(FieldFinishedAt, v))
+}
+
+// FinishedAtLT applies the LT predicate on the "finished_at" field.
+func FinishedAtLT(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLT(FieldFinishedAt, v))
+}
+
+// FinishedAtLTE applies the LTE predicate on the "finished_at" field.
+func FinishedAtLTE(v time.Time) predicate.JobRun {
+	return predicate.JobRun(sql.FieldLTE(FieldFinishedAt, v))
+}
+
+// FinishedAtIsNil applies the IsNil predicate on the "finished_at" field.
+func FinishedAtIsNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldIsNull(FieldFinishedAt))
+}
+
+// FinishedAtNotNil applies the NotNil predicate on the "finished_at" field.
+func FinishedAtNotNil() predicate.JobRun {
+	return predicate.JobRun(sql.FieldNotNull(FieldFinishedAt))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.JobRun) predicate.JobRun {
+	return predicate.JobRun(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.JobRun) predicate.JobRun {
+	return predicate.JobRun(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.JobRun) predicate.JobRun {
+	return predicate.JobRun(sql.NotPredicates(p))
+}
@@ -0,0 +1,428 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/jobrun"
+	"mylittleprice/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// JobRunUpdate is the builder for updating JobRun entities.
+type JobRunUpdate struct {
+	config
+	hooks    []Hook
+	mutation *JobRunMutation
+}
+
+// Where appends a list predicates to the JobRunUpdate builder.
+func (_u *JobRunUpdate) Where(ps ...predicate.JobRun) *JobRunUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *JobRunUpdate) SetStatus(v string) *JobRunUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *JobRunUpdate) SetNillableStatus(v *string) *JobRunUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetRowsAffected sets the "rows_affected" field.
+func (_u *JobRunUpdate) SetRowsAffected(v int64) *JobRunUpdate {
+	_u.mutation.ResetRowsAffected()
+	_u.mutation.SetRowsAffected(v)
+	return _u
+}
+
+// SetNillableRowsAffected sets the "rows_affected" field if the given value is not nil.
+func (_u *JobRunUpdate) SetNillableRowsAffected(v *int64) *JobRunUpdate {
+	if v != nil {
+		_u.SetRowsAffected(*v)
+	}
+	return _u
+}
+
+// AddRowsAffected adds value to the "rows_affected" field.
+func (_u *JobRunUpdate) AddRowsAffected(v int64) *JobRunUpdate {
+	_u.mutation.AddRowsAffected(v)
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *JobRunUpdate) SetError(v string) *JobRunUpdate {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *JobRunUpdate) SetNillableError(v *string) *JobRunUpdate {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *JobRunUpdate) ClearError() *JobRunUpdate {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (_u *JobRunUpdate) SetDurationMs(v int64) *JobRunUpdate {
+	_u.mutation.ResetDurationMs()
+	_u.mutation.SetDurationMs(v)
+	return _u
+}
+
+// SetNillableDurationMs sets the "duration_ms" field if the given value is not nil.
+func (_u *JobRunUpdate) SetNillableDurationMs(v *int64) *JobRunUpdate {
+	if v != nil {
+		_u.SetDurationMs(*v)
+	}
+	return _u
+}
+
+// AddDurationMs adds value to the "duration_ms" field.
+func (_u *JobRunUpdate) AddDurationMs(v int64) *JobRunUpdate {
+	_u.mutation.AddDurationMs(v)
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *JobRunUpdate) SetFinishedAt(v time.Time) *JobRunUpdate {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *JobRunUpdate) SetNillableFinishedAt(v *time.Time) *JobRunUpdate {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *JobRunUpdate) ClearFinishedAt() *JobRunUpdate {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// Mutation returns the JobRunMutation object of the builder.
+func (_u *JobRunUpdate) Mutation() *JobRunMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *JobRunUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *JobRunUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *JobRunUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *JobRunUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *JobRunUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(jobrun.Table, jobrun.Columns, sqlgraph.NewFieldSpec(jobrun.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.TriggeredByCleared() {
+		_spec.ClearField(jobrun.FieldTriggeredBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(jobrun.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RowsAffected(); ok {
+		_spec.SetField(jobrun.FieldRowsAffected, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRowsAffected(); ok {
+		_spec.AddField(jobrun.FieldRowsAffected, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(jobrun.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(jobrun.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.DurationMs(); ok {
+		_spec.SetField(jobrun.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedDurationMs(); ok {
+		_spec.AddField(jobrun.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(jobrun.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(jobrun.FieldFinishedAt, field.TypeTime)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{jobrun.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// JobRunUpdateOne is the builder for updating a single JobRun entity.
+type JobRunUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *JobRunMutation
+}
+
+// SetStatus sets the "status" field.
+func (_u *JobRunUpdateOne) SetStatus(v string) *JobRunUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *JobRunUpdateOne) SetNillableStatus(v *string) *JobRunUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetRowsAffected sets the "rows_affected" field.
+func (_u *JobRunUpdateOne) SetRowsAffected(v int64) *JobRunUpdateOne {
+	_u.mutation.ResetRowsAffected()
+	_u.mutation.SetRowsAffected(v)
+	return _u
+}
+
+// SetNillableRowsAffected sets the "rows_affected" field if the given value is not nil.
+func (_u *JobRunUpdateOne) SetNillableRowsAffected(v *int64) *JobRunUpdateOne {
+	if v != nil {
+		_u.SetRowsAffected(*v)
+	}
+	return _u
+}
+
+// AddRowsAffected adds value to the "rows_affected" field.
+func (_u *JobRunUpdateOne) AddRowsAffected(v int64) *JobRunUpdateOne {
+	_u.mutation.AddRowsAffected(v)
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *JobRunUpdateOne) SetError(v string) *JobRunUpdateOne {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *JobRunUpdateOne) SetNillableError(v *string) *JobRunUpdateOne {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *JobRunUpdateOne) ClearError() *JobRunUpdateOne {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (_u *JobRunUpdateOne) SetDurationMs(v int64) *JobRunUpdateOne {
+	_u.mutation.ResetDurationMs()
+	_u.mutation.SetDurationMs(v)
+	return _u
+}
+
+// SetNillableDurationMs sets the "duration_ms" field if the given value is not nil.
+func (_u *JobRunUpdateOne) SetNillableDurationMs(v *int64) *JobRunUpdateOne {
+	if v != nil {
+		_u.SetDurationMs(*v)
+	}
+	return _u
+}
+
+// AddDurationMs adds value to the "duration_ms" field.
+func (_u *JobRunUpdateOne) AddDurationMs(v int64) *JobRunUpdateOne {
+	_u.mutation.AddDurationMs(v)
+	return _u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (_u *JobRunUpdateOne) SetFinishedAt(v time.Time) *JobRunUpdateOne {
+	_u.mutation.SetFinishedAt(v)
+	return _u
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (_u *JobRunUpdateOne) SetNillableFinishedAt(v *time.Time) *JobRunUpdateOne {
+	if v != nil {
+		_u.SetFinishedAt(*v)
+	}
+	return _u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (_u *JobRunUpdateOne) ClearFinishedAt() *JobRunUpdateOne {
+	_u.mutation.ClearFinishedAt()
+	return _u
+}
+
+// Mutation returns the JobRunMutation object of the builder.
+func (_u *JobRunUpdateOne) Mutation() *JobRunMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the JobRunUpdate builder.
+func (_u *JobRunUpdateOne) Where(ps ...predicate.JobRun) *JobRunUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *JobRunUpdateOne) Select(field string, fields ...string) *JobRunUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated JobRun entity.
+func (_u *JobRunUpdateOne) Save(ctx context.Context) (*JobRun, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *JobRunUpdateOne) SaveX(ctx context.Context) *JobRun {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *JobRunUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *JobRunUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *JobRunUpdateOne) sqlSave(ctx context.Context) (_node *JobRun, err error) {
+	_spec := sqlgraph.NewUpdateSpec(jobrun.Table, jobrun.Columns, sqlgraph.NewFieldSpec(jobrun.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "JobRun.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, jobrun.FieldID)
+		for _, f := range fields {
+			if !jobrun.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != jobrun.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.TriggeredByCleared() {
+		_spec.ClearField(jobrun.FieldTriggeredBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(jobrun.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RowsAffected(); ok {
+		_spec.SetField(jobrun.FieldRowsAffected, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRowsAffected(); ok {
+		_spec.AddField(jobrun.FieldRowsAffected, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(jobrun.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(jobrun.FieldError, field.TypeString)
+	}
+	if value, ok := _u.mutation.DurationMs(); ok {
+		_spec.SetField(jobrun.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedDurationMs(); ok {
+		_spec.AddField(jobrun.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.FinishedAt(); ok {
+		_spec.SetField(jobrun.FieldFinishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.FinishedAtCleared() {
+		_spec.ClearField(jobrun.FieldFinishedAt, field.TypeTime)
+	}
+	_node = &JobRun{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{jobrun.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
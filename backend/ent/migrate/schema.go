@@ -9,6 +9,68 @@ import (
 )
 
 var (
+	// APIKeysColumns holds the columns for the "api_keys" table.
+	APIKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString},
+		{Name: "prefix", Type: field.TypeString},
+		{Name: "key_hash", Type: field.TypeString, Unique: true},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// APIKeysTable holds the schema information for the "api_keys" table.
+	APIKeysTable = &schema.Table{
+		Name:       "api_keys",
+		Columns:    APIKeysColumns,
+		PrimaryKey: []*schema.Column{APIKeysColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "api_keys_users_api_keys",
+				Columns:    []*schema.Column{APIKeysColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "apikey_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{APIKeysColumns[9], APIKeysColumns[8]},
+			},
+		},
+	}
+	// AuditLogsColumns holds the columns for the "audit_logs" table.
+	AuditLogsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "action", Type: field.TypeString},
+		{Name: "actor_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "subject_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "ip_address", Type: field.TypeString, Nullable: true},
+		{Name: "details", Type: field.TypeJSON, Nullable: true, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AuditLogsTable holds the schema information for the "audit_logs" table.
+	AuditLogsTable = &schema.Table{
+		Name:       "audit_logs",
+		Columns:    AuditLogsColumns,
+		PrimaryKey: []*schema.Column{AuditLogsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditlog_action_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[1], AuditLogsColumns[6]},
+			},
+			{
+				Name:    "auditlog_subject_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[3], AuditLogsColumns[6]},
+			},
+		},
+	}
 	// ChatSessionsColumns holds the columns for the "chat_sessions" table.
 	ChatSessionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -56,6 +118,81 @@ var (
 			},
 		},
 	}
+	// CostRecordsColumns holds the columns for the "cost_records" table.
+	CostRecordsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "request_id", Type: field.TypeString, Nullable: true},
+		{Name: "session_id", Type: field.TypeString, Nullable: true},
+		{Name: "user_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "model", Type: field.TypeString},
+		{Name: "purpose", Type: field.TypeString},
+		{Name: "input_tokens", Type: field.TypeInt, Default: 0},
+		{Name: "output_tokens", Type: field.TypeInt, Default: 0},
+		{Name: "grounded", Type: field.TypeBool, Default: false},
+		{Name: "cost_usd", Type: field.TypeFloat64, Default: 0},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// CostRecordsTable holds the schema information for the "cost_records" table.
+	CostRecordsTable = &schema.Table{
+		Name:       "cost_records",
+		Columns:    CostRecordsColumns,
+		PrimaryKey: []*schema.Column{CostRecordsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "costrecord_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{CostRecordsColumns[3], CostRecordsColumns[11]},
+			},
+			{
+				Name:    "costrecord_session_id",
+				Unique:  false,
+				Columns: []*schema.Column{CostRecordsColumns[2]},
+			},
+			{
+				Name:    "costrecord_request_id",
+				Unique:  false,
+				Columns: []*schema.Column{CostRecordsColumns[1]},
+			},
+			{
+				Name:    "costrecord_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{CostRecordsColumns[11]},
+			},
+		},
+	}
+	// JobRunsColumns holds the columns for the "job_runs" table.
+	JobRunsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "job", Type: field.TypeString},
+		{Name: "trigger", Type: field.TypeString},
+		{Name: "triggered_by", Type: field.TypeString, Nullable: true},
+		{Name: "instance", Type: field.TypeString},
+		{Name: "status", Type: field.TypeString, Default: "running"},
+		{Name: "rows_affected", Type: field.TypeInt64, Default: 0},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "duration_ms", Type: field.TypeInt64, Default: 0},
+		{Name: "started_at", Type: field.TypeTime},
+		{Name: "finished_at", Type: field.TypeTime, Nullable: true},
+	}
+	// JobRunsTable holds the schema information for the "job_runs" table.
+	JobRunsTable = &schema.Table{
+		Name:       "job_runs",
+		Columns:    JobRunsColumns,
+		PrimaryKey: []*schema.Column{JobRunsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "jobrun_job_started_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobRunsColumns[1], JobRunsColumns[9]},
+			},
+			{
+				Name:    "jobrun_started_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobRunsColumns[9]},
+			},
+		},
+	}
 	// MessagesColumns holds the columns for the "messages" table.
 	MessagesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -89,6 +226,31 @@ var (
 			},
 		},
 	}
+	// PriceObservationsColumns holds the columns for the "price_observations" table.
+	PriceObservationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "product_key", Type: field.TypeString},
+		{Name: "product_name", Type: field.TypeString},
+		{Name: "merchant", Type: field.TypeString, Nullable: true},
+		{Name: "price", Type: field.TypeFloat64},
+		{Name: "currency", Type: field.TypeString},
+		{Name: "country_code", Type: field.TypeString, Default: "US"},
+		{Name: "source", Type: field.TypeString, Default: "search"},
+		{Name: "observed_at", Type: field.TypeTime},
+	}
+	// PriceObservationsTable holds the schema information for the "price_observations" table.
+	PriceObservationsTable = &schema.Table{
+		Name:       "price_observations",
+		Columns:    PriceObservationsColumns,
+		PrimaryKey: []*schema.Column{PriceObservationsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "priceobservation_product_key_currency_observed_at",
+				Unique:  false,
+				Columns: []*schema.Column{PriceObservationsColumns[1], PriceObservationsColumns[5], PriceObservationsColumns[8]},
+			},
+		},
+	}
 	// SearchHistoriesColumns holds the columns for the "search_histories" table.
 	SearchHistoriesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -141,6 +303,74 @@ var (
 			},
 		},
 	}
+	// SessionParticipantsColumns holds the columns for the "session_participants" table.
+	SessionParticipantsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "session_id", Type: field.TypeString},
+		{Name: "invited_by", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// SessionParticipantsTable holds the schema information for the "session_participants" table.
+	SessionParticipantsTable = &schema.Table{
+		Name:       "session_participants",
+		Columns:    SessionParticipantsColumns,
+		PrimaryKey: []*schema.Column{SessionParticipantsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "session_participants_users_shared_sessions",
+				Columns:    []*schema.Column{SessionParticipantsColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "sessionparticipant_session_id_user_id",
+				Unique:  true,
+				Columns: []*schema.Column{SessionParticipantsColumns[1], SessionParticipantsColumns[4]},
+			},
+			{
+				Name:    "sessionparticipant_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{SessionParticipantsColumns[4]},
+			},
+		},
+	}
+	// SessionSnapshotsColumns holds the columns for the "session_snapshots" table.
+	SessionSnapshotsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "slug", Type: field.TypeString, Unique: true},
+		{Name: "session_id", Type: field.TypeString},
+		{Name: "title", Type: field.TypeString, Nullable: true},
+		{Name: "messages", Type: field.TypeJSON, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "message_count", Type: field.TypeInt, Default: 0},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// SessionSnapshotsTable holds the schema information for the "session_snapshots" table.
+	SessionSnapshotsTable = &schema.Table{
+		Name:       "session_snapshots",
+		Columns:    SessionSnapshotsColumns,
+		PrimaryKey: []*schema.Column{SessionSnapshotsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "session_snapshots_users_snapshots",
+				Columns:    []*schema.Column{SessionSnapshotsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "sessionsnapshot_user_id_session_id",
+				Unique:  false,
+				Columns: []*schema.Column{SessionSnapshotsColumns[9], SessionSnapshotsColumns[2]},
+			},
+		},
+	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -150,6 +380,12 @@ var (
 		{Name: "name", Type: field.TypeString, Nullable: true},
 		{Name: "avatar_url", Type: field.TypeString, Nullable: true},
 		{Name: "provider", Type: field.TypeString, Default: "email"},
+		{Name: "role", Type: field.TypeString, Default: "user"},
+		{Name: "plan", Type: field.TypeString, Default: "free"},
+		{Name: "email_verified", Type: field.TypeBool, Default: false},
+		{Name: "totp_enabled", Type: field.TypeBool, Default: false},
+		{Name: "totp_secret", Type: field.TypeString, Nullable: true},
+		{Name: "totp_recovery_codes", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "last_login", Type: field.TypeTime, Nullable: true},
@@ -172,6 +408,42 @@ var (
 			},
 		},
 	}
+	// UserIdentitiesColumns holds the columns for the "user_identities" table.
+	UserIdentitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "subject", Type: field.TypeString},
+		{Name: "email", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// UserIdentitiesTable holds the schema information for the "user_identities" table.
+	UserIdentitiesTable = &schema.Table{
+		Name:       "user_identities",
+		Columns:    UserIdentitiesColumns,
+		PrimaryKey: []*schema.Column{UserIdentitiesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_identities_users_identities",
+				Columns:    []*schema.Column{UserIdentitiesColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "useridentity_provider_subject",
+				Unique:  true,
+				Columns: []*schema.Column{UserIdentitiesColumns[1], UserIdentitiesColumns[2]},
+			},
+			{
+				Name:    "useridentity_user_id_provider",
+				Unique:  true,
+				Columns: []*schema.Column{UserIdentitiesColumns[6], UserIdentitiesColumns[1]},
+			},
+		},
+	}
 	// UserPreferencesColumns holds the columns for the "user_preferences" table.
 	UserPreferencesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -200,19 +472,83 @@ var (
 			},
 		},
 	}
+	// WatchesColumns holds the columns for the "watches" table.
+	WatchesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "page_token", Type: field.TypeString},
+		{Name: "product_name", Type: field.TypeString},
+		{Name: "target_price", Type: field.TypeFloat64},
+		{Name: "currency", Type: field.TypeString, Default: "CHF"},
+		{Name: "country_code", Type: field.TypeString, Default: "CH"},
+		{Name: "last_price", Type: field.TypeFloat64, Nullable: true},
+		{Name: "last_merchant", Type: field.TypeString, Nullable: true},
+		{Name: "last_link", Type: field.TypeString, Nullable: true},
+		{Name: "notified_price", Type: field.TypeFloat64, Nullable: true},
+		{Name: "active", Type: field.TypeBool, Default: true},
+		{Name: "last_checked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "notified_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// WatchesTable holds the schema information for the "watches" table.
+	WatchesTable = &schema.Table{
+		Name:       "watches",
+		Columns:    WatchesColumns,
+		PrimaryKey: []*schema.Column{WatchesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "watches_users_watches",
+				Columns:    []*schema.Column{WatchesColumns[15]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "watch_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{WatchesColumns[15], WatchesColumns[13]},
+			},
+			{
+				Name:    "watch_user_id_page_token",
+				Unique:  true,
+				Columns: []*schema.Column{WatchesColumns[15], WatchesColumns[1]},
+			},
+			{
+				Name:    "watch_active_last_checked_at",
+				Unique:  false,
+				Columns: []*schema.Column{WatchesColumns[10], WatchesColumns[11]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		APIKeysTable,
+		AuditLogsTable,
 		ChatSessionsTable,
+		CostRecordsTable,
+		JobRunsTable,
 		MessagesTable,
+		PriceObservationsTable,
 		SearchHistoriesTable,
+		SessionParticipantsTable,
+		SessionSnapshotsTable,
 		UsersTable,
+		UserIdentitiesTable,
 		UserPreferencesTable,
+		WatchesTable,
 	}
 )
 
 func init() {
+	APIKeysTable.ForeignKeys[0].RefTable = UsersTable
 	ChatSessionsTable.ForeignKeys[0].RefTable = UsersTable
 	MessagesTable.ForeignKeys[0].RefTable = ChatSessionsTable
 	SearchHistoriesTable.ForeignKeys[0].RefTable = UsersTable
+	SessionParticipantsTable.ForeignKeys[0].RefTable = UsersTable
+	SessionSnapshotsTable.ForeignKeys[0].RefTable = UsersTable
+	UserIdentitiesTable.ForeignKeys[0].RefTable = UsersTable
 	UserPreferencesTable.ForeignKeys[0].RefTable = UsersTable
+	WatchesTable.ForeignKeys[0].RefTable = UsersTable
 }
@@ -4,14 +4,24 @@ package ent
 
 import (
 	"context"
+	"encoding/json/jsontext"
 	"errors"
 	"fmt"
+	"mylittleprice/ent/apikey"
+	"mylittleprice/ent/auditlog"
 	"mylittleprice/ent/chatsession"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/jobrun"
 	"mylittleprice/ent/message"
 	"mylittleprice/ent/predicate"
+	"mylittleprice/ent/priceobservation"
 	"mylittleprice/ent/searchhistory"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/ent/sessionsnapshot"
 	"mylittleprice/ent/user"
+	"mylittleprice/ent/useridentity"
 	"mylittleprice/ent/userpreference"
+	"mylittleprice/ent/watch"
 	"sync"
 	"time"
 
@@ -29,53 +39,56 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeChatSession    = "ChatSession"
-	TypeMessage        = "Message"
-	TypeSearchHistory  = "SearchHistory"
-	TypeUser           = "User"
-	TypeUserPreference = "UserPreference"
+	TypeAPIKey             = "APIKey"
+	TypeAuditLog           = "AuditLog"
+	TypeChatSession        = "ChatSession"
+	TypeCostRecord         = "CostRecord"
+	TypeJobRun             = "JobRun"
+	TypeMessage            = "Message"
+	TypePriceObservation   = "PriceObservation"
+	TypeSearchHistory      = "SearchHistory"
+	TypeSessionParticipant = "SessionParticipant"
+	TypeSessionSnapshot    = "SessionSnapshot"
+	TypeUser               = "User"
+	TypeUserIdentity       = "UserIdentity"
+	TypeUserPreference     = "UserPreference"
+	TypeWatch              = "Watch"
 )
 
-// ChatSessionMutation represents an operation that mutates the ChatSession nodes in the graph.
-type ChatSessionMutation struct {
+// APIKeyMutation represents an operation that mutates the APIKey nodes in the graph.
+type APIKeyMutation struct {
 	config
-	op                   Op
-	typ                  string
-	id                   *uuid.UUID
-	session_id           *string
-	country_code         *string
-	language_code        *string
-	currency             *string
-	message_count        *int
-	addmessage_count     *int
-	search_state         *map[string]interface{}
-	cycle_state          *map[string]interface{}
-	conversation_context *map[string]interface{}
-	created_at           *time.Time
-	updated_at           *time.Time
-	expires_at           *time.Time
-	clearedFields        map[string]struct{}
-	user                 *uuid.UUID
-	cleareduser          bool
-	messages             map[uuid.UUID]struct{}
-	removedmessages      map[uuid.UUID]struct{}
-	clearedmessages      bool
-	done                 bool
-	oldValue             func(context.Context) (*ChatSession, error)
-	predicates           []predicate.ChatSession
-}
-
-var _ ent.Mutation = (*ChatSessionMutation)(nil)
-
-// chatsessionOption allows management of the mutation configuration using functional options.
-type chatsessionOption func(*ChatSessionMutation)
-
-// newChatSessionMutation creates new mutation for the ChatSession entity.
-func newChatSessionMutation(c config, op Op, opts ...chatsessionOption) *ChatSessionMutation {
-	m := &ChatSessionMutation{
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	name          *string
+	prefix        *string
+	key_hash      *string
+	scopes        *[]string
+	appendscopes  []string
+	expires_at    *time.Time
+	last_used_at  *time.Time
+	revoked_at    *time.Time
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*APIKey, error)
+	predicates    []predicate.APIKey
+}
+
+var _ ent.Mutation = (*APIKeyMutation)(nil)
+
+// apikeyOption allows management of the mutation configuration using functional options.
+type apikeyOption func(*APIKeyMutation)
+
+// newAPIKeyMutation creates new mutation for the APIKey entity.
+func newAPIKeyMutation(c config, op Op, opts ...apikeyOption) *APIKeyMutation {
+	m := &APIKeyMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeChatSession,
+		typ:           TypeAPIKey,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -84,20 +97,20 @@ func newChatSessionMutation(c config, op Op, opts ...chatsessionOption) *ChatSes
 	return m
 }
 
-// withChatSessionID sets the ID field of the mutation.
-func withChatSessionID(id uuid.UUID) chatsessionOption {
-	return func(m *ChatSessionMutation) {
+// withAPIKeyID sets the ID field of the mutation.
+func withAPIKeyID(id uuid.UUID) apikeyOption {
+	return func(m *APIKeyMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *ChatSession
+			value *APIKey
 		)
-		m.oldValue = func(ctx context.Context) (*ChatSession, error) {
+		m.oldValue = func(ctx context.Context) (*APIKey, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().ChatSession.Get(ctx, id)
+					value, err = m.Client().APIKey.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -106,10 +119,10 @@ func withChatSessionID(id uuid.UUID) chatsessionOption {
 	}
 }
 
-// withChatSession sets the old ChatSession of the mutation.
-func withChatSession(node *ChatSession) chatsessionOption {
-	return func(m *ChatSessionMutation) {
-		m.oldValue = func(context.Context) (*ChatSession, error) {
+// withAPIKey sets the old APIKey of the mutation.
+func withAPIKey(node *APIKey) apikeyOption {
+	return func(m *APIKeyMutation) {
+		m.oldValue = func(context.Context) (*APIKey, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -118,7 +131,7 @@ func withChatSession(node *ChatSession) chatsessionOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m ChatSessionMutation) Client() *Client {
+func (m APIKeyMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -126,7 +139,7 @@ func (m ChatSessionMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m ChatSessionMutation) Tx() (*Tx, error) {
+func (m APIKeyMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -136,14 +149,14 @@ func (m ChatSessionMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of ChatSession entities.
-func (m *ChatSessionMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of APIKey entities.
+func (m *APIKeyMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *ChatSessionMutation) ID() (id uuid.UUID, exists bool) {
+func (m *APIKeyMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -154,7 +167,7 @@ func (m *ChatSessionMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *ChatSessionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *APIKeyMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -163,389 +176,361 @@ func (m *ChatSessionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().ChatSession.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().APIKey.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetSessionID sets the "session_id" field.
-func (m *ChatSessionMutation) SetSessionID(s string) {
-	m.session_id = &s
+// SetUserID sets the "user_id" field.
+func (m *APIKeyMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
 }
 
-// SessionID returns the value of the "session_id" field in the mutation.
-func (m *ChatSessionMutation) SessionID() (r string, exists bool) {
-	v := m.session_id
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *APIKeyMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionID returns the old "session_id" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldSessionID(ctx context.Context) (v string, err error) {
+func (m *APIKeyMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionID requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.SessionID, nil
+	return oldValue.UserID, nil
 }
 
-// ResetSessionID resets all changes to the "session_id" field.
-func (m *ChatSessionMutation) ResetSessionID() {
-	m.session_id = nil
+// ResetUserID resets all changes to the "user_id" field.
+func (m *APIKeyMutation) ResetUserID() {
+	m.user = nil
 }
 
-// SetUserID sets the "user_id" field.
-func (m *ChatSessionMutation) SetUserID(u uuid.UUID) {
-	m.user = &u
+// SetName sets the "name" field.
+func (m *APIKeyMutation) SetName(s string) {
+	m.name = &s
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *ChatSessionMutation) UserID() (r uuid.UUID, exists bool) {
-	v := m.user
+// Name returns the value of the "name" field in the mutation.
+func (m *APIKeyMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *APIKeyMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.UserID, nil
-}
-
-// ClearUserID clears the value of the "user_id" field.
-func (m *ChatSessionMutation) ClearUserID() {
-	m.user = nil
-	m.clearedFields[chatsession.FieldUserID] = struct{}{}
-}
-
-// UserIDCleared returns if the "user_id" field was cleared in this mutation.
-func (m *ChatSessionMutation) UserIDCleared() bool {
-	_, ok := m.clearedFields[chatsession.FieldUserID]
-	return ok
+	return oldValue.Name, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *ChatSessionMutation) ResetUserID() {
-	m.user = nil
-	delete(m.clearedFields, chatsession.FieldUserID)
+// ResetName resets all changes to the "name" field.
+func (m *APIKeyMutation) ResetName() {
+	m.name = nil
 }
 
-// SetCountryCode sets the "country_code" field.
-func (m *ChatSessionMutation) SetCountryCode(s string) {
-	m.country_code = &s
+// SetPrefix sets the "prefix" field.
+func (m *APIKeyMutation) SetPrefix(s string) {
+	m.prefix = &s
 }
 
-// CountryCode returns the value of the "country_code" field in the mutation.
-func (m *ChatSessionMutation) CountryCode() (r string, exists bool) {
-	v := m.country_code
+// Prefix returns the value of the "prefix" field in the mutation.
+func (m *APIKeyMutation) Prefix() (r string, exists bool) {
+	v := m.prefix
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCountryCode returns the old "country_code" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldPrefix returns the old "prefix" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldCountryCode(ctx context.Context) (v string, err error) {
+func (m *APIKeyMutation) OldPrefix(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldPrefix is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCountryCode requires an ID field in the mutation")
+		return v, errors.New("OldPrefix requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldPrefix: %w", err)
 	}
-	return oldValue.CountryCode, nil
+	return oldValue.Prefix, nil
 }
 
-// ResetCountryCode resets all changes to the "country_code" field.
-func (m *ChatSessionMutation) ResetCountryCode() {
-	m.country_code = nil
+// ResetPrefix resets all changes to the "prefix" field.
+func (m *APIKeyMutation) ResetPrefix() {
+	m.prefix = nil
 }
 
-// SetLanguageCode sets the "language_code" field.
-func (m *ChatSessionMutation) SetLanguageCode(s string) {
-	m.language_code = &s
+// SetKeyHash sets the "key_hash" field.
+func (m *APIKeyMutation) SetKeyHash(s string) {
+	m.key_hash = &s
 }
 
-// LanguageCode returns the value of the "language_code" field in the mutation.
-func (m *ChatSessionMutation) LanguageCode() (r string, exists bool) {
-	v := m.language_code
+// KeyHash returns the value of the "key_hash" field in the mutation.
+func (m *APIKeyMutation) KeyHash() (r string, exists bool) {
+	v := m.key_hash
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLanguageCode returns the old "language_code" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldKeyHash returns the old "key_hash" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldLanguageCode(ctx context.Context) (v string, err error) {
+func (m *APIKeyMutation) OldKeyHash(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLanguageCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldKeyHash is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLanguageCode requires an ID field in the mutation")
+		return v, errors.New("OldKeyHash requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLanguageCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldKeyHash: %w", err)
 	}
-	return oldValue.LanguageCode, nil
+	return oldValue.KeyHash, nil
 }
 
-// ResetLanguageCode resets all changes to the "language_code" field.
-func (m *ChatSessionMutation) ResetLanguageCode() {
-	m.language_code = nil
+// ResetKeyHash resets all changes to the "key_hash" field.
+func (m *APIKeyMutation) ResetKeyHash() {
+	m.key_hash = nil
 }
 
-// SetCurrency sets the "currency" field.
-func (m *ChatSessionMutation) SetCurrency(s string) {
-	m.currency = &s
+// SetScopes sets the "scopes" field.
+func (m *APIKeyMutation) SetScopes(s []string) {
+	m.scopes = &s
+	m.appendscopes = nil
 }
 
-// Currency returns the value of the "currency" field in the mutation.
-func (m *ChatSessionMutation) Currency() (r string, exists bool) {
-	v := m.currency
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *APIKeyMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCurrency returns the old "currency" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldScopes returns the old "scopes" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldCurrency(ctx context.Context) (v string, err error) {
+func (m *APIKeyMutation) OldScopes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCurrency requires an ID field in the mutation")
+		return v, errors.New("OldScopes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
 	}
-	return oldValue.Currency, nil
+	return oldValue.Scopes, nil
 }
 
-// ResetCurrency resets all changes to the "currency" field.
-func (m *ChatSessionMutation) ResetCurrency() {
-	m.currency = nil
+// AppendScopes adds s to the "scopes" field.
+func (m *APIKeyMutation) AppendScopes(s []string) {
+	m.appendscopes = append(m.appendscopes, s...)
 }
 
-// SetMessageCount sets the "message_count" field.
-func (m *ChatSessionMutation) SetMessageCount(i int) {
-	m.message_count = &i
-	m.addmessage_count = nil
+// AppendedScopes returns the list of values that were appended to the "scopes" field in this mutation.
+func (m *APIKeyMutation) AppendedScopes() ([]string, bool) {
+	if len(m.appendscopes) == 0 {
+		return nil, false
+	}
+	return m.appendscopes, true
 }
 
-// MessageCount returns the value of the "message_count" field in the mutation.
-func (m *ChatSessionMutation) MessageCount() (r int, exists bool) {
-	v := m.message_count
+// ResetScopes resets all changes to the "scopes" field.
+func (m *APIKeyMutation) ResetScopes() {
+	m.scopes = nil
+	m.appendscopes = nil
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *APIKeyMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *APIKeyMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMessageCount returns the old "message_count" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldExpiresAt returns the old "expires_at" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldMessageCount(ctx context.Context) (v int, err error) {
+func (m *APIKeyMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMessageCount is only allowed on UpdateOne operations")
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMessageCount requires an ID field in the mutation")
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMessageCount: %w", err)
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
-	return oldValue.MessageCount, nil
+	return oldValue.ExpiresAt, nil
 }
 
-// AddMessageCount adds i to the "message_count" field.
-func (m *ChatSessionMutation) AddMessageCount(i int) {
-	if m.addmessage_count != nil {
-		*m.addmessage_count += i
-	} else {
-		m.addmessage_count = &i
-	}
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *APIKeyMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[apikey.FieldExpiresAt] = struct{}{}
 }
 
-// AddedMessageCount returns the value that was added to the "message_count" field in this mutation.
-func (m *ChatSessionMutation) AddedMessageCount() (r int, exists bool) {
-	v := m.addmessage_count
-	if v == nil {
-		return
-	}
-	return *v, true
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *APIKeyMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldExpiresAt]
+	return ok
 }
 
-// ResetMessageCount resets all changes to the "message_count" field.
-func (m *ChatSessionMutation) ResetMessageCount() {
-	m.message_count = nil
-	m.addmessage_count = nil
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *APIKeyMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, apikey.FieldExpiresAt)
 }
 
-// SetSearchState sets the "search_state" field.
-func (m *ChatSessionMutation) SetSearchState(value map[string]interface{}) {
-	m.search_state = &value
+// SetLastUsedAt sets the "last_used_at" field.
+func (m *APIKeyMutation) SetLastUsedAt(t time.Time) {
+	m.last_used_at = &t
 }
 
-// SearchState returns the value of the "search_state" field in the mutation.
-func (m *ChatSessionMutation) SearchState() (r map[string]interface{}, exists bool) {
-	v := m.search_state
+// LastUsedAt returns the value of the "last_used_at" field in the mutation.
+func (m *APIKeyMutation) LastUsedAt() (r time.Time, exists bool) {
+	v := m.last_used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSearchState returns the old "search_state" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldLastUsedAt returns the old "last_used_at" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldSearchState(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *APIKeyMutation) OldLastUsedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSearchState is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSearchState requires an ID field in the mutation")
+		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSearchState: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
 	}
-	return oldValue.SearchState, nil
+	return oldValue.LastUsedAt, nil
 }
 
-// ResetSearchState resets all changes to the "search_state" field.
-func (m *ChatSessionMutation) ResetSearchState() {
-	m.search_state = nil
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (m *APIKeyMutation) ClearLastUsedAt() {
+	m.last_used_at = nil
+	m.clearedFields[apikey.FieldLastUsedAt] = struct{}{}
 }
 
-// SetCycleState sets the "cycle_state" field.
-func (m *ChatSessionMutation) SetCycleState(value map[string]interface{}) {
-	m.cycle_state = &value
+// LastUsedAtCleared returns if the "last_used_at" field was cleared in this mutation.
+func (m *APIKeyMutation) LastUsedAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldLastUsedAt]
+	return ok
 }
 
-// CycleState returns the value of the "cycle_state" field in the mutation.
-func (m *ChatSessionMutation) CycleState() (r map[string]interface{}, exists bool) {
-	v := m.cycle_state
+// ResetLastUsedAt resets all changes to the "last_used_at" field.
+func (m *APIKeyMutation) ResetLastUsedAt() {
+	m.last_used_at = nil
+	delete(m.clearedFields, apikey.FieldLastUsedAt)
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (m *APIKeyMutation) SetRevokedAt(t time.Time) {
+	m.revoked_at = &t
+}
+
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *APIKeyMutation) RevokedAt() (r time.Time, exists bool) {
+	v := m.revoked_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCycleState returns the old "cycle_state" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldRevokedAt returns the old "revoked_at" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldCycleState(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *APIKeyMutation) OldRevokedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCycleState is only allowed on UpdateOne operations")
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCycleState requires an ID field in the mutation")
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCycleState: %w", err)
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
 	}
-	return oldValue.CycleState, nil
+	return oldValue.RevokedAt, nil
 }
 
-// ResetCycleState resets all changes to the "cycle_state" field.
-func (m *ChatSessionMutation) ResetCycleState() {
-	m.cycle_state = nil
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *APIKeyMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.clearedFields[apikey.FieldRevokedAt] = struct{}{}
 }
 
-// SetConversationContext sets the "conversation_context" field.
-func (m *ChatSessionMutation) SetConversationContext(value map[string]interface{}) {
-	m.conversation_context = &value
-}
-
-// ConversationContext returns the value of the "conversation_context" field in the mutation.
-func (m *ChatSessionMutation) ConversationContext() (r map[string]interface{}, exists bool) {
-	v := m.conversation_context
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldConversationContext returns the old "conversation_context" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldConversationContext(ctx context.Context) (v map[string]interface{}, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConversationContext is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConversationContext requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConversationContext: %w", err)
-	}
-	return oldValue.ConversationContext, nil
-}
-
-// ClearConversationContext clears the value of the "conversation_context" field.
-func (m *ChatSessionMutation) ClearConversationContext() {
-	m.conversation_context = nil
-	m.clearedFields[chatsession.FieldConversationContext] = struct{}{}
-}
-
-// ConversationContextCleared returns if the "conversation_context" field was cleared in this mutation.
-func (m *ChatSessionMutation) ConversationContextCleared() bool {
-	_, ok := m.clearedFields[chatsession.FieldConversationContext]
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *APIKeyMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldRevokedAt]
 	return ok
 }
 
-// ResetConversationContext resets all changes to the "conversation_context" field.
-func (m *ChatSessionMutation) ResetConversationContext() {
-	m.conversation_context = nil
-	delete(m.clearedFields, chatsession.FieldConversationContext)
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *APIKeyMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	delete(m.clearedFields, apikey.FieldRevokedAt)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *ChatSessionMutation) SetCreatedAt(t time.Time) {
+func (m *APIKeyMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *ChatSessionMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *APIKeyMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -553,10 +538,10 @@ func (m *ChatSessionMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *APIKeyMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -571,97 +556,25 @@ func (m *ChatSessionMutation) OldCreatedAt(ctx context.Context) (v time.Time, er
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *ChatSessionMutation) ResetCreatedAt() {
+func (m *APIKeyMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *ChatSessionMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
-}
-
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *ChatSessionMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldUpdatedAt returns the old "updated_at" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
-	}
-	return oldValue.UpdatedAt, nil
-}
-
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *ChatSessionMutation) ResetUpdatedAt() {
-	m.updated_at = nil
-}
-
-// SetExpiresAt sets the "expires_at" field.
-func (m *ChatSessionMutation) SetExpiresAt(t time.Time) {
-	m.expires_at = &t
-}
-
-// ExpiresAt returns the value of the "expires_at" field in the mutation.
-func (m *ChatSessionMutation) ExpiresAt() (r time.Time, exists bool) {
-	v := m.expires_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldExpiresAt returns the old "expires_at" field's value of the ChatSession entity.
-// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *ChatSessionMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
-	}
-	return oldValue.ExpiresAt, nil
-}
-
-// ResetExpiresAt resets all changes to the "expires_at" field.
-func (m *ChatSessionMutation) ResetExpiresAt() {
-	m.expires_at = nil
-}
-
 // ClearUser clears the "user" edge to the User entity.
-func (m *ChatSessionMutation) ClearUser() {
+func (m *APIKeyMutation) ClearUser() {
 	m.cleareduser = true
-	m.clearedFields[chatsession.FieldUserID] = struct{}{}
+	m.clearedFields[apikey.FieldUserID] = struct{}{}
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *ChatSessionMutation) UserCleared() bool {
-	return m.UserIDCleared() || m.cleareduser
+func (m *APIKeyMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *ChatSessionMutation) UserIDs() (ids []uuid.UUID) {
+func (m *APIKeyMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -669,74 +582,20 @@ func (m *ChatSessionMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *ChatSessionMutation) ResetUser() {
+func (m *APIKeyMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// AddMessageIDs adds the "messages" edge to the Message entity by ids.
-func (m *ChatSessionMutation) AddMessageIDs(ids ...uuid.UUID) {
-	if m.messages == nil {
-		m.messages = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		m.messages[ids[i]] = struct{}{}
-	}
-}
-
-// ClearMessages clears the "messages" edge to the Message entity.
-func (m *ChatSessionMutation) ClearMessages() {
-	m.clearedmessages = true
-}
-
-// MessagesCleared reports if the "messages" edge to the Message entity was cleared.
-func (m *ChatSessionMutation) MessagesCleared() bool {
-	return m.clearedmessages
-}
-
-// RemoveMessageIDs removes the "messages" edge to the Message entity by IDs.
-func (m *ChatSessionMutation) RemoveMessageIDs(ids ...uuid.UUID) {
-	if m.removedmessages == nil {
-		m.removedmessages = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		delete(m.messages, ids[i])
-		m.removedmessages[ids[i]] = struct{}{}
-	}
-}
-
-// RemovedMessages returns the removed IDs of the "messages" edge to the Message entity.
-func (m *ChatSessionMutation) RemovedMessagesIDs() (ids []uuid.UUID) {
-	for id := range m.removedmessages {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// MessagesIDs returns the "messages" edge IDs in the mutation.
-func (m *ChatSessionMutation) MessagesIDs() (ids []uuid.UUID) {
-	for id := range m.messages {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// ResetMessages resets all changes to the "messages" edge.
-func (m *ChatSessionMutation) ResetMessages() {
-	m.messages = nil
-	m.clearedmessages = false
-	m.removedmessages = nil
-}
-
-// Where appends a list predicates to the ChatSessionMutation builder.
-func (m *ChatSessionMutation) Where(ps ...predicate.ChatSession) {
+// Where appends a list predicates to the APIKeyMutation builder.
+func (m *APIKeyMutation) Where(ps ...predicate.APIKey) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the ChatSessionMutation builder. Using this method,
+// WhereP appends storage-level predicates to the APIKeyMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *ChatSessionMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.ChatSession, len(ps))
+func (m *APIKeyMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.APIKey, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -744,60 +603,51 @@ func (m *ChatSessionMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *ChatSessionMutation) Op() Op {
+func (m *APIKeyMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *ChatSessionMutation) SetOp(op Op) {
+func (m *APIKeyMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (ChatSession).
-func (m *ChatSessionMutation) Type() string {
+// Type returns the node type of this mutation (APIKey).
+func (m *APIKeyMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *ChatSessionMutation) Fields() []string {
-	fields := make([]string, 0, 12)
-	if m.session_id != nil {
-		fields = append(fields, chatsession.FieldSessionID)
-	}
+func (m *APIKeyMutation) Fields() []string {
+	fields := make([]string, 0, 9)
 	if m.user != nil {
-		fields = append(fields, chatsession.FieldUserID)
+		fields = append(fields, apikey.FieldUserID)
 	}
-	if m.country_code != nil {
-		fields = append(fields, chatsession.FieldCountryCode)
+	if m.name != nil {
+		fields = append(fields, apikey.FieldName)
 	}
-	if m.language_code != nil {
-		fields = append(fields, chatsession.FieldLanguageCode)
+	if m.prefix != nil {
+		fields = append(fields, apikey.FieldPrefix)
 	}
-	if m.currency != nil {
-		fields = append(fields, chatsession.FieldCurrency)
+	if m.key_hash != nil {
+		fields = append(fields, apikey.FieldKeyHash)
 	}
-	if m.message_count != nil {
-		fields = append(fields, chatsession.FieldMessageCount)
+	if m.scopes != nil {
+		fields = append(fields, apikey.FieldScopes)
 	}
-	if m.search_state != nil {
-		fields = append(fields, chatsession.FieldSearchState)
+	if m.expires_at != nil {
+		fields = append(fields, apikey.FieldExpiresAt)
 	}
-	if m.cycle_state != nil {
-		fields = append(fields, chatsession.FieldCycleState)
+	if m.last_used_at != nil {
+		fields = append(fields, apikey.FieldLastUsedAt)
 	}
-	if m.conversation_context != nil {
-		fields = append(fields, chatsession.FieldConversationContext)
+	if m.revoked_at != nil {
+		fields = append(fields, apikey.FieldRevokedAt)
 	}
 	if m.created_at != nil {
-		fields = append(fields, chatsession.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, chatsession.FieldUpdatedAt)
-	}
-	if m.expires_at != nil {
-		fields = append(fields, chatsession.FieldExpiresAt)
+		fields = append(fields, apikey.FieldCreatedAt)
 	}
 	return fields
 }
@@ -805,32 +655,26 @@ func (m *ChatSessionMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *ChatSessionMutation) Field(name string) (ent.Value, bool) {
+func (m *APIKeyMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case chatsession.FieldSessionID:
-		return m.SessionID()
-	case chatsession.FieldUserID:
+	case apikey.FieldUserID:
 		return m.UserID()
-	case chatsession.FieldCountryCode:
-		return m.CountryCode()
-	case chatsession.FieldLanguageCode:
-		return m.LanguageCode()
-	case chatsession.FieldCurrency:
-		return m.Currency()
-	case chatsession.FieldMessageCount:
-		return m.MessageCount()
-	case chatsession.FieldSearchState:
-		return m.SearchState()
-	case chatsession.FieldCycleState:
-		return m.CycleState()
-	case chatsession.FieldConversationContext:
-		return m.ConversationContext()
-	case chatsession.FieldCreatedAt:
-		return m.CreatedAt()
-	case chatsession.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case chatsession.FieldExpiresAt:
+	case apikey.FieldName:
+		return m.Name()
+	case apikey.FieldPrefix:
+		return m.Prefix()
+	case apikey.FieldKeyHash:
+		return m.KeyHash()
+	case apikey.FieldScopes:
+		return m.Scopes()
+	case apikey.FieldExpiresAt:
 		return m.ExpiresAt()
+	case apikey.FieldLastUsedAt:
+		return m.LastUsedAt()
+	case apikey.FieldRevokedAt:
+		return m.RevokedAt()
+	case apikey.FieldCreatedAt:
+		return m.CreatedAt()
 	}
 	return nil, false
 }
@@ -838,380 +682,302 @@ func (m *ChatSessionMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *ChatSessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *APIKeyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case chatsession.FieldSessionID:
-		return m.OldSessionID(ctx)
-	case chatsession.FieldUserID:
+	case apikey.FieldUserID:
 		return m.OldUserID(ctx)
-	case chatsession.FieldCountryCode:
-		return m.OldCountryCode(ctx)
-	case chatsession.FieldLanguageCode:
-		return m.OldLanguageCode(ctx)
-	case chatsession.FieldCurrency:
-		return m.OldCurrency(ctx)
-	case chatsession.FieldMessageCount:
-		return m.OldMessageCount(ctx)
-	case chatsession.FieldSearchState:
-		return m.OldSearchState(ctx)
-	case chatsession.FieldCycleState:
-		return m.OldCycleState(ctx)
-	case chatsession.FieldConversationContext:
-		return m.OldConversationContext(ctx)
-	case chatsession.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case chatsession.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case chatsession.FieldExpiresAt:
+	case apikey.FieldName:
+		return m.OldName(ctx)
+	case apikey.FieldPrefix:
+		return m.OldPrefix(ctx)
+	case apikey.FieldKeyHash:
+		return m.OldKeyHash(ctx)
+	case apikey.FieldScopes:
+		return m.OldScopes(ctx)
+	case apikey.FieldExpiresAt:
 		return m.OldExpiresAt(ctx)
+	case apikey.FieldLastUsedAt:
+		return m.OldLastUsedAt(ctx)
+	case apikey.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
+	case apikey.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown ChatSession field %s", name)
+	return nil, fmt.Errorf("unknown APIKey field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ChatSessionMutation) SetField(name string, value ent.Value) error {
+func (m *APIKeyMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case chatsession.FieldSessionID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSessionID(v)
-		return nil
-	case chatsession.FieldUserID:
+	case apikey.FieldUserID:
 		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetUserID(v)
 		return nil
-	case chatsession.FieldCountryCode:
+	case apikey.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCountryCode(v)
+		m.SetName(v)
 		return nil
-	case chatsession.FieldLanguageCode:
+	case apikey.FieldPrefix:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLanguageCode(v)
+		m.SetPrefix(v)
 		return nil
-	case chatsession.FieldCurrency:
+	case apikey.FieldKeyHash:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCurrency(v)
-		return nil
-	case chatsession.FieldMessageCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetMessageCount(v)
+		m.SetKeyHash(v)
 		return nil
-	case chatsession.FieldSearchState:
-		v, ok := value.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSearchState(v)
-		return nil
-	case chatsession.FieldCycleState:
-		v, ok := value.(map[string]interface{})
+	case apikey.FieldScopes:
+		v, ok := value.([]string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCycleState(v)
+		m.SetScopes(v)
 		return nil
-	case chatsession.FieldConversationContext:
-		v, ok := value.(map[string]interface{})
+	case apikey.FieldExpiresAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetConversationContext(v)
+		m.SetExpiresAt(v)
 		return nil
-	case chatsession.FieldCreatedAt:
+	case apikey.FieldLastUsedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetLastUsedAt(v)
 		return nil
-	case chatsession.FieldUpdatedAt:
+	case apikey.FieldRevokedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetRevokedAt(v)
 		return nil
-	case chatsession.FieldExpiresAt:
+	case apikey.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetExpiresAt(v)
+		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown ChatSession field %s", name)
+	return fmt.Errorf("unknown APIKey field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *ChatSessionMutation) AddedFields() []string {
-	var fields []string
-	if m.addmessage_count != nil {
-		fields = append(fields, chatsession.FieldMessageCount)
-	}
-	return fields
+func (m *APIKeyMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *ChatSessionMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case chatsession.FieldMessageCount:
-		return m.AddedMessageCount()
-	}
+func (m *APIKeyMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *ChatSessionMutation) AddField(name string, value ent.Value) error {
+func (m *APIKeyMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case chatsession.FieldMessageCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddMessageCount(v)
-		return nil
 	}
-	return fmt.Errorf("unknown ChatSession numeric field %s", name)
+	return fmt.Errorf("unknown APIKey numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *ChatSessionMutation) ClearedFields() []string {
+func (m *APIKeyMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(chatsession.FieldUserID) {
-		fields = append(fields, chatsession.FieldUserID)
+	if m.FieldCleared(apikey.FieldExpiresAt) {
+		fields = append(fields, apikey.FieldExpiresAt)
 	}
-	if m.FieldCleared(chatsession.FieldConversationContext) {
-		fields = append(fields, chatsession.FieldConversationContext)
+	if m.FieldCleared(apikey.FieldLastUsedAt) {
+		fields = append(fields, apikey.FieldLastUsedAt)
+	}
+	if m.FieldCleared(apikey.FieldRevokedAt) {
+		fields = append(fields, apikey.FieldRevokedAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *ChatSessionMutation) FieldCleared(name string) bool {
+func (m *APIKeyMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *ChatSessionMutation) ClearField(name string) error {
+func (m *APIKeyMutation) ClearField(name string) error {
 	switch name {
-	case chatsession.FieldUserID:
-		m.ClearUserID()
+	case apikey.FieldExpiresAt:
+		m.ClearExpiresAt()
 		return nil
-	case chatsession.FieldConversationContext:
-		m.ClearConversationContext()
+	case apikey.FieldLastUsedAt:
+		m.ClearLastUsedAt()
+		return nil
+	case apikey.FieldRevokedAt:
+		m.ClearRevokedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown ChatSession nullable field %s", name)
+	return fmt.Errorf("unknown APIKey nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *ChatSessionMutation) ResetField(name string) error {
+func (m *APIKeyMutation) ResetField(name string) error {
 	switch name {
-	case chatsession.FieldSessionID:
-		m.ResetSessionID()
-		return nil
-	case chatsession.FieldUserID:
+	case apikey.FieldUserID:
 		m.ResetUserID()
 		return nil
-	case chatsession.FieldCountryCode:
-		m.ResetCountryCode()
+	case apikey.FieldName:
+		m.ResetName()
 		return nil
-	case chatsession.FieldLanguageCode:
-		m.ResetLanguageCode()
+	case apikey.FieldPrefix:
+		m.ResetPrefix()
 		return nil
-	case chatsession.FieldCurrency:
-		m.ResetCurrency()
+	case apikey.FieldKeyHash:
+		m.ResetKeyHash()
 		return nil
-	case chatsession.FieldMessageCount:
-		m.ResetMessageCount()
+	case apikey.FieldScopes:
+		m.ResetScopes()
 		return nil
-	case chatsession.FieldSearchState:
-		m.ResetSearchState()
+	case apikey.FieldExpiresAt:
+		m.ResetExpiresAt()
 		return nil
-	case chatsession.FieldCycleState:
-		m.ResetCycleState()
+	case apikey.FieldLastUsedAt:
+		m.ResetLastUsedAt()
 		return nil
-	case chatsession.FieldConversationContext:
-		m.ResetConversationContext()
+	case apikey.FieldRevokedAt:
+		m.ResetRevokedAt()
 		return nil
-	case chatsession.FieldCreatedAt:
+	case apikey.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case chatsession.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case chatsession.FieldExpiresAt:
-		m.ResetExpiresAt()
-		return nil
 	}
-	return fmt.Errorf("unknown ChatSession field %s", name)
+	return fmt.Errorf("unknown APIKey field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *ChatSessionMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *APIKeyMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, chatsession.EdgeUser)
-	}
-	if m.messages != nil {
-		edges = append(edges, chatsession.EdgeMessages)
+		edges = append(edges, apikey.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *ChatSessionMutation) AddedIDs(name string) []ent.Value {
+func (m *APIKeyMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case chatsession.EdgeUser:
+	case apikey.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
-	case chatsession.EdgeMessages:
-		ids := make([]ent.Value, 0, len(m.messages))
-		for id := range m.messages {
-			ids = append(ids, id)
-		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *ChatSessionMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedmessages != nil {
-		edges = append(edges, chatsession.EdgeMessages)
-	}
+func (m *APIKeyMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *ChatSessionMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case chatsession.EdgeMessages:
-		ids := make([]ent.Value, 0, len(m.removedmessages))
-		for id := range m.removedmessages {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *APIKeyMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *ChatSessionMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *APIKeyMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, chatsession.EdgeUser)
-	}
-	if m.clearedmessages {
-		edges = append(edges, chatsession.EdgeMessages)
+		edges = append(edges, apikey.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *ChatSessionMutation) EdgeCleared(name string) bool {
+func (m *APIKeyMutation) EdgeCleared(name string) bool {
 	switch name {
-	case chatsession.EdgeUser:
+	case apikey.EdgeUser:
 		return m.cleareduser
-	case chatsession.EdgeMessages:
-		return m.clearedmessages
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *ChatSessionMutation) ClearEdge(name string) error {
+func (m *APIKeyMutation) ClearEdge(name string) error {
 	switch name {
-	case chatsession.EdgeUser:
+	case apikey.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown ChatSession unique edge %s", name)
+	return fmt.Errorf("unknown APIKey unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *ChatSessionMutation) ResetEdge(name string) error {
+func (m *APIKeyMutation) ResetEdge(name string) error {
 	switch name {
-	case chatsession.EdgeUser:
+	case apikey.EdgeUser:
 		m.ResetUser()
 		return nil
-	case chatsession.EdgeMessages:
-		m.ResetMessages()
-		return nil
 	}
-	return fmt.Errorf("unknown ChatSession edge %s", name)
+	return fmt.Errorf("unknown APIKey edge %s", name)
 }
 
-// MessageMutation represents an operation that mutates the Message nodes in the graph.
-type MessageMutation struct {
+// AuditLogMutation represents an operation that mutates the AuditLog nodes in the graph.
+type AuditLogMutation struct {
 	config
-	op                  Op
-	typ                 string
-	id                  *uuid.UUID
-	role                *string
-	content             *string
-	response_type       *string
-	quick_replies       *[]string
-	appendquick_replies []string
-	products            *[]map[string]interface{}
-	appendproducts      []map[string]interface{}
-	search_info         *map[string]interface{}
-	created_at          *time.Time
-	clearedFields       map[string]struct{}
-	session             *uuid.UUID
-	clearedsession      bool
-	done                bool
-	oldValue            func(context.Context) (*Message, error)
-	predicates          []predicate.Message
-}
-
-var _ ent.Mutation = (*MessageMutation)(nil)
-
-// messageOption allows management of the mutation configuration using functional options.
-type messageOption func(*MessageMutation)
-
-// newMessageMutation creates new mutation for the Message entity.
-func newMessageMutation(c config, op Op, opts ...messageOption) *MessageMutation {
-	m := &MessageMutation{
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	action        *string
+	actor_id      *uuid.UUID
+	subject_id    *uuid.UUID
+	ip_address    *string
+	details       *map[string]interface{}
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*AuditLog, error)
+	predicates    []predicate.AuditLog
+}
+
+var _ ent.Mutation = (*AuditLogMutation)(nil)
+
+// auditlogOption allows management of the mutation configuration using functional options.
+type auditlogOption func(*AuditLogMutation)
+
+// newAuditLogMutation creates new mutation for the AuditLog entity.
+func newAuditLogMutation(c config, op Op, opts ...auditlogOption) *AuditLogMutation {
+	m := &AuditLogMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeMessage,
+		typ:           TypeAuditLog,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1220,20 +986,20 @@ func newMessageMutation(c config, op Op, opts ...messageOption) *MessageMutation
 	return m
 }
 
-// withMessageID sets the ID field of the mutation.
-func withMessageID(id uuid.UUID) messageOption {
-	return func(m *MessageMutation) {
+// withAuditLogID sets the ID field of the mutation.
+func withAuditLogID(id uuid.UUID) auditlogOption {
+	return func(m *AuditLogMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Message
+			value *AuditLog
 		)
-		m.oldValue = func(ctx context.Context) (*Message, error) {
+		m.oldValue = func(ctx context.Context) (*AuditLog, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Message.Get(ctx, id)
+					value, err = m.Client().AuditLog.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1242,10 +1008,10 @@ func withMessageID(id uuid.UUID) messageOption {
 	}
 }
 
-// withMessage sets the old Message of the mutation.
-func withMessage(node *Message) messageOption {
-	return func(m *MessageMutation) {
-		m.oldValue = func(context.Context) (*Message, error) {
+// withAuditLog sets the old AuditLog of the mutation.
+func withAuditLog(node *AuditLog) auditlogOption {
+	return func(m *AuditLogMutation) {
+		m.oldValue = func(context.Context) (*AuditLog, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1254,7 +1020,7 @@ func withMessage(node *Message) messageOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m MessageMutation) Client() *Client {
+func (m AuditLogMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1262,7 +1028,7 @@ func (m MessageMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m MessageMutation) Tx() (*Tx, error) {
+func (m AuditLogMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1272,14 +1038,14 @@ func (m MessageMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Message entities.
-func (m *MessageMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of AuditLog entities.
+func (m *AuditLogMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *MessageMutation) ID() (id uuid.UUID, exists bool) {
+func (m *AuditLogMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1290,7 +1056,7 @@ func (m *MessageMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *MessageMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *AuditLogMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1299,355 +1065,251 @@ func (m *MessageMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Message.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().AuditLog.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetSessionID sets the "session_id" field.
-func (m *MessageMutation) SetSessionID(u uuid.UUID) {
-	m.session = &u
+// SetAction sets the "action" field.
+func (m *AuditLogMutation) SetAction(s string) {
+	m.action = &s
 }
 
-// SessionID returns the value of the "session_id" field in the mutation.
-func (m *MessageMutation) SessionID() (r uuid.UUID, exists bool) {
-	v := m.session
+// Action returns the value of the "action" field in the mutation.
+func (m *AuditLogMutation) Action() (r string, exists bool) {
+	v := m.action
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionID returns the old "session_id" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldAction returns the old "action" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldSessionID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *AuditLogMutation) OldAction(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldAction is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionID requires an ID field in the mutation")
+		return v, errors.New("OldAction requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldAction: %w", err)
 	}
-	return oldValue.SessionID, nil
+	return oldValue.Action, nil
 }
 
-// ResetSessionID resets all changes to the "session_id" field.
-func (m *MessageMutation) ResetSessionID() {
-	m.session = nil
+// ResetAction resets all changes to the "action" field.
+func (m *AuditLogMutation) ResetAction() {
+	m.action = nil
 }
 
-// SetRole sets the "role" field.
-func (m *MessageMutation) SetRole(s string) {
-	m.role = &s
+// SetActorID sets the "actor_id" field.
+func (m *AuditLogMutation) SetActorID(u uuid.UUID) {
+	m.actor_id = &u
 }
 
-// Role returns the value of the "role" field in the mutation.
-func (m *MessageMutation) Role() (r string, exists bool) {
-	v := m.role
+// ActorID returns the value of the "actor_id" field in the mutation.
+func (m *AuditLogMutation) ActorID() (r uuid.UUID, exists bool) {
+	v := m.actor_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRole returns the old "role" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldActorID returns the old "actor_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldRole(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldActorID(ctx context.Context) (v *uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+		return v, errors.New("OldActorID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRole requires an ID field in the mutation")
+		return v, errors.New("OldActorID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+		return v, fmt.Errorf("querying old value for OldActorID: %w", err)
 	}
-	return oldValue.Role, nil
+	return oldValue.ActorID, nil
 }
 
-// ResetRole resets all changes to the "role" field.
-func (m *MessageMutation) ResetRole() {
-	m.role = nil
-}
-
-// SetContent sets the "content" field.
-func (m *MessageMutation) SetContent(s string) {
-	m.content = &s
-}
-
-// Content returns the value of the "content" field in the mutation.
-func (m *MessageMutation) Content() (r string, exists bool) {
-	v := m.content
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldContent returns the old "content" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldContent(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldContent is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldContent requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldContent: %w", err)
-	}
-	return oldValue.Content, nil
-}
-
-// ResetContent resets all changes to the "content" field.
-func (m *MessageMutation) ResetContent() {
-	m.content = nil
-}
-
-// SetResponseType sets the "response_type" field.
-func (m *MessageMutation) SetResponseType(s string) {
-	m.response_type = &s
-}
-
-// ResponseType returns the value of the "response_type" field in the mutation.
-func (m *MessageMutation) ResponseType() (r string, exists bool) {
-	v := m.response_type
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldResponseType returns the old "response_type" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldResponseType(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldResponseType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldResponseType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldResponseType: %w", err)
-	}
-	return oldValue.ResponseType, nil
-}
-
-// ClearResponseType clears the value of the "response_type" field.
-func (m *MessageMutation) ClearResponseType() {
-	m.response_type = nil
-	m.clearedFields[message.FieldResponseType] = struct{}{}
+// ClearActorID clears the value of the "actor_id" field.
+func (m *AuditLogMutation) ClearActorID() {
+	m.actor_id = nil
+	m.clearedFields[auditlog.FieldActorID] = struct{}{}
 }
 
-// ResponseTypeCleared returns if the "response_type" field was cleared in this mutation.
-func (m *MessageMutation) ResponseTypeCleared() bool {
-	_, ok := m.clearedFields[message.FieldResponseType]
+// ActorIDCleared returns if the "actor_id" field was cleared in this mutation.
+func (m *AuditLogMutation) ActorIDCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldActorID]
 	return ok
 }
 
-// ResetResponseType resets all changes to the "response_type" field.
-func (m *MessageMutation) ResetResponseType() {
-	m.response_type = nil
-	delete(m.clearedFields, message.FieldResponseType)
+// ResetActorID resets all changes to the "actor_id" field.
+func (m *AuditLogMutation) ResetActorID() {
+	m.actor_id = nil
+	delete(m.clearedFields, auditlog.FieldActorID)
 }
 
-// SetQuickReplies sets the "quick_replies" field.
-func (m *MessageMutation) SetQuickReplies(s []string) {
-	m.quick_replies = &s
-	m.appendquick_replies = nil
+// SetSubjectID sets the "subject_id" field.
+func (m *AuditLogMutation) SetSubjectID(u uuid.UUID) {
+	m.subject_id = &u
 }
 
-// QuickReplies returns the value of the "quick_replies" field in the mutation.
-func (m *MessageMutation) QuickReplies() (r []string, exists bool) {
-	v := m.quick_replies
+// SubjectID returns the value of the "subject_id" field in the mutation.
+func (m *AuditLogMutation) SubjectID() (r uuid.UUID, exists bool) {
+	v := m.subject_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldQuickReplies returns the old "quick_replies" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldSubjectID returns the old "subject_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldQuickReplies(ctx context.Context) (v []string, err error) {
+func (m *AuditLogMutation) OldSubjectID(ctx context.Context) (v *uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldQuickReplies is only allowed on UpdateOne operations")
+		return v, errors.New("OldSubjectID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldQuickReplies requires an ID field in the mutation")
+		return v, errors.New("OldSubjectID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldQuickReplies: %w", err)
+		return v, fmt.Errorf("querying old value for OldSubjectID: %w", err)
 	}
-	return oldValue.QuickReplies, nil
-}
-
-// AppendQuickReplies adds s to the "quick_replies" field.
-func (m *MessageMutation) AppendQuickReplies(s []string) {
-	m.appendquick_replies = append(m.appendquick_replies, s...)
-}
-
-// AppendedQuickReplies returns the list of values that were appended to the "quick_replies" field in this mutation.
-func (m *MessageMutation) AppendedQuickReplies() ([]string, bool) {
-	if len(m.appendquick_replies) == 0 {
-		return nil, false
-	}
-	return m.appendquick_replies, true
+	return oldValue.SubjectID, nil
 }
 
-// ClearQuickReplies clears the value of the "quick_replies" field.
-func (m *MessageMutation) ClearQuickReplies() {
-	m.quick_replies = nil
-	m.appendquick_replies = nil
-	m.clearedFields[message.FieldQuickReplies] = struct{}{}
+// ClearSubjectID clears the value of the "subject_id" field.
+func (m *AuditLogMutation) ClearSubjectID() {
+	m.subject_id = nil
+	m.clearedFields[auditlog.FieldSubjectID] = struct{}{}
 }
 
-// QuickRepliesCleared returns if the "quick_replies" field was cleared in this mutation.
-func (m *MessageMutation) QuickRepliesCleared() bool {
-	_, ok := m.clearedFields[message.FieldQuickReplies]
+// SubjectIDCleared returns if the "subject_id" field was cleared in this mutation.
+func (m *AuditLogMutation) SubjectIDCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldSubjectID]
 	return ok
 }
 
-// ResetQuickReplies resets all changes to the "quick_replies" field.
-func (m *MessageMutation) ResetQuickReplies() {
-	m.quick_replies = nil
-	m.appendquick_replies = nil
-	delete(m.clearedFields, message.FieldQuickReplies)
+// ResetSubjectID resets all changes to the "subject_id" field.
+func (m *AuditLogMutation) ResetSubjectID() {
+	m.subject_id = nil
+	delete(m.clearedFields, auditlog.FieldSubjectID)
 }
 
-// SetProducts sets the "products" field.
-func (m *MessageMutation) SetProducts(value []map[string]interface{}) {
-	m.products = &value
-	m.appendproducts = nil
+// SetIPAddress sets the "ip_address" field.
+func (m *AuditLogMutation) SetIPAddress(s string) {
+	m.ip_address = &s
 }
 
-// Products returns the value of the "products" field in the mutation.
-func (m *MessageMutation) Products() (r []map[string]interface{}, exists bool) {
-	v := m.products
+// IPAddress returns the value of the "ip_address" field in the mutation.
+func (m *AuditLogMutation) IPAddress() (r string, exists bool) {
+	v := m.ip_address
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProducts returns the old "products" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldIPAddress returns the old "ip_address" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldProducts(ctx context.Context) (v []map[string]interface{}, err error) {
+func (m *AuditLogMutation) OldIPAddress(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProducts is only allowed on UpdateOne operations")
+		return v, errors.New("OldIPAddress is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProducts requires an ID field in the mutation")
+		return v, errors.New("OldIPAddress requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProducts: %w", err)
-	}
-	return oldValue.Products, nil
-}
-
-// AppendProducts adds value to the "products" field.
-func (m *MessageMutation) AppendProducts(value []map[string]interface{}) {
-	m.appendproducts = append(m.appendproducts, value...)
-}
-
-// AppendedProducts returns the list of values that were appended to the "products" field in this mutation.
-func (m *MessageMutation) AppendedProducts() ([]map[string]interface{}, bool) {
-	if len(m.appendproducts) == 0 {
-		return nil, false
+		return v, fmt.Errorf("querying old value for OldIPAddress: %w", err)
 	}
-	return m.appendproducts, true
+	return oldValue.IPAddress, nil
 }
 
-// ClearProducts clears the value of the "products" field.
-func (m *MessageMutation) ClearProducts() {
-	m.products = nil
-	m.appendproducts = nil
-	m.clearedFields[message.FieldProducts] = struct{}{}
+// ClearIPAddress clears the value of the "ip_address" field.
+func (m *AuditLogMutation) ClearIPAddress() {
+	m.ip_address = nil
+	m.clearedFields[auditlog.FieldIPAddress] = struct{}{}
 }
 
-// ProductsCleared returns if the "products" field was cleared in this mutation.
-func (m *MessageMutation) ProductsCleared() bool {
-	_, ok := m.clearedFields[message.FieldProducts]
+// IPAddressCleared returns if the "ip_address" field was cleared in this mutation.
+func (m *AuditLogMutation) IPAddressCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldIPAddress]
 	return ok
 }
 
-// ResetProducts resets all changes to the "products" field.
-func (m *MessageMutation) ResetProducts() {
-	m.products = nil
-	m.appendproducts = nil
-	delete(m.clearedFields, message.FieldProducts)
+// ResetIPAddress resets all changes to the "ip_address" field.
+func (m *AuditLogMutation) ResetIPAddress() {
+	m.ip_address = nil
+	delete(m.clearedFields, auditlog.FieldIPAddress)
 }
 
-// SetSearchInfo sets the "search_info" field.
-func (m *MessageMutation) SetSearchInfo(value map[string]interface{}) {
-	m.search_info = &value
+// SetDetails sets the "details" field.
+func (m *AuditLogMutation) SetDetails(value map[string]interface{}) {
+	m.details = &value
 }
 
-// SearchInfo returns the value of the "search_info" field in the mutation.
-func (m *MessageMutation) SearchInfo() (r map[string]interface{}, exists bool) {
-	v := m.search_info
+// Details returns the value of the "details" field in the mutation.
+func (m *AuditLogMutation) Details() (r map[string]interface{}, exists bool) {
+	v := m.details
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSearchInfo returns the old "search_info" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldDetails returns the old "details" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldSearchInfo(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *AuditLogMutation) OldDetails(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSearchInfo is only allowed on UpdateOne operations")
+		return v, errors.New("OldDetails is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSearchInfo requires an ID field in the mutation")
+		return v, errors.New("OldDetails requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSearchInfo: %w", err)
+		return v, fmt.Errorf("querying old value for OldDetails: %w", err)
 	}
-	return oldValue.SearchInfo, nil
+	return oldValue.Details, nil
 }
 
-// ClearSearchInfo clears the value of the "search_info" field.
-func (m *MessageMutation) ClearSearchInfo() {
-	m.search_info = nil
-	m.clearedFields[message.FieldSearchInfo] = struct{}{}
+// ClearDetails clears the value of the "details" field.
+func (m *AuditLogMutation) ClearDetails() {
+	m.details = nil
+	m.clearedFields[auditlog.FieldDetails] = struct{}{}
 }
 
-// SearchInfoCleared returns if the "search_info" field was cleared in this mutation.
-func (m *MessageMutation) SearchInfoCleared() bool {
-	_, ok := m.clearedFields[message.FieldSearchInfo]
+// DetailsCleared returns if the "details" field was cleared in this mutation.
+func (m *AuditLogMutation) DetailsCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldDetails]
 	return ok
 }
 
-// ResetSearchInfo resets all changes to the "search_info" field.
-func (m *MessageMutation) ResetSearchInfo() {
-	m.search_info = nil
-	delete(m.clearedFields, message.FieldSearchInfo)
+// ResetDetails resets all changes to the "details" field.
+func (m *AuditLogMutation) ResetDetails() {
+	m.details = nil
+	delete(m.clearedFields, auditlog.FieldDetails)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *MessageMutation) SetCreatedAt(t time.Time) {
+func (m *AuditLogMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *MessageMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *AuditLogMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -1655,10 +1317,10 @@ func (m *MessageMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Message entity.
-// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MessageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *AuditLogMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -1673,46 +1335,19 @@ func (m *MessageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err er
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *MessageMutation) ResetCreatedAt() {
+func (m *AuditLogMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// ClearSession clears the "session" edge to the ChatSession entity.
-func (m *MessageMutation) ClearSession() {
-	m.clearedsession = true
-	m.clearedFields[message.FieldSessionID] = struct{}{}
-}
-
-// SessionCleared reports if the "session" edge to the ChatSession entity was cleared.
-func (m *MessageMutation) SessionCleared() bool {
-	return m.clearedsession
-}
-
-// SessionIDs returns the "session" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// SessionID instead. It exists only for internal usage by the builders.
-func (m *MessageMutation) SessionIDs() (ids []uuid.UUID) {
-	if id := m.session; id != nil {
-		ids = append(ids, *id)
-	}
-	return
-}
-
-// ResetSession resets all changes to the "session" edge.
-func (m *MessageMutation) ResetSession() {
-	m.session = nil
-	m.clearedsession = false
-}
-
-// Where appends a list predicates to the MessageMutation builder.
-func (m *MessageMutation) Where(ps ...predicate.Message) {
+// Where appends a list predicates to the AuditLogMutation builder.
+func (m *AuditLogMutation) Where(ps ...predicate.AuditLog) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the MessageMutation builder. Using this method,
+// WhereP appends storage-level predicates to the AuditLogMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *MessageMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Message, len(ps))
+func (m *AuditLogMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AuditLog, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1720,48 +1355,42 @@ func (m *MessageMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *MessageMutation) Op() Op {
+func (m *AuditLogMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *MessageMutation) SetOp(op Op) {
+func (m *AuditLogMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Message).
-func (m *MessageMutation) Type() string {
+// Type returns the node type of this mutation (AuditLog).
+func (m *AuditLogMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *MessageMutation) Fields() []string {
-	fields := make([]string, 0, 8)
-	if m.session != nil {
-		fields = append(fields, message.FieldSessionID)
-	}
-	if m.role != nil {
-		fields = append(fields, message.FieldRole)
+func (m *AuditLogMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.action != nil {
+		fields = append(fields, auditlog.FieldAction)
 	}
-	if m.content != nil {
-		fields = append(fields, message.FieldContent)
-	}
-	if m.response_type != nil {
-		fields = append(fields, message.FieldResponseType)
+	if m.actor_id != nil {
+		fields = append(fields, auditlog.FieldActorID)
 	}
-	if m.quick_replies != nil {
-		fields = append(fields, message.FieldQuickReplies)
+	if m.subject_id != nil {
+		fields = append(fields, auditlog.FieldSubjectID)
 	}
-	if m.products != nil {
-		fields = append(fields, message.FieldProducts)
+	if m.ip_address != nil {
+		fields = append(fields, auditlog.FieldIPAddress)
 	}
-	if m.search_info != nil {
-		fields = append(fields, message.FieldSearchInfo)
+	if m.details != nil {
+		fields = append(fields, auditlog.FieldDetails)
 	}
 	if m.created_at != nil {
-		fields = append(fields, message.FieldCreatedAt)
+		fields = append(fields, auditlog.FieldCreatedAt)
 	}
 	return fields
 }
@@ -1769,23 +1398,19 @@ func (m *MessageMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *MessageMutation) Field(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case message.FieldSessionID:
-		return m.SessionID()
-	case message.FieldRole:
-		return m.Role()
-	case message.FieldContent:
-		return m.Content()
-	case message.FieldResponseType:
-		return m.ResponseType()
-	case message.FieldQuickReplies:
-		return m.QuickReplies()
-	case message.FieldProducts:
-		return m.Products()
-	case message.FieldSearchInfo:
-		return m.SearchInfo()
-	case message.FieldCreatedAt:
+	case auditlog.FieldAction:
+		return m.Action()
+	case auditlog.FieldActorID:
+		return m.ActorID()
+	case auditlog.FieldSubjectID:
+		return m.SubjectID()
+	case auditlog.FieldIPAddress:
+		return m.IPAddress()
+	case auditlog.FieldDetails:
+		return m.Details()
+	case auditlog.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -1794,83 +1419,65 @@ func (m *MessageMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *MessageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *AuditLogMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case message.FieldSessionID:
-		return m.OldSessionID(ctx)
-	case message.FieldRole:
-		return m.OldRole(ctx)
-	case message.FieldContent:
-		return m.OldContent(ctx)
-	case message.FieldResponseType:
-		return m.OldResponseType(ctx)
-	case message.FieldQuickReplies:
-		return m.OldQuickReplies(ctx)
-	case message.FieldProducts:
-		return m.OldProducts(ctx)
-	case message.FieldSearchInfo:
-		return m.OldSearchInfo(ctx)
-	case message.FieldCreatedAt:
+	case auditlog.FieldAction:
+		return m.OldAction(ctx)
+	case auditlog.FieldActorID:
+		return m.OldActorID(ctx)
+	case auditlog.FieldSubjectID:
+		return m.OldSubjectID(ctx)
+	case auditlog.FieldIPAddress:
+		return m.OldIPAddress(ctx)
+	case auditlog.FieldDetails:
+		return m.OldDetails(ctx)
+	case auditlog.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Message field %s", name)
+	return nil, fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MessageMutation) SetField(name string, value ent.Value) error {
+func (m *AuditLogMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case message.FieldSessionID:
-		v, ok := value.(uuid.UUID)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSessionID(v)
-		return nil
-	case message.FieldRole:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRole(v)
-		return nil
-	case message.FieldContent:
+	case auditlog.FieldAction:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetContent(v)
+		m.SetAction(v)
 		return nil
-	case message.FieldResponseType:
-		v, ok := value.(string)
+	case auditlog.FieldActorID:
+		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetResponseType(v)
+		m.SetActorID(v)
 		return nil
-	case message.FieldQuickReplies:
-		v, ok := value.([]string)
+	case auditlog.FieldSubjectID:
+		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetQuickReplies(v)
+		m.SetSubjectID(v)
 		return nil
-	case message.FieldProducts:
-		v, ok := value.([]map[string]interface{})
+	case auditlog.FieldIPAddress:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetProducts(v)
+		m.SetIPAddress(v)
 		return nil
-	case message.FieldSearchInfo:
+	case auditlog.FieldDetails:
 		v, ok := value.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSearchInfo(v)
+		m.SetDetails(v)
 		return nil
-	case message.FieldCreatedAt:
+	case auditlog.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -1878,223 +1485,191 @@ func (m *MessageMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Message field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *MessageMutation) AddedFields() []string {
+func (m *AuditLogMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *MessageMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MessageMutation) AddField(name string, value ent.Value) error {
+func (m *AuditLogMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Message numeric field %s", name)
+	return fmt.Errorf("unknown AuditLog numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *MessageMutation) ClearedFields() []string {
+func (m *AuditLogMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(message.FieldResponseType) {
-		fields = append(fields, message.FieldResponseType)
+	if m.FieldCleared(auditlog.FieldActorID) {
+		fields = append(fields, auditlog.FieldActorID)
 	}
-	if m.FieldCleared(message.FieldQuickReplies) {
-		fields = append(fields, message.FieldQuickReplies)
+	if m.FieldCleared(auditlog.FieldSubjectID) {
+		fields = append(fields, auditlog.FieldSubjectID)
 	}
-	if m.FieldCleared(message.FieldProducts) {
-		fields = append(fields, message.FieldProducts)
+	if m.FieldCleared(auditlog.FieldIPAddress) {
+		fields = append(fields, auditlog.FieldIPAddress)
 	}
-	if m.FieldCleared(message.FieldSearchInfo) {
-		fields = append(fields, message.FieldSearchInfo)
+	if m.FieldCleared(auditlog.FieldDetails) {
+		fields = append(fields, auditlog.FieldDetails)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *MessageMutation) FieldCleared(name string) bool {
+func (m *AuditLogMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *MessageMutation) ClearField(name string) error {
+func (m *AuditLogMutation) ClearField(name string) error {
 	switch name {
-	case message.FieldResponseType:
-		m.ClearResponseType()
+	case auditlog.FieldActorID:
+		m.ClearActorID()
 		return nil
-	case message.FieldQuickReplies:
-		m.ClearQuickReplies()
+	case auditlog.FieldSubjectID:
+		m.ClearSubjectID()
 		return nil
-	case message.FieldProducts:
-		m.ClearProducts()
+	case auditlog.FieldIPAddress:
+		m.ClearIPAddress()
 		return nil
-	case message.FieldSearchInfo:
-		m.ClearSearchInfo()
+	case auditlog.FieldDetails:
+		m.ClearDetails()
 		return nil
 	}
-	return fmt.Errorf("unknown Message nullable field %s", name)
+	return fmt.Errorf("unknown AuditLog nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *MessageMutation) ResetField(name string) error {
+func (m *AuditLogMutation) ResetField(name string) error {
 	switch name {
-	case message.FieldSessionID:
-		m.ResetSessionID()
-		return nil
-	case message.FieldRole:
-		m.ResetRole()
+	case auditlog.FieldAction:
+		m.ResetAction()
 		return nil
-	case message.FieldContent:
-		m.ResetContent()
-		return nil
-	case message.FieldResponseType:
-		m.ResetResponseType()
+	case auditlog.FieldActorID:
+		m.ResetActorID()
 		return nil
-	case message.FieldQuickReplies:
-		m.ResetQuickReplies()
+	case auditlog.FieldSubjectID:
+		m.ResetSubjectID()
 		return nil
-	case message.FieldProducts:
-		m.ResetProducts()
+	case auditlog.FieldIPAddress:
+		m.ResetIPAddress()
 		return nil
-	case message.FieldSearchInfo:
-		m.ResetSearchInfo()
+	case auditlog.FieldDetails:
+		m.ResetDetails()
 		return nil
-	case message.FieldCreatedAt:
+	case auditlog.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Message field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *MessageMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.session != nil {
-		edges = append(edges, message.EdgeSession)
-	}
+func (m *AuditLogMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *MessageMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case message.EdgeSession:
-		if id := m.session; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *AuditLogMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *MessageMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *AuditLogMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *MessageMutation) RemovedIDs(name string) []ent.Value {
+func (m *AuditLogMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *MessageMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedsession {
-		edges = append(edges, message.EdgeSession)
-	}
+func (m *AuditLogMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *MessageMutation) EdgeCleared(name string) bool {
-	switch name {
-	case message.EdgeSession:
-		return m.clearedsession
-	}
+func (m *AuditLogMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *MessageMutation) ClearEdge(name string) error {
-	switch name {
-	case message.EdgeSession:
-		m.ClearSession()
-		return nil
-	}
-	return fmt.Errorf("unknown Message unique edge %s", name)
+func (m *AuditLogMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown AuditLog unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *MessageMutation) ResetEdge(name string) error {
-	switch name {
-	case message.EdgeSession:
-		m.ResetSession()
-		return nil
-	}
-	return fmt.Errorf("unknown Message edge %s", name)
+func (m *AuditLogMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown AuditLog edge %s", name)
 }
 
-// SearchHistoryMutation represents an operation that mutates the SearchHistory nodes in the graph.
-type SearchHistoryMutation struct {
+// ChatSessionMutation represents an operation that mutates the ChatSession nodes in the graph.
+type ChatSessionMutation struct {
 	config
 	op                   Op
 	typ                  string
 	id                   *uuid.UUID
 	session_id           *string
-	search_query         *string
-	optimized_query      *string
-	search_type          *string
-	category             *string
 	country_code         *string
 	language_code        *string
 	currency             *string
-	result_count         *int
-	addresult_count      *int
-	products_found       *[]map[string]interface{}
-	appendproducts_found []map[string]interface{}
-	clicked_product_id   *string
+	message_count        *int
+	addmessage_count     *int
+	search_state         *map[string]interface{}
+	cycle_state          *map[string]interface{}
+	conversation_context *map[string]interface{}
 	created_at           *time.Time
+	updated_at           *time.Time
 	expires_at           *time.Time
 	clearedFields        map[string]struct{}
 	user                 *uuid.UUID
 	cleareduser          bool
+	messages             map[uuid.UUID]struct{}
+	removedmessages      map[uuid.UUID]struct{}
+	clearedmessages      bool
 	done                 bool
-	oldValue             func(context.Context) (*SearchHistory, error)
-	predicates           []predicate.SearchHistory
+	oldValue             func(context.Context) (*ChatSession, error)
+	predicates           []predicate.ChatSession
 }
 
-var _ ent.Mutation = (*SearchHistoryMutation)(nil)
+var _ ent.Mutation = (*ChatSessionMutation)(nil)
 
-// searchhistoryOption allows management of the mutation configuration using functional options.
-type searchhistoryOption func(*SearchHistoryMutation)
+// chatsessionOption allows management of the mutation configuration using functional options.
+type chatsessionOption func(*ChatSessionMutation)
 
-// newSearchHistoryMutation creates new mutation for the SearchHistory entity.
-func newSearchHistoryMutation(c config, op Op, opts ...searchhistoryOption) *SearchHistoryMutation {
-	m := &SearchHistoryMutation{
+// newChatSessionMutation creates new mutation for the ChatSession entity.
+func newChatSessionMutation(c config, op Op, opts ...chatsessionOption) *ChatSessionMutation {
+	m := &ChatSessionMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeSearchHistory,
+		typ:           TypeChatSession,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2103,20 +1678,20 @@ func newSearchHistoryMutation(c config, op Op, opts ...searchhistoryOption) *Sea
 	return m
 }
 
-// withSearchHistoryID sets the ID field of the mutation.
-func withSearchHistoryID(id uuid.UUID) searchhistoryOption {
-	return func(m *SearchHistoryMutation) {
+// withChatSessionID sets the ID field of the mutation.
+func withChatSessionID(id uuid.UUID) chatsessionOption {
+	return func(m *ChatSessionMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *SearchHistory
+			value *ChatSession
 		)
-		m.oldValue = func(ctx context.Context) (*SearchHistory, error) {
+		m.oldValue = func(ctx context.Context) (*ChatSession, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().SearchHistory.Get(ctx, id)
+					value, err = m.Client().ChatSession.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2125,10 +1700,10 @@ func withSearchHistoryID(id uuid.UUID) searchhistoryOption {
 	}
 }
 
-// withSearchHistory sets the old SearchHistory of the mutation.
-func withSearchHistory(node *SearchHistory) searchhistoryOption {
-	return func(m *SearchHistoryMutation) {
-		m.oldValue = func(context.Context) (*SearchHistory, error) {
+// withChatSession sets the old ChatSession of the mutation.
+func withChatSession(node *ChatSession) chatsessionOption {
+	return func(m *ChatSessionMutation) {
+		m.oldValue = func(context.Context) (*ChatSession, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2137,7 +1712,7 @@ func withSearchHistory(node *SearchHistory) searchhistoryOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m SearchHistoryMutation) Client() *Client {
+func (m ChatSessionMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2145,7 +1720,7 @@ func (m SearchHistoryMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m SearchHistoryMutation) Tx() (*Tx, error) {
+func (m ChatSessionMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -2155,14 +1730,14 @@ func (m SearchHistoryMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of SearchHistory entities.
-func (m *SearchHistoryMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of ChatSession entities.
+func (m *ChatSessionMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *SearchHistoryMutation) ID() (id uuid.UUID, exists bool) {
+func (m *ChatSessionMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2173,7 +1748,7 @@ func (m *SearchHistoryMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *SearchHistoryMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *ChatSessionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -2182,575 +1757,5343 @@ func (m *SearchHistoryMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().SearchHistory.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().ChatSession.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUserID sets the "user_id" field.
-func (m *SearchHistoryMutation) SetUserID(u uuid.UUID) {
-	m.user = &u
+// SetSessionID sets the "session_id" field.
+func (m *ChatSessionMutation) SetSessionID(s string) {
+	m.session_id = &s
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *SearchHistoryMutation) UserID() (r uuid.UUID, exists bool) {
-	v := m.user
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *ChatSessionMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldSessionID returns the old "session_id" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *ChatSessionMutation) OldSessionID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.SessionID, nil
 }
 
-// ClearUserID clears the value of the "user_id" field.
-func (m *SearchHistoryMutation) ClearUserID() {
-	m.user = nil
-	m.clearedFields[searchhistory.FieldUserID] = struct{}{}
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *ChatSessionMutation) ResetSessionID() {
+	m.session_id = nil
 }
 
-// UserIDCleared returns if the "user_id" field was cleared in this mutation.
-func (m *SearchHistoryMutation) UserIDCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldUserID]
-	return ok
+// SetUserID sets the "user_id" field.
+func (m *ChatSessionMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *SearchHistoryMutation) ResetUserID() {
-	m.user = nil
-	delete(m.clearedFields, searchhistory.FieldUserID)
-}
-
-// SetSessionID sets the "session_id" field.
-func (m *SearchHistoryMutation) SetSessionID(s string) {
-	m.session_id = &s
-}
-
-// SessionID returns the value of the "session_id" field in the mutation.
-func (m *SearchHistoryMutation) SessionID() (r string, exists bool) {
-	v := m.session_id
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *ChatSessionMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionID returns the old "session_id" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldSessionID(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionID requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.SessionID, nil
+	return oldValue.UserID, nil
 }
 
-// ClearSessionID clears the value of the "session_id" field.
-func (m *SearchHistoryMutation) ClearSessionID() {
-	m.session_id = nil
-	m.clearedFields[searchhistory.FieldSessionID] = struct{}{}
+// ClearUserID clears the value of the "user_id" field.
+func (m *ChatSessionMutation) ClearUserID() {
+	m.user = nil
+	m.clearedFields[chatsession.FieldUserID] = struct{}{}
 }
 
-// SessionIDCleared returns if the "session_id" field was cleared in this mutation.
-func (m *SearchHistoryMutation) SessionIDCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldSessionID]
+// UserIDCleared returns if the "user_id" field was cleared in this mutation.
+func (m *ChatSessionMutation) UserIDCleared() bool {
+	_, ok := m.clearedFields[chatsession.FieldUserID]
 	return ok
 }
 
-// ResetSessionID resets all changes to the "session_id" field.
-func (m *SearchHistoryMutation) ResetSessionID() {
-	m.session_id = nil
-	delete(m.clearedFields, searchhistory.FieldSessionID)
+// ResetUserID resets all changes to the "user_id" field.
+func (m *ChatSessionMutation) ResetUserID() {
+	m.user = nil
+	delete(m.clearedFields, chatsession.FieldUserID)
 }
 
-// SetSearchQuery sets the "search_query" field.
-func (m *SearchHistoryMutation) SetSearchQuery(s string) {
-	m.search_query = &s
+// SetCountryCode sets the "country_code" field.
+func (m *ChatSessionMutation) SetCountryCode(s string) {
+	m.country_code = &s
 }
 
-// SearchQuery returns the value of the "search_query" field in the mutation.
-func (m *SearchHistoryMutation) SearchQuery() (r string, exists bool) {
-	v := m.search_query
+// CountryCode returns the value of the "country_code" field in the mutation.
+func (m *ChatSessionMutation) CountryCode() (r string, exists bool) {
+	v := m.country_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSearchQuery returns the old "search_query" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldCountryCode returns the old "country_code" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldSearchQuery(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldCountryCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSearchQuery is only allowed on UpdateOne operations")
+		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSearchQuery requires an ID field in the mutation")
+		return v, errors.New("OldCountryCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSearchQuery: %w", err)
+		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
 	}
-	return oldValue.SearchQuery, nil
+	return oldValue.CountryCode, nil
 }
 
-// ResetSearchQuery resets all changes to the "search_query" field.
-func (m *SearchHistoryMutation) ResetSearchQuery() {
-	m.search_query = nil
+// ResetCountryCode resets all changes to the "country_code" field.
+func (m *ChatSessionMutation) ResetCountryCode() {
+	m.country_code = nil
 }
 
-// SetOptimizedQuery sets the "optimized_query" field.
-func (m *SearchHistoryMutation) SetOptimizedQuery(s string) {
-	m.optimized_query = &s
+// SetLanguageCode sets the "language_code" field.
+func (m *ChatSessionMutation) SetLanguageCode(s string) {
+	m.language_code = &s
 }
 
-// OptimizedQuery returns the value of the "optimized_query" field in the mutation.
-func (m *SearchHistoryMutation) OptimizedQuery() (r string, exists bool) {
-	v := m.optimized_query
+// LanguageCode returns the value of the "language_code" field in the mutation.
+func (m *ChatSessionMutation) LanguageCode() (r string, exists bool) {
+	v := m.language_code
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOptimizedQuery returns the old "optimized_query" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldLanguageCode returns the old "language_code" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldOptimizedQuery(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldLanguageCode(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOptimizedQuery is only allowed on UpdateOne operations")
+		return v, errors.New("OldLanguageCode is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOptimizedQuery requires an ID field in the mutation")
+		return v, errors.New("OldLanguageCode requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOptimizedQuery: %w", err)
+		return v, fmt.Errorf("querying old value for OldLanguageCode: %w", err)
 	}
-	return oldValue.OptimizedQuery, nil
-}
-
-// ClearOptimizedQuery clears the value of the "optimized_query" field.
-func (m *SearchHistoryMutation) ClearOptimizedQuery() {
-	m.optimized_query = nil
-	m.clearedFields[searchhistory.FieldOptimizedQuery] = struct{}{}
-}
-
-// OptimizedQueryCleared returns if the "optimized_query" field was cleared in this mutation.
-func (m *SearchHistoryMutation) OptimizedQueryCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldOptimizedQuery]
-	return ok
+	return oldValue.LanguageCode, nil
 }
 
-// ResetOptimizedQuery resets all changes to the "optimized_query" field.
-func (m *SearchHistoryMutation) ResetOptimizedQuery() {
-	m.optimized_query = nil
-	delete(m.clearedFields, searchhistory.FieldOptimizedQuery)
+// ResetLanguageCode resets all changes to the "language_code" field.
+func (m *ChatSessionMutation) ResetLanguageCode() {
+	m.language_code = nil
 }
 
-// SetSearchType sets the "search_type" field.
-func (m *SearchHistoryMutation) SetSearchType(s string) {
-	m.search_type = &s
+// SetCurrency sets the "currency" field.
+func (m *ChatSessionMutation) SetCurrency(s string) {
+	m.currency = &s
 }
 
-// SearchType returns the value of the "search_type" field in the mutation.
-func (m *SearchHistoryMutation) SearchType() (r string, exists bool) {
-	v := m.search_type
+// Currency returns the value of the "currency" field in the mutation.
+func (m *ChatSessionMutation) Currency() (r string, exists bool) {
+	v := m.currency
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSearchType returns the old "search_type" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldCurrency returns the old "currency" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldSearchType(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldCurrency(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSearchType is only allowed on UpdateOne operations")
+		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSearchType requires an ID field in the mutation")
+		return v, errors.New("OldCurrency requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSearchType: %w", err)
+		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
 	}
-	return oldValue.SearchType, nil
+	return oldValue.Currency, nil
 }
 
-// ResetSearchType resets all changes to the "search_type" field.
-func (m *SearchHistoryMutation) ResetSearchType() {
-	m.search_type = nil
+// ResetCurrency resets all changes to the "currency" field.
+func (m *ChatSessionMutation) ResetCurrency() {
+	m.currency = nil
 }
 
-// SetCategory sets the "category" field.
-func (m *SearchHistoryMutation) SetCategory(s string) {
-	m.category = &s
+// SetMessageCount sets the "message_count" field.
+func (m *ChatSessionMutation) SetMessageCount(i int) {
+	m.message_count = &i
+	m.addmessage_count = nil
 }
 
-// Category returns the value of the "category" field in the mutation.
-func (m *SearchHistoryMutation) Category() (r string, exists bool) {
-	v := m.category
+// MessageCount returns the value of the "message_count" field in the mutation.
+func (m *ChatSessionMutation) MessageCount() (r int, exists bool) {
+	v := m.message_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCategory returns the old "category" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldMessageCount returns the old "message_count" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldCategory(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldMessageCount(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCategory is only allowed on UpdateOne operations")
+		return v, errors.New("OldMessageCount is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCategory requires an ID field in the mutation")
+		return v, errors.New("OldMessageCount requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCategory: %w", err)
+		return v, fmt.Errorf("querying old value for OldMessageCount: %w", err)
 	}
-	return oldValue.Category, nil
+	return oldValue.MessageCount, nil
 }
 
-// ClearCategory clears the value of the "category" field.
-func (m *SearchHistoryMutation) ClearCategory() {
-	m.category = nil
-	m.clearedFields[searchhistory.FieldCategory] = struct{}{}
+// AddMessageCount adds i to the "message_count" field.
+func (m *ChatSessionMutation) AddMessageCount(i int) {
+	if m.addmessage_count != nil {
+		*m.addmessage_count += i
+	} else {
+		m.addmessage_count = &i
+	}
 }
 
-// CategoryCleared returns if the "category" field was cleared in this mutation.
-func (m *SearchHistoryMutation) CategoryCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldCategory]
-	return ok
+// AddedMessageCount returns the value that was added to the "message_count" field in this mutation.
+func (m *ChatSessionMutation) AddedMessageCount() (r int, exists bool) {
+	v := m.addmessage_count
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetCategory resets all changes to the "category" field.
-func (m *SearchHistoryMutation) ResetCategory() {
-	m.category = nil
-	delete(m.clearedFields, searchhistory.FieldCategory)
+// ResetMessageCount resets all changes to the "message_count" field.
+func (m *ChatSessionMutation) ResetMessageCount() {
+	m.message_count = nil
+	m.addmessage_count = nil
 }
 
-// SetCountryCode sets the "country_code" field.
-func (m *SearchHistoryMutation) SetCountryCode(s string) {
-	m.country_code = &s
+// SetSearchState sets the "search_state" field.
+func (m *ChatSessionMutation) SetSearchState(value map[string]interface{}) {
+	m.search_state = &value
 }
 
-// CountryCode returns the value of the "country_code" field in the mutation.
-func (m *SearchHistoryMutation) CountryCode() (r string, exists bool) {
-	v := m.country_code
+// SearchState returns the value of the "search_state" field in the mutation.
+func (m *ChatSessionMutation) SearchState() (r map[string]interface{}, exists bool) {
+	v := m.search_state
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCountryCode returns the old "country_code" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldSearchState returns the old "search_state" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldCountryCode(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldSearchState(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldSearchState is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCountryCode requires an ID field in the mutation")
+		return v, errors.New("OldSearchState requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldSearchState: %w", err)
 	}
-	return oldValue.CountryCode, nil
+	return oldValue.SearchState, nil
 }
 
-// ResetCountryCode resets all changes to the "country_code" field.
-func (m *SearchHistoryMutation) ResetCountryCode() {
-	m.country_code = nil
+// ResetSearchState resets all changes to the "search_state" field.
+func (m *ChatSessionMutation) ResetSearchState() {
+	m.search_state = nil
 }
 
-// SetLanguageCode sets the "language_code" field.
-func (m *SearchHistoryMutation) SetLanguageCode(s string) {
-	m.language_code = &s
+// SetCycleState sets the "cycle_state" field.
+func (m *ChatSessionMutation) SetCycleState(value map[string]interface{}) {
+	m.cycle_state = &value
 }
 
-// LanguageCode returns the value of the "language_code" field in the mutation.
-func (m *SearchHistoryMutation) LanguageCode() (r string, exists bool) {
-	v := m.language_code
+// CycleState returns the value of the "cycle_state" field in the mutation.
+func (m *ChatSessionMutation) CycleState() (r map[string]interface{}, exists bool) {
+	v := m.cycle_state
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLanguageCode returns the old "language_code" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldCycleState returns the old "cycle_state" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldLanguageCode(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldCycleState(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLanguageCode is only allowed on UpdateOne operations")
+		return v, errors.New("OldCycleState is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLanguageCode requires an ID field in the mutation")
+		return v, errors.New("OldCycleState requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLanguageCode: %w", err)
+		return v, fmt.Errorf("querying old value for OldCycleState: %w", err)
 	}
-	return oldValue.LanguageCode, nil
+	return oldValue.CycleState, nil
 }
 
-// ResetLanguageCode resets all changes to the "language_code" field.
-func (m *SearchHistoryMutation) ResetLanguageCode() {
-	m.language_code = nil
+// ResetCycleState resets all changes to the "cycle_state" field.
+func (m *ChatSessionMutation) ResetCycleState() {
+	m.cycle_state = nil
 }
 
-// SetCurrency sets the "currency" field.
-func (m *SearchHistoryMutation) SetCurrency(s string) {
-	m.currency = &s
+// SetConversationContext sets the "conversation_context" field.
+func (m *ChatSessionMutation) SetConversationContext(value map[string]interface{}) {
+	m.conversation_context = &value
 }
 
-// Currency returns the value of the "currency" field in the mutation.
-func (m *SearchHistoryMutation) Currency() (r string, exists bool) {
-	v := m.currency
+// ConversationContext returns the value of the "conversation_context" field in the mutation.
+func (m *ChatSessionMutation) ConversationContext() (r map[string]interface{}, exists bool) {
+	v := m.conversation_context
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCurrency returns the old "currency" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldConversationContext returns the old "conversation_context" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldCurrency(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldConversationContext(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
+		return v, errors.New("OldConversationContext is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCurrency requires an ID field in the mutation")
+		return v, errors.New("OldConversationContext requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
+		return v, fmt.Errorf("querying old value for OldConversationContext: %w", err)
 	}
-	return oldValue.Currency, nil
+	return oldValue.ConversationContext, nil
 }
 
-// ResetCurrency resets all changes to the "currency" field.
-func (m *SearchHistoryMutation) ResetCurrency() {
-	m.currency = nil
+// ClearConversationContext clears the value of the "conversation_context" field.
+func (m *ChatSessionMutation) ClearConversationContext() {
+	m.conversation_context = nil
+	m.clearedFields[chatsession.FieldConversationContext] = struct{}{}
 }
 
-// SetResultCount sets the "result_count" field.
-func (m *SearchHistoryMutation) SetResultCount(i int) {
-	m.result_count = &i
-	m.addresult_count = nil
+// ConversationContextCleared returns if the "conversation_context" field was cleared in this mutation.
+func (m *ChatSessionMutation) ConversationContextCleared() bool {
+	_, ok := m.clearedFields[chatsession.FieldConversationContext]
+	return ok
 }
 
-// ResultCount returns the value of the "result_count" field in the mutation.
-func (m *SearchHistoryMutation) ResultCount() (r int, exists bool) {
-	v := m.result_count
+// ResetConversationContext resets all changes to the "conversation_context" field.
+func (m *ChatSessionMutation) ResetConversationContext() {
+	m.conversation_context = nil
+	delete(m.clearedFields, chatsession.FieldConversationContext)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ChatSessionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ChatSessionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldResultCount returns the old "result_count" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldResultCount(ctx context.Context) (v int, err error) {
+func (m *ChatSessionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldResultCount is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldResultCount requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldResultCount: %w", err)
-	}
-	return oldValue.ResultCount, nil
-}
-
-// AddResultCount adds i to the "result_count" field.
-func (m *SearchHistoryMutation) AddResultCount(i int) {
-	if m.addresult_count != nil {
-		*m.addresult_count += i
-	} else {
-		m.addresult_count = &i
-	}
-}
-
-// AddedResultCount returns the value that was added to the "result_count" field in this mutation.
-func (m *SearchHistoryMutation) AddedResultCount() (r int, exists bool) {
-	v := m.addresult_count
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return *v, true
+	return oldValue.CreatedAt, nil
 }
 
-// ResetResultCount resets all changes to the "result_count" field.
-func (m *SearchHistoryMutation) ResetResultCount() {
-	m.result_count = nil
-	m.addresult_count = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ChatSessionMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetProductsFound sets the "products_found" field.
-func (m *SearchHistoryMutation) SetProductsFound(value []map[string]interface{}) {
-	m.products_found = &value
-	m.appendproducts_found = nil
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ChatSessionMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// ProductsFound returns the value of the "products_found" field in the mutation.
-func (m *SearchHistoryMutation) ProductsFound() (r []map[string]interface{}, exists bool) {
-	v := m.products_found
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ChatSessionMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProductsFound returns the old "products_found" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldProductsFound(ctx context.Context) (v []map[string]interface{}, err error) {
+func (m *ChatSessionMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProductsFound is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProductsFound requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProductsFound: %w", err)
-	}
-	return oldValue.ProductsFound, nil
-}
-
-// AppendProductsFound adds value to the "products_found" field.
-func (m *SearchHistoryMutation) AppendProductsFound(value []map[string]interface{}) {
-	m.appendproducts_found = append(m.appendproducts_found, value...)
-}
-
-// AppendedProductsFound returns the list of values that were appended to the "products_found" field in this mutation.
-func (m *SearchHistoryMutation) AppendedProductsFound() ([]map[string]interface{}, bool) {
-	if len(m.appendproducts_found) == 0 {
-		return nil, false
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return m.appendproducts_found, true
-}
-
-// ClearProductsFound clears the value of the "products_found" field.
-func (m *SearchHistoryMutation) ClearProductsFound() {
-	m.products_found = nil
-	m.appendproducts_found = nil
-	m.clearedFields[searchhistory.FieldProductsFound] = struct{}{}
-}
-
-// ProductsFoundCleared returns if the "products_found" field was cleared in this mutation.
-func (m *SearchHistoryMutation) ProductsFoundCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldProductsFound]
-	return ok
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetProductsFound resets all changes to the "products_found" field.
-func (m *SearchHistoryMutation) ResetProductsFound() {
-	m.products_found = nil
-	m.appendproducts_found = nil
-	delete(m.clearedFields, searchhistory.FieldProductsFound)
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ChatSessionMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// SetClickedProductID sets the "clicked_product_id" field.
-func (m *SearchHistoryMutation) SetClickedProductID(s string) {
-	m.clicked_product_id = &s
+// SetExpiresAt sets the "expires_at" field.
+func (m *ChatSessionMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// ClickedProductID returns the value of the "clicked_product_id" field in the mutation.
-func (m *SearchHistoryMutation) ClickedProductID() (r string, exists bool) {
-	v := m.clicked_product_id
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *ChatSessionMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClickedProductID returns the old "clicked_product_id" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldExpiresAt returns the old "expires_at" field's value of the ChatSession entity.
+// If the ChatSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldClickedProductID(ctx context.Context) (v string, err error) {
+func (m *ChatSessionMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClickedProductID is only allowed on UpdateOne operations")
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClickedProductID requires an ID field in the mutation")
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClickedProductID: %w", err)
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
-	return oldValue.ClickedProductID, nil
+	return oldValue.ExpiresAt, nil
 }
 
-// ClearClickedProductID clears the value of the "clicked_product_id" field.
-func (m *SearchHistoryMutation) ClearClickedProductID() {
-	m.clicked_product_id = nil
-	m.clearedFields[searchhistory.FieldClickedProductID] = struct{}{}
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *ChatSessionMutation) ResetExpiresAt() {
+	m.expires_at = nil
 }
 
-// ClickedProductIDCleared returns if the "clicked_product_id" field was cleared in this mutation.
-func (m *SearchHistoryMutation) ClickedProductIDCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldClickedProductID]
-	return ok
+// ClearUser clears the "user" edge to the User entity.
+func (m *ChatSessionMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[chatsession.FieldUserID] = struct{}{}
 }
 
-// ResetClickedProductID resets all changes to the "clicked_product_id" field.
-func (m *SearchHistoryMutation) ResetClickedProductID() {
-	m.clicked_product_id = nil
-	delete(m.clearedFields, searchhistory.FieldClickedProductID)
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *ChatSessionMutation) UserCleared() bool {
+	return m.UserIDCleared() || m.cleareduser
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *SearchHistoryMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *ChatSessionMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *SearchHistoryMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetUser resets all changes to the "user" edge.
+func (m *ChatSessionMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+// AddMessageIDs adds the "messages" edge to the Message entity by ids.
+func (m *ChatSessionMutation) AddMessageIDs(ids ...uuid.UUID) {
+	if m.messages == nil {
+		m.messages = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.messages[ids[i]] = struct{}{}
+	}
+}
+
+// ClearMessages clears the "messages" edge to the Message entity.
+func (m *ChatSessionMutation) ClearMessages() {
+	m.clearedmessages = true
+}
+
+// MessagesCleared reports if the "messages" edge to the Message entity was cleared.
+func (m *ChatSessionMutation) MessagesCleared() bool {
+	return m.clearedmessages
+}
+
+// RemoveMessageIDs removes the "messages" edge to the Message entity by IDs.
+func (m *ChatSessionMutation) RemoveMessageIDs(ids ...uuid.UUID) {
+	if m.removedmessages == nil {
+		m.removedmessages = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.messages, ids[i])
+		m.removedmessages[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedMessages returns the removed IDs of the "messages" edge to the Message entity.
+func (m *ChatSessionMutation) RemovedMessagesIDs() (ids []uuid.UUID) {
+	for id := range m.removedmessages {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// MessagesIDs returns the "messages" edge IDs in the mutation.
+func (m *ChatSessionMutation) MessagesIDs() (ids []uuid.UUID) {
+	for id := range m.messages {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetMessages resets all changes to the "messages" edge.
+func (m *ChatSessionMutation) ResetMessages() {
+	m.messages = nil
+	m.clearedmessages = false
+	m.removedmessages = nil
+}
+
+// Where appends a list predicates to the ChatSessionMutation builder.
+func (m *ChatSessionMutation) Where(ps ...predicate.ChatSession) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ChatSessionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ChatSessionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ChatSession, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ChatSessionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ChatSessionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ChatSession).
+func (m *ChatSessionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ChatSessionMutation) Fields() []string {
+	fields := make([]string, 0, 12)
+	if m.session_id != nil {
+		fields = append(fields, chatsession.FieldSessionID)
+	}
+	if m.user != nil {
+		fields = append(fields, chatsession.FieldUserID)
+	}
+	if m.country_code != nil {
+		fields = append(fields, chatsession.FieldCountryCode)
+	}
+	if m.language_code != nil {
+		fields = append(fields, chatsession.FieldLanguageCode)
+	}
+	if m.currency != nil {
+		fields = append(fields, chatsession.FieldCurrency)
+	}
+	if m.message_count != nil {
+		fields = append(fields, chatsession.FieldMessageCount)
+	}
+	if m.search_state != nil {
+		fields = append(fields, chatsession.FieldSearchState)
+	}
+	if m.cycle_state != nil {
+		fields = append(fields, chatsession.FieldCycleState)
+	}
+	if m.conversation_context != nil {
+		fields = append(fields, chatsession.FieldConversationContext)
+	}
+	if m.created_at != nil {
+		fields = append(fields, chatsession.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, chatsession.FieldUpdatedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, chatsession.FieldExpiresAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ChatSessionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case chatsession.FieldSessionID:
+		return m.SessionID()
+	case chatsession.FieldUserID:
+		return m.UserID()
+	case chatsession.FieldCountryCode:
+		return m.CountryCode()
+	case chatsession.FieldLanguageCode:
+		return m.LanguageCode()
+	case chatsession.FieldCurrency:
+		return m.Currency()
+	case chatsession.FieldMessageCount:
+		return m.MessageCount()
+	case chatsession.FieldSearchState:
+		return m.SearchState()
+	case chatsession.FieldCycleState:
+		return m.CycleState()
+	case chatsession.FieldConversationContext:
+		return m.ConversationContext()
+	case chatsession.FieldCreatedAt:
+		return m.CreatedAt()
+	case chatsession.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case chatsession.FieldExpiresAt:
+		return m.ExpiresAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ChatSessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case chatsession.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case chatsession.FieldUserID:
+		return m.OldUserID(ctx)
+	case chatsession.FieldCountryCode:
+		return m.OldCountryCode(ctx)
+	case chatsession.FieldLanguageCode:
+		return m.OldLanguageCode(ctx)
+	case chatsession.FieldCurrency:
+		return m.OldCurrency(ctx)
+	case chatsession.FieldMessageCount:
+		return m.OldMessageCount(ctx)
+	case chatsession.FieldSearchState:
+		return m.OldSearchState(ctx)
+	case chatsession.FieldCycleState:
+		return m.OldCycleState(ctx)
+	case chatsession.FieldConversationContext:
+		return m.OldConversationContext(ctx)
+	case chatsession.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case chatsession.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case chatsession.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown ChatSession field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ChatSessionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case chatsession.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case chatsession.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case chatsession.FieldCountryCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCountryCode(v)
+		return nil
+	case chatsession.FieldLanguageCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLanguageCode(v)
+		return nil
+	case chatsession.FieldCurrency:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCurrency(v)
+		return nil
+	case chatsession.FieldMessageCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMessageCount(v)
+		return nil
+	case chatsession.FieldSearchState:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSearchState(v)
+		return nil
+	case chatsession.FieldCycleState:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCycleState(v)
+		return nil
+	case chatsession.FieldConversationContext:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConversationContext(v)
+		return nil
+	case chatsession.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case chatsession.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case chatsession.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ChatSessionMutation) AddedFields() []string {
+	var fields []string
+	if m.addmessage_count != nil {
+		fields = append(fields, chatsession.FieldMessageCount)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ChatSessionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case chatsession.FieldMessageCount:
+		return m.AddedMessageCount()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ChatSessionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case chatsession.FieldMessageCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMessageCount(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ChatSessionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(chatsession.FieldUserID) {
+		fields = append(fields, chatsession.FieldUserID)
+	}
+	if m.FieldCleared(chatsession.FieldConversationContext) {
+		fields = append(fields, chatsession.FieldConversationContext)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ChatSessionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ChatSessionMutation) ClearField(name string) error {
+	switch name {
+	case chatsession.FieldUserID:
+		m.ClearUserID()
+		return nil
+	case chatsession.FieldConversationContext:
+		m.ClearConversationContext()
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ChatSessionMutation) ResetField(name string) error {
+	switch name {
+	case chatsession.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case chatsession.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case chatsession.FieldCountryCode:
+		m.ResetCountryCode()
+		return nil
+	case chatsession.FieldLanguageCode:
+		m.ResetLanguageCode()
+		return nil
+	case chatsession.FieldCurrency:
+		m.ResetCurrency()
+		return nil
+	case chatsession.FieldMessageCount:
+		m.ResetMessageCount()
+		return nil
+	case chatsession.FieldSearchState:
+		m.ResetSearchState()
+		return nil
+	case chatsession.FieldCycleState:
+		m.ResetCycleState()
+		return nil
+	case chatsession.FieldConversationContext:
+		m.ResetConversationContext()
+		return nil
+	case chatsession.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case chatsession.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case chatsession.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ChatSessionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.user != nil {
+		edges = append(edges, chatsession.EdgeUser)
+	}
+	if m.messages != nil {
+		edges = append(edges, chatsession.EdgeMessages)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ChatSessionMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case chatsession.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	case chatsession.EdgeMessages:
+		ids := make([]ent.Value, 0, len(m.messages))
+		for id := range m.messages {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ChatSessionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedmessages != nil {
+		edges = append(edges, chatsession.EdgeMessages)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ChatSessionMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case chatsession.EdgeMessages:
+		ids := make([]ent.Value, 0, len(m.removedmessages))
+		for id := range m.removedmessages {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ChatSessionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.cleareduser {
+		edges = append(edges, chatsession.EdgeUser)
+	}
+	if m.clearedmessages {
+		edges = append(edges, chatsession.EdgeMessages)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ChatSessionMutation) EdgeCleared(name string) bool {
+	switch name {
+	case chatsession.EdgeUser:
+		return m.cleareduser
+	case chatsession.EdgeMessages:
+		return m.clearedmessages
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ChatSessionMutation) ClearEdge(name string) error {
+	switch name {
+	case chatsession.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ChatSessionMutation) ResetEdge(name string) error {
+	switch name {
+	case chatsession.EdgeUser:
+		m.ResetUser()
+		return nil
+	case chatsession.EdgeMessages:
+		m.ResetMessages()
+		return nil
+	}
+	return fmt.Errorf("unknown ChatSession edge %s", name)
+}
+
+// CostRecordMutation represents an operation that mutates the CostRecord nodes in the graph.
+type CostRecordMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *uuid.UUID
+	request_id       *string
+	session_id       *string
+	user_id          *uuid.UUID
+	provider         *string
+	model            *string
+	purpose          *string
+	input_tokens     *int
+	addinput_tokens  *int
+	output_tokens    *int
+	addoutput_tokens *int
+	grounded         *bool
+	cost_usd         *float64
+	addcost_usd      *float64
+	created_at       *time.Time
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*CostRecord, error)
+	predicates       []predicate.CostRecord
+}
+
+var _ ent.Mutation = (*CostRecordMutation)(nil)
+
+// costrecordOption allows management of the mutation configuration using functional options.
+type costrecordOption func(*CostRecordMutation)
+
+// newCostRecordMutation creates new mutation for the CostRecord entity.
+func newCostRecordMutation(c config, op Op, opts ...costrecordOption) *CostRecordMutation {
+	m := &CostRecordMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeCostRecord,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withCostRecordID sets the ID field of the mutation.
+func withCostRecordID(id uuid.UUID) costrecordOption {
+	return func(m *CostRecordMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *CostRecord
+		)
+		m.oldValue = func(ctx context.Context) (*CostRecord, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().CostRecord.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withCostRecord sets the old CostRecord of the mutation.
+func withCostRecord(node *CostRecord) costrecordOption {
+	return func(m *CostRecordMutation) {
+		m.oldValue = func(context.Context) (*CostRecord, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m CostRecordMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m CostRecordMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of CostRecord entities.
+func (m *CostRecordMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *CostRecordMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *CostRecordMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().CostRecord.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetRequestID sets the "request_id" field.
+func (m *CostRecordMutation) SetRequestID(s string) {
+	m.request_id = &s
+}
+
+// RequestID returns the value of the "request_id" field in the mutation.
+func (m *CostRecordMutation) RequestID() (r string, exists bool) {
+	v := m.request_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequestID returns the old "request_id" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldRequestID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequestID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequestID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequestID: %w", err)
+	}
+	return oldValue.RequestID, nil
+}
+
+// ClearRequestID clears the value of the "request_id" field.
+func (m *CostRecordMutation) ClearRequestID() {
+	m.request_id = nil
+	m.clearedFields[costrecord.FieldRequestID] = struct{}{}
+}
+
+// RequestIDCleared returns if the "request_id" field was cleared in this mutation.
+func (m *CostRecordMutation) RequestIDCleared() bool {
+	_, ok := m.clearedFields[costrecord.FieldRequestID]
+	return ok
+}
+
+// ResetRequestID resets all changes to the "request_id" field.
+func (m *CostRecordMutation) ResetRequestID() {
+	m.request_id = nil
+	delete(m.clearedFields, costrecord.FieldRequestID)
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *CostRecordMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *CostRecordMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (m *CostRecordMutation) ClearSessionID() {
+	m.session_id = nil
+	m.clearedFields[costrecord.FieldSessionID] = struct{}{}
+}
+
+// SessionIDCleared returns if the "session_id" field was cleared in this mutation.
+func (m *CostRecordMutation) SessionIDCleared() bool {
+	_, ok := m.clearedFields[costrecord.FieldSessionID]
+	return ok
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *CostRecordMutation) ResetSessionID() {
+	m.session_id = nil
+	delete(m.clearedFields, costrecord.FieldSessionID)
+}
+
+// SetUserID sets the "user_id" field.
+func (m *CostRecordMutation) SetUserID(u uuid.UUID) {
+	m.user_id = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *CostRecordMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldUserID(ctx context.Context) (v *uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ClearUserID clears the value of the "user_id" field.
+func (m *CostRecordMutation) ClearUserID() {
+	m.user_id = nil
+	m.clearedFields[costrecord.FieldUserID] = struct{}{}
+}
+
+// UserIDCleared returns if the "user_id" field was cleared in this mutation.
+func (m *CostRecordMutation) UserIDCleared() bool {
+	_, ok := m.clearedFields[costrecord.FieldUserID]
+	return ok
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *CostRecordMutation) ResetUserID() {
+	m.user_id = nil
+	delete(m.clearedFields, costrecord.FieldUserID)
+}
+
+// SetProvider sets the "provider" field.
+func (m *CostRecordMutation) SetProvider(s string) {
+	m.provider = &s
+}
+
+// Provider returns the value of the "provider" field in the mutation.
+func (m *CostRecordMutation) Provider() (r string, exists bool) {
+	v := m.provider
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProvider returns the old "provider" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldProvider(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProvider requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+	}
+	return oldValue.Provider, nil
+}
+
+// ResetProvider resets all changes to the "provider" field.
+func (m *CostRecordMutation) ResetProvider() {
+	m.provider = nil
+}
+
+// SetModel sets the "model" field.
+func (m *CostRecordMutation) SetModel(s string) {
+	m.model = &s
+}
+
+// Model returns the value of the "model" field in the mutation.
+func (m *CostRecordMutation) Model() (r string, exists bool) {
+	v := m.model
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldModel returns the old "model" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldModel(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModel is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+	}
+	return oldValue.Model, nil
+}
+
+// ResetModel resets all changes to the "model" field.
+func (m *CostRecordMutation) ResetModel() {
+	m.model = nil
+}
+
+// SetPurpose sets the "purpose" field.
+func (m *CostRecordMutation) SetPurpose(s string) {
+	m.purpose = &s
+}
+
+// Purpose returns the value of the "purpose" field in the mutation.
+func (m *CostRecordMutation) Purpose() (r string, exists bool) {
+	v := m.purpose
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPurpose returns the old "purpose" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldPurpose(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPurpose is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPurpose requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPurpose: %w", err)
+	}
+	return oldValue.Purpose, nil
+}
+
+// ResetPurpose resets all changes to the "purpose" field.
+func (m *CostRecordMutation) ResetPurpose() {
+	m.purpose = nil
+}
+
+// SetInputTokens sets the "input_tokens" field.
+func (m *CostRecordMutation) SetInputTokens(i int) {
+	m.input_tokens = &i
+	m.addinput_tokens = nil
+}
+
+// InputTokens returns the value of the "input_tokens" field in the mutation.
+func (m *CostRecordMutation) InputTokens() (r int, exists bool) {
+	v := m.input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInputTokens returns the old "input_tokens" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldInputTokens(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInputTokens: %w", err)
+	}
+	return oldValue.InputTokens, nil
+}
+
+// AddInputTokens adds i to the "input_tokens" field.
+func (m *CostRecordMutation) AddInputTokens(i int) {
+	if m.addinput_tokens != nil {
+		*m.addinput_tokens += i
+	} else {
+		m.addinput_tokens = &i
+	}
+}
+
+// AddedInputTokens returns the value that was added to the "input_tokens" field in this mutation.
+func (m *CostRecordMutation) AddedInputTokens() (r int, exists bool) {
+	v := m.addinput_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetInputTokens resets all changes to the "input_tokens" field.
+func (m *CostRecordMutation) ResetInputTokens() {
+	m.input_tokens = nil
+	m.addinput_tokens = nil
+}
+
+// SetOutputTokens sets the "output_tokens" field.
+func (m *CostRecordMutation) SetOutputTokens(i int) {
+	m.output_tokens = &i
+	m.addoutput_tokens = nil
+}
+
+// OutputTokens returns the value of the "output_tokens" field in the mutation.
+func (m *CostRecordMutation) OutputTokens() (r int, exists bool) {
+	v := m.output_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOutputTokens returns the old "output_tokens" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldOutputTokens(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOutputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOutputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOutputTokens: %w", err)
+	}
+	return oldValue.OutputTokens, nil
+}
+
+// AddOutputTokens adds i to the "output_tokens" field.
+func (m *CostRecordMutation) AddOutputTokens(i int) {
+	if m.addoutput_tokens != nil {
+		*m.addoutput_tokens += i
+	} else {
+		m.addoutput_tokens = &i
+	}
+}
+
+// AddedOutputTokens returns the value that was added to the "output_tokens" field in this mutation.
+func (m *CostRecordMutation) AddedOutputTokens() (r int, exists bool) {
+	v := m.addoutput_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetOutputTokens resets all changes to the "output_tokens" field.
+func (m *CostRecordMutation) ResetOutputTokens() {
+	m.output_tokens = nil
+	m.addoutput_tokens = nil
+}
+
+// SetGrounded sets the "grounded" field.
+func (m *CostRecordMutation) SetGrounded(b bool) {
+	m.grounded = &b
+}
+
+// Grounded returns the value of the "grounded" field in the mutation.
+func (m *CostRecordMutation) Grounded() (r bool, exists bool) {
+	v := m.grounded
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldGrounded returns the old "grounded" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldGrounded(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGrounded is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGrounded requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGrounded: %w", err)
+	}
+	return oldValue.Grounded, nil
+}
+
+// ResetGrounded resets all changes to the "grounded" field.
+func (m *CostRecordMutation) ResetGrounded() {
+	m.grounded = nil
+}
+
+// SetCostUsd sets the "cost_usd" field.
+func (m *CostRecordMutation) SetCostUsd(f float64) {
+	m.cost_usd = &f
+	m.addcost_usd = nil
+}
+
+// CostUsd returns the value of the "cost_usd" field in the mutation.
+func (m *CostRecordMutation) CostUsd() (r float64, exists bool) {
+	v := m.cost_usd
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCostUsd returns the old "cost_usd" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldCostUsd(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCostUsd is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCostUsd requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCostUsd: %w", err)
+	}
+	return oldValue.CostUsd, nil
+}
+
+// AddCostUsd adds f to the "cost_usd" field.
+func (m *CostRecordMutation) AddCostUsd(f float64) {
+	if m.addcost_usd != nil {
+		*m.addcost_usd += f
+	} else {
+		m.addcost_usd = &f
+	}
+}
+
+// AddedCostUsd returns the value that was added to the "cost_usd" field in this mutation.
+func (m *CostRecordMutation) AddedCostUsd() (r float64, exists bool) {
+	v := m.addcost_usd
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCostUsd resets all changes to the "cost_usd" field.
+func (m *CostRecordMutation) ResetCostUsd() {
+	m.cost_usd = nil
+	m.addcost_usd = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *CostRecordMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *CostRecordMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the CostRecord entity.
+// If the CostRecord object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CostRecordMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *CostRecordMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the CostRecordMutation builder.
+func (m *CostRecordMutation) Where(ps ...predicate.CostRecord) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the CostRecordMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *CostRecordMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.CostRecord, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *CostRecordMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *CostRecordMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (CostRecord).
+func (m *CostRecordMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *CostRecordMutation) Fields() []string {
+	fields := make([]string, 0, 11)
+	if m.request_id != nil {
+		fields = append(fields, costrecord.FieldRequestID)
+	}
+	if m.session_id != nil {
+		fields = append(fields, costrecord.FieldSessionID)
+	}
+	if m.user_id != nil {
+		fields = append(fields, costrecord.FieldUserID)
+	}
+	if m.provider != nil {
+		fields = append(fields, costrecord.FieldProvider)
+	}
+	if m.model != nil {
+		fields = append(fields, costrecord.FieldModel)
+	}
+	if m.purpose != nil {
+		fields = append(fields, costrecord.FieldPurpose)
+	}
+	if m.input_tokens != nil {
+		fields = append(fields, costrecord.FieldInputTokens)
+	}
+	if m.output_tokens != nil {
+		fields = append(fields, costrecord.FieldOutputTokens)
+	}
+	if m.grounded != nil {
+		fields = append(fields, costrecord.FieldGrounded)
+	}
+	if m.cost_usd != nil {
+		fields = append(fields, costrecord.FieldCostUsd)
+	}
+	if m.created_at != nil {
+		fields = append(fields, costrecord.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *CostRecordMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case costrecord.FieldRequestID:
+		return m.RequestID()
+	case costrecord.FieldSessionID:
+		return m.SessionID()
+	case costrecord.FieldUserID:
+		return m.UserID()
+	case costrecord.FieldProvider:
+		return m.Provider()
+	case costrecord.FieldModel:
+		return m.Model()
+	case costrecord.FieldPurpose:
+		return m.Purpose()
+	case costrecord.FieldInputTokens:
+		return m.InputTokens()
+	case costrecord.FieldOutputTokens:
+		return m.OutputTokens()
+	case costrecord.FieldGrounded:
+		return m.Grounded()
+	case costrecord.FieldCostUsd:
+		return m.CostUsd()
+	case costrecord.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *CostRecordMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case costrecord.FieldRequestID:
+		return m.OldRequestID(ctx)
+	case costrecord.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case costrecord.FieldUserID:
+		return m.OldUserID(ctx)
+	case costrecord.FieldProvider:
+		return m.OldProvider(ctx)
+	case costrecord.FieldModel:
+		return m.OldModel(ctx)
+	case costrecord.FieldPurpose:
+		return m.OldPurpose(ctx)
+	case costrecord.FieldInputTokens:
+		return m.OldInputTokens(ctx)
+	case costrecord.FieldOutputTokens:
+		return m.OldOutputTokens(ctx)
+	case costrecord.FieldGrounded:
+		return m.OldGrounded(ctx)
+	case costrecord.FieldCostUsd:
+		return m.OldCostUsd(ctx)
+	case costrecord.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown CostRecord field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *CostRecordMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case costrecord.FieldRequestID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequestID(v)
+		return nil
+	case costrecord.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case costrecord.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case costrecord.FieldProvider:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProvider(v)
+		return nil
+	case costrecord.FieldModel:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModel(v)
+		return nil
+	case costrecord.FieldPurpose:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPurpose(v)
+		return nil
+	case costrecord.FieldInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInputTokens(v)
+		return nil
+	case costrecord.FieldOutputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOutputTokens(v)
+		return nil
+	case costrecord.FieldGrounded:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGrounded(v)
+		return nil
+	case costrecord.FieldCostUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCostUsd(v)
+		return nil
+	case costrecord.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown CostRecord field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *CostRecordMutation) AddedFields() []string {
+	var fields []string
+	if m.addinput_tokens != nil {
+		fields = append(fields, costrecord.FieldInputTokens)
+	}
+	if m.addoutput_tokens != nil {
+		fields = append(fields, costrecord.FieldOutputTokens)
+	}
+	if m.addcost_usd != nil {
+		fields = append(fields, costrecord.FieldCostUsd)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *CostRecordMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case costrecord.FieldInputTokens:
+		return m.AddedInputTokens()
+	case costrecord.FieldOutputTokens:
+		return m.AddedOutputTokens()
+	case costrecord.FieldCostUsd:
+		return m.AddedCostUsd()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *CostRecordMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case costrecord.FieldInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddInputTokens(v)
+		return nil
+	case costrecord.FieldOutputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOutputTokens(v)
+		return nil
+	case costrecord.FieldCostUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCostUsd(v)
+		return nil
+	}
+	return fmt.Errorf("unknown CostRecord numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *CostRecordMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(costrecord.FieldRequestID) {
+		fields = append(fields, costrecord.FieldRequestID)
+	}
+	if m.FieldCleared(costrecord.FieldSessionID) {
+		fields = append(fields, costrecord.FieldSessionID)
+	}
+	if m.FieldCleared(costrecord.FieldUserID) {
+		fields = append(fields, costrecord.FieldUserID)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *CostRecordMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *CostRecordMutation) ClearField(name string) error {
+	switch name {
+	case costrecord.FieldRequestID:
+		m.ClearRequestID()
+		return nil
+	case costrecord.FieldSessionID:
+		m.ClearSessionID()
+		return nil
+	case costrecord.FieldUserID:
+		m.ClearUserID()
+		return nil
+	}
+	return fmt.Errorf("unknown CostRecord nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *CostRecordMutation) ResetField(name string) error {
+	switch name {
+	case costrecord.FieldRequestID:
+		m.ResetRequestID()
+		return nil
+	case costrecord.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case costrecord.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case costrecord.FieldProvider:
+		m.ResetProvider()
+		return nil
+	case costrecord.FieldModel:
+		m.ResetModel()
+		return nil
+	case costrecord.FieldPurpose:
+		m.ResetPurpose()
+		return nil
+	case costrecord.FieldInputTokens:
+		m.ResetInputTokens()
+		return nil
+	case costrecord.FieldOutputTokens:
+		m.ResetOutputTokens()
+		return nil
+	case costrecord.FieldGrounded:
+		m.ResetGrounded()
+		return nil
+	case costrecord.FieldCostUsd:
+		m.ResetCostUsd()
+		return nil
+	case costrecord.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown CostRecord field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *CostRecordMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *CostRecordMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *CostRecordMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *CostRecordMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *CostRecordMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *CostRecordMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *CostRecordMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown CostRecord unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *CostRecordMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown CostRecord edge %s", name)
+}
+
+// JobRunMutation represents an operation that mutates the JobRun nodes in the graph.
+type JobRunMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *uuid.UUID
+	job              *string
+	trigger          *string
+	triggered_by     *string
+	instance         *string
+	status           *string
+	rows_affected    *int64
+	addrows_affected *int64
+	error            *string
+	duration_ms      *int64
+	addduration_ms   *int64
+	started_at       *time.Time
+	finished_at      *time.Time
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*JobRun, error)
+	predicates       []predicate.JobRun
+}
+
+var _ ent.Mutation = (*JobRunMutation)(nil)
+
+// jobrunOption allows management of the mutation configuration using functional options.
+type jobrunOption func(*JobRunMutation)
+
+// newJobRunMutation creates new mutation for the JobRun entity.
+func newJobRunMutation(c config, op Op, opts ...jobrunOption) *JobRunMutation {
+	m := &JobRunMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeJobRun,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withJobRunID sets the ID field of the mutation.
+func withJobRunID(id uuid.UUID) jobrunOption {
+	return func(m *JobRunMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *JobRun
+		)
+		m.oldValue = func(ctx context.Context) (*JobRun, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().JobRun.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withJobRun sets the old JobRun of the mutation.
+func withJobRun(node *JobRun) jobrunOption {
+	return func(m *JobRunMutation) {
+		m.oldValue = func(context.Context) (*JobRun, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m JobRunMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m JobRunMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of JobRun entities.
+func (m *JobRunMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *JobRunMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *JobRunMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().JobRun.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetJob sets the "job" field.
+func (m *JobRunMutation) SetJob(s string) {
+	m.job = &s
+}
+
+// Job returns the value of the "job" field in the mutation.
+func (m *JobRunMutation) Job() (r string, exists bool) {
+	v := m.job
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldJob returns the old "job" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldJob(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldJob is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldJob requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldJob: %w", err)
+	}
+	return oldValue.Job, nil
+}
+
+// ResetJob resets all changes to the "job" field.
+func (m *JobRunMutation) ResetJob() {
+	m.job = nil
+}
+
+// SetTrigger sets the "trigger" field.
+func (m *JobRunMutation) SetTrigger(s string) {
+	m.trigger = &s
+}
+
+// Trigger returns the value of the "trigger" field in the mutation.
+func (m *JobRunMutation) Trigger() (r string, exists bool) {
+	v := m.trigger
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTrigger returns the old "trigger" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldTrigger(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTrigger is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTrigger requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTrigger: %w", err)
+	}
+	return oldValue.Trigger, nil
+}
+
+// ResetTrigger resets all changes to the "trigger" field.
+func (m *JobRunMutation) ResetTrigger() {
+	m.trigger = nil
+}
+
+// SetTriggeredBy sets the "triggered_by" field.
+func (m *JobRunMutation) SetTriggeredBy(s string) {
+	m.triggered_by = &s
+}
+
+// TriggeredBy returns the value of the "triggered_by" field in the mutation.
+func (m *JobRunMutation) TriggeredBy() (r string, exists bool) {
+	v := m.triggered_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTriggeredBy returns the old "triggered_by" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldTriggeredBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTriggeredBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTriggeredBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTriggeredBy: %w", err)
+	}
+	return oldValue.TriggeredBy, nil
+}
+
+// ClearTriggeredBy clears the value of the "triggered_by" field.
+func (m *JobRunMutation) ClearTriggeredBy() {
+	m.triggered_by = nil
+	m.clearedFields[jobrun.FieldTriggeredBy] = struct{}{}
+}
+
+// TriggeredByCleared returns if the "triggered_by" field was cleared in this mutation.
+func (m *JobRunMutation) TriggeredByCleared() bool {
+	_, ok := m.clearedFields[jobrun.FieldTriggeredBy]
+	return ok
+}
+
+// ResetTriggeredBy resets all changes to the "triggered_by" field.
+func (m *JobRunMutation) ResetTriggeredBy() {
+	m.triggered_by = nil
+	delete(m.clearedFields, jobrun.FieldTriggeredBy)
+}
+
+// SetInstance sets the "instance" field.
+func (m *JobRunMutation) SetInstance(s string) {
+	m.instance = &s
+}
+
+// Instance returns the value of the "instance" field in the mutation.
+func (m *JobRunMutation) Instance() (r string, exists bool) {
+	v := m.instance
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInstance returns the old "instance" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldInstance(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInstance is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInstance requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInstance: %w", err)
+	}
+	return oldValue.Instance, nil
+}
+
+// ResetInstance resets all changes to the "instance" field.
+func (m *JobRunMutation) ResetInstance() {
+	m.instance = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *JobRunMutation) SetStatus(s string) {
+	m.status = &s
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *JobRunMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *JobRunMutation) ResetStatus() {
+	m.status = nil
+}
+
+// SetRowsAffected sets the "rows_affected" field.
+func (m *JobRunMutation) SetRowsAffected(i int64) {
+	m.rows_affected = &i
+	m.addrows_affected = nil
+}
+
+// RowsAffected returns the value of the "rows_affected" field in the mutation.
+func (m *JobRunMutation) RowsAffected() (r int64, exists bool) {
+	v := m.rows_affected
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRowsAffected returns the old "rows_affected" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldRowsAffected(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRowsAffected is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRowsAffected requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRowsAffected: %w", err)
+	}
+	return oldValue.RowsAffected, nil
+}
+
+// AddRowsAffected adds i to the "rows_affected" field.
+func (m *JobRunMutation) AddRowsAffected(i int64) {
+	if m.addrows_affected != nil {
+		*m.addrows_affected += i
+	} else {
+		m.addrows_affected = &i
+	}
+}
+
+// AddedRowsAffected returns the value that was added to the "rows_affected" field in this mutation.
+func (m *JobRunMutation) AddedRowsAffected() (r int64, exists bool) {
+	v := m.addrows_affected
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRowsAffected resets all changes to the "rows_affected" field.
+func (m *JobRunMutation) ResetRowsAffected() {
+	m.rows_affected = nil
+	m.addrows_affected = nil
+}
+
+// SetError sets the "error" field.
+func (m *JobRunMutation) SetError(s string) {
+	m.error = &s
+}
+
+// Error returns the value of the "error" field in the mutation.
+func (m *JobRunMutation) Error() (r string, exists bool) {
+	v := m.error
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldError returns the old "error" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldError(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldError is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldError requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldError: %w", err)
+	}
+	return oldValue.Error, nil
+}
+
+// ClearError clears the value of the "error" field.
+func (m *JobRunMutation) ClearError() {
+	m.error = nil
+	m.clearedFields[jobrun.FieldError] = struct{}{}
+}
+
+// ErrorCleared returns if the "error" field was cleared in this mutation.
+func (m *JobRunMutation) ErrorCleared() bool {
+	_, ok := m.clearedFields[jobrun.FieldError]
+	return ok
+}
+
+// ResetError resets all changes to the "error" field.
+func (m *JobRunMutation) ResetError() {
+	m.error = nil
+	delete(m.clearedFields, jobrun.FieldError)
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (m *JobRunMutation) SetDurationMs(i int64) {
+	m.duration_ms = &i
+	m.addduration_ms = nil
+}
+
+// DurationMs returns the value of the "duration_ms" field in the mutation.
+func (m *JobRunMutation) DurationMs() (r int64, exists bool) {
+	v := m.duration_ms
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDurationMs returns the old "duration_ms" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldDurationMs(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDurationMs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDurationMs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDurationMs: %w", err)
+	}
+	return oldValue.DurationMs, nil
+}
+
+// AddDurationMs adds i to the "duration_ms" field.
+func (m *JobRunMutation) AddDurationMs(i int64) {
+	if m.addduration_ms != nil {
+		*m.addduration_ms += i
+	} else {
+		m.addduration_ms = &i
+	}
+}
+
+// AddedDurationMs returns the value that was added to the "duration_ms" field in this mutation.
+func (m *JobRunMutation) AddedDurationMs() (r int64, exists bool) {
+	v := m.addduration_ms
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDurationMs resets all changes to the "duration_ms" field.
+func (m *JobRunMutation) ResetDurationMs() {
+	m.duration_ms = nil
+	m.addduration_ms = nil
+}
+
+// SetStartedAt sets the "started_at" field.
+func (m *JobRunMutation) SetStartedAt(t time.Time) {
+	m.started_at = &t
+}
+
+// StartedAt returns the value of the "started_at" field in the mutation.
+func (m *JobRunMutation) StartedAt() (r time.Time, exists bool) {
+	v := m.started_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStartedAt returns the old "started_at" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldStartedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStartedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStartedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStartedAt: %w", err)
+	}
+	return oldValue.StartedAt, nil
+}
+
+// ResetStartedAt resets all changes to the "started_at" field.
+func (m *JobRunMutation) ResetStartedAt() {
+	m.started_at = nil
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (m *JobRunMutation) SetFinishedAt(t time.Time) {
+	m.finished_at = &t
+}
+
+// FinishedAt returns the value of the "finished_at" field in the mutation.
+func (m *JobRunMutation) FinishedAt() (r time.Time, exists bool) {
+	v := m.finished_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFinishedAt returns the old "finished_at" field's value of the JobRun entity.
+// If the JobRun object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobRunMutation) OldFinishedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFinishedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFinishedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFinishedAt: %w", err)
+	}
+	return oldValue.FinishedAt, nil
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (m *JobRunMutation) ClearFinishedAt() {
+	m.finished_at = nil
+	m.clearedFields[jobrun.FieldFinishedAt] = struct{}{}
+}
+
+// FinishedAtCleared returns if the "finished_at" field was cleared in this mutation.
+func (m *JobRunMutation) FinishedAtCleared() bool {
+	_, ok := m.clearedFields[jobrun.FieldFinishedAt]
+	return ok
+}
+
+// ResetFinishedAt resets all changes to the "finished_at" field.
+func (m *JobRunMutation) ResetFinishedAt() {
+	m.finished_at = nil
+	delete(m.clearedFields, jobrun.FieldFinishedAt)
+}
+
+// Where appends a list predicates to the JobRunMutation builder.
+func (m *JobRunMutation) Where(ps ...predicate.JobRun) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the JobRunMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *JobRunMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.JobRun, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *JobRunMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *JobRunMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (JobRun).
+func (m *JobRunMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *JobRunMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.job != nil {
+		fields = append(fields, jobrun.FieldJob)
+	}
+	if m.trigger != nil {
+		fields = append(fields, jobrun.FieldTrigger)
+	}
+	if m.triggered_by != nil {
+		fields = append(fields, jobrun.FieldTriggeredBy)
+	}
+	if m.instance != nil {
+		fields = append(fields, jobrun.FieldInstance)
+	}
+	if m.status != nil {
+		fields = append(fields, jobrun.FieldStatus)
+	}
+	if m.rows_affected != nil {
+		fields = append(fields, jobrun.FieldRowsAffected)
+	}
+	if m.error != nil {
+		fields = append(fields, jobrun.FieldError)
+	}
+	if m.duration_ms != nil {
+		fields = append(fields, jobrun.FieldDurationMs)
+	}
+	if m.started_at != nil {
+		fields = append(fields, jobrun.FieldStartedAt)
+	}
+	if m.finished_at != nil {
+		fields = append(fields, jobrun.FieldFinishedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *JobRunMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case jobrun.FieldJob:
+		return m.Job()
+	case jobrun.FieldTrigger:
+		return m.Trigger()
+	case jobrun.FieldTriggeredBy:
+		return m.TriggeredBy()
+	case jobrun.FieldInstance:
+		return m.Instance()
+	case jobrun.FieldStatus:
+		return m.Status()
+	case jobrun.FieldRowsAffected:
+		return m.RowsAffected()
+	case jobrun.FieldError:
+		return m.Error()
+	case jobrun.FieldDurationMs:
+		return m.DurationMs()
+	case jobrun.FieldStartedAt:
+		return m.StartedAt()
+	case jobrun.FieldFinishedAt:
+		return m.FinishedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *JobRunMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case jobrun.FieldJob:
+		return m.OldJob(ctx)
+	case jobrun.FieldTrigger:
+		return m.OldTrigger(ctx)
+	case jobrun.FieldTriggeredBy:
+		return m.OldTriggeredBy(ctx)
+	case jobrun.FieldInstance:
+		return m.OldInstance(ctx)
+	case jobrun.FieldStatus:
+		return m.OldStatus(ctx)
+	case jobrun.FieldRowsAffected:
+		return m.OldRowsAffected(ctx)
+	case jobrun.FieldError:
+		return m.OldError(ctx)
+	case jobrun.FieldDurationMs:
+		return m.OldDurationMs(ctx)
+	case jobrun.FieldStartedAt:
+		return m.OldStartedAt(ctx)
+	case jobrun.FieldFinishedAt:
+		return m.OldFinishedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown JobRun field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *JobRunMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case jobrun.FieldJob:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetJob(v)
+		return nil
+	case jobrun.FieldTrigger:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTrigger(v)
+		return nil
+	case jobrun.FieldTriggeredBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTriggeredBy(v)
+		return nil
+	case jobrun.FieldInstance:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInstance(v)
+		return nil
+	case jobrun.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case jobrun.FieldRowsAffected:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRowsAffected(v)
+		return nil
+	case jobrun.FieldError:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetError(v)
+		return nil
+	case jobrun.FieldDurationMs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDurationMs(v)
+		return nil
+	case jobrun.FieldStartedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStartedAt(v)
+		return nil
+	case jobrun.FieldFinishedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFinishedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown JobRun field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *JobRunMutation) AddedFields() []string {
+	var fields []string
+	if m.addrows_affected != nil {
+		fields = append(fields, jobrun.FieldRowsAffected)
+	}
+	if m.addduration_ms != nil {
+		fields = append(fields, jobrun.FieldDurationMs)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *JobRunMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case jobrun.FieldRowsAffected:
+		return m.AddedRowsAffected()
+	case jobrun.FieldDurationMs:
+		return m.AddedDurationMs()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *JobRunMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case jobrun.FieldRowsAffected:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRowsAffected(v)
+		return nil
+	case jobrun.FieldDurationMs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDurationMs(v)
+		return nil
+	}
+	return fmt.Errorf("unknown JobRun numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *JobRunMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(jobrun.FieldTriggeredBy) {
+		fields = append(fields, jobrun.FieldTriggeredBy)
+	}
+	if m.FieldCleared(jobrun.FieldError) {
+		fields = append(fields, jobrun.FieldError)
+	}
+	if m.FieldCleared(jobrun.FieldFinishedAt) {
+		fields = append(fields, jobrun.FieldFinishedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *JobRunMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *JobRunMutation) ClearField(name string) error {
+	switch name {
+	case jobrun.FieldTriggeredBy:
+		m.ClearTriggeredBy()
+		return nil
+	case jobrun.FieldError:
+		m.ClearError()
+		return nil
+	case jobrun.FieldFinishedAt:
+		m.ClearFinishedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown JobRun nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *JobRunMutation) ResetField(name string) error {
+	switch name {
+	case jobrun.FieldJob:
+		m.ResetJob()
+		return nil
+	case jobrun.FieldTrigger:
+		m.ResetTrigger()
+		return nil
+	case jobrun.FieldTriggeredBy:
+		m.ResetTriggeredBy()
+		return nil
+	case jobrun.FieldInstance:
+		m.ResetInstance()
+		return nil
+	case jobrun.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case jobrun.FieldRowsAffected:
+		m.ResetRowsAffected()
+		return nil
+	case jobrun.FieldError:
+		m.ResetError()
+		return nil
+	case jobrun.FieldDurationMs:
+		m.ResetDurationMs()
+		return nil
+	case jobrun.FieldStartedAt:
+		m.ResetStartedAt()
+		return nil
+	case jobrun.FieldFinishedAt:
+		m.ResetFinishedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown JobRun field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *JobRunMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *JobRunMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *JobRunMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *JobRunMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *JobRunMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *JobRunMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *JobRunMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown JobRun unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *JobRunMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown JobRun edge %s", name)
+}
+
+// MessageMutation represents an operation that mutates the Message nodes in the graph.
+type MessageMutation struct {
+	config
+	op                  Op
+	typ                 string
+	id                  *uuid.UUID
+	role                *string
+	content             *string
+	response_type       *string
+	quick_replies       *[]string
+	appendquick_replies []string
+	products            *[]map[string]interface{}
+	appendproducts      []map[string]interface{}
+	search_info         *map[string]interface{}
+	created_at          *time.Time
+	clearedFields       map[string]struct{}
+	session             *uuid.UUID
+	clearedsession      bool
+	done                bool
+	oldValue            func(context.Context) (*Message, error)
+	predicates          []predicate.Message
+}
+
+var _ ent.Mutation = (*MessageMutation)(nil)
+
+// messageOption allows management of the mutation configuration using functional options.
+type messageOption func(*MessageMutation)
+
+// newMessageMutation creates new mutation for the Message entity.
+func newMessageMutation(c config, op Op, opts ...messageOption) *MessageMutation {
+	m := &MessageMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeMessage,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withMessageID sets the ID field of the mutation.
+func withMessageID(id uuid.UUID) messageOption {
+	return func(m *MessageMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Message
+		)
+		m.oldValue = func(ctx context.Context) (*Message, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Message.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withMessage sets the old Message of the mutation.
+func withMessage(node *Message) messageOption {
+	return func(m *MessageMutation) {
+		m.oldValue = func(context.Context) (*Message, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m MessageMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m MessageMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Message entities.
+func (m *MessageMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *MessageMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *MessageMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Message.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *MessageMutation) SetSessionID(u uuid.UUID) {
+	m.session = &u
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *MessageMutation) SessionID() (r uuid.UUID, exists bool) {
+	v := m.session
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldSessionID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *MessageMutation) ResetSessionID() {
+	m.session = nil
+}
+
+// SetRole sets the "role" field.
+func (m *MessageMutation) SetRole(s string) {
+	m.role = &s
+}
+
+// Role returns the value of the "role" field in the mutation.
+func (m *MessageMutation) Role() (r string, exists bool) {
+	v := m.role
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRole returns the old "role" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldRole(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRole requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+	}
+	return oldValue.Role, nil
+}
+
+// ResetRole resets all changes to the "role" field.
+func (m *MessageMutation) ResetRole() {
+	m.role = nil
+}
+
+// SetContent sets the "content" field.
+func (m *MessageMutation) SetContent(s string) {
+	m.content = &s
+}
+
+// Content returns the value of the "content" field in the mutation.
+func (m *MessageMutation) Content() (r string, exists bool) {
+	v := m.content
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldContent returns the old "content" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldContent(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldContent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldContent: %w", err)
+	}
+	return oldValue.Content, nil
+}
+
+// ResetContent resets all changes to the "content" field.
+func (m *MessageMutation) ResetContent() {
+	m.content = nil
+}
+
+// SetResponseType sets the "response_type" field.
+func (m *MessageMutation) SetResponseType(s string) {
+	m.response_type = &s
+}
+
+// ResponseType returns the value of the "response_type" field in the mutation.
+func (m *MessageMutation) ResponseType() (r string, exists bool) {
+	v := m.response_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResponseType returns the old "response_type" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldResponseType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResponseType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResponseType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResponseType: %w", err)
+	}
+	return oldValue.ResponseType, nil
+}
+
+// ClearResponseType clears the value of the "response_type" field.
+func (m *MessageMutation) ClearResponseType() {
+	m.response_type = nil
+	m.clearedFields[message.FieldResponseType] = struct{}{}
+}
+
+// ResponseTypeCleared returns if the "response_type" field was cleared in this mutation.
+func (m *MessageMutation) ResponseTypeCleared() bool {
+	_, ok := m.clearedFields[message.FieldResponseType]
+	return ok
+}
+
+// ResetResponseType resets all changes to the "response_type" field.
+func (m *MessageMutation) ResetResponseType() {
+	m.response_type = nil
+	delete(m.clearedFields, message.FieldResponseType)
+}
+
+// SetQuickReplies sets the "quick_replies" field.
+func (m *MessageMutation) SetQuickReplies(s []string) {
+	m.quick_replies = &s
+	m.appendquick_replies = nil
+}
+
+// QuickReplies returns the value of the "quick_replies" field in the mutation.
+func (m *MessageMutation) QuickReplies() (r []string, exists bool) {
+	v := m.quick_replies
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuickReplies returns the old "quick_replies" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldQuickReplies(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuickReplies is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuickReplies requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuickReplies: %w", err)
+	}
+	return oldValue.QuickReplies, nil
+}
+
+// AppendQuickReplies adds s to the "quick_replies" field.
+func (m *MessageMutation) AppendQuickReplies(s []string) {
+	m.appendquick_replies = append(m.appendquick_replies, s...)
+}
+
+// AppendedQuickReplies returns the list of values that were appended to the "quick_replies" field in this mutation.
+func (m *MessageMutation) AppendedQuickReplies() ([]string, bool) {
+	if len(m.appendquick_replies) == 0 {
+		return nil, false
+	}
+	return m.appendquick_replies, true
+}
+
+// ClearQuickReplies clears the value of the "quick_replies" field.
+func (m *MessageMutation) ClearQuickReplies() {
+	m.quick_replies = nil
+	m.appendquick_replies = nil
+	m.clearedFields[message.FieldQuickReplies] = struct{}{}
+}
+
+// QuickRepliesCleared returns if the "quick_replies" field was cleared in this mutation.
+func (m *MessageMutation) QuickRepliesCleared() bool {
+	_, ok := m.clearedFields[message.FieldQuickReplies]
+	return ok
+}
+
+// ResetQuickReplies resets all changes to the "quick_replies" field.
+func (m *MessageMutation) ResetQuickReplies() {
+	m.quick_replies = nil
+	m.appendquick_replies = nil
+	delete(m.clearedFields, message.FieldQuickReplies)
+}
+
+// SetProducts sets the "products" field.
+func (m *MessageMutation) SetProducts(value []map[string]interface{}) {
+	m.products = &value
+	m.appendproducts = nil
+}
+
+// Products returns the value of the "products" field in the mutation.
+func (m *MessageMutation) Products() (r []map[string]interface{}, exists bool) {
+	v := m.products
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProducts returns the old "products" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldProducts(ctx context.Context) (v []map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProducts is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProducts requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProducts: %w", err)
+	}
+	return oldValue.Products, nil
+}
+
+// AppendProducts adds value to the "products" field.
+func (m *MessageMutation) AppendProducts(value []map[string]interface{}) {
+	m.appendproducts = append(m.appendproducts, value...)
+}
+
+// AppendedProducts returns the list of values that were appended to the "products" field in this mutation.
+func (m *MessageMutation) AppendedProducts() ([]map[string]interface{}, bool) {
+	if len(m.appendproducts) == 0 {
+		return nil, false
+	}
+	return m.appendproducts, true
+}
+
+// ClearProducts clears the value of the "products" field.
+func (m *MessageMutation) ClearProducts() {
+	m.products = nil
+	m.appendproducts = nil
+	m.clearedFields[message.FieldProducts] = struct{}{}
+}
+
+// ProductsCleared returns if the "products" field was cleared in this mutation.
+func (m *MessageMutation) ProductsCleared() bool {
+	_, ok := m.clearedFields[message.FieldProducts]
+	return ok
+}
+
+// ResetProducts resets all changes to the "products" field.
+func (m *MessageMutation) ResetProducts() {
+	m.products = nil
+	m.appendproducts = nil
+	delete(m.clearedFields, message.FieldProducts)
+}
+
+// SetSearchInfo sets the "search_info" field.
+func (m *MessageMutation) SetSearchInfo(value map[string]interface{}) {
+	m.search_info = &value
+}
+
+// SearchInfo returns the value of the "search_info" field in the mutation.
+func (m *MessageMutation) SearchInfo() (r map[string]interface{}, exists bool) {
+	v := m.search_info
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSearchInfo returns the old "search_info" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldSearchInfo(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSearchInfo is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSearchInfo requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSearchInfo: %w", err)
+	}
+	return oldValue.SearchInfo, nil
+}
+
+// ClearSearchInfo clears the value of the "search_info" field.
+func (m *MessageMutation) ClearSearchInfo() {
+	m.search_info = nil
+	m.clearedFields[message.FieldSearchInfo] = struct{}{}
+}
+
+// SearchInfoCleared returns if the "search_info" field was cleared in this mutation.
+func (m *MessageMutation) SearchInfoCleared() bool {
+	_, ok := m.clearedFields[message.FieldSearchInfo]
+	return ok
+}
+
+// ResetSearchInfo resets all changes to the "search_info" field.
+func (m *MessageMutation) ResetSearchInfo() {
+	m.search_info = nil
+	delete(m.clearedFields, message.FieldSearchInfo)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *MessageMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *MessageMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *MessageMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearSession clears the "session" edge to the ChatSession entity.
+func (m *MessageMutation) ClearSession() {
+	m.clearedsession = true
+	m.clearedFields[message.FieldSessionID] = struct{}{}
+}
+
+// SessionCleared reports if the "session" edge to the ChatSession entity was cleared.
+func (m *MessageMutation) SessionCleared() bool {
+	return m.clearedsession
+}
+
+// SessionIDs returns the "session" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// SessionID instead. It exists only for internal usage by the builders.
+func (m *MessageMutation) SessionIDs() (ids []uuid.UUID) {
+	if id := m.session; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetSession resets all changes to the "session" edge.
+func (m *MessageMutation) ResetSession() {
+	m.session = nil
+	m.clearedsession = false
+}
+
+// Where appends a list predicates to the MessageMutation builder.
+func (m *MessageMutation) Where(ps ...predicate.Message) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the MessageMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *MessageMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Message, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *MessageMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *MessageMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Message).
+func (m *MessageMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *MessageMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.session != nil {
+		fields = append(fields, message.FieldSessionID)
+	}
+	if m.role != nil {
+		fields = append(fields, message.FieldRole)
+	}
+	if m.content != nil {
+		fields = append(fields, message.FieldContent)
+	}
+	if m.response_type != nil {
+		fields = append(fields, message.FieldResponseType)
+	}
+	if m.quick_replies != nil {
+		fields = append(fields, message.FieldQuickReplies)
+	}
+	if m.products != nil {
+		fields = append(fields, message.FieldProducts)
+	}
+	if m.search_info != nil {
+		fields = append(fields, message.FieldSearchInfo)
+	}
+	if m.created_at != nil {
+		fields = append(fields, message.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *MessageMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case message.FieldSessionID:
+		return m.SessionID()
+	case message.FieldRole:
+		return m.Role()
+	case message.FieldContent:
+		return m.Content()
+	case message.FieldResponseType:
+		return m.ResponseType()
+	case message.FieldQuickReplies:
+		return m.QuickReplies()
+	case message.FieldProducts:
+		return m.Products()
+	case message.FieldSearchInfo:
+		return m.SearchInfo()
+	case message.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *MessageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case message.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case message.FieldRole:
+		return m.OldRole(ctx)
+	case message.FieldContent:
+		return m.OldContent(ctx)
+	case message.FieldResponseType:
+		return m.OldResponseType(ctx)
+	case message.FieldQuickReplies:
+		return m.OldQuickReplies(ctx)
+	case message.FieldProducts:
+		return m.OldProducts(ctx)
+	case message.FieldSearchInfo:
+		return m.OldSearchInfo(ctx)
+	case message.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Message field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *MessageMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case message.FieldSessionID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case message.FieldRole:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRole(v)
+		return nil
+	case message.FieldContent:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContent(v)
+		return nil
+	case message.FieldResponseType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseType(v)
+		return nil
+	case message.FieldQuickReplies:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuickReplies(v)
+		return nil
+	case message.FieldProducts:
+		v, ok := value.([]map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProducts(v)
+		return nil
+	case message.FieldSearchInfo:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSearchInfo(v)
+		return nil
+	case message.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Message field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *MessageMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *MessageMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *MessageMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Message numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *MessageMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(message.FieldResponseType) {
+		fields = append(fields, message.FieldResponseType)
+	}
+	if m.FieldCleared(message.FieldQuickReplies) {
+		fields = append(fields, message.FieldQuickReplies)
+	}
+	if m.FieldCleared(message.FieldProducts) {
+		fields = append(fields, message.FieldProducts)
+	}
+	if m.FieldCleared(message.FieldSearchInfo) {
+		fields = append(fields, message.FieldSearchInfo)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *MessageMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *MessageMutation) ClearField(name string) error {
+	switch name {
+	case message.FieldResponseType:
+		m.ClearResponseType()
+		return nil
+	case message.FieldQuickReplies:
+		m.ClearQuickReplies()
+		return nil
+	case message.FieldProducts:
+		m.ClearProducts()
+		return nil
+	case message.FieldSearchInfo:
+		m.ClearSearchInfo()
+		return nil
+	}
+	return fmt.Errorf("unknown Message nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *MessageMutation) ResetField(name string) error {
+	switch name {
+	case message.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case message.FieldRole:
+		m.ResetRole()
+		return nil
+	case message.FieldContent:
+		m.ResetContent()
+		return nil
+	case message.FieldResponseType:
+		m.ResetResponseType()
+		return nil
+	case message.FieldQuickReplies:
+		m.ResetQuickReplies()
+		return nil
+	case message.FieldProducts:
+		m.ResetProducts()
+		return nil
+	case message.FieldSearchInfo:
+		m.ResetSearchInfo()
+		return nil
+	case message.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Message field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *MessageMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.session != nil {
+		edges = append(edges, message.EdgeSession)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *MessageMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case message.EdgeSession:
+		if id := m.session; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *MessageMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *MessageMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *MessageMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedsession {
+		edges = append(edges, message.EdgeSession)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *MessageMutation) EdgeCleared(name string) bool {
+	switch name {
+	case message.EdgeSession:
+		return m.clearedsession
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *MessageMutation) ClearEdge(name string) error {
+	switch name {
+	case message.EdgeSession:
+		m.ClearSession()
+		return nil
+	}
+	return fmt.Errorf("unknown Message unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *MessageMutation) ResetEdge(name string) error {
+	switch name {
+	case message.EdgeSession:
+		m.ResetSession()
+		return nil
+	}
+	return fmt.Errorf("unknown Message edge %s", name)
+}
+
+// PriceObservationMutation represents an operation that mutates the PriceObservation nodes in the graph.
+type PriceObservationMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	product_key   *string
+	product_name  *string
+	merchant      *string
+	price         *float64
+	addprice      *float64
+	currency      *string
+	country_code  *string
+	source        *string
+	observed_at   *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*PriceObservation, error)
+	predicates    []predicate.PriceObservation
+}
+
+var _ ent.Mutation = (*PriceObservationMutation)(nil)
+
+// priceobservationOption allows management of the mutation configuration using functional options.
+type priceobservationOption func(*PriceObservationMutation)
+
+// newPriceObservationMutation creates new mutation for the PriceObservation entity.
+func newPriceObservationMutation(c config, op Op, opts ...priceobservationOption) *PriceObservationMutation {
+	m := &PriceObservationMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePriceObservation,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPriceObservationID sets the ID field of the mutation.
+func withPriceObservationID(id uuid.UUID) priceobservationOption {
+	return func(m *PriceObservationMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PriceObservation
+		)
+		m.oldValue = func(ctx context.Context) (*PriceObservation, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PriceObservation.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPriceObservation sets the old PriceObservation of the mutation.
+func withPriceObservation(node *PriceObservation) priceobservationOption {
+	return func(m *PriceObservationMutation) {
+		m.oldValue = func(context.Context) (*PriceObservation, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PriceObservationMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PriceObservationMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of PriceObservation entities.
+func (m *PriceObservationMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PriceObservationMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PriceObservationMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PriceObservation.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetProductKey sets the "product_key" field.
+func (m *PriceObservationMutation) SetProductKey(s string) {
+	m.product_key = &s
+}
+
+// ProductKey returns the value of the "product_key" field in the mutation.
+func (m *PriceObservationMutation) ProductKey() (r string, exists bool) {
+	v := m.product_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProductKey returns the old "product_key" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldProductKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProductKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProductKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProductKey: %w", err)
+	}
+	return oldValue.ProductKey, nil
+}
+
+// ResetProductKey resets all changes to the "product_key" field.
+func (m *PriceObservationMutation) ResetProductKey() {
+	m.product_key = nil
+}
+
+// SetProductName sets the "product_name" field.
+func (m *PriceObservationMutation) SetProductName(s string) {
+	m.product_name = &s
+}
+
+// ProductName returns the value of the "product_name" field in the mutation.
+func (m *PriceObservationMutation) ProductName() (r string, exists bool) {
+	v := m.product_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProductName returns the old "product_name" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldProductName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProductName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProductName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProductName: %w", err)
+	}
+	return oldValue.ProductName, nil
+}
+
+// ResetProductName resets all changes to the "product_name" field.
+func (m *PriceObservationMutation) ResetProductName() {
+	m.product_name = nil
+}
+
+// SetMerchant sets the "merchant" field.
+func (m *PriceObservationMutation) SetMerchant(s string) {
+	m.merchant = &s
+}
+
+// Merchant returns the value of the "merchant" field in the mutation.
+func (m *PriceObservationMutation) Merchant() (r string, exists bool) {
+	v := m.merchant
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMerchant returns the old "merchant" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldMerchant(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMerchant is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMerchant requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMerchant: %w", err)
+	}
+	return oldValue.Merchant, nil
+}
+
+// ClearMerchant clears the value of the "merchant" field.
+func (m *PriceObservationMutation) ClearMerchant() {
+	m.merchant = nil
+	m.clearedFields[priceobservation.FieldMerchant] = struct{}{}
+}
+
+// MerchantCleared returns if the "merchant" field was cleared in this mutation.
+func (m *PriceObservationMutation) MerchantCleared() bool {
+	_, ok := m.clearedFields[priceobservation.FieldMerchant]
+	return ok
+}
+
+// ResetMerchant resets all changes to the "merchant" field.
+func (m *PriceObservationMutation) ResetMerchant() {
+	m.merchant = nil
+	delete(m.clearedFields, priceobservation.FieldMerchant)
+}
+
+// SetPrice sets the "price" field.
+func (m *PriceObservationMutation) SetPrice(f float64) {
+	m.price = &f
+	m.addprice = nil
+}
+
+// Price returns the value of the "price" field in the mutation.
+func (m *PriceObservationMutation) Price() (r float64, exists bool) {
+	v := m.price
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrice returns the old "price" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldPrice(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrice is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrice requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrice: %w", err)
+	}
+	return oldValue.Price, nil
+}
+
+// AddPrice adds f to the "price" field.
+func (m *PriceObservationMutation) AddPrice(f float64) {
+	if m.addprice != nil {
+		*m.addprice += f
+	} else {
+		m.addprice = &f
+	}
+}
+
+// AddedPrice returns the value that was added to the "price" field in this mutation.
+func (m *PriceObservationMutation) AddedPrice() (r float64, exists bool) {
+	v := m.addprice
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPrice resets all changes to the "price" field.
+func (m *PriceObservationMutation) ResetPrice() {
+	m.price = nil
+	m.addprice = nil
+}
+
+// SetCurrency sets the "currency" field.
+func (m *PriceObservationMutation) SetCurrency(s string) {
+	m.currency = &s
+}
+
+// Currency returns the value of the "currency" field in the mutation.
+func (m *PriceObservationMutation) Currency() (r string, exists bool) {
+	v := m.currency
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCurrency returns the old "currency" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldCurrency(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCurrency requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
+	}
+	return oldValue.Currency, nil
+}
+
+// ResetCurrency resets all changes to the "currency" field.
+func (m *PriceObservationMutation) ResetCurrency() {
+	m.currency = nil
+}
+
+// SetCountryCode sets the "country_code" field.
+func (m *PriceObservationMutation) SetCountryCode(s string) {
+	m.country_code = &s
+}
+
+// CountryCode returns the value of the "country_code" field in the mutation.
+func (m *PriceObservationMutation) CountryCode() (r string, exists bool) {
+	v := m.country_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCountryCode returns the old "country_code" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldCountryCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCountryCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
+	}
+	return oldValue.CountryCode, nil
+}
+
+// ResetCountryCode resets all changes to the "country_code" field.
+func (m *PriceObservationMutation) ResetCountryCode() {
+	m.country_code = nil
+}
+
+// SetSource sets the "source" field.
+func (m *PriceObservationMutation) SetSource(s string) {
+	m.source = &s
+}
+
+// Source returns the value of the "source" field in the mutation.
+func (m *PriceObservationMutation) Source() (r string, exists bool) {
+	v := m.source
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSource returns the old "source" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldSource(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSource is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSource requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSource: %w", err)
+	}
+	return oldValue.Source, nil
+}
+
+// ResetSource resets all changes to the "source" field.
+func (m *PriceObservationMutation) ResetSource() {
+	m.source = nil
+}
+
+// SetObservedAt sets the "observed_at" field.
+func (m *PriceObservationMutation) SetObservedAt(t time.Time) {
+	m.observed_at = &t
+}
+
+// ObservedAt returns the value of the "observed_at" field in the mutation.
+func (m *PriceObservationMutation) ObservedAt() (r time.Time, exists bool) {
+	v := m.observed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldObservedAt returns the old "observed_at" field's value of the PriceObservation entity.
+// If the PriceObservation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PriceObservationMutation) OldObservedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldObservedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldObservedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldObservedAt: %w", err)
+	}
+	return oldValue.ObservedAt, nil
+}
+
+// ResetObservedAt resets all changes to the "observed_at" field.
+func (m *PriceObservationMutation) ResetObservedAt() {
+	m.observed_at = nil
+}
+
+// Where appends a list predicates to the PriceObservationMutation builder.
+func (m *PriceObservationMutation) Where(ps ...predicate.PriceObservation) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PriceObservationMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PriceObservationMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PriceObservation, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PriceObservationMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PriceObservationMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PriceObservation).
+func (m *PriceObservationMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PriceObservationMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.product_key != nil {
+		fields = append(fields, priceobservation.FieldProductKey)
+	}
+	if m.product_name != nil {
+		fields = append(fields, priceobservation.FieldProductName)
+	}
+	if m.merchant != nil {
+		fields = append(fields, priceobservation.FieldMerchant)
+	}
+	if m.price != nil {
+		fields = append(fields, priceobservation.FieldPrice)
+	}
+	if m.currency != nil {
+		fields = append(fields, priceobservation.FieldCurrency)
+	}
+	if m.country_code != nil {
+		fields = append(fields, priceobservation.FieldCountryCode)
+	}
+	if m.source != nil {
+		fields = append(fields, priceobservation.FieldSource)
+	}
+	if m.observed_at != nil {
+		fields = append(fields, priceobservation.FieldObservedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PriceObservationMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case priceobservation.FieldProductKey:
+		return m.ProductKey()
+	case priceobservation.FieldProductName:
+		return m.ProductName()
+	case priceobservation.FieldMerchant:
+		return m.Merchant()
+	case priceobservation.FieldPrice:
+		return m.Price()
+	case priceobservation.FieldCurrency:
+		return m.Currency()
+	case priceobservation.FieldCountryCode:
+		return m.CountryCode()
+	case priceobservation.FieldSource:
+		return m.Source()
+	case priceobservation.FieldObservedAt:
+		return m.ObservedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PriceObservationMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case priceobservation.FieldProductKey:
+		return m.OldProductKey(ctx)
+	case priceobservation.FieldProductName:
+		return m.OldProductName(ctx)
+	case priceobservation.FieldMerchant:
+		return m.OldMerchant(ctx)
+	case priceobservation.FieldPrice:
+		return m.OldPrice(ctx)
+	case priceobservation.FieldCurrency:
+		return m.OldCurrency(ctx)
+	case priceobservation.FieldCountryCode:
+		return m.OldCountryCode(ctx)
+	case priceobservation.FieldSource:
+		return m.OldSource(ctx)
+	case priceobservation.FieldObservedAt:
+		return m.OldObservedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PriceObservation field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PriceObservationMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case priceobservation.FieldProductKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProductKey(v)
+		return nil
+	case priceobservation.FieldProductName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProductName(v)
+		return nil
+	case priceobservation.FieldMerchant:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMerchant(v)
+		return nil
+	case priceobservation.FieldPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrice(v)
+		return nil
+	case priceobservation.FieldCurrency:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCurrency(v)
+		return nil
+	case priceobservation.FieldCountryCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCountryCode(v)
+		return nil
+	case priceobservation.FieldSource:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSource(v)
+		return nil
+	case priceobservation.FieldObservedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetObservedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PriceObservation field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PriceObservationMutation) AddedFields() []string {
+	var fields []string
+	if m.addprice != nil {
+		fields = append(fields, priceobservation.FieldPrice)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PriceObservationMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case priceobservation.FieldPrice:
+		return m.AddedPrice()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PriceObservationMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case priceobservation.FieldPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPrice(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PriceObservation numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PriceObservationMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(priceobservation.FieldMerchant) {
+		fields = append(fields, priceobservation.FieldMerchant)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PriceObservationMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PriceObservationMutation) ClearField(name string) error {
+	switch name {
+	case priceobservation.FieldMerchant:
+		m.ClearMerchant()
+		return nil
+	}
+	return fmt.Errorf("unknown PriceObservation nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PriceObservationMutation) ResetField(name string) error {
+	switch name {
+	case priceobservation.FieldProductKey:
+		m.ResetProductKey()
+		return nil
+	case priceobservation.FieldProductName:
+		m.ResetProductName()
+		return nil
+	case priceobservation.FieldMerchant:
+		m.ResetMerchant()
+		return nil
+	case priceobservation.FieldPrice:
+		m.ResetPrice()
+		return nil
+	case priceobservation.FieldCurrency:
+		m.ResetCurrency()
+		return nil
+	case priceobservation.FieldCountryCode:
+		m.ResetCountryCode()
+		return nil
+	case priceobservation.FieldSource:
+		m.ResetSource()
+		return nil
+	case priceobservation.FieldObservedAt:
+		m.ResetObservedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PriceObservation field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PriceObservationMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PriceObservationMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PriceObservationMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PriceObservationMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PriceObservationMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PriceObservationMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PriceObservationMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown PriceObservation unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PriceObservationMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown PriceObservation edge %s", name)
+}
+
+// SearchHistoryMutation represents an operation that mutates the SearchHistory nodes in the graph.
+type SearchHistoryMutation struct {
+	config
+	op                   Op
+	typ                  string
+	id                   *uuid.UUID
+	session_id           *string
+	search_query         *string
+	optimized_query      *string
+	search_type          *string
+	category             *string
+	country_code         *string
+	language_code        *string
+	currency             *string
+	result_count         *int
+	addresult_count      *int
+	products_found       *[]map[string]interface{}
+	appendproducts_found []map[string]interface{}
+	clicked_product_id   *string
+	created_at           *time.Time
+	expires_at           *time.Time
+	clearedFields        map[string]struct{}
+	user                 *uuid.UUID
+	cleareduser          bool
+	done                 bool
+	oldValue             func(context.Context) (*SearchHistory, error)
+	predicates           []predicate.SearchHistory
+}
+
+var _ ent.Mutation = (*SearchHistoryMutation)(nil)
+
+// searchhistoryOption allows management of the mutation configuration using functional options.
+type searchhistoryOption func(*SearchHistoryMutation)
+
+// newSearchHistoryMutation creates new mutation for the SearchHistory entity.
+func newSearchHistoryMutation(c config, op Op, opts ...searchhistoryOption) *SearchHistoryMutation {
+	m := &SearchHistoryMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSearchHistory,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSearchHistoryID sets the ID field of the mutation.
+func withSearchHistoryID(id uuid.UUID) searchhistoryOption {
+	return func(m *SearchHistoryMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SearchHistory
+		)
+		m.oldValue = func(ctx context.Context) (*SearchHistory, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SearchHistory.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSearchHistory sets the old SearchHistory of the mutation.
+func withSearchHistory(node *SearchHistory) searchhistoryOption {
+	return func(m *SearchHistoryMutation) {
+		m.oldValue = func(context.Context) (*SearchHistory, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SearchHistoryMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SearchHistoryMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of SearchHistory entities.
+func (m *SearchHistoryMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SearchHistoryMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SearchHistoryMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SearchHistory.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *SearchHistoryMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *SearchHistoryMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ClearUserID clears the value of the "user_id" field.
+func (m *SearchHistoryMutation) ClearUserID() {
+	m.user = nil
+	m.clearedFields[searchhistory.FieldUserID] = struct{}{}
+}
+
+// UserIDCleared returns if the "user_id" field was cleared in this mutation.
+func (m *SearchHistoryMutation) UserIDCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldUserID]
+	return ok
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *SearchHistoryMutation) ResetUserID() {
+	m.user = nil
+	delete(m.clearedFields, searchhistory.FieldUserID)
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *SearchHistoryMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *SearchHistoryMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (m *SearchHistoryMutation) ClearSessionID() {
+	m.session_id = nil
+	m.clearedFields[searchhistory.FieldSessionID] = struct{}{}
+}
+
+// SessionIDCleared returns if the "session_id" field was cleared in this mutation.
+func (m *SearchHistoryMutation) SessionIDCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldSessionID]
+	return ok
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *SearchHistoryMutation) ResetSessionID() {
+	m.session_id = nil
+	delete(m.clearedFields, searchhistory.FieldSessionID)
+}
+
+// SetSearchQuery sets the "search_query" field.
+func (m *SearchHistoryMutation) SetSearchQuery(s string) {
+	m.search_query = &s
+}
+
+// SearchQuery returns the value of the "search_query" field in the mutation.
+func (m *SearchHistoryMutation) SearchQuery() (r string, exists bool) {
+	v := m.search_query
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSearchQuery returns the old "search_query" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldSearchQuery(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSearchQuery is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSearchQuery requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSearchQuery: %w", err)
+	}
+	return oldValue.SearchQuery, nil
+}
+
+// ResetSearchQuery resets all changes to the "search_query" field.
+func (m *SearchHistoryMutation) ResetSearchQuery() {
+	m.search_query = nil
+}
+
+// SetOptimizedQuery sets the "optimized_query" field.
+func (m *SearchHistoryMutation) SetOptimizedQuery(s string) {
+	m.optimized_query = &s
+}
+
+// OptimizedQuery returns the value of the "optimized_query" field in the mutation.
+func (m *SearchHistoryMutation) OptimizedQuery() (r string, exists bool) {
+	v := m.optimized_query
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOptimizedQuery returns the old "optimized_query" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldOptimizedQuery(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOptimizedQuery is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOptimizedQuery requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOptimizedQuery: %w", err)
+	}
+	return oldValue.OptimizedQuery, nil
+}
+
+// ClearOptimizedQuery clears the value of the "optimized_query" field.
+func (m *SearchHistoryMutation) ClearOptimizedQuery() {
+	m.optimized_query = nil
+	m.clearedFields[searchhistory.FieldOptimizedQuery] = struct{}{}
+}
+
+// OptimizedQueryCleared returns if the "optimized_query" field was cleared in this mutation.
+func (m *SearchHistoryMutation) OptimizedQueryCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldOptimizedQuery]
+	return ok
+}
+
+// ResetOptimizedQuery resets all changes to the "optimized_query" field.
+func (m *SearchHistoryMutation) ResetOptimizedQuery() {
+	m.optimized_query = nil
+	delete(m.clearedFields, searchhistory.FieldOptimizedQuery)
+}
+
+// SetSearchType sets the "search_type" field.
+func (m *SearchHistoryMutation) SetSearchType(s string) {
+	m.search_type = &s
+}
+
+// SearchType returns the value of the "search_type" field in the mutation.
+func (m *SearchHistoryMutation) SearchType() (r string, exists bool) {
+	v := m.search_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSearchType returns the old "search_type" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldSearchType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSearchType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSearchType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSearchType: %w", err)
+	}
+	return oldValue.SearchType, nil
+}
+
+// ResetSearchType resets all changes to the "search_type" field.
+func (m *SearchHistoryMutation) ResetSearchType() {
+	m.search_type = nil
+}
+
+// SetCategory sets the "category" field.
+func (m *SearchHistoryMutation) SetCategory(s string) {
+	m.category = &s
+}
+
+// Category returns the value of the "category" field in the mutation.
+func (m *SearchHistoryMutation) Category() (r string, exists bool) {
+	v := m.category
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCategory returns the old "category" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldCategory(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCategory is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCategory requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCategory: %w", err)
+	}
+	return oldValue.Category, nil
+}
+
+// ClearCategory clears the value of the "category" field.
+func (m *SearchHistoryMutation) ClearCategory() {
+	m.category = nil
+	m.clearedFields[searchhistory.FieldCategory] = struct{}{}
+}
+
+// CategoryCleared returns if the "category" field was cleared in this mutation.
+func (m *SearchHistoryMutation) CategoryCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldCategory]
+	return ok
+}
+
+// ResetCategory resets all changes to the "category" field.
+func (m *SearchHistoryMutation) ResetCategory() {
+	m.category = nil
+	delete(m.clearedFields, searchhistory.FieldCategory)
+}
+
+// SetCountryCode sets the "country_code" field.
+func (m *SearchHistoryMutation) SetCountryCode(s string) {
+	m.country_code = &s
+}
+
+// CountryCode returns the value of the "country_code" field in the mutation.
+func (m *SearchHistoryMutation) CountryCode() (r string, exists bool) {
+	v := m.country_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCountryCode returns the old "country_code" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldCountryCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCountryCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
+	}
+	return oldValue.CountryCode, nil
+}
+
+// ResetCountryCode resets all changes to the "country_code" field.
+func (m *SearchHistoryMutation) ResetCountryCode() {
+	m.country_code = nil
+}
+
+// SetLanguageCode sets the "language_code" field.
+func (m *SearchHistoryMutation) SetLanguageCode(s string) {
+	m.language_code = &s
+}
+
+// LanguageCode returns the value of the "language_code" field in the mutation.
+func (m *SearchHistoryMutation) LanguageCode() (r string, exists bool) {
+	v := m.language_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLanguageCode returns the old "language_code" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldLanguageCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLanguageCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLanguageCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLanguageCode: %w", err)
+	}
+	return oldValue.LanguageCode, nil
+}
+
+// ResetLanguageCode resets all changes to the "language_code" field.
+func (m *SearchHistoryMutation) ResetLanguageCode() {
+	m.language_code = nil
+}
+
+// SetCurrency sets the "currency" field.
+func (m *SearchHistoryMutation) SetCurrency(s string) {
+	m.currency = &s
+}
+
+// Currency returns the value of the "currency" field in the mutation.
+func (m *SearchHistoryMutation) Currency() (r string, exists bool) {
+	v := m.currency
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCurrency returns the old "currency" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldCurrency(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCurrency requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
+	}
+	return oldValue.Currency, nil
+}
+
+// ResetCurrency resets all changes to the "currency" field.
+func (m *SearchHistoryMutation) ResetCurrency() {
+	m.currency = nil
+}
+
+// SetResultCount sets the "result_count" field.
+func (m *SearchHistoryMutation) SetResultCount(i int) {
+	m.result_count = &i
+	m.addresult_count = nil
+}
+
+// ResultCount returns the value of the "result_count" field in the mutation.
+func (m *SearchHistoryMutation) ResultCount() (r int, exists bool) {
+	v := m.result_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResultCount returns the old "result_count" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldResultCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResultCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResultCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResultCount: %w", err)
+	}
+	return oldValue.ResultCount, nil
+}
+
+// AddResultCount adds i to the "result_count" field.
+func (m *SearchHistoryMutation) AddResultCount(i int) {
+	if m.addresult_count != nil {
+		*m.addresult_count += i
+	} else {
+		m.addresult_count = &i
+	}
+}
+
+// AddedResultCount returns the value that was added to the "result_count" field in this mutation.
+func (m *SearchHistoryMutation) AddedResultCount() (r int, exists bool) {
+	v := m.addresult_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetResultCount resets all changes to the "result_count" field.
+func (m *SearchHistoryMutation) ResetResultCount() {
+	m.result_count = nil
+	m.addresult_count = nil
+}
+
+// SetProductsFound sets the "products_found" field.
+func (m *SearchHistoryMutation) SetProductsFound(value []map[string]interface{}) {
+	m.products_found = &value
+	m.appendproducts_found = nil
+}
+
+// ProductsFound returns the value of the "products_found" field in the mutation.
+func (m *SearchHistoryMutation) ProductsFound() (r []map[string]interface{}, exists bool) {
+	v := m.products_found
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProductsFound returns the old "products_found" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldProductsFound(ctx context.Context) (v []map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProductsFound is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProductsFound requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProductsFound: %w", err)
+	}
+	return oldValue.ProductsFound, nil
+}
+
+// AppendProductsFound adds value to the "products_found" field.
+func (m *SearchHistoryMutation) AppendProductsFound(value []map[string]interface{}) {
+	m.appendproducts_found = append(m.appendproducts_found, value...)
+}
+
+// AppendedProductsFound returns the list of values that were appended to the "products_found" field in this mutation.
+func (m *SearchHistoryMutation) AppendedProductsFound() ([]map[string]interface{}, bool) {
+	if len(m.appendproducts_found) == 0 {
+		return nil, false
+	}
+	return m.appendproducts_found, true
+}
+
+// ClearProductsFound clears the value of the "products_found" field.
+func (m *SearchHistoryMutation) ClearProductsFound() {
+	m.products_found = nil
+	m.appendproducts_found = nil
+	m.clearedFields[searchhistory.FieldProductsFound] = struct{}{}
+}
+
+// ProductsFoundCleared returns if the "products_found" field was cleared in this mutation.
+func (m *SearchHistoryMutation) ProductsFoundCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldProductsFound]
+	return ok
+}
+
+// ResetProductsFound resets all changes to the "products_found" field.
+func (m *SearchHistoryMutation) ResetProductsFound() {
+	m.products_found = nil
+	m.appendproducts_found = nil
+	delete(m.clearedFields, searchhistory.FieldProductsFound)
+}
+
+// SetClickedProductID sets the "clicked_product_id" field.
+func (m *SearchHistoryMutation) SetClickedProductID(s string) {
+	m.clicked_product_id = &s
+}
+
+// ClickedProductID returns the value of the "clicked_product_id" field in the mutation.
+func (m *SearchHistoryMutation) ClickedProductID() (r string, exists bool) {
+	v := m.clicked_product_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClickedProductID returns the old "clicked_product_id" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldClickedProductID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClickedProductID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClickedProductID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClickedProductID: %w", err)
+	}
+	return oldValue.ClickedProductID, nil
+}
+
+// ClearClickedProductID clears the value of the "clicked_product_id" field.
+func (m *SearchHistoryMutation) ClearClickedProductID() {
+	m.clicked_product_id = nil
+	m.clearedFields[searchhistory.FieldClickedProductID] = struct{}{}
+}
+
+// ClickedProductIDCleared returns if the "clicked_product_id" field was cleared in this mutation.
+func (m *SearchHistoryMutation) ClickedProductIDCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldClickedProductID]
+	return ok
+}
+
+// ResetClickedProductID resets all changes to the "clicked_product_id" field.
+func (m *SearchHistoryMutation) ResetClickedProductID() {
+	m.clicked_product_id = nil
+	delete(m.clearedFields, searchhistory.FieldClickedProductID)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *SearchHistoryMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SearchHistoryMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
 func (m *SearchHistoryMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
@@ -2766,74 +7109,4321 @@ func (m *SearchHistoryMutation) OldCreatedAt(ctx context.Context) (v time.Time,
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *SearchHistoryMutation) ResetCreatedAt() {
+func (m *SearchHistoryMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *SearchHistoryMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *SearchHistoryMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expires_at" field's value of the SearchHistory entity.
+// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SearchHistoryMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *SearchHistoryMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[searchhistory.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *SearchHistoryMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[searchhistory.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *SearchHistoryMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, searchhistory.FieldExpiresAt)
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *SearchHistoryMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[searchhistory.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *SearchHistoryMutation) UserCleared() bool {
+	return m.UserIDCleared() || m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *SearchHistoryMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *SearchHistoryMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the SearchHistoryMutation builder.
+func (m *SearchHistoryMutation) Where(ps ...predicate.SearchHistory) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SearchHistoryMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SearchHistoryMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SearchHistory, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SearchHistoryMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SearchHistoryMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SearchHistory).
+func (m *SearchHistoryMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SearchHistoryMutation) Fields() []string {
+	fields := make([]string, 0, 14)
+	if m.user != nil {
+		fields = append(fields, searchhistory.FieldUserID)
+	}
+	if m.session_id != nil {
+		fields = append(fields, searchhistory.FieldSessionID)
+	}
+	if m.search_query != nil {
+		fields = append(fields, searchhistory.FieldSearchQuery)
+	}
+	if m.optimized_query != nil {
+		fields = append(fields, searchhistory.FieldOptimizedQuery)
+	}
+	if m.search_type != nil {
+		fields = append(fields, searchhistory.FieldSearchType)
+	}
+	if m.category != nil {
+		fields = append(fields, searchhistory.FieldCategory)
+	}
+	if m.country_code != nil {
+		fields = append(fields, searchhistory.FieldCountryCode)
+	}
+	if m.language_code != nil {
+		fields = append(fields, searchhistory.FieldLanguageCode)
+	}
+	if m.currency != nil {
+		fields = append(fields, searchhistory.FieldCurrency)
+	}
+	if m.result_count != nil {
+		fields = append(fields, searchhistory.FieldResultCount)
+	}
+	if m.products_found != nil {
+		fields = append(fields, searchhistory.FieldProductsFound)
+	}
+	if m.clicked_product_id != nil {
+		fields = append(fields, searchhistory.FieldClickedProductID)
+	}
+	if m.created_at != nil {
+		fields = append(fields, searchhistory.FieldCreatedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, searchhistory.FieldExpiresAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SearchHistoryMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case searchhistory.FieldUserID:
+		return m.UserID()
+	case searchhistory.FieldSessionID:
+		return m.SessionID()
+	case searchhistory.FieldSearchQuery:
+		return m.SearchQuery()
+	case searchhistory.FieldOptimizedQuery:
+		return m.OptimizedQuery()
+	case searchhistory.FieldSearchType:
+		return m.SearchType()
+	case searchhistory.FieldCategory:
+		return m.Category()
+	case searchhistory.FieldCountryCode:
+		return m.CountryCode()
+	case searchhistory.FieldLanguageCode:
+		return m.LanguageCode()
+	case searchhistory.FieldCurrency:
+		return m.Currency()
+	case searchhistory.FieldResultCount:
+		return m.ResultCount()
+	case searchhistory.FieldProductsFound:
+		return m.ProductsFound()
+	case searchhistory.FieldClickedProductID:
+		return m.ClickedProductID()
+	case searchhistory.FieldCreatedAt:
+		return m.CreatedAt()
+	case searchhistory.FieldExpiresAt:
+		return m.ExpiresAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SearchHistoryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case searchhistory.FieldUserID:
+		return m.OldUserID(ctx)
+	case searchhistory.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case searchhistory.FieldSearchQuery:
+		return m.OldSearchQuery(ctx)
+	case searchhistory.FieldOptimizedQuery:
+		return m.OldOptimizedQuery(ctx)
+	case searchhistory.FieldSearchType:
+		return m.OldSearchType(ctx)
+	case searchhistory.FieldCategory:
+		return m.OldCategory(ctx)
+	case searchhistory.FieldCountryCode:
+		return m.OldCountryCode(ctx)
+	case searchhistory.FieldLanguageCode:
+		return m.OldLanguageCode(ctx)
+	case searchhistory.FieldCurrency:
+		return m.OldCurrency(ctx)
+	case searchhistory.FieldResultCount:
+		return m.OldResultCount(ctx)
+	case searchhistory.FieldProductsFound:
+		return m.OldProductsFound(ctx)
+	case searchhistory.FieldClickedProductID:
+		return m.OldClickedProductID(ctx)
+	case searchhistory.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case searchhistory.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SearchHistory field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SearchHistoryMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case searchhistory.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case searchhistory.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case searchhistory.FieldSearchQuery:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSearchQuery(v)
+		return nil
+	case searchhistory.FieldOptimizedQuery:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOptimizedQuery(v)
+		return nil
+	case searchhistory.FieldSearchType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSearchType(v)
+		return nil
+	case searchhistory.FieldCategory:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCategory(v)
+		return nil
+	case searchhistory.FieldCountryCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCountryCode(v)
+		return nil
+	case searchhistory.FieldLanguageCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLanguageCode(v)
+		return nil
+	case searchhistory.FieldCurrency:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCurrency(v)
+		return nil
+	case searchhistory.FieldResultCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResultCount(v)
+		return nil
+	case searchhistory.FieldProductsFound:
+		v, ok := value.([]map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProductsFound(v)
+		return nil
+	case searchhistory.FieldClickedProductID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClickedProductID(v)
+		return nil
+	case searchhistory.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case searchhistory.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SearchHistoryMutation) AddedFields() []string {
+	var fields []string
+	if m.addresult_count != nil {
+		fields = append(fields, searchhistory.FieldResultCount)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SearchHistoryMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case searchhistory.FieldResultCount:
+		return m.AddedResultCount()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SearchHistoryMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case searchhistory.FieldResultCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddResultCount(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SearchHistoryMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(searchhistory.FieldUserID) {
+		fields = append(fields, searchhistory.FieldUserID)
+	}
+	if m.FieldCleared(searchhistory.FieldSessionID) {
+		fields = append(fields, searchhistory.FieldSessionID)
+	}
+	if m.FieldCleared(searchhistory.FieldOptimizedQuery) {
+		fields = append(fields, searchhistory.FieldOptimizedQuery)
+	}
+	if m.FieldCleared(searchhistory.FieldCategory) {
+		fields = append(fields, searchhistory.FieldCategory)
+	}
+	if m.FieldCleared(searchhistory.FieldProductsFound) {
+		fields = append(fields, searchhistory.FieldProductsFound)
+	}
+	if m.FieldCleared(searchhistory.FieldClickedProductID) {
+		fields = append(fields, searchhistory.FieldClickedProductID)
+	}
+	if m.FieldCleared(searchhistory.FieldExpiresAt) {
+		fields = append(fields, searchhistory.FieldExpiresAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SearchHistoryMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SearchHistoryMutation) ClearField(name string) error {
+	switch name {
+	case searchhistory.FieldUserID:
+		m.ClearUserID()
+		return nil
+	case searchhistory.FieldSessionID:
+		m.ClearSessionID()
+		return nil
+	case searchhistory.FieldOptimizedQuery:
+		m.ClearOptimizedQuery()
+		return nil
+	case searchhistory.FieldCategory:
+		m.ClearCategory()
+		return nil
+	case searchhistory.FieldProductsFound:
+		m.ClearProductsFound()
+		return nil
+	case searchhistory.FieldClickedProductID:
+		m.ClearClickedProductID()
+		return nil
+	case searchhistory.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SearchHistoryMutation) ResetField(name string) error {
+	switch name {
+	case searchhistory.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case searchhistory.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case searchhistory.FieldSearchQuery:
+		m.ResetSearchQuery()
+		return nil
+	case searchhistory.FieldOptimizedQuery:
+		m.ResetOptimizedQuery()
+		return nil
+	case searchhistory.FieldSearchType:
+		m.ResetSearchType()
+		return nil
+	case searchhistory.FieldCategory:
+		m.ResetCategory()
+		return nil
+	case searchhistory.FieldCountryCode:
+		m.ResetCountryCode()
+		return nil
+	case searchhistory.FieldLanguageCode:
+		m.ResetLanguageCode()
+		return nil
+	case searchhistory.FieldCurrency:
+		m.ResetCurrency()
+		return nil
+	case searchhistory.FieldResultCount:
+		m.ResetResultCount()
+		return nil
+	case searchhistory.FieldProductsFound:
+		m.ResetProductsFound()
+		return nil
+	case searchhistory.FieldClickedProductID:
+		m.ResetClickedProductID()
+		return nil
+	case searchhistory.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case searchhistory.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SearchHistoryMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, searchhistory.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SearchHistoryMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case searchhistory.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SearchHistoryMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SearchHistoryMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SearchHistoryMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, searchhistory.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SearchHistoryMutation) EdgeCleared(name string) bool {
+	switch name {
+	case searchhistory.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SearchHistoryMutation) ClearEdge(name string) error {
+	switch name {
+	case searchhistory.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SearchHistoryMutation) ResetEdge(name string) error {
+	switch name {
+	case searchhistory.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SearchHistory edge %s", name)
+}
+
+// SessionParticipantMutation represents an operation that mutates the SessionParticipant nodes in the graph.
+type SessionParticipantMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	session_id    *string
+	invited_by    *uuid.UUID
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*SessionParticipant, error)
+	predicates    []predicate.SessionParticipant
+}
+
+var _ ent.Mutation = (*SessionParticipantMutation)(nil)
+
+// sessionparticipantOption allows management of the mutation configuration using functional options.
+type sessionparticipantOption func(*SessionParticipantMutation)
+
+// newSessionParticipantMutation creates new mutation for the SessionParticipant entity.
+func newSessionParticipantMutation(c config, op Op, opts ...sessionparticipantOption) *SessionParticipantMutation {
+	m := &SessionParticipantMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSessionParticipant,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSessionParticipantID sets the ID field of the mutation.
+func withSessionParticipantID(id uuid.UUID) sessionparticipantOption {
+	return func(m *SessionParticipantMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SessionParticipant
+		)
+		m.oldValue = func(ctx context.Context) (*SessionParticipant, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SessionParticipant.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSessionParticipant sets the old SessionParticipant of the mutation.
+func withSessionParticipant(node *SessionParticipant) sessionparticipantOption {
+	return func(m *SessionParticipantMutation) {
+		m.oldValue = func(context.Context) (*SessionParticipant, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SessionParticipantMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SessionParticipantMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of SessionParticipant entities.
+func (m *SessionParticipantMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionParticipantMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionParticipantMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SessionParticipant.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *SessionParticipantMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *SessionParticipantMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the SessionParticipant entity.
+// If the SessionParticipant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionParticipantMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *SessionParticipantMutation) ResetSessionID() {
+	m.session_id = nil
+}
+
+// SetUserID sets the "user_id" field.
+func (m *SessionParticipantMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *SessionParticipantMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the SessionParticipant entity.
+// If the SessionParticipant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionParticipantMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *SessionParticipantMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (m *SessionParticipantMutation) SetInvitedBy(u uuid.UUID) {
+	m.invited_by = &u
+}
+
+// InvitedBy returns the value of the "invited_by" field in the mutation.
+func (m *SessionParticipantMutation) InvitedBy() (r uuid.UUID, exists bool) {
+	v := m.invited_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInvitedBy returns the old "invited_by" field's value of the SessionParticipant entity.
+// If the SessionParticipant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionParticipantMutation) OldInvitedBy(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInvitedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInvitedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInvitedBy: %w", err)
+	}
+	return oldValue.InvitedBy, nil
+}
+
+// ResetInvitedBy resets all changes to the "invited_by" field.
+func (m *SessionParticipantMutation) ResetInvitedBy() {
+	m.invited_by = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *SessionParticipantMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SessionParticipantMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the SessionParticipant entity.
+// If the SessionParticipant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionParticipantMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *SessionParticipantMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *SessionParticipantMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[sessionparticipant.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *SessionParticipantMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *SessionParticipantMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *SessionParticipantMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the SessionParticipantMutation builder.
+func (m *SessionParticipantMutation) Where(ps ...predicate.SessionParticipant) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SessionParticipantMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SessionParticipantMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SessionParticipant, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SessionParticipantMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SessionParticipantMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SessionParticipant).
+func (m *SessionParticipantMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SessionParticipantMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.session_id != nil {
+		fields = append(fields, sessionparticipant.FieldSessionID)
+	}
+	if m.user != nil {
+		fields = append(fields, sessionparticipant.FieldUserID)
+	}
+	if m.invited_by != nil {
+		fields = append(fields, sessionparticipant.FieldInvitedBy)
+	}
+	if m.created_at != nil {
+		fields = append(fields, sessionparticipant.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SessionParticipantMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case sessionparticipant.FieldSessionID:
+		return m.SessionID()
+	case sessionparticipant.FieldUserID:
+		return m.UserID()
+	case sessionparticipant.FieldInvitedBy:
+		return m.InvitedBy()
+	case sessionparticipant.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SessionParticipantMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case sessionparticipant.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case sessionparticipant.FieldUserID:
+		return m.OldUserID(ctx)
+	case sessionparticipant.FieldInvitedBy:
+		return m.OldInvitedBy(ctx)
+	case sessionparticipant.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SessionParticipant field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionParticipantMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case sessionparticipant.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case sessionparticipant.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case sessionparticipant.FieldInvitedBy:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInvitedBy(v)
+		return nil
+	case sessionparticipant.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SessionParticipant field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SessionParticipantMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SessionParticipantMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionParticipantMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown SessionParticipant numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SessionParticipantMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SessionParticipantMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SessionParticipantMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown SessionParticipant nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SessionParticipantMutation) ResetField(name string) error {
+	switch name {
+	case sessionparticipant.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case sessionparticipant.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case sessionparticipant.FieldInvitedBy:
+		m.ResetInvitedBy()
+		return nil
+	case sessionparticipant.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionParticipant field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SessionParticipantMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, sessionparticipant.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SessionParticipantMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case sessionparticipant.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SessionParticipantMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SessionParticipantMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SessionParticipantMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, sessionparticipant.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SessionParticipantMutation) EdgeCleared(name string) bool {
+	switch name {
+	case sessionparticipant.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SessionParticipantMutation) ClearEdge(name string) error {
+	switch name {
+	case sessionparticipant.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionParticipant unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SessionParticipantMutation) ResetEdge(name string) error {
+	switch name {
+	case sessionparticipant.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionParticipant edge %s", name)
+}
+
+// SessionSnapshotMutation represents an operation that mutates the SessionSnapshot nodes in the graph.
+type SessionSnapshotMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *uuid.UUID
+	slug             *string
+	session_id       *string
+	title            *string
+	messages         *jsontext.Value
+	appendmessages   jsontext.Value
+	message_count    *int
+	addmessage_count *int
+	expires_at       *time.Time
+	revoked_at       *time.Time
+	created_at       *time.Time
+	clearedFields    map[string]struct{}
+	user             *uuid.UUID
+	cleareduser      bool
+	done             bool
+	oldValue         func(context.Context) (*SessionSnapshot, error)
+	predicates       []predicate.SessionSnapshot
+}
+
+var _ ent.Mutation = (*SessionSnapshotMutation)(nil)
+
+// sessionsnapshotOption allows management of the mutation configuration using functional options.
+type sessionsnapshotOption func(*SessionSnapshotMutation)
+
+// newSessionSnapshotMutation creates new mutation for the SessionSnapshot entity.
+func newSessionSnapshotMutation(c config, op Op, opts ...sessionsnapshotOption) *SessionSnapshotMutation {
+	m := &SessionSnapshotMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSessionSnapshot,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSessionSnapshotID sets the ID field of the mutation.
+func withSessionSnapshotID(id uuid.UUID) sessionsnapshotOption {
+	return func(m *SessionSnapshotMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SessionSnapshot
+		)
+		m.oldValue = func(ctx context.Context) (*SessionSnapshot, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SessionSnapshot.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSessionSnapshot sets the old SessionSnapshot of the mutation.
+func withSessionSnapshot(node *SessionSnapshot) sessionsnapshotOption {
+	return func(m *SessionSnapshotMutation) {
+		m.oldValue = func(context.Context) (*SessionSnapshot, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SessionSnapshotMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SessionSnapshotMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of SessionSnapshot entities.
+func (m *SessionSnapshotMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionSnapshotMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionSnapshotMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SessionSnapshot.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSlug sets the "slug" field.
+func (m *SessionSnapshotMutation) SetSlug(s string) {
+	m.slug = &s
+}
+
+// Slug returns the value of the "slug" field in the mutation.
+func (m *SessionSnapshotMutation) Slug() (r string, exists bool) {
+	v := m.slug
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSlug returns the old "slug" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldSlug(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSlug is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSlug requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSlug: %w", err)
+	}
+	return oldValue.Slug, nil
+}
+
+// ResetSlug resets all changes to the "slug" field.
+func (m *SessionSnapshotMutation) ResetSlug() {
+	m.slug = nil
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *SessionSnapshotMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *SessionSnapshotMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *SessionSnapshotMutation) ResetSessionID() {
+	m.session_id = nil
+}
+
+// SetUserID sets the "user_id" field.
+func (m *SessionSnapshotMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *SessionSnapshotMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *SessionSnapshotMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetTitle sets the "title" field.
+func (m *SessionSnapshotMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *SessionSnapshotMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ClearTitle clears the value of the "title" field.
+func (m *SessionSnapshotMutation) ClearTitle() {
+	m.title = nil
+	m.clearedFields[sessionsnapshot.FieldTitle] = struct{}{}
+}
+
+// TitleCleared returns if the "title" field was cleared in this mutation.
+func (m *SessionSnapshotMutation) TitleCleared() bool {
+	_, ok := m.clearedFields[sessionsnapshot.FieldTitle]
+	return ok
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *SessionSnapshotMutation) ResetTitle() {
+	m.title = nil
+	delete(m.clearedFields, sessionsnapshot.FieldTitle)
+}
+
+// SetMessages sets the "messages" field.
+func (m *SessionSnapshotMutation) SetMessages(j jsontext.Value) {
+	m.messages = &j
+	m.appendmessages = nil
+}
+
+// Messages returns the value of the "messages" field in the mutation.
+func (m *SessionSnapshotMutation) Messages() (r jsontext.Value, exists bool) {
+	v := m.messages
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMessages returns the old "messages" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldMessages(ctx context.Context) (v jsontext.Value, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMessages is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMessages requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMessages: %w", err)
+	}
+	return oldValue.Messages, nil
+}
+
+// AppendMessages adds j to the "messages" field.
+func (m *SessionSnapshotMutation) AppendMessages(j jsontext.Value) {
+	m.appendmessages = append(m.appendmessages, j...)
+}
+
+// AppendedMessages returns the list of values that were appended to the "messages" field in this mutation.
+func (m *SessionSnapshotMutation) AppendedMessages() (jsontext.Value, bool) {
+	if len(m.appendmessages) == 0 {
+		return nil, false
+	}
+	return m.appendmessages, true
+}
+
+// ResetMessages resets all changes to the "messages" field.
+func (m *SessionSnapshotMutation) ResetMessages() {
+	m.messages = nil
+	m.appendmessages = nil
+}
+
+// SetMessageCount sets the "message_count" field.
+func (m *SessionSnapshotMutation) SetMessageCount(i int) {
+	m.message_count = &i
+	m.addmessage_count = nil
+}
+
+// MessageCount returns the value of the "message_count" field in the mutation.
+func (m *SessionSnapshotMutation) MessageCount() (r int, exists bool) {
+	v := m.message_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMessageCount returns the old "message_count" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldMessageCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMessageCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMessageCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMessageCount: %w", err)
+	}
+	return oldValue.MessageCount, nil
+}
+
+// AddMessageCount adds i to the "message_count" field.
+func (m *SessionSnapshotMutation) AddMessageCount(i int) {
+	if m.addmessage_count != nil {
+		*m.addmessage_count += i
+	} else {
+		m.addmessage_count = &i
+	}
+}
+
+// AddedMessageCount returns the value that was added to the "message_count" field in this mutation.
+func (m *SessionSnapshotMutation) AddedMessageCount() (r int, exists bool) {
+	v := m.addmessage_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMessageCount resets all changes to the "message_count" field.
+func (m *SessionSnapshotMutation) ResetMessageCount() {
+	m.message_count = nil
+	m.addmessage_count = nil
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *SessionSnapshotMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *SessionSnapshotMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expires_at" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *SessionSnapshotMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[sessionsnapshot.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *SessionSnapshotMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[sessionsnapshot.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *SessionSnapshotMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, sessionsnapshot.FieldExpiresAt)
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (m *SessionSnapshotMutation) SetRevokedAt(t time.Time) {
+	m.revoked_at = &t
+}
+
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *SessionSnapshotMutation) RevokedAt() (r time.Time, exists bool) {
+	v := m.revoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevokedAt returns the old "revoked_at" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldRevokedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
+	}
+	return oldValue.RevokedAt, nil
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *SessionSnapshotMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.clearedFields[sessionsnapshot.FieldRevokedAt] = struct{}{}
+}
+
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *SessionSnapshotMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[sessionsnapshot.FieldRevokedAt]
+	return ok
+}
+
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *SessionSnapshotMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	delete(m.clearedFields, sessionsnapshot.FieldRevokedAt)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *SessionSnapshotMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SessionSnapshotMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the SessionSnapshot entity.
+// If the SessionSnapshot object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionSnapshotMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *SessionSnapshotMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *SessionSnapshotMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[sessionsnapshot.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *SessionSnapshotMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *SessionSnapshotMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *SessionSnapshotMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the SessionSnapshotMutation builder.
+func (m *SessionSnapshotMutation) Where(ps ...predicate.SessionSnapshot) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SessionSnapshotMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SessionSnapshotMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SessionSnapshot, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SessionSnapshotMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SessionSnapshotMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SessionSnapshot).
+func (m *SessionSnapshotMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SessionSnapshotMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.slug != nil {
+		fields = append(fields, sessionsnapshot.FieldSlug)
+	}
+	if m.session_id != nil {
+		fields = append(fields, sessionsnapshot.FieldSessionID)
+	}
+	if m.user != nil {
+		fields = append(fields, sessionsnapshot.FieldUserID)
+	}
+	if m.title != nil {
+		fields = append(fields, sessionsnapshot.FieldTitle)
+	}
+	if m.messages != nil {
+		fields = append(fields, sessionsnapshot.FieldMessages)
+	}
+	if m.message_count != nil {
+		fields = append(fields, sessionsnapshot.FieldMessageCount)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, sessionsnapshot.FieldExpiresAt)
+	}
+	if m.revoked_at != nil {
+		fields = append(fields, sessionsnapshot.FieldRevokedAt)
+	}
+	if m.created_at != nil {
+		fields = append(fields, sessionsnapshot.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SessionSnapshotMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case sessionsnapshot.FieldSlug:
+		return m.Slug()
+	case sessionsnapshot.FieldSessionID:
+		return m.SessionID()
+	case sessionsnapshot.FieldUserID:
+		return m.UserID()
+	case sessionsnapshot.FieldTitle:
+		return m.Title()
+	case sessionsnapshot.FieldMessages:
+		return m.Messages()
+	case sessionsnapshot.FieldMessageCount:
+		return m.MessageCount()
+	case sessionsnapshot.FieldExpiresAt:
+		return m.ExpiresAt()
+	case sessionsnapshot.FieldRevokedAt:
+		return m.RevokedAt()
+	case sessionsnapshot.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SessionSnapshotMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case sessionsnapshot.FieldSlug:
+		return m.OldSlug(ctx)
+	case sessionsnapshot.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case sessionsnapshot.FieldUserID:
+		return m.OldUserID(ctx)
+	case sessionsnapshot.FieldTitle:
+		return m.OldTitle(ctx)
+	case sessionsnapshot.FieldMessages:
+		return m.OldMessages(ctx)
+	case sessionsnapshot.FieldMessageCount:
+		return m.OldMessageCount(ctx)
+	case sessionsnapshot.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case sessionsnapshot.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
+	case sessionsnapshot.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SessionSnapshot field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionSnapshotMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case sessionsnapshot.FieldSlug:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSlug(v)
+		return nil
+	case sessionsnapshot.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case sessionsnapshot.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case sessionsnapshot.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case sessionsnapshot.FieldMessages:
+		v, ok := value.(jsontext.Value)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMessages(v)
+		return nil
+	case sessionsnapshot.FieldMessageCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMessageCount(v)
+		return nil
+	case sessionsnapshot.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case sessionsnapshot.FieldRevokedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevokedAt(v)
+		return nil
+	case sessionsnapshot.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SessionSnapshotMutation) AddedFields() []string {
+	var fields []string
+	if m.addmessage_count != nil {
+		fields = append(fields, sessionsnapshot.FieldMessageCount)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SessionSnapshotMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case sessionsnapshot.FieldMessageCount:
+		return m.AddedMessageCount()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionSnapshotMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case sessionsnapshot.FieldMessageCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMessageCount(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SessionSnapshotMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(sessionsnapshot.FieldTitle) {
+		fields = append(fields, sessionsnapshot.FieldTitle)
+	}
+	if m.FieldCleared(sessionsnapshot.FieldExpiresAt) {
+		fields = append(fields, sessionsnapshot.FieldExpiresAt)
+	}
+	if m.FieldCleared(sessionsnapshot.FieldRevokedAt) {
+		fields = append(fields, sessionsnapshot.FieldRevokedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SessionSnapshotMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SessionSnapshotMutation) ClearField(name string) error {
+	switch name {
+	case sessionsnapshot.FieldTitle:
+		m.ClearTitle()
+		return nil
+	case sessionsnapshot.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case sessionsnapshot.FieldRevokedAt:
+		m.ClearRevokedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SessionSnapshotMutation) ResetField(name string) error {
+	switch name {
+	case sessionsnapshot.FieldSlug:
+		m.ResetSlug()
+		return nil
+	case sessionsnapshot.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case sessionsnapshot.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case sessionsnapshot.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case sessionsnapshot.FieldMessages:
+		m.ResetMessages()
+		return nil
+	case sessionsnapshot.FieldMessageCount:
+		m.ResetMessageCount()
+		return nil
+	case sessionsnapshot.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case sessionsnapshot.FieldRevokedAt:
+		m.ResetRevokedAt()
+		return nil
+	case sessionsnapshot.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SessionSnapshotMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, sessionsnapshot.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SessionSnapshotMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case sessionsnapshot.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SessionSnapshotMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SessionSnapshotMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SessionSnapshotMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, sessionsnapshot.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SessionSnapshotMutation) EdgeCleared(name string) bool {
+	switch name {
+	case sessionsnapshot.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SessionSnapshotMutation) ClearEdge(name string) error {
+	switch name {
+	case sessionsnapshot.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SessionSnapshotMutation) ResetEdge(name string) error {
+	switch name {
+	case sessionsnapshot.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionSnapshot edge %s", name)
+}
+
+// UserMutation represents an operation that mutates the User nodes in the graph.
+type UserMutation struct {
+	config
+	op                        Op
+	typ                       string
+	id                        *uuid.UUID
+	email                     *string
+	password_hash             *string
+	google_id                 *string
+	name                      *string
+	avatar_url                *string
+	provider                  *string
+	role                      *string
+	plan                      *string
+	email_verified            *bool
+	totp_enabled              *bool
+	totp_secret               *string
+	totp_recovery_codes       *[]string
+	appendtotp_recovery_codes []string
+	created_at                *time.Time
+	updated_at                *time.Time
+	last_login                *time.Time
+	clearedFields             map[string]struct{}
+	sessions                  map[uuid.UUID]struct{}
+	removedsessions           map[uuid.UUID]struct{}
+	clearedsessions           bool
+	search_history            map[uuid.UUID]struct{}
+	removedsearch_history     map[uuid.UUID]struct{}
+	clearedsearch_history     bool
+	preferences               *uuid.UUID
+	clearedpreferences        bool
+	watches                   map[uuid.UUID]struct{}
+	removedwatches            map[uuid.UUID]struct{}
+	clearedwatches            bool
+	shared_sessions           map[uuid.UUID]struct{}
+	removedshared_sessions    map[uuid.UUID]struct{}
+	clearedshared_sessions    bool
+	snapshots                 map[uuid.UUID]struct{}
+	removedsnapshots          map[uuid.UUID]struct{}
+	clearedsnapshots          bool
+	api_keys                  map[uuid.UUID]struct{}
+	removedapi_keys           map[uuid.UUID]struct{}
+	clearedapi_keys           bool
+	identities                map[uuid.UUID]struct{}
+	removedidentities         map[uuid.UUID]struct{}
+	clearedidentities         bool
+	done                      bool
+	oldValue                  func(context.Context) (*User, error)
+	predicates                []predicate.User
+}
+
+var _ ent.Mutation = (*UserMutation)(nil)
+
+// userOption allows management of the mutation configuration using functional options.
+type userOption func(*UserMutation)
+
+// newUserMutation creates new mutation for the User entity.
+func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
+	m := &UserMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUser,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserID sets the ID field of the mutation.
+func withUserID(id uuid.UUID) userOption {
+	return func(m *UserMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *User
+		)
+		m.oldValue = func(ctx context.Context) (*User, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().User.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUser sets the old User of the mutation.
+func withUser(node *User) userOption {
+	return func(m *UserMutation) {
+		m.oldValue = func(context.Context) (*User, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of User entities.
+func (m *UserMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetEmail sets the "email" field.
+func (m *UserMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *UserMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *UserMutation) ResetEmail() {
+	m.email = nil
+}
+
+// SetPasswordHash sets the "password_hash" field.
+func (m *UserMutation) SetPasswordHash(s string) {
+	m.password_hash = &s
+}
+
+// PasswordHash returns the value of the "password_hash" field in the mutation.
+func (m *UserMutation) PasswordHash() (r string, exists bool) {
+	v := m.password_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPasswordHash returns the old "password_hash" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPasswordHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPasswordHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPasswordHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPasswordHash: %w", err)
+	}
+	return oldValue.PasswordHash, nil
+}
+
+// ClearPasswordHash clears the value of the "password_hash" field.
+func (m *UserMutation) ClearPasswordHash() {
+	m.password_hash = nil
+	m.clearedFields[user.FieldPasswordHash] = struct{}{}
+}
+
+// PasswordHashCleared returns if the "password_hash" field was cleared in this mutation.
+func (m *UserMutation) PasswordHashCleared() bool {
+	_, ok := m.clearedFields[user.FieldPasswordHash]
+	return ok
+}
+
+// ResetPasswordHash resets all changes to the "password_hash" field.
+func (m *UserMutation) ResetPasswordHash() {
+	m.password_hash = nil
+	delete(m.clearedFields, user.FieldPasswordHash)
+}
+
+// SetGoogleID sets the "google_id" field.
+func (m *UserMutation) SetGoogleID(s string) {
+	m.google_id = &s
+}
+
+// GoogleID returns the value of the "google_id" field in the mutation.
+func (m *UserMutation) GoogleID() (r string, exists bool) {
+	v := m.google_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldGoogleID returns the old "google_id" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldGoogleID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGoogleID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGoogleID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGoogleID: %w", err)
+	}
+	return oldValue.GoogleID, nil
+}
+
+// ClearGoogleID clears the value of the "google_id" field.
+func (m *UserMutation) ClearGoogleID() {
+	m.google_id = nil
+	m.clearedFields[user.FieldGoogleID] = struct{}{}
+}
+
+// GoogleIDCleared returns if the "google_id" field was cleared in this mutation.
+func (m *UserMutation) GoogleIDCleared() bool {
+	_, ok := m.clearedFields[user.FieldGoogleID]
+	return ok
+}
+
+// ResetGoogleID resets all changes to the "google_id" field.
+func (m *UserMutation) ResetGoogleID() {
+	m.google_id = nil
+	delete(m.clearedFields, user.FieldGoogleID)
+}
+
+// SetName sets the "name" field.
+func (m *UserMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *UserMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ClearName clears the value of the "name" field.
+func (m *UserMutation) ClearName() {
+	m.name = nil
+	m.clearedFields[user.FieldName] = struct{}{}
+}
+
+// NameCleared returns if the "name" field was cleared in this mutation.
+func (m *UserMutation) NameCleared() bool {
+	_, ok := m.clearedFields[user.FieldName]
+	return ok
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *UserMutation) ResetName() {
+	m.name = nil
+	delete(m.clearedFields, user.FieldName)
+}
+
+// SetAvatarURL sets the "avatar_url" field.
+func (m *UserMutation) SetAvatarURL(s string) {
+	m.avatar_url = &s
+}
+
+// AvatarURL returns the value of the "avatar_url" field in the mutation.
+func (m *UserMutation) AvatarURL() (r string, exists bool) {
+	v := m.avatar_url
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvatarURL returns the old "avatar_url" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAvatarURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvatarURL is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvatarURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvatarURL: %w", err)
+	}
+	return oldValue.AvatarURL, nil
+}
+
+// ClearAvatarURL clears the value of the "avatar_url" field.
+func (m *UserMutation) ClearAvatarURL() {
+	m.avatar_url = nil
+	m.clearedFields[user.FieldAvatarURL] = struct{}{}
+}
+
+// AvatarURLCleared returns if the "avatar_url" field was cleared in this mutation.
+func (m *UserMutation) AvatarURLCleared() bool {
+	_, ok := m.clearedFields[user.FieldAvatarURL]
+	return ok
+}
+
+// ResetAvatarURL resets all changes to the "avatar_url" field.
+func (m *UserMutation) ResetAvatarURL() {
+	m.avatar_url = nil
+	delete(m.clearedFields, user.FieldAvatarURL)
+}
+
+// SetProvider sets the "provider" field.
+func (m *UserMutation) SetProvider(s string) {
+	m.provider = &s
+}
+
+// Provider returns the value of the "provider" field in the mutation.
+func (m *UserMutation) Provider() (r string, exists bool) {
+	v := m.provider
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProvider returns the old "provider" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldProvider(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProvider requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+	}
+	return oldValue.Provider, nil
+}
+
+// ResetProvider resets all changes to the "provider" field.
+func (m *UserMutation) ResetProvider() {
+	m.provider = nil
+}
+
+// SetRole sets the "role" field.
+func (m *UserMutation) SetRole(s string) {
+	m.role = &s
+}
+
+// Role returns the value of the "role" field in the mutation.
+func (m *UserMutation) Role() (r string, exists bool) {
+	v := m.role
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRole returns the old "role" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldRole(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRole requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+	}
+	return oldValue.Role, nil
+}
+
+// ResetRole resets all changes to the "role" field.
+func (m *UserMutation) ResetRole() {
+	m.role = nil
+}
+
+// SetPlan sets the "plan" field.
+func (m *UserMutation) SetPlan(s string) {
+	m.plan = &s
+}
+
+// Plan returns the value of the "plan" field in the mutation.
+func (m *UserMutation) Plan() (r string, exists bool) {
+	v := m.plan
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPlan returns the old "plan" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPlan(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPlan is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPlan requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPlan: %w", err)
+	}
+	return oldValue.Plan, nil
+}
+
+// ResetPlan resets all changes to the "plan" field.
+func (m *UserMutation) ResetPlan() {
+	m.plan = nil
+}
+
+// SetEmailVerified sets the "email_verified" field.
+func (m *UserMutation) SetEmailVerified(b bool) {
+	m.email_verified = &b
+}
+
+// EmailVerified returns the value of the "email_verified" field in the mutation.
+func (m *UserMutation) EmailVerified() (r bool, exists bool) {
+	v := m.email_verified
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmailVerified returns the old "email_verified" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmailVerified(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmailVerified is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmailVerified requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmailVerified: %w", err)
+	}
+	return oldValue.EmailVerified, nil
+}
+
+// ResetEmailVerified resets all changes to the "email_verified" field.
+func (m *UserMutation) ResetEmailVerified() {
+	m.email_verified = nil
+}
+
+// SetTotpEnabled sets the "totp_enabled" field.
+func (m *UserMutation) SetTotpEnabled(b bool) {
+	m.totp_enabled = &b
+}
+
+// TotpEnabled returns the value of the "totp_enabled" field in the mutation.
+func (m *UserMutation) TotpEnabled() (r bool, exists bool) {
+	v := m.totp_enabled
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpEnabled returns the old "totp_enabled" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpEnabled(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpEnabled is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpEnabled requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpEnabled: %w", err)
+	}
+	return oldValue.TotpEnabled, nil
+}
+
+// ResetTotpEnabled resets all changes to the "totp_enabled" field.
+func (m *UserMutation) ResetTotpEnabled() {
+	m.totp_enabled = nil
+}
+
+// SetTotpSecret sets the "totp_secret" field.
+func (m *UserMutation) SetTotpSecret(s string) {
+	m.totp_secret = &s
+}
+
+// TotpSecret returns the value of the "totp_secret" field in the mutation.
+func (m *UserMutation) TotpSecret() (r string, exists bool) {
+	v := m.totp_secret
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpSecret returns the old "totp_secret" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpSecret(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpSecret is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpSecret requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpSecret: %w", err)
+	}
+	return oldValue.TotpSecret, nil
+}
+
+// ClearTotpSecret clears the value of the "totp_secret" field.
+func (m *UserMutation) ClearTotpSecret() {
+	m.totp_secret = nil
+	m.clearedFields[user.FieldTotpSecret] = struct{}{}
+}
+
+// TotpSecretCleared returns if the "totp_secret" field was cleared in this mutation.
+func (m *UserMutation) TotpSecretCleared() bool {
+	_, ok := m.clearedFields[user.FieldTotpSecret]
+	return ok
+}
+
+// ResetTotpSecret resets all changes to the "totp_secret" field.
+func (m *UserMutation) ResetTotpSecret() {
+	m.totp_secret = nil
+	delete(m.clearedFields, user.FieldTotpSecret)
+}
+
+// SetTotpRecoveryCodes sets the "totp_recovery_codes" field.
+func (m *UserMutation) SetTotpRecoveryCodes(s []string) {
+	m.totp_recovery_codes = &s
+	m.appendtotp_recovery_codes = nil
+}
+
+// TotpRecoveryCodes returns the value of the "totp_recovery_codes" field in the mutation.
+func (m *UserMutation) TotpRecoveryCodes() (r []string, exists bool) {
+	v := m.totp_recovery_codes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpRecoveryCodes returns the old "totp_recovery_codes" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpRecoveryCodes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpRecoveryCodes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpRecoveryCodes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpRecoveryCodes: %w", err)
+	}
+	return oldValue.TotpRecoveryCodes, nil
+}
+
+// AppendTotpRecoveryCodes adds s to the "totp_recovery_codes" field.
+func (m *UserMutation) AppendTotpRecoveryCodes(s []string) {
+	m.appendtotp_recovery_codes = append(m.appendtotp_recovery_codes, s...)
+}
+
+// AppendedTotpRecoveryCodes returns the list of values that were appended to the "totp_recovery_codes" field in this mutation.
+func (m *UserMutation) AppendedTotpRecoveryCodes() ([]string, bool) {
+	if len(m.appendtotp_recovery_codes) == 0 {
+		return nil, false
+	}
+	return m.appendtotp_recovery_codes, true
+}
+
+// ClearTotpRecoveryCodes clears the value of the "totp_recovery_codes" field.
+func (m *UserMutation) ClearTotpRecoveryCodes() {
+	m.totp_recovery_codes = nil
+	m.appendtotp_recovery_codes = nil
+	m.clearedFields[user.FieldTotpRecoveryCodes] = struct{}{}
+}
+
+// TotpRecoveryCodesCleared returns if the "totp_recovery_codes" field was cleared in this mutation.
+func (m *UserMutation) TotpRecoveryCodesCleared() bool {
+	_, ok := m.clearedFields[user.FieldTotpRecoveryCodes]
+	return ok
+}
+
+// ResetTotpRecoveryCodes resets all changes to the "totp_recovery_codes" field.
+func (m *UserMutation) ResetTotpRecoveryCodes() {
+	m.totp_recovery_codes = nil
+	m.appendtotp_recovery_codes = nil
+	delete(m.clearedFields, user.FieldTotpRecoveryCodes)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *UserMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *UserMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *UserMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *UserMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetLastLogin sets the "last_login" field.
+func (m *UserMutation) SetLastLogin(t time.Time) {
+	m.last_login = &t
+}
+
+// LastLogin returns the value of the "last_login" field in the mutation.
+func (m *UserMutation) LastLogin() (r time.Time, exists bool) {
+	v := m.last_login
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastLogin returns the old "last_login" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLastLogin(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastLogin is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastLogin requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastLogin: %w", err)
+	}
+	return oldValue.LastLogin, nil
+}
+
+// ClearLastLogin clears the value of the "last_login" field.
+func (m *UserMutation) ClearLastLogin() {
+	m.last_login = nil
+	m.clearedFields[user.FieldLastLogin] = struct{}{}
+}
+
+// LastLoginCleared returns if the "last_login" field was cleared in this mutation.
+func (m *UserMutation) LastLoginCleared() bool {
+	_, ok := m.clearedFields[user.FieldLastLogin]
+	return ok
+}
+
+// ResetLastLogin resets all changes to the "last_login" field.
+func (m *UserMutation) ResetLastLogin() {
+	m.last_login = nil
+	delete(m.clearedFields, user.FieldLastLogin)
+}
+
+// AddSessionIDs adds the "sessions" edge to the ChatSession entity by ids.
+func (m *UserMutation) AddSessionIDs(ids ...uuid.UUID) {
+	if m.sessions == nil {
+		m.sessions = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.sessions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSessions clears the "sessions" edge to the ChatSession entity.
+func (m *UserMutation) ClearSessions() {
+	m.clearedsessions = true
+}
+
+// SessionsCleared reports if the "sessions" edge to the ChatSession entity was cleared.
+func (m *UserMutation) SessionsCleared() bool {
+	return m.clearedsessions
+}
+
+// RemoveSessionIDs removes the "sessions" edge to the ChatSession entity by IDs.
+func (m *UserMutation) RemoveSessionIDs(ids ...uuid.UUID) {
+	if m.removedsessions == nil {
+		m.removedsessions = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.sessions, ids[i])
+		m.removedsessions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSessions returns the removed IDs of the "sessions" edge to the ChatSession entity.
+func (m *UserMutation) RemovedSessionsIDs() (ids []uuid.UUID) {
+	for id := range m.removedsessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SessionsIDs returns the "sessions" edge IDs in the mutation.
+func (m *UserMutation) SessionsIDs() (ids []uuid.UUID) {
+	for id := range m.sessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSessions resets all changes to the "sessions" edge.
+func (m *UserMutation) ResetSessions() {
+	m.sessions = nil
+	m.clearedsessions = false
+	m.removedsessions = nil
+}
+
+// AddSearchHistoryIDs adds the "search_history" edge to the SearchHistory entity by ids.
+func (m *UserMutation) AddSearchHistoryIDs(ids ...uuid.UUID) {
+	if m.search_history == nil {
+		m.search_history = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.search_history[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSearchHistory clears the "search_history" edge to the SearchHistory entity.
+func (m *UserMutation) ClearSearchHistory() {
+	m.clearedsearch_history = true
+}
+
+// SearchHistoryCleared reports if the "search_history" edge to the SearchHistory entity was cleared.
+func (m *UserMutation) SearchHistoryCleared() bool {
+	return m.clearedsearch_history
+}
+
+// RemoveSearchHistoryIDs removes the "search_history" edge to the SearchHistory entity by IDs.
+func (m *UserMutation) RemoveSearchHistoryIDs(ids ...uuid.UUID) {
+	if m.removedsearch_history == nil {
+		m.removedsearch_history = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.search_history, ids[i])
+		m.removedsearch_history[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSearchHistory returns the removed IDs of the "search_history" edge to the SearchHistory entity.
+func (m *UserMutation) RemovedSearchHistoryIDs() (ids []uuid.UUID) {
+	for id := range m.removedsearch_history {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SearchHistoryIDs returns the "search_history" edge IDs in the mutation.
+func (m *UserMutation) SearchHistoryIDs() (ids []uuid.UUID) {
+	for id := range m.search_history {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSearchHistory resets all changes to the "search_history" edge.
+func (m *UserMutation) ResetSearchHistory() {
+	m.search_history = nil
+	m.clearedsearch_history = false
+	m.removedsearch_history = nil
+}
+
+// SetPreferencesID sets the "preferences" edge to the UserPreference entity by id.
+func (m *UserMutation) SetPreferencesID(id uuid.UUID) {
+	m.preferences = &id
+}
+
+// ClearPreferences clears the "preferences" edge to the UserPreference entity.
+func (m *UserMutation) ClearPreferences() {
+	m.clearedpreferences = true
+}
+
+// PreferencesCleared reports if the "preferences" edge to the UserPreference entity was cleared.
+func (m *UserMutation) PreferencesCleared() bool {
+	return m.clearedpreferences
+}
+
+// PreferencesID returns the "preferences" edge ID in the mutation.
+func (m *UserMutation) PreferencesID() (id uuid.UUID, exists bool) {
+	if m.preferences != nil {
+		return *m.preferences, true
+	}
+	return
+}
+
+// PreferencesIDs returns the "preferences" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PreferencesID instead. It exists only for internal usage by the builders.
+func (m *UserMutation) PreferencesIDs() (ids []uuid.UUID) {
+	if id := m.preferences; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPreferences resets all changes to the "preferences" edge.
+func (m *UserMutation) ResetPreferences() {
+	m.preferences = nil
+	m.clearedpreferences = false
+}
+
+// AddWatchIDs adds the "watches" edge to the Watch entity by ids.
+func (m *UserMutation) AddWatchIDs(ids ...uuid.UUID) {
+	if m.watches == nil {
+		m.watches = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.watches[ids[i]] = struct{}{}
+	}
+}
+
+// ClearWatches clears the "watches" edge to the Watch entity.
+func (m *UserMutation) ClearWatches() {
+	m.clearedwatches = true
+}
+
+// WatchesCleared reports if the "watches" edge to the Watch entity was cleared.
+func (m *UserMutation) WatchesCleared() bool {
+	return m.clearedwatches
+}
+
+// RemoveWatchIDs removes the "watches" edge to the Watch entity by IDs.
+func (m *UserMutation) RemoveWatchIDs(ids ...uuid.UUID) {
+	if m.removedwatches == nil {
+		m.removedwatches = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.watches, ids[i])
+		m.removedwatches[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedWatches returns the removed IDs of the "watches" edge to the Watch entity.
+func (m *UserMutation) RemovedWatchesIDs() (ids []uuid.UUID) {
+	for id := range m.removedwatches {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// WatchesIDs returns the "watches" edge IDs in the mutation.
+func (m *UserMutation) WatchesIDs() (ids []uuid.UUID) {
+	for id := range m.watches {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetWatches resets all changes to the "watches" edge.
+func (m *UserMutation) ResetWatches() {
+	m.watches = nil
+	m.clearedwatches = false
+	m.removedwatches = nil
+}
+
+// AddSharedSessionIDs adds the "shared_sessions" edge to the SessionParticipant entity by ids.
+func (m *UserMutation) AddSharedSessionIDs(ids ...uuid.UUID) {
+	if m.shared_sessions == nil {
+		m.shared_sessions = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.shared_sessions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSharedSessions clears the "shared_sessions" edge to the SessionParticipant entity.
+func (m *UserMutation) ClearSharedSessions() {
+	m.clearedshared_sessions = true
+}
+
+// SharedSessionsCleared reports if the "shared_sessions" edge to the SessionParticipant entity was cleared.
+func (m *UserMutation) SharedSessionsCleared() bool {
+	return m.clearedshared_sessions
+}
+
+// RemoveSharedSessionIDs removes the "shared_sessions" edge to the SessionParticipant entity by IDs.
+func (m *UserMutation) RemoveSharedSessionIDs(ids ...uuid.UUID) {
+	if m.removedshared_sessions == nil {
+		m.removedshared_sessions = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.shared_sessions, ids[i])
+		m.removedshared_sessions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSharedSessions returns the removed IDs of the "shared_sessions" edge to the SessionParticipant entity.
+func (m *UserMutation) RemovedSharedSessionsIDs() (ids []uuid.UUID) {
+	for id := range m.removedshared_sessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SharedSessionsIDs returns the "shared_sessions" edge IDs in the mutation.
+func (m *UserMutation) SharedSessionsIDs() (ids []uuid.UUID) {
+	for id := range m.shared_sessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSharedSessions resets all changes to the "shared_sessions" edge.
+func (m *UserMutation) ResetSharedSessions() {
+	m.shared_sessions = nil
+	m.clearedshared_sessions = false
+	m.removedshared_sessions = nil
+}
+
+// AddSnapshotIDs adds the "snapshots" edge to the SessionSnapshot entity by ids.
+func (m *UserMutation) AddSnapshotIDs(ids ...uuid.UUID) {
+	if m.snapshots == nil {
+		m.snapshots = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.snapshots[ids[i]] = struct{}{}
+	}
+}
+
+// ClearSnapshots clears the "snapshots" edge to the SessionSnapshot entity.
+func (m *UserMutation) ClearSnapshots() {
+	m.clearedsnapshots = true
+}
+
+// SnapshotsCleared reports if the "snapshots" edge to the SessionSnapshot entity was cleared.
+func (m *UserMutation) SnapshotsCleared() bool {
+	return m.clearedsnapshots
+}
+
+// RemoveSnapshotIDs removes the "snapshots" edge to the SessionSnapshot entity by IDs.
+func (m *UserMutation) RemoveSnapshotIDs(ids ...uuid.UUID) {
+	if m.removedsnapshots == nil {
+		m.removedsnapshots = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.snapshots, ids[i])
+		m.removedsnapshots[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedSnapshots returns the removed IDs of the "snapshots" edge to the SessionSnapshot entity.
+func (m *UserMutation) RemovedSnapshotsIDs() (ids []uuid.UUID) {
+	for id := range m.removedsnapshots {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// SnapshotsIDs returns the "snapshots" edge IDs in the mutation.
+func (m *UserMutation) SnapshotsIDs() (ids []uuid.UUID) {
+	for id := range m.snapshots {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetSnapshots resets all changes to the "snapshots" edge.
+func (m *UserMutation) ResetSnapshots() {
+	m.snapshots = nil
+	m.clearedsnapshots = false
+	m.removedsnapshots = nil
+}
+
+// AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
+func (m *UserMutation) AddAPIKeyIDs(ids ...uuid.UUID) {
+	if m.api_keys == nil {
+		m.api_keys = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.api_keys[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAPIKeys clears the "api_keys" edge to the APIKey entity.
+func (m *UserMutation) ClearAPIKeys() {
+	m.clearedapi_keys = true
+}
+
+// APIKeysCleared reports if the "api_keys" edge to the APIKey entity was cleared.
+func (m *UserMutation) APIKeysCleared() bool {
+	return m.clearedapi_keys
+}
+
+// RemoveAPIKeyIDs removes the "api_keys" edge to the APIKey entity by IDs.
+func (m *UserMutation) RemoveAPIKeyIDs(ids ...uuid.UUID) {
+	if m.removedapi_keys == nil {
+		m.removedapi_keys = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.api_keys, ids[i])
+		m.removedapi_keys[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAPIKeys returns the removed IDs of the "api_keys" edge to the APIKey entity.
+func (m *UserMutation) RemovedAPIKeysIDs() (ids []uuid.UUID) {
+	for id := range m.removedapi_keys {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// APIKeysIDs returns the "api_keys" edge IDs in the mutation.
+func (m *UserMutation) APIKeysIDs() (ids []uuid.UUID) {
+	for id := range m.api_keys {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAPIKeys resets all changes to the "api_keys" edge.
+func (m *UserMutation) ResetAPIKeys() {
+	m.api_keys = nil
+	m.clearedapi_keys = false
+	m.removedapi_keys = nil
+}
+
+// AddIdentityIDs adds the "identities" edge to the UserIdentity entity by ids.
+func (m *UserMutation) AddIdentityIDs(ids ...uuid.UUID) {
+	if m.identities == nil {
+		m.identities = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.identities[ids[i]] = struct{}{}
+	}
+}
+
+// ClearIdentities clears the "identities" edge to the UserIdentity entity.
+func (m *UserMutation) ClearIdentities() {
+	m.clearedidentities = true
+}
+
+// IdentitiesCleared reports if the "identities" edge to the UserIdentity entity was cleared.
+func (m *UserMutation) IdentitiesCleared() bool {
+	return m.clearedidentities
+}
+
+// RemoveIdentityIDs removes the "identities" edge to the UserIdentity entity by IDs.
+func (m *UserMutation) RemoveIdentityIDs(ids ...uuid.UUID) {
+	if m.removedidentities == nil {
+		m.removedidentities = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.identities, ids[i])
+		m.removedidentities[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedIdentities returns the removed IDs of the "identities" edge to the UserIdentity entity.
+func (m *UserMutation) RemovedIdentitiesIDs() (ids []uuid.UUID) {
+	for id := range m.removedidentities {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// IdentitiesIDs returns the "identities" edge IDs in the mutation.
+func (m *UserMutation) IdentitiesIDs() (ids []uuid.UUID) {
+	for id := range m.identities {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetIdentities resets all changes to the "identities" edge.
+func (m *UserMutation) ResetIdentities() {
+	m.identities = nil
+	m.clearedidentities = false
+	m.removedidentities = nil
+}
+
+// Where appends a list predicates to the UserMutation builder.
+func (m *UserMutation) Where(ps ...predicate.User) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.User, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *UserMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *UserMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (User).
+func (m *UserMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *UserMutation) Fields() []string {
+	fields := make([]string, 0, 15)
+	if m.email != nil {
+		fields = append(fields, user.FieldEmail)
+	}
+	if m.password_hash != nil {
+		fields = append(fields, user.FieldPasswordHash)
+	}
+	if m.google_id != nil {
+		fields = append(fields, user.FieldGoogleID)
+	}
+	if m.name != nil {
+		fields = append(fields, user.FieldName)
+	}
+	if m.avatar_url != nil {
+		fields = append(fields, user.FieldAvatarURL)
+	}
+	if m.provider != nil {
+		fields = append(fields, user.FieldProvider)
+	}
+	if m.role != nil {
+		fields = append(fields, user.FieldRole)
+	}
+	if m.plan != nil {
+		fields = append(fields, user.FieldPlan)
+	}
+	if m.email_verified != nil {
+		fields = append(fields, user.FieldEmailVerified)
+	}
+	if m.totp_enabled != nil {
+		fields = append(fields, user.FieldTotpEnabled)
+	}
+	if m.totp_secret != nil {
+		fields = append(fields, user.FieldTotpSecret)
+	}
+	if m.totp_recovery_codes != nil {
+		fields = append(fields, user.FieldTotpRecoveryCodes)
+	}
+	if m.created_at != nil {
+		fields = append(fields, user.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, user.FieldUpdatedAt)
+	}
+	if m.last_login != nil {
+		fields = append(fields, user.FieldLastLogin)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *UserMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldEmail:
+		return m.Email()
+	case user.FieldPasswordHash:
+		return m.PasswordHash()
+	case user.FieldGoogleID:
+		return m.GoogleID()
+	case user.FieldName:
+		return m.Name()
+	case user.FieldAvatarURL:
+		return m.AvatarURL()
+	case user.FieldProvider:
+		return m.Provider()
+	case user.FieldRole:
+		return m.Role()
+	case user.FieldPlan:
+		return m.Plan()
+	case user.FieldEmailVerified:
+		return m.EmailVerified()
+	case user.FieldTotpEnabled:
+		return m.TotpEnabled()
+	case user.FieldTotpSecret:
+		return m.TotpSecret()
+	case user.FieldTotpRecoveryCodes:
+		return m.TotpRecoveryCodes()
+	case user.FieldCreatedAt:
+		return m.CreatedAt()
+	case user.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case user.FieldLastLogin:
+		return m.LastLogin()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case user.FieldEmail:
+		return m.OldEmail(ctx)
+	case user.FieldPasswordHash:
+		return m.OldPasswordHash(ctx)
+	case user.FieldGoogleID:
+		return m.OldGoogleID(ctx)
+	case user.FieldName:
+		return m.OldName(ctx)
+	case user.FieldAvatarURL:
+		return m.OldAvatarURL(ctx)
+	case user.FieldProvider:
+		return m.OldProvider(ctx)
+	case user.FieldRole:
+		return m.OldRole(ctx)
+	case user.FieldPlan:
+		return m.OldPlan(ctx)
+	case user.FieldEmailVerified:
+		return m.OldEmailVerified(ctx)
+	case user.FieldTotpEnabled:
+		return m.OldTotpEnabled(ctx)
+	case user.FieldTotpSecret:
+		return m.OldTotpSecret(ctx)
+	case user.FieldTotpRecoveryCodes:
+		return m.OldTotpRecoveryCodes(ctx)
+	case user.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case user.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case user.FieldLastLogin:
+		return m.OldLastLogin(ctx)
+	}
+	return nil, fmt.Errorf("unknown User field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmail(v)
+		return nil
+	case user.FieldPasswordHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPasswordHash(v)
+		return nil
+	case user.FieldGoogleID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGoogleID(v)
+		return nil
+	case user.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case user.FieldAvatarURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvatarURL(v)
+		return nil
+	case user.FieldProvider:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProvider(v)
+		return nil
+	case user.FieldRole:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRole(v)
+		return nil
+	case user.FieldPlan:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPlan(v)
+		return nil
+	case user.FieldEmailVerified:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmailVerified(v)
+		return nil
+	case user.FieldTotpEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpEnabled(v)
+		return nil
+	case user.FieldTotpSecret:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpSecret(v)
+		return nil
+	case user.FieldTotpRecoveryCodes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpRecoveryCodes(v)
+		return nil
+	case user.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case user.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case user.FieldLastLogin:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastLogin(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *UserMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown User numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *UserMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(user.FieldPasswordHash) {
+		fields = append(fields, user.FieldPasswordHash)
+	}
+	if m.FieldCleared(user.FieldGoogleID) {
+		fields = append(fields, user.FieldGoogleID)
+	}
+	if m.FieldCleared(user.FieldName) {
+		fields = append(fields, user.FieldName)
+	}
+	if m.FieldCleared(user.FieldAvatarURL) {
+		fields = append(fields, user.FieldAvatarURL)
+	}
+	if m.FieldCleared(user.FieldTotpSecret) {
+		fields = append(fields, user.FieldTotpSecret)
+	}
+	if m.FieldCleared(user.FieldTotpRecoveryCodes) {
+		fields = append(fields, user.FieldTotpRecoveryCodes)
+	}
+	if m.FieldCleared(user.FieldLastLogin) {
+		fields = append(fields, user.FieldLastLogin)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *UserMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldPasswordHash:
+		m.ClearPasswordHash()
+		return nil
+	case user.FieldGoogleID:
+		m.ClearGoogleID()
+		return nil
+	case user.FieldName:
+		m.ClearName()
+		return nil
+	case user.FieldAvatarURL:
+		m.ClearAvatarURL()
+		return nil
+	case user.FieldTotpSecret:
+		m.ClearTotpSecret()
+		return nil
+	case user.FieldTotpRecoveryCodes:
+		m.ClearTotpRecoveryCodes()
+		return nil
+	case user.FieldLastLogin:
+		m.ClearLastLogin()
+		return nil
+	}
+	return fmt.Errorf("unknown User nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *UserMutation) ResetField(name string) error {
+	switch name {
+	case user.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case user.FieldPasswordHash:
+		m.ResetPasswordHash()
+		return nil
+	case user.FieldGoogleID:
+		m.ResetGoogleID()
+		return nil
+	case user.FieldName:
+		m.ResetName()
+		return nil
+	case user.FieldAvatarURL:
+		m.ResetAvatarURL()
+		return nil
+	case user.FieldProvider:
+		m.ResetProvider()
+		return nil
+	case user.FieldRole:
+		m.ResetRole()
+		return nil
+	case user.FieldPlan:
+		m.ResetPlan()
+		return nil
+	case user.FieldEmailVerified:
+		m.ResetEmailVerified()
+		return nil
+	case user.FieldTotpEnabled:
+		m.ResetTotpEnabled()
+		return nil
+	case user.FieldTotpSecret:
+		m.ResetTotpSecret()
+		return nil
+	case user.FieldTotpRecoveryCodes:
+		m.ResetTotpRecoveryCodes()
+		return nil
+	case user.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case user.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case user.FieldLastLogin:
+		m.ResetLastLogin()
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *UserMutation) AddedEdges() []string {
+	edges := make([]string, 0, 8)
+	if m.sessions != nil {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.search_history != nil {
+		edges = append(edges, user.EdgeSearchHistory)
+	}
+	if m.preferences != nil {
+		edges = append(edges, user.EdgePreferences)
+	}
+	if m.watches != nil {
+		edges = append(edges, user.EdgeWatches)
+	}
+	if m.shared_sessions != nil {
+		edges = append(edges, user.EdgeSharedSessions)
+	}
+	if m.snapshots != nil {
+		edges = append(edges, user.EdgeSnapshots)
+	}
+	if m.api_keys != nil {
+		edges = append(edges, user.EdgeAPIKeys)
+	}
+	if m.identities != nil {
+		edges = append(edges, user.EdgeIdentities)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *UserMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgeSessions:
+		ids := make([]ent.Value, 0, len(m.sessions))
+		for id := range m.sessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSearchHistory:
+		ids := make([]ent.Value, 0, len(m.search_history))
+		for id := range m.search_history {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgePreferences:
+		if id := m.preferences; id != nil {
+			return []ent.Value{*id}
+		}
+	case user.EdgeWatches:
+		ids := make([]ent.Value, 0, len(m.watches))
+		for id := range m.watches {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSharedSessions:
+		ids := make([]ent.Value, 0, len(m.shared_sessions))
+		for id := range m.shared_sessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSnapshots:
+		ids := make([]ent.Value, 0, len(m.snapshots))
+		for id := range m.snapshots {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAPIKeys:
+		ids := make([]ent.Value, 0, len(m.api_keys))
+		for id := range m.api_keys {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeIdentities:
+		ids := make([]ent.Value, 0, len(m.identities))
+		for id := range m.identities {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *UserMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 8)
+	if m.removedsessions != nil {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.removedsearch_history != nil {
+		edges = append(edges, user.EdgeSearchHistory)
+	}
+	if m.removedwatches != nil {
+		edges = append(edges, user.EdgeWatches)
+	}
+	if m.removedshared_sessions != nil {
+		edges = append(edges, user.EdgeSharedSessions)
+	}
+	if m.removedsnapshots != nil {
+		edges = append(edges, user.EdgeSnapshots)
+	}
+	if m.removedapi_keys != nil {
+		edges = append(edges, user.EdgeAPIKeys)
+	}
+	if m.removedidentities != nil {
+		edges = append(edges, user.EdgeIdentities)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgeSessions:
+		ids := make([]ent.Value, 0, len(m.removedsessions))
+		for id := range m.removedsessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSearchHistory:
+		ids := make([]ent.Value, 0, len(m.removedsearch_history))
+		for id := range m.removedsearch_history {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeWatches:
+		ids := make([]ent.Value, 0, len(m.removedwatches))
+		for id := range m.removedwatches {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSharedSessions:
+		ids := make([]ent.Value, 0, len(m.removedshared_sessions))
+		for id := range m.removedshared_sessions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeSnapshots:
+		ids := make([]ent.Value, 0, len(m.removedsnapshots))
+		for id := range m.removedsnapshots {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAPIKeys:
+		ids := make([]ent.Value, 0, len(m.removedapi_keys))
+		for id := range m.removedapi_keys {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeIdentities:
+		ids := make([]ent.Value, 0, len(m.removedidentities))
+		for id := range m.removedidentities {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *UserMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 8)
+	if m.clearedsessions {
+		edges = append(edges, user.EdgeSessions)
+	}
+	if m.clearedsearch_history {
+		edges = append(edges, user.EdgeSearchHistory)
+	}
+	if m.clearedpreferences {
+		edges = append(edges, user.EdgePreferences)
+	}
+	if m.clearedwatches {
+		edges = append(edges, user.EdgeWatches)
+	}
+	if m.clearedshared_sessions {
+		edges = append(edges, user.EdgeSharedSessions)
+	}
+	if m.clearedsnapshots {
+		edges = append(edges, user.EdgeSnapshots)
+	}
+	if m.clearedapi_keys {
+		edges = append(edges, user.EdgeAPIKeys)
+	}
+	if m.clearedidentities {
+		edges = append(edges, user.EdgeIdentities)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *UserMutation) EdgeCleared(name string) bool {
+	switch name {
+	case user.EdgeSessions:
+		return m.clearedsessions
+	case user.EdgeSearchHistory:
+		return m.clearedsearch_history
+	case user.EdgePreferences:
+		return m.clearedpreferences
+	case user.EdgeWatches:
+		return m.clearedwatches
+	case user.EdgeSharedSessions:
+		return m.clearedshared_sessions
+	case user.EdgeSnapshots:
+		return m.clearedsnapshots
+	case user.EdgeAPIKeys:
+		return m.clearedapi_keys
+	case user.EdgeIdentities:
+		return m.clearedidentities
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *UserMutation) ClearEdge(name string) error {
+	switch name {
+	case user.EdgePreferences:
+		m.ClearPreferences()
+		return nil
+	}
+	return fmt.Errorf("unknown User unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *UserMutation) ResetEdge(name string) error {
+	switch name {
+	case user.EdgeSessions:
+		m.ResetSessions()
+		return nil
+	case user.EdgeSearchHistory:
+		m.ResetSearchHistory()
+		return nil
+	case user.EdgePreferences:
+		m.ResetPreferences()
+		return nil
+	case user.EdgeWatches:
+		m.ResetWatches()
+		return nil
+	case user.EdgeSharedSessions:
+		m.ResetSharedSessions()
+		return nil
+	case user.EdgeSnapshots:
+		m.ResetSnapshots()
+		return nil
+	case user.EdgeAPIKeys:
+		m.ResetAPIKeys()
+		return nil
+	case user.EdgeIdentities:
+		m.ResetIdentities()
+		return nil
+	}
+	return fmt.Errorf("unknown User edge %s", name)
+}
+
+// UserIdentityMutation represents an operation that mutates the UserIdentity nodes in the graph.
+type UserIdentityMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	provider      *string
+	subject       *string
+	email         *string
+	created_at    *time.Time
+	last_used_at  *time.Time
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*UserIdentity, error)
+	predicates    []predicate.UserIdentity
+}
+
+var _ ent.Mutation = (*UserIdentityMutation)(nil)
+
+// useridentityOption allows management of the mutation configuration using functional options.
+type useridentityOption func(*UserIdentityMutation)
+
+// newUserIdentityMutation creates new mutation for the UserIdentity entity.
+func newUserIdentityMutation(c config, op Op, opts ...useridentityOption) *UserIdentityMutation {
+	m := &UserIdentityMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUserIdentity,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserIdentityID sets the ID field of the mutation.
+func withUserIdentityID(id uuid.UUID) useridentityOption {
+	return func(m *UserIdentityMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *UserIdentity
+		)
+		m.oldValue = func(ctx context.Context) (*UserIdentity, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().UserIdentity.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUserIdentity sets the old UserIdentity of the mutation.
+func withUserIdentity(node *UserIdentity) useridentityOption {
+	return func(m *UserIdentityMutation) {
+		m.oldValue = func(context.Context) (*UserIdentity, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserIdentityMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserIdentityMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of UserIdentity entities.
+func (m *UserIdentityMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserIdentityMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserIdentityMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().UserIdentity.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *UserIdentityMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *UserIdentityMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserIdentityMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *UserIdentityMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetProvider sets the "provider" field.
+func (m *UserIdentityMutation) SetProvider(s string) {
+	m.provider = &s
+}
+
+// Provider returns the value of the "provider" field in the mutation.
+func (m *UserIdentityMutation) Provider() (r string, exists bool) {
+	v := m.provider
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProvider returns the old "provider" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserIdentityMutation) OldProvider(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProvider requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+	}
+	return oldValue.Provider, nil
+}
+
+// ResetProvider resets all changes to the "provider" field.
+func (m *UserIdentityMutation) ResetProvider() {
+	m.provider = nil
+}
+
+// SetSubject sets the "subject" field.
+func (m *UserIdentityMutation) SetSubject(s string) {
+	m.subject = &s
+}
+
+// Subject returns the value of the "subject" field in the mutation.
+func (m *UserIdentityMutation) Subject() (r string, exists bool) {
+	v := m.subject
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSubject returns the old "subject" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserIdentityMutation) OldSubject(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSubject is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSubject requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSubject: %w", err)
+	}
+	return oldValue.Subject, nil
+}
+
+// ResetSubject resets all changes to the "subject" field.
+func (m *UserIdentityMutation) ResetSubject() {
+	m.subject = nil
+}
+
+// SetEmail sets the "email" field.
+func (m *UserIdentityMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *UserIdentityMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserIdentityMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ClearEmail clears the value of the "email" field.
+func (m *UserIdentityMutation) ClearEmail() {
+	m.email = nil
+	m.clearedFields[useridentity.FieldEmail] = struct{}{}
+}
+
+// EmailCleared returns if the "email" field was cleared in this mutation.
+func (m *UserIdentityMutation) EmailCleared() bool {
+	_, ok := m.clearedFields[useridentity.FieldEmail]
+	return ok
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *UserIdentityMutation) ResetEmail() {
+	m.email = nil
+	delete(m.clearedFields, useridentity.FieldEmail)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *UserIdentityMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *UserIdentityMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserIdentityMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *UserIdentityMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// SetExpiresAt sets the "expires_at" field.
-func (m *SearchHistoryMutation) SetExpiresAt(t time.Time) {
-	m.expires_at = &t
-}
-
-// ExpiresAt returns the value of the "expires_at" field in the mutation.
-func (m *SearchHistoryMutation) ExpiresAt() (r time.Time, exists bool) {
-	v := m.expires_at
+// SetLastUsedAt sets the "last_used_at" field.
+func (m *UserIdentityMutation) SetLastUsedAt(t time.Time) {
+	m.last_used_at = &t
+}
+
+// LastUsedAt returns the value of the "last_used_at" field in the mutation.
+func (m *UserIdentityMutation) LastUsedAt() (r time.Time, exists bool) {
+	v := m.last_used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiresAt returns the old "expires_at" field's value of the SearchHistory entity.
-// If the SearchHistory object wasn't provided to the builder, the object is fetched from the database.
+// OldLastUsedAt returns the old "last_used_at" field's value of the UserIdentity entity.
+// If the UserIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *SearchHistoryMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+func (m *UserIdentityMutation) OldLastUsedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
 	}
-	return oldValue.ExpiresAt, nil
+	return oldValue.LastUsedAt, nil
 }
 
-// ClearExpiresAt clears the value of the "expires_at" field.
-func (m *SearchHistoryMutation) ClearExpiresAt() {
-	m.expires_at = nil
-	m.clearedFields[searchhistory.FieldExpiresAt] = struct{}{}
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (m *UserIdentityMutation) ClearLastUsedAt() {
+	m.last_used_at = nil
+	m.clearedFields[useridentity.FieldLastUsedAt] = struct{}{}
 }
 
-// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
-func (m *SearchHistoryMutation) ExpiresAtCleared() bool {
-	_, ok := m.clearedFields[searchhistory.FieldExpiresAt]
+// LastUsedAtCleared returns if the "last_used_at" field was cleared in this mutation.
+func (m *UserIdentityMutation) LastUsedAtCleared() bool {
+	_, ok := m.clearedFields[useridentity.FieldLastUsedAt]
 	return ok
 }
 
-// ResetExpiresAt resets all changes to the "expires_at" field.
-func (m *SearchHistoryMutation) ResetExpiresAt() {
-	m.expires_at = nil
-	delete(m.clearedFields, searchhistory.FieldExpiresAt)
+// ResetLastUsedAt resets all changes to the "last_used_at" field.
+func (m *UserIdentityMutation) ResetLastUsedAt() {
+	m.last_used_at = nil
+	delete(m.clearedFields, useridentity.FieldLastUsedAt)
 }
 
 // ClearUser clears the "user" edge to the User entity.
-func (m *SearchHistoryMutation) ClearUser() {
+func (m *UserIdentityMutation) ClearUser() {
 	m.cleareduser = true
-	m.clearedFields[searchhistory.FieldUserID] = struct{}{}
+	m.clearedFields[useridentity.FieldUserID] = struct{}{}
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *SearchHistoryMutation) UserCleared() bool {
-	return m.UserIDCleared() || m.cleareduser
+func (m *UserIdentityMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *SearchHistoryMutation) UserIDs() (ids []uuid.UUID) {
+func (m *UserIdentityMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -2841,20 +11431,20 @@ func (m *SearchHistoryMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *SearchHistoryMutation) ResetUser() {
+func (m *UserIdentityMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// Where appends a list predicates to the SearchHistoryMutation builder.
-func (m *SearchHistoryMutation) Where(ps ...predicate.SearchHistory) {
+// Where appends a list predicates to the UserIdentityMutation builder.
+func (m *UserIdentityMutation) Where(ps ...predicate.UserIdentity) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the SearchHistoryMutation builder. Using this method,
+// WhereP appends storage-level predicates to the UserIdentityMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *SearchHistoryMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.SearchHistory, len(ps))
+func (m *UserIdentityMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.UserIdentity, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -2862,66 +11452,42 @@ func (m *SearchHistoryMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *SearchHistoryMutation) Op() Op {
+func (m *UserIdentityMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *SearchHistoryMutation) SetOp(op Op) {
+func (m *UserIdentityMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (SearchHistory).
-func (m *SearchHistoryMutation) Type() string {
+// Type returns the node type of this mutation (UserIdentity).
+func (m *UserIdentityMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *SearchHistoryMutation) Fields() []string {
-	fields := make([]string, 0, 14)
+func (m *UserIdentityMutation) Fields() []string {
+	fields := make([]string, 0, 6)
 	if m.user != nil {
-		fields = append(fields, searchhistory.FieldUserID)
-	}
-	if m.session_id != nil {
-		fields = append(fields, searchhistory.FieldSessionID)
-	}
-	if m.search_query != nil {
-		fields = append(fields, searchhistory.FieldSearchQuery)
-	}
-	if m.optimized_query != nil {
-		fields = append(fields, searchhistory.FieldOptimizedQuery)
-	}
-	if m.search_type != nil {
-		fields = append(fields, searchhistory.FieldSearchType)
-	}
-	if m.category != nil {
-		fields = append(fields, searchhistory.FieldCategory)
-	}
-	if m.country_code != nil {
-		fields = append(fields, searchhistory.FieldCountryCode)
-	}
-	if m.language_code != nil {
-		fields = append(fields, searchhistory.FieldLanguageCode)
-	}
-	if m.currency != nil {
-		fields = append(fields, searchhistory.FieldCurrency)
+		fields = append(fields, useridentity.FieldUserID)
 	}
-	if m.result_count != nil {
-		fields = append(fields, searchhistory.FieldResultCount)
+	if m.provider != nil {
+		fields = append(fields, useridentity.FieldProvider)
 	}
-	if m.products_found != nil {
-		fields = append(fields, searchhistory.FieldProductsFound)
+	if m.subject != nil {
+		fields = append(fields, useridentity.FieldSubject)
 	}
-	if m.clicked_product_id != nil {
-		fields = append(fields, searchhistory.FieldClickedProductID)
+	if m.email != nil {
+		fields = append(fields, useridentity.FieldEmail)
 	}
 	if m.created_at != nil {
-		fields = append(fields, searchhistory.FieldCreatedAt)
+		fields = append(fields, useridentity.FieldCreatedAt)
 	}
-	if m.expires_at != nil {
-		fields = append(fields, searchhistory.FieldExpiresAt)
+	if m.last_used_at != nil {
+		fields = append(fields, useridentity.FieldLastUsedAt)
 	}
 	return fields
 }
@@ -2929,36 +11495,20 @@ func (m *SearchHistoryMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *SearchHistoryMutation) Field(name string) (ent.Value, bool) {
+func (m *UserIdentityMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case searchhistory.FieldUserID:
+	case useridentity.FieldUserID:
 		return m.UserID()
-	case searchhistory.FieldSessionID:
-		return m.SessionID()
-	case searchhistory.FieldSearchQuery:
-		return m.SearchQuery()
-	case searchhistory.FieldOptimizedQuery:
-		return m.OptimizedQuery()
-	case searchhistory.FieldSearchType:
-		return m.SearchType()
-	case searchhistory.FieldCategory:
-		return m.Category()
-	case searchhistory.FieldCountryCode:
-		return m.CountryCode()
-	case searchhistory.FieldLanguageCode:
-		return m.LanguageCode()
-	case searchhistory.FieldCurrency:
-		return m.Currency()
-	case searchhistory.FieldResultCount:
-		return m.ResultCount()
-	case searchhistory.FieldProductsFound:
-		return m.ProductsFound()
-	case searchhistory.FieldClickedProductID:
-		return m.ClickedProductID()
-	case searchhistory.FieldCreatedAt:
+	case useridentity.FieldProvider:
+		return m.Provider()
+	case useridentity.FieldSubject:
+		return m.Subject()
+	case useridentity.FieldEmail:
+		return m.Email()
+	case useridentity.FieldCreatedAt:
 		return m.CreatedAt()
-	case searchhistory.FieldExpiresAt:
-		return m.ExpiresAt()
+	case useridentity.FieldLastUsedAt:
+		return m.LastUsedAt()
 	}
 	return nil, false
 }
@@ -2966,312 +11516,171 @@ func (m *SearchHistoryMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *SearchHistoryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *UserIdentityMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case searchhistory.FieldUserID:
+	case useridentity.FieldUserID:
 		return m.OldUserID(ctx)
-	case searchhistory.FieldSessionID:
-		return m.OldSessionID(ctx)
-	case searchhistory.FieldSearchQuery:
-		return m.OldSearchQuery(ctx)
-	case searchhistory.FieldOptimizedQuery:
-		return m.OldOptimizedQuery(ctx)
-	case searchhistory.FieldSearchType:
-		return m.OldSearchType(ctx)
-	case searchhistory.FieldCategory:
-		return m.OldCategory(ctx)
-	case searchhistory.FieldCountryCode:
-		return m.OldCountryCode(ctx)
-	case searchhistory.FieldLanguageCode:
-		return m.OldLanguageCode(ctx)
-	case searchhistory.FieldCurrency:
-		return m.OldCurrency(ctx)
-	case searchhistory.FieldResultCount:
-		return m.OldResultCount(ctx)
-	case searchhistory.FieldProductsFound:
-		return m.OldProductsFound(ctx)
-	case searchhistory.FieldClickedProductID:
-		return m.OldClickedProductID(ctx)
-	case searchhistory.FieldCreatedAt:
+	case useridentity.FieldProvider:
+		return m.OldProvider(ctx)
+	case useridentity.FieldSubject:
+		return m.OldSubject(ctx)
+	case useridentity.FieldEmail:
+		return m.OldEmail(ctx)
+	case useridentity.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case searchhistory.FieldExpiresAt:
-		return m.OldExpiresAt(ctx)
+	case useridentity.FieldLastUsedAt:
+		return m.OldLastUsedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown SearchHistory field %s", name)
+	return nil, fmt.Errorf("unknown UserIdentity field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *SearchHistoryMutation) SetField(name string, value ent.Value) error {
+func (m *UserIdentityMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case searchhistory.FieldUserID:
+	case useridentity.FieldUserID:
 		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetUserID(v)
 		return nil
-	case searchhistory.FieldSessionID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSessionID(v)
-		return nil
-	case searchhistory.FieldSearchQuery:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSearchQuery(v)
-		return nil
-	case searchhistory.FieldOptimizedQuery:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetOptimizedQuery(v)
-		return nil
-	case searchhistory.FieldSearchType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSearchType(v)
-		return nil
-	case searchhistory.FieldCategory:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCategory(v)
-		return nil
-	case searchhistory.FieldCountryCode:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCountryCode(v)
-		return nil
-	case searchhistory.FieldLanguageCode:
+	case useridentity.FieldProvider:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLanguageCode(v)
+		m.SetProvider(v)
 		return nil
-	case searchhistory.FieldCurrency:
+	case useridentity.FieldSubject:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCurrency(v)
-		return nil
-	case searchhistory.FieldResultCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetResultCount(v)
-		return nil
-	case searchhistory.FieldProductsFound:
-		v, ok := value.([]map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetProductsFound(v)
+		m.SetSubject(v)
 		return nil
-	case searchhistory.FieldClickedProductID:
+	case useridentity.FieldEmail:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetClickedProductID(v)
+		m.SetEmail(v)
 		return nil
-	case searchhistory.FieldCreatedAt:
+	case useridentity.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case searchhistory.FieldExpiresAt:
+	case useridentity.FieldLastUsedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetExpiresAt(v)
+		m.SetLastUsedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown SearchHistory field %s", name)
+	return fmt.Errorf("unknown UserIdentity field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *SearchHistoryMutation) AddedFields() []string {
-	var fields []string
-	if m.addresult_count != nil {
-		fields = append(fields, searchhistory.FieldResultCount)
-	}
-	return fields
+func (m *UserIdentityMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *SearchHistoryMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case searchhistory.FieldResultCount:
-		return m.AddedResultCount()
-	}
-	return nil, false
-}
-
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *SearchHistoryMutation) AddField(name string, value ent.Value) error {
-	switch name {
-	case searchhistory.FieldResultCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddResultCount(v)
-		return nil
-	}
-	return fmt.Errorf("unknown SearchHistory numeric field %s", name)
-}
-
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *SearchHistoryMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(searchhistory.FieldUserID) {
-		fields = append(fields, searchhistory.FieldUserID)
-	}
-	if m.FieldCleared(searchhistory.FieldSessionID) {
-		fields = append(fields, searchhistory.FieldSessionID)
-	}
-	if m.FieldCleared(searchhistory.FieldOptimizedQuery) {
-		fields = append(fields, searchhistory.FieldOptimizedQuery)
-	}
-	if m.FieldCleared(searchhistory.FieldCategory) {
-		fields = append(fields, searchhistory.FieldCategory)
-	}
-	if m.FieldCleared(searchhistory.FieldProductsFound) {
-		fields = append(fields, searchhistory.FieldProductsFound)
+// was not set, or was not defined in the schema.
+func (m *UserIdentityMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserIdentityMutation) AddField(name string, value ent.Value) error {
+	switch name {
 	}
-	if m.FieldCleared(searchhistory.FieldClickedProductID) {
-		fields = append(fields, searchhistory.FieldClickedProductID)
+	return fmt.Errorf("unknown UserIdentity numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *UserIdentityMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(useridentity.FieldEmail) {
+		fields = append(fields, useridentity.FieldEmail)
 	}
-	if m.FieldCleared(searchhistory.FieldExpiresAt) {
-		fields = append(fields, searchhistory.FieldExpiresAt)
+	if m.FieldCleared(useridentity.FieldLastUsedAt) {
+		fields = append(fields, useridentity.FieldLastUsedAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *SearchHistoryMutation) FieldCleared(name string) bool {
+func (m *UserIdentityMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *SearchHistoryMutation) ClearField(name string) error {
+func (m *UserIdentityMutation) ClearField(name string) error {
 	switch name {
-	case searchhistory.FieldUserID:
-		m.ClearUserID()
-		return nil
-	case searchhistory.FieldSessionID:
-		m.ClearSessionID()
-		return nil
-	case searchhistory.FieldOptimizedQuery:
-		m.ClearOptimizedQuery()
-		return nil
-	case searchhistory.FieldCategory:
-		m.ClearCategory()
-		return nil
-	case searchhistory.FieldProductsFound:
-		m.ClearProductsFound()
-		return nil
-	case searchhistory.FieldClickedProductID:
-		m.ClearClickedProductID()
+	case useridentity.FieldEmail:
+		m.ClearEmail()
 		return nil
-	case searchhistory.FieldExpiresAt:
-		m.ClearExpiresAt()
+	case useridentity.FieldLastUsedAt:
+		m.ClearLastUsedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown SearchHistory nullable field %s", name)
+	return fmt.Errorf("unknown UserIdentity nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *SearchHistoryMutation) ResetField(name string) error {
+func (m *UserIdentityMutation) ResetField(name string) error {
 	switch name {
-	case searchhistory.FieldUserID:
+	case useridentity.FieldUserID:
 		m.ResetUserID()
 		return nil
-	case searchhistory.FieldSessionID:
-		m.ResetSessionID()
-		return nil
-	case searchhistory.FieldSearchQuery:
-		m.ResetSearchQuery()
-		return nil
-	case searchhistory.FieldOptimizedQuery:
-		m.ResetOptimizedQuery()
-		return nil
-	case searchhistory.FieldSearchType:
-		m.ResetSearchType()
-		return nil
-	case searchhistory.FieldCategory:
-		m.ResetCategory()
-		return nil
-	case searchhistory.FieldCountryCode:
-		m.ResetCountryCode()
-		return nil
-	case searchhistory.FieldLanguageCode:
-		m.ResetLanguageCode()
-		return nil
-	case searchhistory.FieldCurrency:
-		m.ResetCurrency()
-		return nil
-	case searchhistory.FieldResultCount:
-		m.ResetResultCount()
+	case useridentity.FieldProvider:
+		m.ResetProvider()
 		return nil
-	case searchhistory.FieldProductsFound:
-		m.ResetProductsFound()
+	case useridentity.FieldSubject:
+		m.ResetSubject()
 		return nil
-	case searchhistory.FieldClickedProductID:
-		m.ResetClickedProductID()
+	case useridentity.FieldEmail:
+		m.ResetEmail()
 		return nil
-	case searchhistory.FieldCreatedAt:
+	case useridentity.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case searchhistory.FieldExpiresAt:
-		m.ResetExpiresAt()
+	case useridentity.FieldLastUsedAt:
+		m.ResetLastUsedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown SearchHistory field %s", name)
+	return fmt.Errorf("unknown UserIdentity field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *SearchHistoryMutation) AddedEdges() []string {
+func (m *UserIdentityMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, searchhistory.EdgeUser)
+		edges = append(edges, useridentity.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *SearchHistoryMutation) AddedIDs(name string) []ent.Value {
+func (m *UserIdentityMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case searchhistory.EdgeUser:
+	case useridentity.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
@@ -3280,31 +11689,31 @@ func (m *SearchHistoryMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *SearchHistoryMutation) RemovedEdges() []string {
+func (m *UserIdentityMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *SearchHistoryMutation) RemovedIDs(name string) []ent.Value {
+func (m *UserIdentityMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *SearchHistoryMutation) ClearedEdges() []string {
+func (m *UserIdentityMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, searchhistory.EdgeUser)
+		edges = append(edges, useridentity.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *SearchHistoryMutation) EdgeCleared(name string) bool {
+func (m *UserIdentityMutation) EdgeCleared(name string) bool {
 	switch name {
-	case searchhistory.EdgeUser:
+	case useridentity.EdgeUser:
 		return m.cleareduser
 	}
 	return false
@@ -3312,66 +11721,60 @@ func (m *SearchHistoryMutation) EdgeCleared(name string) bool {
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *SearchHistoryMutation) ClearEdge(name string) error {
+func (m *UserIdentityMutation) ClearEdge(name string) error {
 	switch name {
-	case searchhistory.EdgeUser:
+	case useridentity.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown SearchHistory unique edge %s", name)
+	return fmt.Errorf("unknown UserIdentity unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *SearchHistoryMutation) ResetEdge(name string) error {
+func (m *UserIdentityMutation) ResetEdge(name string) error {
 	switch name {
-	case searchhistory.EdgeUser:
+	case useridentity.EdgeUser:
 		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown SearchHistory edge %s", name)
+	return fmt.Errorf("unknown UserIdentity edge %s", name)
 }
 
-// UserMutation represents an operation that mutates the User nodes in the graph.
-type UserMutation struct {
+// UserPreferenceMutation represents an operation that mutates the UserPreference nodes in the graph.
+type UserPreferenceMutation struct {
 	config
-	op                    Op
-	typ                   string
-	id                    *uuid.UUID
-	email                 *string
-	password_hash         *string
-	google_id             *string
-	name                  *string
-	avatar_url            *string
-	provider              *string
-	created_at            *time.Time
-	updated_at            *time.Time
-	last_login            *time.Time
-	clearedFields         map[string]struct{}
-	sessions              map[uuid.UUID]struct{}
-	removedsessions       map[uuid.UUID]struct{}
-	clearedsessions       bool
-	search_history        map[uuid.UUID]struct{}
-	removedsearch_history map[uuid.UUID]struct{}
-	clearedsearch_history bool
-	preferences           *uuid.UUID
-	clearedpreferences    bool
-	done                  bool
-	oldValue              func(context.Context) (*User, error)
-	predicates            []predicate.User
+	op                     Op
+	typ                    string
+	id                     *uuid.UUID
+	country                *string
+	currency               *string
+	language               *string
+	theme                  *string
+	sidebar_open           *bool
+	last_active_session_id *string
+	saved_search           *map[string]interface{}
+	created_at             *time.Time
+	updated_at             *time.Time
+	clearedFields          map[string]struct{}
+	user                   *uuid.UUID
+	cleareduser            bool
+	done                   bool
+	oldValue               func(context.Context) (*UserPreference, error)
+	predicates             []predicate.UserPreference
 }
 
-var _ ent.Mutation = (*UserMutation)(nil)
+var _ ent.Mutation = (*UserPreferenceMutation)(nil)
 
-// userOption allows management of the mutation configuration using functional options.
-type userOption func(*UserMutation)
+// userpreferenceOption allows management of the mutation configuration using functional options.
+type userpreferenceOption func(*UserPreferenceMutation)
 
-// newUserMutation creates new mutation for the User entity.
-func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
-	m := &UserMutation{
+// newUserPreferenceMutation creates new mutation for the UserPreference entity.
+func newUserPreferenceMutation(c config, op Op, opts ...userpreferenceOption) *UserPreferenceMutation {
+	m := &UserPreferenceMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeUser,
+		typ:           TypeUserPreference,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3380,20 +11783,20 @@ func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
 	return m
 }
 
-// withUserID sets the ID field of the mutation.
-func withUserID(id uuid.UUID) userOption {
-	return func(m *UserMutation) {
+// withUserPreferenceID sets the ID field of the mutation.
+func withUserPreferenceID(id uuid.UUID) userpreferenceOption {
+	return func(m *UserPreferenceMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *User
+			value *UserPreference
 		)
-		m.oldValue = func(ctx context.Context) (*User, error) {
+		m.oldValue = func(ctx context.Context) (*UserPreference, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().User.Get(ctx, id)
+					value, err = m.Client().UserPreference.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3402,10 +11805,10 @@ func withUserID(id uuid.UUID) userOption {
 	}
 }
 
-// withUser sets the old User of the mutation.
-func withUser(node *User) userOption {
-	return func(m *UserMutation) {
-		m.oldValue = func(context.Context) (*User, error) {
+// withUserPreference sets the old UserPreference of the mutation.
+func withUserPreference(node *UserPreference) userpreferenceOption {
+	return func(m *UserPreferenceMutation) {
+		m.oldValue = func(context.Context) (*UserPreference, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3414,7 +11817,7 @@ func withUser(node *User) userOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UserMutation) Client() *Client {
+func (m UserPreferenceMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3422,7 +11825,7 @@ func (m UserMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m UserMutation) Tx() (*Tx, error) {
+func (m UserPreferenceMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -3432,314 +11835,425 @@ func (m UserMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of User entities.
-func (m *UserMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of UserPreference entities.
+func (m *UserPreferenceMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *UserMutation) ID() (id uuid.UUID, exists bool) {
-	if m.id == nil {
-		return
-	}
-	return *m.id, true
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserPreferenceMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserPreferenceMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().UserPreference.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *UserPreferenceMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *UserPreferenceMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserPreferenceMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *UserPreferenceMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetCountry sets the "country" field.
+func (m *UserPreferenceMutation) SetCountry(s string) {
+	m.country = &s
+}
+
+// Country returns the value of the "country" field in the mutation.
+func (m *UserPreferenceMutation) Country() (r string, exists bool) {
+	v := m.country
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCountry returns the old "country" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserPreferenceMutation) OldCountry(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCountry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCountry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCountry: %w", err)
+	}
+	return oldValue.Country, nil
+}
+
+// ClearCountry clears the value of the "country" field.
+func (m *UserPreferenceMutation) ClearCountry() {
+	m.country = nil
+	m.clearedFields[userpreference.FieldCountry] = struct{}{}
+}
+
+// CountryCleared returns if the "country" field was cleared in this mutation.
+func (m *UserPreferenceMutation) CountryCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldCountry]
+	return ok
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []uuid.UUID{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
+// ResetCountry resets all changes to the "country" field.
+func (m *UserPreferenceMutation) ResetCountry() {
+	m.country = nil
+	delete(m.clearedFields, userpreference.FieldCountry)
 }
 
-// SetEmail sets the "email" field.
-func (m *UserMutation) SetEmail(s string) {
-	m.email = &s
+// SetCurrency sets the "currency" field.
+func (m *UserPreferenceMutation) SetCurrency(s string) {
+	m.currency = &s
 }
 
-// Email returns the value of the "email" field in the mutation.
-func (m *UserMutation) Email() (r string, exists bool) {
-	v := m.email
+// Currency returns the value of the "currency" field in the mutation.
+func (m *UserPreferenceMutation) Currency() (r string, exists bool) {
+	v := m.currency
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldEmail returns the old "email" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldCurrency returns the old "currency" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldCurrency(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldEmail requires an ID field in the mutation")
+		return v, errors.New("OldCurrency requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+		return v, fmt.Errorf("querying old value for OldCurrency: %w", err)
 	}
-	return oldValue.Email, nil
+	return oldValue.Currency, nil
 }
 
-// ResetEmail resets all changes to the "email" field.
-func (m *UserMutation) ResetEmail() {
-	m.email = nil
+// ClearCurrency clears the value of the "currency" field.
+func (m *UserPreferenceMutation) ClearCurrency() {
+	m.currency = nil
+	m.clearedFields[userpreference.FieldCurrency] = struct{}{}
 }
 
-// SetPasswordHash sets the "password_hash" field.
-func (m *UserMutation) SetPasswordHash(s string) {
-	m.password_hash = &s
+// CurrencyCleared returns if the "currency" field was cleared in this mutation.
+func (m *UserPreferenceMutation) CurrencyCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldCurrency]
+	return ok
 }
 
-// PasswordHash returns the value of the "password_hash" field in the mutation.
-func (m *UserMutation) PasswordHash() (r string, exists bool) {
-	v := m.password_hash
+// ResetCurrency resets all changes to the "currency" field.
+func (m *UserPreferenceMutation) ResetCurrency() {
+	m.currency = nil
+	delete(m.clearedFields, userpreference.FieldCurrency)
+}
+
+// SetLanguage sets the "language" field.
+func (m *UserPreferenceMutation) SetLanguage(s string) {
+	m.language = &s
+}
+
+// Language returns the value of the "language" field in the mutation.
+func (m *UserPreferenceMutation) Language() (r string, exists bool) {
+	v := m.language
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPasswordHash returns the old "password_hash" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldLanguage returns the old "language" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldPasswordHash(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldLanguage(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPasswordHash is only allowed on UpdateOne operations")
+		return v, errors.New("OldLanguage is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPasswordHash requires an ID field in the mutation")
+		return v, errors.New("OldLanguage requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPasswordHash: %w", err)
+		return v, fmt.Errorf("querying old value for OldLanguage: %w", err)
 	}
-	return oldValue.PasswordHash, nil
+	return oldValue.Language, nil
 }
 
-// ClearPasswordHash clears the value of the "password_hash" field.
-func (m *UserMutation) ClearPasswordHash() {
-	m.password_hash = nil
-	m.clearedFields[user.FieldPasswordHash] = struct{}{}
+// ClearLanguage clears the value of the "language" field.
+func (m *UserPreferenceMutation) ClearLanguage() {
+	m.language = nil
+	m.clearedFields[userpreference.FieldLanguage] = struct{}{}
 }
 
-// PasswordHashCleared returns if the "password_hash" field was cleared in this mutation.
-func (m *UserMutation) PasswordHashCleared() bool {
-	_, ok := m.clearedFields[user.FieldPasswordHash]
+// LanguageCleared returns if the "language" field was cleared in this mutation.
+func (m *UserPreferenceMutation) LanguageCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldLanguage]
 	return ok
 }
 
-// ResetPasswordHash resets all changes to the "password_hash" field.
-func (m *UserMutation) ResetPasswordHash() {
-	m.password_hash = nil
-	delete(m.clearedFields, user.FieldPasswordHash)
+// ResetLanguage resets all changes to the "language" field.
+func (m *UserPreferenceMutation) ResetLanguage() {
+	m.language = nil
+	delete(m.clearedFields, userpreference.FieldLanguage)
 }
 
-// SetGoogleID sets the "google_id" field.
-func (m *UserMutation) SetGoogleID(s string) {
-	m.google_id = &s
+// SetTheme sets the "theme" field.
+func (m *UserPreferenceMutation) SetTheme(s string) {
+	m.theme = &s
 }
 
-// GoogleID returns the value of the "google_id" field in the mutation.
-func (m *UserMutation) GoogleID() (r string, exists bool) {
-	v := m.google_id
+// Theme returns the value of the "theme" field in the mutation.
+func (m *UserPreferenceMutation) Theme() (r string, exists bool) {
+	v := m.theme
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGoogleID returns the old "google_id" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldTheme returns the old "theme" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldGoogleID(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldTheme(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldGoogleID is only allowed on UpdateOne operations")
+		return v, errors.New("OldTheme is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldGoogleID requires an ID field in the mutation")
+		return v, errors.New("OldTheme requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldGoogleID: %w", err)
+		return v, fmt.Errorf("querying old value for OldTheme: %w", err)
 	}
-	return oldValue.GoogleID, nil
+	return oldValue.Theme, nil
 }
 
-// ClearGoogleID clears the value of the "google_id" field.
-func (m *UserMutation) ClearGoogleID() {
-	m.google_id = nil
-	m.clearedFields[user.FieldGoogleID] = struct{}{}
+// ClearTheme clears the value of the "theme" field.
+func (m *UserPreferenceMutation) ClearTheme() {
+	m.theme = nil
+	m.clearedFields[userpreference.FieldTheme] = struct{}{}
 }
 
-// GoogleIDCleared returns if the "google_id" field was cleared in this mutation.
-func (m *UserMutation) GoogleIDCleared() bool {
-	_, ok := m.clearedFields[user.FieldGoogleID]
+// ThemeCleared returns if the "theme" field was cleared in this mutation.
+func (m *UserPreferenceMutation) ThemeCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldTheme]
 	return ok
 }
 
-// ResetGoogleID resets all changes to the "google_id" field.
-func (m *UserMutation) ResetGoogleID() {
-	m.google_id = nil
-	delete(m.clearedFields, user.FieldGoogleID)
+// ResetTheme resets all changes to the "theme" field.
+func (m *UserPreferenceMutation) ResetTheme() {
+	m.theme = nil
+	delete(m.clearedFields, userpreference.FieldTheme)
 }
 
-// SetName sets the "name" field.
-func (m *UserMutation) SetName(s string) {
-	m.name = &s
+// SetSidebarOpen sets the "sidebar_open" field.
+func (m *UserPreferenceMutation) SetSidebarOpen(b bool) {
+	m.sidebar_open = &b
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *UserMutation) Name() (r string, exists bool) {
-	v := m.name
+// SidebarOpen returns the value of the "sidebar_open" field in the mutation.
+func (m *UserPreferenceMutation) SidebarOpen() (r bool, exists bool) {
+	v := m.sidebar_open
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldSidebarOpen returns the old "sidebar_open" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldSidebarOpen(ctx context.Context) (v *bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldSidebarOpen is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldSidebarOpen requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldSidebarOpen: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.SidebarOpen, nil
 }
 
-// ClearName clears the value of the "name" field.
-func (m *UserMutation) ClearName() {
-	m.name = nil
-	m.clearedFields[user.FieldName] = struct{}{}
+// ClearSidebarOpen clears the value of the "sidebar_open" field.
+func (m *UserPreferenceMutation) ClearSidebarOpen() {
+	m.sidebar_open = nil
+	m.clearedFields[userpreference.FieldSidebarOpen] = struct{}{}
 }
 
-// NameCleared returns if the "name" field was cleared in this mutation.
-func (m *UserMutation) NameCleared() bool {
-	_, ok := m.clearedFields[user.FieldName]
+// SidebarOpenCleared returns if the "sidebar_open" field was cleared in this mutation.
+func (m *UserPreferenceMutation) SidebarOpenCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldSidebarOpen]
 	return ok
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *UserMutation) ResetName() {
-	m.name = nil
-	delete(m.clearedFields, user.FieldName)
+// ResetSidebarOpen resets all changes to the "sidebar_open" field.
+func (m *UserPreferenceMutation) ResetSidebarOpen() {
+	m.sidebar_open = nil
+	delete(m.clearedFields, userpreference.FieldSidebarOpen)
 }
 
-// SetAvatarURL sets the "avatar_url" field.
-func (m *UserMutation) SetAvatarURL(s string) {
-	m.avatar_url = &s
+// SetLastActiveSessionID sets the "last_active_session_id" field.
+func (m *UserPreferenceMutation) SetLastActiveSessionID(s string) {
+	m.last_active_session_id = &s
 }
 
-// AvatarURL returns the value of the "avatar_url" field in the mutation.
-func (m *UserMutation) AvatarURL() (r string, exists bool) {
-	v := m.avatar_url
+// LastActiveSessionID returns the value of the "last_active_session_id" field in the mutation.
+func (m *UserPreferenceMutation) LastActiveSessionID() (r string, exists bool) {
+	v := m.last_active_session_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvatarURL returns the old "avatar_url" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldLastActiveSessionID returns the old "last_active_session_id" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldAvatarURL(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldLastActiveSessionID(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvatarURL is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastActiveSessionID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvatarURL requires an ID field in the mutation")
+		return v, errors.New("OldLastActiveSessionID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvatarURL: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastActiveSessionID: %w", err)
 	}
-	return oldValue.AvatarURL, nil
+	return oldValue.LastActiveSessionID, nil
 }
 
-// ClearAvatarURL clears the value of the "avatar_url" field.
-func (m *UserMutation) ClearAvatarURL() {
-	m.avatar_url = nil
-	m.clearedFields[user.FieldAvatarURL] = struct{}{}
+// ClearLastActiveSessionID clears the value of the "last_active_session_id" field.
+func (m *UserPreferenceMutation) ClearLastActiveSessionID() {
+	m.last_active_session_id = nil
+	m.clearedFields[userpreference.FieldLastActiveSessionID] = struct{}{}
 }
 
-// AvatarURLCleared returns if the "avatar_url" field was cleared in this mutation.
-func (m *UserMutation) AvatarURLCleared() bool {
-	_, ok := m.clearedFields[user.FieldAvatarURL]
+// LastActiveSessionIDCleared returns if the "last_active_session_id" field was cleared in this mutation.
+func (m *UserPreferenceMutation) LastActiveSessionIDCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldLastActiveSessionID]
 	return ok
 }
 
-// ResetAvatarURL resets all changes to the "avatar_url" field.
-func (m *UserMutation) ResetAvatarURL() {
-	m.avatar_url = nil
-	delete(m.clearedFields, user.FieldAvatarURL)
+// ResetLastActiveSessionID resets all changes to the "last_active_session_id" field.
+func (m *UserPreferenceMutation) ResetLastActiveSessionID() {
+	m.last_active_session_id = nil
+	delete(m.clearedFields, userpreference.FieldLastActiveSessionID)
 }
 
-// SetProvider sets the "provider" field.
-func (m *UserMutation) SetProvider(s string) {
-	m.provider = &s
+// SetSavedSearch sets the "saved_search" field.
+func (m *UserPreferenceMutation) SetSavedSearch(value map[string]interface{}) {
+	m.saved_search = &value
 }
 
-// Provider returns the value of the "provider" field in the mutation.
-func (m *UserMutation) Provider() (r string, exists bool) {
-	v := m.provider
+// SavedSearch returns the value of the "saved_search" field in the mutation.
+func (m *UserPreferenceMutation) SavedSearch() (r map[string]interface{}, exists bool) {
+	v := m.saved_search
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProvider returns the old "provider" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldSavedSearch returns the old "saved_search" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldProvider(ctx context.Context) (v string, err error) {
+func (m *UserPreferenceMutation) OldSavedSearch(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+		return v, errors.New("OldSavedSearch is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProvider requires an ID field in the mutation")
+		return v, errors.New("OldSavedSearch requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+		return v, fmt.Errorf("querying old value for OldSavedSearch: %w", err)
 	}
-	return oldValue.Provider, nil
+	return oldValue.SavedSearch, nil
 }
 
-// ResetProvider resets all changes to the "provider" field.
-func (m *UserMutation) ResetProvider() {
-	m.provider = nil
+// ClearSavedSearch clears the value of the "saved_search" field.
+func (m *UserPreferenceMutation) ClearSavedSearch() {
+	m.saved_search = nil
+	m.clearedFields[userpreference.FieldSavedSearch] = struct{}{}
+}
+
+// SavedSearchCleared returns if the "saved_search" field was cleared in this mutation.
+func (m *UserPreferenceMutation) SavedSearchCleared() bool {
+	_, ok := m.clearedFields[userpreference.FieldSavedSearch]
+	return ok
+}
+
+// ResetSavedSearch resets all changes to the "saved_search" field.
+func (m *UserPreferenceMutation) ResetSavedSearch() {
+	m.saved_search = nil
+	delete(m.clearedFields, userpreference.FieldSavedSearch)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *UserMutation) SetCreatedAt(t time.Time) {
+func (m *UserPreferenceMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *UserPreferenceMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -3747,10 +12261,10 @@ func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *UserPreferenceMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -3765,17 +12279,17 @@ func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *UserMutation) ResetCreatedAt() {
+func (m *UserPreferenceMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
 // SetUpdatedAt sets the "updated_at" field.
-func (m *UserMutation) SetUpdatedAt(t time.Time) {
+func (m *UserPreferenceMutation) SetUpdatedAt(t time.Time) {
 	m.updated_at = &t
 }
 
 // UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
+func (m *UserPreferenceMutation) UpdatedAt() (r time.Time, exists bool) {
 	v := m.updated_at
 	if v == nil {
 		return
@@ -3783,10 +12297,10 @@ func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the UserPreference entity.
+// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *UserPreferenceMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
@@ -3801,215 +12315,46 @@ func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error
 }
 
 // ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *UserMutation) ResetUpdatedAt() {
+func (m *UserPreferenceMutation) ResetUpdatedAt() {
 	m.updated_at = nil
 }
 
-// SetLastLogin sets the "last_login" field.
-func (m *UserMutation) SetLastLogin(t time.Time) {
-	m.last_login = &t
-}
-
-// LastLogin returns the value of the "last_login" field in the mutation.
-func (m *UserMutation) LastLogin() (r time.Time, exists bool) {
-	v := m.last_login
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldLastLogin returns the old "last_login" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldLastLogin(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastLogin is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastLogin requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastLogin: %w", err)
-	}
-	return oldValue.LastLogin, nil
-}
-
-// ClearLastLogin clears the value of the "last_login" field.
-func (m *UserMutation) ClearLastLogin() {
-	m.last_login = nil
-	m.clearedFields[user.FieldLastLogin] = struct{}{}
-}
-
-// LastLoginCleared returns if the "last_login" field was cleared in this mutation.
-func (m *UserMutation) LastLoginCleared() bool {
-	_, ok := m.clearedFields[user.FieldLastLogin]
-	return ok
-}
-
-// ResetLastLogin resets all changes to the "last_login" field.
-func (m *UserMutation) ResetLastLogin() {
-	m.last_login = nil
-	delete(m.clearedFields, user.FieldLastLogin)
-}
-
-// AddSessionIDs adds the "sessions" edge to the ChatSession entity by ids.
-func (m *UserMutation) AddSessionIDs(ids ...uuid.UUID) {
-	if m.sessions == nil {
-		m.sessions = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		m.sessions[ids[i]] = struct{}{}
-	}
-}
-
-// ClearSessions clears the "sessions" edge to the ChatSession entity.
-func (m *UserMutation) ClearSessions() {
-	m.clearedsessions = true
-}
-
-// SessionsCleared reports if the "sessions" edge to the ChatSession entity was cleared.
-func (m *UserMutation) SessionsCleared() bool {
-	return m.clearedsessions
-}
-
-// RemoveSessionIDs removes the "sessions" edge to the ChatSession entity by IDs.
-func (m *UserMutation) RemoveSessionIDs(ids ...uuid.UUID) {
-	if m.removedsessions == nil {
-		m.removedsessions = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		delete(m.sessions, ids[i])
-		m.removedsessions[ids[i]] = struct{}{}
-	}
-}
-
-// RemovedSessions returns the removed IDs of the "sessions" edge to the ChatSession entity.
-func (m *UserMutation) RemovedSessionsIDs() (ids []uuid.UUID) {
-	for id := range m.removedsessions {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// SessionsIDs returns the "sessions" edge IDs in the mutation.
-func (m *UserMutation) SessionsIDs() (ids []uuid.UUID) {
-	for id := range m.sessions {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// ResetSessions resets all changes to the "sessions" edge.
-func (m *UserMutation) ResetSessions() {
-	m.sessions = nil
-	m.clearedsessions = false
-	m.removedsessions = nil
-}
-
-// AddSearchHistoryIDs adds the "search_history" edge to the SearchHistory entity by ids.
-func (m *UserMutation) AddSearchHistoryIDs(ids ...uuid.UUID) {
-	if m.search_history == nil {
-		m.search_history = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		m.search_history[ids[i]] = struct{}{}
-	}
-}
-
-// ClearSearchHistory clears the "search_history" edge to the SearchHistory entity.
-func (m *UserMutation) ClearSearchHistory() {
-	m.clearedsearch_history = true
-}
-
-// SearchHistoryCleared reports if the "search_history" edge to the SearchHistory entity was cleared.
-func (m *UserMutation) SearchHistoryCleared() bool {
-	return m.clearedsearch_history
-}
-
-// RemoveSearchHistoryIDs removes the "search_history" edge to the SearchHistory entity by IDs.
-func (m *UserMutation) RemoveSearchHistoryIDs(ids ...uuid.UUID) {
-	if m.removedsearch_history == nil {
-		m.removedsearch_history = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		delete(m.search_history, ids[i])
-		m.removedsearch_history[ids[i]] = struct{}{}
-	}
-}
-
-// RemovedSearchHistory returns the removed IDs of the "search_history" edge to the SearchHistory entity.
-func (m *UserMutation) RemovedSearchHistoryIDs() (ids []uuid.UUID) {
-	for id := range m.removedsearch_history {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// SearchHistoryIDs returns the "search_history" edge IDs in the mutation.
-func (m *UserMutation) SearchHistoryIDs() (ids []uuid.UUID) {
-	for id := range m.search_history {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// ResetSearchHistory resets all changes to the "search_history" edge.
-func (m *UserMutation) ResetSearchHistory() {
-	m.search_history = nil
-	m.clearedsearch_history = false
-	m.removedsearch_history = nil
-}
-
-// SetPreferencesID sets the "preferences" edge to the UserPreference entity by id.
-func (m *UserMutation) SetPreferencesID(id uuid.UUID) {
-	m.preferences = &id
-}
-
-// ClearPreferences clears the "preferences" edge to the UserPreference entity.
-func (m *UserMutation) ClearPreferences() {
-	m.clearedpreferences = true
-}
-
-// PreferencesCleared reports if the "preferences" edge to the UserPreference entity was cleared.
-func (m *UserMutation) PreferencesCleared() bool {
-	return m.clearedpreferences
+// ClearUser clears the "user" edge to the User entity.
+func (m *UserPreferenceMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[userpreference.FieldUserID] = struct{}{}
 }
 
-// PreferencesID returns the "preferences" edge ID in the mutation.
-func (m *UserMutation) PreferencesID() (id uuid.UUID, exists bool) {
-	if m.preferences != nil {
-		return *m.preferences, true
-	}
-	return
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *UserPreferenceMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
-// PreferencesIDs returns the "preferences" edge IDs in the mutation.
+// UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// PreferencesID instead. It exists only for internal usage by the builders.
-func (m *UserMutation) PreferencesIDs() (ids []uuid.UUID) {
-	if id := m.preferences; id != nil {
+// UserID instead. It exists only for internal usage by the builders.
+func (m *UserPreferenceMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetPreferences resets all changes to the "preferences" edge.
-func (m *UserMutation) ResetPreferences() {
-	m.preferences = nil
-	m.clearedpreferences = false
+// ResetUser resets all changes to the "user" edge.
+func (m *UserPreferenceMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the UserMutation builder.
-func (m *UserMutation) Where(ps ...predicate.User) {
+// Where appends a list predicates to the UserPreferenceMutation builder.
+func (m *UserPreferenceMutation) Where(ps ...predicate.UserPreference) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// WhereP appends storage-level predicates to the UserPreferenceMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.User, len(ps))
+func (m *UserPreferenceMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.UserPreference, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -4017,51 +12362,54 @@ func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *UserMutation) Op() Op {
+func (m *UserPreferenceMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *UserMutation) SetOp(op Op) {
+func (m *UserPreferenceMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (User).
-func (m *UserMutation) Type() string {
+// Type returns the node type of this mutation (UserPreference).
+func (m *UserPreferenceMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 9)
-	if m.email != nil {
-		fields = append(fields, user.FieldEmail)
+func (m *UserPreferenceMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.user != nil {
+		fields = append(fields, userpreference.FieldUserID)
 	}
-	if m.password_hash != nil {
-		fields = append(fields, user.FieldPasswordHash)
+	if m.country != nil {
+		fields = append(fields, userpreference.FieldCountry)
 	}
-	if m.google_id != nil {
-		fields = append(fields, user.FieldGoogleID)
+	if m.currency != nil {
+		fields = append(fields, userpreference.FieldCurrency)
 	}
-	if m.name != nil {
-		fields = append(fields, user.FieldName)
+	if m.language != nil {
+		fields = append(fields, userpreference.FieldLanguage)
 	}
-	if m.avatar_url != nil {
-		fields = append(fields, user.FieldAvatarURL)
+	if m.theme != nil {
+		fields = append(fields, userpreference.FieldTheme)
 	}
-	if m.provider != nil {
-		fields = append(fields, user.FieldProvider)
+	if m.sidebar_open != nil {
+		fields = append(fields, userpreference.FieldSidebarOpen)
+	}
+	if m.last_active_session_id != nil {
+		fields = append(fields, userpreference.FieldLastActiveSessionID)
+	}
+	if m.saved_search != nil {
+		fields = append(fields, userpreference.FieldSavedSearch)
 	}
 	if m.created_at != nil {
-		fields = append(fields, user.FieldCreatedAt)
+		fields = append(fields, userpreference.FieldCreatedAt)
 	}
 	if m.updated_at != nil {
-		fields = append(fields, user.FieldUpdatedAt)
-	}
-	if m.last_login != nil {
-		fields = append(fields, user.FieldLastLogin)
+		fields = append(fields, userpreference.FieldUpdatedAt)
 	}
 	return fields
 }
@@ -4069,26 +12417,28 @@ func (m *UserMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *UserMutation) Field(name string) (ent.Value, bool) {
+func (m *UserPreferenceMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case user.FieldEmail:
-		return m.Email()
-	case user.FieldPasswordHash:
-		return m.PasswordHash()
-	case user.FieldGoogleID:
-		return m.GoogleID()
-	case user.FieldName:
-		return m.Name()
-	case user.FieldAvatarURL:
-		return m.AvatarURL()
-	case user.FieldProvider:
-		return m.Provider()
-	case user.FieldCreatedAt:
+	case userpreference.FieldUserID:
+		return m.UserID()
+	case userpreference.FieldCountry:
+		return m.Country()
+	case userpreference.FieldCurrency:
+		return m.Currency()
+	case userpreference.FieldLanguage:
+		return m.Language()
+	case userpreference.FieldTheme:
+		return m.Theme()
+	case userpreference.FieldSidebarOpen:
+		return m.SidebarOpen()
+	case userpreference.FieldLastActiveSessionID:
+		return m.LastActiveSessionID()
+	case userpreference.FieldSavedSearch:
+		return m.SavedSearch()
+	case userpreference.FieldCreatedAt:
 		return m.CreatedAt()
-	case user.FieldUpdatedAt:
+	case userpreference.FieldUpdatedAt:
 		return m.UpdatedAt()
-	case user.FieldLastLogin:
-		return m.LastLogin()
 	}
 	return nil, false
 }
@@ -4096,244 +12446,250 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *UserPreferenceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case user.FieldEmail:
-		return m.OldEmail(ctx)
-	case user.FieldPasswordHash:
-		return m.OldPasswordHash(ctx)
-	case user.FieldGoogleID:
-		return m.OldGoogleID(ctx)
-	case user.FieldName:
-		return m.OldName(ctx)
-	case user.FieldAvatarURL:
-		return m.OldAvatarURL(ctx)
-	case user.FieldProvider:
-		return m.OldProvider(ctx)
-	case user.FieldCreatedAt:
+	case userpreference.FieldUserID:
+		return m.OldUserID(ctx)
+	case userpreference.FieldCountry:
+		return m.OldCountry(ctx)
+	case userpreference.FieldCurrency:
+		return m.OldCurrency(ctx)
+	case userpreference.FieldLanguage:
+		return m.OldLanguage(ctx)
+	case userpreference.FieldTheme:
+		return m.OldTheme(ctx)
+	case userpreference.FieldSidebarOpen:
+		return m.OldSidebarOpen(ctx)
+	case userpreference.FieldLastActiveSessionID:
+		return m.OldLastActiveSessionID(ctx)
+	case userpreference.FieldSavedSearch:
+		return m.OldSavedSearch(ctx)
+	case userpreference.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case user.FieldUpdatedAt:
+	case userpreference.FieldUpdatedAt:
 		return m.OldUpdatedAt(ctx)
-	case user.FieldLastLogin:
-		return m.OldLastLogin(ctx)
 	}
-	return nil, fmt.Errorf("unknown User field %s", name)
+	return nil, fmt.Errorf("unknown UserPreference field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) SetField(name string, value ent.Value) error {
+func (m *UserPreferenceMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case user.FieldEmail:
-		v, ok := value.(string)
+	case userpreference.FieldUserID:
+		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetEmail(v)
+		m.SetUserID(v)
 		return nil
-	case user.FieldPasswordHash:
+	case userpreference.FieldCountry:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPasswordHash(v)
+		m.SetCountry(v)
 		return nil
-	case user.FieldGoogleID:
+	case userpreference.FieldCurrency:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetGoogleID(v)
+		m.SetCurrency(v)
 		return nil
-	case user.FieldName:
+	case userpreference.FieldLanguage:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetLanguage(v)
 		return nil
-	case user.FieldAvatarURL:
+	case userpreference.FieldTheme:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAvatarURL(v)
+		m.SetTheme(v)
 		return nil
-	case user.FieldProvider:
+	case userpreference.FieldSidebarOpen:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSidebarOpen(v)
+		return nil
+	case userpreference.FieldLastActiveSessionID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetProvider(v)
+		m.SetLastActiveSessionID(v)
 		return nil
-	case user.FieldCreatedAt:
-		v, ok := value.(time.Time)
+	case userpreference.FieldSavedSearch:
+		v, ok := value.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetSavedSearch(v)
 		return nil
-	case user.FieldUpdatedAt:
+	case userpreference.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetCreatedAt(v)
 		return nil
-	case user.FieldLastLogin:
+	case userpreference.FieldUpdatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLastLogin(v)
+		m.SetUpdatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown UserPreference field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *UserMutation) AddedFields() []string {
+func (m *UserPreferenceMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+func (m *UserPreferenceMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) AddField(name string, value ent.Value) error {
+func (m *UserPreferenceMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown User numeric field %s", name)
+	return fmt.Errorf("unknown UserPreference numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *UserMutation) ClearedFields() []string {
+func (m *UserPreferenceMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(user.FieldPasswordHash) {
-		fields = append(fields, user.FieldPasswordHash)
+	if m.FieldCleared(userpreference.FieldCountry) {
+		fields = append(fields, userpreference.FieldCountry)
 	}
-	if m.FieldCleared(user.FieldGoogleID) {
-		fields = append(fields, user.FieldGoogleID)
+	if m.FieldCleared(userpreference.FieldCurrency) {
+		fields = append(fields, userpreference.FieldCurrency)
 	}
-	if m.FieldCleared(user.FieldName) {
-		fields = append(fields, user.FieldName)
+	if m.FieldCleared(userpreference.FieldLanguage) {
+		fields = append(fields, userpreference.FieldLanguage)
 	}
-	if m.FieldCleared(user.FieldAvatarURL) {
-		fields = append(fields, user.FieldAvatarURL)
+	if m.FieldCleared(userpreference.FieldTheme) {
+		fields = append(fields, userpreference.FieldTheme)
 	}
-	if m.FieldCleared(user.FieldLastLogin) {
-		fields = append(fields, user.FieldLastLogin)
+	if m.FieldCleared(userpreference.FieldSidebarOpen) {
+		fields = append(fields, userpreference.FieldSidebarOpen)
+	}
+	if m.FieldCleared(userpreference.FieldLastActiveSessionID) {
+		fields = append(fields, userpreference.FieldLastActiveSessionID)
+	}
+	if m.FieldCleared(userpreference.FieldSavedSearch) {
+		fields = append(fields, userpreference.FieldSavedSearch)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *UserMutation) FieldCleared(name string) bool {
+func (m *UserPreferenceMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *UserMutation) ClearField(name string) error {
+func (m *UserPreferenceMutation) ClearField(name string) error {
 	switch name {
-	case user.FieldPasswordHash:
-		m.ClearPasswordHash()
+	case userpreference.FieldCountry:
+		m.ClearCountry()
+		return nil
+	case userpreference.FieldCurrency:
+		m.ClearCurrency()
 		return nil
-	case user.FieldGoogleID:
-		m.ClearGoogleID()
+	case userpreference.FieldLanguage:
+		m.ClearLanguage()
 		return nil
-	case user.FieldName:
-		m.ClearName()
+	case userpreference.FieldTheme:
+		m.ClearTheme()
 		return nil
-	case user.FieldAvatarURL:
-		m.ClearAvatarURL()
+	case userpreference.FieldSidebarOpen:
+		m.ClearSidebarOpen()
 		return nil
-	case user.FieldLastLogin:
-		m.ClearLastLogin()
+	case userpreference.FieldLastActiveSessionID:
+		m.ClearLastActiveSessionID()
+		return nil
+	case userpreference.FieldSavedSearch:
+		m.ClearSavedSearch()
 		return nil
 	}
-	return fmt.Errorf("unknown User nullable field %s", name)
+	return fmt.Errorf("unknown UserPreference nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *UserMutation) ResetField(name string) error {
+func (m *UserPreferenceMutation) ResetField(name string) error {
 	switch name {
-	case user.FieldEmail:
-		m.ResetEmail()
+	case userpreference.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case user.FieldPasswordHash:
-		m.ResetPasswordHash()
+	case userpreference.FieldCountry:
+		m.ResetCountry()
 		return nil
-	case user.FieldGoogleID:
-		m.ResetGoogleID()
+	case userpreference.FieldCurrency:
+		m.ResetCurrency()
 		return nil
-	case user.FieldName:
-		m.ResetName()
+	case userpreference.FieldLanguage:
+		m.ResetLanguage()
 		return nil
-	case user.FieldAvatarURL:
-		m.ResetAvatarURL()
+	case userpreference.FieldTheme:
+		m.ResetTheme()
 		return nil
-	case user.FieldProvider:
-		m.ResetProvider()
+	case userpreference.FieldSidebarOpen:
+		m.ResetSidebarOpen()
 		return nil
-	case user.FieldCreatedAt:
+	case userpreference.FieldLastActiveSessionID:
+		m.ResetLastActiveSessionID()
+		return nil
+	case userpreference.FieldSavedSearch:
+		m.ResetSavedSearch()
+		return nil
+	case userpreference.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case user.FieldUpdatedAt:
+	case userpreference.FieldUpdatedAt:
 		m.ResetUpdatedAt()
 		return nil
-	case user.FieldLastLogin:
-		m.ResetLastLogin()
-		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown UserPreference field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *UserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.sessions != nil {
-		edges = append(edges, user.EdgeSessions)
-	}
-	if m.search_history != nil {
-		edges = append(edges, user.EdgeSearchHistory)
-	}
-	if m.preferences != nil {
-		edges = append(edges, user.EdgePreferences)
+func (m *UserPreferenceMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, userpreference.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *UserMutation) AddedIDs(name string) []ent.Value {
+func (m *UserPreferenceMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case user.EdgeSessions:
-		ids := make([]ent.Value, 0, len(m.sessions))
-		for id := range m.sessions {
-			ids = append(ids, id)
-		}
-		return ids
-	case user.EdgeSearchHistory:
-		ids := make([]ent.Value, 0, len(m.search_history))
-		for id := range m.search_history {
-			ids = append(ids, id)
-		}
-		return ids
-	case user.EdgePreferences:
-		if id := m.preferences; id != nil {
+	case userpreference.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -4341,128 +12697,100 @@ func (m *UserMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *UserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.removedsessions != nil {
-		edges = append(edges, user.EdgeSessions)
-	}
-	if m.removedsearch_history != nil {
-		edges = append(edges, user.EdgeSearchHistory)
-	}
+func (m *UserPreferenceMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *UserMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case user.EdgeSessions:
-		ids := make([]ent.Value, 0, len(m.removedsessions))
-		for id := range m.removedsessions {
-			ids = append(ids, id)
-		}
-		return ids
-	case user.EdgeSearchHistory:
-		ids := make([]ent.Value, 0, len(m.removedsearch_history))
-		for id := range m.removedsearch_history {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *UserPreferenceMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *UserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.clearedsessions {
-		edges = append(edges, user.EdgeSessions)
-	}
-	if m.clearedsearch_history {
-		edges = append(edges, user.EdgeSearchHistory)
-	}
-	if m.clearedpreferences {
-		edges = append(edges, user.EdgePreferences)
+func (m *UserPreferenceMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, userpreference.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *UserMutation) EdgeCleared(name string) bool {
+func (m *UserPreferenceMutation) EdgeCleared(name string) bool {
 	switch name {
-	case user.EdgeSessions:
-		return m.clearedsessions
-	case user.EdgeSearchHistory:
-		return m.clearedsearch_history
-	case user.EdgePreferences:
-		return m.clearedpreferences
+	case userpreference.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *UserMutation) ClearEdge(name string) error {
+func (m *UserPreferenceMutation) ClearEdge(name string) error {
 	switch name {
-	case user.EdgePreferences:
-		m.ClearPreferences()
+	case userpreference.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown User unique edge %s", name)
+	return fmt.Errorf("unknown UserPreference unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *UserMutation) ResetEdge(name string) error {
+func (m *UserPreferenceMutation) ResetEdge(name string) error {
 	switch name {
-	case user.EdgeSessions:
-		m.ResetSessions()
-		return nil
-	case user.EdgeSearchHistory:
-		m.ResetSearchHistory()
-		return nil
-	case user.EdgePreferences:
-		m.ResetPreferences()
+	case userpreference.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown User edge %s", name)
+	return fmt.Errorf("unknown UserPreference edge %s", name)
 }
 
-// UserPreferenceMutation represents an operation that mutates the UserPreference nodes in the graph.
-type UserPreferenceMutation struct {
+// WatchMutation represents an operation that mutates the Watch nodes in the graph.
+type WatchMutation struct {
 	config
-	op                     Op
-	typ                    string
-	id                     *uuid.UUID
-	country                *string
-	currency               *string
-	language               *string
-	theme                  *string
-	sidebar_open           *bool
-	last_active_session_id *string
-	saved_search           *map[string]interface{}
-	created_at             *time.Time
-	updated_at             *time.Time
-	clearedFields          map[string]struct{}
-	user                   *uuid.UUID
-	cleareduser            bool
-	done                   bool
-	oldValue               func(context.Context) (*UserPreference, error)
-	predicates             []predicate.UserPreference
-}
-
-var _ ent.Mutation = (*UserPreferenceMutation)(nil)
-
-// userpreferenceOption allows management of the mutation configuration using functional options.
-type userpreferenceOption func(*UserPreferenceMutation)
-
-// newUserPreferenceMutation creates new mutation for the UserPreference entity.
-func newUserPreferenceMutation(c config, op Op, opts ...userpreferenceOption) *UserPreferenceMutation {
-	m := &UserPreferenceMutation{
+	op                Op
+	typ               string
+	id                *uuid.UUID
+	page_token        *string
+	product_name      *string
+	target_price      *float64
+	addtarget_price   *float64
+	currency          *string
+	country_code      *string
+	last_price        *float64
+	addlast_price     *float64
+	last_merchant     *string
+	last_link         *string
+	notified_price    *float64
+	addnotified_price *float64
+	active            *bool
+	last_checked_at   *time.Time
+	notified_at       *time.Time
+	created_at        *time.Time
+	updated_at        *time.Time
+	clearedFields     map[string]struct{}
+	user              *uuid.UUID
+	cleareduser       bool
+	done              bool
+	oldValue          func(context.Context) (*Watch, error)
+	predicates        []predicate.Watch
+}
+
+var _ ent.Mutation = (*WatchMutation)(nil)
+
+// watchOption allows management of the mutation configuration using functional options.
+type watchOption func(*WatchMutation)
+
+// newWatchMutation creates new mutation for the Watch entity.
+func newWatchMutation(c config, op Op, opts ...watchOption) *WatchMutation {
+	m := &WatchMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeUserPreference,
+		typ:           TypeWatch,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4471,20 +12799,20 @@ func newUserPreferenceMutation(c config, op Op, opts ...userpreferenceOption) *U
 	return m
 }
 
-// withUserPreferenceID sets the ID field of the mutation.
-func withUserPreferenceID(id uuid.UUID) userpreferenceOption {
-	return func(m *UserPreferenceMutation) {
+// withWatchID sets the ID field of the mutation.
+func withWatchID(id uuid.UUID) watchOption {
+	return func(m *WatchMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *UserPreference
+			value *Watch
 		)
-		m.oldValue = func(ctx context.Context) (*UserPreference, error) {
+		m.oldValue = func(ctx context.Context) (*Watch, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().UserPreference.Get(ctx, id)
+					value, err = m.Client().Watch.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4493,10 +12821,10 @@ func withUserPreferenceID(id uuid.UUID) userpreferenceOption {
 	}
 }
 
-// withUserPreference sets the old UserPreference of the mutation.
-func withUserPreference(node *UserPreference) userpreferenceOption {
-	return func(m *UserPreferenceMutation) {
-		m.oldValue = func(context.Context) (*UserPreference, error) {
+// withWatch sets the old Watch of the mutation.
+func withWatch(node *Watch) watchOption {
+	return func(m *WatchMutation) {
+		m.oldValue = func(context.Context) (*Watch, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4505,7 +12833,7 @@ func withUserPreference(node *UserPreference) userpreferenceOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UserPreferenceMutation) Client() *Client {
+func (m WatchMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4513,7 +12841,7 @@ func (m UserPreferenceMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m UserPreferenceMutation) Tx() (*Tx, error) {
+func (m WatchMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -4523,14 +12851,14 @@ func (m UserPreferenceMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of UserPreference entities.
-func (m *UserPreferenceMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Watch entities.
+func (m *WatchMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *UserPreferenceMutation) ID() (id uuid.UUID, exists bool) {
+func (m *WatchMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -4541,7 +12869,7 @@ func (m *UserPreferenceMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *UserPreferenceMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *WatchMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -4550,104 +12878,183 @@ func (m *UserPreferenceMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().UserPreference.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Watch.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUserID sets the "user_id" field.
-func (m *UserPreferenceMutation) SetUserID(u uuid.UUID) {
-	m.user = &u
+// SetUserID sets the "user_id" field.
+func (m *WatchMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *WatchMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WatchMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *WatchMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetPageToken sets the "page_token" field.
+func (m *WatchMutation) SetPageToken(s string) {
+	m.page_token = &s
+}
+
+// PageToken returns the value of the "page_token" field in the mutation.
+func (m *WatchMutation) PageToken() (r string, exists bool) {
+	v := m.page_token
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPageToken returns the old "page_token" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WatchMutation) OldPageToken(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPageToken is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPageToken requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPageToken: %w", err)
+	}
+	return oldValue.PageToken, nil
+}
+
+// ResetPageToken resets all changes to the "page_token" field.
+func (m *WatchMutation) ResetPageToken() {
+	m.page_token = nil
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *UserPreferenceMutation) UserID() (r uuid.UUID, exists bool) {
-	v := m.user
+// SetProductName sets the "product_name" field.
+func (m *WatchMutation) SetProductName(s string) {
+	m.product_name = &s
+}
+
+// ProductName returns the value of the "product_name" field in the mutation.
+func (m *WatchMutation) ProductName() (r string, exists bool) {
+	v := m.product_name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldProductName returns the old "product_name" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *WatchMutation) OldProductName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldProductName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldProductName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldProductName: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.ProductName, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *UserPreferenceMutation) ResetUserID() {
-	m.user = nil
+// ResetProductName resets all changes to the "product_name" field.
+func (m *WatchMutation) ResetProductName() {
+	m.product_name = nil
 }
 
-// SetCountry sets the "country" field.
-func (m *UserPreferenceMutation) SetCountry(s string) {
-	m.country = &s
+// SetTargetPrice sets the "target_price" field.
+func (m *WatchMutation) SetTargetPrice(f float64) {
+	m.target_price = &f
+	m.addtarget_price = nil
 }
 
-// Country returns the value of the "country" field in the mutation.
-func (m *UserPreferenceMutation) Country() (r string, exists bool) {
-	v := m.country
+// TargetPrice returns the value of the "target_price" field in the mutation.
+func (m *WatchMutation) TargetPrice() (r float64, exists bool) {
+	v := m.target_price
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCountry returns the old "country" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldTargetPrice returns the old "target_price" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldCountry(ctx context.Context) (v *string, err error) {
+func (m *WatchMutation) OldTargetPrice(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCountry is only allowed on UpdateOne operations")
+		return v, errors.New("OldTargetPrice is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCountry requires an ID field in the mutation")
+		return v, errors.New("OldTargetPrice requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCountry: %w", err)
+		return v, fmt.Errorf("querying old value for OldTargetPrice: %w", err)
 	}
-	return oldValue.Country, nil
+	return oldValue.TargetPrice, nil
 }
 
-// ClearCountry clears the value of the "country" field.
-func (m *UserPreferenceMutation) ClearCountry() {
-	m.country = nil
-	m.clearedFields[userpreference.FieldCountry] = struct{}{}
+// AddTargetPrice adds f to the "target_price" field.
+func (m *WatchMutation) AddTargetPrice(f float64) {
+	if m.addtarget_price != nil {
+		*m.addtarget_price += f
+	} else {
+		m.addtarget_price = &f
+	}
 }
 
-// CountryCleared returns if the "country" field was cleared in this mutation.
-func (m *UserPreferenceMutation) CountryCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldCountry]
-	return ok
+// AddedTargetPrice returns the value that was added to the "target_price" field in this mutation.
+func (m *WatchMutation) AddedTargetPrice() (r float64, exists bool) {
+	v := m.addtarget_price
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetCountry resets all changes to the "country" field.
-func (m *UserPreferenceMutation) ResetCountry() {
-	m.country = nil
-	delete(m.clearedFields, userpreference.FieldCountry)
+// ResetTargetPrice resets all changes to the "target_price" field.
+func (m *WatchMutation) ResetTargetPrice() {
+	m.target_price = nil
+	m.addtarget_price = nil
 }
 
 // SetCurrency sets the "currency" field.
-func (m *UserPreferenceMutation) SetCurrency(s string) {
+func (m *WatchMutation) SetCurrency(s string) {
 	m.currency = &s
 }
 
 // Currency returns the value of the "currency" field in the mutation.
-func (m *UserPreferenceMutation) Currency() (r string, exists bool) {
+func (m *WatchMutation) Currency() (r string, exists bool) {
 	v := m.currency
 	if v == nil {
 		return
@@ -4655,10 +13062,10 @@ func (m *UserPreferenceMutation) Currency() (r string, exists bool) {
 	return *v, true
 }
 
-// OldCurrency returns the old "currency" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldCurrency returns the old "currency" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldCurrency(ctx context.Context) (v *string, err error) {
+func (m *WatchMutation) OldCurrency(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCurrency is only allowed on UpdateOne operations")
 	}
@@ -4672,276 +13079,426 @@ func (m *UserPreferenceMutation) OldCurrency(ctx context.Context) (v *string, er
 	return oldValue.Currency, nil
 }
 
-// ClearCurrency clears the value of the "currency" field.
-func (m *UserPreferenceMutation) ClearCurrency() {
+// ResetCurrency resets all changes to the "currency" field.
+func (m *WatchMutation) ResetCurrency() {
 	m.currency = nil
-	m.clearedFields[userpreference.FieldCurrency] = struct{}{}
 }
 
-// CurrencyCleared returns if the "currency" field was cleared in this mutation.
-func (m *UserPreferenceMutation) CurrencyCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldCurrency]
-	return ok
+// SetCountryCode sets the "country_code" field.
+func (m *WatchMutation) SetCountryCode(s string) {
+	m.country_code = &s
 }
 
-// ResetCurrency resets all changes to the "currency" field.
-func (m *UserPreferenceMutation) ResetCurrency() {
-	m.currency = nil
-	delete(m.clearedFields, userpreference.FieldCurrency)
+// CountryCode returns the value of the "country_code" field in the mutation.
+func (m *WatchMutation) CountryCode() (r string, exists bool) {
+	v := m.country_code
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// SetLanguage sets the "language" field.
-func (m *UserPreferenceMutation) SetLanguage(s string) {
-	m.language = &s
+// OldCountryCode returns the old "country_code" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WatchMutation) OldCountryCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCountryCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCountryCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCountryCode: %w", err)
+	}
+	return oldValue.CountryCode, nil
 }
 
-// Language returns the value of the "language" field in the mutation.
-func (m *UserPreferenceMutation) Language() (r string, exists bool) {
-	v := m.language
+// ResetCountryCode resets all changes to the "country_code" field.
+func (m *WatchMutation) ResetCountryCode() {
+	m.country_code = nil
+}
+
+// SetLastPrice sets the "last_price" field.
+func (m *WatchMutation) SetLastPrice(f float64) {
+	m.last_price = &f
+	m.addlast_price = nil
+}
+
+// LastPrice returns the value of the "last_price" field in the mutation.
+func (m *WatchMutation) LastPrice() (r float64, exists bool) {
+	v := m.last_price
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLanguage returns the old "language" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldLastPrice returns the old "last_price" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldLanguage(ctx context.Context) (v *string, err error) {
+func (m *WatchMutation) OldLastPrice(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLanguage is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastPrice is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLanguage requires an ID field in the mutation")
+		return v, errors.New("OldLastPrice requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLanguage: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastPrice: %w", err)
 	}
-	return oldValue.Language, nil
+	return oldValue.LastPrice, nil
 }
 
-// ClearLanguage clears the value of the "language" field.
-func (m *UserPreferenceMutation) ClearLanguage() {
-	m.language = nil
-	m.clearedFields[userpreference.FieldLanguage] = struct{}{}
+// AddLastPrice adds f to the "last_price" field.
+func (m *WatchMutation) AddLastPrice(f float64) {
+	if m.addlast_price != nil {
+		*m.addlast_price += f
+	} else {
+		m.addlast_price = &f
+	}
 }
 
-// LanguageCleared returns if the "language" field was cleared in this mutation.
-func (m *UserPreferenceMutation) LanguageCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldLanguage]
+// AddedLastPrice returns the value that was added to the "last_price" field in this mutation.
+func (m *WatchMutation) AddedLastPrice() (r float64, exists bool) {
+	v := m.addlast_price
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearLastPrice clears the value of the "last_price" field.
+func (m *WatchMutation) ClearLastPrice() {
+	m.last_price = nil
+	m.addlast_price = nil
+	m.clearedFields[watch.FieldLastPrice] = struct{}{}
+}
+
+// LastPriceCleared returns if the "last_price" field was cleared in this mutation.
+func (m *WatchMutation) LastPriceCleared() bool {
+	_, ok := m.clearedFields[watch.FieldLastPrice]
 	return ok
 }
 
-// ResetLanguage resets all changes to the "language" field.
-func (m *UserPreferenceMutation) ResetLanguage() {
-	m.language = nil
-	delete(m.clearedFields, userpreference.FieldLanguage)
+// ResetLastPrice resets all changes to the "last_price" field.
+func (m *WatchMutation) ResetLastPrice() {
+	m.last_price = nil
+	m.addlast_price = nil
+	delete(m.clearedFields, watch.FieldLastPrice)
 }
 
-// SetTheme sets the "theme" field.
-func (m *UserPreferenceMutation) SetTheme(s string) {
-	m.theme = &s
+// SetLastMerchant sets the "last_merchant" field.
+func (m *WatchMutation) SetLastMerchant(s string) {
+	m.last_merchant = &s
 }
 
-// Theme returns the value of the "theme" field in the mutation.
-func (m *UserPreferenceMutation) Theme() (r string, exists bool) {
-	v := m.theme
+// LastMerchant returns the value of the "last_merchant" field in the mutation.
+func (m *WatchMutation) LastMerchant() (r string, exists bool) {
+	v := m.last_merchant
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTheme returns the old "theme" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldLastMerchant returns the old "last_merchant" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldTheme(ctx context.Context) (v *string, err error) {
+func (m *WatchMutation) OldLastMerchant(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTheme is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastMerchant is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTheme requires an ID field in the mutation")
+		return v, errors.New("OldLastMerchant requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTheme: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastMerchant: %w", err)
 	}
-	return oldValue.Theme, nil
+	return oldValue.LastMerchant, nil
 }
 
-// ClearTheme clears the value of the "theme" field.
-func (m *UserPreferenceMutation) ClearTheme() {
-	m.theme = nil
-	m.clearedFields[userpreference.FieldTheme] = struct{}{}
+// ClearLastMerchant clears the value of the "last_merchant" field.
+func (m *WatchMutation) ClearLastMerchant() {
+	m.last_merchant = nil
+	m.clearedFields[watch.FieldLastMerchant] = struct{}{}
 }
 
-// ThemeCleared returns if the "theme" field was cleared in this mutation.
-func (m *UserPreferenceMutation) ThemeCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldTheme]
+// LastMerchantCleared returns if the "last_merchant" field was cleared in this mutation.
+func (m *WatchMutation) LastMerchantCleared() bool {
+	_, ok := m.clearedFields[watch.FieldLastMerchant]
 	return ok
 }
 
-// ResetTheme resets all changes to the "theme" field.
-func (m *UserPreferenceMutation) ResetTheme() {
-	m.theme = nil
-	delete(m.clearedFields, userpreference.FieldTheme)
+// ResetLastMerchant resets all changes to the "last_merchant" field.
+func (m *WatchMutation) ResetLastMerchant() {
+	m.last_merchant = nil
+	delete(m.clearedFields, watch.FieldLastMerchant)
 }
 
-// SetSidebarOpen sets the "sidebar_open" field.
-func (m *UserPreferenceMutation) SetSidebarOpen(b bool) {
-	m.sidebar_open = &b
+// SetLastLink sets the "last_link" field.
+func (m *WatchMutation) SetLastLink(s string) {
+	m.last_link = &s
 }
 
-// SidebarOpen returns the value of the "sidebar_open" field in the mutation.
-func (m *UserPreferenceMutation) SidebarOpen() (r bool, exists bool) {
-	v := m.sidebar_open
+// LastLink returns the value of the "last_link" field in the mutation.
+func (m *WatchMutation) LastLink() (r string, exists bool) {
+	v := m.last_link
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSidebarOpen returns the old "sidebar_open" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldLastLink returns the old "last_link" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldSidebarOpen(ctx context.Context) (v *bool, err error) {
+func (m *WatchMutation) OldLastLink(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSidebarOpen is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastLink is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSidebarOpen requires an ID field in the mutation")
+		return v, errors.New("OldLastLink requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSidebarOpen: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastLink: %w", err)
 	}
-	return oldValue.SidebarOpen, nil
+	return oldValue.LastLink, nil
 }
 
-// ClearSidebarOpen clears the value of the "sidebar_open" field.
-func (m *UserPreferenceMutation) ClearSidebarOpen() {
-	m.sidebar_open = nil
-	m.clearedFields[userpreference.FieldSidebarOpen] = struct{}{}
+// ClearLastLink clears the value of the "last_link" field.
+func (m *WatchMutation) ClearLastLink() {
+	m.last_link = nil
+	m.clearedFields[watch.FieldLastLink] = struct{}{}
 }
 
-// SidebarOpenCleared returns if the "sidebar_open" field was cleared in this mutation.
-func (m *UserPreferenceMutation) SidebarOpenCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldSidebarOpen]
+// LastLinkCleared returns if the "last_link" field was cleared in this mutation.
+func (m *WatchMutation) LastLinkCleared() bool {
+	_, ok := m.clearedFields[watch.FieldLastLink]
 	return ok
 }
 
-// ResetSidebarOpen resets all changes to the "sidebar_open" field.
-func (m *UserPreferenceMutation) ResetSidebarOpen() {
-	m.sidebar_open = nil
-	delete(m.clearedFields, userpreference.FieldSidebarOpen)
+// ResetLastLink resets all changes to the "last_link" field.
+func (m *WatchMutation) ResetLastLink() {
+	m.last_link = nil
+	delete(m.clearedFields, watch.FieldLastLink)
 }
 
-// SetLastActiveSessionID sets the "last_active_session_id" field.
-func (m *UserPreferenceMutation) SetLastActiveSessionID(s string) {
-	m.last_active_session_id = &s
+// SetNotifiedPrice sets the "notified_price" field.
+func (m *WatchMutation) SetNotifiedPrice(f float64) {
+	m.notified_price = &f
+	m.addnotified_price = nil
 }
 
-// LastActiveSessionID returns the value of the "last_active_session_id" field in the mutation.
-func (m *UserPreferenceMutation) LastActiveSessionID() (r string, exists bool) {
-	v := m.last_active_session_id
+// NotifiedPrice returns the value of the "notified_price" field in the mutation.
+func (m *WatchMutation) NotifiedPrice() (r float64, exists bool) {
+	v := m.notified_price
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLastActiveSessionID returns the old "last_active_session_id" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldNotifiedPrice returns the old "notified_price" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldLastActiveSessionID(ctx context.Context) (v *string, err error) {
+func (m *WatchMutation) OldNotifiedPrice(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastActiveSessionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldNotifiedPrice is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastActiveSessionID requires an ID field in the mutation")
+		return v, errors.New("OldNotifiedPrice requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastActiveSessionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldNotifiedPrice: %w", err)
 	}
-	return oldValue.LastActiveSessionID, nil
+	return oldValue.NotifiedPrice, nil
 }
 
-// ClearLastActiveSessionID clears the value of the "last_active_session_id" field.
-func (m *UserPreferenceMutation) ClearLastActiveSessionID() {
-	m.last_active_session_id = nil
-	m.clearedFields[userpreference.FieldLastActiveSessionID] = struct{}{}
+// AddNotifiedPrice adds f to the "notified_price" field.
+func (m *WatchMutation) AddNotifiedPrice(f float64) {
+	if m.addnotified_price != nil {
+		*m.addnotified_price += f
+	} else {
+		m.addnotified_price = &f
+	}
 }
 
-// LastActiveSessionIDCleared returns if the "last_active_session_id" field was cleared in this mutation.
-func (m *UserPreferenceMutation) LastActiveSessionIDCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldLastActiveSessionID]
+// AddedNotifiedPrice returns the value that was added to the "notified_price" field in this mutation.
+func (m *WatchMutation) AddedNotifiedPrice() (r float64, exists bool) {
+	v := m.addnotified_price
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearNotifiedPrice clears the value of the "notified_price" field.
+func (m *WatchMutation) ClearNotifiedPrice() {
+	m.notified_price = nil
+	m.addnotified_price = nil
+	m.clearedFields[watch.FieldNotifiedPrice] = struct{}{}
+}
+
+// NotifiedPriceCleared returns if the "notified_price" field was cleared in this mutation.
+func (m *WatchMutation) NotifiedPriceCleared() bool {
+	_, ok := m.clearedFields[watch.FieldNotifiedPrice]
 	return ok
 }
 
-// ResetLastActiveSessionID resets all changes to the "last_active_session_id" field.
-func (m *UserPreferenceMutation) ResetLastActiveSessionID() {
-	m.last_active_session_id = nil
-	delete(m.clearedFields, userpreference.FieldLastActiveSessionID)
+// ResetNotifiedPrice resets all changes to the "notified_price" field.
+func (m *WatchMutation) ResetNotifiedPrice() {
+	m.notified_price = nil
+	m.addnotified_price = nil
+	delete(m.clearedFields, watch.FieldNotifiedPrice)
+}
+
+// SetActive sets the "active" field.
+func (m *WatchMutation) SetActive(b bool) {
+	m.active = &b
+}
+
+// Active returns the value of the "active" field in the mutation.
+func (m *WatchMutation) Active() (r bool, exists bool) {
+	v := m.active
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActive returns the old "active" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WatchMutation) OldActive(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActive is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActive requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActive: %w", err)
+	}
+	return oldValue.Active, nil
+}
+
+// ResetActive resets all changes to the "active" field.
+func (m *WatchMutation) ResetActive() {
+	m.active = nil
+}
+
+// SetLastCheckedAt sets the "last_checked_at" field.
+func (m *WatchMutation) SetLastCheckedAt(t time.Time) {
+	m.last_checked_at = &t
+}
+
+// LastCheckedAt returns the value of the "last_checked_at" field in the mutation.
+func (m *WatchMutation) LastCheckedAt() (r time.Time, exists bool) {
+	v := m.last_checked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastCheckedAt returns the old "last_checked_at" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WatchMutation) OldLastCheckedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastCheckedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastCheckedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastCheckedAt: %w", err)
+	}
+	return oldValue.LastCheckedAt, nil
+}
+
+// ClearLastCheckedAt clears the value of the "last_checked_at" field.
+func (m *WatchMutation) ClearLastCheckedAt() {
+	m.last_checked_at = nil
+	m.clearedFields[watch.FieldLastCheckedAt] = struct{}{}
+}
+
+// LastCheckedAtCleared returns if the "last_checked_at" field was cleared in this mutation.
+func (m *WatchMutation) LastCheckedAtCleared() bool {
+	_, ok := m.clearedFields[watch.FieldLastCheckedAt]
+	return ok
 }
 
-// SetSavedSearch sets the "saved_search" field.
-func (m *UserPreferenceMutation) SetSavedSearch(value map[string]interface{}) {
-	m.saved_search = &value
+// ResetLastCheckedAt resets all changes to the "last_checked_at" field.
+func (m *WatchMutation) ResetLastCheckedAt() {
+	m.last_checked_at = nil
+	delete(m.clearedFields, watch.FieldLastCheckedAt)
 }
 
-// SavedSearch returns the value of the "saved_search" field in the mutation.
-func (m *UserPreferenceMutation) SavedSearch() (r map[string]interface{}, exists bool) {
-	v := m.saved_search
+// SetNotifiedAt sets the "notified_at" field.
+func (m *WatchMutation) SetNotifiedAt(t time.Time) {
+	m.notified_at = &t
+}
+
+// NotifiedAt returns the value of the "notified_at" field in the mutation.
+func (m *WatchMutation) NotifiedAt() (r time.Time, exists bool) {
+	v := m.notified_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSavedSearch returns the old "saved_search" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldNotifiedAt returns the old "notified_at" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldSavedSearch(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *WatchMutation) OldNotifiedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSavedSearch is only allowed on UpdateOne operations")
+		return v, errors.New("OldNotifiedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSavedSearch requires an ID field in the mutation")
+		return v, errors.New("OldNotifiedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSavedSearch: %w", err)
+		return v, fmt.Errorf("querying old value for OldNotifiedAt: %w", err)
 	}
-	return oldValue.SavedSearch, nil
+	return oldValue.NotifiedAt, nil
 }
 
-// ClearSavedSearch clears the value of the "saved_search" field.
-func (m *UserPreferenceMutation) ClearSavedSearch() {
-	m.saved_search = nil
-	m.clearedFields[userpreference.FieldSavedSearch] = struct{}{}
+// ClearNotifiedAt clears the value of the "notified_at" field.
+func (m *WatchMutation) ClearNotifiedAt() {
+	m.notified_at = nil
+	m.clearedFields[watch.FieldNotifiedAt] = struct{}{}
 }
 
-// SavedSearchCleared returns if the "saved_search" field was cleared in this mutation.
-func (m *UserPreferenceMutation) SavedSearchCleared() bool {
-	_, ok := m.clearedFields[userpreference.FieldSavedSearch]
+// NotifiedAtCleared returns if the "notified_at" field was cleared in this mutation.
+func (m *WatchMutation) NotifiedAtCleared() bool {
+	_, ok := m.clearedFields[watch.FieldNotifiedAt]
 	return ok
 }
 
-// ResetSavedSearch resets all changes to the "saved_search" field.
-func (m *UserPreferenceMutation) ResetSavedSearch() {
-	m.saved_search = nil
-	delete(m.clearedFields, userpreference.FieldSavedSearch)
+// ResetNotifiedAt resets all changes to the "notified_at" field.
+func (m *WatchMutation) ResetNotifiedAt() {
+	m.notified_at = nil
+	delete(m.clearedFields, watch.FieldNotifiedAt)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *UserPreferenceMutation) SetCreatedAt(t time.Time) {
+func (m *WatchMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *UserPreferenceMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *WatchMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -4949,10 +13506,10 @@ func (m *UserPreferenceMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *WatchMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -4967,17 +13524,17 @@ func (m *UserPreferenceMutation) OldCreatedAt(ctx context.Context) (v time.Time,
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *UserPreferenceMutation) ResetCreatedAt() {
+func (m *WatchMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
 // SetUpdatedAt sets the "updated_at" field.
-func (m *UserPreferenceMutation) SetUpdatedAt(t time.Time) {
+func (m *WatchMutation) SetUpdatedAt(t time.Time) {
 	m.updated_at = &t
 }
 
 // UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *UserPreferenceMutation) UpdatedAt() (r time.Time, exists bool) {
+func (m *WatchMutation) UpdatedAt() (r time.Time, exists bool) {
 	v := m.updated_at
 	if v == nil {
 		return
@@ -4985,10 +13542,10 @@ func (m *UserPreferenceMutation) UpdatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the UserPreference entity.
-// If the UserPreference object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the Watch entity.
+// If the Watch object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserPreferenceMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *WatchMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
@@ -5003,25 +13560,25 @@ func (m *UserPreferenceMutation) OldUpdatedAt(ctx context.Context) (v time.Time,
 }
 
 // ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *UserPreferenceMutation) ResetUpdatedAt() {
+func (m *WatchMutation) ResetUpdatedAt() {
 	m.updated_at = nil
 }
 
 // ClearUser clears the "user" edge to the User entity.
-func (m *UserPreferenceMutation) ClearUser() {
+func (m *WatchMutation) ClearUser() {
 	m.cleareduser = true
-	m.clearedFields[userpreference.FieldUserID] = struct{}{}
+	m.clearedFields[watch.FieldUserID] = struct{}{}
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *UserPreferenceMutation) UserCleared() bool {
+func (m *WatchMutation) UserCleared() bool {
 	return m.cleareduser
 }
 
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *UserPreferenceMutation) UserIDs() (ids []uuid.UUID) {
+func (m *WatchMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -5029,20 +13586,20 @@ func (m *UserPreferenceMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *UserPreferenceMutation) ResetUser() {
+func (m *WatchMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// Where appends a list predicates to the UserPreferenceMutation builder.
-func (m *UserPreferenceMutation) Where(ps ...predicate.UserPreference) {
+// Where appends a list predicates to the WatchMutation builder.
+func (m *WatchMutation) Where(ps ...predicate.Watch) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the UserPreferenceMutation builder. Using this method,
+// WhereP appends storage-level predicates to the WatchMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *UserPreferenceMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.UserPreference, len(ps))
+func (m *WatchMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Watch, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -5050,54 +13607,69 @@ func (m *UserPreferenceMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *UserPreferenceMutation) Op() Op {
+func (m *WatchMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *UserPreferenceMutation) SetOp(op Op) {
+func (m *WatchMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (UserPreference).
-func (m *UserPreferenceMutation) Type() string {
+// Type returns the node type of this mutation (Watch).
+func (m *WatchMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *UserPreferenceMutation) Fields() []string {
-	fields := make([]string, 0, 10)
+func (m *WatchMutation) Fields() []string {
+	fields := make([]string, 0, 15)
 	if m.user != nil {
-		fields = append(fields, userpreference.FieldUserID)
+		fields = append(fields, watch.FieldUserID)
 	}
-	if m.country != nil {
-		fields = append(fields, userpreference.FieldCountry)
+	if m.page_token != nil {
+		fields = append(fields, watch.FieldPageToken)
+	}
+	if m.product_name != nil {
+		fields = append(fields, watch.FieldProductName)
+	}
+	if m.target_price != nil {
+		fields = append(fields, watch.FieldTargetPrice)
 	}
 	if m.currency != nil {
-		fields = append(fields, userpreference.FieldCurrency)
+		fields = append(fields, watch.FieldCurrency)
 	}
-	if m.language != nil {
-		fields = append(fields, userpreference.FieldLanguage)
+	if m.country_code != nil {
+		fields = append(fields, watch.FieldCountryCode)
 	}
-	if m.theme != nil {
-		fields = append(fields, userpreference.FieldTheme)
+	if m.last_price != nil {
+		fields = append(fields, watch.FieldLastPrice)
 	}
-	if m.sidebar_open != nil {
-		fields = append(fields, userpreference.FieldSidebarOpen)
+	if m.last_merchant != nil {
+		fields = append(fields, watch.FieldLastMerchant)
 	}
-	if m.last_active_session_id != nil {
-		fields = append(fields, userpreference.FieldLastActiveSessionID)
+	if m.last_link != nil {
+		fields = append(fields, watch.FieldLastLink)
 	}
-	if m.saved_search != nil {
-		fields = append(fields, userpreference.FieldSavedSearch)
+	if m.notified_price != nil {
+		fields = append(fields, watch.FieldNotifiedPrice)
+	}
+	if m.active != nil {
+		fields = append(fields, watch.FieldActive)
+	}
+	if m.last_checked_at != nil {
+		fields = append(fields, watch.FieldLastCheckedAt)
+	}
+	if m.notified_at != nil {
+		fields = append(fields, watch.FieldNotifiedAt)
 	}
 	if m.created_at != nil {
-		fields = append(fields, userpreference.FieldCreatedAt)
+		fields = append(fields, watch.FieldCreatedAt)
 	}
 	if m.updated_at != nil {
-		fields = append(fields, userpreference.FieldUpdatedAt)
+		fields = append(fields, watch.FieldUpdatedAt)
 	}
 	return fields
 }
@@ -5105,27 +13677,37 @@ func (m *UserPreferenceMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *UserPreferenceMutation) Field(name string) (ent.Value, bool) {
+func (m *WatchMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case userpreference.FieldUserID:
+	case watch.FieldUserID:
 		return m.UserID()
-	case userpreference.FieldCountry:
-		return m.Country()
-	case userpreference.FieldCurrency:
+	case watch.FieldPageToken:
+		return m.PageToken()
+	case watch.FieldProductName:
+		return m.ProductName()
+	case watch.FieldTargetPrice:
+		return m.TargetPrice()
+	case watch.FieldCurrency:
 		return m.Currency()
-	case userpreference.FieldLanguage:
-		return m.Language()
-	case userpreference.FieldTheme:
-		return m.Theme()
-	case userpreference.FieldSidebarOpen:
-		return m.SidebarOpen()
-	case userpreference.FieldLastActiveSessionID:
-		return m.LastActiveSessionID()
-	case userpreference.FieldSavedSearch:
-		return m.SavedSearch()
-	case userpreference.FieldCreatedAt:
+	case watch.FieldCountryCode:
+		return m.CountryCode()
+	case watch.FieldLastPrice:
+		return m.LastPrice()
+	case watch.FieldLastMerchant:
+		return m.LastMerchant()
+	case watch.FieldLastLink:
+		return m.LastLink()
+	case watch.FieldNotifiedPrice:
+		return m.NotifiedPrice()
+	case watch.FieldActive:
+		return m.Active()
+	case watch.FieldLastCheckedAt:
+		return m.LastCheckedAt()
+	case watch.FieldNotifiedAt:
+		return m.NotifiedAt()
+	case watch.FieldCreatedAt:
 		return m.CreatedAt()
-	case userpreference.FieldUpdatedAt:
+	case watch.FieldUpdatedAt:
 		return m.UpdatedAt()
 	}
 	return nil, false
@@ -5134,101 +13716,146 @@ func (m *UserPreferenceMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *UserPreferenceMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *WatchMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case userpreference.FieldUserID:
+	case watch.FieldUserID:
 		return m.OldUserID(ctx)
-	case userpreference.FieldCountry:
-		return m.OldCountry(ctx)
-	case userpreference.FieldCurrency:
+	case watch.FieldPageToken:
+		return m.OldPageToken(ctx)
+	case watch.FieldProductName:
+		return m.OldProductName(ctx)
+	case watch.FieldTargetPrice:
+		return m.OldTargetPrice(ctx)
+	case watch.FieldCurrency:
 		return m.OldCurrency(ctx)
-	case userpreference.FieldLanguage:
-		return m.OldLanguage(ctx)
-	case userpreference.FieldTheme:
-		return m.OldTheme(ctx)
-	case userpreference.FieldSidebarOpen:
-		return m.OldSidebarOpen(ctx)
-	case userpreference.FieldLastActiveSessionID:
-		return m.OldLastActiveSessionID(ctx)
-	case userpreference.FieldSavedSearch:
-		return m.OldSavedSearch(ctx)
-	case userpreference.FieldCreatedAt:
+	case watch.FieldCountryCode:
+		return m.OldCountryCode(ctx)
+	case watch.FieldLastPrice:
+		return m.OldLastPrice(ctx)
+	case watch.FieldLastMerchant:
+		return m.OldLastMerchant(ctx)
+	case watch.FieldLastLink:
+		return m.OldLastLink(ctx)
+	case watch.FieldNotifiedPrice:
+		return m.OldNotifiedPrice(ctx)
+	case watch.FieldActive:
+		return m.OldActive(ctx)
+	case watch.FieldLastCheckedAt:
+		return m.OldLastCheckedAt(ctx)
+	case watch.FieldNotifiedAt:
+		return m.OldNotifiedAt(ctx)
+	case watch.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case userpreference.FieldUpdatedAt:
+	case watch.FieldUpdatedAt:
 		return m.OldUpdatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown UserPreference field %s", name)
+	return nil, fmt.Errorf("unknown Watch field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserPreferenceMutation) SetField(name string, value ent.Value) error {
+func (m *WatchMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case userpreference.FieldUserID:
+	case watch.FieldUserID:
 		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetUserID(v)
 		return nil
-	case userpreference.FieldCountry:
+	case watch.FieldPageToken:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCountry(v)
+		m.SetPageToken(v)
 		return nil
-	case userpreference.FieldCurrency:
+	case watch.FieldProductName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProductName(v)
+		return nil
+	case watch.FieldTargetPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTargetPrice(v)
+		return nil
+	case watch.FieldCurrency:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCurrency(v)
 		return nil
-	case userpreference.FieldLanguage:
+	case watch.FieldCountryCode:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLanguage(v)
+		m.SetCountryCode(v)
 		return nil
-	case userpreference.FieldTheme:
+	case watch.FieldLastPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastPrice(v)
+		return nil
+	case watch.FieldLastMerchant:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetTheme(v)
+		m.SetLastMerchant(v)
 		return nil
-	case userpreference.FieldSidebarOpen:
+	case watch.FieldLastLink:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastLink(v)
+		return nil
+	case watch.FieldNotifiedPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNotifiedPrice(v)
+		return nil
+	case watch.FieldActive:
 		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSidebarOpen(v)
+		m.SetActive(v)
 		return nil
-	case userpreference.FieldLastActiveSessionID:
-		v, ok := value.(string)
+	case watch.FieldLastCheckedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLastActiveSessionID(v)
+		m.SetLastCheckedAt(v)
 		return nil
-	case userpreference.FieldSavedSearch:
-		v, ok := value.(map[string]interface{})
+	case watch.FieldNotifiedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSavedSearch(v)
+		m.SetNotifiedAt(v)
 		return nil
-	case userpreference.FieldCreatedAt:
+	case watch.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case userpreference.FieldUpdatedAt:
+	case watch.FieldUpdatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -5236,147 +13863,195 @@ func (m *UserPreferenceMutation) SetField(name string, value ent.Value) error {
 		m.SetUpdatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown UserPreference field %s", name)
+	return fmt.Errorf("unknown Watch field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *UserPreferenceMutation) AddedFields() []string {
-	return nil
+func (m *WatchMutation) AddedFields() []string {
+	var fields []string
+	if m.addtarget_price != nil {
+		fields = append(fields, watch.FieldTargetPrice)
+	}
+	if m.addlast_price != nil {
+		fields = append(fields, watch.FieldLastPrice)
+	}
+	if m.addnotified_price != nil {
+		fields = append(fields, watch.FieldNotifiedPrice)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *UserPreferenceMutation) AddedField(name string) (ent.Value, bool) {
+func (m *WatchMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case watch.FieldTargetPrice:
+		return m.AddedTargetPrice()
+	case watch.FieldLastPrice:
+		return m.AddedLastPrice()
+	case watch.FieldNotifiedPrice:
+		return m.AddedNotifiedPrice()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserPreferenceMutation) AddField(name string, value ent.Value) error {
+func (m *WatchMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case watch.FieldTargetPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTargetPrice(v)
+		return nil
+	case watch.FieldLastPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddLastPrice(v)
+		return nil
+	case watch.FieldNotifiedPrice:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddNotifiedPrice(v)
+		return nil
 	}
-	return fmt.Errorf("unknown UserPreference numeric field %s", name)
+	return fmt.Errorf("unknown Watch numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *UserPreferenceMutation) ClearedFields() []string {
+func (m *WatchMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(userpreference.FieldCountry) {
-		fields = append(fields, userpreference.FieldCountry)
-	}
-	if m.FieldCleared(userpreference.FieldCurrency) {
-		fields = append(fields, userpreference.FieldCurrency)
+	if m.FieldCleared(watch.FieldLastPrice) {
+		fields = append(fields, watch.FieldLastPrice)
 	}
-	if m.FieldCleared(userpreference.FieldLanguage) {
-		fields = append(fields, userpreference.FieldLanguage)
+	if m.FieldCleared(watch.FieldLastMerchant) {
+		fields = append(fields, watch.FieldLastMerchant)
 	}
-	if m.FieldCleared(userpreference.FieldTheme) {
-		fields = append(fields, userpreference.FieldTheme)
+	if m.FieldCleared(watch.FieldLastLink) {
+		fields = append(fields, watch.FieldLastLink)
 	}
-	if m.FieldCleared(userpreference.FieldSidebarOpen) {
-		fields = append(fields, userpreference.FieldSidebarOpen)
+	if m.FieldCleared(watch.FieldNotifiedPrice) {
+		fields = append(fields, watch.FieldNotifiedPrice)
 	}
-	if m.FieldCleared(userpreference.FieldLastActiveSessionID) {
-		fields = append(fields, userpreference.FieldLastActiveSessionID)
+	if m.FieldCleared(watch.FieldLastCheckedAt) {
+		fields = append(fields, watch.FieldLastCheckedAt)
 	}
-	if m.FieldCleared(userpreference.FieldSavedSearch) {
-		fields = append(fields, userpreference.FieldSavedSearch)
+	if m.FieldCleared(watch.FieldNotifiedAt) {
+		fields = append(fields, watch.FieldNotifiedAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *UserPreferenceMutation) FieldCleared(name string) bool {
+func (m *WatchMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *UserPreferenceMutation) ClearField(name string) error {
+func (m *WatchMutation) ClearField(name string) error {
 	switch name {
-	case userpreference.FieldCountry:
-		m.ClearCountry()
-		return nil
-	case userpreference.FieldCurrency:
-		m.ClearCurrency()
+	case watch.FieldLastPrice:
+		m.ClearLastPrice()
 		return nil
-	case userpreference.FieldLanguage:
-		m.ClearLanguage()
+	case watch.FieldLastMerchant:
+		m.ClearLastMerchant()
 		return nil
-	case userpreference.FieldTheme:
-		m.ClearTheme()
+	case watch.FieldLastLink:
+		m.ClearLastLink()
 		return nil
-	case userpreference.FieldSidebarOpen:
-		m.ClearSidebarOpen()
+	case watch.FieldNotifiedPrice:
+		m.ClearNotifiedPrice()
 		return nil
-	case userpreference.FieldLastActiveSessionID:
-		m.ClearLastActiveSessionID()
+	case watch.FieldLastCheckedAt:
+		m.ClearLastCheckedAt()
 		return nil
-	case userpreference.FieldSavedSearch:
-		m.ClearSavedSearch()
+	case watch.FieldNotifiedAt:
+		m.ClearNotifiedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown UserPreference nullable field %s", name)
+	return fmt.Errorf("unknown Watch nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *UserPreferenceMutation) ResetField(name string) error {
+func (m *WatchMutation) ResetField(name string) error {
 	switch name {
-	case userpreference.FieldUserID:
+	case watch.FieldUserID:
 		m.ResetUserID()
 		return nil
-	case userpreference.FieldCountry:
-		m.ResetCountry()
+	case watch.FieldPageToken:
+		m.ResetPageToken()
 		return nil
-	case userpreference.FieldCurrency:
+	case watch.FieldProductName:
+		m.ResetProductName()
+		return nil
+	case watch.FieldTargetPrice:
+		m.ResetTargetPrice()
+		return nil
+	case watch.FieldCurrency:
 		m.ResetCurrency()
 		return nil
-	case userpreference.FieldLanguage:
-		m.ResetLanguage()
+	case watch.FieldCountryCode:
+		m.ResetCountryCode()
 		return nil
-	case userpreference.FieldTheme:
-		m.ResetTheme()
+	case watch.FieldLastPrice:
+		m.ResetLastPrice()
 		return nil
-	case userpreference.FieldSidebarOpen:
-		m.ResetSidebarOpen()
+	case watch.FieldLastMerchant:
+		m.ResetLastMerchant()
 		return nil
-	case userpreference.FieldLastActiveSessionID:
-		m.ResetLastActiveSessionID()
+	case watch.FieldLastLink:
+		m.ResetLastLink()
 		return nil
-	case userpreference.FieldSavedSearch:
-		m.ResetSavedSearch()
+	case watch.FieldNotifiedPrice:
+		m.ResetNotifiedPrice()
 		return nil
-	case userpreference.FieldCreatedAt:
+	case watch.FieldActive:
+		m.ResetActive()
+		return nil
+	case watch.FieldLastCheckedAt:
+		m.ResetLastCheckedAt()
+		return nil
+	case watch.FieldNotifiedAt:
+		m.ResetNotifiedAt()
+		return nil
+	case watch.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case userpreference.FieldUpdatedAt:
+	case watch.FieldUpdatedAt:
 		m.ResetUpdatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown UserPreference field %s", name)
+	return fmt.Errorf("unknown Watch field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *UserPreferenceMutation) AddedEdges() []string {
+func (m *WatchMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, userpreference.EdgeUser)
+		edges = append(edges, watch.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *UserPreferenceMutation) AddedIDs(name string) []ent.Value {
+func (m *WatchMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case userpreference.EdgeUser:
+	case watch.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
@@ -5385,31 +14060,31 @@ func (m *UserPreferenceMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *UserPreferenceMutation) RemovedEdges() []string {
+func (m *WatchMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *UserPreferenceMutation) RemovedIDs(name string) []ent.Value {
+func (m *WatchMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *UserPreferenceMutation) ClearedEdges() []string {
+func (m *WatchMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, userpreference.EdgeUser)
+		edges = append(edges, watch.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *UserPreferenceMutation) EdgeCleared(name string) bool {
+func (m *WatchMutation) EdgeCleared(name string) bool {
 	switch name {
-	case userpreference.EdgeUser:
+	case watch.EdgeUser:
 		return m.cleareduser
 	}
 	return false
@@ -5417,22 +14092,22 @@ func (m *UserPreferenceMutation) EdgeCleared(name string) bool {
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *UserPreferenceMutation) ClearEdge(name string) error {
+func (m *WatchMutation) ClearEdge(name string) error {
 	switch name {
-	case userpreference.EdgeUser:
+	case watch.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown UserPreference unique edge %s", name)
+	return fmt.Errorf("unknown Watch unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *UserPreferenceMutation) ResetEdge(name string) error {
+func (m *WatchMutation) ResetEdge(name string) error {
 	switch name {
-	case userpreference.EdgeUser:
+	case watch.EdgeUser:
 		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown UserPreference edge %s", name)
+	return fmt.Errorf("unknown Watch edge %s", name)
 }
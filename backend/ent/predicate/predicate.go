@@ -6,17 +6,44 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// APIKey is the predicate function for apikey builders.
+type APIKey func(*sql.Selector)
+
+// AuditLog is the predicate function for auditlog builders.
+type AuditLog func(*sql.Selector)
+
 // ChatSession is the predicate function for chatsession builders.
 type ChatSession func(*sql.Selector)
 
+// CostRecord is the predicate function for costrecord builders.
+type CostRecord func(*sql.Selector)
+
+// JobRun is the predicate function for jobrun builders.
+type JobRun func(*sql.Selector)
+
 // Message is the predicate function for message builders.
 type Message func(*sql.Selector)
 
+// PriceObservation is the predicate function for priceobservation builders.
+type PriceObservation func(*sql.Selector)
+
 // SearchHistory is the predicate function for searchhistory builders.
 type SearchHistory func(*sql.Selector)
 
+// SessionParticipant is the predicate function for sessionparticipant builders.
+type SessionParticipant func(*sql.Selector)
+
+// SessionSnapshot is the predicate function for sessionsnapshot builders.
+type SessionSnapshot func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
 
+// UserIdentity is the predicate function for useridentity builders.
+type UserIdentity func(*sql.Selector)
+
 // UserPreference is the predicate function for userpreference builders.
 type UserPreference func(*sql.Selector)
+
+// Watch is the predicate function for watch builders.
+type Watch func(*sql.Selector)
@@ -0,0 +1,125 @@
+// Code generated by ent, DO NOT EDIT.
+
+package priceobservation
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the priceobservation type in the database.
+	Label = "price_observation"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldProductKey holds the string denoting the product_key field in the database.
+	FieldProductKey = "product_key"
+	// FieldProductName holds the string denoting the product_name field in the database.
+	FieldProductName = "product_name"
+	// FieldMerchant holds the string denoting the merchant field in the database.
+	FieldMerchant = "merchant"
+	// FieldPrice holds the string denoting the price field in the database.
+	FieldPrice = "price"
+	// FieldCurrency holds the string denoting the currency field in the database.
+	FieldCurrency = "currency"
+	// FieldCountryCode holds the string denoting the country_code field in the database.
+	FieldCountryCode = "country_code"
+	// FieldSource holds the string denoting the source field in the database.
+	FieldSource = "source"
+	// FieldObservedAt holds the string denoting the observed_at field in the database.
+	FieldObservedAt = "observed_at"
+	// Table holds the table name of the priceobservation in the database.
+	Table = "price_observations"
+)
+
+// Columns holds all SQL columns for priceobservation fields.
+var Columns = []string{
+	FieldID,
+	FieldProductKey,
+	FieldProductName,
+	FieldMerchant,
+	FieldPrice,
+	FieldCurrency,
+	FieldCountryCode,
+	FieldSource,
+	FieldObservedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ProductKeyValidator is a validator for the "product_key" field. It is called by the builders before save.
+	ProductKeyValidator func(string) error
+	// ProductNameValidator is a validator for the "product_name" field. It is called by the builders before save.
+	ProductNameValidator func(string) error
+	// PriceValidator is a validator for the "price" field. It is called by the builders before save.
+	PriceValidator func(float64) error
+	// CurrencyValidator is a validator for the "currency" field. It is called by the builders before save.
+	CurrencyValidator func(string) error
+	// DefaultCountryCode holds the default value on creation for the "country_code" field.
+	DefaultCountryCode string
+	// DefaultSource holds the default value on creation for the "source" field.
+	DefaultSource string
+	// DefaultObservedAt holds the default value on creation for the "observed_at" field.
+	DefaultObservedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the PriceObservation queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByProductKey orders the results by the product_key field.
+func ByProductKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProductKey, opts...).ToFunc()
+}
+
+// ByProductName orders the results by the product_name field.
+func ByProductName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProductName, opts...).ToFunc()
+}
+
+// ByMerchant orders the results by the merchant field.
+func ByMerchant(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMerchant, opts...).ToFunc()
+}
+
+// ByPrice orders the results by the price field.
+func ByPrice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPrice, opts...).ToFunc()
+}
+
+// ByCurrency orders the results by the currency field.
+func ByCurrency(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCurrency, opts...).ToFunc()
+}
+
+// ByCountryCode orders the results by the country_code field.
+func ByCountryCode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCountryCode, opts...).ToFunc()
+}
+
+// BySource orders the results by the source field.
+func BySource(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSource, opts...).ToFunc()
+}
+
+// ByObservedAt orders the results by the observed_at field.
+func ByObservedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldObservedAt, opts...).ToFunc()
+}
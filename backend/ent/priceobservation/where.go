@@ -0,0 +1,591 @@
+// Code generated by ent, DO NOT EDIT.
+
+package priceobservation
+
+import (
+	"mylittleprice/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldID, id))
+}
+
+// ProductKey applies equality check predicate on the "product_key" field. It's identical to ProductKeyEQ.
+func ProductKey(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldProductKey, v))
+}
+
+// ProductName applies equality check predicate on the "product_name" field. It's identical to ProductNameEQ.
+func ProductName(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldProductName, v))
+}
+
+// Merchant applies equality check predicate on the "merchant" field. It's identical to MerchantEQ.
+func Merchant(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldMerchant, v))
+}
+
+// Price applies equality check predicate on the "price" field. It's identical to PriceEQ.
+func Price(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldPrice, v))
+}
+
+// Currency applies equality check predicate on the "currency" field. It's identical to CurrencyEQ.
+func Currency(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldCurrency, v))
+}
+
+// CountryCode applies equality check predicate on the "country_code" field. It's identical to CountryCodeEQ.
+func CountryCode(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldCountryCode, v))
+}
+
+// Source applies equality check predicate on the "source" field. It's identical to SourceEQ.
+func Source(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldSource, v))
+}
+
+// ObservedAt applies equality check predicate on the "observed_at" field. It's identical to ObservedAtEQ.
+func ObservedAt(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldObservedAt, v))
+}
+
+// ProductKeyEQ applies the EQ predicate on the "product_key" field.
+func ProductKeyEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldProductKey, v))
+}
+
+// ProductKeyNEQ applies the NEQ predicate on the "product_key" field.
+func ProductKeyNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldProductKey, v))
+}
+
+// ProductKeyIn applies the In predicate on the "product_key" field.
+func ProductKeyIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldProductKey, vs...))
+}
+
+// ProductKeyNotIn applies the NotIn predicate on the "product_key" field.
+func ProductKeyNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldProductKey, vs...))
+}
+
+// ProductKeyGT applies the GT predicate on the "product_key" field.
+func ProductKeyGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldProductKey, v))
+}
+
+// ProductKeyGTE applies the GTE predicate on the "product_key" field.
+func ProductKeyGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldProductKey, v))
+}
+
+// ProductKeyLT applies the LT predicate on the "product_key" field.
+func ProductKeyLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldProductKey, v))
+}
+
+// ProductKeyLTE applies the LTE predicate on the "product_key" field.
+func ProductKeyLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldProductKey, v))
+}
+
+// ProductKeyContains applies the Contains predicate on the "product_key" field.
+func ProductKeyContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldProductKey, v))
+}
+
+// ProductKeyHasPrefix applies the HasPrefix predicate on the "product_key" field.
+func ProductKeyHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldProductKey, v))
+}
+
+// ProductKeyHasSuffix applies the HasSuffix predicate on the "product_key" field.
+func ProductKeyHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldProductKey, v))
+}
+
+// ProductKeyEqualFold applies the EqualFold predicate on the "product_key" field.
+func ProductKeyEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldProductKey, v))
+}
+
+// ProductKeyContainsFold applies the ContainsFold predicate on the "product_key" field.
+func ProductKeyContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldProductKey, v))
+}
+
+// ProductNameEQ applies the EQ predicate on the "product_name" field.
+func ProductNameEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldProductName, v))
+}
+
+// ProductNameNEQ applies the NEQ predicate on the "product_name" field.
+func ProductNameNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldProductName, v))
+}
+
+// ProductNameIn applies the In predicate on the "product_name" field.
+func ProductNameIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldProductName, vs...))
+}
+
+// ProductNameNotIn applies the NotIn predicate on the "product_name" field.
+func ProductNameNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldProductName, vs...))
+}
+
+// ProductNameGT applies the GT predicate on the "product_name" field.
+func ProductNameGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldProductName, v))
+}
+
+// ProductNameGTE applies the GTE predicate on the "product_name" field.
+func ProductNameGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldProductName, v))
+}
+
+// ProductNameLT applies the LT predicate on the "product_name" field.
+func ProductNameLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldProductName, v))
+}
+
+// ProductNameLTE applies the LTE predicate on the "product_name" field.
+func ProductNameLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldProductName, v))
+}
+
+// ProductNameContains applies the Contains predicate on the "product_name" field.
+func ProductNameContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldProductName, v))
+}
+
+// ProductNameHasPrefix applies the HasPrefix predicate on the "product_name" field.
+func ProductNameHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldProductName, v))
+}
+
+// ProductNameHasSuffix applies the HasSuffix predicate on the "product_name" field.
+func ProductNameHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldProductName, v))
+}
+
+// ProductNameEqualFold applies the EqualFold predicate on the "product_name" field.
+func ProductNameEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldProductName, v))
+}
+
+// ProductNameContainsFold applies the ContainsFold predicate on the "product_name" field.
+func ProductNameContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldProductName, v))
+}
+
+// MerchantEQ applies the EQ predicate on the "merchant" field.
+func MerchantEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldMerchant, v))
+}
+
+// MerchantNEQ applies the NEQ predicate on the "merchant" field.
+func MerchantNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldMerchant, v))
+}
+
+// MerchantIn applies the In predicate on the "merchant" field.
+func MerchantIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldMerchant, vs...))
+}
+
+// MerchantNotIn applies the NotIn predicate on the "merchant" field.
+func MerchantNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldMerchant, vs...))
+}
+
+// MerchantGT applies the GT predicate on the "merchant" field.
+func MerchantGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldMerchant, v))
+}
+
+// MerchantGTE applies the GTE predicate on the "merchant" field.
+func MerchantGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldMerchant, v))
+}
+
+// MerchantLT applies the LT predicate on the "merchant" field.
+func MerchantLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldMerchant, v))
+}
+
+// MerchantLTE applies the LTE predicate on the "merchant" field.
+func MerchantLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldMerchant, v))
+}
+
+// MerchantContains applies the Contains predicate on the "merchant" field.
+func MerchantContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldMerchant, v))
+}
+
+// MerchantHasPrefix applies the HasPrefix predicate on the "merchant" field.
+func MerchantHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldMerchant, v))
+}
+
+// MerchantHasSuffix applies the HasSuffix predicate on the "merchant" field.
+func MerchantHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldMerchant, v))
+}
+
+// MerchantIsNil applies the IsNil predicate on the "merchant" field.
+func MerchantIsNil() predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIsNull(FieldMerchant))
+}
+
+// MerchantNotNil applies the NotNil predicate on the "merchant" field.
+func MerchantNotNil() predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotNull(FieldMerchant))
+}
+
+// MerchantEqualFold applies the EqualFold predicate on the "merchant" field.
+func MerchantEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldMerchant, v))
+}
+
+// MerchantContainsFold applies the ContainsFold predicate on the "merchant" field.
+func MerchantContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldMerchant, v))
+}
+
+// PriceEQ applies the EQ predicate on the "price" field.
+func PriceEQ(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldPrice, v))
+}
+
+// PriceNEQ applies the NEQ predicate on the "price" field.
+func PriceNEQ(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldPrice, v))
+}
+
+// PriceIn applies the In predicate on the "price" field.
+func PriceIn(vs ...float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldPrice, vs...))
+}
+
+// PriceNotIn applies the NotIn predicate on the "price" field.
+func PriceNotIn(vs ...float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldPrice, vs...))
+}
+
+// PriceGT applies the GT predicate on the "price" field.
+func PriceGT(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldPrice, v))
+}
+
+// PriceGTE applies the GTE predicate on the "price" field.
+func PriceGTE(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldPrice, v))
+}
+
+// PriceLT applies the LT predicate on the "price" field.
+func PriceLT(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldPrice, v))
+}
+
+// PriceLTE applies the LTE predicate on the "price" field.
+func PriceLTE(v float64) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldPrice, v))
+}
+
+// CurrencyEQ applies the EQ predicate on the "currency" field.
+func CurrencyEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldCurrency, v))
+}
+
+// CurrencyNEQ applies the NEQ predicate on the "currency" field.
+func CurrencyNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldCurrency, v))
+}
+
+// CurrencyIn applies the In predicate on the "currency" field.
+func CurrencyIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldCurrency, vs...))
+}
+
+// CurrencyNotIn applies the NotIn predicate on the "currency" field.
+func CurrencyNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldCurrency, vs...))
+}
+
+// CurrencyGT applies the GT predicate on the "currency" field.
+func CurrencyGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldCurrency, v))
+}
+
+// CurrencyGTE applies the GTE predicate on the "currency" field.
+func CurrencyGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldCurrency, v))
+}
+
+// CurrencyLT applies the LT predicate on the "currency" field.
+func CurrencyLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldCurrency, v))
+}
+
+// CurrencyLTE applies the LTE predicate on the "currency" field.
+func CurrencyLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldCurrency, v))
+}
+
+// CurrencyContains applies the Contains predicate on the "currency" field.
+func CurrencyContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldCurrency, v))
+}
+
+// CurrencyHasPrefix applies the HasPrefix predicate on the "currency" field.
+func CurrencyHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldCurrency, v))
+}
+
+// CurrencyHasSuffix applies the HasSuffix predicate on the "currency" field.
+func CurrencyHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldCurrency, v))
+}
+
+// CurrencyEqualFold applies the EqualFold predicate on the "currency" field.
+func CurrencyEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldCurrency, v))
+}
+
+// CurrencyContainsFold applies the ContainsFold predicate on the "currency" field.
+func CurrencyContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldCurrency, v))
+}
+
+// CountryCodeEQ applies the EQ predicate on the "country_code" field.
+func CountryCodeEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldCountryCode, v))
+}
+
+// CountryCodeNEQ applies the NEQ predicate on the "country_code" field.
+func CountryCodeNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldCountryCode, v))
+}
+
+// CountryCodeIn applies the In predicate on the "country_code" field.
+func CountryCodeIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldCountryCode, vs...))
+}
+
+// CountryCodeNotIn applies the NotIn predicate on the "country_code" field.
+func CountryCodeNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldCountryCode, vs...))
+}
+
+// CountryCodeGT applies the GT predicate on the "country_code" field.
+func CountryCodeGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldCountryCode, v))
+}
+
+// CountryCodeGTE applies the GTE predicate on the "country_code" field.
+func CountryCodeGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldCountryCode, v))
+}
+
+// CountryCodeLT applies the LT predicate on the "country_code" field.
+func CountryCodeLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldCountryCode, v))
+}
+
+// CountryCodeLTE applies the LTE predicate on the "country_code" field.
+func CountryCodeLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldCountryCode, v))
+}
+
+// CountryCodeContains applies the Contains predicate on the "country_code" field.
+func CountryCodeContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldCountryCode, v))
+}
+
+// CountryCodeHasPrefix applies the HasPrefix predicate on the "country_code" field.
+func CountryCodeHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldCountryCode, v))
+}
+
+// CountryCodeHasSuffix applies the HasSuffix predicate on the "country_code" field.
+func CountryCodeHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldCountryCode, v))
+}
+
+// CountryCodeEqualFold applies the EqualFold predicate on the "country_code" field.
+func CountryCodeEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldCountryCode, v))
+}
+
+// CountryCodeContainsFold applies the ContainsFold predicate on the "country_code" field.
+func CountryCodeContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldCountryCode, v))
+}
+
+// SourceEQ applies the EQ predicate on the "source" field.
+func SourceEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldSource, v))
+}
+
+// SourceNEQ applies the NEQ predicate on the "source" field.
+func SourceNEQ(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldSource, v))
+}
+
+// SourceIn applies the In predicate on the "source" field.
+func SourceIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldSource, vs...))
+}
+
+// SourceNotIn applies the NotIn predicate on the "source" field.
+func SourceNotIn(vs ...string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldSource, vs...))
+}
+
+// SourceGT applies the GT predicate on the "source" field.
+func SourceGT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldSource, v))
+}
+
+// SourceGTE applies the GTE predicate on the "source" field.
+func SourceGTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldSource, v))
+}
+
+// SourceLT applies the LT predicate on the "source" field.
+func SourceLT(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldSource, v))
+}
+
+// SourceLTE applies the LTE predicate on the "source" field.
+func SourceLTE(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldSource, v))
+}
+
+// SourceContains applies the Contains predicate on the "source" field.
+func SourceContains(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContains(FieldSource, v))
+}
+
+// SourceHasPrefix applies the HasPrefix predicate on the "source" field.
+func SourceHasPrefix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasPrefix(FieldSource, v))
+}
+
+// SourceHasSuffix applies the HasSuffix predicate on the "source" field.
+func SourceHasSuffix(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldHasSuffix(FieldSource, v))
+}
+
+// SourceEqualFold applies the EqualFold predicate on the "source" field.
+func SourceEqualFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEqualFold(FieldSource, v))
+}
+
+// SourceContainsFold applies the ContainsFold predicate on the "source" field.
+func SourceContainsFold(v string) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldContainsFold(FieldSource, v))
+}
+
+// ObservedAtEQ applies the EQ predicate on the "observed_at" field.
+func ObservedAtEQ(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldEQ(FieldObservedAt, v))
+}
+
+// ObservedAtNEQ applies the NEQ predicate on the "observed_at" field.
+func ObservedAtNEQ(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNEQ(FieldObservedAt, v))
+}
+
+// ObservedAtIn applies the In predicate on the "observed_at" field.
+func ObservedAtIn(vs ...time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldIn(FieldObservedAt, vs...))
+}
+
+// ObservedAtNotIn applies the NotIn predicate on the "observed_at" field.
+func ObservedAtNotIn(vs ...time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldNotIn(FieldObservedAt, vs...))
+}
+
+// ObservedAtGT applies the GT predicate on the "observed_at" field.
+func ObservedAtGT(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGT(FieldObservedAt, v))
+}
+
+// ObservedAtGTE applies the GTE predicate on the "observed_at" field.
+func ObservedAtGTE(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldGTE(FieldObservedAt, v))
+}
+
+// ObservedAtLT applies the LT predicate on the "observed_at" field.
+func ObservedAtLT(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLT(FieldObservedAt, v))
+}
+
+// ObservedAtLTE applies the LTE predicate on the "observed_at" field.
+func ObservedAtLTE(v time.Time) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.FieldLTE(FieldObservedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PriceObservation) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PriceObservation) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PriceObservation) predicate.PriceObservation {
+	return predicate.PriceObservation(sql.NotPredicates(p))
+}
@@ -0,0 +1,366 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/priceobservation"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PriceObservationCreate is the builder for creating a PriceObservation entity.
+type PriceObservationCreate struct {
+	config
+	mutation *PriceObservationMutation
+	hooks    []Hook
+}
+
+// SetProductKey sets the "product_key" field.
+func (_c *PriceObservationCreate) SetProductKey(v string) *PriceObservationCreate {
+	_c.mutation.SetProductKey(v)
+	return _c
+}
+
+// SetProductName sets the "product_name" field.
+func (_c *PriceObservationCreate) SetProductName(v string) *PriceObservationCreate {
+	_c.mutation.SetProductName(v)
+	return _c
+}
+
+// SetMerchant sets the "merchant" field.
+func (_c *PriceObservationCreate) SetMerchant(v string) *PriceObservationCreate {
+	_c.mutation.SetMerchant(v)
+	return _c
+}
+
+// SetNillableMerchant sets the "merchant" field if the given value is not nil.
+func (_c *PriceObservationCreate) SetNillableMerchant(v *string) *PriceObservationCreate {
+	if v != nil {
+		_c.SetMerchant(*v)
+	}
+	return _c
+}
+
+// SetPrice sets the "price" field.
+func (_c *PriceObservationCreate) SetPrice(v float64) *PriceObservationCreate {
+	_c.mutation.SetPrice(v)
+	return _c
+}
+
+// SetCurrency sets the "currency" field.
+func (_c *PriceObservationCreate) SetCurrency(v string) *PriceObservationCreate {
+	_c.mutation.SetCurrency(v)
+	return _c
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_c *PriceObservationCreate) SetCountryCode(v string) *PriceObservationCreate {
+	_c.mutation.SetCountryCode(v)
+	return _c
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_c *PriceObservationCreate) SetNillableCountryCode(v *string) *PriceObservationCreate {
+	if v != nil {
+		_c.SetCountryCode(*v)
+	}
+	return _c
+}
+
+// SetSource sets the "source" field.
+func (_c *PriceObservationCreate) SetSource(v string) *PriceObservationCreate {
+	_c.mutation.SetSource(v)
+	return _c
+}
+
+// SetNillableSource sets the "source" field if the given value is not nil.
+func (_c *PriceObservationCreate) SetNillableSource(v *string) *PriceObservationCreate {
+	if v != nil {
+		_c.SetSource(*v)
+	}
+	return _c
+}
+
+// SetObservedAt sets the "observed_at" field.
+func (_c *PriceObservationCreate) SetObservedAt(v time.Time) *PriceObservationCreate {
+	_c.mutation.SetObservedAt(v)
+	return _c
+}
+
+// SetNillableObservedAt sets the "observed_at" field if the given value is not nil.
+func (_c *PriceObservationCreate) SetNillableObservedAt(v *time.Time) *PriceObservationCreate {
+	if v != nil {
+		_c.SetObservedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *PriceObservationCreate) SetID(v uuid.UUID) *PriceObservationCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *PriceObservationCreate) SetNillableID(v *uuid.UUID) *PriceObservationCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the PriceObservationMutation object of the builder.
+func (_c *PriceObservationCreate) Mutation() *PriceObservationMutation {
+	return _c.mutation
+}
+
+// Save creates the PriceObservation in the database.
+func (_c *PriceObservationCreate) Save(ctx context.Context) (*PriceObservation, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PriceObservationCreate) SaveX(ctx context.Context) *PriceObservation {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PriceObservationCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PriceObservationCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PriceObservationCreate) defaults() {
+	if _, ok := _c.mutation.CountryCode(); !ok {
+		v := priceobservation.DefaultCountryCode
+		_c.mutation.SetCountryCode(v)
+	}
+	if _, ok := _c.mutation.Source(); !ok {
+		v := priceobservation.DefaultSource
+		_c.mutation.SetSource(v)
+	}
+	if _, ok := _c.mutation.ObservedAt(); !ok {
+		v := priceobservation.DefaultObservedAt()
+		_c.mutation.SetObservedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := priceobservation.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PriceObservationCreate) check() error {
+	if _, ok := _c.mutation.ProductKey(); !ok {
+		return &ValidationError{Name: "product_key", err: errors.New(`ent: missing required field "PriceObservation.product_key"`)}
+	}
+	if v, ok := _c.mutation.ProductKey(); ok {
+		if err := priceobservation.ProductKeyValidator(v); err != nil {
+			return &ValidationError{Name: "product_key", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ProductName(); !ok {
+		return &ValidationError{Name: "product_name", err: errors.New(`ent: missing required field "PriceObservation.product_name"`)}
+	}
+	if v, ok := _c.mutation.ProductName(); ok {
+		if err := priceobservation.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Price(); !ok {
+		return &ValidationError{Name: "price", err: errors.New(`ent: missing required field "PriceObservation.price"`)}
+	}
+	if v, ok := _c.mutation.Price(); ok {
+		if err := priceobservation.PriceValidator(v); err != nil {
+			return &ValidationError{Name: "price", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.price": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Currency(); !ok {
+		return &ValidationError{Name: "currency", err: errors.New(`ent: missing required field "PriceObservation.currency"`)}
+	}
+	if v, ok := _c.mutation.Currency(); ok {
+		if err := priceobservation.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.currency": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CountryCode(); !ok {
+		return &ValidationError{Name: "country_code", err: errors.New(`ent: missing required field "PriceObservation.country_code"`)}
+	}
+	if _, ok := _c.mutation.Source(); !ok {
+		return &ValidationError{Name: "source", err: errors.New(`ent: missing required field "PriceObservation.source"`)}
+	}
+	if _, ok := _c.mutation.ObservedAt(); !ok {
+		return &ValidationError{Name: "observed_at", err: errors.New(`ent: missing required field "PriceObservation.observed_at"`)}
+	}
+	return nil
+}
+
+func (_c *PriceObservationCreate) sqlSave(ctx context.Context) (*PriceObservation, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PriceObservationCreate) createSpec() (*PriceObservation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PriceObservation{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(priceobservation.Table, sqlgraph.NewFieldSpec(priceobservation.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.ProductKey(); ok {
+		_spec.SetField(priceobservation.FieldProductKey, field.TypeString, value)
+		_node.ProductKey = value
+	}
+	if value, ok := _c.mutation.ProductName(); ok {
+		_spec.SetField(priceobservation.FieldProductName, field.TypeString, value)
+		_node.ProductName = value
+	}
+	if value, ok := _c.mutation.Merchant(); ok {
+		_spec.SetField(priceobservation.FieldMerchant, field.TypeString, value)
+		_node.Merchant = value
+	}
+	if value, ok := _c.mutation.Price(); ok {
+		_spec.SetField(priceobservation.FieldPrice, field.TypeFloat64, value)
+		_node.Price = value
+	}
+	if value, ok := _c.mutation.Currency(); ok {
+		_spec.SetField(priceobservation.FieldCurrency, field.TypeString, value)
+		_node.Currency = value
+	}
+	if value, ok := _c.mutation.CountryCode(); ok {
+		_spec.SetField(priceobservation.FieldCountryCode, field.TypeString, value)
+		_node.CountryCode = value
+	}
+	if value, ok := _c.mutation.Source(); ok {
+		_spec.SetField(priceobservation.FieldSource, field.TypeString, value)
+		_node.Source = value
+	}
+	if value, ok := _c.mutation.ObservedAt(); ok {
+		_spec.SetField(priceobservation.FieldObservedAt, field.TypeTime, value)
+		_node.ObservedAt = value
+	}
+	return _node, _spec
+}
+
+// PriceObservationCreateBulk is the builder for creating many PriceObservation entities in bulk.
+type PriceObservationCreateBulk struct {
+	config
+	err      error
+	builders []*PriceObservationCreate
+}
+
+// Save creates the PriceObservation entities in the database.
+func (_c *PriceObservationCreateBulk) Save(ctx context.Context) ([]*PriceObservation, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PriceObservation, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PriceObservationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PriceObservationCreateBulk) SaveX(ctx context.Context) []*PriceObservation {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PriceObservationCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PriceObservationCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
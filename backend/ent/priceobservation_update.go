@@ -0,0 +1,507 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/predicate"
+	"mylittleprice/ent/priceobservation"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PriceObservationUpdate is the builder for updating PriceObservation entities.
+type PriceObservationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PriceObservationMutation
+}
+
+// Where appends a list predicates to the PriceObservationUpdate builder.
+func (_u *PriceObservationUpdate) Where(ps ...predicate.PriceObservation) *PriceObservationUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetProductKey sets the "product_key" field.
+func (_u *PriceObservationUpdate) SetProductKey(v string) *PriceObservationUpdate {
+	_u.mutation.SetProductKey(v)
+	return _u
+}
+
+// SetNillableProductKey sets the "product_key" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableProductKey(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetProductKey(*v)
+	}
+	return _u
+}
+
+// SetProductName sets the "product_name" field.
+func (_u *PriceObservationUpdate) SetProductName(v string) *PriceObservationUpdate {
+	_u.mutation.SetProductName(v)
+	return _u
+}
+
+// SetNillableProductName sets the "product_name" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableProductName(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetProductName(*v)
+	}
+	return _u
+}
+
+// SetMerchant sets the "merchant" field.
+func (_u *PriceObservationUpdate) SetMerchant(v string) *PriceObservationUpdate {
+	_u.mutation.SetMerchant(v)
+	return _u
+}
+
+// SetNillableMerchant sets the "merchant" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableMerchant(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetMerchant(*v)
+	}
+	return _u
+}
+
+// ClearMerchant clears the value of the "merchant" field.
+func (_u *PriceObservationUpdate) ClearMerchant() *PriceObservationUpdate {
+	_u.mutation.ClearMerchant()
+	return _u
+}
+
+// SetPrice sets the "price" field.
+func (_u *PriceObservationUpdate) SetPrice(v float64) *PriceObservationUpdate {
+	_u.mutation.ResetPrice()
+	_u.mutation.SetPrice(v)
+	return _u
+}
+
+// SetNillablePrice sets the "price" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillablePrice(v *float64) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetPrice(*v)
+	}
+	return _u
+}
+
+// AddPrice adds value to the "price" field.
+func (_u *PriceObservationUpdate) AddPrice(v float64) *PriceObservationUpdate {
+	_u.mutation.AddPrice(v)
+	return _u
+}
+
+// SetCurrency sets the "currency" field.
+func (_u *PriceObservationUpdate) SetCurrency(v string) *PriceObservationUpdate {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableCurrency(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_u *PriceObservationUpdate) SetCountryCode(v string) *PriceObservationUpdate {
+	_u.mutation.SetCountryCode(v)
+	return _u
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableCountryCode(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetCountryCode(*v)
+	}
+	return _u
+}
+
+// SetSource sets the "source" field.
+func (_u *PriceObservationUpdate) SetSource(v string) *PriceObservationUpdate {
+	_u.mutation.SetSource(v)
+	return _u
+}
+
+// SetNillableSource sets the "source" field if the given value is not nil.
+func (_u *PriceObservationUpdate) SetNillableSource(v *string) *PriceObservationUpdate {
+	if v != nil {
+		_u.SetSource(*v)
+	}
+	return _u
+}
+
+// Mutation returns the PriceObservationMutation object of the builder.
+func (_u *PriceObservationUpdate) Mutation() *PriceObservationMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PriceObservationUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PriceObservationUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PriceObservationUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PriceObservationUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PriceObservationUpdate) check() error {
+	if v, ok := _u.mutation.ProductKey(); ok {
+		if err := priceobservation.ProductKeyValidator(v); err != nil {
+			return &ValidationError{Name: "product_key", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_key": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProductName(); ok {
+		if err := priceobservation.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Price(); ok {
+		if err := priceobservation.PriceValidator(v); err != nil {
+			return &ValidationError{Name: "price", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.price": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Currency(); ok {
+		if err := priceobservation.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.currency": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *PriceObservationUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(priceobservation.Table, priceobservation.Columns, sqlgraph.NewFieldSpec(priceobservation.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ProductKey(); ok {
+		_spec.SetField(priceobservation.FieldProductKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProductName(); ok {
+		_spec.SetField(priceobservation.FieldProductName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Merchant(); ok {
+		_spec.SetField(priceobservation.FieldMerchant, field.TypeString, value)
+	}
+	if _u.mutation.MerchantCleared() {
+		_spec.ClearField(priceobservation.FieldMerchant, field.TypeString)
+	}
+	if value, ok := _u.mutation.Price(); ok {
+		_spec.SetField(priceobservation.FieldPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedPrice(); ok {
+		_spec.AddField(priceobservation.FieldPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(priceobservation.FieldCurrency, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CountryCode(); ok {
+		_spec.SetField(priceobservation.FieldCountryCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Source(); ok {
+		_spec.SetField(priceobservation.FieldSource, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{priceobservation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PriceObservationUpdateOne is the builder for updating a single PriceObservation entity.
+type PriceObservationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PriceObservationMutation
+}
+
+// SetProductKey sets the "product_key" field.
+func (_u *PriceObservationUpdateOne) SetProductKey(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetProductKey(v)
+	return _u
+}
+
+// SetNillableProductKey sets the "product_key" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableProductKey(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetProductKey(*v)
+	}
+	return _u
+}
+
+// SetProductName sets the "product_name" field.
+func (_u *PriceObservationUpdateOne) SetProductName(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetProductName(v)
+	return _u
+}
+
+// SetNillableProductName sets the "product_name" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableProductName(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetProductName(*v)
+	}
+	return _u
+}
+
+// SetMerchant sets the "merchant" field.
+func (_u *PriceObservationUpdateOne) SetMerchant(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetMerchant(v)
+	return _u
+}
+
+// SetNillableMerchant sets the "merchant" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableMerchant(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetMerchant(*v)
+	}
+	return _u
+}
+
+// ClearMerchant clears the value of the "merchant" field.
+func (_u *PriceObservationUpdateOne) ClearMerchant() *PriceObservationUpdateOne {
+	_u.mutation.ClearMerchant()
+	return _u
+}
+
+// SetPrice sets the "price" field.
+func (_u *PriceObservationUpdateOne) SetPrice(v float64) *PriceObservationUpdateOne {
+	_u.mutation.ResetPrice()
+	_u.mutation.SetPrice(v)
+	return _u
+}
+
+// SetNillablePrice sets the "price" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillablePrice(v *float64) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetPrice(*v)
+	}
+	return _u
+}
+
+// AddPrice adds value to the "price" field.
+func (_u *PriceObservationUpdateOne) AddPrice(v float64) *PriceObservationUpdateOne {
+	_u.mutation.AddPrice(v)
+	return _u
+}
+
+// SetCurrency sets the "currency" field.
+func (_u *PriceObservationUpdateOne) SetCurrency(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableCurrency(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_u *PriceObservationUpdateOne) SetCountryCode(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetCountryCode(v)
+	return _u
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableCountryCode(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetCountryCode(*v)
+	}
+	return _u
+}
+
+// SetSource sets the "source" field.
+func (_u *PriceObservationUpdateOne) SetSource(v string) *PriceObservationUpdateOne {
+	_u.mutation.SetSource(v)
+	return _u
+}
+
+// SetNillableSource sets the "source" field if the given value is not nil.
+func (_u *PriceObservationUpdateOne) SetNillableSource(v *string) *PriceObservationUpdateOne {
+	if v != nil {
+		_u.SetSource(*v)
+	}
+	return _u
+}
+
+// Mutation returns the PriceObservationMutation object of the builder.
+func (_u *PriceObservationUpdateOne) Mutation() *PriceObservationMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the PriceObservationUpdate builder.
+func (_u *PriceObservationUpdateOne) Where(ps ...predicate.PriceObservation) *PriceObservationUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PriceObservationUpdateOne) Select(field string, fields ...string) *PriceObservationUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PriceObservation entity.
+func (_u *PriceObservationUpdateOne) Save(ctx context.Context) (*PriceObservation, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PriceObservationUpdateOne) SaveX(ctx context.Context) *PriceObservation {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PriceObservationUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PriceObservationUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PriceObservationUpdateOne) check() error {
+	if v, ok := _u.mutation.ProductKey(); ok {
+		if err := priceobservation.ProductKeyValidator(v); err != nil {
+			return &ValidationError{Name: "product_key", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_key": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProductName(); ok {
+		if err := priceobservation.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.product_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Price(); ok {
+		if err := priceobservation.PriceValidator(v); err != nil {
+			return &ValidationError{Name: "price", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.price": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Currency(); ok {
+		if err := priceobservation.CurrencyValidator(v); err != nil {
+			return &ValidationError{Name: "currency", err: fmt.Errorf(`ent: validator failed for field "PriceObservation.currency": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *PriceObservationUpdateOne) sqlSave(ctx context.Context) (_node *PriceObservation, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(priceobservation.Table, priceobservation.Columns, sqlgraph.NewFieldSpec(priceobservation.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PriceObservation.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, priceobservation.FieldID)
+		for _, f := range fields {
+			if !priceobservation.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != priceobservation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ProductKey(); ok {
+		_spec.SetField(priceobservation.FieldProductKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProductName(); ok {
+		_spec.SetField(priceobservation.FieldProductName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Merchant(); ok {
+		_spec.SetField(priceobservation.FieldMerchant, field.TypeString, value)
+	}
+	if _u.mutation.MerchantCleared() {
+		_spec.ClearField(priceobservation.FieldMerchant, field.TypeString)
+	}
+	if value, ok := _u.mutation.Price(); ok {
+		_spec.SetField(priceobservation.FieldPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedPrice(); ok {
+		_spec.AddField(priceobservation.FieldPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(priceobservation.FieldCurrency, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CountryCode(); ok {
+		_spec.SetField(priceobservation.FieldCountryCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Source(); ok {
+		_spec.SetField(priceobservation.FieldSource, field.TypeString, value)
+	}
+	_node = &PriceObservation{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{priceobservation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
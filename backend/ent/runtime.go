@@ -3,12 +3,21 @@
 package ent
 
 import (
+	"mylittleprice/ent/apikey"
+	"mylittleprice/ent/auditlog"
 	"mylittleprice/ent/chatsession"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/jobrun"
 	"mylittleprice/ent/message"
+	"mylittleprice/ent/priceobservation"
 	"mylittleprice/ent/schema"
 	"mylittleprice/ent/searchhistory"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/ent/sessionsnapshot"
 	"mylittleprice/ent/user"
+	"mylittleprice/ent/useridentity"
 	"mylittleprice/ent/userpreference"
+	"mylittleprice/ent/watch"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +27,42 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apikeyFields := schema.APIKey{}.Fields()
+	_ = apikeyFields
+	// apikeyDescName is the schema descriptor for name field.
+	apikeyDescName := apikeyFields[2].Descriptor()
+	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apikey.NameValidator = apikeyDescName.Validators[0].(func(string) error)
+	// apikeyDescPrefix is the schema descriptor for prefix field.
+	apikeyDescPrefix := apikeyFields[3].Descriptor()
+	// apikey.PrefixValidator is a validator for the "prefix" field. It is called by the builders before save.
+	apikey.PrefixValidator = apikeyDescPrefix.Validators[0].(func(string) error)
+	// apikeyDescKeyHash is the schema descriptor for key_hash field.
+	apikeyDescKeyHash := apikeyFields[4].Descriptor()
+	// apikey.KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	apikey.KeyHashValidator = apikeyDescKeyHash.Validators[0].(func(string) error)
+	// apikeyDescCreatedAt is the schema descriptor for created_at field.
+	apikeyDescCreatedAt := apikeyFields[9].Descriptor()
+	// apikey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apikey.DefaultCreatedAt = apikeyDescCreatedAt.Default.(func() time.Time)
+	// apikeyDescID is the schema descriptor for id field.
+	apikeyDescID := apikeyFields[0].Descriptor()
+	// apikey.DefaultID holds the default value on creation for the id field.
+	apikey.DefaultID = apikeyDescID.Default.(func() uuid.UUID)
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescAction is the schema descriptor for action field.
+	auditlogDescAction := auditlogFields[1].Descriptor()
+	// auditlog.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditlog.ActionValidator = auditlogDescAction.Validators[0].(func(string) error)
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[6].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
+	// auditlogDescID is the schema descriptor for id field.
+	auditlogDescID := auditlogFields[0].Descriptor()
+	// auditlog.DefaultID holds the default value on creation for the id field.
+	auditlog.DefaultID = auditlogDescID.Default.(func() uuid.UUID)
 	chatsessionFields := schema.ChatSession{}.Fields()
 	_ = chatsessionFields
 	// chatsessionDescSessionID is the schema descriptor for session_id field.
@@ -66,6 +111,78 @@ func init() {
 	chatsessionDescID := chatsessionFields[0].Descriptor()
 	// chatsession.DefaultID holds the default value on creation for the id field.
 	chatsession.DefaultID = chatsessionDescID.Default.(func() uuid.UUID)
+	costrecordFields := schema.CostRecord{}.Fields()
+	_ = costrecordFields
+	// costrecordDescProvider is the schema descriptor for provider field.
+	costrecordDescProvider := costrecordFields[4].Descriptor()
+	// costrecord.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	costrecord.ProviderValidator = costrecordDescProvider.Validators[0].(func(string) error)
+	// costrecordDescModel is the schema descriptor for model field.
+	costrecordDescModel := costrecordFields[5].Descriptor()
+	// costrecord.ModelValidator is a validator for the "model" field. It is called by the builders before save.
+	costrecord.ModelValidator = costrecordDescModel.Validators[0].(func(string) error)
+	// costrecordDescPurpose is the schema descriptor for purpose field.
+	costrecordDescPurpose := costrecordFields[6].Descriptor()
+	// costrecord.PurposeValidator is a validator for the "purpose" field. It is called by the builders before save.
+	costrecord.PurposeValidator = costrecordDescPurpose.Validators[0].(func(string) error)
+	// costrecordDescInputTokens is the schema descriptor for input_tokens field.
+	costrecordDescInputTokens := costrecordFields[7].Descriptor()
+	// costrecord.DefaultInputTokens holds the default value on creation for the input_tokens field.
+	costrecord.DefaultInputTokens = costrecordDescInputTokens.Default.(int)
+	// costrecordDescOutputTokens is the schema descriptor for output_tokens field.
+	costrecordDescOutputTokens := costrecordFields[8].Descriptor()
+	// costrecord.DefaultOutputTokens holds the default value on creation for the output_tokens field.
+	costrecord.DefaultOutputTokens = costrecordDescOutputTokens.Default.(int)
+	// costrecordDescGrounded is the schema descriptor for grounded field.
+	costrecordDescGrounded := costrecordFields[9].Descriptor()
+	// costrecord.DefaultGrounded holds the default value on creation for the grounded field.
+	costrecord.DefaultGrounded = costrecordDescGrounded.Default.(bool)
+	// costrecordDescCostUsd is the schema descriptor for cost_usd field.
+	costrecordDescCostUsd := costrecordFields[10].Descriptor()
+	// costrecord.DefaultCostUsd holds the default value on creation for the cost_usd field.
+	costrecord.DefaultCostUsd = costrecordDescCostUsd.Default.(float64)
+	// costrecordDescCreatedAt is the schema descriptor for created_at field.
+	costrecordDescCreatedAt := costrecordFields[11].Descriptor()
+	// costrecord.DefaultCreatedAt holds the default value on creation for the created_at field.
+	costrecord.DefaultCreatedAt = costrecordDescCreatedAt.Default.(func() time.Time)
+	// costrecordDescID is the schema descriptor for id field.
+	costrecordDescID := costrecordFields[0].Descriptor()
+	// costrecord.DefaultID holds the default value on creation for the id field.
+	costrecord.DefaultID = costrecordDescID.Default.(func() uuid.UUID)
+	jobrunFields := schema.JobRun{}.Fields()
+	_ = jobrunFields
+	// jobrunDescJob is the schema descriptor for job field.
+	jobrunDescJob := jobrunFields[1].Descriptor()
+	// jobrun.JobValidator is a validator for the "job" field. It is called by the builders before save.
+	jobrun.JobValidator = jobrunDescJob.Validators[0].(func(string) error)
+	// jobrunDescTrigger is the schema descriptor for trigger field.
+	jobrunDescTrigger := jobrunFields[2].Descriptor()
+	// jobrun.TriggerValidator is a validator for the "trigger" field. It is called by the builders before save.
+	jobrun.TriggerValidator = jobrunDescTrigger.Validators[0].(func(string) error)
+	// jobrunDescInstance is the schema descriptor for instance field.
+	jobrunDescInstance := jobrunFields[4].Descriptor()
+	// jobrun.InstanceValidator is a validator for the "instance" field. It is called by the builders before save.
+	jobrun.InstanceValidator = jobrunDescInstance.Validators[0].(func(string) error)
+	// jobrunDescStatus is the schema descriptor for status field.
+	jobrunDescStatus := jobrunFields[5].Descriptor()
+	// jobrun.DefaultStatus holds the default value on creation for the status field.
+	jobrun.DefaultStatus = jobrunDescStatus.Default.(string)
+	// jobrunDescRowsAffected is the schema descriptor for rows_affected field.
+	jobrunDescRowsAffected := jobrunFields[6].Descriptor()
+	// jobrun.DefaultRowsAffected holds the default value on creation for the rows_affected field.
+	jobrun.DefaultRowsAffected = jobrunDescRowsAffected.Default.(int64)
+	// jobrunDescDurationMs is the schema descriptor for duration_ms field.
+	jobrunDescDurationMs := jobrunFields[8].Descriptor()
+	// jobrun.DefaultDurationMs holds the default value on creation for the duration_ms field.
+	jobrun.DefaultDurationMs = jobrunDescDurationMs.Default.(int64)
+	// jobrunDescStartedAt is the schema descriptor for started_at field.
+	jobrunDescStartedAt := jobrunFields[9].Descriptor()
+	// jobrun.DefaultStartedAt holds the default value on creation for the started_at field.
+	jobrun.DefaultStartedAt = jobrunDescStartedAt.Default.(func() time.Time)
+	// jobrunDescID is the schema descriptor for id field.
+	jobrunDescID := jobrunFields[0].Descriptor()
+	// jobrun.DefaultID holds the default value on creation for the id field.
+	jobrun.DefaultID = jobrunDescID.Default.(func() uuid.UUID)
 	messageFields := schema.Message{}.Fields()
 	_ = messageFields
 	// messageDescRole is the schema descriptor for role field.
@@ -84,6 +201,40 @@ func init() {
 	messageDescID := messageFields[0].Descriptor()
 	// message.DefaultID holds the default value on creation for the id field.
 	message.DefaultID = messageDescID.Default.(func() uuid.UUID)
+	priceobservationFields := schema.PriceObservation{}.Fields()
+	_ = priceobservationFields
+	// priceobservationDescProductKey is the schema descriptor for product_key field.
+	priceobservationDescProductKey := priceobservationFields[1].Descriptor()
+	// priceobservation.ProductKeyValidator is a validator for the "product_key" field. It is called by the builders before save.
+	priceobservation.ProductKeyValidator = priceobservationDescProductKey.Validators[0].(func(string) error)
+	// priceobservationDescProductName is the schema descriptor for product_name field.
+	priceobservationDescProductName := priceobservationFields[2].Descriptor()
+	// priceobservation.ProductNameValidator is a validator for the "product_name" field. It is called by the builders before save.
+	priceobservation.ProductNameValidator = priceobservationDescProductName.Validators[0].(func(string) error)
+	// priceobservationDescPrice is the schema descriptor for price field.
+	priceobservationDescPrice := priceobservationFields[4].Descriptor()
+	// priceobservation.PriceValidator is a validator for the "price" field. It is called by the builders before save.
+	priceobservation.PriceValidator = priceobservationDescPrice.Validators[0].(func(float64) error)
+	// priceobservationDescCurrency is the schema descriptor for currency field.
+	priceobservationDescCurrency := priceobservationFields[5].Descriptor()
+	// priceobservation.CurrencyValidator is a validator for the "currency" field. It is called by the builders before save.
+	priceobservation.CurrencyValidator = priceobservationDescCurrency.Validators[0].(func(string) error)
+	// priceobservationDescCountryCode is the schema descriptor for country_code field.
+	priceobservationDescCountryCode := priceobservationFields[6].Descriptor()
+	// priceobservation.DefaultCountryCode holds the default value on creation for the country_code field.
+	priceobservation.DefaultCountryCode = priceobservationDescCountryCode.Default.(string)
+	// priceobservationDescSource is the schema descriptor for source field.
+	priceobservationDescSource := priceobservationFields[7].Descriptor()
+	// priceobservation.DefaultSource holds the default value on creation for the source field.
+	priceobservation.DefaultSource = priceobservationDescSource.Default.(string)
+	// priceobservationDescObservedAt is the schema descriptor for observed_at field.
+	priceobservationDescObservedAt := priceobservationFields[8].Descriptor()
+	// priceobservation.DefaultObservedAt holds the default value on creation for the observed_at field.
+	priceobservation.DefaultObservedAt = priceobservationDescObservedAt.Default.(func() time.Time)
+	// priceobservationDescID is the schema descriptor for id field.
+	priceobservationDescID := priceobservationFields[0].Descriptor()
+	// priceobservation.DefaultID holds the default value on creation for the id field.
+	priceobservation.DefaultID = priceobservationDescID.Default.(func() uuid.UUID)
 	searchhistoryFields := schema.SearchHistory{}.Fields()
 	_ = searchhistoryFields
 	// searchhistoryDescSearchQuery is the schema descriptor for search_query field.
@@ -118,6 +269,42 @@ func init() {
 	searchhistoryDescID := searchhistoryFields[0].Descriptor()
 	// searchhistory.DefaultID holds the default value on creation for the id field.
 	searchhistory.DefaultID = searchhistoryDescID.Default.(func() uuid.UUID)
+	sessionparticipantFields := schema.SessionParticipant{}.Fields()
+	_ = sessionparticipantFields
+	// sessionparticipantDescSessionID is the schema descriptor for session_id field.
+	sessionparticipantDescSessionID := sessionparticipantFields[1].Descriptor()
+	// sessionparticipant.SessionIDValidator is a validator for the "session_id" field. It is called by the builders before save.
+	sessionparticipant.SessionIDValidator = sessionparticipantDescSessionID.Validators[0].(func(string) error)
+	// sessionparticipantDescCreatedAt is the schema descriptor for created_at field.
+	sessionparticipantDescCreatedAt := sessionparticipantFields[4].Descriptor()
+	// sessionparticipant.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sessionparticipant.DefaultCreatedAt = sessionparticipantDescCreatedAt.Default.(func() time.Time)
+	// sessionparticipantDescID is the schema descriptor for id field.
+	sessionparticipantDescID := sessionparticipantFields[0].Descriptor()
+	// sessionparticipant.DefaultID holds the default value on creation for the id field.
+	sessionparticipant.DefaultID = sessionparticipantDescID.Default.(func() uuid.UUID)
+	sessionsnapshotFields := schema.SessionSnapshot{}.Fields()
+	_ = sessionsnapshotFields
+	// sessionsnapshotDescSlug is the schema descriptor for slug field.
+	sessionsnapshotDescSlug := sessionsnapshotFields[1].Descriptor()
+	// sessionsnapshot.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	sessionsnapshot.SlugValidator = sessionsnapshotDescSlug.Validators[0].(func(string) error)
+	// sessionsnapshotDescSessionID is the schema descriptor for session_id field.
+	sessionsnapshotDescSessionID := sessionsnapshotFields[2].Descriptor()
+	// sessionsnapshot.SessionIDValidator is a validator for the "session_id" field. It is called by the builders before save.
+	sessionsnapshot.SessionIDValidator = sessionsnapshotDescSessionID.Validators[0].(func(string) error)
+	// sessionsnapshotDescMessageCount is the schema descriptor for message_count field.
+	sessionsnapshotDescMessageCount := sessionsnapshotFields[6].Descriptor()
+	// sessionsnapshot.DefaultMessageCount holds the default value on creation for the message_count field.
+	sessionsnapshot.DefaultMessageCount = sessionsnapshotDescMessageCount.Default.(int)
+	// sessionsnapshotDescCreatedAt is the schema descriptor for created_at field.
+	sessionsnapshotDescCreatedAt := sessionsnapshotFields[9].Descriptor()
+	// sessionsnapshot.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sessionsnapshot.DefaultCreatedAt = sessionsnapshotDescCreatedAt.Default.(func() time.Time)
+	// sessionsnapshotDescID is the schema descriptor for id field.
+	sessionsnapshotDescID := sessionsnapshotFields[0].Descriptor()
+	// sessionsnapshot.DefaultID holds the default value on creation for the id field.
+	sessionsnapshot.DefaultID = sessionsnapshotDescID.Default.(func() uuid.UUID)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescEmail is the schema descriptor for email field.
@@ -128,12 +315,28 @@ func init() {
 	userDescProvider := userFields[6].Descriptor()
 	// user.DefaultProvider holds the default value on creation for the provider field.
 	user.DefaultProvider = userDescProvider.Default.(string)
+	// userDescRole is the schema descriptor for role field.
+	userDescRole := userFields[7].Descriptor()
+	// user.DefaultRole holds the default value on creation for the role field.
+	user.DefaultRole = userDescRole.Default.(string)
+	// userDescPlan is the schema descriptor for plan field.
+	userDescPlan := userFields[8].Descriptor()
+	// user.DefaultPlan holds the default value on creation for the plan field.
+	user.DefaultPlan = userDescPlan.Default.(string)
+	// userDescEmailVerified is the schema descriptor for email_verified field.
+	userDescEmailVerified := userFields[9].Descriptor()
+	// user.DefaultEmailVerified holds the default value on creation for the email_verified field.
+	user.DefaultEmailVerified = userDescEmailVerified.Default.(bool)
+	// userDescTotpEnabled is the schema descriptor for totp_enabled field.
+	userDescTotpEnabled := userFields[10].Descriptor()
+	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
+	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[7].Descriptor()
+	userDescCreatedAt := userFields[13].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[8].Descriptor()
+	userDescUpdatedAt := userFields[14].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -142,6 +345,24 @@ func init() {
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
 	user.DefaultID = userDescID.Default.(func() uuid.UUID)
+	useridentityFields := schema.UserIdentity{}.Fields()
+	_ = useridentityFields
+	// useridentityDescProvider is the schema descriptor for provider field.
+	useridentityDescProvider := useridentityFields[2].Descriptor()
+	// useridentity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	useridentity.ProviderValidator = useridentityDescProvider.Validators[0].(func(string) error)
+	// useridentityDescSubject is the schema descriptor for subject field.
+	useridentityDescSubject := useridentityFields[3].Descriptor()
+	// useridentity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	useridentity.SubjectValidator = useridentityDescSubject.Validators[0].(func(string) error)
+	// useridentityDescCreatedAt is the schema descriptor for created_at field.
+	useridentityDescCreatedAt := useridentityFields[5].Descriptor()
+	// useridentity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	useridentity.DefaultCreatedAt = useridentityDescCreatedAt.Default.(func() time.Time)
+	// useridentityDescID is the schema descriptor for id field.
+	useridentityDescID := useridentityFields[0].Descriptor()
+	// useridentity.DefaultID holds the default value on creation for the id field.
+	useridentity.DefaultID = useridentityDescID.Default.(func() uuid.UUID)
 	userpreferenceFields := schema.UserPreference{}.Fields()
 	_ = userpreferenceFields
 	// userpreferenceDescCreatedAt is the schema descriptor for created_at field.
@@ -158,4 +379,44 @@ func init() {
 	userpreferenceDescID := userpreferenceFields[0].Descriptor()
 	// userpreference.DefaultID holds the default value on creation for the id field.
 	userpreference.DefaultID = userpreferenceDescID.Default.(func() uuid.UUID)
+	watchFields := schema.Watch{}.Fields()
+	_ = watchFields
+	// watchDescPageToken is the schema descriptor for page_token field.
+	watchDescPageToken := watchFields[2].Descriptor()
+	// watch.PageTokenValidator is a validator for the "page_token" field. It is called by the builders before save.
+	watch.PageTokenValidator = watchDescPageToken.Validators[0].(func(string) error)
+	// watchDescProductName is the schema descriptor for product_name field.
+	watchDescProductName := watchFields[3].Descriptor()
+	// watch.ProductNameValidator is a validator for the "product_name" field. It is called by the builders before save.
+	watch.ProductNameValidator = watchDescProductName.Validators[0].(func(string) error)
+	// watchDescTargetPrice is the schema descriptor for target_price field.
+	watchDescTargetPrice := watchFields[4].Descriptor()
+	// watch.TargetPriceValidator is a validator for the "target_price" field. It is called by the builders before save.
+	watch.TargetPriceValidator = watchDescTargetPrice.Validators[0].(func(float64) error)
+	// watchDescCurrency is the schema descriptor for currency field.
+	watchDescCurrency := watchFields[5].Descriptor()
+	// watch.DefaultCurrency holds the default value on creation for the currency field.
+	watch.DefaultCurrency = watchDescCurrency.Default.(string)
+	// watchDescCountryCode is the schema descriptor for country_code field.
+	watchDescCountryCode := watchFields[6].Descriptor()
+	// watch.DefaultCountryCode holds the default value on creation for the country_code field.
+	watch.DefaultCountryCode = watchDescCountryCode.Default.(string)
+	// watchDescActive is the schema descriptor for active field.
+	watchDescActive := watchFields[11].Descriptor()
+	// watch.DefaultActive holds the default value on creation for the active field.
+	watch.DefaultActive = watchDescActive.Default.(bool)
+	// watchDescCreatedAt is the schema descriptor for created_at field.
+	watchDescCreatedAt := watchFields[14].Descriptor()
+	// watch.DefaultCreatedAt holds the default value on creation for the created_at field.
+	watch.DefaultCreatedAt = watchDescCreatedAt.Default.(func() time.Time)
+	// watchDescUpdatedAt is the schema descriptor for updated_at field.
+	watchDescUpdatedAt := watchFields[15].Descriptor()
+	// watch.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	watch.DefaultUpdatedAt = watchDescUpdatedAt.Default.(func() time.Time)
+	// watch.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	watch.UpdateDefaultUpdatedAt = watchDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// watchDescID is the schema descriptor for id field.
+	watchDescID := watchFields[0].Descriptor()
+	// watch.DefaultID holds the default value on creation for the id field.
+	watch.DefaultID = watchDescID.Default.(func() uuid.UUID)
 }
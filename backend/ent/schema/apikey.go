@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// APIKey holds the schema definition for the APIKey entity.
+// Personal API keys let users script against the API without refreshing
+// JWTs. Only the SHA-256 hash of a key is stored.
+type APIKey struct {
+	ent.Schema
+}
+
+// Fields of the APIKey.
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		field.String("name").
+			NotEmpty(),
+		field.String("prefix").
+			NotEmpty().
+			Immutable(), // First characters of the key, to recognise it in lists
+		field.String("key_hash").
+			NotEmpty().
+			Unique().
+			Immutable().
+			Sensitive(),
+		field.Strings("scopes").
+			Immutable(), // "chat", "search-history", "preferences"
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Edges of the APIKey.
+func (APIKey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("api_keys").
+			Field("user_id").
+			Required().
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the APIKey.
+func (APIKey) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for listing the keys of a user
+		index.Fields("user_id", "created_at"),
+	}
+}
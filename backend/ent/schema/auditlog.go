@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+// Entries record security and privacy relevant actions. They have no edges
+// so they outlive the users they mention.
+type AuditLog struct {
+	ent.Schema
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("action").
+			NotEmpty().
+			Immutable(), // e.g. "account_deleted", "account_exported"
+		field.UUID("actor_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Immutable(), // Who did it, nil for the system
+		field.UUID("subject_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Immutable(), // User the action was about
+		field.String("ip_address").
+			Optional().
+			Immutable(),
+		field.JSON("details", map[string]interface{}{}).
+			Optional().
+			Immutable().
+			SchemaType(map[string]string{
+				dialect.Postgres: "jsonb",
+			}),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for the admin audit log filtered by action
+		index.Fields("action", "created_at"),
+		// Index for the history of one user
+		index.Fields("subject_id", "created_at"),
+	}
+}
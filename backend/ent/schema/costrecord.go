@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// CostRecord holds the schema definition for the CostRecord entity.
+// One row is one paid LLM or SerpAPI call with the request, session and
+// user it was made for. No edges, so totals survive deleted sessions.
+// Erasing an account clears the user and session of its rows.
+type CostRecord struct {
+	ent.Schema
+}
+
+// Fields of the CostRecord.
+func (CostRecord) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("request_id").
+			Optional().
+			Immutable(),
+		field.String("session_id").
+			Optional(),
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.String("provider").
+			NotEmpty().
+			Immutable(), // gemini, openai or serpapi
+		field.String("model").
+			NotEmpty().
+			Immutable(),
+		field.String("purpose").
+			NotEmpty().
+			Immutable(), // dialogue, fallback, extraction, translation, embedding, comparison, search, product_details
+		field.Int("input_tokens").
+			Default(0).
+			Immutable(),
+		field.Int("output_tokens").
+			Default(0).
+			Immutable(),
+		field.Bool("grounded").
+			Default(false).
+			Immutable(),
+		field.Float("cost_usd").
+			Default(0).
+			Immutable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Indexes of the CostRecord.
+func (CostRecord) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for the cost of one user over time
+		index.Fields("user_id", "created_at"),
+		// Index for the cost of one session
+		index.Fields("session_id"),
+		// Index for the calls of one request
+		index.Fields("request_id"),
+		// Index for summaries over a time range and retention cleanup
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// JobRun holds the schema definition for the JobRun entity.
+// One row is one run of a scheduled job, started by its schedule or by an
+// admin.
+type JobRun struct {
+	ent.Schema
+}
+
+// Fields of the JobRun.
+func (JobRun) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("job").
+			NotEmpty().
+			Immutable(),
+		field.String("trigger").
+			NotEmpty().
+			Immutable(), // schedule or manual
+		field.String("triggered_by").
+			Optional().
+			Immutable(), // Admin who started a manual run
+		field.String("instance").
+			NotEmpty().
+			Immutable(), // Host that ran the job
+		field.String("status").
+			Default("running"), // running, succeeded, failed, abandoned
+		field.Int64("rows_affected").
+			Default(0),
+		field.String("error").
+			Optional(),
+		field.Int64("duration_ms").
+			Default(0),
+		field.Time("started_at").
+			Immutable().
+			Default(time.Now),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the JobRun.
+func (JobRun) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for the history of one job and its last scheduled run
+		index.Fields("job", "started_at"),
+		// Index for retention cleanup
+		index.Fields("started_at"),
+	}
+}
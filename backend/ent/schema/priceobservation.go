@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PriceObservation holds the schema definition for the PriceObservation entity.
+// One row is one price seen for a product at one merchant, taken from
+// search results or product details we already fetched.
+type PriceObservation struct {
+	ent.Schema
+}
+
+// Fields of the PriceObservation.
+func (PriceObservation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("product_key").
+			NotEmpty(), // Normalized product name
+		field.String("product_name").
+			NotEmpty(),
+		field.String("merchant").
+			Optional(),
+		field.Float("price").
+			Positive(),
+		field.String("currency").
+			NotEmpty(),
+		field.String("country_code").
+			Default("US"),
+		field.String("source").
+			Default("search"), // search or product_details
+		field.Time("observed_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Edges of the PriceObservation.
+func (PriceObservation) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the PriceObservation.
+func (PriceObservation) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for PriceHistoryService.GetPriceHistory - filtering by product and currency, ordering by date
+		index.Fields("product_key", "currency", "observed_at"),
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SessionParticipant holds the schema definition for the SessionParticipant entity.
+// A participant is a user who accepted an invite to another user's chat
+// session. The owner is the session's user_id and has no row here.
+type SessionParticipant struct {
+	ent.Schema
+}
+
+// Fields of the SessionParticipant.
+func (SessionParticipant) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("session_id").
+			NotEmpty().
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		field.UUID("invited_by", uuid.UUID{}).
+			Immutable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Edges of the SessionParticipant.
+func (SessionParticipant) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("shared_sessions").
+			Field("user_id").
+			Required().
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the SessionParticipant.
+func (SessionParticipant) Indexes() []ent.Index {
+	return []ent.Index{
+		// One grant per user and session; also the access check lookup
+		index.Fields("session_id", "user_id").
+			Unique(),
+		// Index for listing the sessions shared with a user
+		index.Fields("user_id"),
+	}
+}
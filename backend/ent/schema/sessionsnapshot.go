@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SessionSnapshot holds the schema definition for the SessionSnapshot entity.
+// A snapshot is a frozen, read-only copy of a chat session's messages that
+// anyone with the slug can open. It is independent of the session, so it
+// outlives session cleanup.
+type SessionSnapshot struct {
+	ent.Schema
+}
+
+// Fields of the SessionSnapshot.
+func (SessionSnapshot) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("slug").
+			NotEmpty().
+			Unique().
+			Immutable(),
+		field.String("session_id").
+			NotEmpty().
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(), // Owner who shared it
+		field.String("title").
+			Optional().
+			Immutable(),
+		field.JSON("messages", json.RawMessage{}).
+			Immutable().
+			SchemaType(map[string]string{
+				dialect.Postgres: "jsonb",
+			}),
+		field.Int("message_count").
+			Default(0).
+			Immutable(),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Edges of the SessionSnapshot.
+func (SessionSnapshot) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("snapshots").
+			Field("user_id").
+			Required().
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the SessionSnapshot.
+func (SessionSnapshot) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for listing the snapshots of a session
+		index.Fields("user_id", "session_id"),
+	}
+}
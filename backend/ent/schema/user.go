@@ -35,7 +35,21 @@ func (User) Fields() []ent.Field {
 		field.String("avatar_url").
 			Optional(),
 		field.String("provider").
-			Default("email"), // "email" or "google"
+			Default("email"), // "email" or the provider the account was created with
+		field.String("role").
+			Default("user"), // "user" or "admin"
+		field.String("plan").
+			Default("free"), // Usage plan: "free", "pro" or "internal"
+		field.Bool("email_verified").
+			Default(false), // Google accounts are verified by Google
+		field.Bool("totp_enabled").
+			Default(false),
+		field.String("totp_secret").
+			Optional().
+			Sensitive(), // Base32 sealed with TOTP_ENCRYPTION_KEY, set once enrolment is confirmed
+		field.Strings("totp_recovery_codes").
+			Optional().
+			Sensitive(), // SHA-256 hashes of the unused recovery codes
 		field.Time("created_at").
 			Immutable().
 			Default(func() time.Time { return time.Now() }),
@@ -54,6 +68,11 @@ func (User) Edges() []ent.Edge {
 		edge.To("search_history", SearchHistory.Type),
 		edge.To("preferences", UserPreference.Type).
 			Unique(), // One-to-one relationship
+		edge.To("watches", Watch.Type),
+		edge.To("shared_sessions", SessionParticipant.Type),
+		edge.To("snapshots", SessionSnapshot.Type),
+		edge.To("api_keys", APIKey.Type),
+		edge.To("identities", UserIdentity.Type),
 	}
 }
 
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserIdentity holds the schema definition for the UserIdentity entity.
+// An identity is an account at an external provider (Google, Apple, a
+// Keycloak realm, ...) linked to a user, who can log in with any of them.
+type UserIdentity struct {
+	ent.Schema
+}
+
+// Fields of the UserIdentity.
+func (UserIdentity) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		field.String("provider").
+			NotEmpty().
+			Immutable(), // "google" or the name of a configured OIDC provider
+		field.String("subject").
+			NotEmpty().
+			Immutable(), // The provider's "sub" claim
+		field.String("email").
+			Optional(), // Email at the provider, for display only
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the UserIdentity.
+func (UserIdentity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("identities").
+			Field("user_id").
+			Required().
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the UserIdentity.
+func (UserIdentity) Indexes() []ent.Index {
+	return []ent.Index{
+		// An identity belongs to one user
+		index.Fields("provider", "subject").
+			Unique(),
+		// One account per provider and user
+		index.Fields("user_id", "provider").
+			Unique(),
+	}
+}
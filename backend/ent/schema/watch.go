@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Watch holds the schema definition for the Watch entity.
+// A watch tracks a product (by SerpAPI page token) until its best offer
+// drops to the user's target price.
+type Watch struct {
+	ent.Schema
+}
+
+// Fields of the Watch.
+func (Watch) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("page_token").
+			NotEmpty(),
+		field.String("product_name").
+			NotEmpty(),
+		field.Float("target_price").
+			Positive(),
+		field.String("currency").
+			Default("CHF"),
+		field.String("country_code").
+			Default("CH"),
+		field.Float("last_price").
+			Optional().
+			Nillable(),
+		field.String("last_merchant").
+			Optional(),
+		field.String("last_link").
+			Optional(),
+		field.Float("notified_price").
+			Optional().
+			Nillable(), // Price of the last alert, to avoid repeating it
+		field.Bool("active").
+			Default(true),
+		field.Time("last_checked_at").
+			Optional().
+			Nillable(),
+		field.Time("notified_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Watch.
+func (Watch) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("watches").
+			Field("user_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the Watch.
+func (Watch) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index for listing a user's watches
+		index.Fields("user_id", "created_at"),
+		// One watch per user and product
+		index.Fields("user_id", "page_token").
+			Unique(),
+		// Index for the price watch job - picking active watches due for a re-check
+		index.Fields("active", "last_checked_at"),
+	}
+}
@@ -0,0 +1,169 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/ent/user"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// SessionParticipant is the model entity for the SessionParticipant schema.
+type SessionParticipant struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// SessionID holds the value of the "session_id" field.
+	SessionID string `json:"session_id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// InvitedBy holds the value of the "invited_by" field.
+	InvitedBy uuid.UUID `json:"invited_by,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the SessionParticipantQuery when eager-loading is set.
+	Edges        SessionParticipantEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// SessionParticipantEdges holds the relations/edges for other nodes in the graph.
+type SessionParticipantEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e SessionParticipantEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SessionParticipant) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case sessionparticipant.FieldSessionID:
+			values[i] = new(sql.NullString)
+		case sessionparticipant.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case sessionparticipant.FieldID, sessionparticipant.FieldUserID, sessionparticipant.FieldInvitedBy:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SessionParticipant fields.
+func (_m *SessionParticipant) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case sessionparticipant.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case sessionparticipant.FieldSessionID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field session_id", values[i])
+			} else if value.Valid {
+				_m.SessionID = value.String
+			}
+		case sessionparticipant.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				_m.UserID = *value
+			}
+		case sessionparticipant.FieldInvitedBy:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field invited_by", values[i])
+			} else if value != nil {
+				_m.InvitedBy = *value
+			}
+		case sessionparticipant.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SessionParticipant.
+// This includes values selected through modifiers, order, etc.
+func (_m *SessionParticipant) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the SessionParticipant entity.
+func (_m *SessionParticipant) QueryUser() *UserQuery {
+	return NewSessionParticipantClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this SessionParticipant.
+// Note that you need to call SessionParticipant.Unwrap() before calling this method if this SessionParticipant
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *SessionParticipant) Update() *SessionParticipantUpdateOne {
+	return NewSessionParticipantClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the SessionParticipant entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *SessionParticipant) Unwrap() *SessionParticipant {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SessionParticipant is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *SessionParticipant) String() string {
+	var builder strings.Builder
+	builder.WriteString("SessionParticipant(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("session_id=")
+	builder.WriteString(_m.SessionID)
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("invited_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InvitedBy))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SessionParticipants is a parsable slice of SessionParticipant.
+type SessionParticipants []*SessionParticipant
@@ -0,0 +1,390 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/sessionsnapshot"
+	"mylittleprice/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// SessionSnapshotCreate is the builder for creating a SessionSnapshot entity.
+type SessionSnapshotCreate struct {
+	config
+	mutation *SessionSnapshotMutation
+	hooks    []Hook
+}
+
+// SetSlug sets the "slug" field.
+func (_c *SessionSnapshotCreate) SetSlug(v string) *SessionSnapshotCreate {
+	_c.mutation.SetSlug(v)
+	return _c
+}
+
+// SetSessionID sets the "session_id" field.
+func (_c *SessionSnapshotCreate) SetSessionID(v string) *SessionSnapshotCreate {
+	_c.mutation.SetSessionID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *SessionSnapshotCreate) SetUserID(v uuid.UUID) *SessionSnapshotCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetTitle sets the "title" field.
+func (_c *SessionSnapshotCreate) SetTitle(v string) *SessionSnapshotCreate {
+	_c.mutation.SetTitle(v)
+	return _c
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableTitle(v *string) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetTitle(*v)
+	}
+	return _c
+}
+
+// SetMessages sets the "messages" field.
+func (_c *SessionSnapshotCreate) SetMessages(v jsontext.Value) *SessionSnapshotCreate {
+	_c.mutation.SetMessages(v)
+	return _c
+}
+
+// SetMessageCount sets the "message_count" field.
+func (_c *SessionSnapshotCreate) SetMessageCount(v int) *SessionSnapshotCreate {
+	_c.mutation.SetMessageCount(v)
+	return _c
+}
+
+// SetNillableMessageCount sets the "message_count" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableMessageCount(v *int) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetMessageCount(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *SessionSnapshotCreate) SetExpiresAt(v time.Time) *SessionSnapshotCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableExpiresAt(v *time.Time) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_c *SessionSnapshotCreate) SetRevokedAt(v time.Time) *SessionSnapshotCreate {
+	_c.mutation.SetRevokedAt(v)
+	return _c
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableRevokedAt(v *time.Time) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetRevokedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SessionSnapshotCreate) SetCreatedAt(v time.Time) *SessionSnapshotCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableCreatedAt(v *time.Time) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *SessionSnapshotCreate) SetID(v uuid.UUID) *SessionSnapshotCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *SessionSnapshotCreate) SetNillableID(v *uuid.UUID) *SessionSnapshotCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *SessionSnapshotCreate) SetUser(v *User) *SessionSnapshotCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the SessionSnapshotMutation object of the builder.
+func (_c *SessionSnapshotCreate) Mutation() *SessionSnapshotMutation {
+	return _c.mutation
+}
+
+// Save creates the SessionSnapshot in the database.
+func (_c *SessionSnapshotCreate) Save(ctx context.Context) (*SessionSnapshot, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SessionSnapshotCreate) SaveX(ctx context.Context) *SessionSnapshot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionSnapshotCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionSnapshotCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SessionSnapshotCreate) defaults() {
+	if _, ok := _c.mutation.MessageCount(); !ok {
+		v := sessionsnapshot.DefaultMessageCount
+		_c.mutation.SetMessageCount(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := sessionsnapshot.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := sessionsnapshot.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SessionSnapshotCreate) check() error {
+	if _, ok := _c.mutation.Slug(); !ok {
+		return &ValidationError{Name: "slug", err: errors.New(`ent: missing required field "SessionSnapshot.slug"`)}
+	}
+	if v, ok := _c.mutation.Slug(); ok {
+		if err := sessionsnapshot.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "SessionSnapshot.slug": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.SessionID(); !ok {
+		return &ValidationError{Name: "session_id", err: errors.New(`ent: missing required field "SessionSnapshot.session_id"`)}
+	}
+	if v, ok := _c.mutation.SessionID(); ok {
+		if err := sessionsnapshot.SessionIDValidator(v); err != nil {
+			return &ValidationError{Name: "session_id", err: fmt.Errorf(`ent: validator failed for field "SessionSnapshot.session_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "SessionSnapshot.user_id"`)}
+	}
+	if _, ok := _c.mutation.Messages(); !ok {
+		return &ValidationError{Name: "messages", err: errors.New(`ent: missing required field "SessionSnapshot.messages"`)}
+	}
+	if _, ok := _c.mutation.MessageCount(); !ok {
+		return &ValidationError{Name: "message_count", err: errors.New(`ent: missing required field "SessionSnapshot.message_count"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SessionSnapshot.created_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "SessionSnapshot.user"`)}
+	}
+	return nil
+}
+
+func (_c *SessionSnapshotCreate) sqlSave(ctx context.Context) (*SessionSnapshot, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SessionSnapshotCreate) createSpec() (*SessionSnapshot, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SessionSnapshot{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(sessionsnapshot.Table, sqlgraph.NewFieldSpec(sessionsnapshot.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Slug(); ok {
+		_spec.SetField(sessionsnapshot.FieldSlug, field.TypeString, value)
+		_node.Slug = value
+	}
+	if value, ok := _c.mutation.SessionID(); ok {
+		_spec.SetField(sessionsnapshot.FieldSessionID, field.TypeString, value)
+		_node.SessionID = value
+	}
+	if value, ok := _c.mutation.Title(); ok {
+		_spec.SetField(sessionsnapshot.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := _c.mutation.Messages(); ok {
+		_spec.SetField(sessionsnapshot.FieldMessages, field.TypeJSON, value)
+		_node.Messages = value
+	}
+	if value, ok := _c.mutation.MessageCount(); ok {
+		_spec.SetField(sessionsnapshot.FieldMessageCount, field.TypeInt, value)
+		_node.MessageCount = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(sessionsnapshot.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := _c.mutation.RevokedAt(); ok {
+		_spec.SetField(sessionsnapshot.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(sessionsnapshot.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   sessionsnapshot.UserTable,
+			Columns: []string{sessionsnapshot.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// SessionSnapshotCreateBulk is the builder for creating many SessionSnapshot entities in bulk.
+type SessionSnapshotCreateBulk struct {
+	config
+	err      error
+	builders []*SessionSnapshotCreate
+}
+
+// Save creates the SessionSnapshot entities in the database.
+func (_c *SessionSnapshotCreateBulk) Save(ctx context.Context) ([]*SessionSnapshot, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*SessionSnapshot, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SessionSnapshotMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SessionSnapshotCreateBulk) SaveX(ctx context.Context) []*SessionSnapshot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionSnapshotCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionSnapshotCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
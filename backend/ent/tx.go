@@ -12,16 +12,34 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// APIKey is the client for interacting with the APIKey builders.
+	APIKey *APIKeyClient
+	// AuditLog is the client for interacting with the AuditLog builders.
+	AuditLog *AuditLogClient
 	// ChatSession is the client for interacting with the ChatSession builders.
 	ChatSession *ChatSessionClient
+	// CostRecord is the client for interacting with the CostRecord builders.
+	CostRecord *CostRecordClient
+	// JobRun is the client for interacting with the JobRun builders.
+	JobRun *JobRunClient
 	// Message is the client for interacting with the Message builders.
 	Message *MessageClient
+	// PriceObservation is the client for interacting with the PriceObservation builders.
+	PriceObservation *PriceObservationClient
 	// SearchHistory is the client for interacting with the SearchHistory builders.
 	SearchHistory *SearchHistoryClient
+	// SessionParticipant is the client for interacting with the SessionParticipant builders.
+	SessionParticipant *SessionParticipantClient
+	// SessionSnapshot is the client for interacting with the SessionSnapshot builders.
+	SessionSnapshot *SessionSnapshotClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// UserIdentity is the client for interacting with the UserIdentity builders.
+	UserIdentity *UserIdentityClient
 	// UserPreference is the client for interacting with the UserPreference builders.
 	UserPreference *UserPreferenceClient
+	// Watch is the client for interacting with the Watch builders.
+	Watch *WatchClient
 
 	// lazily loaded.
 	client     *Client
@@ -153,11 +171,20 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.APIKey = NewAPIKeyClient(tx.config)
+	tx.AuditLog = NewAuditLogClient(tx.config)
 	tx.ChatSession = NewChatSessionClient(tx.config)
+	tx.CostRecord = NewCostRecordClient(tx.config)
+	tx.JobRun = NewJobRunClient(tx.config)
 	tx.Message = NewMessageClient(tx.config)
+	tx.PriceObservation = NewPriceObservationClient(tx.config)
 	tx.SearchHistory = NewSearchHistoryClient(tx.config)
+	tx.SessionParticipant = NewSessionParticipantClient(tx.config)
+	tx.SessionSnapshot = NewSessionSnapshotClient(tx.config)
 	tx.User = NewUserClient(tx.config)
+	tx.UserIdentity = NewUserIdentityClient(tx.config)
 	tx.UserPreference = NewUserPreferenceClient(tx.config)
+	tx.Watch = NewWatchClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -167,7 +194,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: ChatSession.QueryXXX(), the query will be executed
+// applies a query, for example: APIKey.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
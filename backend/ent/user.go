@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"mylittleprice/ent/user"
 	"mylittleprice/ent/userpreference"
@@ -31,6 +32,18 @@ type User struct {
 	AvatarURL string `json:"avatar_url,omitempty"`
 	// Provider holds the value of the "provider" field.
 	Provider string `json:"provider,omitempty"`
+	// Role holds the value of the "role" field.
+	Role string `json:"role,omitempty"`
+	// Plan holds the value of the "plan" field.
+	Plan string `json:"plan,omitempty"`
+	// EmailVerified holds the value of the "email_verified" field.
+	EmailVerified bool `json:"email_verified,omitempty"`
+	// TotpEnabled holds the value of the "totp_enabled" field.
+	TotpEnabled bool `json:"totp_enabled,omitempty"`
+	// TotpSecret holds the value of the "totp_secret" field.
+	TotpSecret string `json:"-"`
+	// TotpRecoveryCodes holds the value of the "totp_recovery_codes" field.
+	TotpRecoveryCodes []string `json:"-"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -51,9 +64,19 @@ type UserEdges struct {
 	SearchHistory []*SearchHistory `json:"search_history,omitempty"`
 	// Preferences holds the value of the preferences edge.
 	Preferences *UserPreference `json:"preferences,omitempty"`
+	// Watches holds the value of the watches edge.
+	Watches []*Watch `json:"watches,omitempty"`
+	// SharedSessions holds the value of the shared_sessions edge.
+	SharedSessions []*SessionParticipant `json:"shared_sessions,omitempty"`
+	// Snapshots holds the value of the snapshots edge.
+	Snapshots []*SessionSnapshot `json:"snapshots,omitempty"`
+	// APIKeys holds the value of the api_keys edge.
+	APIKeys []*APIKey `json:"api_keys,omitempty"`
+	// Identities holds the value of the identities edge.
+	Identities []*UserIdentity `json:"identities,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [3]bool
+	loadedTypes [8]bool
 }
 
 // SessionsOrErr returns the Sessions value or an error if the edge
@@ -85,12 +108,61 @@ func (e UserEdges) PreferencesOrErr() (*UserPreference, error) {
 	return nil, &NotLoadedError{edge: "preferences"}
 }
 
+// WatchesOrErr returns the Watches value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) WatchesOrErr() ([]*Watch, error) {
+	if e.loadedTypes[3] {
+		return e.Watches, nil
+	}
+	return nil, &NotLoadedError{edge: "watches"}
+}
+
+// SharedSessionsOrErr returns the SharedSessions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SharedSessionsOrErr() ([]*SessionParticipant, error) {
+	if e.loadedTypes[4] {
+		return e.SharedSessions, nil
+	}
+	return nil, &NotLoadedError{edge: "shared_sessions"}
+}
+
+// SnapshotsOrErr returns the Snapshots value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SnapshotsOrErr() ([]*SessionSnapshot, error) {
+	if e.loadedTypes[5] {
+		return e.Snapshots, nil
+	}
+	return nil, &NotLoadedError{edge: "snapshots"}
+}
+
+// APIKeysOrErr returns the APIKeys value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) APIKeysOrErr() ([]*APIKey, error) {
+	if e.loadedTypes[6] {
+		return e.APIKeys, nil
+	}
+	return nil, &NotLoadedError{edge: "api_keys"}
+}
+
+// IdentitiesOrErr returns the Identities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) IdentitiesOrErr() ([]*UserIdentity, error) {
+	if e.loadedTypes[7] {
+		return e.Identities, nil
+	}
+	return nil, &NotLoadedError{edge: "identities"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldEmail, user.FieldPasswordHash, user.FieldGoogleID, user.FieldName, user.FieldAvatarURL, user.FieldProvider:
+		case user.FieldTotpRecoveryCodes:
+			values[i] = new([]byte)
+		case user.FieldEmailVerified, user.FieldTotpEnabled:
+			values[i] = new(sql.NullBool)
+		case user.FieldEmail, user.FieldPasswordHash, user.FieldGoogleID, user.FieldName, user.FieldAvatarURL, user.FieldProvider, user.FieldRole, user.FieldPlan, user.FieldTotpSecret:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt, user.FieldLastLogin:
 			values[i] = new(sql.NullTime)
@@ -153,6 +225,44 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Provider = value.String
 			}
+		case user.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				_m.Role = value.String
+			}
+		case user.FieldPlan:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field plan", values[i])
+			} else if value.Valid {
+				_m.Plan = value.String
+			}
+		case user.FieldEmailVerified:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field email_verified", values[i])
+			} else if value.Valid {
+				_m.EmailVerified = value.Bool
+			}
+		case user.FieldTotpEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_enabled", values[i])
+			} else if value.Valid {
+				_m.TotpEnabled = value.Bool
+			}
+		case user.FieldTotpSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_secret", values[i])
+			} else if value.Valid {
+				_m.TotpSecret = value.String
+			}
+		case user.FieldTotpRecoveryCodes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_recovery_codes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.TotpRecoveryCodes); err != nil {
+					return fmt.Errorf("unmarshal field totp_recovery_codes: %w", err)
+				}
+			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -199,6 +309,31 @@ func (_m *User) QueryPreferences() *UserPreferenceQuery {
 	return NewUserClient(_m.config).QueryPreferences(_m)
 }
 
+// QueryWatches queries the "watches" edge of the User entity.
+func (_m *User) QueryWatches() *WatchQuery {
+	return NewUserClient(_m.config).QueryWatches(_m)
+}
+
+// QuerySharedSessions queries the "shared_sessions" edge of the User entity.
+func (_m *User) QuerySharedSessions() *SessionParticipantQuery {
+	return NewUserClient(_m.config).QuerySharedSessions(_m)
+}
+
+// QuerySnapshots queries the "snapshots" edge of the User entity.
+func (_m *User) QuerySnapshots() *SessionSnapshotQuery {
+	return NewUserClient(_m.config).QuerySnapshots(_m)
+}
+
+// QueryAPIKeys queries the "api_keys" edge of the User entity.
+func (_m *User) QueryAPIKeys() *APIKeyQuery {
+	return NewUserClient(_m.config).QueryAPIKeys(_m)
+}
+
+// QueryIdentities queries the "identities" edge of the User entity.
+func (_m *User) QueryIdentities() *UserIdentityQuery {
+	return NewUserClient(_m.config).QueryIdentities(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -239,6 +374,22 @@ func (_m *User) String() string {
 	builder.WriteString("provider=")
 	builder.WriteString(_m.Provider)
 	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(_m.Role)
+	builder.WriteString(", ")
+	builder.WriteString("plan=")
+	builder.WriteString(_m.Plan)
+	builder.WriteString(", ")
+	builder.WriteString("email_verified=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EmailVerified))
+	builder.WriteString(", ")
+	builder.WriteString("totp_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotpEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("totp_secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("totp_recovery_codes=<sensitive>")
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
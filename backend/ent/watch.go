@@ -0,0 +1,306 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"mylittleprice/ent/user"
+	"mylittleprice/ent/watch"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Watch is the model entity for the Watch schema.
+type Watch struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// PageToken holds the value of the "page_token" field.
+	PageToken string `json:"page_token,omitempty"`
+	// ProductName holds the value of the "product_name" field.
+	ProductName string `json:"product_name,omitempty"`
+	// TargetPrice holds the value of the "target_price" field.
+	TargetPrice float64 `json:"target_price,omitempty"`
+	// Currency holds the value of the "currency" field.
+	Currency string `json:"currency,omitempty"`
+	// CountryCode holds the value of the "country_code" field.
+	CountryCode string `json:"country_code,omitempty"`
+	// LastPrice holds the value of the "last_price" field.
+	LastPrice *float64 `json:"last_price,omitempty"`
+	// LastMerchant holds the value of the "last_merchant" field.
+	LastMerchant string `json:"last_merchant,omitempty"`
+	// LastLink holds the value of the "last_link" field.
+	LastLink string `json:"last_link,omitempty"`
+	// NotifiedPrice holds the value of the "notified_price" field.
+	NotifiedPrice *float64 `json:"notified_price,omitempty"`
+	// Active holds the value of the "active" field.
+	Active bool `json:"active,omitempty"`
+	// LastCheckedAt holds the value of the "last_checked_at" field.
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	// NotifiedAt holds the value of the "notified_at" field.
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WatchQuery when eager-loading is set.
+	Edges        WatchEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// WatchEdges holds the relations/edges for other nodes in the graph.
+type WatchEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e WatchEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Watch) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case watch.FieldActive:
+			values[i] = new(sql.NullBool)
+		case watch.FieldTargetPrice, watch.FieldLastPrice, watch.FieldNotifiedPrice:
+			values[i] = new(sql.NullFloat64)
+		case watch.FieldPageToken, watch.FieldProductName, watch.FieldCurrency, watch.FieldCountryCode, watch.FieldLastMerchant, watch.FieldLastLink:
+			values[i] = new(sql.NullString)
+		case watch.FieldLastCheckedAt, watch.FieldNotifiedAt, watch.FieldCreatedAt, watch.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case watch.FieldID, watch.FieldUserID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Watch fields.
+func (_m *Watch) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case watch.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case watch.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				_m.UserID = *value
+			}
+		case watch.FieldPageToken:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field page_token", values[i])
+			} else if value.Valid {
+				_m.PageToken = value.String
+			}
+		case watch.FieldProductName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field product_name", values[i])
+			} else if value.Valid {
+				_m.ProductName = value.String
+			}
+		case watch.FieldTargetPrice:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field target_price", values[i])
+			} else if value.Valid {
+				_m.TargetPrice = value.Float64
+			}
+		case watch.FieldCurrency:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field currency", values[i])
+			} else if value.Valid {
+				_m.Currency = value.String
+			}
+		case watch.FieldCountryCode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field country_code", values[i])
+			} else if value.Valid {
+				_m.CountryCode = value.String
+			}
+		case watch.FieldLastPrice:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_price", values[i])
+			} else if value.Valid {
+				_m.LastPrice = new(float64)
+				*_m.LastPrice = value.Float64
+			}
+		case watch.FieldLastMerchant:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_merchant", values[i])
+			} else if value.Valid {
+				_m.LastMerchant = value.String
+			}
+		case watch.FieldLastLink:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_link", values[i])
+			} else if value.Valid {
+				_m.LastLink = value.String
+			}
+		case watch.FieldNotifiedPrice:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field notified_price", values[i])
+			} else if value.Valid {
+				_m.NotifiedPrice = new(float64)
+				*_m.NotifiedPrice = value.Float64
+			}
+		case watch.FieldActive:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field active", values[i])
+			} else if value.Valid {
+				_m.Active = value.Bool
+			}
+		case watch.FieldLastCheckedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_checked_at", values[i])
+			} else if value.Valid {
+				_m.LastCheckedAt = new(time.Time)
+				*_m.LastCheckedAt = value.Time
+			}
+		case watch.FieldNotifiedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field notified_at", values[i])
+			} else if value.Valid {
+				_m.NotifiedAt = new(time.Time)
+				*_m.NotifiedAt = value.Time
+			}
+		case watch.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case watch.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Watch.
+// This includes values selected through modifiers, order, etc.
+func (_m *Watch) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the Watch entity.
+func (_m *Watch) QueryUser() *UserQuery {
+	return NewWatchClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this Watch.
+// Note that you need to call Watch.Unwrap() before calling this method if this Watch
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Watch) Update() *WatchUpdateOne {
+	return NewWatchClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Watch entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Watch) Unwrap() *Watch {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Watch is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Watch) String() string {
+	var builder strings.Builder
+	builder.WriteString("Watch(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("page_token=")
+	builder.WriteString(_m.PageToken)
+	builder.WriteString(", ")
+	builder.WriteString("product_name=")
+	builder.WriteString(_m.ProductName)
+	builder.WriteString(", ")
+	builder.WriteString("target_price=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TargetPrice))
+	builder.WriteString(", ")
+	builder.WriteString("currency=")
+	builder.WriteString(_m.Currency)
+	builder.WriteString(", ")
+	builder.WriteString("country_code=")
+	builder.WriteString(_m.CountryCode)
+	builder.WriteString(", ")
+	if v := _m.LastPrice; v != nil {
+		builder.WriteString("last_price=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("last_merchant=")
+	builder.WriteString(_m.LastMerchant)
+	builder.WriteString(", ")
+	builder.WriteString("last_link=")
+	builder.WriteString(_m.LastLink)
+	builder.WriteString(", ")
+	if v := _m.NotifiedPrice; v != nil {
+		builder.WriteString("notified_price=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("active=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Active))
+	builder.WriteString(", ")
+	if v := _m.LastCheckedAt; v != nil {
+		builder.WriteString("last_checked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.NotifiedAt; v != nil {
+		builder.WriteString("notified_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Watches is a parsable slice of Watch.
+type Watches []*Watch
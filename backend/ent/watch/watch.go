@@ -0,0 +1,209 @@
+// Code generated by ent, DO NOT EDIT.
+
+package watch
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the watch type in the database.
+	Label = "watch"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldPageToken holds the string denoting the page_token field in the database.
+	FieldPageToken = "page_token"
+	// FieldProductName holds the string denoting the product_name field in the database.
+	FieldProductName = "product_name"
+	// FieldTargetPrice holds the string denoting the target_price field in the database.
+	FieldTargetPrice = "target_price"
+	// FieldCurrency holds the string denoting the currency field in the database.
+	FieldCurrency = "currency"
+	// FieldCountryCode holds the string denoting the country_code field in the database.
+	FieldCountryCode = "country_code"
+	// FieldLastPrice holds the string denoting the last_price field in the database.
+	FieldLastPrice = "last_price"
+	// FieldLastMerchant holds the string denoting the last_merchant field in the database.
+	FieldLastMerchant = "last_merchant"
+	// FieldLastLink holds the string denoting the last_link field in the database.
+	FieldLastLink = "last_link"
+	// FieldNotifiedPrice holds the string denoting the notified_price field in the database.
+	FieldNotifiedPrice = "notified_price"
+	// FieldActive holds the string denoting the active field in the database.
+	FieldActive = "active"
+	// FieldLastCheckedAt holds the string denoting the last_checked_at field in the database.
+	FieldLastCheckedAt = "last_checked_at"
+	// FieldNotifiedAt holds the string denoting the notified_at field in the database.
+	FieldNotifiedAt = "notified_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// EdgeUser holds the string denoting the user edge name in mutations.
+	EdgeUser = "user"
+	// Table holds the table name of the watch in the database.
+	Table = "watches"
+	// UserTable is the table that holds the user relation/edge.
+	UserTable = "watches"
+	// UserInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	UserInverseTable = "users"
+	// UserColumn is the table column denoting the user relation/edge.
+	UserColumn = "user_id"
+)
+
+// Columns holds all SQL columns for watch fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldPageToken,
+	FieldProductName,
+	FieldTargetPrice,
+	FieldCurrency,
+	FieldCountryCode,
+	FieldLastPrice,
+	FieldLastMerchant,
+	FieldLastLink,
+	FieldNotifiedPrice,
+	FieldActive,
+	FieldLastCheckedAt,
+	FieldNotifiedAt,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// PageTokenValidator is a validator for the "page_token" field. It is called by the builders before save.
+	PageTokenValidator func(string) error
+	// ProductNameValidator is a validator for the "product_name" field. It is called by the builders before save.
+	ProductNameValidator func(string) error
+	// TargetPriceValidator is a validator for the "target_price" field. It is called by the builders before save.
+	TargetPriceValidator func(float64) error
+	// DefaultCurrency holds the default value on creation for the "currency" field.
+	DefaultCurrency string
+	// DefaultCountryCode holds the default value on creation for the "country_code" field.
+	DefaultCountryCode string
+	// DefaultActive holds the default value on creation for the "active" field.
+	DefaultActive bool
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the Watch queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByPageToken orders the results by the page_token field.
+func ByPageToken(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPageToken, opts...).ToFunc()
+}
+
+// ByProductName orders the results by the product_name field.
+func ByProductName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProductName, opts...).ToFunc()
+}
+
+// ByTargetPrice orders the results by the target_price field.
+func ByTargetPrice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTargetPrice, opts...).ToFunc()
+}
+
+// ByCurrency orders the results by the currency field.
+func ByCurrency(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCurrency, opts...).ToFunc()
+}
+
+// ByCountryCode orders the results by the country_code field.
+func ByCountryCode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCountryCode, opts...).ToFunc()
+}
+
+// ByLastPrice orders the results by the last_price field.
+func ByLastPrice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastPrice, opts...).ToFunc()
+}
+
+// ByLastMerchant orders the results by the last_merchant field.
+func ByLastMerchant(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastMerchant, opts...).ToFunc()
+}
+
+// ByLastLink orders the results by the last_link field.
+func ByLastLink(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastLink, opts...).ToFunc()
+}
+
+// ByNotifiedPrice orders the results by the notified_price field.
+func ByNotifiedPrice(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNotifiedPrice, opts...).ToFunc()
+}
+
+// ByActive orders the results by the active field.
+func ByActive(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldActive, opts...).ToFunc()
+}
+
+// ByLastCheckedAt orders the results by the last_checked_at field.
+func ByLastCheckedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastCheckedAt, opts...).ToFunc()
+}
+
+// ByNotifiedAt orders the results by the notified_at field.
+func ByNotifiedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNotifiedAt, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByUserField orders the results by user field.
+func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newUserStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newUserStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(UserInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+	)
+}
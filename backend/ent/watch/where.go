@@ -0,0 +1,930 @@
+// Code generated by ent, DO NOT EDIT.
+
+package watch
+
+import (
+	"mylittleprice/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldUserID, v))
+}
+
+// PageToken applies equality check predicate on the "page_token" field. It's identical to PageTokenEQ.
+func PageToken(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldPageToken, v))
+}
+
+// ProductName applies equality check predicate on the "product_name" field. It's identical to ProductNameEQ.
+func ProductName(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldProductName, v))
+}
+
+// TargetPrice applies equality check predicate on the "target_price" field. It's identical to TargetPriceEQ.
+func TargetPrice(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldTargetPrice, v))
+}
+
+// Currency applies equality check predicate on the "currency" field. It's identical to CurrencyEQ.
+func Currency(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCurrency, v))
+}
+
+// CountryCode applies equality check predicate on the "country_code" field. It's identical to CountryCodeEQ.
+func CountryCode(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCountryCode, v))
+}
+
+// LastPrice applies equality check predicate on the "last_price" field. It's identical to LastPriceEQ.
+func LastPrice(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastPrice, v))
+}
+
+// LastMerchant applies equality check predicate on the "last_merchant" field. It's identical to LastMerchantEQ.
+func LastMerchant(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastMerchant, v))
+}
+
+// LastLink applies equality check predicate on the "last_link" field. It's identical to LastLinkEQ.
+func LastLink(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastLink, v))
+}
+
+// NotifiedPrice applies equality check predicate on the "notified_price" field. It's identical to NotifiedPriceEQ.
+func NotifiedPrice(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldNotifiedPrice, v))
+}
+
+// Active applies equality check predicate on the "active" field. It's identical to ActiveEQ.
+func Active(v bool) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldActive, v))
+}
+
+// LastCheckedAt applies equality check predicate on the "last_checked_at" field. It's identical to LastCheckedAtEQ.
+func LastCheckedAt(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastCheckedAt, v))
+}
+
+// NotifiedAt applies equality check predicate on the "notified_at" field. It's identical to NotifiedAtEQ.
+func NotifiedAt(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldNotifiedAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// PageTokenEQ applies the EQ predicate on the "page_token" field.
+func PageTokenEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldPageToken, v))
+}
+
+// PageTokenNEQ applies the NEQ predicate on the "page_token" field.
+func PageTokenNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldPageToken, v))
+}
+
+// PageTokenIn applies the In predicate on the "page_token" field.
+func PageTokenIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldPageToken, vs...))
+}
+
+// PageTokenNotIn applies the NotIn predicate on the "page_token" field.
+func PageTokenNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldPageToken, vs...))
+}
+
+// PageTokenGT applies the GT predicate on the "page_token" field.
+func PageTokenGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldPageToken, v))
+}
+
+// PageTokenGTE applies the GTE predicate on the "page_token" field.
+func PageTokenGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldPageToken, v))
+}
+
+// PageTokenLT applies the LT predicate on the "page_token" field.
+func PageTokenLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldPageToken, v))
+}
+
+// PageTokenLTE applies the LTE predicate on the "page_token" field.
+func PageTokenLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldPageToken, v))
+}
+
+// PageTokenContains applies the Contains predicate on the "page_token" field.
+func PageTokenContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldPageToken, v))
+}
+
+// PageTokenHasPrefix applies the HasPrefix predicate on the "page_token" field.
+func PageTokenHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldPageToken, v))
+}
+
+// PageTokenHasSuffix applies the HasSuffix predicate on the "page_token" field.
+func PageTokenHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldPageToken, v))
+}
+
+// PageTokenEqualFold applies the EqualFold predicate on the "page_token" field.
+func PageTokenEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldPageToken, v))
+}
+
+// PageTokenContainsFold applies the ContainsFold predicate on the "page_token" field.
+func PageTokenContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldPageToken, v))
+}
+
+// ProductNameEQ applies the EQ predicate on the "product_name" field.
+func ProductNameEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldProductName, v))
+}
+
+// ProductNameNEQ applies the NEQ predicate on the "product_name" field.
+func ProductNameNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldProductName, v))
+}
+
+// ProductNameIn applies the In predicate on the "product_name" field.
+func ProductNameIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldProductName, vs...))
+}
+
+// ProductNameNotIn applies the NotIn predicate on the "product_name" field.
+func ProductNameNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldProductName, vs...))
+}
+
+// ProductNameGT applies the GT predicate on the "product_name" field.
+func ProductNameGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldProductName, v))
+}
+
+// ProductNameGTE applies the GTE predicate on the "product_name" field.
+func ProductNameGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldProductName, v))
+}
+
+// ProductNameLT applies the LT predicate on the "product_name" field.
+func ProductNameLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldProductName, v))
+}
+
+// ProductNameLTE applies the LTE predicate on the "product_name" field.
+func ProductNameLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldProductName, v))
+}
+
+// ProductNameContains applies the Contains predicate on the "product_name" field.
+func ProductNameContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldProductName, v))
+}
+
+// ProductNameHasPrefix applies the HasPrefix predicate on the "product_name" field.
+func ProductNameHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldProductName, v))
+}
+
+// ProductNameHasSuffix applies the HasSuffix predicate on the "product_name" field.
+func ProductNameHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldProductName, v))
+}
+
+// ProductNameEqualFold applies the EqualFold predicate on the "product_name" field.
+func ProductNameEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldProductName, v))
+}
+
+// ProductNameContainsFold applies the ContainsFold predicate on the "product_name" field.
+func ProductNameContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldProductName, v))
+}
+
+// TargetPriceEQ applies the EQ predicate on the "target_price" field.
+func TargetPriceEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldTargetPrice, v))
+}
+
+// TargetPriceNEQ applies the NEQ predicate on the "target_price" field.
+func TargetPriceNEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldTargetPrice, v))
+}
+
+// TargetPriceIn applies the In predicate on the "target_price" field.
+func TargetPriceIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldTargetPrice, vs...))
+}
+
+// TargetPriceNotIn applies the NotIn predicate on the "target_price" field.
+func TargetPriceNotIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldTargetPrice, vs...))
+}
+
+// TargetPriceGT applies the GT predicate on the "target_price" field.
+func TargetPriceGT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldTargetPrice, v))
+}
+
+// TargetPriceGTE applies the GTE predicate on the "target_price" field.
+func TargetPriceGTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldTargetPrice, v))
+}
+
+// TargetPriceLT applies the LT predicate on the "target_price" field.
+func TargetPriceLT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldTargetPrice, v))
+}
+
+// TargetPriceLTE applies the LTE predicate on the "target_price" field.
+func TargetPriceLTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldTargetPrice, v))
+}
+
+// CurrencyEQ applies the EQ predicate on the "currency" field.
+func CurrencyEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCurrency, v))
+}
+
+// CurrencyNEQ applies the NEQ predicate on the "currency" field.
+func CurrencyNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldCurrency, v))
+}
+
+// CurrencyIn applies the In predicate on the "currency" field.
+func CurrencyIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldCurrency, vs...))
+}
+
+// CurrencyNotIn applies the NotIn predicate on the "currency" field.
+func CurrencyNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldCurrency, vs...))
+}
+
+// CurrencyGT applies the GT predicate on the "currency" field.
+func CurrencyGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldCurrency, v))
+}
+
+// CurrencyGTE applies the GTE predicate on the "currency" field.
+func CurrencyGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldCurrency, v))
+}
+
+// CurrencyLT applies the LT predicate on the "currency" field.
+func CurrencyLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldCurrency, v))
+}
+
+// CurrencyLTE applies the LTE predicate on the "currency" field.
+func CurrencyLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldCurrency, v))
+}
+
+// CurrencyContains applies the Contains predicate on the "currency" field.
+func CurrencyContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldCurrency, v))
+}
+
+// CurrencyHasPrefix applies the HasPrefix predicate on the "currency" field.
+func CurrencyHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldCurrency, v))
+}
+
+// CurrencyHasSuffix applies the HasSuffix predicate on the "currency" field.
+func CurrencyHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldCurrency, v))
+}
+
+// CurrencyEqualFold applies the EqualFold predicate on the "currency" field.
+func CurrencyEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldCurrency, v))
+}
+
+// CurrencyContainsFold applies the ContainsFold predicate on the "currency" field.
+func CurrencyContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldCurrency, v))
+}
+
+// CountryCodeEQ applies the EQ predicate on the "country_code" field.
+func CountryCodeEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCountryCode, v))
+}
+
+// CountryCodeNEQ applies the NEQ predicate on the "country_code" field.
+func CountryCodeNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldCountryCode, v))
+}
+
+// CountryCodeIn applies the In predicate on the "country_code" field.
+func CountryCodeIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldCountryCode, vs...))
+}
+
+// CountryCodeNotIn applies the NotIn predicate on the "country_code" field.
+func CountryCodeNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldCountryCode, vs...))
+}
+
+// CountryCodeGT applies the GT predicate on the "country_code" field.
+func CountryCodeGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldCountryCode, v))
+}
+
+// CountryCodeGTE applies the GTE predicate on the "country_code" field.
+func CountryCodeGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldCountryCode, v))
+}
+
+// CountryCodeLT applies the LT predicate on the "country_code" field.
+func CountryCodeLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldCountryCode, v))
+}
+
+// CountryCodeLTE applies the LTE predicate on the "country_code" field.
+func CountryCodeLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldCountryCode, v))
+}
+
+// CountryCodeContains applies the Contains predicate on the "country_code" field.
+func CountryCodeContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldCountryCode, v))
+}
+
+// CountryCodeHasPrefix applies the HasPrefix predicate on the "country_code" field.
+func CountryCodeHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldCountryCode, v))
+}
+
+// CountryCodeHasSuffix applies the HasSuffix predicate on the "country_code" field.
+func CountryCodeHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldCountryCode, v))
+}
+
+// CountryCodeEqualFold applies the EqualFold predicate on the "country_code" field.
+func CountryCodeEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldCountryCode, v))
+}
+
+// CountryCodeContainsFold applies the ContainsFold predicate on the "country_code" field.
+func CountryCodeContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldCountryCode, v))
+}
+
+// LastPriceEQ applies the EQ predicate on the "last_price" field.
+func LastPriceEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastPrice, v))
+}
+
+// LastPriceNEQ applies the NEQ predicate on the "last_price" field.
+func LastPriceNEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldLastPrice, v))
+}
+
+// LastPriceIn applies the In predicate on the "last_price" field.
+func LastPriceIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldLastPrice, vs...))
+}
+
+// LastPriceNotIn applies the NotIn predicate on the "last_price" field.
+func LastPriceNotIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldLastPrice, vs...))
+}
+
+// LastPriceGT applies the GT predicate on the "last_price" field.
+func LastPriceGT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldLastPrice, v))
+}
+
+// LastPriceGTE applies the GTE predicate on the "last_price" field.
+func LastPriceGTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldLastPrice, v))
+}
+
+// LastPriceLT applies the LT predicate on the "last_price" field.
+func LastPriceLT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldLastPrice, v))
+}
+
+// LastPriceLTE applies the LTE predicate on the "last_price" field.
+func LastPriceLTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldLastPrice, v))
+}
+
+// LastPriceIsNil applies the IsNil predicate on the "last_price" field.
+func LastPriceIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldLastPrice))
+}
+
+// LastPriceNotNil applies the NotNil predicate on the "last_price" field.
+func LastPriceNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldLastPrice))
+}
+
+// LastMerchantEQ applies the EQ predicate on the "last_merchant" field.
+func LastMerchantEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastMerchant, v))
+}
+
+// LastMerchantNEQ applies the NEQ predicate on the "last_merchant" field.
+func LastMerchantNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldLastMerchant, v))
+}
+
+// LastMerchantIn applies the In predicate on the "last_merchant" field.
+func LastMerchantIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldLastMerchant, vs...))
+}
+
+// LastMerchantNotIn applies the NotIn predicate on the "last_merchant" field.
+func LastMerchantNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldLastMerchant, vs...))
+}
+
+// LastMerchantGT applies the GT predicate on the "last_merchant" field.
+func LastMerchantGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldLastMerchant, v))
+}
+
+// LastMerchantGTE applies the GTE predicate on the "last_merchant" field.
+func LastMerchantGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldLastMerchant, v))
+}
+
+// LastMerchantLT applies the LT predicate on the "last_merchant" field.
+func LastMerchantLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldLastMerchant, v))
+}
+
+// LastMerchantLTE applies the LTE predicate on the "last_merchant" field.
+func LastMerchantLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldLastMerchant, v))
+}
+
+// LastMerchantContains applies the Contains predicate on the "last_merchant" field.
+func LastMerchantContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldLastMerchant, v))
+}
+
+// LastMerchantHasPrefix applies the HasPrefix predicate on the "last_merchant" field.
+func LastMerchantHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldLastMerchant, v))
+}
+
+// LastMerchantHasSuffix applies the HasSuffix predicate on the "last_merchant" field.
+func LastMerchantHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldLastMerchant, v))
+}
+
+// LastMerchantIsNil applies the IsNil predicate on the "last_merchant" field.
+func LastMerchantIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldLastMerchant))
+}
+
+// LastMerchantNotNil applies the NotNil predicate on the "last_merchant" field.
+func LastMerchantNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldLastMerchant))
+}
+
+// LastMerchantEqualFold applies the EqualFold predicate on the "last_merchant" field.
+func LastMerchantEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldLastMerchant, v))
+}
+
+// LastMerchantContainsFold applies the ContainsFold predicate on the "last_merchant" field.
+func LastMerchantContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldLastMerchant, v))
+}
+
+// LastLinkEQ applies the EQ predicate on the "last_link" field.
+func LastLinkEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastLink, v))
+}
+
+// LastLinkNEQ applies the NEQ predicate on the "last_link" field.
+func LastLinkNEQ(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldLastLink, v))
+}
+
+// LastLinkIn applies the In predicate on the "last_link" field.
+func LastLinkIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldLastLink, vs...))
+}
+
+// LastLinkNotIn applies the NotIn predicate on the "last_link" field.
+func LastLinkNotIn(vs ...string) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldLastLink, vs...))
+}
+
+// LastLinkGT applies the GT predicate on the "last_link" field.
+func LastLinkGT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldLastLink, v))
+}
+
+// LastLinkGTE applies the GTE predicate on the "last_link" field.
+func LastLinkGTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldLastLink, v))
+}
+
+// LastLinkLT applies the LT predicate on the "last_link" field.
+func LastLinkLT(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldLastLink, v))
+}
+
+// LastLinkLTE applies the LTE predicate on the "last_link" field.
+func LastLinkLTE(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldLastLink, v))
+}
+
+// LastLinkContains applies the Contains predicate on the "last_link" field.
+func LastLinkContains(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContains(FieldLastLink, v))
+}
+
+// LastLinkHasPrefix applies the HasPrefix predicate on the "last_link" field.
+func LastLinkHasPrefix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasPrefix(FieldLastLink, v))
+}
+
+// LastLinkHasSuffix applies the HasSuffix predicate on the "last_link" field.
+func LastLinkHasSuffix(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldHasSuffix(FieldLastLink, v))
+}
+
+// LastLinkIsNil applies the IsNil predicate on the "last_link" field.
+func LastLinkIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldLastLink))
+}
+
+// LastLinkNotNil applies the NotNil predicate on the "last_link" field.
+func LastLinkNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldLastLink))
+}
+
+// LastLinkEqualFold applies the EqualFold predicate on the "last_link" field.
+func LastLinkEqualFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldEqualFold(FieldLastLink, v))
+}
+
+// LastLinkContainsFold applies the ContainsFold predicate on the "last_link" field.
+func LastLinkContainsFold(v string) predicate.Watch {
+	return predicate.Watch(sql.FieldContainsFold(FieldLastLink, v))
+}
+
+// NotifiedPriceEQ applies the EQ predicate on the "notified_price" field.
+func NotifiedPriceEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceNEQ applies the NEQ predicate on the "notified_price" field.
+func NotifiedPriceNEQ(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceIn applies the In predicate on the "notified_price" field.
+func NotifiedPriceIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldNotifiedPrice, vs...))
+}
+
+// NotifiedPriceNotIn applies the NotIn predicate on the "notified_price" field.
+func NotifiedPriceNotIn(vs ...float64) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldNotifiedPrice, vs...))
+}
+
+// NotifiedPriceGT applies the GT predicate on the "notified_price" field.
+func NotifiedPriceGT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceGTE applies the GTE predicate on the "notified_price" field.
+func NotifiedPriceGTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceLT applies the LT predicate on the "notified_price" field.
+func NotifiedPriceLT(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceLTE applies the LTE predicate on the "notified_price" field.
+func NotifiedPriceLTE(v float64) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldNotifiedPrice, v))
+}
+
+// NotifiedPriceIsNil applies the IsNil predicate on the "notified_price" field.
+func NotifiedPriceIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldNotifiedPrice))
+}
+
+// NotifiedPriceNotNil applies the NotNil predicate on the "notified_price" field.
+func NotifiedPriceNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldNotifiedPrice))
+}
+
+// ActiveEQ applies the EQ predicate on the "active" field.
+func ActiveEQ(v bool) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldActive, v))
+}
+
+// ActiveNEQ applies the NEQ predicate on the "active" field.
+func ActiveNEQ(v bool) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldActive, v))
+}
+
+// LastCheckedAtEQ applies the EQ predicate on the "last_checked_at" field.
+func LastCheckedAtEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtNEQ applies the NEQ predicate on the "last_checked_at" field.
+func LastCheckedAtNEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtIn applies the In predicate on the "last_checked_at" field.
+func LastCheckedAtIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldLastCheckedAt, vs...))
+}
+
+// LastCheckedAtNotIn applies the NotIn predicate on the "last_checked_at" field.
+func LastCheckedAtNotIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldLastCheckedAt, vs...))
+}
+
+// LastCheckedAtGT applies the GT predicate on the "last_checked_at" field.
+func LastCheckedAtGT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtGTE applies the GTE predicate on the "last_checked_at" field.
+func LastCheckedAtGTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtLT applies the LT predicate on the "last_checked_at" field.
+func LastCheckedAtLT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtLTE applies the LTE predicate on the "last_checked_at" field.
+func LastCheckedAtLTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldLastCheckedAt, v))
+}
+
+// LastCheckedAtIsNil applies the IsNil predicate on the "last_checked_at" field.
+func LastCheckedAtIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldLastCheckedAt))
+}
+
+// LastCheckedAtNotNil applies the NotNil predicate on the "last_checked_at" field.
+func LastCheckedAtNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldLastCheckedAt))
+}
+
+// NotifiedAtEQ applies the EQ predicate on the "notified_at" field.
+func NotifiedAtEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldNotifiedAt, v))
+}
+
+// NotifiedAtNEQ applies the NEQ predicate on the "notified_at" field.
+func NotifiedAtNEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldNotifiedAt, v))
+}
+
+// NotifiedAtIn applies the In predicate on the "notified_at" field.
+func NotifiedAtIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldNotifiedAt, vs...))
+}
+
+// NotifiedAtNotIn applies the NotIn predicate on the "notified_at" field.
+func NotifiedAtNotIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldNotifiedAt, vs...))
+}
+
+// NotifiedAtGT applies the GT predicate on the "notified_at" field.
+func NotifiedAtGT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldNotifiedAt, v))
+}
+
+// NotifiedAtGTE applies the GTE predicate on the "notified_at" field.
+func NotifiedAtGTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldNotifiedAt, v))
+}
+
+// NotifiedAtLT applies the LT predicate on the "notified_at" field.
+func NotifiedAtLT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldNotifiedAt, v))
+}
+
+// NotifiedAtLTE applies the LTE predicate on the "notified_at" field.
+func NotifiedAtLTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldNotifiedAt, v))
+}
+
+// NotifiedAtIsNil applies the IsNil predicate on the "notified_at" field.
+func NotifiedAtIsNil() predicate.Watch {
+	return predicate.Watch(sql.FieldIsNull(FieldNotifiedAt))
+}
+
+// NotifiedAtNotNil applies the NotNil predicate on the "notified_at" field.
+func NotifiedAtNotNil() predicate.Watch {
+	return predicate.Watch(sql.FieldNotNull(FieldNotifiedAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Watch {
+	return predicate.Watch(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.Watch {
+	return predicate.Watch(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.Watch {
+	return predicate.Watch(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Watch) predicate.Watch {
+	return predicate.Watch(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Watch) predicate.Watch {
+	return predicate.Watch(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Watch) predicate.Watch {
+	return predicate.Watch(sql.NotPredicates(p))
+}
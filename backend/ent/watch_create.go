@@ -0,0 +1,523 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/user"
+	"mylittleprice/ent/watch"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// WatchCreate is the builder for creating a Watch entity.
+type WatchCreate struct {
+	config
+	mutation *WatchMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *WatchCreate) SetUserID(v uuid.UUID) *WatchCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetPageToken sets the "page_token" field.
+func (_c *WatchCreate) SetPageToken(v string) *WatchCreate {
+	_c.mutation.SetPageToken(v)
+	return _c
+}
+
+// SetProductName sets the "product_name" field.
+func (_c *WatchCreate) SetProductName(v string) *WatchCreate {
+	_c.mutation.SetProductName(v)
+	return _c
+}
+
+// SetTargetPrice sets the "target_price" field.
+func (_c *WatchCreate) SetTargetPrice(v float64) *WatchCreate {
+	_c.mutation.SetTargetPrice(v)
+	return _c
+}
+
+// SetCurrency sets the "currency" field.
+func (_c *WatchCreate) SetCurrency(v string) *WatchCreate {
+	_c.mutation.SetCurrency(v)
+	return _c
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableCurrency(v *string) *WatchCreate {
+	if v != nil {
+		_c.SetCurrency(*v)
+	}
+	return _c
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_c *WatchCreate) SetCountryCode(v string) *WatchCreate {
+	_c.mutation.SetCountryCode(v)
+	return _c
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableCountryCode(v *string) *WatchCreate {
+	if v != nil {
+		_c.SetCountryCode(*v)
+	}
+	return _c
+}
+
+// SetLastPrice sets the "last_price" field.
+func (_c *WatchCreate) SetLastPrice(v float64) *WatchCreate {
+	_c.mutation.SetLastPrice(v)
+	return _c
+}
+
+// SetNillableLastPrice sets the "last_price" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableLastPrice(v *float64) *WatchCreate {
+	if v != nil {
+		_c.SetLastPrice(*v)
+	}
+	return _c
+}
+
+// SetLastMerchant sets the "last_merchant" field.
+func (_c *WatchCreate) SetLastMerchant(v string) *WatchCreate {
+	_c.mutation.SetLastMerchant(v)
+	return _c
+}
+
+// SetNillableLastMerchant sets the "last_merchant" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableLastMerchant(v *string) *WatchCreate {
+	if v != nil {
+		_c.SetLastMerchant(*v)
+	}
+	return _c
+}
+
+// SetLastLink sets the "last_link" field.
+func (_c *WatchCreate) SetLastLink(v string) *WatchCreate {
+	_c.mutation.SetLastLink(v)
+	return _c
+}
+
+// SetNillableLastLink sets the "last_link" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableLastLink(v *string) *WatchCreate {
+	if v != nil {
+		_c.SetLastLink(*v)
+	}
+	return _c
+}
+
+// SetNotifiedPrice sets the "notified_price" field.
+func (_c *WatchCreate) SetNotifiedPrice(v float64) *WatchCreate {
+	_c.mutation.SetNotifiedPrice(v)
+	return _c
+}
+
+// SetNillableNotifiedPrice sets the "notified_price" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableNotifiedPrice(v *float64) *WatchCreate {
+	if v != nil {
+		_c.SetNotifiedPrice(*v)
+	}
+	return _c
+}
+
+// SetActive sets the "active" field.
+func (_c *WatchCreate) SetActive(v bool) *WatchCreate {
+	_c.mutation.SetActive(v)
+	return _c
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableActive(v *bool) *WatchCreate {
+	if v != nil {
+		_c.SetActive(*v)
+	}
+	return _c
+}
+
+// SetLastCheckedAt sets the "last_checked_at" field.
+func (_c *WatchCreate) SetLastCheckedAt(v time.Time) *WatchCreate {
+	_c.mutation.SetLastCheckedAt(v)
+	return _c
+}
+
+// SetNillableLastCheckedAt sets the "last_checked_at" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableLastCheckedAt(v *time.Time) *WatchCreate {
+	if v != nil {
+		_c.SetLastCheckedAt(*v)
+	}
+	return _c
+}
+
+// SetNotifiedAt sets the "notified_at" field.
+func (_c *WatchCreate) SetNotifiedAt(v time.Time) *WatchCreate {
+	_c.mutation.SetNotifiedAt(v)
+	return _c
+}
+
+// SetNillableNotifiedAt sets the "notified_at" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableNotifiedAt(v *time.Time) *WatchCreate {
+	if v != nil {
+		_c.SetNotifiedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *WatchCreate) SetCreatedAt(v time.Time) *WatchCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableCreatedAt(v *time.Time) *WatchCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *WatchCreate) SetUpdatedAt(v time.Time) *WatchCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableUpdatedAt(v *time.Time) *WatchCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *WatchCreate) SetID(v uuid.UUID) *WatchCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *WatchCreate) SetNillableID(v *uuid.UUID) *WatchCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *WatchCreate) SetUser(v *User) *WatchCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the WatchMutation object of the builder.
+func (_c *WatchCreate) Mutation() *WatchMutation {
+	return _c.mutation
+}
+
+// Save creates the Watch in the database.
+func (_c *WatchCreate) Save(ctx context.Context) (*Watch, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WatchCreate) SaveX(ctx context.Context) *Watch {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WatchCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WatchCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WatchCreate) defaults() {
+	if _, ok := _c.mutation.Currency(); !ok {
+		v := watch.DefaultCurrency
+		_c.mutation.SetCurrency(v)
+	}
+	if _, ok := _c.mutation.CountryCode(); !ok {
+		v := watch.DefaultCountryCode
+		_c.mutation.SetCountryCode(v)
+	}
+	if _, ok := _c.mutation.Active(); !ok {
+		v := watch.DefaultActive
+		_c.mutation.SetActive(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := watch.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := watch.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := watch.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WatchCreate) check() error {
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Watch.user_id"`)}
+	}
+	if _, ok := _c.mutation.PageToken(); !ok {
+		return &ValidationError{Name: "page_token", err: errors.New(`ent: missing required field "Watch.page_token"`)}
+	}
+	if v, ok := _c.mutation.PageToken(); ok {
+		if err := watch.PageTokenValidator(v); err != nil {
+			return &ValidationError{Name: "page_token", err: fmt.Errorf(`ent: validator failed for field "Watch.page_token": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ProductName(); !ok {
+		return &ValidationError{Name: "product_name", err: errors.New(`ent: missing required field "Watch.product_name"`)}
+	}
+	if v, ok := _c.mutation.ProductName(); ok {
+		if err := watch.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "Watch.product_name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.TargetPrice(); !ok {
+		return &ValidationError{Name: "target_price", err: errors.New(`ent: missing required field "Watch.target_price"`)}
+	}
+	if v, ok := _c.mutation.TargetPrice(); ok {
+		if err := watch.TargetPriceValidator(v); err != nil {
+			return &ValidationError{Name: "target_price", err: fmt.Errorf(`ent: validator failed for field "Watch.target_price": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Currency(); !ok {
+		return &ValidationError{Name: "currency", err: errors.New(`ent: missing required field "Watch.currency"`)}
+	}
+	if _, ok := _c.mutation.CountryCode(); !ok {
+		return &ValidationError{Name: "country_code", err: errors.New(`ent: missing required field "Watch.country_code"`)}
+	}
+	if _, ok := _c.mutation.Active(); !ok {
+		return &ValidationError{Name: "active", err: errors.New(`ent: missing required field "Watch.active"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Watch.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Watch.updated_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Watch.user"`)}
+	}
+	return nil
+}
+
+func (_c *WatchCreate) sqlSave(ctx context.Context) (*Watch, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WatchCreate) createSpec() (*Watch, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Watch{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(watch.Table, sqlgraph.NewFieldSpec(watch.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.PageToken(); ok {
+		_spec.SetField(watch.FieldPageToken, field.TypeString, value)
+		_node.PageToken = value
+	}
+	if value, ok := _c.mutation.ProductName(); ok {
+		_spec.SetField(watch.FieldProductName, field.TypeString, value)
+		_node.ProductName = value
+	}
+	if value, ok := _c.mutation.TargetPrice(); ok {
+		_spec.SetField(watch.FieldTargetPrice, field.TypeFloat64, value)
+		_node.TargetPrice = value
+	}
+	if value, ok := _c.mutation.Currency(); ok {
+		_spec.SetField(watch.FieldCurrency, field.TypeString, value)
+		_node.Currency = value
+	}
+	if value, ok := _c.mutation.CountryCode(); ok {
+		_spec.SetField(watch.FieldCountryCode, field.TypeString, value)
+		_node.CountryCode = value
+	}
+	if value, ok := _c.mutation.LastPrice(); ok {
+		_spec.SetField(watch.FieldLastPrice, field.TypeFloat64, value)
+		_node.LastPrice = &value
+	}
+	if value, ok := _c.mutation.LastMerchant(); ok {
+		_spec.SetField(watch.FieldLastMerchant, field.TypeString, value)
+		_node.LastMerchant = value
+	}
+	if value, ok := _c.mutation.LastLink(); ok {
+		_spec.SetField(watch.FieldLastLink, field.TypeString, value)
+		_node.LastLink = value
+	}
+	if value, ok := _c.mutation.NotifiedPrice(); ok {
+		_spec.SetField(watch.FieldNotifiedPrice, field.TypeFloat64, value)
+		_node.NotifiedPrice = &value
+	}
+	if value, ok := _c.mutation.Active(); ok {
+		_spec.SetField(watch.FieldActive, field.TypeBool, value)
+		_node.Active = value
+	}
+	if value, ok := _c.mutation.LastCheckedAt(); ok {
+		_spec.SetField(watch.FieldLastCheckedAt, field.TypeTime, value)
+		_node.LastCheckedAt = &value
+	}
+	if value, ok := _c.mutation.NotifiedAt(); ok {
+		_spec.SetField(watch.FieldNotifiedAt, field.TypeTime, value)
+		_node.NotifiedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(watch.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(watch.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   watch.UserTable,
+			Columns: []string{watch.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WatchCreateBulk is the builder for creating many Watch entities in bulk.
+type WatchCreateBulk struct {
+	config
+	err      error
+	builders []*WatchCreate
+}
+
+// Save creates the Watch entities in the database.
+func (_c *WatchCreateBulk) Save(ctx context.Context) ([]*Watch, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Watch, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WatchMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WatchCreateBulk) SaveX(ctx context.Context) []*Watch {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WatchCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WatchCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
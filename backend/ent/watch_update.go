@@ -0,0 +1,950 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mylittleprice/ent/predicate"
+	"mylittleprice/ent/user"
+	"mylittleprice/ent/watch"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// WatchUpdate is the builder for updating Watch entities.
+type WatchUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WatchMutation
+}
+
+// Where appends a list predicates to the WatchUpdate builder.
+func (_u *WatchUpdate) Where(ps ...predicate.Watch) *WatchUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *WatchUpdate) SetUserID(v uuid.UUID) *WatchUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableUserID(v *uuid.UUID) *WatchUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetPageToken sets the "page_token" field.
+func (_u *WatchUpdate) SetPageToken(v string) *WatchUpdate {
+	_u.mutation.SetPageToken(v)
+	return _u
+}
+
+// SetNillablePageToken sets the "page_token" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillablePageToken(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetPageToken(*v)
+	}
+	return _u
+}
+
+// SetProductName sets the "product_name" field.
+func (_u *WatchUpdate) SetProductName(v string) *WatchUpdate {
+	_u.mutation.SetProductName(v)
+	return _u
+}
+
+// SetNillableProductName sets the "product_name" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableProductName(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetProductName(*v)
+	}
+	return _u
+}
+
+// SetTargetPrice sets the "target_price" field.
+func (_u *WatchUpdate) SetTargetPrice(v float64) *WatchUpdate {
+	_u.mutation.ResetTargetPrice()
+	_u.mutation.SetTargetPrice(v)
+	return _u
+}
+
+// SetNillableTargetPrice sets the "target_price" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableTargetPrice(v *float64) *WatchUpdate {
+	if v != nil {
+		_u.SetTargetPrice(*v)
+	}
+	return _u
+}
+
+// AddTargetPrice adds value to the "target_price" field.
+func (_u *WatchUpdate) AddTargetPrice(v float64) *WatchUpdate {
+	_u.mutation.AddTargetPrice(v)
+	return _u
+}
+
+// SetCurrency sets the "currency" field.
+func (_u *WatchUpdate) SetCurrency(v string) *WatchUpdate {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableCurrency(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_u *WatchUpdate) SetCountryCode(v string) *WatchUpdate {
+	_u.mutation.SetCountryCode(v)
+	return _u
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableCountryCode(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetCountryCode(*v)
+	}
+	return _u
+}
+
+// SetLastPrice sets the "last_price" field.
+func (_u *WatchUpdate) SetLastPrice(v float64) *WatchUpdate {
+	_u.mutation.ResetLastPrice()
+	_u.mutation.SetLastPrice(v)
+	return _u
+}
+
+// SetNillableLastPrice sets the "last_price" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableLastPrice(v *float64) *WatchUpdate {
+	if v != nil {
+		_u.SetLastPrice(*v)
+	}
+	return _u
+}
+
+// AddLastPrice adds value to the "last_price" field.
+func (_u *WatchUpdate) AddLastPrice(v float64) *WatchUpdate {
+	_u.mutation.AddLastPrice(v)
+	return _u
+}
+
+// ClearLastPrice clears the value of the "last_price" field.
+func (_u *WatchUpdate) ClearLastPrice() *WatchUpdate {
+	_u.mutation.ClearLastPrice()
+	return _u
+}
+
+// SetLastMerchant sets the "last_merchant" field.
+func (_u *WatchUpdate) SetLastMerchant(v string) *WatchUpdate {
+	_u.mutation.SetLastMerchant(v)
+	return _u
+}
+
+// SetNillableLastMerchant sets the "last_merchant" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableLastMerchant(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetLastMerchant(*v)
+	}
+	return _u
+}
+
+// ClearLastMerchant clears the value of the "last_merchant" field.
+func (_u *WatchUpdate) ClearLastMerchant() *WatchUpdate {
+	_u.mutation.ClearLastMerchant()
+	return _u
+}
+
+// SetLastLink sets the "last_link" field.
+func (_u *WatchUpdate) SetLastLink(v string) *WatchUpdate {
+	_u.mutation.SetLastLink(v)
+	return _u
+}
+
+// SetNillableLastLink sets the "last_link" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableLastLink(v *string) *WatchUpdate {
+	if v != nil {
+		_u.SetLastLink(*v)
+	}
+	return _u
+}
+
+// ClearLastLink clears the value of the "last_link" field.
+func (_u *WatchUpdate) ClearLastLink() *WatchUpdate {
+	_u.mutation.ClearLastLink()
+	return _u
+}
+
+// SetNotifiedPrice sets the "notified_price" field.
+func (_u *WatchUpdate) SetNotifiedPrice(v float64) *WatchUpdate {
+	_u.mutation.ResetNotifiedPrice()
+	_u.mutation.SetNotifiedPrice(v)
+	return _u
+}
+
+// SetNillableNotifiedPrice sets the "notified_price" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableNotifiedPrice(v *float64) *WatchUpdate {
+	if v != nil {
+		_u.SetNotifiedPrice(*v)
+	}
+	return _u
+}
+
+// AddNotifiedPrice adds value to the "notified_price" field.
+func (_u *WatchUpdate) AddNotifiedPrice(v float64) *WatchUpdate {
+	_u.mutation.AddNotifiedPrice(v)
+	return _u
+}
+
+// ClearNotifiedPrice clears the value of the "notified_price" field.
+func (_u *WatchUpdate) ClearNotifiedPrice() *WatchUpdate {
+	_u.mutation.ClearNotifiedPrice()
+	return _u
+}
+
+// SetActive sets the "active" field.
+func (_u *WatchUpdate) SetActive(v bool) *WatchUpdate {
+	_u.mutation.SetActive(v)
+	return _u
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableActive(v *bool) *WatchUpdate {
+	if v != nil {
+		_u.SetActive(*v)
+	}
+	return _u
+}
+
+// SetLastCheckedAt sets the "last_checked_at" field.
+func (_u *WatchUpdate) SetLastCheckedAt(v time.Time) *WatchUpdate {
+	_u.mutation.SetLastCheckedAt(v)
+	return _u
+}
+
+// SetNillableLastCheckedAt sets the "last_checked_at" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableLastCheckedAt(v *time.Time) *WatchUpdate {
+	if v != nil {
+		_u.SetLastCheckedAt(*v)
+	}
+	return _u
+}
+
+// ClearLastCheckedAt clears the value of the "last_checked_at" field.
+func (_u *WatchUpdate) ClearLastCheckedAt() *WatchUpdate {
+	_u.mutation.ClearLastCheckedAt()
+	return _u
+}
+
+// SetNotifiedAt sets the "notified_at" field.
+func (_u *WatchUpdate) SetNotifiedAt(v time.Time) *WatchUpdate {
+	_u.mutation.SetNotifiedAt(v)
+	return _u
+}
+
+// SetNillableNotifiedAt sets the "notified_at" field if the given value is not nil.
+func (_u *WatchUpdate) SetNillableNotifiedAt(v *time.Time) *WatchUpdate {
+	if v != nil {
+		_u.SetNotifiedAt(*v)
+	}
+	return _u
+}
+
+// ClearNotifiedAt clears the value of the "notified_at" field.
+func (_u *WatchUpdate) ClearNotifiedAt() *WatchUpdate {
+	_u.mutation.ClearNotifiedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *WatchUpdate) SetUpdatedAt(v time.Time) *WatchUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *WatchUpdate) SetUser(v *User) *WatchUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the WatchMutation object of the builder.
+func (_u *WatchUpdate) Mutation() *WatchMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *WatchUpdate) ClearUser() *WatchUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *WatchUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WatchUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *WatchUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WatchUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WatchUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := watch.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WatchUpdate) check() error {
+	if v, ok := _u.mutation.PageToken(); ok {
+		if err := watch.PageTokenValidator(v); err != nil {
+			return &ValidationError{Name: "page_token", err: fmt.Errorf(`ent: validator failed for field "Watch.page_token": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProductName(); ok {
+		if err := watch.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "Watch.product_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetPrice(); ok {
+		if err := watch.TargetPriceValidator(v); err != nil {
+			return &ValidationError{Name: "target_price", err: fmt.Errorf(`ent: validator failed for field "Watch.target_price": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Watch.user"`)
+	}
+	return nil
+}
+
+func (_u *WatchUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(watch.Table, watch.Columns, sqlgraph.NewFieldSpec(watch.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.PageToken(); ok {
+		_spec.SetField(watch.FieldPageToken, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProductName(); ok {
+		_spec.SetField(watch.FieldProductName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TargetPrice(); ok {
+		_spec.SetField(watch.FieldTargetPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedTargetPrice(); ok {
+		_spec.AddField(watch.FieldTargetPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(watch.FieldCurrency, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CountryCode(); ok {
+		_spec.SetField(watch.FieldCountryCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastPrice(); ok {
+		_spec.SetField(watch.FieldLastPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLastPrice(); ok {
+		_spec.AddField(watch.FieldLastPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.LastPriceCleared() {
+		_spec.ClearField(watch.FieldLastPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.LastMerchant(); ok {
+		_spec.SetField(watch.FieldLastMerchant, field.TypeString, value)
+	}
+	if _u.mutation.LastMerchantCleared() {
+		_spec.ClearField(watch.FieldLastMerchant, field.TypeString)
+	}
+	if value, ok := _u.mutation.LastLink(); ok {
+		_spec.SetField(watch.FieldLastLink, field.TypeString, value)
+	}
+	if _u.mutation.LastLinkCleared() {
+		_spec.ClearField(watch.FieldLastLink, field.TypeString)
+	}
+	if value, ok := _u.mutation.NotifiedPrice(); ok {
+		_spec.SetField(watch.FieldNotifiedPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedNotifiedPrice(); ok {
+		_spec.AddField(watch.FieldNotifiedPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.NotifiedPriceCleared() {
+		_spec.ClearField(watch.FieldNotifiedPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.Active(); ok {
+		_spec.SetField(watch.FieldActive, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.LastCheckedAt(); ok {
+		_spec.SetField(watch.FieldLastCheckedAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastCheckedAtCleared() {
+		_spec.ClearField(watch.FieldLastCheckedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.NotifiedAt(); ok {
+		_spec.SetField(watch.FieldNotifiedAt, field.TypeTime, value)
+	}
+	if _u.mutation.NotifiedAtCleared() {
+		_spec.ClearField(watch.FieldNotifiedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(watch.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   watch.UserTable,
+			Columns: []string{watch.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   watch.UserTable,
+			Columns: []string{watch.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{watch.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// WatchUpdateOne is the builder for updating a single Watch entity.
+type WatchUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WatchMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *WatchUpdateOne) SetUserID(v uuid.UUID) *WatchUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableUserID(v *uuid.UUID) *WatchUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetPageToken sets the "page_token" field.
+func (_u *WatchUpdateOne) SetPageToken(v string) *WatchUpdateOne {
+	_u.mutation.SetPageToken(v)
+	return _u
+}
+
+// SetNillablePageToken sets the "page_token" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillablePageToken(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetPageToken(*v)
+	}
+	return _u
+}
+
+// SetProductName sets the "product_name" field.
+func (_u *WatchUpdateOne) SetProductName(v string) *WatchUpdateOne {
+	_u.mutation.SetProductName(v)
+	return _u
+}
+
+// SetNillableProductName sets the "product_name" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableProductName(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetProductName(*v)
+	}
+	return _u
+}
+
+// SetTargetPrice sets the "target_price" field.
+func (_u *WatchUpdateOne) SetTargetPrice(v float64) *WatchUpdateOne {
+	_u.mutation.ResetTargetPrice()
+	_u.mutation.SetTargetPrice(v)
+	return _u
+}
+
+// SetNillableTargetPrice sets the "target_price" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableTargetPrice(v *float64) *WatchUpdateOne {
+	if v != nil {
+		_u.SetTargetPrice(*v)
+	}
+	return _u
+}
+
+// AddTargetPrice adds value to the "target_price" field.
+func (_u *WatchUpdateOne) AddTargetPrice(v float64) *WatchUpdateOne {
+	_u.mutation.AddTargetPrice(v)
+	return _u
+}
+
+// SetCurrency sets the "currency" field.
+func (_u *WatchUpdateOne) SetCurrency(v string) *WatchUpdateOne {
+	_u.mutation.SetCurrency(v)
+	return _u
+}
+
+// SetNillableCurrency sets the "currency" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableCurrency(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetCurrency(*v)
+	}
+	return _u
+}
+
+// SetCountryCode sets the "country_code" field.
+func (_u *WatchUpdateOne) SetCountryCode(v string) *WatchUpdateOne {
+	_u.mutation.SetCountryCode(v)
+	return _u
+}
+
+// SetNillableCountryCode sets the "country_code" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableCountryCode(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetCountryCode(*v)
+	}
+	return _u
+}
+
+// SetLastPrice sets the "last_price" field.
+func (_u *WatchUpdateOne) SetLastPrice(v float64) *WatchUpdateOne {
+	_u.mutation.ResetLastPrice()
+	_u.mutation.SetLastPrice(v)
+	return _u
+}
+
+// SetNillableLastPrice sets the "last_price" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableLastPrice(v *float64) *WatchUpdateOne {
+	if v != nil {
+		_u.SetLastPrice(*v)
+	}
+	return _u
+}
+
+// AddLastPrice adds value to the "last_price" field.
+func (_u *WatchUpdateOne) AddLastPrice(v float64) *WatchUpdateOne {
+	_u.mutation.AddLastPrice(v)
+	return _u
+}
+
+// ClearLastPrice clears the value of the "last_price" field.
+func (_u *WatchUpdateOne) ClearLastPrice() *WatchUpdateOne {
+	_u.mutation.ClearLastPrice()
+	return _u
+}
+
+// SetLastMerchant sets the "last_merchant" field.
+func (_u *WatchUpdateOne) SetLastMerchant(v string) *WatchUpdateOne {
+	_u.mutation.SetLastMerchant(v)
+	return _u
+}
+
+// SetNillableLastMerchant sets the "last_merchant" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableLastMerchant(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetLastMerchant(*v)
+	}
+	return _u
+}
+
+// ClearLastMerchant clears the value of the "last_merchant" field.
+func (_u *WatchUpdateOne) ClearLastMerchant() *WatchUpdateOne {
+	_u.mutation.ClearLastMerchant()
+	return _u
+}
+
+// SetLastLink sets the "last_link" field.
+func (_u *WatchUpdateOne) SetLastLink(v string) *WatchUpdateOne {
+	_u.mutation.SetLastLink(v)
+	return _u
+}
+
+// SetNillableLastLink sets the "last_link" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableLastLink(v *string) *WatchUpdateOne {
+	if v != nil {
+		_u.SetLastLink(*v)
+	}
+	return _u
+}
+
+// ClearLastLink clears the value of the "last_link" field.
+func (_u *WatchUpdateOne) ClearLastLink() *WatchUpdateOne {
+	_u.mutation.ClearLastLink()
+	return _u
+}
+
+// SetNotifiedPrice sets the "notified_price" field.
+func (_u *WatchUpdateOne) SetNotifiedPrice(v float64) *WatchUpdateOne {
+	_u.mutation.ResetNotifiedPrice()
+	_u.mutation.SetNotifiedPrice(v)
+	return _u
+}
+
+// SetNillableNotifiedPrice sets the "notified_price" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableNotifiedPrice(v *float64) *WatchUpdateOne {
+	if v != nil {
+		_u.SetNotifiedPrice(*v)
+	}
+	return _u
+}
+
+// AddNotifiedPrice adds value to the "notified_price" field.
+func (_u *WatchUpdateOne) AddNotifiedPrice(v float64) *WatchUpdateOne {
+	_u.mutation.AddNotifiedPrice(v)
+	return _u
+}
+
+// ClearNotifiedPrice clears the value of the "notified_price" field.
+func (_u *WatchUpdateOne) ClearNotifiedPrice() *WatchUpdateOne {
+	_u.mutation.ClearNotifiedPrice()
+	return _u
+}
+
+// SetActive sets the "active" field.
+func (_u *WatchUpdateOne) SetActive(v bool) *WatchUpdateOne {
+	_u.mutation.SetActive(v)
+	return _u
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableActive(v *bool) *WatchUpdateOne {
+	if v != nil {
+		_u.SetActive(*v)
+	}
+	return _u
+}
+
+// SetLastCheckedAt sets the "last_checked_at" field.
+func (_u *WatchUpdateOne) SetLastCheckedAt(v time.Time) *WatchUpdateOne {
+	_u.mutation.SetLastCheckedAt(v)
+	return _u
+}
+
+// SetNillableLastCheckedAt sets the "last_checked_at" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableLastCheckedAt(v *time.Time) *WatchUpdateOne {
+	if v != nil {
+		_u.SetLastCheckedAt(*v)
+	}
+	return _u
+}
+
+// ClearLastCheckedAt clears the value of the "last_checked_at" field.
+func (_u *WatchUpdateOne) ClearLastCheckedAt() *WatchUpdateOne {
+	_u.mutation.ClearLastCheckedAt()
+	return _u
+}
+
+// SetNotifiedAt sets the "notified_at" field.
+func (_u *WatchUpdateOne) SetNotifiedAt(v time.Time) *WatchUpdateOne {
+	_u.mutation.SetNotifiedAt(v)
+	return _u
+}
+
+// SetNillableNotifiedAt sets the "notified_at" field if the given value is not nil.
+func (_u *WatchUpdateOne) SetNillableNotifiedAt(v *time.Time) *WatchUpdateOne {
+	if v != nil {
+		_u.SetNotifiedAt(*v)
+	}
+	return _u
+}
+
+// ClearNotifiedAt clears the value of the "notified_at" field.
+func (_u *WatchUpdateOne) ClearNotifiedAt() *WatchUpdateOne {
+	_u.mutation.ClearNotifiedAt()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *WatchUpdateOne) SetUpdatedAt(v time.Time) *WatchUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *WatchUpdateOne) SetUser(v *User) *WatchUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the WatchMutation object of the builder.
+func (_u *WatchUpdateOne) Mutation() *WatchMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *WatchUpdateOne) ClearUser() *WatchUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the WatchUpdate builder.
+func (_u *WatchUpdateOne) Where(ps ...predicate.Watch) *WatchUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *WatchUpdateOne) Select(field string, fields ...string) *WatchUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Watch entity.
+func (_u *WatchUpdateOne) Save(ctx context.Context) (*Watch, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WatchUpdateOne) SaveX(ctx context.Context) *Watch {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *WatchUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WatchUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WatchUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := watch.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WatchUpdateOne) check() error {
+	if v, ok := _u.mutation.PageToken(); ok {
+		if err := watch.PageTokenValidator(v); err != nil {
+			return &ValidationError{Name: "page_token", err: fmt.Errorf(`ent: validator failed for field "Watch.page_token": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ProductName(); ok {
+		if err := watch.ProductNameValidator(v); err != nil {
+			return &ValidationError{Name: "product_name", err: fmt.Errorf(`ent: validator failed for field "Watch.product_name": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetPrice(); ok {
+		if err := watch.TargetPriceValidator(v); err != nil {
+			return &ValidationError{Name: "target_price", err: fmt.Errorf(`ent: validator failed for field "Watch.target_price": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Watch.user"`)
+	}
+	return nil
+}
+
+func (_u *WatchUpdateOne) sqlSave(ctx context.Context) (_node *Watch, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(watch.Table, watch.Columns, sqlgraph.NewFieldSpec(watch.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Watch.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, watch.FieldID)
+		for _, f := range fields {
+			if !watch.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != watch.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.PageToken(); ok {
+		_spec.SetField(watch.FieldPageToken, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ProductName(); ok {
+		_spec.SetField(watch.FieldProductName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TargetPrice(); ok {
+		_spec.SetField(watch.FieldTargetPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedTargetPrice(); ok {
+		_spec.AddField(watch.FieldTargetPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Currency(); ok {
+		_spec.SetField(watch.FieldCurrency, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CountryCode(); ok {
+		_spec.SetField(watch.FieldCountryCode, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastPrice(); ok {
+		_spec.SetField(watch.FieldLastPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLastPrice(); ok {
+		_spec.AddField(watch.FieldLastPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.LastPriceCleared() {
+		_spec.ClearField(watch.FieldLastPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.LastMerchant(); ok {
+		_spec.SetField(watch.FieldLastMerchant, field.TypeString, value)
+	}
+	if _u.mutation.LastMerchantCleared() {
+		_spec.ClearField(watch.FieldLastMerchant, field.TypeString)
+	}
+	if value, ok := _u.mutation.LastLink(); ok {
+		_spec.SetField(watch.FieldLastLink, field.TypeString, value)
+	}
+	if _u.mutation.LastLinkCleared() {
+		_spec.ClearField(watch.FieldLastLink, field.TypeString)
+	}
+	if value, ok := _u.mutation.NotifiedPrice(); ok {
+		_spec.SetField(watch.FieldNotifiedPrice, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedNotifiedPrice(); ok {
+		_spec.AddField(watch.FieldNotifiedPrice, field.TypeFloat64, value)
+	}
+	if _u.mutation.NotifiedPriceCleared() {
+		_spec.ClearField(watch.FieldNotifiedPrice, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.Active(); ok {
+		_spec.SetField(watch.FieldActive, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.LastCheckedAt(); ok {
+		_spec.SetField(watch.FieldLastCheckedAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastCheckedAtCleared() {
+		_spec.ClearField(watch.FieldLastCheckedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.NotifiedAt(); ok {
+		_spec.SetField(watch.FieldNotifiedAt, field.TypeTime, value)
+	}
+	if _u.mutation.NotifiedAtCleared() {
+		_spec.ClearField(watch.FieldNotifiedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(watch.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   watch.UserTable,
+			Columns: []string{watch.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   watch.UserTable,
+			Columns: []string{watch.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Watch{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{watch.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -10,6 +10,7 @@ import (
 	"mylittleprice/internal/container"
 	"mylittleprice/internal/handlers"
 	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
 )
 
 // SetupRoutes configures all application routes
@@ -51,6 +52,12 @@ func SetupRoutes(app *fiber.App, c *container.Container) {
 	// User preferences routes (authenticated)
 	setupPreferencesRoutes(api, c)
 
+	// Price history routes (public)
+	setupPriceHistoryRoutes(api, c)
+
+	// Price watch routes (authenticated)
+	setupWatchRoutes(api, c)
+
 	// Stats routes
 	setupStatsRoutes(api, c)
 
@@ -59,6 +66,9 @@ func SetupRoutes(app *fiber.App, c *container.Container) {
 
 	// Contact form routes
 	setupContactRoutes(api, c)
+
+	// Admin routes (admin role required)
+	setupAdminRoutes(api, c)
 }
 
 func setupAuthRoutes(api fiber.Router, c *container.Container) {
@@ -78,10 +88,33 @@ func setupAuthRoutes(api fiber.Router, c *container.Container) {
 	auth.Post("/request-password-reset", authRateLimiter, authHandler.RequestPasswordReset)
 	auth.Post("/reset-password", authRateLimiter, authHandler.ResetPassword)
 
+	// Email verification - the link works without being signed in
+	auth.Post("/verify-email", authRateLimiter, authHandler.VerifyEmail)
+	auth.Post("/resend-verification", authMiddleware, middleware.VerificationEmailRateLimiter(c.Redis), authHandler.ResendVerification)
+
+	// Two-factor authentication - verify is the second step of login, the
+	// rest manage 2FA of the signed-in user. Code checks are rate limited.
+	auth.Post("/2fa/verify", authRateLimiter, authHandler.VerifyTwoFactor)
+	auth.Get("/2fa", authMiddleware, authHandler.GetTwoFactorStatus)
+	auth.Post("/2fa/enroll", authMiddleware, authHandler.EnrollTwoFactor)
+	auth.Post("/2fa/confirm", authMiddleware, authRateLimiter, authHandler.ConfirmTwoFactor)
+	auth.Post("/2fa/disable", authMiddleware, authRateLimiter, authHandler.DisableTwoFactor)
+
 	// Protected routes
 	auth.Get("/me", authMiddleware, authHandler.GetMe)
 	auth.Post("/claim-sessions", authMiddleware, authHandler.ClaimSessions)
 	auth.Post("/change-password", authMiddleware, authHandler.ChangePassword)
+
+	// OpenID Connect login and the providers linked to the account
+	auth.Get("/oidc/providers", authHandler.ListOIDCProviders)
+	auth.Post("/oidc/:provider", authRateLimiter, authHandler.OIDCLogin)
+	auth.Get("/identities", authMiddleware, authHandler.ListIdentities)
+	auth.Post("/identities/:provider", authMiddleware, authRateLimiter, authHandler.LinkIdentity)
+	auth.Delete("/identities/:id", authMiddleware, authHandler.UnlinkIdentity)
+
+	// Signed-in devices (refresh token families)
+	auth.Get("/devices", authMiddleware, authHandler.ListDevices)
+	auth.Delete("/devices/:id", authMiddleware, authHandler.RevokeDevice)
 }
 
 func setupWebSocketRoutes(app *fiber.App, c *container.Container) {
@@ -132,20 +165,46 @@ func setupChatRoutes(api fiber.Router, c *container.Container) {
 	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(c.JWTService)
 	sessionOwnership := c.SessionOwnershipChecker.ValidateSessionOwnership()
 
-	api.Post("/chat", optionalAuthMiddleware, chatHandler.HandleChat)
-	api.Get("/chat/messages/since", optionalAuthMiddleware, sessionOwnership, chatHandler.GetMessagesSince) // Reconnect endpoint with ownership check
-	api.Get("/chat/messages", optionalAuthMiddleware, sessionOwnership, chatHandler.GetSessionMessages)     // Get messages with ownership check
+	// Also accepts API keys with the chat scope
+	chatAuth := middleware.APIKeyAuth(apiKeyConfig(c), models.APIKeyScopeChat, optionalAuthMiddleware)
+
+	api.Post("/chat", chatAuth, chatHandler.HandleChat)
+	api.Get("/chat/messages/since", chatAuth, sessionOwnership, chatHandler.GetMessagesSince) // Reconnect endpoint with ownership check
+	api.Get("/chat/messages", chatAuth, sessionOwnership, chatHandler.GetSessionMessages)     // Get messages with ownership check
 }
 
 func setupProductRoutes(api fiber.Router, c *container.Container) {
 	productHandler := handlers.NewProductHandler(c)
-	api.Post("/product-details", productHandler.HandleProductDetails)
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(c.JWTService)
+
+	// Each comparison fetches up to 5 product details and asks Gemini for a verdict
+	compareRateLimiter := middleware.RateLimiter(middleware.RateLimiterConfig{
+		Redis:      c.Redis,
+		Max:        10,
+		Window:     time.Minute,
+		KeyPrefix:  "compare_limit:",
+		Message:    "Too many comparisons, please try again later",
+		StatusCode: fiber.StatusTooManyRequests,
+		KeyGenerator: func(ctx *fiber.Ctx) string {
+			if userID, ok := middleware.GetUserID(ctx); ok {
+				return userID.String()
+			}
+			return ctx.IP()
+		},
+	})
+
+	// Optional auth: lookups count against the plan of the user
+	api.Post("/product-details", optionalAuthMiddleware, productHandler.HandleProductDetails)
+	api.Post("/products/compare", optionalAuthMiddleware, compareRateLimiter, productHandler.HandleCompare)
 }
 
 func setupSearchHistoryRoutes(api fiber.Router, c *container.Container) {
 	historyHandler := handlers.NewSearchHistoryHandler(c)
-	authMiddleware := middleware.AuthMiddleware(c.JWTService)
-	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(c.JWTService)
+	apiKeys := apiKeyConfig(c)
+
+	// Also accept API keys with the search-history scope
+	authMiddleware := middleware.APIKeyAuth(apiKeys, models.APIKeyScopeSearchHistory, middleware.AuthMiddleware(c.JWTService))
+	optionalAuthMiddleware := middleware.APIKeyAuth(apiKeys, models.APIKeyScopeSearchHistory, middleware.OptionalAuthMiddleware(c.JWTService))
 
 	// Get search history - supports both authenticated and anonymous users
 	api.Get("/search-history", optionalAuthMiddleware, historyHandler.GetSearchHistory)
@@ -171,16 +230,82 @@ func setupSessionRoutes(api fiber.Router, c *container.Container) {
 
 	// Sign session ID - optional authentication (works for both authenticated and anonymous users)
 	sessions.Post("/sign", optionalAuthMiddleware, sessionHandler.GetSignedSessionID)
+
+	// Shared sessions - invite links and participants (live updates over /ws)
+	shareHandler := handlers.NewSessionShareHandler(c)
+	sessions.Post("/invites/:token/accept", authMiddleware, shareHandler.AcceptInvite)
+	sessions.Post("/:id/invites", authMiddleware, shareHandler.CreateInvite)
+	sessions.Get("/:id/participants", authMiddleware, shareHandler.ListParticipants)
+	sessions.Delete("/:id/participants/:userId", authMiddleware, shareHandler.RemoveParticipant)
+
+	// Read-only snapshots - created and revoked by the owner, served publicly by slug
+	snapshotHandler := handlers.NewSessionSnapshotHandler(c)
+	sessions.Post("/:id/share", authMiddleware, snapshotHandler.CreateSnapshot)
+	sessions.Get("/:id/share", authMiddleware, snapshotHandler.ListSnapshots)
+	sessions.Delete("/:id/share/:slug", authMiddleware, snapshotHandler.RevokeSnapshot)
+	api.Get("/shared/:slug", snapshotHandler.GetSharedSnapshot)
 }
 
 func setupPreferencesRoutes(api fiber.Router, c *container.Container) {
 	preferencesHandler := handlers.NewPreferencesHandler(c)
 	authMiddleware := middleware.AuthMiddleware(c.JWTService)
 
-	// User preferences - requires authentication
-	userGroup := api.Group("/user", authMiddleware)
-	userGroup.Get("/preferences", preferencesHandler.GetUserPreferences)
-	userGroup.Put("/preferences", preferencesHandler.UpdateUserPreferences)
+	// User preferences - requires authentication, also with API keys with
+	// the preferences scope
+	preferencesAuth := middleware.APIKeyAuth(apiKeyConfig(c), models.APIKeyScopePreferences, authMiddleware)
+	userGroup := api.Group("/user")
+	userGroup.Get("/preferences", preferencesAuth, preferencesHandler.GetUserPreferences)
+	userGroup.Put("/preferences", preferencesAuth, preferencesHandler.UpdateUserPreferences)
+
+	// Data export and account deletion (GDPR / FADP)
+	accountHandler := handlers.NewAccountHandler(c)
+	exportRateLimiter := middleware.RateLimiter(middleware.RateLimiterConfig{
+		Redis:      c.Redis,
+		Max:        5,
+		Window:     time.Hour,
+		KeyPrefix:  "account_export_limit:",
+		Message:    "Too many data exports, please try again later",
+		StatusCode: fiber.StatusTooManyRequests,
+		KeyGenerator: func(ctx *fiber.Ctx) string {
+			if userID, ok := middleware.GetUserID(ctx); ok {
+				return userID.String()
+			}
+			return ctx.IP()
+		},
+	})
+	userGroup.Get("/export", authMiddleware, exportRateLimiter, accountHandler.ExportData)
+	userGroup.Delete("", authMiddleware, accountHandler.DeleteAccount)
+
+	// Personal API keys - managed with a signed-in session only
+	apiKeyHandler := handlers.NewAPIKeyHandler(c)
+	userGroup.Post("/api-keys", authMiddleware, apiKeyHandler.CreateKey)
+	userGroup.Get("/api-keys", authMiddleware, apiKeyHandler.ListKeys)
+	userGroup.Delete("/api-keys/:id", authMiddleware, apiKeyHandler.RevokeKey)
+}
+
+// apiKeyConfig lets routes accept personal API keys besides JWTs
+func apiKeyConfig(c *container.Container) middleware.APIKeyConfig {
+	return middleware.APIKeyConfig{
+		Lookup:    c.APIKeyService.Authenticate,
+		Redis:     c.Redis,
+		RateLimit: c.Config.APIKeyRateLimit,
+	}
+}
+
+func setupPriceHistoryRoutes(api fiber.Router, c *container.Container) {
+	priceHistoryHandler := handlers.NewPriceHistoryHandler(c)
+	api.Get("/prices/history", priceHistoryHandler.GetPriceHistory)
+}
+
+func setupWatchRoutes(api fiber.Router, c *container.Container) {
+	watchHandler := handlers.NewWatchHandler(c)
+	authMiddleware := middleware.AuthMiddleware(c.JWTService)
+
+	// Price-drop watchlist - requires authentication
+	watches := api.Group("/watches", authMiddleware)
+	watches.Get("/", watchHandler.ListWatches)
+	watches.Post("/", watchHandler.CreateWatch)
+	watches.Delete("/:id", watchHandler.DeleteWatch)
 }
 
 func setupStatsRoutes(api fiber.Router, c *container.Container) {
@@ -189,8 +314,9 @@ func setupStatsRoutes(api fiber.Router, c *container.Container) {
 		serpStats, _ := c.SerpRotator.GetAllStats()
 
 		return ctx.JSON(fiber.Map{
-			"gemini": geminiStats,
-			"serp":   serpStats,
+			"gemini":           geminiStats,
+			"serp":             serpStats,
+			"search_providers": c.SearchProviders.GetStats(),
 		})
 	})
 
@@ -221,8 +347,9 @@ func setupStatsRoutes(api fiber.Router, c *container.Container) {
 		tokenStats := c.GeminiService.GetTokenStats()
 
 		return ctx.JSON(fiber.Map{
-			"token_usage": tokenStats,
-			"timestamp":   time.Now(),
+			"token_usage":   tokenStats,
+			"llm_providers": c.LLMProviders.GetStats(),
+			"timestamp":     time.Now(),
 		})
 	})
 
@@ -251,8 +378,9 @@ func setupStatsRoutes(api fiber.Router, c *container.Container) {
 				"average_confidence":   fmt.Sprintf("%.2f", groundingStats.AverageConfidence),
 				"mode":                 c.Config.GeminiGroundingMode,
 			},
-			"tokens":    tokenStats,
-			"timestamp": time.Now(),
+			"tokens":        tokenStats,
+			"llm_providers": c.LLMProviders.GetStats(),
+			"timestamp":     time.Now(),
 		})
 	})
 }
@@ -274,9 +402,7 @@ func setupBugReportRoutes(api fiber.Router, c *container.Container) {
 	// Public endpoint - anyone can submit bug reports
 	api.Post("/bug-report", bugReportRateLimiter, bugReportHandler.SubmitBugReport)
 
-	// Admin endpoint - requires authentication
-	// TODO: Add admin middleware when implemented
-	// api.Get("/bug-reports", authMiddleware, adminMiddleware, bugReportHandler.GetBugReports)
+	// Submitted reports are read through /api/admin/bug-reports
 }
 
 func setupContactRoutes(api fiber.Router, c *container.Container) {
@@ -296,3 +422,38 @@ func setupContactRoutes(api fiber.Router, c *container.Container) {
 	// Public endpoint - anyone can submit contact forms
 	api.Post("/contact", contactRateLimiter, contactHandler.SubmitContactForm)
 }
+
+func setupAdminRoutes(api fiber.Router, c *container.Container) {
+	adminHandler := handlers.NewAdminHandler(c)
+	authMiddleware := middleware.AuthMiddleware(c.JWTService)
+	adminMiddleware := middleware.AdminMiddleware(c.AuthService.GetUserRole)
+
+	admin := api.Group("/admin", authMiddleware, adminMiddleware)
+
+	// Bug report inbox
+	admin.Get("/bug-reports", adminHandler.ListBugReports)
+	admin.Post("/bug-reports/:id/resolve", adminHandler.ResolveBugReport)
+	admin.Delete("/bug-reports/:id", adminHandler.DeleteBugReport)
+
+	// Contact form inbox
+	admin.Get("/contact-messages", adminHandler.ListContactMessages)
+	admin.Post("/contact-messages/:id/resolve", adminHandler.ResolveContactMessage)
+	admin.Delete("/contact-messages/:id", adminHandler.DeleteContactMessage)
+
+	// Users
+	admin.Get("/users", adminHandler.ListUsers)
+	admin.Put("/users/:id/role", adminHandler.UpdateUserRole)
+	admin.Put("/users/:id/plan", adminHandler.UpdateUserPlan)
+
+	// Audit log
+	admin.Get("/audit-log", adminHandler.ListAuditLog)
+
+	// Costs of paid LLM and SerpAPI calls
+	admin.Get("/costs", adminHandler.ListCosts)
+	admin.Get("/costs/summary", adminHandler.CostSummary)
+
+	// Scheduled jobs: status, run history and manual runs
+	admin.Get("/jobs", adminHandler.ListJobs)
+	admin.Get("/jobs/:name/runs", adminHandler.ListJobRuns)
+	admin.Post("/jobs/:name/run", adminHandler.TriggerJob)
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"mylittleprice/internal/utils"
 )
 
 type Config struct {
@@ -47,21 +49,40 @@ type Config struct {
 	JWTAccessTTL     time.Duration
 	JWTRefreshTTL    time.Duration
 
+	// Two-factor authentication
+	TOTPEncryptionKey string // Hex AES-256 key sealing TOTP secrets in PostgreSQL
+
 	// Google OAuth
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
 
+	// OpenID Connect providers (Apple, Microsoft, Keycloak, ...)
+	OIDCProviders []OIDCProviderConfig
+
 	// Session
 	SessionTTL               int
-	MaxMessagesPerSession    int
-	MaxSearchesPerSession    int // Added from .env
-	AnonymousSearchLimit     int // Maximum searches allowed for anonymous users
+
+	// Usage plans: daily allowances of anonymous visitors and of each user plan
+	Plans map[string]PlanLimits
+
+	// Cost accounting: USD prices of paid LLM and SerpAPI calls
+	CostModelPrices    map[string]ModelPrice // By model name, see loadModelPrices
+	CostGroundingPrice float64               // Per grounded request
+	CostSerpAPIPrice   float64               // Per search or product details request
+	CostRetentionDays  int                   // Cost records older than this are deleted
 
 	// API Keys
 	GeminiAPIKeys []string
 	SerpAPIKeys   []string
 
+	// API key rotation: when exhausted keys get their quota back, and the
+	// circuit breaker for keys failing with other errors
+	GeminiKeyReset     string // "daily", "monthly" or "monthly:<day>"
+	SerpKeyReset       string
+	KeyCircuitFailures int           // Consecutive failures that open a key's circuit
+	KeyCircuitCooldown time.Duration // How long an open circuit skips a key before probing it
+
 	// Gemini Configuration
 	GeminiModel           string
 	GeminiFallbackModel   string // Fallback model for retries
@@ -99,6 +120,30 @@ type Config struct {
 	EmbeddingCategoryDetectionThresh float64
 	CacheQueryEmbeddingTTL           int
 
+	// Semantic search cache: cached results of similar queries are reused
+	CacheSemanticIndex     string  // "auto", "redis", "memory" or "off"
+	CacheSemanticThreshold float64 // Minimum cosine similarity of a reused query
+
+	// LLM Providers per task: "gemini" or "openai" (OpenAI-compatible API)
+	LLMDialogueProvider    string
+	LLMExtractionProvider  string
+	LLMTranslationProvider string
+	LLMEmbeddingProvider   string
+
+	// OpenAI-compatible Provider (e.g. a self-hosted model server)
+	OpenAIBaseURL        string
+	OpenAIAPIKey         string
+	OpenAIModel          string
+	OpenAIEmbeddingModel string
+
+	// Product Search Providers
+	SearchProvider          string            // Default provider name (e.g. "serpapi")
+	SearchProviderByCountry map[string]string // Per-country overrides, e.g. "US:serpapi,DE:serpapi"
+
+	// Offline Fixtures
+	FixtureMode string // "off", "replay" or "record"
+	FixtureDir  string // Cassette directory
+
 	// SERP Relevance Thresholds
 	SerpThresholdExact      float64
 	SerpThresholdParameters float64
@@ -132,10 +177,35 @@ type Config struct {
 	CacheSerpTTL      int
 	CacheImmersiveTTL int
 
+	// Price Watches
+	PriceWatchRecheck    time.Duration // Minimum time between re-checks of one watch
+	PriceWatchBatchSize  int
+	PriceWatchMaxPerUser int
+
+	// Scheduled jobs
+	JobsEnabled             bool              // Run scheduled jobs on this instance; manual runs work either way
+	JobPollInterval         time.Duration     // How often due jobs are looked for
+	JobHistoryRetentionDays int               // Job runs older than this are deleted
+	JobSchedules            map[string]string // Cron schedule per job name
+
+	// Currency Conversion
+	FXSource          string        // static or http
+	FXRatesFile       string        // Static rates, also the fallback for http
+	FXRatesURL        string
+	FXRefreshInterval time.Duration
+
+	// Session Sharing
+	SessionInviteTTL       time.Duration // How long an invite link can be accepted
+	SessionMaxParticipants int           // Invited users per session, the owner not counted
+
 	// Rate Limiting
 	RateLimitRequests int
 	RateLimitWindow   int
 
+	// Personal API keys
+	APIKeyRateLimit  int // Requests per minute per key
+	APIKeyMaxPerUser int // Active keys per user
+
 	// CORS
 	CORSOrigins []string
 
@@ -148,6 +218,10 @@ type Config struct {
 	SMTPFromName  string
 	FrontendURL   string
 
+	// Email verification
+	EmailVerificationTTL    time.Duration // How long a verification link stays valid
+	RestrictUnverifiedUsers bool          // Unverified email accounts get the anonymous plan
+
 	// Notifications
 	DiscordWebhookURL string // Bug reports webhook
 	ContactWebhookURL string // Contact form webhook
@@ -160,6 +234,71 @@ type Config struct {
 	LokiEnabled     bool
 	LokiURL         string
 	LokiServiceName string
+
+	// Tracing (OpenTelemetry, exported over OTLP/HTTP)
+	TracingEnabled     bool
+	TracingEndpoint    string  // Collector base URL, e.g. http://localhost:4318
+	TracingServiceName string
+	TracingSampleRatio float64 // Share of new traces kept, 0-1
+}
+
+// OIDCProviderConfig configures an OpenID Connect provider whose ID tokens
+// are accepted for login and account linking
+type OIDCProviderConfig struct {
+	Name        string   // Lowercase identifier used in URLs and stored with identities
+	DisplayName string   // Shown on the login button
+	Issuer      string   // Discovery is read from <issuer>/.well-known/openid-configuration
+	ClientIDs   []string // Accepted audiences, e.g. the web and the iOS client of Apple
+	TrustEmail  bool     // Emails are verified even without the email_verified claim
+}
+
+// PlanLimits are the allowances of a usage plan. -1 is unlimited.
+type PlanLimits struct {
+	DailySearches       int // Turns that found products
+	DailyMessages       int // Chat messages
+	DailyGrounding      int // Turns answered with Google Search grounding
+	DailyProductDetails int // Product detail lookups
+	WSMessagesPerMinute int // WebSocket messages across all connections of a user
+}
+
+// defaultPlanLimits apply unless overridden with PLAN_<NAME>_<LIMIT>,
+// e.g. PLAN_PRO_DAILY_SEARCHES=1000
+var defaultPlanLimits = map[string]PlanLimits{
+	"anonymous": {DailySearches: 3, DailyMessages: 30, DailyGrounding: 10, DailyProductDetails: 20, WSMessagesPerMinute: 20},
+	"free":      {DailySearches: 50, DailyMessages: 200, DailyGrounding: 100, DailyProductDetails: 100, WSMessagesPerMinute: 50},
+	"pro":       {DailySearches: 500, DailyMessages: 2000, DailyGrounding: 1000, DailyProductDetails: 1000, WSMessagesPerMinute: 120},
+	"internal":  {DailySearches: -1, DailyMessages: -1, DailyGrounding: -1, DailyProductDetails: -1, WSMessagesPerMinute: -1},
+}
+
+// ModelPrice is the USD price of a million input and output tokens
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// defaultModelPrices are the list prices of the models this app uses by
+// default. A name also prices the models it is a prefix of, so
+// "gemini-2.5-flash" covers "gemini-2.5-flash-preview-09-2025".
+var defaultModelPrices = map[string]ModelPrice{
+	"gemini-2.5-pro":           {Input: 1.25, Output: 10},
+	"gemini-2.5-flash":         {Input: 0.30, Output: 2.50},
+	"gemini-2.5-flash-lite":    {Input: 0.10, Output: 0.40},
+	"gemini-2.0-flash":         {Input: 0.10, Output: 0.40},
+	"gemini-2.0-flash-lite":    {Input: 0.075, Output: 0.30},
+	"gemini-flash-latest":      {Input: 0.30, Output: 2.50},
+	"gemini-flash-lite-latest": {Input: 0.10, Output: 0.40},
+	"gemini-embedding-001":     {Input: 0.15},
+	"text-embedding-004":       {},
+}
+
+// defaultJobSchedules apply unless overridden with JOB_<NAME>_SCHEDULE,
+// e.g. JOB_SESSION_CLEANUP_SCHEDULE="0 4 * * *". Times are server local.
+var defaultJobSchedules = map[string]string{
+	"search_history_cleanup": "0 3 * * *",
+	"session_cleanup":        "15 3 * * *",
+	"cost_records_cleanup":   "30 3 * * *",
+	"job_runs_cleanup":       "45 3 * * *",
+	"price_watch":            "0 * * * *",
 }
 
 func Load() (*Config, error) {
@@ -189,15 +328,20 @@ func Load() (*Config, error) {
 		JWTRefreshSecret:      getEnv("JWT_REFRESH_SECRET", ""),
 		JWTAccessTTL:          time.Duration(getEnvAsInt("JWT_ACCESS_TTL", 900)) * time.Second,     // 15 minutes default
 		JWTRefreshTTL:         time.Duration(getEnvAsInt("JWT_REFRESH_TTL", 604800)) * time.Second, // 7 days default
+		TOTPEncryptionKey:     getEnv("TOTP_ENCRYPTION_KEY", ""),
 		GoogleClientID:        getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret:    getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURL:     getEnv("GOOGLE_REDIRECT_URL", ""),
 		SessionTTL:            getEnvAsInt("SESSION_TTL", 86400),
-		MaxMessagesPerSession: getEnvAsInt("MAX_MESSAGES_PER_SESSION", 8),
-		MaxSearchesPerSession: getEnvAsInt("MAX_SEARCHES_PER_SESSION", 3),
-		AnonymousSearchLimit:  getEnvAsInt("ANONYMOUS_SEARCH_LIMIT", 3),
+		CostGroundingPrice:    getEnvAsFloat("COST_GROUNDING_PRICE", 0.035),
+		CostSerpAPIPrice:      getEnvAsFloat("COST_SERPAPI_PRICE", 0.015),
+		CostRetentionDays:     getEnvAsInt("COST_RETENTION_DAYS", 180),
 		GeminiAPIKeys:         getEnvAsSlice("GEMINI_API_KEYS", []string{}),
 		SerpAPIKeys:           getEnvAsSlice("SERP_API_KEYS", []string{}),
+		GeminiKeyReset:        getEnv("GEMINI_KEY_RESET", "daily"),
+		SerpKeyReset:          getEnv("SERP_KEY_RESET", "monthly"),
+		KeyCircuitFailures:    getEnvAsInt("KEY_CIRCUIT_FAILURES", 3),
+		KeyCircuitCooldown:    time.Duration(getEnvAsInt("KEY_CIRCUIT_COOLDOWN", 60)) * time.Second,
 		GeminiModel:           getEnv("GEMINI_MODEL", "gemini-flash-latest"),
 		GeminiFallbackModel:   getEnv("GEMINI_FALLBACK_MODEL", "gemini-flash-lite-latest"),
 		GeminiTemperature:     float32(getEnvAsFloat("GEMINI_TEMPERATURE", 0.7)),
@@ -234,6 +378,28 @@ func Load() (*Config, error) {
 		EmbeddingCategoryDetectionThresh: getEnvAsFloat("EMBEDDING_CATEGORY_DETECTION_THRESHOLD", 0.6),
 		CacheQueryEmbeddingTTL:           getEnvAsInt("CACHE_QUERY_EMBEDDING_TTL", 86400),
 
+		// Semantic search cache
+		CacheSemanticIndex:     getEnv("CACHE_SEMANTIC_INDEX", "auto"),
+		CacheSemanticThreshold: getEnvAsFloat("CACHE_SEMANTIC_THRESHOLD", 0.92),
+
+		// LLM Providers
+		LLMDialogueProvider:    getEnv("LLM_DIALOGUE_PROVIDER", "gemini"),
+		LLMExtractionProvider:  getEnv("LLM_EXTRACTION_PROVIDER", "gemini"),
+		LLMTranslationProvider: getEnv("LLM_TRANSLATION_PROVIDER", "gemini"),
+		LLMEmbeddingProvider:   getEnv("LLM_EMBEDDING_PROVIDER", "gemini"),
+		OpenAIBaseURL:          getEnv("OPENAI_BASE_URL", ""),
+		OpenAIAPIKey:           getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:            getEnv("OPENAI_MODEL", ""),
+		OpenAIEmbeddingModel:   getEnv("OPENAI_EMBEDDING_MODEL", ""),
+
+		// Offline Fixtures
+		FixtureMode: strings.ToLower(getEnv("FIXTURE_MODE", "off")),
+		FixtureDir:  getEnv("FIXTURE_DIR", "fixtures"),
+
+		// Product Search Providers
+		SearchProvider:          strings.ToLower(getEnv("SEARCH_PROVIDER", "serpapi")),
+		SearchProviderByCountry: getEnvAsMap("SEARCH_PROVIDER_BY_COUNTRY", map[string]string{}),
+
 		// SERP Relevance Thresholds
 		SerpThresholdExact:      getEnvAsFloat("SERP_THRESHOLD_EXACT", 0.4),
 		SerpThresholdParameters: getEnvAsFloat("SERP_THRESHOLD_PARAMETERS", 0.2),
@@ -265,8 +431,22 @@ func Load() (*Config, error) {
 		CacheGeminiTTL:    getEnvAsInt("CACHE_GEMINI_TTL", 3600),
 		CacheSerpTTL:      getEnvAsInt("CACHE_SERP_TTL", 86400),
 		CacheImmersiveTTL: getEnvAsInt("CACHE_IMMERSIVE_TTL", 43200),
+		PriceWatchRecheck:    time.Duration(getEnvAsInt("PRICE_WATCH_RECHECK", 21600)) * time.Second,   // 6 hours
+		PriceWatchBatchSize:  getEnvAsInt("PRICE_WATCH_BATCH_SIZE", 50),
+		PriceWatchMaxPerUser: getEnvAsInt("PRICE_WATCH_MAX_PER_USER", 20),
+		JobsEnabled:             getEnvAsBool("JOBS_ENABLED", true),
+		JobPollInterval:         time.Duration(getEnvAsInt("JOBS_POLL_INTERVAL", 30)) * time.Second,
+		JobHistoryRetentionDays: getEnvAsInt("JOB_HISTORY_RETENTION_DAYS", 30),
+		FXSource:          getEnv("FX_SOURCE", "static"),
+		FXRatesFile:       getEnv("FX_RATES_FILE", "data/fx_rates.json"),
+		FXRatesURL:        getEnv("FX_RATES_URL", "https://api.frankfurter.app/latest?from=EUR"),
+		FXRefreshInterval: time.Duration(getEnvAsInt("FX_REFRESH_INTERVAL", 21600)) * time.Second, // 6 hours
+		SessionInviteTTL:       time.Duration(getEnvAsInt("SESSION_INVITE_TTL", 604800)) * time.Second, // 7 days
+		SessionMaxParticipants: getEnvAsInt("SESSION_MAX_PARTICIPANTS", 5),
 		RateLimitRequests: getEnvAsInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow:   getEnvAsInt("RATE_LIMIT_WINDOW", 60),
+		APIKeyRateLimit:   getEnvAsInt("API_KEY_RATE_LIMIT", 60),
+		APIKeyMaxPerUser:  getEnvAsInt("API_KEY_MAX_PER_USER", 10),
 		CORSOrigins: getEnvAsSlice("CORS_ORIGINS", []string{"http://localhost:3000"}),
 
 		// Email (SMTP)
@@ -278,6 +458,10 @@ func Load() (*Config, error) {
 		SMTPFromName:  getEnv("SMTP_FROM_NAME", "MyLittlePrice"),
 		FrontendURL:   getEnv("FRONTEND_URL", "http://localhost:3000"),
 
+		// Email verification
+		EmailVerificationTTL:    time.Duration(getEnvAsInt("EMAIL_VERIFICATION_TTL", 86400)) * time.Second, // 24 hours
+		RestrictUnverifiedUsers: getEnvAsBool("RESTRICT_UNVERIFIED_USERS", false),
+
 		// Notifications
 		DiscordWebhookURL: os.Getenv("DISCORD_WEBHOOK_URL"),
 		ContactWebhookURL: os.Getenv("CONTACT_WEBHOOK_URL"),
@@ -286,6 +470,26 @@ func Load() (*Config, error) {
 		LokiEnabled:       getEnvAsBool("LOKI_ENABLED", false),
 		LokiURL:           getEnv("LOKI_URL", "http://localhost:3100/loki/api/v1/push"),
 		LokiServiceName:   getEnv("LOKI_SERVICE_NAME", "mylittleprice-backend"),
+
+		TracingEnabled:     getEnvAsBool("TRACING_ENABLED", false),
+		TracingEndpoint:    getEnv("TRACING_ENDPOINT", "http://localhost:4318"),
+		TracingServiceName: getEnv("TRACING_SERVICE_NAME", "mylittleprice-backend"),
+		TracingSampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1.0),
+	}
+
+	config.OIDCProviders = loadOIDCProviders()
+	config.Plans = loadPlans()
+	config.JobSchedules = loadJobSchedules()
+
+	modelPrices, err := loadModelPrices()
+	if err != nil {
+		return nil, err
+	}
+	config.CostModelPrices = modelPrices
+
+	// Fixtures replace SerpAPI unless a provider is chosen explicitly
+	if config.FixtureMode != "off" && os.Getenv("SEARCH_PROVIDER") == "" {
+		config.SearchProvider = "fixture"
 	}
 
 	if err := config.validate(); err != nil {
@@ -296,20 +500,94 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) validate() error {
-	if len(c.GeminiAPIKeys) == 0 {
-		return fmt.Errorf("at least one GEMINI_API_KEY is required")
+	validFixtureModes := map[string]bool{"off": true, "replay": true, "record": true}
+	if !validFixtureModes[c.FixtureMode] {
+		return fmt.Errorf("FIXTURE_MODE must be one of: off, replay, record")
+	}
+
+	// Replay mode runs fully offline: API keys and OAuth credentials are optional
+	if c.FixtureMode != "replay" {
+		if c.UsesLLMProvider("gemini") && len(c.GeminiAPIKeys) == 0 {
+			return fmt.Errorf("at least one GEMINI_API_KEY is required")
+		}
+
+		if len(c.SerpAPIKeys) == 0 {
+			return fmt.Errorf("at least one SERP_API_KEY is required")
+		}
+
+		// Validate Google OAuth config (required for authentication)
+		if c.GoogleClientID == "" {
+			return fmt.Errorf("GOOGLE_CLIENT_ID is required")
+		}
+		if c.GoogleClientSecret == "" {
+			return fmt.Errorf("GOOGLE_CLIENT_SECRET is required")
+		}
+
+		if c.TOTPEncryptionKey == "" {
+			return fmt.Errorf("TOTP_ENCRYPTION_KEY is required")
+		}
+	}
+	if c.TOTPEncryptionKey != "" {
+		if _, err := utils.NewSecretBox(c.TOTPEncryptionKey); err != nil {
+			return fmt.Errorf("TOTP_ENCRYPTION_KEY: %w", err)
+		}
 	}
 
-	if len(c.SerpAPIKeys) == 0 {
-		return fmt.Errorf("at least one SERP_API_KEY is required")
+	// Validate OIDC providers
+	seenOIDCProviders := make(map[string]bool, len(c.OIDCProviders))
+	for _, provider := range c.OIDCProviders {
+		if !validOIDCProviderName(provider.Name) {
+			return fmt.Errorf("OIDC provider name %q must be lowercase letters, digits and dashes and not \"email\"", provider.Name)
+		}
+		if seenOIDCProviders[provider.Name] {
+			return fmt.Errorf("OIDC provider %q is configured twice", provider.Name)
+		}
+		seenOIDCProviders[provider.Name] = true
+
+		prefix := oidcEnvPrefix(provider.Name)
+		if provider.Issuer == "" {
+			return fmt.Errorf("%sISSUER is required", prefix)
+		}
+		if !strings.HasPrefix(provider.Issuer, "https://") && !(c.Env == "development" && strings.HasPrefix(provider.Issuer, "http://")) {
+			return fmt.Errorf("%sISSUER must be an https URL", prefix)
+		}
+		if len(provider.ClientIDs) == 0 {
+			return fmt.Errorf("%sCLIENT_ID is required", prefix)
+		}
 	}
 
-	// Validate Google OAuth config (required for authentication)
-	if c.GoogleClientID == "" {
-		return fmt.Errorf("GOOGLE_CLIENT_ID is required")
+	// Validate LLM providers
+	for _, provider := range c.llmProviders() {
+		if provider != "gemini" && provider != "openai" {
+			return fmt.Errorf("LLM_*_PROVIDER must be one of: [gemini openai], got %q", provider)
+		}
 	}
-	if c.GoogleClientSecret == "" {
-		return fmt.Errorf("GOOGLE_CLIENT_SECRET is required")
+	if c.UsesLLMProvider("openai") {
+		if c.OpenAIBaseURL == "" {
+			return fmt.Errorf("OPENAI_BASE_URL is required when an LLM task uses the openai provider")
+		}
+		for _, provider := range []string{c.LLMDialogueProvider, c.LLMExtractionProvider, c.LLMTranslationProvider} {
+			if c.OpenAIModel == "" && strings.EqualFold(provider, "openai") {
+				return fmt.Errorf("OPENAI_MODEL is required when a generation task uses the openai provider")
+			}
+		}
+		if c.OpenAIEmbeddingModel == "" && strings.EqualFold(c.LLMEmbeddingProvider, "openai") {
+			return fmt.Errorf("OPENAI_EMBEDDING_MODEL is required when LLM_EMBEDDING_PROVIDER=openai")
+		}
+	}
+
+	// Validate API key rotation
+	if _, err := utils.ParseKeyResetWindow(c.GeminiKeyReset); err != nil {
+		return fmt.Errorf("GEMINI_KEY_RESET: %w", err)
+	}
+	if _, err := utils.ParseKeyResetWindow(c.SerpKeyReset); err != nil {
+		return fmt.Errorf("SERP_KEY_RESET: %w", err)
+	}
+	if c.KeyCircuitFailures < 1 {
+		return fmt.Errorf("KEY_CIRCUIT_FAILURES must be at least 1")
+	}
+	if c.KeyCircuitCooldown < time.Second {
+		return fmt.Errorf("KEY_CIRCUIT_COOLDOWN must be at least 1 second")
 	}
 
 	// Validate grounding mode
@@ -330,14 +608,166 @@ func (c *Config) validate() error {
 		return fmt.Errorf("GEMINI_GROUNDING_MIN_WORDS must be between 1 and 10")
 	}
 
-	// Validate max searches
-	if c.MaxSearchesPerSession < 1 || c.MaxSearchesPerSession > 10 {
-		return fmt.Errorf("MAX_SEARCHES_PER_SESSION must be between 1 and 10")
+	validSemanticIndexes := map[string]bool{"auto": true, "redis": true, "memory": true, "off": true}
+	if !validSemanticIndexes[c.CacheSemanticIndex] {
+		return fmt.Errorf("CACHE_SEMANTIC_INDEX must be one of: auto, redis, memory, off")
+	}
+	if c.CacheSemanticThreshold <= 0 || c.CacheSemanticThreshold > 1 {
+		return fmt.Errorf("CACHE_SEMANTIC_THRESHOLD must be greater than 0 and at most 1")
+	}
+
+	// Validate tracing
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	// Validate scheduled jobs (schedules are parsed when the jobs are registered)
+	if c.JobPollInterval < time.Second {
+		return fmt.Errorf("JOBS_POLL_INTERVAL must be at least 1 second")
+	}
+	if c.JobHistoryRetentionDays < 1 {
+		return fmt.Errorf("JOB_HISTORY_RETENTION_DAYS must be at least 1")
+	}
+
+	// Validate cost accounting
+	if c.CostGroundingPrice < 0 || c.CostSerpAPIPrice < 0 {
+		return fmt.Errorf("COST_GROUNDING_PRICE and COST_SERPAPI_PRICE must not be negative")
+	}
+	if c.CostRetentionDays < 1 {
+		return fmt.Errorf("COST_RETENTION_DAYS must be at least 1")
+	}
+
+	// Validate plan limits
+	for name, plan := range c.Plans {
+		limits := map[string]int{
+			"DAILY_SEARCHES":         plan.DailySearches,
+			"DAILY_MESSAGES":         plan.DailyMessages,
+			"DAILY_GROUNDING":        plan.DailyGrounding,
+			"DAILY_PRODUCT_DETAILS":  plan.DailyProductDetails,
+			"WS_MESSAGES_PER_MINUTE": plan.WSMessagesPerMinute,
+		}
+		for limit, value := range limits {
+			if value < -1 {
+				return fmt.Errorf("%s%s must be -1 (unlimited) or greater", planEnvPrefix(name), limit)
+			}
+		}
 	}
 
 	return nil
 }
 
+// UsesLLMProvider reports whether any LLM task is routed to the provider
+func (c *Config) UsesLLMProvider(name string) bool {
+	for _, provider := range c.llmProviders() {
+		if provider == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) llmProviders() []string {
+	return []string{
+		strings.ToLower(c.LLMDialogueProvider),
+		strings.ToLower(c.LLMExtractionProvider),
+		strings.ToLower(c.LLMTranslationProvider),
+		strings.ToLower(c.LLMEmbeddingProvider),
+	}
+}
+
+// loadOIDCProviders reads OIDC_PROVIDERS=apple,keycloak and for each
+// provider OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID (comma-separated),
+// OIDC_<NAME>_DISPLAY_NAME and OIDC_<NAME>_TRUST_EMAIL
+func loadOIDCProviders() []OIDCProviderConfig {
+	names := getEnvAsSlice("OIDC_PROVIDERS", nil)
+	providers := make([]OIDCProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		prefix := oidcEnvPrefix(name)
+		providers = append(providers, OIDCProviderConfig{
+			Name:        name,
+			DisplayName: getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:      getEnv(prefix+"ISSUER", ""),
+			ClientIDs:   getEnvAsSlice(prefix+"CLIENT_ID", nil),
+			TrustEmail:  getEnvAsBool(prefix+"TRUST_EMAIL", false),
+		})
+	}
+	return providers
+}
+
+// loadPlans reads the limits of each plan from PLAN_<NAME>_DAILY_SEARCHES,
+// _DAILY_MESSAGES, _DAILY_GROUNDING, _DAILY_PRODUCT_DETAILS and
+// _WS_MESSAGES_PER_MINUTE
+func loadPlans() map[string]PlanLimits {
+	plans := make(map[string]PlanLimits, len(defaultPlanLimits))
+	for name, defaults := range defaultPlanLimits {
+		if name == "anonymous" {
+			// The anonymous search limit predates plans
+			defaults.DailySearches = getEnvAsInt("ANONYMOUS_SEARCH_LIMIT", defaults.DailySearches)
+		}
+		prefix := planEnvPrefix(name)
+		plans[name] = PlanLimits{
+			DailySearches:       getEnvAsInt(prefix+"DAILY_SEARCHES", defaults.DailySearches),
+			DailyMessages:       getEnvAsInt(prefix+"DAILY_MESSAGES", defaults.DailyMessages),
+			DailyGrounding:      getEnvAsInt(prefix+"DAILY_GROUNDING", defaults.DailyGrounding),
+			DailyProductDetails: getEnvAsInt(prefix+"DAILY_PRODUCT_DETAILS", defaults.DailyProductDetails),
+			WSMessagesPerMinute: getEnvAsInt(prefix+"WS_MESSAGES_PER_MINUTE", defaults.WSMessagesPerMinute),
+		}
+	}
+	return plans
+}
+
+func loadJobSchedules() map[string]string {
+	schedules := make(map[string]string, len(defaultJobSchedules))
+	for name, schedule := range defaultJobSchedules {
+		schedules[name] = strings.TrimSpace(getEnv("JOB_"+strings.ToUpper(name)+"_SCHEDULE", schedule))
+	}
+	return schedules
+}
+
+// loadModelPrices adds the prices of COST_MODEL_PRICES to the defaults.
+// Entries are "model:input/output" in USD per million tokens, e.g.
+// "gemini-2.5-flash:0.30/2.50,gpt-4o-mini:0.15/0.60".
+func loadModelPrices() (map[string]ModelPrice, error) {
+	prices := make(map[string]ModelPrice, len(defaultModelPrices))
+	for model, price := range defaultModelPrices {
+		prices[model] = price
+	}
+
+	for model, value := range getEnvAsMap("COST_MODEL_PRICES", nil) {
+		input, output, ok := strings.Cut(value, "/")
+		inputPrice, inputErr := strconv.ParseFloat(strings.TrimSpace(input), 64)
+		outputPrice, outputErr := strconv.ParseFloat(strings.TrimSpace(output), 64)
+		if !ok || inputErr != nil || outputErr != nil || inputPrice < 0 || outputPrice < 0 {
+			return nil, fmt.Errorf("COST_MODEL_PRICES: invalid price %q for %s, use input/output in USD per million tokens", value, model)
+		}
+		prices[model] = ModelPrice{Input: inputPrice, Output: outputPrice}
+	}
+	return prices, nil
+}
+
+func planEnvPrefix(name string) string {
+	return "PLAN_" + strings.ToUpper(name) + "_"
+}
+
+func oidcEnvPrefix(name string) string {
+	return "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// validOIDCProviderName rejects names that cannot appear in a URL path and
+// "email", which marks password accounts
+func validOIDCProviderName(name string) bool {
+	if name == "" || name == "email" || len(name) > 32 {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -383,6 +813,29 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	return result
 }
 
+// getEnvAsMap parses "key:value,key:value" pairs
+func getEnvAsMap(key string, defaultValue map[string]string) map[string]string {
+	pairs := getEnvAsSlice(key, nil)
+	if len(pairs) == 0 {
+		return defaultValue
+	}
+
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, ":")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if ok && k != "" && v != "" {
+			result[k] = v
+		}
+	}
+
+	if len(result) == 0 {
+		return defaultValue
+	}
+
+	return result
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := getEnv(key, "")
 	if valueStr == "" {
@@ -0,0 +1,63 @@
+package config
+
+import "testing"
+
+func TestLoadPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		plan string
+		want PlanLimits
+	}{
+		{
+			name: "defaults",
+			plan: "free",
+			want: defaultPlanLimits["free"],
+		},
+		{
+			name: "override one limit",
+			env:  map[string]string{"PLAN_PRO_DAILY_SEARCHES": "1000"},
+			plan: "pro",
+			want: PlanLimits{DailySearches: 1000, DailyMessages: 2000, DailyGrounding: 1000, DailyProductDetails: 1000, WSMessagesPerMinute: 120},
+		},
+		{
+			name: "unlimited",
+			env:  map[string]string{"PLAN_FREE_DAILY_GROUNDING": "-1"},
+			plan: "free",
+			want: PlanLimits{DailySearches: 50, DailyMessages: 200, DailyGrounding: -1, DailyProductDetails: 100, WSMessagesPerMinute: 50},
+		},
+		{
+			name: "legacy anonymous search limit",
+			env:  map[string]string{"ANONYMOUS_SEARCH_LIMIT": "5"},
+			plan: "anonymous",
+			want: PlanLimits{DailySearches: 5, DailyMessages: 30, DailyGrounding: 10, DailyProductDetails: 20, WSMessagesPerMinute: 20},
+		},
+		{
+			name: "plan variable wins over the legacy one",
+			env:  map[string]string{"ANONYMOUS_SEARCH_LIMIT": "5", "PLAN_ANONYMOUS_DAILY_SEARCHES": "7"},
+			plan: "anonymous",
+			want: PlanLimits{DailySearches: 7, DailyMessages: 30, DailyGrounding: 10, DailyProductDetails: 20, WSMessagesPerMinute: 20},
+		},
+		{
+			name: "invalid number keeps the default",
+			env:  map[string]string{"PLAN_PRO_WS_MESSAGES_PER_MINUTE": "lots"},
+			plan: "pro",
+			want: defaultPlanLimits["pro"],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			plans := loadPlans()
+			if len(plans) != len(defaultPlanLimits) {
+				t.Errorf("loaded %d plans, want %d", len(plans), len(defaultPlanLimits))
+			}
+			if got := plans[tt.plan]; got != tt.want {
+				t.Errorf("plan %s = %+v, want %+v", tt.plan, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,13 +12,15 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"github.com/redis/go-redis/v9/maintnotifications"
-	"google.golang.org/genai"
 
 	"mylittleprice/ent"
 	"mylittleprice/internal/config"
+	"mylittleprice/internal/fixtures"
+	"mylittleprice/internal/jobs"
 	"mylittleprice/internal/metrics"
 	"mylittleprice/internal/middleware"
 	"mylittleprice/internal/services"
+	"mylittleprice/internal/tracing"
 	"mylittleprice/internal/utils"
 )
 
@@ -33,20 +35,38 @@ type Container struct {
 	GeminiRotator *utils.KeyRotator
 	SerpRotator   *utils.KeyRotator
 	JWTService    *utils.JWTService
+	Fixtures      *fixtures.Store // Offline cassettes (nil when FIXTURE_MODE=off)
 
+	LLMProviders            *services.LLMRegistry
 	EmbeddingService        *services.EmbeddingService
 	GeminiService           *services.GeminiService
 	SerpService             *services.SerpService
+	SearchProviders         *services.SearchProviderRegistry
 	CacheService            *services.CacheService
 	SessionService          *services.SessionService
+	SessionShareService     *services.SessionShareService
+	SessionSnapshotService  *services.SessionSnapshotService
 	MessageService          *services.MessageService
 	CycleService            *services.CycleService
 	GoogleOAuthService      *services.GoogleOAuthService
+	OIDCService             *services.OIDCService
 	AuthService             *services.AuthService
+	UsageService            *services.UsageService
 	EmailService            *services.EmailService
 	SearchHistoryService    *services.SearchHistoryService
+	PriceHistoryService     *services.PriceHistoryService
+	FXService               *services.FXService
 	PreferencesService      *services.PreferencesService
 	CleanupService          *services.CleanupService
+	WatchService            *services.WatchService
+	AdminService            *services.AdminService
+	AuditService            *services.AuditService
+	CostService             *services.CostService
+	AccountService          *services.AccountService
+	APIKeyService           *services.APIKeyService
+	PubSubService           *services.PubSubService // Publisher for background jobs (WS handlers own their subscriber)
+	JobRunService           *services.JobRunService
+	Scheduler               *jobs.Scheduler // Scheduled data retention and price watch jobs, started by main
 	SessionOwnershipChecker *middleware.SessionOwnershipValidator
 }
 
@@ -91,7 +111,7 @@ func (c *Container) initDatabase() error {
 
 	// Create Ent client with the SQL driver
 	drv := entsql.OpenDB(dialect.Postgres, sqlDB)
-	entClient := ent.NewClient(ent.Driver(drv))
+	entClient := ent.NewClient(ent.Driver(tracing.NewDriver(drv)))
 
 	// Run auto migrations
 	if err := entClient.Schema.Create(c.ctx); err != nil {
@@ -135,6 +155,9 @@ func (c *Container) initRedis() error {
 		},
 	})
 
+	// Commands made while serving a traced request get spans
+	c.Redis.AddHook(tracing.RedisHook{})
+
 	// Health check with context timeout
 	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
@@ -157,6 +180,11 @@ func (c *Container) initKeyRotators() error {
 		"gemini",
 		c.Config.GeminiAPIKeys,
 		c.Redis,
+		utils.KeyRotatorConfig{
+			ResetWindow:     c.Config.GeminiKeyReset,
+			CircuitFailures: c.Config.KeyCircuitFailures,
+			CircuitCooldown: c.Config.KeyCircuitCooldown,
+		},
 	)
 
 	c.SerpRotator = utils.NewKeyRotator(
@@ -164,11 +192,42 @@ func (c *Container) initKeyRotators() error {
 		"serp",
 		c.Config.SerpAPIKeys,
 		c.Redis,
+		utils.KeyRotatorConfig{
+			ResetWindow:     c.Config.SerpKeyReset,
+			CircuitFailures: c.Config.KeyCircuitFailures,
+			CircuitCooldown: c.Config.KeyCircuitCooldown,
+		},
+	)
+
+	utils.LogInfo(c.ctx, "Gemini key rotator initialized",
+		slog.Int("total_keys", c.GeminiRotator.GetTotalKeys()),
+		slog.String("reset_window", c.Config.GeminiKeyReset),
+	)
+	utils.LogInfo(c.ctx, "SERP key rotator initialized",
+		slog.Int("total_keys", c.SerpRotator.GetTotalKeys()),
+		slog.String("reset_window", c.Config.SerpKeyReset),
 	)
 
-	utils.LogInfo(c.ctx, "Gemini key rotator initialized", slog.Int("total_keys", c.GeminiRotator.GetTotalKeys()))
-	utils.LogInfo(c.ctx, "SERP key rotator initialized", slog.Int("total_keys", c.SerpRotator.GetTotalKeys()))
+	return nil
+}
 
+// initLLMProviders registers the providers used by at least one task and
+// checks that every task has one
+func (c *Container) initLLMProviders() error {
+	registry, err := services.NewLLMRegistryFromConfig(c.Config, c.GeminiRotator, c.Fixtures)
+	if err != nil {
+		return err
+	}
+	c.LLMProviders = registry
+	c.LLMProviders.SetCostRecorder(c.CostService)
+
+	utils.LogInfo(c.ctx, "LLM providers initialized",
+		slog.Any("registered", c.LLMProviders.Names()),
+		slog.String("dialogue", c.Config.LLMDialogueProvider),
+		slog.String("extraction", c.Config.LLMExtractionProvider),
+		slog.String("translation", c.Config.LLMTranslationProvider),
+		slog.String("embedding", c.Config.LLMEmbeddingProvider),
+	)
 	return nil
 }
 
@@ -186,14 +245,30 @@ func (c *Container) initServices() error {
 	c.GoogleOAuthService = services.NewGoogleOAuthService(c.Config)
 	utils.LogInfo(c.ctx, "Google OAuth service initialized")
 
+	// Initialize OIDC Service (discovery and keys load on first login)
+	c.OIDCService = services.NewOIDCService(c.Config)
+	utils.LogInfo(c.ctx, "OIDC service initialized", slog.Int("providers", len(c.Config.OIDCProviders)))
+
 	// Initialize Email Service
 	c.EmailService = services.NewEmailService(c.Config)
 	utils.LogInfo(c.ctx, "Email service initialized")
 
 	// Initialize Auth Service
-	c.AuthService = services.NewAuthService(c.Ent, c.Redis, c.JWTService, c.GoogleOAuthService)
+	c.AuthService = services.NewAuthService(c.Ent, c.Redis, c.JWTService, c.GoogleOAuthService, c.OIDCService)
+	c.AuthService.SetEmailVerificationTTL(c.Config.EmailVerificationTTL)
+	if c.Config.TOTPEncryptionKey != "" {
+		totpBox, err := utils.NewSecretBox(c.Config.TOTPEncryptionKey)
+		if err != nil {
+			return fmt.Errorf("invalid TOTP_ENCRYPTION_KEY: %w", err)
+		}
+		c.AuthService.SetTOTPSecretBox(totpBox)
+	}
 	utils.LogInfo(c.ctx, "Auth service initialized")
 
+	// Initialize Usage Service (daily allowances of usage plans)
+	c.UsageService = services.NewUsageService(c.Redis, c.AuthService, c.Config)
+	utils.LogInfo(c.ctx, "Usage service initialized")
+
 	// Initialize CycleService (no dependencies)
 	c.CycleService = services.NewCycleService()
 	utils.LogInfo(c.ctx, "Cycle service initialized")
@@ -208,44 +283,104 @@ func (c *Container) initServices() error {
 		c.Ent,
 		c.CycleService,
 		c.Config.SessionTTL,
-		c.Config.MaxMessagesPerSession,
 	)
 	c.SessionService.SetAuthService(c.AuthService)
 	utils.LogInfo(c.ctx, "Session service initialized")
 
-	apiKey, _, _ := c.GeminiRotator.GetNextKey()
-	geminiClient, _ := genai.NewClient(c.ctx, &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI,
-	})
+	// Initialize Cost Service (prices and records paid LLM and SerpAPI calls)
+	c.CostService = services.NewCostService(c.Ent, c.Config)
+	utils.LogInfo(c.ctx, "Cost service initialized", slog.Int("priced_models", len(c.Config.CostModelPrices)))
+
+	if mode := fixtures.ParseMode(c.Config.FixtureMode); mode != fixtures.ModeOff {
+		c.Fixtures = fixtures.NewStore(c.Config.FixtureDir, mode)
+		utils.LogWarn(c.ctx, "External APIs served from fixtures",
+			slog.String("mode", string(mode)),
+			slog.String("dir", c.Config.FixtureDir),
+		)
+	}
+
+	if err := c.initLLMProviders(); err != nil {
+		return err
+	}
 
-	c.EmbeddingService = services.NewEmbeddingService(geminiClient, c.Redis, c.Config)
+	c.EmbeddingService = services.NewEmbeddingService(c.LLMProviders, c.Redis, c.Config)
 	utils.LogInfo(c.ctx, "Embedding service initialized")
 
 	c.CacheService = services.NewCacheService(c.Redis, c.Config, c.EmbeddingService)
 
-	c.GeminiService = services.NewGeminiService(c.GeminiRotator, c.Config, c.EmbeddingService)
+	c.GeminiService = services.NewGeminiService(c.LLMProviders, c.Config, c.EmbeddingService)
 	utils.LogInfo(c.ctx, "Smart grounding configured",
 		slog.String("mode", c.Config.GeminiGroundingMode),
 		slog.Bool("enabled", c.Config.GeminiUseGrounding),
 	)
 
-	c.SerpService = services.NewSerpService(c.SerpRotator, c.Config)
+	c.SearchProviders = services.NewSearchProviderRegistry(c.Config.SearchProvider, c.Config.SearchProviderByCountry)
+	serpAPIProvider := services.NewSerpAPIProvider(c.SerpRotator, c.CostService)
+	c.SearchProviders.Register(serpAPIProvider)
+	if c.Fixtures != nil {
+		c.SearchProviders.Register(services.NewFixtureSearchProvider(c.Fixtures, serpAPIProvider))
+	}
+	if _, err := c.SearchProviders.Default(); err != nil {
+		return fmt.Errorf("failed to configure search providers: %w", err)
+	}
+	utils.LogInfo(c.ctx, "Search providers initialized",
+		slog.String("default", c.Config.SearchProvider),
+		slog.Any("registered", c.SearchProviders.Names()),
+	)
+
+	c.SerpService = services.NewSerpService(c.SearchProviders, c.Config)
 
 	c.SearchHistoryService = services.NewSearchHistoryService(c.Ent)
 	utils.LogInfo(c.ctx, "Search history service initialized")
 
+	c.PriceHistoryService = services.NewPriceHistoryService(c.Ent)
+	utils.LogInfo(c.ctx, "Price history service initialized")
+
+	c.FXService = services.NewFXService(c.Config)
+	utils.LogInfo(c.ctx, "FX service initialized", slog.String("source", c.Config.FXSource))
+
 	c.PreferencesService = services.NewPreferencesService(c.Ent, c.AuthService)
 	utils.LogInfo(c.ctx, "Preferences service initialized")
 
 	c.CleanupService = services.NewCleanupService(c.Ent)
 	utils.LogInfo(c.ctx, "Cleanup service initialized")
 
-	// Start periodic cleanup (runs daily at 3 AM)
-	c.CleanupService.StartPeriodicCleanup(24 * time.Hour)
+	c.WatchService = services.NewWatchService(c.Ent, c.Redis, c.AuthService, c.Config)
+	utils.LogInfo(c.ctx, "Watch service initialized")
+
+	c.AdminService = services.NewAdminService(c.Ent, c.Redis)
+	utils.LogInfo(c.ctx, "Admin service initialized")
+
+	c.SessionShareService = services.NewSessionShareService(c.Ent, c.Redis, c.SessionService, c.AuthService, c.Config)
+	utils.LogInfo(c.ctx, "Session sharing service initialized")
+
+	c.SessionSnapshotService = services.NewSessionSnapshotService(c.Ent, c.SessionService, c.MessageService, c.AuthService, c.Config)
+	utils.LogInfo(c.ctx, "Session snapshot service initialized")
+
+	c.AuditService = services.NewAuditService(c.Ent)
+	c.AccountService = services.NewAccountService(c.Ent, c.AuthService, c.SessionService, c.MessageService, c.PreferencesService, c.SearchHistoryService, c.WatchService, c.SessionSnapshotService, c.AuditService)
+	utils.LogInfo(c.ctx, "Account service initialized")
+
+	c.APIKeyService = services.NewAPIKeyService(c.Ent, c.AuthService, c.Config)
+	utils.LogInfo(c.ctx, "API key service initialized")
+
+	c.PubSubService = services.NewPubSubService(c.Redis)
+
+	c.JobRunService = services.NewJobRunService(c.Ent, c.Config)
+	c.Scheduler = jobs.NewScheduler(c.JobRunService, c.Redis, c.Config)
+	scheduled := append(
+		jobs.CleanupJobs(c.SearchHistoryService, c.CleanupService, c.CostService, c.JobRunService),
+		jobs.PriceWatchJob(c.WatchService, c.SerpService, c.CacheService, c.AuthService, c.EmailService, c.PubSubService, c.Config),
+	)
+	for _, job := range scheduled {
+		if err := c.Scheduler.Register(job); err != nil {
+			return fmt.Errorf("failed to register job: %w", err)
+		}
+	}
+	utils.LogInfo(c.ctx, "Job scheduler initialized")
 
 	// Initialize Session Ownership Validator
-	c.SessionOwnershipChecker = middleware.NewSessionOwnershipValidator(&services.SessionAdapter{SessionService: c.SessionService}, c.Config.JWTAccessSecret)
+	c.SessionOwnershipChecker = middleware.NewSessionOwnershipValidator(&services.SessionAdapter{SessionService: c.SessionService, Shares: c.SessionShareService}, c.Config.JWTAccessSecret)
 	utils.LogInfo(c.ctx, "Session ownership validation initialized")
 
 	utils.LogInfo(c.ctx, "all services initialized")
@@ -255,6 +390,15 @@ func (c *Container) initServices() error {
 func (c *Container) Close() error {
 	utils.LogInfo(c.ctx, "shutting down container")
 
+	if c.PubSubService != nil {
+		c.PubSubService.Close()
+	}
+
+	// Write pending cost records before the database goes away
+	if c.CostService != nil {
+		c.CostService.Close()
+	}
+
 	// Close Ent client
 	if c.Ent != nil {
 		if err := c.Ent.Close(); err != nil {
@@ -277,10 +421,12 @@ func (c *Container) Close() error {
 	return nil
 }
 
-// RegisterMetrics registers all WebSocket and Session metrics
+// RegisterMetrics registers all WebSocket, Session, Cost and Job metrics
 func (c *Container) RegisterMetrics() {
 	metrics.RegisterWebSocketMetrics()
 	metrics.RegisterSessionMetrics()
+	metrics.RegisterCostMetrics()
+	metrics.RegisterJobMetrics()
 }
 
 func (c *Container) HealthCheck() map[string]interface{} {
@@ -0,0 +1,96 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Report is the result of one eval run. Reports are written per prompt
+// hash, so runs of different prompt versions can be compared side by side.
+type Report struct {
+	PromptID    string          `json:"prompt_id"`
+	PromptHash  string          `json:"prompt_hash"`
+	Provider    string          `json:"provider"`
+	Model       string          `json:"model"`
+	FixtureMode string          `json:"fixture_mode"` // "off" = live provider
+	StartedAt   time.Time       `json:"started_at"`
+	DurationMs  int64           `json:"duration_ms"`
+	Total       int             `json:"total"`
+	Passed      int             `json:"passed"`
+	Failed      int             `json:"failed"`
+	Scripts     []*ScriptResult `json:"scripts"`
+}
+
+// ScriptResult is the outcome of one script
+type ScriptResult struct {
+	Name       string       `json:"name"`
+	File       string       `json:"file"`
+	Passed     bool         `json:"passed"`
+	Failures   []string     `json:"failures,omitempty"`
+	Turns      []TurnResult `json:"turns"`
+	DurationMs int64        `json:"duration_ms"`
+}
+
+// TurnResult is the model's answer to one turn and the failed checks
+type TurnResult struct {
+	Turn         int      `json:"turn"`
+	User         string   `json:"user"`
+	Tool         string   `json:"tool,omitempty"` // First tool called by the model
+	Category     string   `json:"category,omitempty"`
+	SearchPhrase string   `json:"search_phrase,omitempty"`
+	Output       string   `json:"output,omitempty"`
+	Passed       bool     `json:"passed"`
+	Failures     []string `json:"failures,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+func (r *Report) add(result *ScriptResult) {
+	r.Scripts = append(r.Scripts, result)
+	r.Total++
+	if result.Passed {
+		r.Passed++
+	} else {
+		r.Failed++
+	}
+}
+
+// Write saves the report as <dir>/<prompt hash>.json, replacing the
+// previous run of the same prompt
+func (r *Report) Write(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	path := filepath.Join(dir, r.PromptHash+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return path, nil
+}
+
+// PrintSummary writes a human readable summary with every failure
+func (r *Report) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "\n📊 Prompt %s (%s)\n", r.PromptID, r.PromptHash)
+	fmt.Fprintf(w, "   Provider: %s / %s, fixtures: %s\n", r.Provider, r.Model, r.FixtureMode)
+
+	for _, script := range r.Scripts {
+		if script.Passed {
+			continue
+		}
+		fmt.Fprintf(w, "\n❌ %s (%s)\n", script.Name, script.File)
+		for _, failure := range script.Failures {
+			fmt.Fprintf(w, "   - %s\n", failure)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d/%d scripts passed in %.1fs\n", r.Passed, r.Total, float64(r.DurationMs)/1000)
+}
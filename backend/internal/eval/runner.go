@@ -0,0 +1,240 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// Runner drives scripts through GeminiService.StartDialogue and scores the
+// first tool call of every turn. Sessions are kept in memory and advanced
+// the way ChatProcessor does (cycle history, iterations, category); tools
+// are not executed.
+type Runner struct {
+	gemini *services.GeminiService
+	cycles *services.CycleService
+	llm    *services.LLMRegistry
+	config *config.Config
+}
+
+func NewRunner(gemini *services.GeminiService, llm *services.LLMRegistry, cfg *config.Config) *Runner {
+	return &Runner{
+		gemini: gemini,
+		cycles: services.NewCycleService(),
+		llm:    llm,
+		config: cfg,
+	}
+}
+
+// Run runs every script and returns the report
+func (r *Runner) Run(scripts []*Script) *Report {
+	upm := r.cycles.GetUniversalPromptManager()
+	report := &Report{
+		PromptID:    upm.GetPromptID(),
+		PromptHash:  upm.GetPromptHash(),
+		FixtureMode: r.config.FixtureMode,
+		StartedAt:   time.Now(),
+	}
+	if _, route, err := r.llm.ForTask(services.LLMTaskDialogue); err == nil {
+		report.Provider = route.Provider
+		report.Model = route.Model
+	}
+
+	for _, script := range scripts {
+		fmt.Printf("▶️  %s\n", script.Name)
+		result := r.runScript(script)
+		report.add(result)
+
+		status := "✅ PASS"
+		if !result.Passed {
+			status = "❌ FAIL"
+		}
+		fmt.Printf("%s %s (%d turns, %dms)\n", status, script.Name, len(result.Turns), result.DurationMs)
+	}
+
+	report.DurationMs = time.Since(report.StartedAt).Milliseconds()
+	return report
+}
+
+func (r *Runner) runScript(script *Script) *ScriptResult {
+	start := time.Now()
+	result := &ScriptResult{
+		Name: script.Name,
+		File: script.File,
+	}
+
+	session := r.newSession(script)
+	searchedAt := 0 // Turn of the first search_products call
+
+	for i, turn := range script.Turns {
+		turnResult := r.runTurn(session, i+1, &turn)
+		result.Turns = append(result.Turns, turnResult)
+
+		if turnResult.Error != "" {
+			// The conversation can't continue without an answer
+			result.Failures = append(result.Failures, fmt.Sprintf("turn %d: %s", i+1, turnResult.Error))
+			break
+		}
+		for _, failure := range turnResult.Failures {
+			result.Failures = append(result.Failures, fmt.Sprintf("turn %d: %s", i+1, failure))
+		}
+
+		if searchedAt == 0 && turnResult.Tool == services.ToolSearchProducts {
+			searchedAt = i + 1
+		}
+	}
+
+	if limit := script.MaxIterationsBeforeSearch; limit > 0 {
+		switch {
+		case searchedAt == 0:
+			result.Failures = append(result.Failures, fmt.Sprintf("no search within %d iterations", limit))
+		case searchedAt > limit:
+			result.Failures = append(result.Failures, fmt.Sprintf("first search at iteration %d, expected by %d", searchedAt, limit))
+		}
+	}
+
+	result.Passed = len(result.Failures) == 0
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+func (r *Runner) runTurn(session *models.ChatSession, index int, turn *Turn) TurnResult {
+	result := TurnResult{
+		Turn: index,
+		User: turn.User,
+	}
+
+	r.cycles.AddToCycleHistoryInMemory(session, "user", turn.User)
+	session.MessageCount++
+
+	ctx := context.Background()
+	calls, err := r.gemini.StartDialogue(ctx, turn.User, session).Next(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	call := &calls[0]
+	result.Tool = call.Name
+
+	switch call.Name {
+	case services.ToolSearchProducts:
+		var args services.SearchProductsArgs
+		if err := call.DecodeArgs(&args); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Category = args.Category
+		result.SearchPhrase = args.Query
+	case services.ToolAskClarification, services.ToolFinishCycle:
+		var answer services.DialogueAnswer
+		if err := call.DecodeArgs(&answer); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Category = answer.Category
+		result.Output = answer.Message
+	}
+
+	result.Failures = checkExpectation(&turn.Expect, &result)
+	result.Passed = len(result.Failures) == 0
+
+	r.advanceSession(session, &result)
+	return result
+}
+
+// advanceSession applies a turn to the session like ChatProcessor
+func (r *Runner) advanceSession(session *models.ChatSession, result *TurnResult) {
+	if result.Category != "" {
+		session.SearchState.Category = result.Category
+	}
+	if result.Tool == services.ToolSearchProducts {
+		session.SearchState.SearchCount++
+	}
+
+	r.cycles.AddToCycleHistoryInMemory(session, "assistant", result.Output)
+	session.MessageCount++
+
+	newCycle := r.cycles.IncrementCycleIterationInMemory(session)
+	if newCycle || result.Tool == services.ToolFinishCycle {
+		var products []models.ProductInfo
+		if session.SearchState.LastProduct != nil {
+			products = append(products, *session.SearchState.LastProduct)
+		}
+		r.cycles.StartNewCycleInMemory(session, lastUserMessage(session), products)
+	}
+}
+
+func (r *Runner) newSession(script *Script) *models.ChatSession {
+	country, language, currency := script.Country, script.Language, script.Currency
+	if country == "" {
+		country = r.config.DefaultCountry
+	}
+	if language == "" {
+		language = r.config.DefaultLanguage
+	}
+	if currency == "" {
+		currency = r.config.DefaultCurrency
+	}
+
+	now := time.Now()
+	return &models.ChatSession{
+		ID:           uuid.New(),
+		SessionID:    "eval-" + uuid.New().String(),
+		CountryCode:  country,
+		LanguageCode: language,
+		Currency:     currency,
+		SearchState: models.SearchState{
+			Status: models.SearchStatusIdle,
+		},
+		CycleState: r.cycles.InitializeCycleState(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+}
+
+func checkExpectation(expect *Expectation, result *TurnResult) []string {
+	var failures []string
+
+	if expect.Tool != "" && !matchesAlternative(expect.Tool, result.Tool) {
+		failures = append(failures, fmt.Sprintf("tool: expected %s, got %q", expect.Tool, result.Tool))
+	}
+	if expect.Category != "" && !strings.EqualFold(expect.Category, result.Category) {
+		failures = append(failures, fmt.Sprintf("category: expected %q, got %q", expect.Category, result.Category))
+	}
+	if expect.searchPhrase != nil && !expect.searchPhrase.MatchString(result.SearchPhrase) {
+		failures = append(failures, fmt.Sprintf("search_phrase: %q does not match /%s/", result.SearchPhrase, expect.SearchPhrase))
+	}
+	if expect.output != nil && !expect.output.MatchString(result.Output) {
+		failures = append(failures, fmt.Sprintf("output does not match /%s/", expect.Output))
+	}
+
+	return failures
+}
+
+func matchesAlternative(expected, actual string) bool {
+	for _, alternative := range strings.Split(expected, "|") {
+		if strings.EqualFold(strings.TrimSpace(alternative), actual) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastUserMessage(session *models.ChatSession) string {
+	history := session.CycleState.CycleHistory
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == "user" {
+			return history[i].Content
+		}
+	}
+	return ""
+}
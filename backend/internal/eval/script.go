@@ -0,0 +1,133 @@
+// Package eval scores the universal prompt against scripted conversations,
+// so prompt edits can be checked before deploy.
+package eval
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Script is a conversation replayed turn by turn through the universal
+// prompt, with the outcomes expected from the model
+type Script struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Country     string `yaml:"country"`  // Defaults to DEFAULT_COUNTRY
+	Language    string `yaml:"language"` // Defaults to DEFAULT_LANGUAGE
+	Currency    string `yaml:"currency"` // Defaults to DEFAULT_CURRENCY
+
+	// MaxIterationsBeforeSearch fails the script unless the model calls
+	// search_products within this many turns (0 = no limit)
+	MaxIterationsBeforeSearch int `yaml:"max_iterations_before_search"`
+
+	Turns []Turn `yaml:"turns"`
+
+	File string `yaml:"-"`
+}
+
+// Turn is one user message and what the model should answer
+type Turn struct {
+	User   string      `yaml:"user"`
+	Expect Expectation `yaml:"expect"`
+}
+
+// Expectation lists the checks for one response. Empty fields are not checked.
+type Expectation struct {
+	// Tool is the first tool the model calls, or alternatives separated
+	// by "|" (e.g. "ask_clarification|finish_cycle")
+	Tool         string `yaml:"tool"`
+	Category     string `yaml:"category"`
+	SearchPhrase string `yaml:"search_phrase"` // Regex on the search_products query
+	Output       string `yaml:"output"`        // Regex on the answer message
+
+	searchPhrase *regexp.Regexp
+	output       *regexp.Regexp
+}
+
+// LoadScripts reads every *.yaml / *.yml script in dir, sorted by file name
+func LoadScripts(dir string) ([]*Script, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid scripts directory: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no eval scripts found in %s", dir)
+	}
+
+	scripts := make([]*Script, 0, len(files))
+	for _, file := range files {
+		script, err := loadScript(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		scripts = append(scripts, script)
+	}
+	return scripts, nil
+}
+
+func loadScript(file string) (*Script, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+
+	// Unknown keys are usually typos in expectations - reject them
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var script Script
+	if err := decoder.Decode(&script); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	script.File = file
+
+	if script.Name == "" {
+		script.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if len(script.Turns) == 0 {
+		return nil, fmt.Errorf("script has no turns")
+	}
+	if script.MaxIterationsBeforeSearch < 0 {
+		return nil, fmt.Errorf("max_iterations_before_search must not be negative")
+	}
+
+	for i := range script.Turns {
+		turn := &script.Turns[i]
+		if strings.TrimSpace(turn.User) == "" {
+			return nil, fmt.Errorf("turn %d: user message is required", i+1)
+		}
+		if err := turn.Expect.compile(); err != nil {
+			return nil, fmt.Errorf("turn %d: %w", i+1, err)
+		}
+	}
+
+	return &script, nil
+}
+
+func (e *Expectation) compile() error {
+	var err error
+	if e.SearchPhrase != "" {
+		if e.searchPhrase, err = regexp.Compile(e.SearchPhrase); err != nil {
+			return fmt.Errorf("invalid search_phrase regex: %w", err)
+		}
+	}
+	if e.Output != "" {
+		if e.output, err = regexp.Compile(e.Output); err != nil {
+			return fmt.Errorf("invalid output regex: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// backend/internal/fixtures/cassette.go
+package fixtures
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode controls how external API calls are served
+type Mode string
+
+const (
+	ModeOff    Mode = "off"    // Live calls only
+	ModeReplay Mode = "replay" // Serve cassettes from disk, never touch the network
+	ModeRecord Mode = "record" // Make live calls and write cassettes to disk
+)
+
+// ReplayAPIKey is a placeholder credential for SDK clients in replay mode
+const ReplayAPIKey = "fixture-replay"
+
+// ParseMode converts a config string to a Mode (unknown values mean off)
+func ParseMode(value string) Mode {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case ModeReplay:
+		return ModeReplay
+	case ModeRecord:
+		return ModeRecord
+	default:
+		return ModeOff
+	}
+}
+
+// Cassette is one recorded request/response pair.
+//
+// Recorded cassettes are looked up by Key. Hand-written cassettes can
+// instead list Match substrings: the cassette is used for any request
+// whose body contains all of them (case-insensitive). A cassette named
+// default.json is used when nothing else matches.
+type Cassette struct {
+	Key        string            `json:"key,omitempty"`
+	Match      []string          `json:"match,omitempty"`
+	Status     int               `json:"status,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Request    json.RawMessage   `json:"request,omitempty"`
+	Response   json.RawMessage   `json:"response,omitempty"` // JSON bodies
+	Body       string            `json:"body,omitempty"`     // Non-JSON bodies (e.g. SSE streams)
+	RecordedAt time.Time         `json:"recorded_at,omitempty"`
+}
+
+// ResponseBody returns the raw response bytes
+func (c *Cassette) ResponseBody() []byte {
+	if len(c.Response) > 0 {
+		return c.Response
+	}
+	return []byte(c.Body)
+}
+
+// SetResponseBody stores body as JSON when possible, otherwise as a string
+func (c *Cassette) SetResponseBody(body []byte) {
+	if json.Valid(body) {
+		c.Response = append(json.RawMessage(nil), body...)
+		c.Body = ""
+		return
+	}
+	c.Response = nil
+	c.Body = string(body)
+}
+
+// Store reads and writes cassettes under a directory, one
+// sub-directory per kind (e.g. "gemini/generateContent")
+type Store struct {
+	dir  string
+	mode Mode
+
+	mu    sync.RWMutex
+	cache map[string][]*Cassette // kind -> matchable cassettes
+}
+
+func NewStore(dir string, mode Mode) *Store {
+	return &Store{
+		dir:   dir,
+		mode:  mode,
+		cache: make(map[string][]*Cassette),
+	}
+}
+
+func (s *Store) Mode() Mode {
+	return s.mode
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Key builds a stable cassette key from request parts
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// Lookup finds the cassette for a request: exact key first, then
+// Match-based cassettes (most specific first), then default.json
+func (s *Store) Lookup(kind, key string, request []byte) (*Cassette, error) {
+	if cassette, err := s.readFile(filepath.Join(s.kindDir(kind), key+".json")); err == nil {
+		return cassette, nil
+	}
+
+	cassettes, err := s.loadMatchable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	haystack := strings.ToLower(string(request))
+	for _, cassette := range cassettes {
+		if matchesAll(haystack, cassette.Match) {
+			return cassette, nil
+		}
+	}
+
+	if cassette, err := s.readFile(filepath.Join(s.kindDir(kind), "default.json")); err == nil {
+		return cassette, nil
+	}
+
+	return nil, fmt.Errorf("no cassette for %s (key %s) in %s", kind, key, s.dir)
+}
+
+// Save writes a recorded cassette to <dir>/<kind>/<key>.json
+func (s *Store) Save(kind string, cassette *Cassette) error {
+	if cassette.Key == "" {
+		return fmt.Errorf("cassette key is required")
+	}
+	if cassette.RecordedAt.IsZero() {
+		cassette.RecordedAt = time.Now().UTC()
+	}
+
+	dir := s.kindDir(kind)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	path := filepath.Join(dir, cassette.Key+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	fmt.Printf("   📼 Recorded cassette %s/%s\n", kind, cassette.Key)
+	return nil
+}
+
+func (s *Store) kindDir(kind string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(kind))
+}
+
+func (s *Store) readFile(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// loadMatchable reads (once) all cassettes of a kind that declare Match
+func (s *Store) loadMatchable(kind string) ([]*Cassette, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[kind]
+	s.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.kindDir(kind), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	cassettes := []*Cassette{}
+	for _, path := range paths {
+		cassette, err := s.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(cassette.Match) > 0 {
+			cassettes = append(cassettes, cassette)
+		}
+	}
+
+	// More specific cassettes win
+	sort.SliceStable(cassettes, func(i, j int) bool {
+		return len(cassettes[i].Match) > len(cassettes[j].Match)
+	})
+
+	s.mu.Lock()
+	s.cache[kind] = cassettes
+	s.mu.Unlock()
+
+	return cassettes, nil
+}
+
+func matchesAll(haystack string, needles []string) bool {
+	for _, needle := range needles {
+		if !strings.Contains(haystack, strings.ToLower(needle)) {
+			return false
+		}
+	}
+	return true
+}
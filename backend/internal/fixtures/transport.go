@@ -0,0 +1,136 @@
+// backend/internal/fixtures/transport.go
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport is an http.RoundTripper that replays or records cassettes.
+// It sits under SDK clients (e.g. genai) so the calling code is unchanged.
+type Transport struct {
+	store  *Store
+	prefix string            // Cassette kind prefix, e.g. "gemini"
+	base   http.RoundTripper // Live transport used in record mode
+}
+
+func NewTransport(store *Store, prefix string, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		store:  store,
+		prefix: prefix,
+		base:   base,
+	}
+}
+
+// NewHTTPClient returns an *http.Client backed by a cassette Transport
+func NewHTTPClient(store *Store, prefix string) *http.Client {
+	return &http.Client{Transport: NewTransport(store, prefix, nil)}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	model, operation := splitModelOperation(req.URL.Path)
+	kind := t.prefix + "/" + operation
+	key := Key(req.Method, model, operation, string(body))
+
+	if t.store.Mode() != ModeRecord {
+		cassette, err := t.store.Lookup(kind, key, body)
+		if err != nil {
+			return nil, err
+		}
+		return cassetteResponse(req, cassette, operation), nil
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Only successful responses are worth replaying
+	if resp.StatusCode < 300 {
+		cassette := &Cassette{
+			Key:     key,
+			Status:  resp.StatusCode,
+			Headers: map[string]string{"Content-Type": resp.Header.Get("Content-Type")},
+		}
+		if json.Valid(body) {
+			cassette.Request = body
+		}
+		cassette.SetResponseBody(respBody)
+
+		if err := t.store.Save(kind, cassette); err != nil {
+			fmt.Printf("   ⚠️ Failed to save cassette: %v\n", err)
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+	return resp, nil
+}
+
+func cassetteResponse(req *http.Request, cassette *Cassette, operation string) *http.Response {
+	status := cassette.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := make(http.Header)
+	for k, v := range cassette.Headers {
+		if v != "" {
+			header.Set(k, v)
+		}
+	}
+	if header.Get("Content-Type") == "" {
+		if strings.HasPrefix(operation, "stream") {
+			header.Set("Content-Type", "text/event-stream")
+		} else {
+			header.Set("Content-Type", "application/json")
+		}
+	}
+
+	body := cassette.ResponseBody()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// splitModelOperation parses ".../models/<model>:<operation>" paths
+func splitModelOperation(path string) (string, string) {
+	last := path[strings.LastIndex(path, "/")+1:]
+	model, operation, ok := strings.Cut(last, ":")
+	if !ok {
+		return "", strings.Trim(strings.ReplaceAll(path, "/", "_"), "_")
+	}
+	return model, operation
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// AccountHandler serves the data export and deletion of the user's own
+// account
+type AccountHandler struct {
+	container *container.Container
+}
+
+func NewAccountHandler(c *container.Container) *AccountHandler {
+	return &AccountHandler{container: c}
+}
+
+// ExportData downloads everything stored about the user as one JSON
+// document, or with ?format=zip as a ZIP with one JSON file per section
+// GET /api/user/export
+func (h *AccountHandler) ExportData(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	format := c.Query("format", "json")
+	if format != "json" && format != "zip" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "format must be json or zip",
+		})
+	}
+
+	export, err := h.container.AccountService.ExportUserData(c.Context(), userID, c.IP())
+	if err != nil {
+		return accountErrorResponse(c, err, "Failed to export account data")
+	}
+
+	filename := fmt.Sprintf("mylittleprice-export-%s", export.ExportedAt.Format("2006-01-02"))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if format == "json" {
+		c.Attachment(filename + ".json")
+		return c.JSON(export)
+	}
+
+	archive, err := exportArchive(export)
+	if err != nil {
+		return accountErrorResponse(c, err, "Failed to export account data")
+	}
+	c.Attachment(filename + ".zip")
+	c.Set(fiber.HeaderContentType, "application/zip")
+	return c.Send(archive)
+}
+
+// DeleteAccount erases the user's account and data. Requires
+// {"confirm": true} and, for email accounts, the password.
+// DELETE /api/user
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	var req models.DeleteAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+	if !req.Confirm {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "confirmation_required",
+			Message: "Set confirm to true to delete your account",
+		})
+	}
+
+	summary, err := h.container.AccountService.DeleteAccount(c.Context(), userID, req.Password)
+	if err != nil {
+		return accountErrorResponse(c, err, "Failed to delete account")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Account deleted successfully",
+		"deleted": summary,
+	})
+}
+
+// exportArchive splits the export into one file per section
+func exportArchive(export *models.AccountExport) ([]byte, error) {
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"user.json", export.User},
+		{"preferences.json", export.Preferences},
+		{"sessions.json", export.Sessions},
+		{"search_history.json", export.SearchHistory},
+		{"watches.json", export.Watches},
+		{"shared_sessions.json", export.SharedSessions},
+		{"snapshots.json", export.Snapshots},
+		{"refresh_tokens.json", export.RefreshTokens},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, section := range sections {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     section.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(section.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func accountErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "User not found",
+		})
+	case errors.Is(err, services.ErrInvalidPassword):
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "invalid_password",
+			Message: "Password is incorrect",
+		})
+	case errors.Is(err, services.ErrPasswordNotSet):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "password_not_set",
+			Message: "This account has no password",
+		})
+	default:
+		fmt.Printf("❌ Account data error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/jobs"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// AdminHandler serves /api/admin. Every route sits behind AuthMiddleware
+// and AdminMiddleware.
+type AdminHandler struct {
+	container *container.Container
+}
+
+func NewAdminHandler(c *container.Container) *AdminHandler {
+	return &AdminHandler{container: c}
+}
+
+// ListBugReports lists bug reports
+// GET /api/admin/bug-reports?status=open&since=2024-01-01&q=checkout&limit=50&offset=0
+func (h *AdminHandler) ListBugReports(c *fiber.Ctx) error {
+	return h.listInbox(c, models.InboxBugReports)
+}
+
+// ResolveBugReport marks a bug report as resolved
+// POST /api/admin/bug-reports/:id/resolve
+func (h *AdminHandler) ResolveBugReport(c *fiber.Ctx) error {
+	return h.resolveInboxItem(c, models.InboxBugReports)
+}
+
+// DeleteBugReport deletes a bug report
+// DELETE /api/admin/bug-reports/:id
+func (h *AdminHandler) DeleteBugReport(c *fiber.Ctx) error {
+	return h.deleteInboxItem(c, models.InboxBugReports)
+}
+
+// ListContactMessages lists contact form submissions
+// GET /api/admin/contact-messages?status=open&since=2024-01-01&q=refund&limit=50&offset=0
+func (h *AdminHandler) ListContactMessages(c *fiber.Ctx) error {
+	return h.listInbox(c, models.InboxContactMessages)
+}
+
+// ResolveContactMessage marks a contact message as resolved
+// POST /api/admin/contact-messages/:id/resolve
+func (h *AdminHandler) ResolveContactMessage(c *fiber.Ctx) error {
+	return h.resolveInboxItem(c, models.InboxContactMessages)
+}
+
+// DeleteContactMessage deletes a contact message
+// DELETE /api/admin/contact-messages/:id
+func (h *AdminHandler) DeleteContactMessage(c *fiber.Ctx) error {
+	return h.deleteInboxItem(c, models.InboxContactMessages)
+}
+
+// ListUsers lists users with their session and search counts
+// GET /api/admin/users?q=gmail&limit=50&offset=0
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	limit, offset := parsePage(c)
+
+	users, err := h.container.AdminService.ListUsers(c.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		log.Printf("❌ Failed to list users: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list users",
+		})
+	}
+
+	return c.JSON(users)
+}
+
+// UpdateUserRole promotes or demotes a user
+// PUT /api/admin/users/:id/role
+func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid user ID",
+		})
+	}
+
+	var req models.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	// Admins can't lock themselves out
+	if adminID, ok := middleware.GetUserID(c); ok && adminID == userID && req.Role != models.UserRoleAdmin {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "You cannot remove your own admin role",
+		})
+	}
+
+	if err := h.container.AuthService.SetUserRole(c.Context(), userID, req.Role); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidRole):
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "invalid_role",
+				Message: "Role must be \"user\" or \"admin\"",
+			})
+		case errors.Is(err, services.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:   "not_found",
+				Message: "User not found",
+			})
+		}
+		log.Printf("❌ Failed to update role of user %s: %v", userID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to update role",
+		})
+	}
+
+	log.Printf("🔐 User %s role set to %s by %s", userID, req.Role, adminName(c))
+
+	return c.JSON(fiber.Map{
+		"id":   userID,
+		"role": req.Role,
+	})
+}
+
+// UpdateUserPlan moves a user to another usage plan
+// PUT /api/admin/users/:id/plan
+func (h *AdminHandler) UpdateUserPlan(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid user ID",
+		})
+	}
+
+	var req models.UpdateUserPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	if err := h.container.AuthService.SetUserPlan(c.Context(), userID, req.Plan); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPlan):
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "invalid_plan",
+				Message: "Plan must be \"free\", \"pro\" or \"internal\"",
+			})
+		case errors.Is(err, services.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:   "not_found",
+				Message: "User not found",
+			})
+		}
+		log.Printf("❌ Failed to update plan of user %s: %v", userID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to update plan",
+		})
+	}
+
+	log.Printf("🎫 User %s plan set to %s by %s", userID, req.Plan, adminName(c))
+
+	return c.JSON(fiber.Map{
+		"id":   userID,
+		"plan": req.Plan,
+	})
+}
+
+// ListAuditLog lists audit entries, newest first
+// GET /api/admin/audit-log?action=account_deleted&user_id=...&limit=50&offset=0
+func (h *AdminHandler) ListAuditLog(c *fiber.Ctx) error {
+	limit, offset := parsePage(c)
+
+	var subjectID *uuid.UUID
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "INVALID_ID",
+				Message: "Invalid user ID",
+			})
+		}
+		subjectID = &id
+	}
+
+	entries, err := h.container.AuditService.List(c.Context(), c.Query("action"), subjectID, limit, offset)
+	if err != nil {
+		log.Printf("❌ Failed to list audit log: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list audit log",
+		})
+	}
+
+	return c.JSON(entries)
+}
+
+// ListCosts lists recorded paid LLM and SerpAPI calls, newest first, with
+// the total cost of all matching calls
+// GET /api/admin/costs?user_id=...&session_id=...&request_id=...&model=...&purpose=...&since=...&until=...&limit=50&offset=0
+func (h *AdminHandler) ListCosts(c *fiber.Ctx) error {
+	filter, err := parseCostFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+	}
+	filter.Limit, filter.Offset = parsePage(c)
+
+	records, err := h.container.CostService.List(c.Context(), filter)
+	if err != nil {
+		log.Printf("❌ Failed to list costs: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list costs",
+		})
+	}
+
+	return c.JSON(records)
+}
+
+// CostSummary totals recorded costs per user, session, model or purpose,
+// most expensive first. Takes the filters of ListCosts.
+// GET /api/admin/costs/summary?group_by=user&since=2024-01-01&limit=50
+func (h *AdminHandler) CostSummary(c *fiber.Ctx) error {
+	filter, err := parseCostFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+	}
+	filter.Limit, _ = parsePage(c)
+
+	summary, err := h.container.CostService.Summary(c.Context(), c.Query("group_by", models.CostGroupByUser), filter)
+	if errors.Is(err, services.ErrInvalidCostGroup) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "group_by must be \"user\", \"session\", \"model\" or \"purpose\"",
+		})
+	}
+	if err != nil {
+		log.Printf("❌ Failed to summarize costs: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to summarize costs",
+		})
+	}
+
+	return c.JSON(summary)
+}
+
+// ListJobs handles GET /api/admin/jobs: the scheduled jobs with their
+// schedule, next due time and last run
+func (h *AdminHandler) ListJobs(c *fiber.Ctx) error {
+	jobList, err := h.container.Scheduler.Jobs(c.Context())
+	if err != nil {
+		log.Printf("❌ Failed to list jobs: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list jobs",
+		})
+	}
+
+	return c.JSON(jobList)
+}
+
+// ListJobRuns handles GET /api/admin/jobs/:name/runs, newest first
+func (h *AdminHandler) ListJobRuns(c *fiber.Ctx) error {
+	limit, offset := parsePage(c)
+
+	runs, err := h.container.Scheduler.Runs(c.Context(), c.Params("name"), limit, offset)
+	if err != nil {
+		if errors.Is(err, jobs.ErrJobNotFound) {
+			return jobNotFoundResponse(c)
+		}
+		log.Printf("❌ Failed to list runs of job %s: %v", c.Params("name"), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list job runs",
+		})
+	}
+
+	return c.JSON(runs)
+}
+
+// TriggerJob handles POST /api/admin/jobs/:name/run. The job runs in the
+// background; the response is the started run, to follow in the run list.
+func (h *AdminHandler) TriggerJob(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	run, err := h.container.Scheduler.Trigger(c.Context(), name, adminName(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, jobs.ErrJobNotFound):
+			return jobNotFoundResponse(c)
+		case errors.Is(err, jobs.ErrJobRunning):
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+				Error:   "job_running",
+				Message: "Job is already running",
+			})
+		}
+		log.Printf("❌ Failed to trigger job %s: %v", name, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to trigger job",
+		})
+	}
+
+	log.Printf("⏰ Job %s triggered by %s", name, adminName(c))
+	return c.Status(fiber.StatusAccepted).JSON(run)
+}
+
+func jobNotFoundResponse(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+		Error:   "not_found",
+		Message: "Job not found",
+	})
+}
+
+// parseCostFilter reads the cost record filters of the query string
+func parseCostFilter(c *fiber.Ctx) (*models.CostFilter, error) {
+	filter := &models.CostFilter{
+		SessionID: c.Query("session_id"),
+		RequestID: c.Query("request_id"),
+		Model:     c.Query("model"),
+		Purpose:   c.Query("purpose"),
+	}
+
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, errors.New("Invalid user ID")
+		}
+		filter.UserID = &id
+	}
+
+	var err error
+	if filter.Since, err = parseAdminTime(c.Query("since")); err != nil {
+		return nil, errInvalidAdminTime("since")
+	}
+	if filter.Until, err = parseAdminTime(c.Query("until")); err != nil {
+		return nil, errInvalidAdminTime("until")
+	}
+	return filter, nil
+}
+
+func (h *AdminHandler) listInbox(c *fiber.Ctx, kind string) error {
+	filter := &models.InboxFilter{
+		Status: c.Query("status"),
+		Query:  c.Query("q"),
+	}
+	if filter.Status != "" && filter.Status != models.InboxStatusOpen && filter.Status != models.InboxStatusResolved {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "status must be \"open\" or \"resolved\"",
+		})
+	}
+
+	var err error
+	if filter.Since, err = parseAdminTime(c.Query("since")); err != nil {
+		return invalidTimeResponse(c, "since")
+	}
+	if filter.Until, err = parseAdminTime(c.Query("until")); err != nil {
+		return invalidTimeResponse(c, "until")
+	}
+	filter.Limit, filter.Offset = parsePage(c)
+
+	items, err := h.container.AdminService.ListInbox(c.Context(), kind, filter)
+	if err != nil {
+		log.Printf("❌ Failed to list %s inbox: %v", kind, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to list inbox",
+		})
+	}
+
+	return c.JSON(items)
+}
+
+func (h *AdminHandler) resolveInboxItem(c *fiber.Ctx, kind string) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil || id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid item ID",
+		})
+	}
+
+	item, err := h.container.AdminService.ResolveInboxItem(c.Context(), kind, id, adminName(c))
+	if err != nil {
+		return inboxErrorResponse(c, kind, err)
+	}
+
+	return c.JSON(item)
+}
+
+func (h *AdminHandler) deleteInboxItem(c *fiber.Ctx, kind string) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil || id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid item ID",
+		})
+	}
+
+	if err := h.container.AdminService.DeleteInboxItem(c.Context(), kind, id); err != nil {
+		return inboxErrorResponse(c, kind, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Item deleted successfully",
+	})
+}
+
+func inboxErrorResponse(c *fiber.Ctx, kind string, err error) error {
+	if errors.Is(err, services.ErrInboxItemNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Item not found or expired",
+		})
+	}
+
+	log.Printf("❌ Admin %s inbox error: %v", kind, err)
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+		Error:   "server_error",
+		Message: "Failed to update inbox",
+	})
+}
+
+func invalidTimeResponse(c *fiber.Ctx, param string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+		Error:   "validation_error",
+		Message: errInvalidAdminTime(param).Error(),
+	})
+}
+
+func errInvalidAdminTime(param string) error {
+	return errors.New("Invalid " + param + ". Use RFC3339 (2024-01-01T00:00:00Z) or a date (2024-01-01)")
+}
+
+// parseAdminTime accepts RFC3339 or a plain date in server local time
+func parseAdminTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+func parsePage(c *fiber.Ctx) (int, int) {
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// adminName identifies the acting admin in logs and resolved items
+func adminName(c *fiber.Ctx) string {
+	if email, ok := middleware.GetUserEmail(c); ok && email != "" {
+		return email
+	}
+	if userID, ok := middleware.GetUserID(c); ok {
+		return userID.String()
+	}
+	return "unknown"
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// APIKeyHandler lets users manage their personal API keys. Keys are
+// managed with a signed-in session only, never with a key.
+type APIKeyHandler struct {
+	container *container.Container
+}
+
+func NewAPIKeyHandler(c *container.Container) *APIKeyHandler {
+	return &APIKeyHandler{container: c}
+}
+
+// CreateKey creates a key and returns it once
+// POST /api/user/api-keys
+func (h *APIKeyHandler) CreateKey(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+	if req.ExpiresInDays < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "expires_in_days must not be negative",
+		})
+	}
+
+	key, err := h.container.APIKeyService.CreateKey(c.Context(), userID, &req)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// ListKeys returns the user's active keys without the keys themselves
+// GET /api/user/api-keys
+func (h *APIKeyHandler) ListKeys(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	keys, err := h.container.APIKeyService.ListKeys(c.Context(), userID)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+
+	return c.JSON(models.APIKeysResponse{Keys: keys})
+}
+
+// RevokeKey stops a key from working
+// DELETE /api/user/api-keys/:id
+func (h *APIKeyHandler) RevokeKey(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	keyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid API key ID",
+		})
+	}
+
+	if err := h.container.APIKeyService.RevokeKey(c.Context(), userID, keyID); err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "API key revoked successfully",
+	})
+}
+
+func apiKeyErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrAPIKeyNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+		})
+	case errors.Is(err, services.ErrAPIKeyInvalidName), errors.Is(err, services.ErrAPIKeyInvalidScope):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrAPIKeyLimit):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "api_key_limit_reached",
+			Message: "Revoke an API key before creating another one",
+		})
+	default:
+		fmt.Printf("❌ API key error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process API key request",
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"mylittleprice/internal/services"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type AuthHandler struct {
@@ -47,7 +48,7 @@ func (h *AuthHandler) Signup(c *fiber.Ctx) error {
 	}
 
 	// Create user
-	authResp, err := h.container.AuthService.Signup(&req)
+	authResp, err := h.container.AuthService.Signup(&req, deviceInfo(c))
 	if err != nil {
 		if errors.Is(err, services.ErrUserExists) {
 			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
@@ -61,6 +62,10 @@ func (h *AuthHandler) Signup(c *fiber.Ctx) error {
 		})
 	}
 
+	// The account works right away; the link can be sent again from
+	// /api/auth/resend-verification
+	go h.sendVerificationEmail(authResp.User.ID)
+
 	return c.Status(fiber.StatusCreated).JSON(authResp)
 }
 
@@ -83,12 +88,20 @@ func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 	}
 
 	// Authenticate user with Google
-	authResp, err := h.container.AuthService.GoogleLogin(req.IDToken)
+	authResp, challenge, err := h.container.AuthService.GoogleLogin(req.IDToken, deviceInfo(c))
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "invalid_token",
-			Message: "Failed to verify Google token",
-		})
+		if errors.Is(err, services.ErrOIDCInvalidToken) {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:   "invalid_token",
+				Message: "Failed to verify Google token",
+			})
+		}
+		return identityErrorResponse(c, err)
+	}
+
+	// 2FA enabled - the client completes the login at /api/auth/2fa/verify
+	if challenge != nil {
+		return c.JSON(challenge)
 	}
 
 	return c.JSON(authResp)
@@ -114,7 +127,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	// Authenticate user
-	authResp, err := h.container.AuthService.Login(&req)
+	authResp, challenge, err := h.container.AuthService.Login(&req, deviceInfo(c))
 	if err != nil {
 		if errors.Is(err, services.ErrUserNotFound) || errors.Is(err, services.ErrInvalidPassword) {
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
@@ -128,6 +141,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	// 2FA enabled - the client completes the login at /api/auth/2fa/verify
+	if challenge != nil {
+		return c.JSON(challenge)
+	}
+
 	return c.JSON(authResp)
 }
 
@@ -149,7 +167,7 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 		})
 	}
 
-	authResp, err := h.container.AuthService.RefreshAccessToken(req.RefreshToken)
+	authResp, err := h.container.AuthService.RefreshAccessToken(req.RefreshToken, deviceInfo(c))
 	if err != nil {
 		if errors.Is(err, services.ErrInvalidToken) {
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
@@ -157,6 +175,12 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 				Message: "Invalid or expired refresh token",
 			})
 		}
+		if errors.Is(err, services.ErrRefreshTokenReused) {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:   "token_reused",
+				Message: "Refresh token was already used, please sign in again",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to refresh token",
@@ -426,3 +450,94 @@ func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
 		"message": "Password reset successfully",
 	})
 }
+
+// VerifyEmail confirms the email of an account with the emailed token
+// POST /api/auth/verify-email
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req models.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "Token is required",
+		})
+	}
+
+	user, err := h.container.AuthService.VerifyEmail(req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrVerificationInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "invalid_token",
+				Message: "Verification link is invalid or has expired",
+			})
+		}
+		fmt.Printf("❌ Failed to verify email: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to verify email",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":        "Email verified successfully",
+		"email_verified": user.EmailVerified,
+	})
+}
+
+// ResendVerification sends the verification link again
+// POST /api/auth/resend-verification
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	user, token, err := h.container.AuthService.CreateEmailVerificationToken(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrEmailAlreadyVerified) {
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+				Error:   "already_verified",
+				Message: "Email is already verified",
+			})
+		}
+		fmt.Printf("❌ Failed to create verification token: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create verification link",
+		})
+	}
+
+	if err := h.container.EmailService.SendVerificationEmail(user.Email, token); err != nil {
+		fmt.Printf("⚠️ Failed to send verification email: %v\n", err)
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
+			Error:   "email_failed",
+			Message: "Failed to send verification email, please try again later",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Verification email sent",
+	})
+}
+
+// sendVerificationEmail sends the first verification link after signup.
+// Failures are only logged: the signup itself succeeded.
+func (h *AuthHandler) sendVerificationEmail(userID uuid.UUID) {
+	user, token, err := h.container.AuthService.CreateEmailVerificationToken(userID)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to create verification token: %v\n", err)
+		return
+	}
+	if err := h.container.EmailService.SendVerificationEmail(user.Email, token); err != nil {
+		fmt.Printf("⚠️ Failed to send verification email: %v\n", err)
+	}
+}
@@ -158,43 +158,6 @@ func (h *BugReportHandler) SubmitBugReport(c *fiber.Ctx) error {
 	})
 }
 
-// GetBugReports retrieves recent bug reports (admin only)
-// GET /api/bug-reports
-func (h *BugReportHandler) GetBugReports(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
-	defer cancel()
-
-	// Get all bug report keys
-	keys, err := h.container.Redis.Keys(ctx, "bug_report:*").Result()
-	if err != nil {
-		slog.Error("Failed to fetch bug report keys", "error", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to fetch bug reports",
-		})
-	}
-
-	reports := make([]map[string]interface{}, 0, len(keys))
-	for _, key := range keys {
-		data, err := h.container.Redis.Get(ctx, key).Result()
-		if err != nil {
-			continue
-		}
-
-		var report map[string]interface{}
-		if err := json.Unmarshal([]byte(data), &report); err != nil {
-			continue
-		}
-
-		report["id"] = key
-		reports = append(reports, report)
-	}
-
-	return c.JSON(fiber.Map{
-		"reports": reports,
-		"total":   len(reports),
-	})
-}
-
 // sendDiscordNotification sends a bug report notification to Discord webhook
 func (h *BugReportHandler) sendDiscordNotification(req BugReportRequest, reportID string, attachments []string, attachmentNames []string) {
 	// Truncate description if too long
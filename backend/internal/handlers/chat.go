@@ -38,6 +38,14 @@ func (h *ChatHandler) HandleChat(c *fiber.Ctx) error {
 		userID = &uid
 	}
 
+	// A user's session only takes messages from the owner and its participants
+	if !h.processor.CanChatInSession(c.UserContext(), req.SessionID, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:   "session_ownership",
+			Message: "You don't have access to this session",
+		})
+	}
+
 	// Process chat using shared processor
 	processorReq := &ChatRequest{
 		SessionID:       req.SessionID,
@@ -48,9 +56,10 @@ func (h *ChatHandler) HandleChat(c *fiber.Ctx) error {
 		Currency:        req.Currency,
 		NewSearch:       req.NewSearch,
 		CurrentCategory: "",
+		BrowserID:       req.BrowserID,
 	}
 
-	result := h.processor.ProcessChat(processorReq)
+	result := h.processor.ProcessChat(c.UserContext(), processorReq)
 
 	// Handle errors
 	if result.Error != nil {
@@ -74,6 +83,7 @@ func (h *ChatHandler) HandleChat(c *fiber.Ctx) error {
 		SessionID:    result.SessionID,
 		MessageCount: result.MessageCount,
 		SearchState:  result.SearchState,
+		Comparison:   result.Comparison,
 	}
 
 	return c.JSON(response)
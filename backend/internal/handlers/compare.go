@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+const (
+	minCompareProducts = 2
+	maxCompareProducts = 5
+)
+
+var (
+	errInvalidCompareRequest = fmt.Errorf("between %d and %d distinct page tokens are required", minCompareProducts, maxCompareProducts)
+	errNotEnoughProducts     = errors.New("not enough products could be fetched to compare")
+	errCompareLimitReached   = errors.New("the product details allowance of the plan doesn't cover this comparison")
+)
+
+// HandleCompare compares 2-5 products side by side
+// POST /api/products/compare
+func (h *ProductHandler) HandleCompare(c *fiber.Ctx) error {
+	var req models.CompareRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body",
+		})
+	}
+
+	var userID *uuid.UUID
+	if uid, ok := middleware.GetUserID(c); ok {
+		userID = &uid
+	}
+
+	usage := h.container.UsageService.Subject(c.Context(), userID, req.BrowserID)
+	comparison, err := BuildComparison(costContext(c.UserContext(), userID, ""), h.container, &req, usage)
+	if err != nil {
+		if errors.Is(err, errInvalidCompareRequest) {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+			})
+		}
+		if errors.Is(err, errCompareLimitReached) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
+				Error:   "usage_limit_reached",
+				Message: "You've reached today's product details limit of your plan",
+			})
+		}
+		return c.Status(fiber.StatusBadGateway).JSON(models.ErrorResponse{
+			Error:   "fetch_error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(comparison)
+}
+
+// BuildComparison fetches product details concurrently (using the product
+// cache), aligns specifications by title and adds a Gemini verdict. Each
+// compared product uses one product details lookup of the usage subject's
+// plan. Paid calls are recorded as costs of the request in ctx.
+func BuildComparison(ctx context.Context, c *container.Container, req *models.CompareRequest, usage *services.UsageSubject) (*models.ComparisonResponse, error) {
+	pageTokens := uniqueTokens(req.PageTokens)
+	if len(pageTokens) < minCompareProducts || len(pageTokens) > maxCompareProducts {
+		return nil, errInvalidCompareRequest
+	}
+
+	if !c.UsageService.ConsumeN(ctx, usage, models.UsageProductDetails, len(pageTokens)) {
+		return nil, errCompareLimitReached
+	}
+
+	if req.Country == "" {
+		req.Country = c.Config.DefaultCountry
+	}
+	if req.Language == "" {
+		req.Language = c.Config.DefaultLanguage
+	}
+	if req.Currency == "" {
+		req.Currency = c.Config.DefaultCurrency
+	}
+
+	details := make([]*models.ProductDetailsResponse, len(pageTokens))
+	errs := make([]error, len(pageTokens))
+
+	var wg sync.WaitGroup
+	for i, pageToken := range pageTokens {
+		wg.Add(1)
+		go func(i int, pageToken string) {
+			defer wg.Done()
+
+			productData, err := fetchProductDetails(ctx, c, pageToken, req.Country)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			details[i], errs[i] = FormatProductDetails(productData)
+			if errs[i] == nil {
+				c.FXService.ConvertOffers(details[i].Offers, req.Country, req.Currency)
+			}
+		}(i, pageToken)
+	}
+	wg.Wait()
+
+	comparison := &models.ComparisonResponse{
+		Type:     "compare",
+		Products: make([]models.ComparedProduct, len(pageTokens)),
+	}
+
+	fetched := 0
+	for i, pageToken := range pageTokens {
+		if errs[i] != nil {
+			fmt.Printf("⚠️ Compare: failed to fetch %s: %v\n", pageToken, errs[i])
+			comparison.Products[i] = models.ComparedProduct{
+				PageToken: pageToken,
+				Error:     "Failed to fetch product details",
+			}
+			continue
+		}
+
+		comparison.Products[i] = toComparedProduct(pageToken, details[i])
+		fetched++
+	}
+
+	if fetched < minCompareProducts {
+		return nil, errNotEnoughProducts
+	}
+
+	comparison.Specifications = alignSpecifications(details)
+
+	verdict, err := c.GeminiService.GenerateComparisonVerdict(ctx, comparison, req.Language, req.Currency)
+	if err != nil {
+		// The table is still useful without a verdict
+		fmt.Printf("⚠️ Compare: verdict failed: %v\n", err)
+	} else {
+		comparison.Verdict = verdict
+	}
+
+	return comparison, nil
+}
+
+func toComparedProduct(pageToken string, details *models.ProductDetailsResponse) models.ComparedProduct {
+	product := models.ComparedProduct{
+		PageToken:  pageToken,
+		Title:      details.Title,
+		Price:      details.Price,
+		Rating:     details.Rating,
+		Reviews:    details.Reviews,
+		OfferCount: len(details.Offers),
+	}
+
+	if len(details.Images) > 0 {
+		product.Image = details.Images[0]
+	}
+
+	// Cheapest offer with a known price, otherwise the first one
+	for i := range details.Offers {
+		offer := &details.Offers[i]
+		if product.BestOffer == nil ||
+			(offer.ExtractedPrice > 0 && (product.BestOffer.ExtractedPrice == 0 || offer.ExtractedPrice < product.BestOffer.ExtractedPrice)) {
+			product.BestOffer = offer
+		}
+	}
+
+	if product.Price == "" && product.BestOffer != nil {
+		product.Price = product.BestOffer.Price
+	}
+
+	return product
+}
+
+// alignSpecifications builds one row per specification title (matched
+// case-insensitively), in the order titles first appear
+func alignSpecifications(details []*models.ProductDetailsResponse) []models.ComparisonRow {
+	rows := []models.ComparisonRow{}
+	rowIndex := map[string]int{}
+
+	for i, d := range details {
+		if d == nil {
+			continue
+		}
+
+		for _, spec := range d.Specifications {
+			key := strings.ToLower(strings.TrimSpace(spec.Title))
+			if key == "" {
+				continue
+			}
+
+			idx, ok := rowIndex[key]
+			if !ok {
+				idx = len(rows)
+				rowIndex[key] = idx
+				rows = append(rows, models.ComparisonRow{
+					Title:  strings.TrimSpace(spec.Title),
+					Values: make([]string, len(details)),
+				})
+			}
+
+			// Keep the first value if a product repeats a title
+			if rows[idx].Values[i] == "" {
+				rows[idx].Values[i] = spec.Value
+			}
+		}
+	}
+
+	return rows
+}
+
+// matchComparedProducts maps product names chosen by Gemini to page tokens
+// of products shown in recent messages (newest results win)
+func matchComparedProducts(messages []*models.Message, names []string) []string {
+	shown := []models.ProductCard{}
+	for i := len(messages) - 1; i >= 0; i-- {
+		shown = append(shown, messages[i].Products...)
+	}
+
+	tokens := make([]string, 0, len(names))
+	for _, name := range names {
+		key := services.NormalizeProductKey(name)
+		if key == "" {
+			continue
+		}
+
+		// Exact name match wins, otherwise the first partial match
+		var match string
+		for _, product := range shown {
+			productKey := services.NormalizeProductKey(product.Name)
+			if product.PageToken == "" || productKey == "" {
+				continue
+			}
+			if productKey == key {
+				match = product.PageToken
+				break
+			}
+			if match == "" && (strings.Contains(productKey, key) || strings.Contains(key, productKey)) {
+				match = product.PageToken
+			}
+		}
+
+		if match != "" {
+			tokens = append(tokens, match)
+		}
+	}
+
+	tokens = uniqueTokens(tokens)
+	if len(tokens) > maxCompareProducts {
+		tokens = tokens[:maxCompareProducts]
+	}
+	return tokens
+}
+
+func uniqueTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	unique := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		unique = append(unique, token)
+	}
+	return unique
+}
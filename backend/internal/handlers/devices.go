@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// ListDevices returns the signed-in devices of the user
+// GET /api/auth/devices
+func (h *AuthHandler) ListDevices(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	currentID, _ := middleware.GetDeviceID(c)
+	devices, err := h.container.AuthService.ListDevices(userID, currentID)
+	if err != nil {
+		fmt.Printf("❌ Failed to list devices: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list devices",
+		})
+	}
+
+	return c.JSON(models.DevicesResponse{Devices: devices})
+}
+
+// RevokeDevice signs one of the user's devices out
+// DELETE /api/auth/devices/:id
+func (h *AuthHandler) RevokeDevice(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	deviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid device ID",
+		})
+	}
+
+	if err := h.container.AuthService.RevokeDevice(userID, deviceID); err != nil {
+		if errors.Is(err, services.ErrDeviceNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Device not found",
+			})
+		}
+		fmt.Printf("❌ Failed to revoke device %s: %v\n", deviceID.String(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to sign out device",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Device signed out successfully",
+	})
+}
+
+// deviceInfo describes the client of a request that gets new tokens
+func deviceInfo(c *fiber.Ctx) *models.DeviceInfo {
+	return &models.DeviceInfo{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IPAddress: c.IP(),
+	}
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// OpenID Connect login and linked identities: users log in with any
+// provider linked to their account and link or unlink providers while
+// signed in.
+
+// ListOIDCProviders returns the configured OpenID Connect providers
+// GET /api/auth/oidc/providers
+func (h *AuthHandler) ListOIDCProviders(c *fiber.Ctx) error {
+	return c.JSON(models.OIDCProvidersResponse{
+		Providers: h.container.OIDCService.Providers(),
+	})
+}
+
+// OIDCLogin authenticates a user with an ID token of a provider
+// POST /api/auth/oidc/:provider
+func (h *AuthHandler) OIDCLogin(c *fiber.Ctx) error {
+	var req models.OIDCAuthRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.IDToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "ID token is required",
+		})
+	}
+
+	authResp, challenge, err := h.container.AuthService.OIDCLogin(c.Params("provider"), req.IDToken, req.Nonce, deviceInfo(c))
+	if err != nil {
+		return identityErrorResponse(c, err)
+	}
+
+	// 2FA enabled - the client completes the login at /api/auth/2fa/verify
+	if challenge != nil {
+		return c.JSON(challenge)
+	}
+
+	return c.JSON(authResp)
+}
+
+// ListIdentities returns the providers linked to the user's account
+// GET /api/auth/identities
+func (h *AuthHandler) ListIdentities(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	identities, err := h.container.AuthService.ListIdentities(userID)
+	if err != nil {
+		return identityErrorResponse(c, err)
+	}
+
+	return c.JSON(identities)
+}
+
+// LinkIdentity links the account of an ID token to the signed-in user
+// POST /api/auth/identities/:provider
+func (h *AuthHandler) LinkIdentity(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	var req models.OIDCAuthRequest
+	if err := c.BodyParser(&req); err != nil || req.IDToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "ID token is required",
+		})
+	}
+
+	identity, err := h.container.AuthService.LinkIdentity(userID, c.Params("provider"), req.IDToken, req.Nonce)
+	if err != nil {
+		return identityErrorResponse(c, err)
+	}
+
+	h.recordIdentityChange(c.Context(), models.AuditActionIdentityLinked, userID, identity.Provider, c.IP())
+
+	return c.Status(fiber.StatusCreated).JSON(identity)
+}
+
+// UnlinkIdentity removes a provider from the user's account
+// DELETE /api/auth/identities/:id
+func (h *AuthHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	identityID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid identity ID",
+		})
+	}
+
+	identity, err := h.container.AuthService.UnlinkIdentity(userID, identityID)
+	if err != nil {
+		return identityErrorResponse(c, err)
+	}
+
+	h.recordIdentityChange(c.Context(), models.AuditActionIdentityUnlinked, userID, identity.Provider, c.IP())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *AuthHandler) recordIdentityChange(ctx context.Context, action string, userID uuid.UUID, provider, ip string) {
+	err := h.container.AuditService.Record(ctx, &models.AuditEntry{
+		Action:    action,
+		ActorID:   &userID,
+		SubjectID: &userID,
+		IPAddress: ip,
+		Details:   map[string]interface{}{"provider": provider},
+	})
+	if err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+}
+
+func identityErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrOIDCUnknownProvider):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "unknown_provider",
+			Message: "Unknown identity provider",
+		})
+	case errors.Is(err, services.ErrOIDCInvalidToken):
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "invalid_token",
+			Message: "Failed to verify ID token",
+		})
+	case errors.Is(err, services.ErrOIDCProviderUnavailable):
+		fmt.Printf("❌ %v\n", err)
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
+			Error:   "provider_unavailable",
+			Message: "The identity provider is not reachable, please try again later",
+		})
+	case errors.Is(err, services.ErrIdentityEmailInUse):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "email_in_use",
+			Message: "An account with this email already exists. Sign in to it and link this provider in your account settings.",
+		})
+	case errors.Is(err, services.ErrIdentityEmailUnverified):
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:   "email_not_verified",
+			Message: "The provider did not confirm your email address",
+		})
+	case errors.Is(err, services.ErrIdentityLinked):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "identity_in_use",
+			Message: "This account is already linked to another user",
+		})
+	case errors.Is(err, services.ErrIdentityAlreadyLinked):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "already_linked",
+			Message: "An account of this provider is already linked",
+		})
+	case errors.Is(err, services.ErrIdentityNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Linked identity not found",
+		})
+	case errors.Is(err, services.ErrLastSignInMethod):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "last_sign_in_method",
+			Message: "Link another provider before removing this one",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "user_not_found",
+			Message: "User not found",
+		})
+	default:
+		fmt.Printf("❌ Identity error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process sign-in",
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/models"
+)
+
+type PriceHistoryHandler struct {
+	container *container.Container
+}
+
+func NewPriceHistoryHandler(c *container.Container) *PriceHistoryHandler {
+	return &PriceHistoryHandler{container: c}
+}
+
+// GetPriceHistory returns a product's price series and min/median/max
+// GET /api/prices/history?product=Sony+WH-1000XM5&currency=CHF&country=CH&days=90
+func (h *PriceHistoryHandler) GetPriceHistory(c *fiber.Ctx) error {
+	product := c.Query("product")
+	if product == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "product is required",
+		})
+	}
+
+	days, err := strconv.Atoi(c.Query("days", "90"))
+	if err != nil || days <= 0 {
+		days = 90
+	}
+
+	history, err := h.container.PriceHistoryService.GetPriceHistory(
+		c.Context(),
+		product,
+		c.Query("currency"),
+		c.Query("country"),
+		days,
+	)
+	if err != nil {
+		fmt.Printf("❌ Error getting price history for %q: %v\n", product, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "PRICE_HISTORY_FETCH_ERROR",
+			Message: "Failed to retrieve price history",
+		})
+	}
+
+	return c.JSON(history)
+}
@@ -2,16 +2,19 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"mylittleprice/internal/container"
 	"mylittleprice/internal/models"
 	"mylittleprice/internal/services"
+	"mylittleprice/internal/tracing"
 	"mylittleprice/internal/utils"
 )
 
@@ -40,6 +43,29 @@ type ChatRequest struct {
 	BrowserID         string // Persistent browser identifier for anonymous tracking
 	UserMessageID     string // Pre-generated UUID for user message (for consistent sync)
 	AssistantMessageID string // Pre-generated UUID for assistant message (for consistent sync)
+	OnEvent           func(event *ChatEvent) // Optional progress callback (WebSocket streaming)
+}
+
+// Progress events emitted through ChatRequest.OnEvent while a message is processed
+const (
+	ChatEventThinking        = "thinking"
+	ChatEventSearching       = "searching"
+	ChatEventProductsPartial = "products_partial"
+	ChatEventToken           = "token"
+)
+
+// ChatEvent is a progress update for a chat request in flight
+type ChatEvent struct {
+	Type         string
+	Delta        string               // token: next piece of the assistant output
+	SearchPhrase string               // searching: phrase sent to the shopping search
+	Products     []models.ProductCard // products_partial: results before the turn is finalized
+}
+
+func (r *ChatRequest) emit(event *ChatEvent) {
+	if r.OnEvent != nil {
+		r.OnEvent(event)
+	}
 }
 
 // ChatProcessorResponse represents the standardized response from chat processing
@@ -52,6 +78,7 @@ type ChatProcessorResponse struct {
 	SessionID    string
 	MessageCount int
 	SearchState  *models.SearchStateResponse
+	Comparison   *models.ComparisonResponse
 	Error        *ErrorInfo
 }
 
@@ -61,12 +88,19 @@ type ErrorInfo struct {
 	Message string
 }
 
-// ProcessChat handles the main chat processing logic
-func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
+// ProcessChat handles the main chat processing logic. ctx carries the
+// request ID and the span of the HTTP request or WebSocket message.
+func (p *ChatProcessor) ProcessChat(ctx context.Context, req *ChatRequest) *ChatProcessorResponse {
 	// Create context with timeout for the entire operation
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
+	ctx, span := tracing.Start(ctx, "chat.process",
+		attribute.Bool("chat.new_search", req.NewSearch),
+		attribute.Bool("chat.authenticated", req.UserID != nil),
+	)
+	defer span.End()
+
 	// Track metrics for message processing
 	start := time.Now()
 	status := "success"
@@ -79,6 +113,7 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		// Determine status based on response
 		if response != nil && response.Error != nil {
 			status = "error"
+			span.SetStatus(codes.Error, response.Error.Code)
 		}
 
 		utils.LogInfo(ctx, "message processing completed",
@@ -111,7 +146,9 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 	}
 
 	// Get or create session
+	_, sessionSpan := tracing.Start(ctx, "chat.session")
 	session, err := p.getOrCreateSession(req)
+	tracing.End(sessionSpan, err)
 	if err != nil {
 		response = &ChatProcessorResponse{
 			Error: &ErrorInfo{
@@ -122,6 +159,11 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		return response
 	}
 
+	// Paid LLM and search calls of the message are attributed to the
+	// request, session and user in ctx
+	ctx = costContext(ctx, req.UserID, session.SessionID)
+	span.SetAttributes(attribute.String("chat.session_id", session.SessionID))
+
 	// Handle new search
 	if req.NewSearch {
 		utils.LogInfo(ctx, "new search started", slog.String("session_id", req.SessionID))
@@ -134,60 +176,32 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		// Will be saved at the end with SaveSession()
 	}
 
-	// Check anonymous search limit (browser-based tracking)
-	anonymousLimit := p.container.Config.AnonymousSearchLimit
-	var anonymousSearchUsed int
-	if req.UserID == nil && req.BrowserID != "" {
-		// Get search count from Redis by browser ID
-		count, err := p.container.CacheService.GetAnonymousSearchCount(req.BrowserID)
-		if err != nil {
-			utils.LogError(ctx, "failed to get anonymous search count", err, slog.String("browser_id", req.BrowserID))
-			count = 0 // Continue on error, don't block user
-		}
-		anonymousSearchUsed = count
+	// Usage counts against the daily allowances of the user's plan
+	usage := p.container.UsageService.Subject(ctx, req.UserID, req.BrowserID)
 
-		// Check if limit reached
-		if anonymousSearchUsed >= anonymousLimit {
-			response = &ChatProcessorResponse{
-				Type:         "text",
-				Output:       "You've used all 3 free searches! Please sign up or log in to continue searching for products.",
-				SessionID:    req.SessionID,
-				MessageCount: session.MessageCount,
-				SearchState: &models.SearchStateResponse{
-					Status:                 string(session.SearchState.Status),
-					Category:               session.SearchState.Category,
-					CanContinue:            false,
-					SearchCount:            session.SearchState.SearchCount,
-					MaxSearches:            p.container.SessionService.GetMaxSearches(),
-					AnonymousSearchUsed:    anonymousSearchUsed,
-					AnonymousSearchLimit:   anonymousLimit,
-					RequiresAuthentication: true,
-					Message:                "Anonymous search limit reached - authentication required",
-				},
-			}
-			return response
-		}
+	// Check search allowance - searches are counted once they found products
+	if !p.container.UsageService.Allow(ctx, usage, models.UsageSearches) {
+		response = p.usageLimitResponse(ctx, req, session, usage, models.UsageSearches)
+		return response
 	}
 
-	// Check search limit
+	// Check per-session search limit
 	if session.SearchState.SearchCount >= p.container.SessionService.GetMaxSearches() {
 		response = &ChatProcessorResponse{
 			Type:         "text",
 			Output:       "You have reached the maximum number of searches. Please start a new search.",
 			SessionID:    req.SessionID,
 			MessageCount: session.MessageCount,
-			SearchState: &models.SearchStateResponse{
-				Status:                 string(session.SearchState.Status),
-				Category:               session.SearchState.Category,
-				CanContinue:            false,
-				SearchCount:            session.SearchState.SearchCount,
-				MaxSearches:            p.container.SessionService.GetMaxSearches(),
-				AnonymousSearchUsed:    anonymousSearchUsed,
-				AnonymousSearchLimit:   anonymousLimit,
-				RequiresAuthentication: false,
-				Message:                "Search limit reached",
-			},
+			SearchState:  p.searchState(ctx, session, usage),
 		}
+		response.SearchState.CanContinue = false
+		response.SearchState.Message = "Search limit reached"
+		return response
+	}
+
+	// Count the message against the daily message allowance
+	if !p.container.UsageService.Consume(ctx, usage, models.UsageMessages) {
+		response = p.usageLimitResponse(ctx, req, session, usage, models.UsageMessages)
 		return response
 	}
 
@@ -228,44 +242,30 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 	// Add user message to cycle history
 	p.container.CycleService.AddToCycleHistoryInMemory(session, "user", req.Message)
 
-	// Process with Universal Prompt System with retry logic
-	var geminiResponse *models.GeminiResponse
-	var geminiErr error
-	const maxProcessingRetries = 2
+	// Run the dialogue: the model calls data tools (search, details,
+	// compare) and gets their results back until it answers with a
+	// terminal tool
+	req.emit(&ChatEvent{Type: ChatEventThinking})
 
-	for attempt := 0; attempt <= maxProcessingRetries; attempt++ {
-		if attempt > 0 {
-			utils.LogInfo(ctx, "retry processing attempt",
-				slog.Int("attempt", attempt+1),
-				slog.Int("max_attempts", maxProcessingRetries+1),
-			)
-		}
-
-		geminiResponse, geminiErr = p.container.GeminiService.ProcessWithUniversalPrompt(
-			req.Message,
-			session,
-		)
+	turn := &toolTurn{req: req, session: session, usage: usage}
+	dialogueCtx, dialogueSpan := tracing.Start(ctx, "chat.dialogue")
+	dialogue := p.container.GeminiService.StartDialogue(dialogueCtx, req.Message, session)
 
-		// Success - break out of retry loop
-		if geminiErr == nil && geminiResponse != nil {
-			if attempt > 0 {
-				utils.LogInfo(ctx, "processing succeeded on retry",
-					slog.Int("attempt", attempt+1),
-				)
-			}
-			break
-		}
+	// Grounding has its own allowance - once spent, turns run without it
+	if dialogue.Grounded() && !p.container.UsageService.Consume(ctx, usage, models.UsageGrounding) {
+		utils.LogInfo(ctx, "grounding allowance spent, answering without grounding", slog.String("plan", usage.Plan))
+		dialogue.DisableGrounding()
+	}
 
-		// Log the error
-		if geminiErr != nil {
-			utils.LogError(ctx, "gemini processing error", geminiErr,
-				slog.Int("attempt", attempt+1),
-				slog.Int("max_attempts", maxProcessingRetries+1),
-			)
+	var answerCall *services.LLMToolCall
+	for round := 1; round <= maxToolRounds && answerCall == nil; round++ {
+		if round == maxToolRounds {
+			dialogue.RequireAnswer()
 		}
 
-		// If this is the last attempt, use fallback response
-		if attempt == maxProcessingRetries {
+		calls, err := p.nextToolCalls(dialogueCtx, dialogue)
+		if err != nil {
+			tracing.End(dialogueSpan, err)
 			utils.LogWarn(ctx, "all processing attempts failed, using fallback response")
 
 			// Return helpful fallback response instead of error
@@ -275,42 +275,64 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 				QuickReplies: []string{"Start over", "Try again"},
 				SessionID:    req.SessionID,
 				MessageCount: session.MessageCount,
-				SearchState: &models.SearchStateResponse{
-					Status:      string(session.SearchState.Status),
-					Category:    session.SearchState.Category,
-					CanContinue: session.SearchState.SearchCount < p.container.SessionService.GetMaxSearches(),
-					SearchCount: session.SearchState.SearchCount,
-					MaxSearches: p.container.SessionService.GetMaxSearches(),
-					Message:     "Temporary processing issue",
-				},
+				SearchState:  p.searchState(ctx, session, usage),
 			}
+			response.SearchState.Message = "Temporary processing issue"
 			return response
 		}
 
-		// Wait a bit before retry (500ms, 1s)
-		if attempt < maxProcessingRetries {
-			retryDelay := time.Duration(500*(attempt+1)) * time.Millisecond
-			time.Sleep(retryDelay)
+		// Data tools run in call order; the first terminal call ends the turn
+		results := make([]services.LLMToolResult, 0, len(calls))
+		for i := range calls {
+			if services.IsTerminalTool(calls[i].Name) {
+				if answerCall == nil {
+					answerCall = &calls[i]
+				}
+				continue
+			}
+			results = append(results, p.executeTool(dialogueCtx, turn, &calls[i]))
 		}
+		if answerCall == nil {
+			dialogue.AddResults(results)
+		}
+		dialogueSpan.SetAttributes(attribute.Int("chat.tool_rounds", round))
 	}
+	dialogueSpan.End()
 
-	// Log the Gemini response for debugging
-	logAttrs := []any{
-		slog.String("response_type", geminiResponse.ResponseType),
-		slog.String("category", geminiResponse.Category),
-		slog.String("search_phrase", geminiResponse.SearchPhrase),
-	}
-	if geminiResponse.MinPrice != nil {
-		logAttrs = append(logAttrs, slog.Any("min_price", geminiResponse.MinPrice))
+	var answer services.DialogueAnswer
+	finishCycle := false
+	if answerCall == nil {
+		// RequireAnswer makes this unlikely, but a turn must still end
+		utils.LogWarn(ctx, "no answer within tool round limit", slog.Int("max_rounds", maxToolRounds))
+	} else {
+		if err := answerCall.DecodeArgs(&answer); err != nil {
+			utils.LogWarn(ctx, "invalid answer arguments", slog.Any("error", err))
+		}
+		finishCycle = answerCall.Name == services.ToolFinishCycle
+
+		utils.LogInfo(ctx, "dialogue answer received",
+			slog.String("tool", answerCall.Name),
+			slog.String("category", answer.Category),
+			slog.Int("product_count", len(turn.products)),
+		)
 	}
-	if geminiResponse.MaxPrice != nil {
-		logAttrs = append(logAttrs, slog.Any("max_price", geminiResponse.MaxPrice))
+
+	if answer.Message == "" && len(turn.products) > 0 {
+		answer.Message = "Here is what I found for you."
 	}
-	utils.LogInfo(ctx, "gemini response received", logAttrs...)
+	streamAnswer(req, answer.Message)
 
 	// Update category
-	if geminiResponse.Category != "" {
-		session.SearchState.Category = geminiResponse.Category
+	if answer.Category != "" {
+		session.SearchState.Category = answer.Category
+	}
+
+	responseType := "dialogue"
+	switch {
+	case turn.comparison != nil:
+		responseType = "compare"
+	case len(turn.products) > 0:
+		responseType = "search"
 	}
 
 	// Create assistant message (but don't save yet - we may need to add products first)
@@ -331,167 +353,60 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		ID:           assistantMsgID,
 		SessionID:    session.ID,
 		Role:         "assistant",
-		Content:      geminiResponse.Output,
-		ResponseType: geminiResponse.ResponseType,
-		QuickReplies: geminiResponse.QuickReplies,
+		Content:      answer.Message,
+		ResponseType: responseType,
+		QuickReplies: answer.QuickReplies,
 		CreatedAt:    time.Now(),
 	}
 
 	// Build response
 	response = &ChatProcessorResponse{
-		Type:         geminiResponse.ResponseType,
-		Output:       geminiResponse.Output,
-		QuickReplies: geminiResponse.QuickReplies,
+		Type:         responseType,
+		Output:       answer.Message,
+		QuickReplies: answer.QuickReplies,
 		SessionID:    req.SessionID,
 		MessageCount: session.MessageCount + 1,
 	}
 
-	// Handle search (intermediate search for verification/grounding)
-	if geminiResponse.ResponseType == "search" {
-		searchLogAttrs := []any{
-			slog.String("phrase", geminiResponse.SearchPhrase),
-			slog.String("search_type", geminiResponse.SearchType),
-		}
-		if geminiResponse.MinPrice != nil {
-			searchLogAttrs = append(searchLogAttrs, slog.Any("min_price", geminiResponse.MinPrice))
-		}
-		if geminiResponse.MaxPrice != nil {
-			searchLogAttrs = append(searchLogAttrs, slog.Any("max_price", geminiResponse.MaxPrice))
-		}
-		utils.LogInfo(ctx, "search request detected", searchLogAttrs...)
+	// Products of the last search are shown with the answer
+	if len(turn.products) > 0 {
+		products := turn.products
+		response.Products = products
+		response.SearchType = turn.search.SearchType
 
-		// Validate search phrase
-		if geminiResponse.SearchPhrase == "" {
-			utils.LogWarn(ctx, "empty search phrase in search request")
-			response.Output = "I need more details about what product you're looking for. Could you be more specific?"
-			response.Type = "dialogue"
-		} else {
-			products, translatedQuery, searchErr := p.performSearch(geminiResponse, req.Country, req.Language)
-			if searchErr != nil {
-				utils.LogWarn(ctx, "search failed", slog.Any("error", searchErr))
-				response.Output = "Sorry, I couldn't find any products. Please try different keywords."
-				response.Type = "text"
-			} else if len(products) > 0 {
-				response.Products = products
-				response.SearchType = geminiResponse.SearchType
-
-				// Update last product
-				if len(products) > 0 {
-					price := parsePrice(products[0].Price)
-					session.SearchState.LastProduct = &models.ProductInfo{
-						Name:  products[0].Name,
-						Price: price,
-					}
-				}
-
-				session.SearchState.SearchCount++
-				// Track anonymous search usage in Redis by browser ID
-				if req.UserID == nil && req.BrowserID != "" {
-					if err := p.container.CacheService.IncrementAnonymousSearchCount(req.BrowserID); err != nil {
-						utils.LogError(ctx, "failed to increment anonymous search count", err, slog.String("browser_id", req.BrowserID))
-					}
-				}
-				// Add products to assistant message BEFORE saving
-				assistantMessage.Products = products
-
-				// NEW: Update last search in conversation context
-				productInfoList := make([]models.ProductInfo, 0, len(products))
-				for _, p := range products {
-					price := parsePrice(p.Price)
-					productInfoList = append(productInfoList, models.ProductInfo{
-						Name:  p.Name,
-						Price: price,
-					})
-				}
-				contextExtractor := p.container.GeminiService.GetContextExtractor()
-				contextExtractor.UpdateLastSearch(session, translatedQuery, geminiResponse.Category, productInfoList, "")
+		// Update last product
+		price := productPrice(products[0])
+		session.SearchState.LastProduct = &models.ProductInfo{
+			Name:  products[0].Name,
+			Price: price,
+		}
 
-				// Save search history
-				p.saveSearchHistory(req, session, geminiResponse, translatedQuery, products)
-			}
+		session.SearchState.SearchCount++
+		p.container.UsageService.Record(ctx, usage, models.UsageSearches)
+		// Add products to assistant message BEFORE saving
+		assistantMessage.Products = products
+
+		// NEW: Update last search in conversation context
+		productInfoList := make([]models.ProductInfo, 0, len(products))
+		for _, p := range products {
+			price := productPrice(p)
+			productInfoList = append(productInfoList, models.ProductInfo{
+				Name:  p.Name,
+				Price: price,
+			})
 		}
+		contextExtractor := p.container.GeminiService.GetContextExtractor()
+		contextExtractor.UpdateLastSearch(session, turn.translatedQuery, turn.search.Category, productInfoList, "")
 	}
 
-	// Handle api_request (final product search to complete cycle)
-	if geminiResponse.ResponseType == "api_request" {
-		utils.LogInfo(ctx, "API request detected",
-			slog.String("api", geminiResponse.API),
-			slog.Any("params", geminiResponse.Params),
-		)
-
-		if geminiResponse.API == "google_shopping" && geminiResponse.Params != nil {
-			// Extract query from params
-			query, ok := geminiResponse.Params["q"].(string)
-			if !ok || query == "" {
-				utils.LogWarn(ctx, "missing or invalid 'q' parameter in api_request")
-				response.Output = "I need more details about what product you're looking for. Could you be more specific?"
-				response.Type = "dialogue"
-			} else {
-				// Perform the final search
-				utils.LogInfo(ctx, "final product search", slog.String("query", query))
-
-				// Create a temporary GeminiResponse for search
-				searchResp := &models.GeminiResponse{
-					SearchPhrase: query,
-					SearchType:   "exact", // Default to exact for final searches
-					Category:     geminiResponse.Category,
-					PriceFilter:  geminiResponse.PriceFilter,
-				}
-
-				products, translatedQuery, searchErr := p.performSearch(searchResp, req.Country, req.Language)
-				if searchErr != nil {
-					utils.LogWarn(ctx, "final search failed", slog.Any("error", searchErr))
-					response.Output = "Sorry, I couldn't find any products. Please try different keywords."
-					response.Type = "text"
-				} else if len(products) > 0 {
-					response.Products = products
-					response.SearchType = "exact"
-					response.Output = geminiResponse.Output // Use AI's message if provided
-
-					// Update last product
-					if len(products) > 0 {
-						price := parsePrice(products[0].Price)
-						session.SearchState.LastProduct = &models.ProductInfo{
-							Name:  products[0].Name,
-							Price: price,
-						}
-					}
-
-					session.SearchState.SearchCount++
-					// Track anonymous search usage in Redis by browser ID
-					if req.UserID == nil && req.BrowserID != "" {
-						if err := p.container.CacheService.IncrementAnonymousSearchCount(req.BrowserID); err != nil {
-							utils.LogError(ctx, "failed to increment anonymous search count", err, slog.String("browser_id", req.BrowserID))
-						}
-					}
-					// Add products to assistant message BEFORE saving
-					assistantMessage.Products = products
-
-					// NEW: Update last search in conversation context
-					productInfoList := make([]models.ProductInfo, 0, len(products))
-					for _, p := range products {
-						price := parsePrice(p.Price)
-						productInfoList = append(productInfoList, models.ProductInfo{
-							Name:  p.Name,
-							Price: price,
-						})
-					}
-					contextExtractor := p.container.GeminiService.GetContextExtractor()
-					contextExtractor.UpdateLastSearch(session, translatedQuery, searchResp.Category, productInfoList, "")
-
-					// Save search history
-					p.saveSearchHistory(req, session, searchResp, translatedQuery, products)
-
-					utils.LogInfo(ctx, "cycle completed", slog.Int("product_count", len(products)))
-				} else {
-					response.Output = "I couldn't find that exact product. Would you like to see similar alternatives?"
-					response.Type = "dialogue"
-				}
+	// Comparison table, with the verdict after the model's intro
+	if turn.comparison != nil {
+		response.Comparison = turn.comparison
+		if turn.comparison.Verdict != "" {
+			if response.Output != "" {
+				response.Output += "\n\n"
 			}
-		} else {
-			utils.LogWarn(ctx, "unsupported API", slog.String("api", geminiResponse.API))
-			response.Output = "I encountered an error processing your request. Please try again."
-			response.Type = "dialogue"
+			response.Output += turn.comparison.Verdict
 		}
 	}
 
@@ -514,7 +429,7 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 	}
 
 	// Add assistant response to cycle history
-	p.container.CycleService.AddToCycleHistoryInMemory(session, "assistant", geminiResponse.Output)
+	p.container.CycleService.AddToCycleHistoryInMemory(session, "assistant", answer.Message)
 
 	// NEW: Update conversation context periodically
 	contextExtractor := p.container.GeminiService.GetContextExtractor()
@@ -522,7 +437,10 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 
 	if contextOptimizer.ShouldUpdateContext(session) {
 		utils.LogInfo(ctx, "updating conversation context")
-		if err := contextExtractor.UpdateConversationContext(session, session.CycleState.CycleHistory); err != nil {
+		updateCtx, updateSpan := tracing.Start(ctx, "chat.context_update")
+		err := contextExtractor.UpdateConversationContext(updateCtx, session, session.CycleState.CycleHistory)
+		tracing.End(updateSpan, err)
+		if err != nil {
 			utils.LogWarn(ctx, "failed to update conversation context (non-critical)", slog.Any("error", err))
 			// This is not critical - conversation will continue with existing context
 		} else {
@@ -531,14 +449,18 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		// Context is updated in-memory, will be saved at the end
 	}
 
-	// Check if we need to start a new cycle (iteration limit reached)
+	// Check if we need to start a new cycle (finish_cycle or iteration limit reached)
 	// This checks BEFORE incrementing, so iteration 6 will trigger a new cycle
 	shouldStartNewCycle := p.container.CycleService.IncrementCycleIterationInMemory(session)
 
-	if shouldStartNewCycle {
-		utils.LogInfo(ctx, "iteration limit reached, starting new cycle",
-			slog.Int("max_iterations", services.MaxIterations),
-		)
+	if finishCycle || shouldStartNewCycle {
+		if finishCycle {
+			utils.LogInfo(ctx, "cycle finished, starting new cycle")
+		} else {
+			utils.LogInfo(ctx, "iteration limit reached, starting new cycle",
+				slog.Int("max_iterations", services.MaxIterations),
+			)
+		}
 
 		// Collect products from last cycle
 		products := []models.ProductInfo{}
@@ -561,9 +483,11 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		BackoffFactor: 2.0,
 	}
 
-	saveErr := utils.RetryWithBackoff(ctx, func() error {
+	saveCtx, saveSpan := tracing.Start(ctx, "chat.save_session")
+	saveErr := utils.RetryWithBackoff(saveCtx, func() error {
 		return p.container.SessionService.SaveSession(session)
 	}, retryConfig)
+	tracing.End(saveSpan, saveErr)
 
 	if saveErr != nil {
 		utils.LogError(ctx, "CRITICAL: failed to save session after retries", saveErr)
@@ -582,36 +506,109 @@ func (p *ChatProcessor) ProcessChat(req *ChatRequest) *ChatProcessorResponse {
 		return response
 	}
 
-	// Build search state response with real-time anonymous count
-	anonymousLimit = p.container.Config.AnonymousSearchLimit
+	response.SearchState = p.searchState(ctx, session, usage)
 
-	// Get real-time anonymous search count from Redis
-	var currentAnonymousCount int
-	if req.UserID == nil && req.BrowserID != "" {
-		count, err := p.container.CacheService.GetAnonymousSearchCount(req.BrowserID)
-		if err != nil {
-			utils.LogError(ctx, "failed to get anonymous search count for response", err)
-			count = 0
+	return response
+}
+
+// streamAnswer sends the message of the answer as token events, word by
+// word. Tool call arguments arrive whole, so the tokens are the message of
+// the answer call itself, exactly as it is stored.
+func streamAnswer(req *ChatRequest, message string) {
+	if req.OnEvent == nil {
+		return
+	}
+	for _, word := range strings.SplitAfter(message, " ") {
+		if word != "" {
+			req.emit(&ChatEvent{Type: ChatEventToken, Delta: word})
 		}
-		currentAnonymousCount = count
 	}
+}
 
-	requiresAuth := req.UserID == nil && currentAnonymousCount >= anonymousLimit
+// searchState reports the search state of the session with the remaining
+// allowance of the user's plan
+func (p *ChatProcessor) searchState(ctx context.Context, session *models.ChatSession, usage *services.UsageSubject) *models.SearchStateResponse {
+	quota := p.container.UsageService.Quota(ctx, usage)
+	outOfSearches := usage.Key != "" && quota.Searches.Remaining == 0
+	outOfMessages := usage.Key != "" && quota.Messages.Remaining == 0
 
-	response.SearchState = &models.SearchStateResponse{
-		Status:                 string(session.SearchState.Status),
-		Category:               session.SearchState.Category,
-		CanContinue:            session.SearchState.SearchCount < p.container.SessionService.GetMaxSearches() && !requiresAuth,
-		SearchCount:            session.SearchState.SearchCount,
-		MaxSearches:            p.container.SessionService.GetMaxSearches(),
-		AnonymousSearchUsed:    currentAnonymousCount,
-		AnonymousSearchLimit:   anonymousLimit,
-		RequiresAuthentication: requiresAuth,
+	state := &models.SearchStateResponse{
+		Status:      string(session.SearchState.Status),
+		Category:    session.SearchState.Category,
+		CanContinue: session.SearchState.SearchCount < p.container.SessionService.GetMaxSearches() && !outOfSearches && !outOfMessages,
+		SearchCount: session.SearchState.SearchCount,
+		MaxSearches: p.container.SessionService.GetMaxSearches(),
+		Quota:       quota,
 	}
 
+	// Visitors on the anonymous plan see their free searches and are asked
+	// to sign up (or verify their email) once they are used
+	if usage.Plan == models.PlanAnonymous {
+		state.AnonymousSearchUsed = quota.Searches.Used
+		state.AnonymousSearchLimit = quota.Searches.Limit
+		state.RequiresAuthentication = outOfSearches && !usage.Unverified
+		state.RequiresEmailVerification = outOfSearches && usage.Unverified
+	}
+
+	return state
+}
+
+// usageLimitResponse answers a message that exceeds a daily allowance of
+// the user's plan
+func (p *ChatProcessor) usageLimitResponse(ctx context.Context, req *ChatRequest, session *models.ChatSession, usage *services.UsageSubject, dimension string) *ChatProcessorResponse {
+	limit := p.container.UsageService.Limit(usage.Plan, dimension)
+	response := &ChatProcessorResponse{
+		Type:         "text",
+		SessionID:    req.SessionID,
+		MessageCount: session.MessageCount,
+		SearchState:  p.searchState(ctx, session, usage),
+	}
+	response.SearchState.CanContinue = false
+
+	switch {
+	case usage.Unverified:
+		response.Output = "Please confirm your email address to continue searching. Check your inbox for the link we sent you."
+		response.SearchState.RequiresEmailVerification = true
+		response.SearchState.Message = "Limit of unverified accounts reached - email verification required"
+	case usage.Plan == models.PlanAnonymous && dimension == models.UsageSearches:
+		response.Output = fmt.Sprintf("You've used all %d free searches! Please sign up or log in to continue searching for products.", limit)
+		response.SearchState.RequiresAuthentication = true
+		response.SearchState.Message = "Anonymous search limit reached - authentication required"
+	case usage.Plan == models.PlanAnonymous:
+		response.Output = "You've reached today's message limit for guests. Please sign up or log in to keep chatting."
+		response.SearchState.RequiresAuthentication = true
+		response.SearchState.Message = "Anonymous message limit reached - authentication required"
+	case dimension == models.UsageSearches:
+		response.Output = fmt.Sprintf("You've used all %d searches of your plan for today. Your searches reset at midnight UTC.", limit)
+		response.SearchState.Message = "Daily search limit reached"
+	default:
+		response.Output = fmt.Sprintf("You've reached your plan's limit of %d messages for today. You can continue chatting after midnight UTC.", limit)
+		response.SearchState.Message = "Daily message limit reached"
+	}
+
+	utils.LogInfo(ctx, "usage limit reached",
+		slog.String("plan", usage.Plan),
+		slog.String("dimension", dimension),
+		slog.Int("limit", limit),
+	)
+
 	return response
 }
 
+// CanChatInSession reports whether a sender may post to a session. New and
+// anonymous sessions are open; a session of a user is open to the owner and
+// the participants it is shared with
+func (p *ChatProcessor) CanChatInSession(ctx context.Context, sessionID string, userID *uuid.UUID) bool {
+	if sessionID == "" {
+		return true
+	}
+	session, err := p.container.SessionService.GetSession(sessionID)
+	if err != nil || session.UserID == nil {
+		return true
+	}
+	return userID != nil && p.container.SessionShareService.HasAccess(ctx, sessionID, *userID)
+}
+
 // getOrCreateSession handles session retrieval or creation
 func (p *ChatProcessor) getOrCreateSession(req *ChatRequest) (*models.ChatSession, error) {
 	var session *models.ChatSession
@@ -633,8 +630,10 @@ func (p *ChatProcessor) getOrCreateSession(req *ChatRequest) (*models.ChatSessio
 			// Session exists - preserve language, country, and currency from session
 			// Only update if explicitly changed by user (non-empty AND different from session)
 
-			// Update user_id if provided and different (user logged in)
-			if req.UserID != nil && (session.UserID == nil || *session.UserID != *req.UserID) {
+			// Link an anonymous session to the user who logged in. A session
+			// of another user is never taken over: participants chat in the
+			// owner's session, everyone else was refused before
+			if req.UserID != nil && session.UserID == nil {
 				utils.LogInfo(context.Background(), "linking session to user",
 					slog.String("user_id", req.UserID.String()),
 				)
@@ -688,20 +687,21 @@ func (p *ChatProcessor) getOrCreateSession(req *ChatRequest) (*models.ChatSessio
 	return session, nil
 }
 
-// performSearch executes product search with translation
-func (p *ChatProcessor) performSearch(geminiResp *models.GeminiResponse, country, language string) ([]models.ProductCard, string, error) {
-	ctx := context.Background()
-
+// performSearch executes product search with translation.
+// Price filters come in the session currency and are converted to the
+// currency of the searched country; results get converted prices.
+// fromCache reports results served from the search cache.
+func (p *ChatProcessor) performSearch(ctx context.Context, args *services.SearchProductsArgs, country, language, currency string) (products []models.ProductCard, translatedQuery string, fromCache bool, err error) {
 	// Translate query to English for better search results
-	utils.LogInfo(ctx, "translation check", slog.String("search_phrase", geminiResp.SearchPhrase))
+	utils.LogInfo(ctx, "translation check", slog.String("query", args.Query))
 
-	translatedQuery, err := p.container.GeminiService.TranslateToEnglish(geminiResp.SearchPhrase)
+	translatedQuery, err = p.container.GeminiService.TranslateToEnglish(ctx, args.Query)
 	if err != nil {
 		utils.LogWarn(ctx, "translation failed, using original query", slog.Any("error", err))
-		translatedQuery = geminiResp.SearchPhrase
-	} else if translatedQuery != geminiResp.SearchPhrase {
+		translatedQuery = args.Query
+	} else if translatedQuery != args.Query {
 		utils.LogInfo(ctx, "query translated",
-			slog.String("original", geminiResp.SearchPhrase),
+			slog.String("original", args.Query),
 			slog.String("translated", translatedQuery),
 		)
 	} else {
@@ -709,33 +709,55 @@ func (p *ChatProcessor) performSearch(geminiResp *models.GeminiResponse, country
 	}
 
 	// Log price range if provided
-	if geminiResp.MinPrice != nil || geminiResp.MaxPrice != nil {
+	if args.MinPrice != nil || args.MaxPrice != nil {
 		utils.LogInfo(ctx, "price range specified",
-			slog.Any("min_price", geminiResp.MinPrice),
-			slog.Any("max_price", geminiResp.MaxPrice),
+			slog.Any("min_price", args.MinPrice),
+			slog.Any("max_price", args.MaxPrice),
 		)
 	}
 
+	minPrice, maxPrice := p.container.FXService.NormalizePriceRange(args.MinPrice, args.MaxPrice, currency, country)
+
 	utils.LogInfo(ctx, "sending to SERP", slog.String("query", translatedQuery))
 
-	products, _, err := p.container.SerpService.SearchWithCache(
+	// SearchWithCache returns key index -1 for cached results
+	products, keyIndex, err := p.container.SerpService.SearchWithCache(
+		ctx,
 		translatedQuery,
-		geminiResp.SearchType,
+		args.SearchType,
 		country,
-		geminiResp.MinPrice,
-		geminiResp.MaxPrice,
+		minPrice,
+		maxPrice,
 		p.container.CacheService,
 	)
 
 	if err != nil {
-		return nil, translatedQuery, err
+		return nil, translatedQuery, false, err
 	}
 
-	return products, translatedQuery, nil
+	p.container.FXService.ConvertProductCards(products, country, currency)
+
+	return products, translatedQuery, keyIndex == -1, nil
 }
 
-// saveSearchHistory saves the search to history
-func (p *ChatProcessor) saveSearchHistory(req *ChatRequest, session *models.ChatSession, geminiResp *models.GeminiResponse, translatedQuery string, products []models.ProductCard) {
+// costContext attributes the paid calls made with ctx to a user and
+// session. A new request ID is added if ctx has none.
+func costContext(ctx context.Context, userID *uuid.UUID, sessionID string) context.Context {
+	if utils.RequestIDFromContext(ctx) == "" {
+		ctx = utils.WithRequestID(ctx, uuid.New().String())
+	}
+	if userID != nil {
+		ctx = utils.WithUserID(ctx, userID.String())
+	}
+	if sessionID != "" {
+		ctx = utils.WithSessionID(ctx, sessionID)
+	}
+	return ctx
+}
+
+// saveSearchHistory saves the search to history. Results fresh from the
+// provider are also recorded as price observations.
+func (p *ChatProcessor) saveSearchHistory(req *ChatRequest, session *models.ChatSession, args *services.SearchProductsArgs, translatedQuery string, products []models.ProductCard, fromCache bool) {
 	// Set currency from request or use default
 	currency := req.Currency
 	if currency == "" {
@@ -751,10 +773,10 @@ func (p *ChatProcessor) saveSearchHistory(req *ChatRequest, session *models.Chat
 	history := &models.SearchHistory{
 		UserID:         req.UserID,
 		SessionID:      sessionIDStr,
-		SearchQuery:    geminiResp.SearchPhrase,
+		SearchQuery:    args.Query,
 		OptimizedQuery: &translatedQuery,
-		SearchType:     geminiResp.SearchType,
-		Category:       &geminiResp.Category,
+		SearchType:     args.SearchType,
+		Category:       &args.Category,
 		CountryCode:    req.Country,
 		LanguageCode:   req.Language,
 		Currency:       currency,
@@ -769,22 +791,26 @@ func (p *ChatProcessor) saveSearchHistory(req *ChatRequest, session *models.Chat
 			utils.LogWarn(ctx, "failed to save search history", slog.Any("error", err))
 		} else {
 			utils.LogInfo(ctx, "search history saved",
-				slog.String("search_query", geminiResp.SearchPhrase),
+				slog.String("search_query", args.Query),
 				slog.Int("result_count", len(products)),
 			)
 		}
+
+		// Cached results were recorded when they were fetched
+		if fromCache {
+			return
+		}
+		if _, err := p.container.PriceHistoryService.RecordSearchResults(ctx, products, req.Country); err != nil {
+			utils.LogWarn(ctx, "failed to record price observations", slog.Any("error", err))
+		}
 	}()
 }
 
-// parsePrice extracts numeric price from price string
-func parsePrice(priceStr string) float64 {
-	priceStr = strings.ReplaceAll(priceStr, "$", "")
-	priceStr = strings.ReplaceAll(priceStr, "€", "")
-	priceStr = strings.ReplaceAll(priceStr, "£", "")
-	priceStr = strings.ReplaceAll(priceStr, "CHF", "")
-	priceStr = strings.TrimSpace(priceStr)
-	priceStr = strings.ReplaceAll(priceStr, ",", "")
-
-	price, _ := strconv.ParseFloat(priceStr, 64)
-	return price
+// productPrice returns the numeric price of a card in the session currency
+// when a converted price is available
+func productPrice(product models.ProductCard) float64 {
+	if product.ConvertedPrice != "" {
+		return services.ParsePrice(product.ConvertedPrice)
+	}
+	return services.ParsePrice(product.Price)
 }
@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+const (
+	// maxToolRounds bounds the model calls per chat turn; the last round
+	// may only answer
+	maxToolRounds = 4
+
+	// Results sent back to the model, the user sees every product
+	maxToolResultProducts = 8
+	maxToolResultSpecs    = 20
+	maxToolResultOffers   = 5
+)
+
+// toolTurn is what the data tools found during one chat turn
+type toolTurn struct {
+	req     *ChatRequest
+	session *models.ChatSession
+	usage   *services.UsageSubject
+
+	// Last search with results: shown with the answer
+	search          *services.SearchProductsArgs
+	translatedQuery string
+	products        []models.ProductCard
+
+	comparison *models.ComparisonResponse
+}
+
+// nextToolCalls asks the dialogue for its next tool calls, retrying
+// failed model calls
+func (p *ChatProcessor) nextToolCalls(ctx context.Context, dialogue *services.Dialogue) ([]services.LLMToolCall, error) {
+	const maxProcessingRetries = 2
+
+	var lastErr error
+	for attempt := 0; attempt <= maxProcessingRetries; attempt++ {
+		if attempt > 0 {
+			// Wait a bit before retry (500ms, 1s)
+			time.Sleep(time.Duration(500*attempt) * time.Millisecond)
+			utils.LogInfo(ctx, "retry processing attempt",
+				slog.Int("attempt", attempt+1),
+				slog.Int("max_attempts", maxProcessingRetries+1),
+			)
+		}
+
+		attemptCtx, span := tracing.Start(ctx, "chat.dialogue_turn", attribute.Int("chat.attempt", attempt+1))
+		calls, err := dialogue.Next(attemptCtx)
+		tracing.End(span, err)
+		if err == nil {
+			if attempt > 0 {
+				utils.LogInfo(ctx, "processing succeeded on retry",
+					slog.Int("attempt", attempt+1),
+				)
+			}
+			return calls, nil
+		}
+
+		lastErr = err
+		utils.LogError(ctx, "gemini processing error", err,
+			slog.Int("attempt", attempt+1),
+			slog.Int("max_attempts", maxProcessingRetries+1),
+		)
+	}
+
+	return nil, lastErr
+}
+
+// executeTool runs a data tool call. Failures are reported to the model
+// in the result so it can tell the user or try something else.
+func (p *ChatProcessor) executeTool(ctx context.Context, turn *toolTurn, call *services.LLMToolCall) services.LLMToolResult {
+	var result map[string]interface{}
+	var err error
+
+	ctx, span := tracing.Start(ctx, "chat.tool "+call.Name)
+	defer func() { tracing.End(span, err) }()
+
+	switch call.Name {
+	case services.ToolSearchProducts:
+		result, err = p.toolSearchProducts(ctx, turn, call)
+	case services.ToolGetProductDetails:
+		result, err = p.toolGetProductDetails(ctx, turn, call)
+	case services.ToolCompareProducts:
+		result, err = p.toolCompareProducts(ctx, turn, call)
+	case services.ToolGetPriceHistory:
+		result, err = p.toolGetPriceHistory(ctx, turn, call)
+	default:
+		err = fmt.Errorf("unknown tool %q", call.Name)
+	}
+
+	if err != nil {
+		utils.LogWarn(ctx, "tool call failed",
+			slog.String("tool", call.Name),
+			slog.Any("error", err),
+		)
+		result = map[string]interface{}{"error": err.Error()}
+	}
+
+	return services.LLMToolResult{
+		CallID: call.ID,
+		Name:   call.Name,
+		Result: result,
+	}
+}
+
+func (p *ChatProcessor) toolSearchProducts(ctx context.Context, turn *toolTurn, call *services.LLMToolCall) (map[string]interface{}, error) {
+	var args services.SearchProductsArgs
+	if err := call.DecodeArgs(&args); err != nil {
+		return nil, err
+	}
+	args.Query = strings.TrimSpace(args.Query)
+	if args.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	searchLogAttrs := []any{
+		slog.String("query", args.Query),
+		slog.String("search_type", args.SearchType),
+	}
+	if args.MinPrice != nil {
+		searchLogAttrs = append(searchLogAttrs, slog.Any("min_price", args.MinPrice))
+	}
+	if args.MaxPrice != nil {
+		searchLogAttrs = append(searchLogAttrs, slog.Any("max_price", args.MaxPrice))
+	}
+	utils.LogInfo(ctx, "search request detected", searchLogAttrs...)
+
+	req := turn.req
+	req.emit(&ChatEvent{Type: ChatEventSearching, SearchPhrase: args.Query})
+
+	products, translatedQuery, fromCache, err := p.performSearch(ctx, &args, req.Country, req.Language, req.Currency)
+	if err != nil {
+		utils.LogWarn(ctx, "search failed", slog.Any("error", err))
+		return nil, errors.New("search failed, try different keywords")
+	}
+	if len(products) == 0 {
+		return map[string]interface{}{
+			"products": []interface{}{},
+			"message":  "No products found. Try a broader query or suggest alternatives.",
+		}, nil
+	}
+
+	req.emit(&ChatEvent{Type: ChatEventProductsPartial, Products: products})
+
+	turn.search = &args
+	turn.translatedQuery = translatedQuery
+	turn.products = products
+
+	// Save search history
+	p.saveSearchHistory(req, turn.session, &args, translatedQuery, products, fromCache)
+
+	summaries := make([]map[string]interface{}, 0, maxToolResultProducts)
+	for i, product := range products {
+		if i >= maxToolResultProducts {
+			break
+		}
+		summary := map[string]interface{}{
+			"name":       product.Name,
+			"price":      displayPrice(product.Price, product.ConvertedPrice),
+			"page_token": product.PageToken,
+		}
+		if product.Badge != "" {
+			summary["badge"] = product.Badge
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return map[string]interface{}{
+		"products": summaries,
+		"total":    len(products),
+	}, nil
+}
+
+func (p *ChatProcessor) toolGetProductDetails(ctx context.Context, turn *toolTurn, call *services.LLMToolCall) (map[string]interface{}, error) {
+	var args services.GetProductDetailsArgs
+	if err := call.DecodeArgs(&args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.PageToken) == "" {
+		return nil, errors.New("page_token is required")
+	}
+
+	utils.LogInfo(ctx, "product details requested", slog.String("page_token", args.PageToken))
+
+	if !p.container.UsageService.Consume(ctx, turn.usage, models.UsageProductDetails) {
+		return nil, errors.New("the daily product details allowance of the user's plan is used up, answer from the search results")
+	}
+
+	productData, err := fetchProductDetails(ctx, p.container, args.PageToken, turn.req.Country)
+	if err != nil {
+		utils.LogWarn(ctx, "product details failed", slog.Any("error", err))
+		return nil, errors.New("product details are not available")
+	}
+
+	details, err := FormatProductDetails(productData)
+	if err != nil {
+		return nil, errors.New("product details are not available")
+	}
+	p.container.FXService.ConvertOffers(details.Offers, turn.req.Country, turn.req.Currency)
+
+	specs := make([]string, 0, maxToolResultSpecs)
+	for i, spec := range details.Specifications {
+		if i >= maxToolResultSpecs {
+			break
+		}
+		specs = append(specs, spec.Title+": "+spec.Value)
+	}
+
+	offers := make([]map[string]interface{}, 0, maxToolResultOffers)
+	for i, offer := range details.Offers {
+		if i >= maxToolResultOffers {
+			break
+		}
+		offers = append(offers, map[string]interface{}{
+			"merchant": offer.Merchant,
+			"price":    displayPrice(offer.Price, offer.ConvertedPrice),
+		})
+	}
+
+	return map[string]interface{}{
+		"title":          details.Title,
+		"price":          details.Price,
+		"rating":         details.Rating,
+		"reviews":        details.Reviews,
+		"specifications": specs,
+		"offers":         offers,
+	}, nil
+}
+
+func (p *ChatProcessor) toolCompareProducts(ctx context.Context, turn *toolTurn, call *services.LLMToolCall) (map[string]interface{}, error) {
+	var args services.CompareProductsArgs
+	if err := call.DecodeArgs(&args); err != nil {
+		return nil, err
+	}
+
+	utils.LogInfo(ctx, "compare request detected", slog.Any("products", args.Products))
+
+	// Products of this turn are not stored yet - they are the newest results
+	recentMessages, err := p.container.MessageService.GetRecentMessages(turn.session.SessionID, 20)
+	if err != nil {
+		utils.LogWarn(ctx, "failed to load recent messages for compare", slog.Any("error", err))
+	}
+	if len(turn.products) > 0 {
+		recentMessages = append(recentMessages, &models.Message{Products: turn.products})
+	}
+
+	pageTokens := matchComparedProducts(recentMessages, args.Products)
+	if len(pageTokens) < minCompareProducts {
+		return nil, errors.New("fewer than 2 of these products were found in the search results, ask the user which products to compare")
+	}
+
+	comparison, err := BuildComparison(ctx, p.container, &models.CompareRequest{
+		PageTokens: pageTokens,
+		Country:    turn.req.Country,
+		Language:   turn.req.Language,
+		Currency:   turn.req.Currency,
+	}, turn.usage)
+	if errors.Is(err, errCompareLimitReached) {
+		return nil, errors.New("the daily product details allowance of the user's plan doesn't cover this comparison, answer from the search results")
+	}
+	if err != nil {
+		utils.LogWarn(ctx, "comparison failed", slog.Any("error", err))
+		return nil, errors.New("the products could not be compared right now")
+	}
+	turn.comparison = comparison
+
+	compared := make([]map[string]interface{}, 0, len(comparison.Products))
+	for _, product := range comparison.Products {
+		if product.Error != "" {
+			continue
+		}
+		compared = append(compared, map[string]interface{}{
+			"title":  product.Title,
+			"price":  product.Price,
+			"rating": product.Rating,
+		})
+	}
+
+	return map[string]interface{}{
+		"products": compared,
+		"verdict":  comparison.Verdict,
+		"note":     "The comparison table and the verdict are shown below your message, don't repeat them",
+	}, nil
+}
+
+func (p *ChatProcessor) toolGetPriceHistory(ctx context.Context, turn *toolTurn, call *services.LLMToolCall) (map[string]interface{}, error) {
+	var args services.GetPriceHistoryArgs
+	if err := call.DecodeArgs(&args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Product) == "" {
+		return nil, errors.New("product is required")
+	}
+
+	utils.LogInfo(ctx, "price history requested", slog.String("product", args.Product))
+
+	history, err := p.container.PriceHistoryService.GetDialoguePriceHistory(ctx, args.Product, turn.req.Country)
+	if err != nil {
+		utils.LogWarn(ctx, "price history failed", slog.Any("error", err))
+		return nil, errors.New("price history is not available")
+	}
+	if history.Stats == nil {
+		return map[string]interface{}{
+			"message": "No prices recorded for this product yet",
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"currency":     history.Currency,
+		"days":         history.Days,
+		"observations": history.Stats.Count,
+		"min":          history.Stats.Min,
+		"median":       history.Stats.Median,
+		"max":          history.Stats.Max,
+		"current":      history.Stats.Current,
+		"verdict":      history.Stats.Verdict,
+	}, nil
+}
+
+// displayPrice prefers the price converted to the session currency
+func displayPrice(price, convertedPrice string) string {
+	if convertedPrice != "" {
+		return convertedPrice
+	}
+	return price
+}
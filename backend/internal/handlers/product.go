@@ -1,12 +1,17 @@
 package handlers
 
 import (
-	"time"
+	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 
 	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
 	"mylittleprice/internal/models"
+	"mylittleprice/internal/utils"
 )
 
 type ProductHandler struct {
@@ -39,17 +44,19 @@ func (h *ProductHandler) HandleProductDetails(c *fiber.Ctx) error {
 		req.Country = h.container.Config.DefaultCountry
 	}
 
-	cachedProduct, err := h.container.CacheService.GetProductByToken(req.PageToken)
-	if err == nil && cachedProduct != nil {
-		return h.formatProductResponse(c, cachedProduct)
+	var userID *uuid.UUID
+	if uid, ok := middleware.GetUserID(c); ok {
+		userID = &uid
+	}
+	if !consumeProductDetails(c.Context(), h.container, userID, req.BrowserID) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
+			Error:   "usage_limit_reached",
+			Message: "You've reached today's product details limit of your plan",
+		})
 	}
 
-	startTime := time.Now()
-	productDetails, keyIndex, err := h.container.SerpService.GetProductDetailsByToken(req.PageToken)
-	responseTime := time.Since(startTime)
-
-	h.container.SerpRotator.RecordUsage(keyIndex, err == nil, responseTime)
-
+	ctx := costContext(c.UserContext(), userID, "")
+	productDetails, err := fetchProductDetails(ctx, h.container, req.PageToken, req.Country)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
 			Error:   "fetch_error",
@@ -57,14 +64,52 @@ func (h *ProductHandler) HandleProductDetails(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.container.CacheService.SetProductByToken(req.PageToken, productDetails, h.container.Config.CacheImmersiveTTL); err != nil {
-		c.Context().Logger().Printf("Warning: Failed to cache product details: %v", err)
+	return h.formatProductResponse(c, productDetails, req.Country, req.Currency)
+}
+
+// consumeProductDetails uses one product details lookup of the daily
+// allowance of the visitor's plan, false once it is used up
+func consumeProductDetails(ctx context.Context, c *container.Container, userID *uuid.UUID, browserID string) bool {
+	usage := c.UsageService.Subject(ctx, userID, browserID)
+	return c.UsageService.Consume(ctx, usage, models.UsageProductDetails)
+}
+
+// fetchProductDetails returns product details from cache, or fetches them
+// from the search provider, caches them and records their prices. A fetch
+// is recorded as a cost of the request in ctx.
+func fetchProductDetails(ctx context.Context, c *container.Container, pageToken, country string) (map[string]interface{}, error) {
+	cachedProduct, err := c.CacheService.GetProductByToken(pageToken)
+	if err == nil && cachedProduct != nil {
+		return cachedProduct, nil
 	}
 
-	return h.formatProductResponse(c, productDetails)
+	// Key usage is recorded by the provider for every attempt
+	productDetails, _, err := c.SerpService.GetProductDetailsByToken(ctx, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.CacheService.SetProductByToken(pageToken, productDetails, c.Config.CacheImmersiveTTL); err != nil {
+		fmt.Printf("⚠️ Failed to cache product details: %v\n", err)
+	}
+
+	recordProductDetailPrices(c, productDetails, country)
+
+	return productDetails, nil
+}
+
+// recordProductDetailPrices stores the offers of freshly fetched product
+// details as price observations without blocking the response
+func recordProductDetailPrices(c *container.Container, productDetails map[string]interface{}, country string) {
+	go func() {
+		ctx := context.Background()
+		if _, err := c.PriceHistoryService.RecordProductDetails(ctx, productDetails, country); err != nil {
+			utils.LogWarn(ctx, "failed to record price observations", slog.Any("error", err))
+		}
+	}()
 }
 
-func (h *ProductHandler) formatProductResponse(c *fiber.Ctx, productData map[string]interface{}) error {
+func (h *ProductHandler) formatProductResponse(c *fiber.Ctx, productData map[string]interface{}, country, currency string) error {
 	response, err := FormatProductDetails(productData)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
@@ -73,5 +118,7 @@ func (h *ProductHandler) formatProductResponse(c *fiber.Ctx, productData map[str
 		})
 	}
 
+	h.container.FXService.ConvertOffers(response.Offers, country, currency)
+
 	return c.JSON(response)
 }
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/utils"
+)
+
+// SessionShareHandler serves invite links and participants of shared
+// chat sessions. Live updates go to the session's WebSocket channel.
+type SessionShareHandler struct {
+	container *container.Container
+}
+
+func NewSessionShareHandler(c *container.Container) *SessionShareHandler {
+	return &SessionShareHandler{container: c}
+}
+
+// CreateInvite creates an invite link to one of the user's sessions
+// POST /api/sessions/:id/invites
+func (h *SessionShareHandler) CreateInvite(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	invite, err := h.container.SessionShareService.CreateInvite(c.Context(), sessionID, userID)
+	if err != nil {
+		return sessionShareErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(invite)
+}
+
+// AcceptInvite joins the session of an invite link
+// POST /api/sessions/invites/:token/accept
+func (h *SessionShareHandler) AcceptInvite(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	response, err := h.container.SessionShareService.AcceptInvite(c.Context(), c.Params("token"), userID)
+	if err != nil {
+		return sessionShareErrorResponse(c, err)
+	}
+
+	h.publishPresence(c.Context(), response.SessionID)
+
+	return c.JSON(response)
+}
+
+// ListParticipants returns the owner and invited users of a session with
+// their presence
+// GET /api/sessions/:id/participants
+func (h *SessionShareHandler) ListParticipants(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	if !h.container.SessionShareService.HasAccess(c.Context(), sessionID, userID) {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Session not found",
+		})
+	}
+
+	participants, err := h.container.SessionShareService.ListParticipants(c.Context(), sessionID)
+	if err != nil {
+		return sessionShareErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SessionParticipantsResponse{
+		SessionID:    sessionID,
+		Participants: participants,
+	})
+}
+
+// RemoveParticipant revokes a participant's access. Participants can
+// remove themselves to leave the session.
+// DELETE /api/sessions/:id/participants/:userId
+func (h *SessionShareHandler) RemoveParticipant(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	participantID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid user ID",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	if err := h.container.SessionShareService.RemoveParticipant(c.Context(), sessionID, userID, participantID); err != nil {
+		return sessionShareErrorResponse(c, err)
+	}
+
+	// Open connections of the removed user leave the session
+	if err := h.container.PubSubService.BroadcastToSession(sessionID, wsTypeParticipantRemoved, &WSResponse{
+		Type:        wsTypeParticipantRemoved,
+		SessionID:   sessionID,
+		Participant: &models.SessionParticipant{UserID: participantID, Role: models.SessionRoleParticipant},
+	}, nil); err != nil {
+		fmt.Printf("⚠️ Failed to broadcast %s: %v\n", wsTypeParticipantRemoved, err)
+	}
+	h.publishPresence(c.Context(), sessionID)
+
+	return c.JSON(fiber.Map{
+		"message": "Participant removed successfully",
+	})
+}
+
+// publishPresence sends the current participants to the session's
+// connections on every server
+func (h *SessionShareHandler) publishPresence(ctx context.Context, sessionID string) {
+	participants, err := h.container.SessionShareService.ListParticipants(ctx, sessionID)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load participants of session %s: %v\n", sessionID, err)
+		return
+	}
+
+	if err := h.container.PubSubService.BroadcastToSession(sessionID, wsTypePresence, &WSResponse{
+		Type:         wsTypePresence,
+		SessionID:    sessionID,
+		Participants: participants,
+	}, nil); err != nil {
+		fmt.Printf("⚠️ Failed to broadcast %s: %v\n", wsTypePresence, err)
+	}
+}
+
+func sessionShareErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSessionNotShareable):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Session not found or not owned by you",
+		})
+	case errors.Is(err, services.ErrInviteNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "invite_not_found",
+			Message: "Invite link is invalid, expired or already used",
+		})
+	case errors.Is(err, services.ErrParticipantLimit):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "participant_limit_reached",
+			Message: "This session has reached the maximum number of participants",
+		})
+	case errors.Is(err, services.ErrParticipantNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Participant not found",
+		})
+	case errors.Is(err, services.ErrParticipantNotAllowed):
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "Only the session owner can remove other participants",
+		})
+	default:
+		fmt.Printf("❌ Session sharing error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process session sharing request",
+		})
+	}
+}
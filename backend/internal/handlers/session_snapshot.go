@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/utils"
+)
+
+// SessionSnapshotHandler publishes read-only snapshots of chat sessions
+type SessionSnapshotHandler struct {
+	container *container.Container
+}
+
+func NewSessionSnapshotHandler(c *container.Container) *SessionSnapshotHandler {
+	return &SessionSnapshotHandler{container: c}
+}
+
+// CreateSnapshot freezes the session's messages into a public snapshot
+// POST /api/sessions/:id/share
+func (h *SessionSnapshotHandler) CreateSnapshot(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	var req models.CreateSessionSnapshotRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+	}
+	if req.ExpiresInDays < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "expires_in_days must not be negative",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	snapshot, err := h.container.SessionSnapshotService.CreateSnapshot(c.Context(), sessionID, userID, req.ExpiresInDays)
+	if err != nil {
+		return snapshotErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(snapshot)
+}
+
+// ListSnapshots returns the snapshots the user made of a session
+// GET /api/sessions/:id/share
+func (h *SessionSnapshotHandler) ListSnapshots(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	snapshots, err := h.container.SessionSnapshotService.ListSnapshots(c.Context(), sessionID, userID)
+	if err != nil {
+		return snapshotErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SessionSnapshotsResponse{
+		SessionID: sessionID,
+		Snapshots: snapshots,
+	})
+}
+
+// RevokeSnapshot takes a snapshot down; its link stops working
+// DELETE /api/sessions/:id/share/:slug
+func (h *SessionSnapshotHandler) RevokeSnapshot(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	sessionID := utils.ExtractRawSessionID(c.Params("id"))
+	if err := h.container.SessionSnapshotService.RevokeSnapshot(c.Context(), sessionID, c.Params("slug"), userID); err != nil {
+		return snapshotErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Snapshot revoked successfully",
+	})
+}
+
+// GetSharedSnapshot serves a snapshot to anyone with the link
+// GET /api/shared/:slug
+func (h *SessionSnapshotHandler) GetSharedSnapshot(c *fiber.Ctx) error {
+	snapshot, err := h.container.SessionSnapshotService.GetSharedSnapshot(c.Context(), c.Params("slug"))
+	if err != nil {
+		return snapshotErrorResponse(c, err)
+	}
+
+	// Revocation must take effect right away
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(snapshot)
+}
+
+func snapshotErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSessionNotShareable):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Session not found or not owned by you",
+		})
+	case errors.Is(err, services.ErrSnapshotNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "snapshot_not_found",
+			Message: "This link is invalid, expired or was revoked",
+		})
+	case errors.Is(err, services.ErrSnapshotEmpty):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "empty_session",
+			Message: "The session has no messages to share yet",
+		})
+	default:
+		fmt.Printf("❌ Snapshot error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process snapshot request",
+		})
+	}
+}
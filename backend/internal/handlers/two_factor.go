@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+// Two-factor authentication: enrolment, confirmation and disabling for
+// signed-in users, and the second step of a login that returned a
+// challenge.
+
+// GetTwoFactorStatus returns whether 2FA is enabled
+// GET /api/auth/2fa
+func (h *AuthHandler) GetTwoFactorStatus(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	status, err := h.container.AuthService.GetTwoFactorStatus(userID)
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.JSON(status)
+}
+
+// EnrollTwoFactor starts enrolment and returns the secret and otpauth URI
+// for the authenticator app
+// POST /api/auth/2fa/enroll
+func (h *AuthHandler) EnrollTwoFactor(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	enrollment, err := h.container.AuthService.BeginTwoFactorEnrollment(userID)
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.JSON(enrollment)
+}
+
+// ConfirmTwoFactor enables 2FA with the first code from the app. Returns
+// the recovery codes and new tokens, as all others are revoked.
+// POST /api/auth/2fa/confirm
+func (h *AuthHandler) ConfirmTwoFactor(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "Code is required",
+		})
+	}
+
+	response, err := h.container.AuthService.ConfirmTwoFactorEnrollment(userID, req.Code, deviceInfo(c))
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	h.recordTwoFactorChange(c.Context(), models.AuditActionTwoFactorEnabled, userID, c.IP())
+
+	return c.JSON(response)
+}
+
+// DisableTwoFactor turns 2FA off with a current code or a recovery code.
+// Returns new tokens, as all others are revoked.
+// POST /api/auth/2fa/disable
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+	}
+
+	var req models.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "Code is required",
+		})
+	}
+
+	authResp, err := h.container.AuthService.DisableTwoFactor(userID, req.Code, deviceInfo(c))
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	h.recordTwoFactorChange(c.Context(), models.AuditActionTwoFactorDisabled, userID, c.IP())
+
+	return c.JSON(authResp)
+}
+
+// VerifyTwoFactor completes a login with the challenge token and a TOTP
+// or recovery code
+// POST /api/auth/2fa/verify
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var req models.TwoFactorVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ChallengeToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "validation_error",
+			Message: "Challenge token and code are required",
+		})
+	}
+
+	authResp, err := h.container.AuthService.VerifyTwoFactorChallenge(req.ChallengeToken, req.Code, deviceInfo(c))
+	if err != nil {
+		return twoFactorErrorResponse(c, err)
+	}
+
+	return c.JSON(authResp)
+}
+
+func (h *AuthHandler) recordTwoFactorChange(ctx context.Context, action string, userID uuid.UUID, ip string) {
+	err := h.container.AuditService.Record(ctx, &models.AuditEntry{
+		Action:    action,
+		ActorID:   &userID,
+		SubjectID: &userID,
+		IPAddress: ip,
+	})
+	if err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+}
+
+func twoFactorErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrTwoFactorInvalidCode):
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "invalid_code",
+			Message: "Invalid or already used code",
+		})
+	case errors.Is(err, services.ErrTwoFactorChallenge):
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "invalid_challenge",
+			Message: "Login attempt expired, please sign in again",
+		})
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "already_enabled",
+			Message: "Two-factor authentication is already enabled",
+		})
+	case errors.Is(err, services.ErrTwoFactorNotEnabled):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "not_enabled",
+			Message: "Two-factor authentication is not enabled",
+		})
+	case errors.Is(err, services.ErrTwoFactorNoEnrollment):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "no_enrollment",
+			Message: "Enrolment expired, please start again",
+		})
+	case errors.Is(err, services.ErrTwoFactorUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.ErrorResponse{
+			Error:   "unavailable",
+			Message: "Two-factor authentication is not available",
+		})
+	default:
+		fmt.Printf("❌ Two-factor authentication error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process two-factor authentication request",
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"mylittleprice/internal/container"
+	"mylittleprice/internal/middleware"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+)
+
+type WatchHandler struct {
+	container *container.Container
+}
+
+func NewWatchHandler(c *container.Container) *WatchHandler {
+	return &WatchHandler{container: c}
+}
+
+// CreateWatch starts watching a product for a price drop
+// POST /api/watches
+func (h *WatchHandler) CreateWatch(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	var req models.CreateWatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+	}
+
+	watch, err := h.container.WatchService.CreateWatch(c.Context(), userID, &req)
+	if err != nil {
+		return watchErrorResponse(c, err)
+	}
+
+	// Let the user's other devices refresh their watchlist
+	if err := h.container.PubSubService.BroadcastToUser(userID, "watch_created", fiber.Map{
+		"type":  "watch_created",
+		"watch": watch,
+	}); err != nil {
+		fmt.Printf("⚠️ Failed to broadcast watch_created: %v\n", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(watch)
+}
+
+// ListWatches returns the authenticated user's watches
+// GET /api/watches
+func (h *WatchHandler) ListWatches(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	watches, err := h.container.WatchService.ListWatches(c.Context(), userID)
+	if err != nil {
+		return watchErrorResponse(c, err)
+	}
+
+	return c.JSON(models.WatchListResponse{
+		Items: watches,
+		Total: len(watches),
+	})
+}
+
+// DeleteWatch stops watching a product
+// DELETE /api/watches/:id
+func (h *WatchHandler) DeleteWatch(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+	}
+
+	watchID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid watch ID",
+		})
+	}
+
+	if err := h.container.WatchService.DeleteWatch(c.Context(), userID, watchID); err != nil {
+		return watchErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Watch deleted successfully",
+	})
+}
+
+func watchErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidWatch):
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:   "invalid_watch",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrWatchLimitReached):
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:   "watch_limit_reached",
+			Message: "You have reached the maximum number of watched products",
+		})
+	case errors.Is(err, services.ErrWatchNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Watch not found",
+		})
+	default:
+		fmt.Printf("❌ Watch error: %v\n", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process watch request",
+		})
+	}
+}
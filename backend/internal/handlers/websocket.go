@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -9,26 +11,40 @@ import (
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"mylittleprice/internal/container"
 	"mylittleprice/internal/models"
 	"mylittleprice/internal/services"
+	"mylittleprice/internal/tracing"
 	"mylittleprice/internal/utils"
 )
 
 type Client struct {
-	Conn   *websocket.Conn
-	UserID *uuid.UUID // nil for anonymous users
+	Conn     *websocket.Conn
+	UserID   *uuid.UUID      // nil for anonymous users
+	Sessions map[string]bool // Shared sessions joined with join_session
+	writeMu  sync.Mutex      // Broadcasts write from other goroutines
+}
+
+// writeJSON serializes writes to the connection
+func (cl *Client) writeJSON(v interface{}) error {
+	cl.writeMu.Lock()
+	defer cl.writeMu.Unlock()
+	return cl.Conn.WriteJSON(v)
 }
 
 type WSHandler struct {
-	container   *container.Container
-	processor   *ChatProcessor
-	clients     map[string]*Client            // clientID -> Client
-	userConns   map[uuid.UUID]map[string]bool // userID -> set of clientIDs
-	mu          sync.RWMutex
-	pubsub      *services.PubSubService // Redis Pub/Sub for cross-server communication
-	rateLimiter *utils.WSRateLimiter    // WebSocket message rate limiter
+	container    *container.Container
+	processor    *ChatProcessor
+	clients      map[string]*Client            // clientID -> Client
+	conns        map[*websocket.Conn]*Client   // Conn -> Client, for locked writes
+	userConns    map[uuid.UUID]map[string]bool // userID -> set of clientIDs
+	sessionConns map[string]map[string]bool    // sessionID -> set of clientIDs that joined it
+	mu           sync.RWMutex
+	pubsub       *services.PubSubService // Redis Pub/Sub for cross-server communication
+	rateLimiter  *utils.WSRateLimiter    // WebSocket message rate limiter
 }
 
 func NewWSHandler(c *container.Container) *WSHandler {
@@ -39,18 +55,27 @@ func NewWSHandler(c *container.Container) *WSHandler {
 	rateLimiter := utils.NewWSRateLimiter(utils.DefaultWSRateLimitConfig())
 
 	handler := &WSHandler{
-		container:   c,
-		processor:   NewChatProcessor(c),
-		clients:     make(map[string]*Client),
-		userConns:   make(map[uuid.UUID]map[string]bool),
-		pubsub:      pubsub,
-		rateLimiter: rateLimiter,
+		container:    c,
+		processor:    NewChatProcessor(c),
+		clients:      make(map[string]*Client),
+		conns:        make(map[*websocket.Conn]*Client),
+		userConns:    make(map[uuid.UUID]map[string]bool),
+		sessionConns: make(map[string]map[string]bool),
+		pubsub:       pubsub,
+		rateLimiter:  rateLimiter,
 	}
 
 	// Subscribe to all users broadcast channel
 	// This allows this server to receive messages from other servers
 	pubsub.SubscribeToAllUsers(handler.handleBroadcastMessage)
 
+	// Per-user channels carry messages published by background jobs
+	// and REST handlers (e.g. price alerts)
+	pubsub.SubscribeToUserChannels(handler.handleBroadcastMessage)
+
+	// Per-session channels mirror shared sessions to their participants
+	pubsub.SubscribeToSessionChannels(handler.handleSessionBroadcast)
+
 	log.Printf("🚀 WebSocket handler initialized with Pub/Sub and Rate Limiting (ServerID: %s)", pubsub.GetServerID()[:8])
 
 	return handler
@@ -70,6 +95,12 @@ type WSMessage struct {
 	AccessToken     string                 `json:"access_token,omitempty"` // Optional JWT token for authentication
 	Preferences     map[string]interface{} `json:"preferences,omitempty"`  // For preferences sync
 	SavedSearch     *models.SavedSearch    `json:"saved_search,omitempty"` // For saved search sync
+	ProductName     string                 `json:"product_name,omitempty"` // For create_watch
+	TargetPrice     float64                `json:"target_price,omitempty"` // For create_watch
+	PageTokens      []string               `json:"page_tokens,omitempty"`  // For compare
+	Stream          bool                   `json:"stream,omitempty"`       // Chat: send progress events before the final "done"
+	MessageID       string                 `json:"message_id,omitempty"`   // For resume_stream
+	Typing          bool                   `json:"typing,omitempty"`       // For typing: false when the user stopped
 }
 
 type WSResponse struct {
@@ -85,6 +116,16 @@ type WSResponse struct {
 	ProductDetails *models.ProductDetailsResponse `json:"product_details,omitempty"`
 	Error          string                         `json:"error,omitempty"`
 	Message        string                         `json:"message,omitempty"`
+	Watch          *models.Watch                  `json:"watch,omitempty"`
+	Watches        []models.Watch                 `json:"watches,omitempty"`
+	Comparison     *models.ComparisonResponse     `json:"comparison,omitempty"`
+	ResponseType   string                         `json:"response_type,omitempty"` // "done"/"stream_state": type of the assistant response
+	Delta          string                         `json:"delta,omitempty"`         // "token": next piece of the output
+	SearchPhrase   string                         `json:"search_phrase,omitempty"` // "searching"
+	Status         string                         `json:"status,omitempty"`        // "stream_state": last event of the stream
+	Participants   []models.SessionParticipant    `json:"participants,omitempty"`  // "session_joined"/"presence"
+	Participant    *models.SessionParticipant     `json:"participant,omitempty"`   // Shared sessions: author of a mirrored message or typing event
+	Typing         bool                           `json:"typing,omitempty"`        // "typing"
 }
 
 func (h *WSHandler) HandleWebSocket(c *websocket.Conn) {
@@ -105,6 +146,7 @@ func (h *WSHandler) HandleWebSocket(c *websocket.Conn) {
 	}
 	h.addClient(clientID, client)
 	defer h.removeClient(clientID)
+	defer h.leaveAllSessions(clientID)
 
 	for {
 		var msg WSMessage
@@ -134,13 +176,25 @@ func (h *WSHandler) HandleWebSocket(c *websocket.Conn) {
 			}
 		}
 
-		h.handleMessage(c, &msg, clientID)
+		ctx, span := startMessageSpan(msg.Type)
+		h.handleMessage(ctx, c, &msg, clientID)
+		span.End()
 	}
 
 	log.Printf("🔌 Client disconnected: %s", clientID)
 }
 
-func (h *WSHandler) handleMessage(c *websocket.Conn, msg *WSMessage, clientID string) {
+// startMessageSpan starts the trace of a WebSocket message. Keep-alive
+// pings are not traced.
+func startMessageSpan(msgType string) (context.Context, trace.Span) {
+	ctx := context.Background()
+	if msgType == "ping" {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return tracing.Start(ctx, "ws.message "+msgType, attribute.String("ws.message.type", msgType))
+}
+
+func (h *WSHandler) handleMessage(ctx context.Context, c *websocket.Conn, msg *WSMessage, clientID string) {
 	// Skip rate limiting for ping messages
 	if msg.Type != "ping" {
 		// Check connection-level rate limit
@@ -151,11 +205,13 @@ func (h *WSHandler) handleMessage(c *websocket.Conn, msg *WSMessage, clientID st
 			return
 		}
 
-		// Check user-level rate limit if authenticated
+		// Check user-level rate limit of the user's plan if authenticated
 		if msg.AccessToken != "" {
 			claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken)
 			if err == nil {
-				allowed, reason, retryAfter := h.rateLimiter.CheckUser(claims.UserID)
+				usage := h.container.UsageService.Subject(ctx, &claims.UserID, msg.BrowserID)
+				limit := h.container.UsageService.WSMessagesPerMinute(usage.Plan)
+				allowed, reason, retryAfter := h.rateLimiter.CheckUserLimit(claims.UserID, limit)
 				if !allowed {
 					h.recordRateLimitViolation("user")
 					h.sendRateLimitError(c, reason, retryAfter)
@@ -167,10 +223,13 @@ func (h *WSHandler) handleMessage(c *websocket.Conn, msg *WSMessage, clientID st
 
 	switch msg.Type {
 	case "chat":
-		h.handleChat(c, msg, clientID)
+		h.handleChat(ctx, c, msg, clientID)
 	case "product_details":
-		h.handleProductDetails(c, msg)
+		h.handleProductDetails(ctx, c, msg)
+	case "compare":
+		h.handleCompare(ctx, c, msg)
 	case "ping":
+		h.touchSessions(clientID)
 		h.sendResponse(c, &WSResponse{Type: "pong"})
 	case "sync_preferences":
 		h.handleSyncPreferences(c, msg, clientID)
@@ -178,12 +237,24 @@ func (h *WSHandler) handleMessage(c *websocket.Conn, msg *WSMessage, clientID st
 		h.handleSyncSavedSearch(c, msg, clientID)
 	case "sync_session":
 		h.handleSyncSession(c, msg, clientID)
+	case "create_watch":
+		h.handleCreateWatch(c, msg, clientID)
+	case "list_watches":
+		h.handleListWatches(c, msg)
+	case "resume_stream":
+		h.handleResumeStream(c, msg)
+	case "join_session":
+		h.handleJoinSession(c, msg, clientID)
+	case "leave_session":
+		h.handleLeaveSession(c, msg, clientID)
+	case "typing":
+		h.handleTyping(c, msg, clientID)
 	default:
 		h.sendError(c, "unknown_message_type", "Unknown message type")
 	}
 }
 
-func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID string) {
+func (h *WSHandler) handleChat(ctx context.Context, c *websocket.Conn, msg *WSMessage, clientID string) {
 	// Extract user ID from access token if provided
 	var userID *uuid.UUID
 	if msg.AccessToken != "" {
@@ -214,6 +285,13 @@ func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID strin
 		sessionID = baseSessionID
 	}
 
+	// Messages to a user's session, and the mirror to its participants, are
+	// only accepted from the owner and current participants
+	if !h.processor.CanChatInSession(ctx, sessionID, userID) {
+		h.sendError(c, "session_ownership", "You don't have access to this session")
+		return
+	}
+
 	// Generate message IDs upfront for consistent deduplication across devices
 	userMessageID := uuid.New().String()
 	assistantMessageID := uuid.New().String()
@@ -229,6 +307,10 @@ func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID strin
 		h.broadcastToUser(*userID, userMsgSync, clientID)
 	}
 
+	// Shared session: participants see the message and the answer live
+	mirror := h.newSessionMirror(sessionID, userID, clientID)
+	mirror.userMessage(userMessageID, msg.Message)
+
 	// Process chat using shared processor
 	processorReq := &ChatRequest{
 		SessionID:         sessionID, // Use base session ID
@@ -244,10 +326,21 @@ func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID strin
 		AssistantMessageID: assistantMessageID, // Pass pre-generated assistant message ID
 	}
 
-	result := h.processor.ProcessChat(processorReq)
+	var stream *chatStream
+	if msg.Stream {
+		stream = h.newChatStream(c, assistantMessageID, sessionID)
+	}
+	processorReq.OnEvent = chatEventHandler(stream, mirror, assistantMessageID)
+
+	result := h.processor.ProcessChat(ctx, processorReq)
 
 	// Handle errors
 	if result.Error != nil {
+		mirror.failed(assistantMessageID, result.Error.Code, result.Error.Message)
+		if stream != nil {
+			stream.fail(result.Error.Code, result.Error.Message)
+			return
+		}
 		h.sendError(c, result.Error.Code, result.Error.Message)
 		return
 	}
@@ -267,10 +360,15 @@ func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID strin
 		SessionID:    result.SessionID,
 		MessageCount: result.MessageCount,
 		SearchState:  result.SearchState,
+		Comparison:   result.Comparison,
 	}
 
 	// Send response to the sender
-	h.sendResponse(c, response)
+	if stream != nil {
+		stream.finish(response)
+	} else {
+		h.sendResponse(c, response)
+	}
 
 	// Broadcast assistant message to other devices of the same user
 	if userID != nil {
@@ -285,12 +383,15 @@ func (h *WSHandler) handleChat(c *websocket.Conn, msg *WSMessage, clientID strin
 			SessionID:    result.SessionID,
 			MessageCount: result.MessageCount,
 			SearchState:  result.SearchState,
+			Comparison:   result.Comparison,
 		}
 		h.broadcastToUser(*userID, syncMsg, clientID)
 	}
+
+	mirror.assistantMessage(response)
 }
 
-func (h *WSHandler) handleProductDetails(c *websocket.Conn, msg *WSMessage) {
+func (h *WSHandler) handleProductDetails(ctx context.Context, c *websocket.Conn, msg *WSMessage) {
 	if msg.PageToken == "" {
 		h.sendError(c, "validation_error", "Page token is required")
 		return
@@ -311,37 +412,72 @@ func (h *WSHandler) handleProductDetails(c *websocket.Conn, msg *WSMessage) {
 		sessionID = baseSessionID
 	}
 
-	cachedProduct, err := h.container.CacheService.GetProductByToken(msg.PageToken)
-	if err == nil && cachedProduct != nil {
-		h.sendProductDetailsResponse(c, cachedProduct, sessionID)
+	var userID *uuid.UUID
+	if msg.AccessToken != "" {
+		if claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken); err == nil {
+			userID = &claims.UserID
+		}
+	}
+	if !consumeProductDetails(ctx, h.container, userID, msg.BrowserID) {
+		h.sendError(c, "usage_limit_reached", "You've reached today's product details limit of your plan")
 		return
 	}
 
-	startTime := time.Now()
-	productDetails, keyIndex, err := h.container.SerpService.GetProductDetailsByToken(msg.PageToken)
-	responseTime := time.Since(startTime)
-
-	h.container.SerpRotator.RecordUsage(keyIndex, err == nil, responseTime)
-
+	productDetails, err := fetchProductDetails(costContext(ctx, userID, sessionID), h.container, msg.PageToken, msg.Country)
 	if err != nil {
 		h.sendError(c, "fetch_error", "Failed to fetch product details")
 		return
 	}
 
-	if err := h.container.CacheService.SetProductByToken(msg.PageToken, productDetails, h.container.Config.CacheImmersiveTTL); err != nil {
-		fmt.Printf("⚠️ Failed to cache product details: %v\n", err)
+	h.sendProductDetailsResponse(c, productDetails, sessionID, msg.Country, msg.Currency)
+}
+
+// handleCompare compares 2-5 products side by side
+func (h *WSHandler) handleCompare(ctx context.Context, c *websocket.Conn, msg *WSMessage) {
+	var userID *uuid.UUID
+	if msg.AccessToken != "" {
+		if claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken); err == nil {
+			userID = &claims.UserID
+		}
+	}
+
+	usage := h.container.UsageService.Subject(ctx, userID, msg.BrowserID)
+	comparison, err := BuildComparison(costContext(ctx, userID, ""), h.container, &models.CompareRequest{
+		PageTokens: msg.PageTokens,
+		Country:    msg.Country,
+		Language:   msg.Language,
+		Currency:   msg.Currency,
+	}, usage)
+	if err != nil {
+		if errors.Is(err, errInvalidCompareRequest) {
+			h.sendError(c, "validation_error", err.Error())
+			return
+		}
+		if errors.Is(err, errCompareLimitReached) {
+			h.sendError(c, "usage_limit_reached", "You've reached today's product details limit of your plan")
+			return
+		}
+		h.sendError(c, "fetch_error", err.Error())
+		return
 	}
 
-	h.sendProductDetailsResponse(c, productDetails, sessionID)
+	h.sendResponse(c, &WSResponse{
+		Type:       "compare",
+		SessionID:  msg.SessionID,
+		Output:     comparison.Verdict,
+		Comparison: comparison,
+	})
 }
 
-func (h *WSHandler) sendProductDetailsResponse(c *websocket.Conn, productData map[string]interface{}, sessionID string) {
+func (h *WSHandler) sendProductDetailsResponse(c *websocket.Conn, productData map[string]interface{}, sessionID, country, currency string) {
 	details, err := FormatProductDetails(productData)
 	if err != nil {
 		h.sendError(c, "parse_error", err.Error())
 		return
 	}
 
+	h.container.FXService.ConvertOffers(details.Offers, country, currency)
+
 	h.sendResponse(c, &WSResponse{
 		Type:           "product_details",
 		ProductDetails: details,
@@ -353,6 +489,7 @@ func (h *WSHandler) addClient(id string, client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.clients[id] = client
+	h.conns[client.Conn] = client
 }
 
 func (h *WSHandler) removeClient(id string) {
@@ -378,6 +515,7 @@ func (h *WSHandler) removeClient(id string) {
 	// Remove rate limit data for this connection
 	h.rateLimiter.RemoveConnection(id)
 
+	delete(h.conns, client.Conn)
 	delete(h.clients, id)
 }
 
@@ -433,7 +571,7 @@ func (h *WSHandler) broadcastToUser(userID uuid.UUID, response *WSResponse, excl
 				continue
 			}
 
-			if err := client.Conn.WriteJSON(response); err != nil {
+			if err := client.writeJSON(response); err != nil {
 				log.Printf("❌ Failed to broadcast to client %s: %v", cid, err)
 			}
 		}
@@ -463,22 +601,9 @@ func (h *WSHandler) handleBroadcastMessage(msg *services.BroadcastMessage) {
 		return
 	}
 
-	// Convert payload to WSResponse
-	payload, ok := msg.Payload.(*WSResponse)
+	payload, ok := broadcastPayload(msg)
 	if !ok {
-		// Try to unmarshal from map
-		data, err := json.Marshal(msg.Payload)
-		if err != nil {
-			log.Printf("❌ Failed to marshal broadcast payload: %v", err)
-			return
-		}
-
-		var wsResp WSResponse
-		if err := json.Unmarshal(data, &wsResp); err != nil {
-			log.Printf("❌ Failed to unmarshal broadcast payload to WSResponse: %v", err)
-			return
-		}
-		payload = &wsResp
+		return
 	}
 
 	// Send to all local clients for this user
@@ -491,7 +616,7 @@ func (h *WSHandler) handleBroadcastMessage(msg *services.BroadcastMessage) {
 			continue
 		}
 
-		if err := client.Conn.WriteJSON(payload); err != nil {
+		if err := client.writeJSON(payload); err != nil {
 			log.Printf("❌ Failed to send broadcast message to client %s: %v", cid, err)
 		} else {
 			log.Printf("📨 Broadcast from server %s delivered to client %s", msg.ServerID[:8], cid[:8])
@@ -499,6 +624,28 @@ func (h *WSHandler) handleBroadcastMessage(msg *services.BroadcastMessage) {
 	}
 }
 
+// broadcastPayload converts a broadcast payload to WSResponse
+func broadcastPayload(msg *services.BroadcastMessage) (*WSResponse, bool) {
+	payload, ok := msg.Payload.(*WSResponse)
+	if ok {
+		return payload, true
+	}
+
+	// Try to unmarshal from map
+	data, err := json.Marshal(msg.Payload)
+	if err != nil {
+		log.Printf("❌ Failed to marshal broadcast payload: %v", err)
+		return nil, false
+	}
+
+	var wsResp WSResponse
+	if err := json.Unmarshal(data, &wsResp); err != nil {
+		log.Printf("❌ Failed to unmarshal broadcast payload to WSResponse: %v", err)
+		return nil, false
+	}
+	return &wsResp, true
+}
+
 // handleSyncPreferences handles preference synchronization across devices
 func (h *WSHandler) handleSyncPreferences(c *websocket.Conn, msg *WSMessage, clientID string) {
 	// Extract user ID from access token
@@ -579,13 +726,94 @@ func (h *WSHandler) handleSyncSession(c *websocket.Conn, msg *WSMessage, clientI
 	h.broadcastToUser(claims.UserID, syncMsg, clientID)
 }
 
-func (h *WSHandler) sendResponse(c *websocket.Conn, response *WSResponse) {
-	if err := c.WriteJSON(response); err != nil {
+// handleCreateWatch adds a product to the user's price watchlist
+func (h *WSHandler) handleCreateWatch(c *websocket.Conn, msg *WSMessage, clientID string) {
+	if msg.AccessToken == "" {
+		h.sendError(c, "auth_required", "Authentication required for price watches")
+		return
+	}
+
+	claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken)
+	if err != nil {
+		h.sendError(c, "invalid_token", "Invalid access token")
+		return
+	}
+
+	watch, err := h.container.WatchService.CreateWatch(context.Background(), claims.UserID, &models.CreateWatchRequest{
+		PageToken:   msg.PageToken,
+		ProductName: msg.ProductName,
+		TargetPrice: msg.TargetPrice,
+		Currency:    msg.Currency,
+		Country:     msg.Country,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidWatch):
+			h.sendError(c, "invalid_watch", err.Error())
+		case errors.Is(err, services.ErrWatchLimitReached):
+			h.sendError(c, "watch_limit_reached", "You have reached the maximum number of watched products")
+		default:
+			log.Printf("❌ Failed to create watch for user %s: %v", claims.UserID.String(), err)
+			h.sendError(c, "watch_failed", "Failed to create price watch")
+		}
+		return
+	}
+
+	response := &WSResponse{
+		Type:      "watch_created",
+		SessionID: msg.SessionID,
+		Watch:     watch,
+	}
+
+	h.sendResponse(c, response)
+	h.broadcastToUser(claims.UserID, response, clientID)
+}
+
+// handleListWatches returns the user's price watchlist
+func (h *WSHandler) handleListWatches(c *websocket.Conn, msg *WSMessage) {
+	if msg.AccessToken == "" {
+		h.sendError(c, "auth_required", "Authentication required for price watches")
+		return
+	}
+
+	claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken)
+	if err != nil {
+		h.sendError(c, "invalid_token", "Invalid access token")
+		return
+	}
+
+	watches, err := h.container.WatchService.ListWatches(context.Background(), claims.UserID)
+	if err != nil {
+		log.Printf("❌ Failed to list watches for user %s: %v", claims.UserID.String(), err)
+		h.sendError(c, "watch_failed", "Failed to load price watches")
+		return
+	}
+
+	h.sendResponse(c, &WSResponse{
+		Type:      "watches",
+		SessionID: msg.SessionID,
+		Watches:   watches,
+	})
+}
+
+func (h *WSHandler) sendResponse(c *websocket.Conn, response *WSResponse) bool {
+	h.mu.RLock()
+	client, ok := h.conns[c]
+	h.mu.RUnlock()
+
+	var err error
+	if ok {
+		err = client.writeJSON(response)
+	} else {
+		err = c.WriteJSON(response)
+	}
+	if err != nil {
 		log.Printf("❌ Failed to send response: %v", err)
 		h.recordMessageSendFailed(response.Type, "write_error")
-	} else {
-		h.recordMessageSent(response.Type)
+		return false
 	}
+	h.recordMessageSent(response.Type)
+	return true
 }
 
 func (h *WSHandler) sendError(c *websocket.Conn, errorCode, message string) {
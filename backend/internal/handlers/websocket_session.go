@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
+
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/utils"
+)
+
+const (
+	wsTypeSessionJoined      = "session_joined"
+	wsTypeSessionLeft        = "session_left"
+	wsTypePresence           = "presence"
+	wsTypeTyping             = "typing"
+	wsTypeParticipantRemoved = "participant_removed"
+)
+
+// Shared sessions: a connection joins a session with join_session and then
+// receives everything happening in it - messages of every participant,
+// progress events of the answers, typing indicators and presence changes.
+// Messages go to the connections on this server directly and to the other
+// servers through the session's Pub/Sub channel.
+
+// handleJoinSession starts mirroring a session to the connection. Requires
+// the owner or an invited user.
+func (h *WSHandler) handleJoinSession(c *websocket.Conn, msg *WSMessage, clientID string) {
+	if msg.AccessToken == "" {
+		h.sendError(c, "auth_required", "Authentication required for shared sessions")
+		return
+	}
+
+	claims, err := h.container.JWTService.ValidateAccessToken(msg.AccessToken)
+	if err != nil {
+		h.sendError(c, "invalid_token", "Invalid access token")
+		return
+	}
+
+	if err := h.container.SessionOwnershipChecker.ValidateWebSocketSessionOwnership(msg.SessionID, &claims.UserID); err != nil {
+		h.sendError(c, "session_ownership", "You don't have access to this session")
+		return
+	}
+	sessionID := utils.ExtractRawSessionID(msg.SessionID)
+
+	h.mu.Lock()
+	client, exists := h.clients[clientID]
+	if exists {
+		if client.Sessions == nil {
+			client.Sessions = make(map[string]bool)
+		}
+		client.Sessions[sessionID] = true
+		if _, ok := h.sessionConns[sessionID]; !ok {
+			h.sessionConns[sessionID] = make(map[string]bool)
+		}
+		h.sessionConns[sessionID][clientID] = true
+	}
+	h.mu.Unlock()
+	if !exists {
+		return
+	}
+
+	ctx := context.Background()
+	if err := h.container.SessionShareService.SetPresence(ctx, sessionID, claims.UserID, clientID, true); err != nil {
+		log.Printf("⚠️ Failed to save presence in session %s: %v", sessionID, err)
+	}
+
+	participants, err := h.container.SessionShareService.ListParticipants(ctx, sessionID)
+	if err != nil {
+		log.Printf("⚠️ Failed to load participants of session %s: %v", sessionID, err)
+	}
+
+	log.Printf("👥 Client %s joined session %s", clientID, sessionID)
+
+	h.sendResponse(c, &WSResponse{
+		Type:         wsTypeSessionJoined,
+		SessionID:    sessionID,
+		Participants: participants,
+	})
+	h.broadcastToSession(sessionID, &WSResponse{
+		Type:         wsTypePresence,
+		SessionID:    sessionID,
+		Participants: participants,
+	}, clientID, nil)
+}
+
+// handleLeaveSession stops mirroring a session to the connection
+func (h *WSHandler) handleLeaveSession(c *websocket.Conn, msg *WSMessage, clientID string) {
+	sessionID := utils.ExtractRawSessionID(msg.SessionID)
+	h.leaveSession(clientID, sessionID)
+	h.sendResponse(c, &WSResponse{
+		Type:      wsTypeSessionLeft,
+		SessionID: sessionID,
+	})
+}
+
+// handleTyping forwards a typing indicator to the other participants
+func (h *WSHandler) handleTyping(c *websocket.Conn, msg *WSMessage, clientID string) {
+	sessionID := utils.ExtractRawSessionID(msg.SessionID)
+
+	h.mu.RLock()
+	client, exists := h.clients[clientID]
+	joined := exists && client.Sessions[sessionID] && client.UserID != nil
+	var userID uuid.UUID
+	if joined {
+		userID = *client.UserID
+	}
+	h.mu.RUnlock()
+
+	if !joined {
+		h.sendError(c, "not_joined", "Join the session before sending typing indicators")
+		return
+	}
+
+	h.touchSessions(clientID)
+
+	// The user's own devices don't show their typing
+	h.broadcastToSession(sessionID, &WSResponse{
+		Type:        wsTypeTyping,
+		SessionID:   sessionID,
+		Participant: h.container.SessionShareService.GetParticipant(sessionID, userID),
+		Typing:      msg.Typing,
+	}, clientID, &userID)
+}
+
+// leaveSession removes the connection from a joined session and tells the
+// others
+func (h *WSHandler) leaveSession(clientID, sessionID string) {
+	h.mu.Lock()
+	client, exists := h.clients[clientID]
+	joined := exists && client.Sessions[sessionID]
+	var userID *uuid.UUID
+	if joined {
+		userID = client.UserID
+		delete(client.Sessions, sessionID)
+		if connSet, ok := h.sessionConns[sessionID]; ok {
+			delete(connSet, clientID)
+			if len(connSet) == 0 {
+				delete(h.sessionConns, sessionID)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	if !joined || userID == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if err := h.container.SessionShareService.SetPresence(ctx, sessionID, *userID, clientID, false); err != nil {
+		log.Printf("⚠️ Failed to clear presence in session %s: %v", sessionID, err)
+	}
+	h.publishPresence(sessionID)
+}
+
+// leaveAllSessions runs when the connection closes
+func (h *WSHandler) leaveAllSessions(clientID string) {
+	h.mu.RLock()
+	var sessionIDs []string
+	if client, exists := h.clients[clientID]; exists {
+		for sessionID := range client.Sessions {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sessionID := range sessionIDs {
+		h.leaveSession(clientID, sessionID)
+	}
+}
+
+// touchSessions keeps the connection present in its joined sessions
+func (h *WSHandler) touchSessions(clientID string) {
+	h.mu.RLock()
+	client, exists := h.clients[clientID]
+	if !exists || client.UserID == nil || len(client.Sessions) == 0 {
+		h.mu.RUnlock()
+		return
+	}
+	userID := *client.UserID
+	sessionIDs := make([]string, 0, len(client.Sessions))
+	for sessionID := range client.Sessions {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	h.mu.RUnlock()
+
+	for _, sessionID := range sessionIDs {
+		if err := h.container.SessionShareService.SetPresence(context.Background(), sessionID, userID, clientID, true); err != nil {
+			log.Printf("⚠️ Failed to refresh presence in session %s: %v", sessionID, err)
+		}
+	}
+}
+
+// publishPresence sends the current participants to every connection of
+// the session
+func (h *WSHandler) publishPresence(sessionID string) {
+	participants, err := h.container.SessionShareService.ListParticipants(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("⚠️ Failed to load participants of session %s: %v", sessionID, err)
+		return
+	}
+
+	h.broadcastToSession(sessionID, &WSResponse{
+		Type:         wsTypePresence,
+		SessionID:    sessionID,
+		Participants: participants,
+	}, "", nil)
+}
+
+// broadcastToSession sends a message to the connections that joined a
+// session except the sender (and the devices of excludeUserID, if set),
+// on this server and through Redis Pub/Sub on the others
+func (h *WSHandler) broadcastToSession(sessionID string, response *WSResponse, excludeClientID string, excludeUserID *uuid.UUID) {
+	h.deliverToSession(sessionID, response, excludeClientID, excludeUserID)
+
+	if err := h.pubsub.BroadcastToSession(sessionID, response.Type, response, excludeUserID); err != nil {
+		log.Printf("⚠️ Failed to broadcast to session %s: %v", sessionID, err)
+	} else {
+		h.recordBroadcastSent()
+	}
+}
+
+// handleSessionBroadcast handles session messages from other servers and
+// from REST handlers
+func (h *WSHandler) handleSessionBroadcast(msg *services.BroadcastMessage) {
+	h.recordBroadcastReceived()
+
+	payload, ok := broadcastPayload(msg)
+	if !ok {
+		return
+	}
+
+	h.deliverToSession(msg.SessionID, payload, "", msg.ExcludeUserID)
+
+	// The removed user's connections stop receiving the session
+	if payload.Type == wsTypeParticipantRemoved && payload.Participant != nil {
+		h.detachUser(msg.SessionID, payload.Participant.UserID)
+	}
+}
+
+func (h *WSHandler) deliverToSession(sessionID string, response *WSResponse, excludeClientID string, excludeUserID *uuid.UUID) {
+	h.mu.RLock()
+	targets := make(map[string]*Client, len(h.sessionConns[sessionID]))
+	for cid := range h.sessionConns[sessionID] {
+		client, exists := h.clients[cid]
+		if !exists || cid == excludeClientID {
+			continue
+		}
+		if excludeUserID != nil && client.UserID != nil && *client.UserID == *excludeUserID {
+			continue
+		}
+		targets[cid] = client
+	}
+	h.mu.RUnlock()
+
+	for cid, client := range targets {
+		if err := client.writeJSON(response); err != nil {
+			log.Printf("❌ Failed to send session message to client %s: %v", cid, err)
+		}
+	}
+}
+
+// detachUser removes the local connections of a user from a session
+func (h *WSHandler) detachUser(sessionID string, userID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for cid := range h.sessionConns[sessionID] {
+		client, exists := h.clients[cid]
+		if !exists || client.UserID == nil || *client.UserID != userID {
+			continue
+		}
+		delete(client.Sessions, sessionID)
+		delete(h.sessionConns[sessionID], cid)
+	}
+	if len(h.sessionConns[sessionID]) == 0 {
+		delete(h.sessionConns, sessionID)
+	}
+}
+
+// sessionMirror copies one chat exchange to the participants of a shared
+// session. For sessions nobody was invited to it does nothing.
+type sessionMirror struct {
+	h               *WSHandler
+	sessionID       string
+	author          *models.SessionParticipant
+	authorID        *uuid.UUID
+	excludeClientID string
+}
+
+func (h *WSHandler) newSessionMirror(sessionID string, userID *uuid.UUID, clientID string) *sessionMirror {
+	if sessionID == "" || !h.container.SessionShareService.IsShared(context.Background(), sessionID) {
+		return nil
+	}
+
+	mirror := &sessionMirror{
+		h:               h,
+		sessionID:       sessionID,
+		authorID:        userID,
+		excludeClientID: clientID,
+	}
+	if userID != nil {
+		mirror.author = h.container.SessionShareService.GetParticipant(sessionID, *userID)
+	}
+	return mirror
+}
+
+// userMessage mirrors the message the author sent. The author's devices
+// already got it as user_message_sync.
+func (m *sessionMirror) userMessage(messageID, text string) {
+	if m == nil {
+		return
+	}
+	m.h.broadcastToSession(m.sessionID, &WSResponse{
+		Type:        "user_message_sync",
+		MessageID:   messageID,
+		Output:      text,
+		SessionID:   m.sessionID,
+		Participant: m.author,
+	}, m.excludeClientID, m.authorID)
+}
+
+// event mirrors a progress event of the answer, to the author's other
+// devices too
+func (m *sessionMirror) event(event *ChatEvent, messageID string) {
+	if m == nil {
+		return
+	}
+	m.h.broadcastToSession(m.sessionID, eventResponse(event, messageID, m.sessionID), m.excludeClientID, nil)
+}
+
+// assistantMessage mirrors the final answer. The author's devices already
+// got it as assistant_message_sync.
+func (m *sessionMirror) assistantMessage(response *WSResponse) {
+	if m == nil {
+		return
+	}
+	sync := *response
+	sync.Type = "assistant_message_sync"
+	sync.Participant = m.author
+	m.h.broadcastToSession(m.sessionID, &sync, m.excludeClientID, m.authorID)
+}
+
+// failed ends the mirrored progress events of an answer that failed
+func (m *sessionMirror) failed(messageID, code, message string) {
+	if m == nil {
+		return
+	}
+	m.h.broadcastToSession(m.sessionID, &WSResponse{
+		Type:      wsTypeStreamError,
+		MessageID: messageID,
+		SessionID: m.sessionID,
+		Error:     code,
+		Message:   message,
+	}, m.excludeClientID, nil)
+}
+
+// chatEventHandler sends the progress events of an answer to the sender's
+// stream and the session's participants. nil when neither wants them.
+func chatEventHandler(stream *chatStream, mirror *sessionMirror, messageID string) func(*ChatEvent) {
+	if stream == nil && mirror == nil {
+		return nil
+	}
+	return func(event *ChatEvent) {
+		if stream != nil {
+			stream.onEvent(event)
+		}
+		mirror.event(event, messageID)
+	}
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+
+	"mylittleprice/internal/models"
+)
+
+const (
+	wsTypeStreamDone  = "done"
+	wsTypeStreamState = "stream_state"
+	wsTypeStreamError = "error"
+
+	// Token snapshots are written to Redis at most this often
+	streamSnapshotInterval = time.Second
+)
+
+// chatStream forwards ChatProcessor progress events of one assistant
+// message to the sender's socket. Every event carries the pre-generated
+// assistant message ID, and a snapshot is kept in Redis so a client that
+// reconnects can catch up with resume_stream.
+type chatStream struct {
+	h         *WSHandler
+	conn      *websocket.Conn
+	state     models.StreamState
+	lastSaved time.Time
+	connLost  bool // Stop writing after the first failed write
+}
+
+func (h *WSHandler) newChatStream(c *websocket.Conn, messageID, sessionID string) *chatStream {
+	return &chatStream{
+		h:    h,
+		conn: c,
+		state: models.StreamState{
+			MessageID: messageID,
+			SessionID: sessionID,
+		},
+	}
+}
+
+func (s *chatStream) onEvent(event *ChatEvent) {
+	s.state.Status = event.Type
+	switch event.Type {
+	case ChatEventToken:
+		s.state.Output += event.Delta
+	case ChatEventSearching:
+		s.state.SearchPhrase = event.SearchPhrase
+	case ChatEventProductsPartial:
+		s.state.Products = event.Products
+	}
+
+	s.send(eventResponse(event, s.state.MessageID, s.state.SessionID))
+
+	if event.Type != ChatEventToken || time.Since(s.lastSaved) >= streamSnapshotInterval {
+		s.save()
+	}
+}
+
+// eventResponse is the WebSocket message of a progress event
+func eventResponse(event *ChatEvent, messageID, sessionID string) *WSResponse {
+	response := &WSResponse{
+		Type:      event.Type,
+		MessageID: messageID,
+		SessionID: sessionID,
+	}
+
+	switch event.Type {
+	case ChatEventToken:
+		response.Delta = event.Delta
+	case ChatEventSearching:
+		response.SearchPhrase = event.SearchPhrase
+	case ChatEventProductsPartial:
+		response.Products = event.Products
+	}
+	return response
+}
+
+// finish sends the final response as a "done" event. The response keeps
+// every field of a non-streamed reply; its type moves to ResponseType.
+func (s *chatStream) finish(response *WSResponse) {
+	done := *response
+	done.Type = wsTypeStreamDone
+	done.ResponseType = response.Type
+	done.MessageID = s.state.MessageID
+
+	s.state.Status = wsTypeStreamDone
+	s.state.Output = response.Output
+	s.state.Products = response.Products
+	s.state.ResponseType = response.Type
+	s.state.QuickReplies = response.QuickReplies
+	s.state.Done = true
+	s.save()
+
+	s.send(&done)
+}
+
+// fail ends the stream with an error tied to the message
+func (s *chatStream) fail(code, message string) {
+	s.state.Status = wsTypeStreamError
+	s.state.Done = true
+	s.save()
+
+	s.send(&WSResponse{
+		Type:      wsTypeStreamError,
+		MessageID: s.state.MessageID,
+		SessionID: s.state.SessionID,
+		Error:     code,
+		Message:   message,
+	})
+}
+
+func (s *chatStream) send(response *WSResponse) {
+	if s.connLost {
+		return
+	}
+	if !s.h.sendResponse(s.conn, response) {
+		log.Printf("⚠️ Stream %s lost its connection, continuing with snapshots only", s.state.MessageID)
+		s.connLost = true
+	}
+}
+
+func (s *chatStream) save() {
+	s.state.UpdatedAt = time.Now()
+	s.lastSaved = s.state.UpdatedAt
+	if err := s.h.container.CacheService.SetStreamState(&s.state); err != nil {
+		log.Printf("⚠️ Failed to save stream snapshot %s: %v", s.state.MessageID, err)
+	}
+}
+
+// handleResumeStream returns the latest snapshot of a streamed message.
+// Snapshots are shared through Redis, so this works on any server; clients
+// repeat it until the status is "done" (or load the saved message).
+func (h *WSHandler) handleResumeStream(c *websocket.Conn, msg *WSMessage) {
+	if msg.MessageID == "" {
+		h.sendError(c, "validation_error", "Message ID is required")
+		return
+	}
+
+	// Extract base session ID from signed session ID if applicable
+	sessionID := msg.SessionID
+	if h.container.SessionOwnershipChecker.Signer.IsSignedSessionID(sessionID) {
+		baseSessionID, _, err := h.container.SessionOwnershipChecker.Signer.VerifyAndExtractSessionID(sessionID, 24*time.Hour)
+		if err != nil {
+			h.sendError(c, "invalid_session", "Invalid or expired session signature")
+			return
+		}
+		sessionID = baseSessionID
+	}
+
+	state, err := h.container.CacheService.GetStreamState(msg.MessageID)
+	if err != nil {
+		log.Printf("❌ Failed to load stream snapshot %s: %v", msg.MessageID, err)
+		h.sendError(c, "internal_error", "Failed to load stream")
+		return
+	}
+
+	// Only the session the message belongs to may read it
+	if state == nil || state.SessionID != sessionID {
+		h.sendError(c, "stream_not_found", "Stream not found or expired")
+		return
+	}
+
+	h.sendResponse(c, &WSResponse{
+		Type:         wsTypeStreamState,
+		MessageID:    state.MessageID,
+		SessionID:    state.SessionID,
+		Status:       state.Status,
+		Output:       state.Output,
+		SearchPhrase: state.SearchPhrase,
+		Products:     state.Products,
+		ResponseType: state.ResponseType,
+		QuickReplies: state.QuickReplies,
+	})
+}
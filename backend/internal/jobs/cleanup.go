@@ -2,82 +2,67 @@ package jobs
 
 import (
 	"context"
-	"log/slog"
 	"time"
 
+	"mylittleprice/internal/metrics"
 	"mylittleprice/internal/services"
-	"mylittleprice/internal/utils"
 )
 
-// CleanupJob handles periodic cleanup of expired anonymous search history
-type CleanupJob struct {
-	searchHistoryService *services.SearchHistoryService
-	interval             time.Duration
-	ctx                  context.Context
-	cancel               context.CancelFunc
-}
-
-// NewCleanupJob creates a new cleanup job instance
-func NewCleanupJob(shs *services.SearchHistoryService) *CleanupJob {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &CleanupJob{
-		searchHistoryService: shs,
-		interval:             24 * time.Hour,
-		ctx:                  ctx,
-		cancel:               cancel,
+// CleanupJobs are the data retention jobs: expired anonymous search
+// history, expired chat sessions and old messages, cost records and job
+// runs past their retention
+func CleanupJobs(
+	shs *services.SearchHistoryService,
+	cleanup *services.CleanupService,
+	costs *services.CostService,
+	runs *services.JobRunService,
+) []*Job {
+	return []*Job{
+		{
+			Name:        "search_history_cleanup",
+			Description: "Deletes expired search history of anonymous users",
+			Task: func(ctx context.Context) (int64, error) {
+				return shs.CleanupExpiredAnonymousHistory(ctx)
+			},
+		},
+		{
+			Name:        "session_cleanup",
+			Description: "Deletes expired chat sessions, orphaned messages and messages older than 90 days",
+			Timeout:     30 * time.Minute,
+			Task: func(ctx context.Context) (int64, error) {
+				start := time.Now()
+				deleted, err := cleanup.RunFullCleanup(ctx)
+				recordSessionCleanup(time.Since(start), err)
+				return int64(deleted), err
+			},
+		},
+		{
+			Name:        "cost_records_cleanup",
+			Description: "Deletes cost records older than COST_RETENTION_DAYS",
+			Task: func(ctx context.Context) (int64, error) {
+				deleted, err := costs.DeleteExpired(ctx)
+				return int64(deleted), err
+			},
+		},
+		{
+			Name:        "job_runs_cleanup",
+			Description: "Deletes job runs older than JOB_HISTORY_RETENTION_DAYS",
+			Task: func(ctx context.Context) (int64, error) {
+				deleted, err := runs.DeleteExpired(ctx)
+				return int64(deleted), err
+			},
+		},
 	}
 }
 
-// Start begins the cleanup job ticker
-func (j *CleanupJob) Start() {
-	ticker := time.NewTicker(j.interval)
-	go func() {
-		// Run cleanup immediately on start
-		utils.LogInfo(j.ctx, "running initial cleanup")
-		j.runCleanup()
-
-		// Then run on schedule
-		for {
-			select {
-			case <-ticker.C:
-				j.runCleanup()
-			case <-j.ctx.Done():
-				ticker.Stop()
-				utils.LogInfo(j.ctx, "cleanup job ticker stopped")
-				return
-			}
-		}
-	}()
-	utils.LogInfo(j.ctx, "cleanup job started", slog.Duration("interval", j.interval))
-}
-
-// runCleanup executes the cleanup operation
-func (j *CleanupJob) runCleanup() {
-	startTime := time.Now()
-
-	count, err := j.searchHistoryService.CleanupExpiredAnonymousHistory(j.ctx)
-	duration := time.Since(startTime)
-
-	if err != nil {
-		utils.LogError(j.ctx, "cleanup job failed", err,
-			slog.Duration("duration", duration),
-		)
-	} else {
-		if count > 0 {
-			utils.LogInfo(j.ctx, "cleanup job completed",
-				slog.Duration("duration", duration),
-				slog.Int64("records_deleted", count),
-			)
-		} else {
-			utils.LogInfo(j.ctx, "cleanup job completed - no expired records found",
-				slog.Duration("duration", duration),
-			)
-		}
+// recordSessionCleanup feeds the session cleanup metrics the
+// SessionCleanupFailing alert watches
+func recordSessionCleanup(duration time.Duration, err error) {
+	if metrics.SessionCleanupDuration == nil {
+		return
+	}
+	metrics.SessionCleanupDuration.Observe(duration.Seconds())
+	if err == nil {
+		metrics.SessionCleanupLastSuccessTimestamp.SetToCurrentTime()
 	}
-}
-
-// Stop gracefully stops the cleanup job
-func (j *CleanupJob) Stop() {
-	j.cancel()
-	utils.LogInfo(j.ctx, "cleanup job stopped")
 }
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/utils"
+)
+
+// priceWatch re-checks watched products and notifies users when the best
+// offer reaches their target price
+type priceWatch struct {
+	watchService *services.WatchService
+	serpService  *services.SerpService
+	cacheService *services.CacheService
+	authService  *services.AuthService
+	emailService *services.EmailService
+	pubsub       *services.PubSubService
+	recheck      time.Duration
+	batchSize    int
+	immersiveTTL int
+}
+
+// PriceWatchJob re-checks one batch of due watches per run. Due watches
+// aren't claimed, so the job relies on the scheduler's lock to run on a
+// single instance at a time.
+func PriceWatchJob(
+	ws *services.WatchService,
+	ss *services.SerpService,
+	cs *services.CacheService,
+	as *services.AuthService,
+	es *services.EmailService,
+	ps *services.PubSubService,
+	cfg *config.Config,
+) *Job {
+	j := &priceWatch{
+		watchService: ws,
+		serpService:  ss,
+		cacheService: cs,
+		authService:  as,
+		emailService: es,
+		pubsub:       ps,
+		recheck:      cfg.PriceWatchRecheck,
+		batchSize:    cfg.PriceWatchBatchSize,
+		immersiveTTL: cfg.CacheImmersiveTTL,
+	}
+	return &Job{
+		Name:        "price_watch",
+		Description: "Re-checks due price watches and sends price alerts",
+		Timeout:     30 * time.Minute,
+		Task:        j.runChecks,
+	}
+}
+
+// runChecks re-checks one batch of due watches and returns how many were
+// checked
+func (j *priceWatch) runChecks(ctx context.Context) (int64, error) {
+	watches, err := j.watchService.GetDueWatches(ctx, time.Now().Add(-j.recheck), j.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load watches: %w", err)
+	}
+
+	var checked, notified int64
+	for i := range watches {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sent, err := j.checkWatch(ctx, &watches[i])
+		if err != nil {
+			utils.LogWarn(ctx, "price watch check failed",
+				slog.String("watch_id", watches[i].ID.String()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		checked++
+		if sent {
+			notified++
+		}
+	}
+
+	utils.LogInfo(ctx, "price watch checks completed",
+		slog.Int("due", len(watches)),
+		slog.Int64("checked", checked),
+		slog.Int64("notified", notified),
+	)
+	return checked, ctx.Err()
+}
+
+// checkWatch fetches fresh product details and sends an alert if needed
+func (j *priceWatch) checkWatch(ctx context.Context, watch *models.Watch) (bool, error) {
+	// The check is paid for on behalf of the watch's owner
+	productDetails, _, err := j.serpService.GetProductDetailsByToken(utils.WithUserID(ctx, watch.UserID.String()), watch.PageToken)
+	if err != nil {
+		// Still mark as checked so a broken token doesn't block the queue
+		_ = j.watchService.RecordPriceCheck(ctx, watch.ID, nil, false)
+		return false, fmt.Errorf("failed to fetch product details: %w", err)
+	}
+
+	// Fresh data is useful for anyone opening the product right now
+	if j.cacheService != nil {
+		_ = j.cacheService.SetProductByToken(watch.PageToken, productDetails, j.immersiveTTL)
+	}
+
+	offer := services.FindBestOffer(productDetails, watch.Currency)
+	notify := services.ShouldNotify(watch, offer)
+
+	if err := j.watchService.RecordPriceCheck(ctx, watch.ID, offer, notify); err != nil {
+		return false, err
+	}
+
+	if !notify {
+		return false, nil
+	}
+
+	now := time.Now()
+	watch.LastPrice = &offer.Price
+	watch.LastMerchant = offer.Merchant
+	watch.LastLink = offer.Link
+	watch.NotifiedPrice = &offer.Price
+	watch.NotifiedAt = &now
+
+	j.notify(ctx, watch)
+	return true, nil
+}
+
+// notify pushes the alert to connected devices and sends an email
+func (j *priceWatch) notify(ctx context.Context, watch *models.Watch) {
+	message := fmt.Sprintf("%s is now %.2f %s (your target: %.2f %s)",
+		watch.ProductName, *watch.LastPrice, watch.Currency, watch.TargetPrice, watch.Currency)
+
+	alert := &models.PriceAlert{
+		Type:    "price_alert",
+		Message: message,
+		Watch:   watch,
+	}
+	if err := j.pubsub.BroadcastToUser(watch.UserID, alert.Type, alert); err != nil {
+		utils.LogWarn(ctx, "failed to publish price alert", slog.Any("error", err))
+	}
+
+	user, err := j.authService.GetUserByID(watch.UserID)
+	if err != nil || user == nil {
+		utils.LogWarn(ctx, "price alert email skipped - user not found",
+			slog.String("user_id", watch.UserID.String()),
+		)
+		return
+	}
+
+	if err := j.emailService.SendPriceAlertEmail(user.Email, watch); err != nil {
+		utils.LogWarn(ctx, "failed to send price alert email", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"a * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"1-x * * * *",
+		"0 0 31 2 *",
+		"@yearly",
+		"@every 30s",
+		"@every soon",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Tuesday
+	now := time.Date(2026, 3, 10, 10, 30, 15, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"0 3 * * *", at(3, 11, 3, 0)},
+		{"30 10 * * *", at(3, 11, 10, 30)},
+		{"31 10 * * *", at(3, 10, 10, 31)},
+		{"*/15 * * * *", at(3, 10, 10, 45)},
+		{"15/20 * * * *", at(3, 10, 10, 35)},
+		{"5-10/2 * * * *", at(3, 10, 11, 5)},
+		{"0,30 8-9 * * *", at(3, 11, 8, 0)},
+		{" 0 3 * * * ", at(3, 11, 3, 0)},
+		{"0 9 * * 1-5", at(3, 11, 9, 0)},
+		{"0 0 * * 0", at(3, 15, 0, 0)},
+		{"0 0 * * 7", at(3, 15, 0, 0)},
+		{"0 0 12 * 5", at(3, 12, 0, 0)}, // The 12th or a Friday, whichever comes first
+		{"0 0 20 * 5", at(3, 13, 0, 0)},
+		{"0 0 10 * 2", at(3, 17, 0, 0)}, // Today already passed
+		{"0 12 * 12 *", at(12, 1, 12, 0)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", at(3, 10, 11, 0)},
+		{"@daily", at(3, 11, 0, 0)},
+		{"@midnight", at(3, 11, 0, 0)},
+		{"@weekly", at(3, 15, 0, 0)},
+		{"@monthly", at(4, 1, 0, 0)},
+		{"@every 90m", now.Add(90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := schedule.Next(now); !got.Equal(tt.want) {
+			t.Errorf("%q: Next(%v) = %v, want %v", tt.spec, now, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleNextLocation(t *testing.T) {
+	schedule, err := ParseSchedule("0 3 * * *")
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+
+	cet := time.FixedZone("CET", 3600)
+	now := time.Date(2026, 3, 10, 2, 0, 0, 0, cet)
+	want := time.Date(2026, 3, 10, 3, 0, 0, 0, cet)
+	if got := schedule.Next(now); !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", now, got, want)
+	}
+}
@@ -0,0 +1,470 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/metrics"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/services"
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+const (
+	defaultJobTimeout = 10 * time.Minute
+
+	// A job's lock expires this long after its holder stops extending it,
+	// e.g. when the instance crashes mid-run
+	jobLockTTL = time.Minute
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job is already running")
+)
+
+// Task is the work of a job. It returns the number of rows it deleted or
+// updated.
+type Task func(ctx context.Context) (int64, error)
+
+// Job is a task the scheduler runs on the schedule configured for its name
+// (JOB_<NAME>_SCHEDULE), on one instance at a time
+type Job struct {
+	Name        string
+	Description string
+	Timeout     time.Duration // Default 10 minutes
+	Task        Task
+}
+
+type scheduledJob struct {
+	*Job
+	schedule *Schedule
+	running  atomic.Bool // A run started by this instance's loop is in progress
+
+	mu   sync.Mutex
+	next time.Time // When the loop next checks the job
+}
+
+func (j *scheduledJob) nextRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+func (j *scheduledJob) setNextRun(next time.Time) {
+	j.mu.Lock()
+	j.next = next
+	j.mu.Unlock()
+}
+
+// Scheduler runs registered jobs on their schedules. Each run takes a
+// Redis lock, so with several instances a job runs on one of them, and
+// is recorded in the job_runs table. The next run of a job follows from
+// its last scheduled run there, so a run missed while no instance was up
+// is caught up once on start.
+type Scheduler struct {
+	runs      *services.JobRunService
+	redis     *redis.Client
+	config    *config.Config
+	instance  string // Recorded with each run
+	jobs      map[string]*scheduledJob
+	order     []string // Registration order, for listings
+	startedAt time.Time
+	active    atomic.Bool
+	wg        sync.WaitGroup
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// NewScheduler creates a scheduler without jobs
+func NewScheduler(runs *services.JobRunService, redisClient *redis.Client, cfg *config.Config) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &Scheduler{
+		runs:      runs,
+		redis:     redisClient,
+		config:    cfg,
+		instance:  fmt.Sprintf("%s-%d", host, os.Getpid()),
+		jobs:      make(map[string]*scheduledJob),
+		startedAt: time.Now(),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Register adds a job. Its schedule is read from the config; jobs must be
+// registered before Start.
+func (s *Scheduler) Register(job *Job) error {
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s registered twice", job.Name)
+	}
+
+	spec, ok := s.config.JobSchedules[job.Name]
+	if !ok {
+		return fmt.Errorf("no schedule configured for job %s", job.Name)
+	}
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("JOB_%s_SCHEDULE: %w", strings.ToUpper(job.Name), err)
+	}
+
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	s.jobs[job.Name] = &scheduledJob{Job: job, schedule: schedule}
+	s.order = append(s.order, job.Name)
+	return nil
+}
+
+// Start runs due jobs in the background until Stop. With JOBS_ENABLED=false
+// this instance runs no scheduled jobs, only manual ones.
+func (s *Scheduler) Start() {
+	if !s.config.JobsEnabled {
+		utils.LogInfo(s.ctx, "job scheduler disabled on this instance, jobs run only when triggered")
+		return
+	}
+
+	for _, name := range s.order {
+		job := s.jobs[name]
+		job.setNextRun(s.dueTime(s.ctx, job))
+		utils.LogInfo(s.ctx, "job scheduled",
+			slog.String("job", name),
+			slog.String("schedule", job.schedule.String()),
+			slog.Time("next_run", job.nextRun()),
+		)
+	}
+
+	s.active.Store(true)
+	s.wg.Add(1)
+	go s.loop()
+
+	utils.LogInfo(s.ctx, "job scheduler started",
+		slog.Int("jobs", len(s.order)),
+		slog.Duration("poll_interval", s.config.JobPollInterval),
+		slog.String("instance", s.instance),
+	)
+}
+
+// Stop stops the scheduler and waits for the runs in progress, which see
+// their context cancelled
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.active.Store(false)
+	utils.LogInfo(s.ctx, "job scheduler stopped")
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.JobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, name := range s.order {
+			job := s.jobs[name]
+			if now.Before(job.nextRun()) || !job.running.CompareAndSwap(false, true) {
+				continue
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer job.running.Store(false)
+				s.runScheduled(job)
+			}()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// runScheduled runs a due job unless another instance holds its lock or
+// has run it since the due time was computed
+func (s *Scheduler) runScheduled(job *scheduledJob) {
+	lock, err := s.acquireLock(s.ctx, job.Name)
+	if err != nil {
+		utils.LogWarn(s.ctx, "failed to take job lock", slog.String("job", job.Name), slog.Any("error", err))
+		return
+	}
+	if lock == nil {
+		metrics.RecordJobLockContended(job.Name)
+		job.setNextRun(s.dueTime(s.ctx, job))
+		return
+	}
+	defer s.releaseLock(lock)
+
+	due := s.dueTime(s.ctx, job)
+	if time.Now().Before(due) {
+		job.setNextRun(due)
+		return
+	}
+
+	run, err := s.begin(s.ctx, job, models.JobTriggerSchedule, "")
+	if err != nil {
+		utils.LogError(s.ctx, "failed to start job", err, slog.String("job", job.Name))
+		return
+	}
+	job.setNextRun(job.schedule.Next(run.StartedAt))
+
+	s.execute(job, run, lock)
+}
+
+// Trigger starts a run of a job now, outside its schedule. The run
+// continues in the background; the returned record is its start.
+func (s *Scheduler) Trigger(ctx context.Context, name, triggeredBy string) (*models.JobRun, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	if s.ctx.Err() != nil {
+		return nil, errors.New("job scheduler is stopped")
+	}
+
+	lock, err := s.acquireLock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, ErrJobRunning
+	}
+
+	run, err := s.begin(ctx, job, models.JobTriggerManual, triggeredBy)
+	if err != nil {
+		s.releaseLock(lock)
+		return nil, err
+	}
+
+	// The record returned to the caller must not change under it
+	started := *run
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.releaseLock(lock)
+		s.execute(job, run, lock)
+	}()
+
+	return &started, nil
+}
+
+// begin records the start of a run. The caller holds the job's lock, so
+// runs still marked running were left behind by a crashed instance.
+func (s *Scheduler) begin(ctx context.Context, job *scheduledJob, trigger, triggeredBy string) (*models.JobRun, error) {
+	if abandoned, err := s.runs.AbandonRunning(ctx, job.Name); err != nil {
+		utils.LogWarn(ctx, "failed to mark abandoned job runs", slog.String("job", job.Name), slog.Any("error", err))
+	} else if abandoned > 0 {
+		utils.LogWarn(ctx, "job runs abandoned by a stopped instance",
+			slog.String("job", job.Name),
+			slog.Int("runs", abandoned),
+		)
+	}
+
+	return s.runs.Start(ctx, job.Name, trigger, triggeredBy, s.instance)
+}
+
+// execute runs the task of a started run while keeping its lock, and
+// records the outcome
+func (s *Scheduler) execute(job *scheduledJob, run *models.JobRun, lock *jobLock) {
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "job "+job.Name,
+		attribute.String("job.name", job.Name),
+		attribute.String("job.trigger", run.Trigger),
+		attribute.String("job.run_id", run.ID.String()),
+	)
+
+	go s.keepLock(ctx, lock)
+
+	utils.LogInfo(ctx, "job started",
+		slog.String("job", job.Name),
+		slog.String("trigger", run.Trigger),
+		slog.String("run_id", run.ID.String()),
+	)
+
+	rows, err := runTask(ctx, job.Task)
+	span.SetAttributes(attribute.Int64("job.rows_affected", rows))
+	tracing.End(span, err)
+
+	// The scheduler may be stopping, the outcome is still recorded
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer finishCancel()
+	if finishErr := s.runs.Finish(finishCtx, run, rows, err); finishErr != nil {
+		utils.LogError(ctx, "failed to record job run", finishErr, slog.String("job", job.Name))
+	}
+
+	metrics.RecordJobRun(job.Name, run.Trigger, run.Status, time.Since(run.StartedAt), rows)
+
+	if err != nil {
+		utils.LogError(ctx, "job failed", err,
+			slog.String("job", job.Name),
+			slog.Int64("duration_ms", run.DurationMS),
+			slog.Int64("rows_affected", rows),
+		)
+		return
+	}
+	utils.LogInfo(ctx, "job completed",
+		slog.String("job", job.Name),
+		slog.Int64("duration_ms", run.DurationMS),
+		slog.Int64("rows_affected", rows),
+	)
+}
+
+// runTask turns a panic of the task into a failed run
+func runTask(ctx context.Context, task Task) (rows int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return task(ctx)
+}
+
+// dueTime is when a job is next due: the schedule's next time after its
+// last scheduled run, or after the scheduler started if it never ran
+func (s *Scheduler) dueTime(ctx context.Context, job *scheduledJob) time.Time {
+	last, err := s.runs.LastScheduled(ctx, job.Name)
+	if err != nil {
+		// Checked again at the next poll
+		utils.LogWarn(ctx, "failed to load last job run", slog.String("job", job.Name), slog.Any("error", err))
+		return time.Now().Add(s.config.JobPollInterval)
+	}
+	if last == nil {
+		return job.schedule.Next(s.startedAt)
+	}
+	return job.schedule.Next(last.StartedAt.In(time.Local))
+}
+
+// Jobs describes the registered jobs with their last run and next due time
+func (s *Scheduler) Jobs(ctx context.Context) (*models.JobsResponse, error) {
+	infos := make([]models.JobInfo, 0, len(s.order))
+	for _, name := range s.order {
+		job := s.jobs[name]
+
+		last, err := s.runs.Last(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		running, err := s.redis.Exists(ctx, jobLockKey(name)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check lock of job %s: %w", name, err)
+		}
+
+		infos = append(infos, models.JobInfo{
+			Name:           name,
+			Description:    job.Description,
+			Schedule:       job.schedule.String(),
+			TimeoutSeconds: int(job.Timeout.Seconds()),
+			NextRunAt:      s.dueTime(ctx, job),
+			Running:        running > 0,
+			LastRun:        last,
+		})
+	}
+
+	return &models.JobsResponse{Jobs: infos, SchedulerActive: s.active.Load()}, nil
+}
+
+// Runs lists the runs of a job, newest first
+func (s *Scheduler) Runs(ctx context.Context, name string, limit, offset int) (*models.JobRunsResponse, error) {
+	if _, ok := s.jobs[name]; !ok {
+		return nil, ErrJobNotFound
+	}
+	return s.runs.List(ctx, name, limit, offset)
+}
+
+// ═══════════════════════════════════════════════════════════
+// LEADER LOCKS
+// ═══════════════════════════════════════════════════════════
+
+// jobLock is a job's Redis lock, held by the instance that knows the token
+type jobLock struct {
+	key   string
+	token string
+}
+
+var (
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+	extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+)
+
+func jobLockKey(name string) string {
+	return "jobs:lock:" + name
+}
+
+// acquireLock takes a job's lock; nil without error if another run holds it
+func (s *Scheduler) acquireLock(ctx context.Context, name string) (*jobLock, error) {
+	lock := &jobLock{key: jobLockKey(name), token: uuid.New().String()}
+	acquired, err := s.redis.SetNX(ctx, lock.key, lock.token, jobLockTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock of job %s: %w", name, err)
+	}
+	if !acquired {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// keepLock extends the lock until ctx is done, so runs may take longer
+// than the lock TTL
+func (s *Scheduler) keepLock(ctx context.Context, lock *jobLock) {
+	ticker := time.NewTicker(jobLockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			extended, err := extendLockScript.Run(ctx, s.redis, []string{lock.key}, lock.token, jobLockTTL.Milliseconds()).Int()
+			if err == nil && extended == 0 {
+				// Expired while Redis was unreachable, another instance may start the job
+				utils.LogWarn(ctx, "job lock lost", slog.String("lock", lock.key))
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Scheduler) releaseLock(lock *jobLock) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := releaseLockScript.Run(ctx, s.redis, []string{lock.key}, lock.token).Err(); err != nil {
+		// Expires on its own after jobLockTTL
+		utils.LogWarn(ctx, "failed to release job lock", slog.String("lock", lock.key), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// Cost accounting metrics, labelled by provider, model and purpose
+	PaidCallsTotal *prometheus.CounterVec
+	TokensTotal    *prometheus.CounterVec
+	CostUSDTotal   *prometheus.CounterVec
+
+	// Ensure metrics are registered only once
+	costMetricsOnce sync.Once
+)
+
+// RegisterCostMetrics registers the cost accounting metrics to default registry
+func RegisterCostMetrics() {
+	costMetricsOnce.Do(func() {
+		log.Printf("🔧 Registering Cost metrics")
+
+		PaidCallsTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "paid_calls_total",
+				Help: "Total number of paid LLM and search API calls",
+			},
+			[]string{"provider", "model", "purpose", "grounded"},
+		)
+		prometheus.MustRegister(PaidCallsTotal)
+
+		TokensTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "llm_tokens_total",
+				Help: "Total number of LLM tokens by direction (input, output)",
+			},
+			[]string{"provider", "model", "purpose", "direction"},
+		)
+		prometheus.MustRegister(TokensTotal)
+
+		CostUSDTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_cost_usd_total",
+				Help: "Total cost of LLM and search API calls in USD, from the configured price table",
+			},
+			[]string{"provider", "model", "purpose"},
+		)
+		prometheus.MustRegister(CostUSDTotal)
+	})
+}
+
+// RecordPaidCall counts a paid call. It does nothing until the metrics are
+// registered, e.g. in the eval runner.
+func RecordPaidCall(provider, model, purpose string, grounded bool, inputTokens, outputTokens int, costUSD float64) {
+	if PaidCallsTotal == nil {
+		return
+	}
+
+	PaidCallsTotal.WithLabelValues(provider, model, purpose, strconv.FormatBool(grounded)).Inc()
+	if inputTokens > 0 {
+		TokensTotal.WithLabelValues(provider, model, purpose, "input").Add(float64(inputTokens))
+	}
+	if outputTokens > 0 {
+		TokensTotal.WithLabelValues(provider, model, purpose, "output").Add(float64(outputTokens))
+	}
+	CostUSDTotal.WithLabelValues(provider, model, purpose).Add(costUSD)
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// Scheduled job metrics, labelled by job_name ("job" is the scrape target label)
+	JobRunsTotal            *prometheus.CounterVec
+	JobRunDuration          *prometheus.HistogramVec
+	JobRowsAffectedTotal    *prometheus.CounterVec
+	JobLastSuccessTimestamp *prometheus.GaugeVec
+	JobLockContendedTotal   *prometheus.CounterVec
+
+	// Ensure metrics are registered only once
+	jobMetricsOnce sync.Once
+)
+
+// RegisterJobMetrics registers the scheduled job metrics to default registry
+func RegisterJobMetrics() {
+	jobMetricsOnce.Do(func() {
+		log.Printf("🔧 Registering Job metrics")
+
+		JobRunsTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "job_runs_total",
+				Help: "Total number of scheduled job runs by trigger (schedule, manual) and status (succeeded, failed)",
+			},
+			[]string{"job_name", "trigger", "status"},
+		)
+		prometheus.MustRegister(JobRunsTotal)
+
+		JobRunDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "job_run_duration_seconds",
+				Help:    "Duration of scheduled job runs in seconds",
+				Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900},
+			},
+			[]string{"job_name"},
+		)
+		prometheus.MustRegister(JobRunDuration)
+
+		JobRowsAffectedTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "job_rows_affected_total",
+				Help: "Total number of rows deleted or updated by scheduled jobs",
+			},
+			[]string{"job_name"},
+		)
+		prometheus.MustRegister(JobRowsAffectedTotal)
+
+		JobLastSuccessTimestamp = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "job_last_success_timestamp",
+				Help: "Timestamp of the last successful run of a job on this instance",
+			},
+			[]string{"job_name"},
+		)
+		prometheus.MustRegister(JobLastSuccessTimestamp)
+
+		JobLockContendedTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "job_lock_contended_total",
+				Help: "Total number of due job runs skipped because another instance held the lock",
+			},
+			[]string{"job_name"},
+		)
+		prometheus.MustRegister(JobLockContendedTotal)
+	})
+}
+
+// RecordJobRun records a finished job run. It does nothing until the
+// metrics are registered.
+func RecordJobRun(job, trigger, status string, duration time.Duration, rowsAffected int64) {
+	if JobRunsTotal == nil {
+		return
+	}
+
+	JobRunsTotal.WithLabelValues(job, trigger, status).Inc()
+	JobRunDuration.WithLabelValues(job).Observe(duration.Seconds())
+	if rowsAffected > 0 {
+		JobRowsAffectedTotal.WithLabelValues(job).Add(float64(rowsAffected))
+	}
+	if status == "succeeded" {
+		JobLastSuccessTimestamp.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// RecordJobLockContended counts a due run another instance took
+func RecordJobLockContended(job string) {
+	if JobLockContendedTotal == nil {
+		return
+	}
+	JobLockContendedTotal.WithLabelValues(job).Inc()
+}
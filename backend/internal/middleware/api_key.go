@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/internal/models"
+)
+
+const apiKeyScheme = "ApiKey "
+
+// APIKeyLookup resolves a raw API key to its owner and scopes
+type APIKeyLookup func(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error)
+
+// APIKeyConfig holds what API key authentication needs
+type APIKeyConfig struct {
+	Lookup    APIKeyLookup
+	Redis     *redis.Client
+	RateLimit int // Requests per minute per key
+}
+
+// APIKeyAuth accepts "Authorization: ApiKey <key>" for keys with the scope
+// and hands every other request to fallback, usually AuthMiddleware or
+// OptionalAuthMiddleware. Requests with a key are rate limited per key.
+func APIKeyAuth(config APIKeyConfig, scope string, fallback fiber.Handler) fiber.Handler {
+	limiter := RateLimiter(RateLimiterConfig{
+		Redis:        config.Redis,
+		Max:          config.RateLimit,
+		Window:       time.Minute,
+		KeyPrefix:    "api_key_limit:",
+		SkipFailOpen: true,
+		Message:      "API key rate limit exceeded, please slow down",
+		StatusCode:   fiber.StatusTooManyRequests,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			keyID, _ := GetAPIKeyID(c)
+			return keyID.String()
+		},
+	})
+
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if !strings.HasPrefix(authHeader, apiKeyScheme) {
+			return fallback(c)
+		}
+
+		principal, err := config.Lookup(c.Context(), strings.TrimPrefix(authHeader, apiKeyScheme))
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:   "invalid_api_key",
+				Message: "Invalid, revoked or expired API key",
+			})
+		}
+
+		if !principal.HasScope(scope) {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:   "insufficient_scope",
+				Message: "This API key lacks the " + scope + " scope",
+			})
+		}
+
+		// Handlers see the key's owner like a signed-in user
+		c.Locals("user_id", principal.UserID)
+		c.Locals("api_key_id", principal.KeyID)
+
+		return limiter(c)
+	}
+}
+
+// GetAPIKeyID retrieves the API key of the request from context, if it was
+// authenticated with one
+func GetAPIKeyID(c *fiber.Ctx) (uuid.UUID, bool) {
+	keyID, ok := c.Locals("api_key_id").(uuid.UUID)
+	return keyID, ok
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/internal/models"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	RegisterMetrics()
+
+	userID := uuid.New()
+	principals := map[string]*models.APIKeyPrincipal{
+		"mlp_chat":    {KeyID: uuid.New(), UserID: userID, Scopes: []string{models.APIKeyScopeChat}},
+		"mlp_history": {KeyID: uuid.New(), UserID: userID, Scopes: []string{models.APIKeyScopeSearchHistory, models.APIKeyScopePreferences}},
+	}
+	lookup := func(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error) {
+		// Revoked and expired keys are never found
+		if principal, ok := principals[rawKey]; ok {
+			return principal, nil
+		}
+		return nil, errors.New("invalid, revoked or expired API key")
+	}
+
+	// Nothing listens there: the per-key rate limiter fails open
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer redisClient.Close()
+
+	app := fiber.New()
+	app.Get("/chat", APIKeyAuth(
+		APIKeyConfig{Lookup: lookup, Redis: redisClient, RateLimit: 10},
+		models.APIKeyScopeChat,
+		func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) },
+	), func(c *fiber.Ctx) error {
+		if id, ok := GetUserID(c); !ok || id != userID {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		if _, ok := GetAPIKeyID(c); !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"no credentials go to the fallback", "", fiber.StatusNoContent},
+		{"bearer tokens go to the fallback", "Bearer some.jwt.token", fiber.StatusNoContent},
+		{"key with the scope", "ApiKey mlp_chat", fiber.StatusOK},
+		{"key without the scope", "ApiKey mlp_history", fiber.StatusForbidden},
+		{"unknown, revoked or expired key", "ApiKey mlp_revoked", fiber.StatusUnauthorized},
+		{"malformed key", "ApiKey not-a-key", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/chat", nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestAPIKeyPrincipalHasScope(t *testing.T) {
+	principal := &models.APIKeyPrincipal{Scopes: []string{models.APIKeyScopeChat, models.APIKeyScopePreferences}}
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{models.APIKeyScopeChat, true},
+		{models.APIKeyScopePreferences, true},
+		{models.APIKeyScopeSearchHistory, false},
+		{"CHAT", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := principal.HasScope(tt.scope); got != tt.want {
+			t.Errorf("HasScope(%q) = %v, want %v", tt.scope, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"mylittleprice/internal/models"
 	"mylittleprice/internal/utils"
 )
 
@@ -46,6 +48,7 @@ func AuthMiddleware(jwtService *utils.JWTService) fiber.Handler {
 		// Add user info to context
 		c.Locals("user_id", claims.UserID)
 		c.Locals("user_email", claims.Email)
+		c.Locals("device_id", claims.DeviceID)
 
 		return c.Next()
 	}
@@ -69,6 +72,35 @@ func OptionalAuthMiddleware(jwtService *utils.JWTService) fiber.Handler {
 		if err == nil {
 			c.Locals("user_id", claims.UserID)
 			c.Locals("user_email", claims.Email)
+			c.Locals("device_id", claims.DeviceID)
+		}
+
+		return c.Next()
+	}
+}
+
+// RoleLookup returns the current role of a user
+type RoleLookup func(ctx context.Context, userID uuid.UUID) (string, error)
+
+// AdminMiddleware allows only admins through. It must run after
+// AuthMiddleware. The role is looked up on every request rather than read
+// from the token, so demoting an admin takes effect immediately.
+func AdminMiddleware(lookupRole RoleLookup) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := GetUserID(c)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Authentication required",
+			})
+		}
+
+		role, err := lookupRole(c.Context(), userID)
+		if err != nil || role != models.UserRoleAdmin {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Admin access required",
+			})
 		}
 
 		return c.Next()
@@ -86,3 +118,11 @@ func GetUserEmail(c *fiber.Ctx) (string, bool) {
 	email, ok := c.Locals("user_email").(string)
 	return email, ok
 }
+
+// GetDeviceID retrieves the device session of the access token from
+// context. Tokens issued before device sessions have none.
+func GetDeviceID(c *fiber.Ctx) (uuid.UUID, bool) {
+	value, _ := c.Locals("device_id").(string)
+	deviceID, err := uuid.Parse(value)
+	return deviceID, err == nil
+}
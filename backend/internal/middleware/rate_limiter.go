@@ -160,3 +160,24 @@ func AuthRateLimiter(redis *redis.Client) fiber.Handler {
 
 	return RateLimiter(config)
 }
+
+// VerificationEmailRateLimiter limits how often a user can have the email
+// verification link sent again. Must run after AuthMiddleware.
+func VerificationEmailRateLimiter(redis *redis.Client) fiber.Handler {
+	config := RateLimiterConfig{
+		Redis:      redis,
+		Max:        3, // 3 emails per hour
+		Window:     1 * time.Hour,
+		KeyPrefix:  "verification_email_limit:",
+		Message:    "Too many verification emails requested, please try again later",
+		StatusCode: fiber.StatusTooManyRequests,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if userID, ok := GetUserID(c); ok {
+				return userID.String()
+			}
+			return c.IP()
+		},
+	}
+
+	return RateLimiter(config)
+}
@@ -14,6 +14,9 @@ import (
 // SessionService interface to avoid circular dependency
 type SessionService interface {
 	GetSession(sessionID string) (interface{}, error)
+	// HasSessionGrant reports whether the user was invited to a session
+	// owned by someone else
+	HasSessionGrant(sessionID string, userID uuid.UUID) bool
 }
 
 // SessionOwnershipValidator validates that the user has access to the requested session
@@ -73,7 +76,7 @@ func (v *SessionOwnershipValidator) ValidateSessionOwnership() fiber.Handler {
 					})
 				}
 
-				if *embeddedUserID != userUUID {
+				if *embeddedUserID != userUUID && !v.sessionService.HasSessionGrant(rawSessionID, userUUID) {
 					return c.Status(http.StatusForbidden).JSON(fiber.Map{
 						"error": "You don't have permission to access this session",
 					})
@@ -109,7 +112,8 @@ func (v *SessionOwnershipValidator) ValidateSessionOwnership() fiber.Handler {
 						})
 					}
 
-					if sessionUserID != userUUID {
+					// Invited users have a grant to the owner's session
+					if sessionUserID != userUUID && !v.sessionService.HasSessionGrant(rawSessionID, userUUID) {
 						return c.Status(http.StatusForbidden).JSON(fiber.Map{
 							"error": "You don't have permission to access this session",
 						})
@@ -169,7 +173,7 @@ func (v *SessionOwnershipValidator) ValidateSessionOwnershipStrict() fiber.Handl
 				})
 			}
 
-			if *embeddedUserID != userUUID {
+			if *embeddedUserID != userUUID && !v.sessionService.HasSessionGrant(rawSessionID, userUUID) {
 				return c.Status(http.StatusForbidden).JSON(fiber.Map{
 					"error": "You don't have permission to access this session",
 				})
@@ -207,7 +211,7 @@ func (v *SessionOwnershipValidator) ValidateWebSocketSessionOwnership(sessionID
 			if userID == nil {
 				return fmt.Errorf("session requires authentication")
 			}
-			if *embeddedUserID != *userID {
+			if *embeddedUserID != *userID && !v.sessionService.HasSessionGrant(rawSessionID, *userID) {
 				return fmt.Errorf("session belongs to different user")
 			}
 		}
@@ -230,7 +234,7 @@ func (v *SessionOwnershipValidator) ValidateWebSocketSessionOwnership(sessionID
 				if userID == nil {
 					return fmt.Errorf("session requires authentication")
 				}
-				if sessionUserID != *userID {
+				if sessionUserID != *userID && !v.sessionService.HasSessionGrant(sessionID, *userID) {
 					return fmt.Errorf("session belongs to different user")
 				}
 			}
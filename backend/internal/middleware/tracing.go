@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+// Tracing starts a server span for each request, continuing the trace of
+// an incoming traceparent header, and puts it in the request context.
+// WebSocket upgrades are skipped - their messages are traced one by one.
+func Tracing() fiber.Handler {
+	propagator := otel.GetTextMapPropagator()
+
+	return func(c *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+
+		// Fiber reuses request buffers, copy strings kept by the span
+		method := string([]byte(c.Method()))
+
+		ctx := propagator.Extract(c.UserContext(), fiberHeaderCarrier{c})
+		ctx, span := tracing.Start(ctx, method,
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLPath(string([]byte(c.Path()))),
+		)
+		defer span.End()
+		span.SetAttributes(semconv.ServerAddress(string([]byte(c.Hostname()))))
+
+		c.SetUserContext(ctx)
+		err := c.Next()
+
+		// Set by RequestContext further down the chain
+		if requestID := utils.RequestIDFromContext(c.UserContext()); requestID != "" {
+			span.SetAttributes(attribute.String("request.id", requestID))
+		}
+
+		route := c.Route().Path
+		if route != "" {
+			span.SetName(method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(string([]byte(route))))
+		}
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			if fiberErr, ok := err.(*fiber.Error); ok {
+				status = fiberErr.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+			span.RecordError(err)
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}
+}
+
+// fiberHeaderCarrier reads propagation headers from a Fiber request
+type fiberHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h fiberHeaderCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h fiberHeaderCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h fiberHeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// ACCOUNT DATA MODELS (export and erasure)
+// ═══════════════════════════════════════════════════════════
+
+// AccountExport is everything stored about a user, from PostgreSQL and Redis
+type AccountExport struct {
+	ExportedAt     time.Time                   `json:"exported_at"`
+	User           *User                       `json:"user"`
+	Preferences    *UserPreferences            `json:"preferences,omitempty"`
+	Sessions       []AccountExportSession      `json:"sessions"`
+	SearchHistory  []SearchHistory             `json:"search_history"`
+	Watches        []Watch                     `json:"watches"`
+	SharedSessions []AccountExportSharedAccess `json:"shared_sessions"` // Other users' sessions the user was invited to
+	Snapshots      []SessionSnapshot           `json:"snapshots"`
+	RefreshTokens  []RefreshToken              `json:"refresh_tokens"` // Sign-ins, without the token hashes
+	APIKeys        []APIKey                    `json:"api_keys"`       // Without the keys
+	Identities     []LinkedIdentity            `json:"identities"`     // Linked login providers
+	CostRecords    []CostRecord                `json:"cost_records"`   // Paid LLM and search calls made for the user
+}
+
+// AccountExportSession is a chat session of the user with its messages
+type AccountExportSession struct {
+	SessionID    string     `json:"session_id"`
+	CountryCode  string     `json:"country_code"`
+	LanguageCode string     `json:"language_code"`
+	Currency     string     `json:"currency"`
+	MessageCount int        `json:"message_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Messages     []*Message `json:"messages"`
+}
+
+type AccountExportSharedAccess struct {
+	SessionID string    `json:"session_id"`
+	InvitedBy uuid.UUID `json:"invited_by"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type DeleteAccountRequest struct {
+	Confirm  bool   `json:"confirm"`            // Must be true
+	Password string `json:"password,omitempty"` // Required for email accounts
+}
+
+// AccountDeletionSummary counts what was erased
+type AccountDeletionSummary struct {
+	Sessions      int `json:"sessions"`
+	Messages      int `json:"messages"`
+	SearchHistory int `json:"search_history"`
+	Watches       int `json:"watches"`
+	Snapshots     int `json:"snapshots"`
+	SharedAccess  int `json:"shared_access"`
+	APIKeys       int `json:"api_keys"`
+	Identities    int `json:"identities"`
+	CostRecords   int `json:"cost_records"` // Anonymized, not deleted
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// ADMIN INBOX MODELS
+// ═══════════════════════════════════════════════════════════
+
+// Inbox kinds
+const (
+	InboxBugReports      = "bug_report"
+	InboxContactMessages = "contact_form"
+)
+
+// Inbox item statuses
+const (
+	InboxStatusOpen     = "open"
+	InboxStatusResolved = "resolved"
+)
+
+// InboxItem is a bug report or contact message as stored in Redis, plus
+// its triage status
+type InboxItem struct {
+	ID          string                 `json:"id"`
+	Kind        string                 `json:"kind"`
+	Status      string                 `json:"status"`
+	SubmittedAt time.Time              `json:"submitted_at"`
+	ExpiresAt   *time.Time             `json:"expires_at,omitempty"`
+	ResolvedAt  *time.Time             `json:"resolved_at,omitempty"`
+	ResolvedBy  string                 `json:"resolved_by,omitempty"`
+	Data        map[string]interface{} `json:"data"`
+}
+
+// InboxFilter narrows an inbox listing. Zero values are not applied.
+type InboxFilter struct {
+	Status string
+	Since  time.Time
+	Until  time.Time
+	Query  string // Case-insensitive match anywhere in the submission
+	Limit  int
+	Offset int
+}
+
+type InboxListResponse struct {
+	Items []InboxItem `json:"items"`
+	Total int         `json:"total"`
+}
+
+// ═══════════════════════════════════════════════════════════
+// ADMIN USER MODELS
+// ═══════════════════════════════════════════════════════════
+
+// AdminUser is a user with activity counts for the admin user list
+type AdminUser struct {
+	ID            uuid.UUID  `json:"id"`
+	Email         string     `json:"email"`
+	FullName      string     `json:"full_name,omitempty"`
+	Provider      string     `json:"provider"`
+	Role          string     `json:"role"`
+	Plan          string     `json:"plan"`
+	EmailVerified bool       `json:"email_verified"`
+	SessionCount  int        `json:"session_count"`
+	SearchCount   int        `json:"search_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+}
+
+type AdminUserListResponse struct {
+	Items []AdminUser `json:"items"`
+	Total int         `json:"total"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type UpdateUserPlanRequest struct {
+	Plan string `json:"plan"`
+}
+
+// ═══════════════════════════════════════════════════════════
+// AUDIT LOG MODELS
+// ═══════════════════════════════════════════════════════════
+
+// Audit log actions
+const (
+	AuditActionAccountExported   = "account_exported"
+	AuditActionAccountDeleted    = "account_deleted"
+	AuditActionTwoFactorEnabled  = "two_factor_enabled"
+	AuditActionTwoFactorDisabled = "two_factor_disabled"
+	AuditActionIdentityLinked    = "identity_linked"
+	AuditActionIdentityUnlinked  = "identity_unlinked"
+)
+
+// AuditEntry is one recorded action. Entries hold IDs and counts only.
+type AuditEntry struct {
+	ID        uuid.UUID              `json:"id"`
+	Action    string                 `json:"action"`
+	ActorID   *uuid.UUID             `json:"actor_id,omitempty"`
+	SubjectID *uuid.UUID             `json:"subject_id,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type AuditLogResponse struct {
+	Items []AuditEntry `json:"items"`
+	Total int          `json:"total"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// API KEY MODELS
+// ═══════════════════════════════════════════════════════════
+
+// API key scopes - each grants one group of endpoints
+const (
+	APIKeyScopeChat          = "chat"           // /api/chat
+	APIKeyScopeSearchHistory = "search-history" // /api/search-history
+	APIKeyScopePreferences   = "preferences"    // /api/user/preferences
+)
+
+// APIKeyScopes lists the valid scopes
+var APIKeyScopes = []string{APIKeyScopeChat, APIKeyScopeSearchHistory, APIKeyScopePreferences}
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty"` // 0 - never expires
+}
+
+// APIKey describes a key to its owner. The key itself is only returned
+// once, in CreatedAPIKey.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeysResponse struct {
+	Keys []APIKey `json:"keys"`
+}
+
+// APIKeyPrincipal is the user and key behind a valid API key
+type APIKeyPrincipal struct {
+	KeyID  uuid.UUID
+	UserID uuid.UUID
+	Scopes []string
+}
+
+// HasScope reports whether the key grants a scope
+func (p *APIKeyPrincipal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -11,30 +11,80 @@ import (
 // ═══════════════════════════════════════════════════════════
 
 type User struct {
-	ID           uuid.UUID  `json:"id" db:"id"`
-	Email        string     `json:"email" db:"email"`
-	PasswordHash string     `json:"-" db:"password_hash"` // Never expose password
-	FullName     string     `json:"full_name,omitempty" db:"full_name"`
-	Picture      string     `json:"picture,omitempty" db:"picture"`         // Profile picture URL
-	Provider     string     `json:"provider" db:"provider"`                 // "google", "email"
-	ProviderID   string     `json:"provider_id,omitempty" db:"provider_id"` // Google user ID
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
-	LastLoginAt  *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Email         string     `json:"email" db:"email"`
+	PasswordHash  string     `json:"-" db:"password_hash"` // Never expose password
+	FullName      string     `json:"full_name,omitempty" db:"full_name"`
+	Picture       string     `json:"picture,omitempty" db:"picture"`         // Profile picture URL
+	Provider      string     `json:"provider" db:"provider"`                 // "email" or the provider the account was created with
+	ProviderID    string     `json:"provider_id,omitempty" db:"provider_id"` // Google user ID of accounts created with Google
+	Role          string     `json:"role" db:"role"`                         // "user", "admin"
+	Plan          string     `json:"plan" db:"plan"`                         // "free", "pro", "internal"
+	EmailVerified bool       `json:"email_verified" db:"email_verified"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
 }
 
+// User roles
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+// RefreshToken is one token of a device session (family). Every refresh
+// rotates it: the used token gets RotatedAt and a new one joins the family.
 type RefreshToken struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	FamilyID   uuid.UUID  `json:"family_id" db:"family_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	UserAgent  string     `json:"user_agent,omitempty" db:"user_agent"`
+	IPAddress  string     `json:"ip_address,omitempty" db:"ip_address"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// DeviceInfo describes the client a token is issued to
+type DeviceInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// DeviceSession is a signed-in device: a refresh token family from the
+// login to its last rotation
+type DeviceSession struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // The device making the request
+}
+
+type DevicesResponse struct {
+	Devices []DeviceSession `json:"devices"`
+}
+
+type PasswordResetToken struct {
 	ID        uuid.UUID  `json:"id" db:"id"`
 	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
 	TokenHash string     `json:"-" db:"token_hash"`
 	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
-	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
 }
 
-type PasswordResetToken struct {
+// EmailVerificationToken proves ownership of the email it was sent to.
+// Stored in Redis like PasswordResetToken.
+type EmailVerificationToken struct {
 	ID        uuid.UUID  `json:"id" db:"id"`
 	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	Email     string     `json:"email" db:"email"`
 	TokenHash string     `json:"-" db:"token_hash"`
 	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
@@ -64,12 +114,15 @@ type AuthResponse struct {
 }
 
 type UserInfo struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	FullName  string    `json:"full_name,omitempty"`
-	Picture   string    `json:"picture,omitempty"`
-	Provider  string    `json:"provider"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uuid.UUID `json:"id"`
+	Email         string    `json:"email"`
+	FullName      string    `json:"full_name,omitempty"`
+	Picture       string    `json:"picture,omitempty"`
+	Provider      string    `json:"provider"`
+	Role          string    `json:"role"`
+	Plan          string    `json:"plan"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type RefreshTokenRequest struct {
@@ -80,6 +133,48 @@ type ClaimSessionsRequest struct {
 	SessionIDs []string `json:"session_ids" validate:"required"`
 }
 
+// ═══════════════════════════════════════════════════════════
+// TWO-FACTOR AUTHENTICATION MODELS
+// ═══════════════════════════════════════════════════════════
+
+// TwoFactorChallenge is returned by login instead of AuthResponse when
+// the account has 2FA enabled. The token is exchanged at
+// /api/auth/2fa/verify together with a code.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int64  `json:"expires_in"` // seconds
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"` // TOTP code or recovery code
+}
+
+// TwoFactorEnrollment is a pending enrolment. The secret is shown once as
+// text and as the otpauth:// URI for the QR code.
+type TwoFactorEnrollment struct {
+	Secret     string    `json:"secret"`
+	OTPAuthURI string    `json:"otpauth_uri"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"` // TOTP code, or recovery code to disable
+}
+
+// TwoFactorEnabledResponse carries the recovery codes, shown only once,
+// and new tokens: enabling 2FA revokes every refresh token of the user.
+type TwoFactorEnabledResponse struct {
+	AuthResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
 // ═══════════════════════════════════════════════════════════
 // GOOGLE OAUTH MODELS
 // ═══════════════════════════════════════════════════════════
@@ -99,6 +194,53 @@ type GoogleUserInfo struct {
 	Locale        string `json:"locale"`
 }
 
+// ═══════════════════════════════════════════════════════════
+// OPENID CONNECT AND LINKED IDENTITY MODELS
+// ═══════════════════════════════════════════════════════════
+
+// OIDCAuthRequest carries an ID token from a configured provider. Nonce is
+// the value the client sent in the authorization request, if any.
+type OIDCAuthRequest struct {
+	IDToken string `json:"id_token" validate:"required"`
+	Nonce   string `json:"nonce,omitempty"`
+}
+
+// ExternalIdentity is a verified account at a provider
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// OIDCProviderInfo is what a client needs to start a login with a provider
+type OIDCProviderInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Issuer      string `json:"issuer"`
+	ClientID    string `json:"client_id"`
+}
+
+type OIDCProvidersResponse struct {
+	Providers []OIDCProviderInfo `json:"providers"`
+}
+
+// LinkedIdentity is a provider account the user can log in with
+type LinkedIdentity struct {
+	ID         uuid.UUID  `json:"id"`
+	Provider   string     `json:"provider"`
+	Email      string     `json:"email,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type IdentitiesResponse struct {
+	Identities  []LinkedIdentity `json:"identities"`
+	HasPassword bool             `json:"has_password"`
+}
+
 // ═══════════════════════════════════════════════════════════
 // PASSWORD MANAGEMENT MODELS
 // ═══════════════════════════════════════════════════════════
@@ -116,3 +258,7 @@ type ResetPasswordRequest struct {
 	Token       string `json:"token" validate:"required"`
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -24,6 +24,7 @@ type ChatResponse struct {
 	SessionID    string               `json:"session_id"`
 	MessageCount int                  `json:"message_count"`
 	SearchState  *SearchStateResponse `json:"search_state,omitempty"`
+	Comparison   *ComparisonResponse  `json:"comparison,omitempty"`
 }
 
 type SearchStateResponse struct {
@@ -36,6 +37,8 @@ type SearchStateResponse struct {
 	AnonymousSearchUsed    int    `json:"anonymous_search_used"`     // Number of searches used without auth
 	AnonymousSearchLimit   int    `json:"anonymous_search_limit"`    // Maximum allowed anonymous searches
 	RequiresAuthentication bool   `json:"requires_authentication"`   // True if user needs to login/signup
+	RequiresEmailVerification bool `json:"requires_email_verification,omitempty"` // True if the user must confirm their email (RESTRICT_UNVERIFIED_USERS)
+	Quota                  *Quota `json:"quota,omitempty"`           // Remaining daily allowance of the user's plan
 }
 
 // ═══════════════════════════════════════════════════════════
@@ -43,7 +46,7 @@ type SearchStateResponse struct {
 // ═══════════════════════════════════════════════════════════
 
 type GeminiResponse struct {
-	ResponseType  string   `json:"response_type"` // "dialogue", "search", or "api_request"
+	ResponseType  string   `json:"response_type"` // "dialogue", "search", "api_request" or "compare"
 	Output        string   `json:"output"`
 	QuickReplies  []string `json:"quick_replies"`
 	SearchPhrase  string   `json:"search_phrase"` // For response_type="search"
@@ -59,6 +62,8 @@ type GeminiResponse struct {
 	// New fields for api_request response type
 	API    string                 `json:"api,omitempty"`    // API name (e.g., "google_shopping")
 	Params map[string]interface{} `json:"params,omitempty"` // API parameters
+	// For response_type="compare": names of previously shown products
+	CompareProducts []string `json:"compare_products,omitempty"`
 }
 
 type SerpConfig struct {
@@ -0,0 +1,39 @@
+package models
+
+// ═══════════════════════════════════════════════════════════
+// PRODUCT COMPARISON MODELS
+// ═══════════════════════════════════════════════════════════
+
+type CompareRequest struct {
+	PageTokens []string `json:"page_tokens"`
+	Country    string   `json:"country"`
+	Language   string   `json:"language"`
+	Currency   string   `json:"currency"`
+	BrowserID  string   `json:"browser_id,omitempty"` // Counts anonymous lookups against the plan
+}
+
+type ComparedProduct struct {
+	PageToken  string  `json:"page_token"`
+	Title      string  `json:"title"`
+	Price      string  `json:"price"`
+	Rating     float32 `json:"rating,omitempty"`
+	Reviews    int     `json:"reviews,omitempty"`
+	Image      string  `json:"image,omitempty"`
+	BestOffer  *Offer  `json:"best_offer,omitempty"`
+	OfferCount int     `json:"offer_count"`
+	Error      string  `json:"error,omitempty"` // Set when details could not be fetched
+}
+
+// ComparisonRow is one specification aligned across all products.
+// Values has one entry per product, in product order ("" when missing).
+type ComparisonRow struct {
+	Title  string   `json:"title"`
+	Values []string `json:"values"`
+}
+
+type ComparisonResponse struct {
+	Type           string            `json:"type"`
+	Products       []ComparedProduct `json:"products"`
+	Specifications []ComparisonRow   `json:"specifications"`
+	Verdict        string            `json:"verdict,omitempty"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// COST ACCOUNTING MODELS
+// ═══════════════════════════════════════════════════════════
+
+// What a paid LLM or search call was made for
+const (
+	CostPurposeDialogue       = "dialogue"    // Chat turn on the main model
+	CostPurposeFallback       = "fallback"    // Chat turn or translation retried on the fallback model
+	CostPurposeExtraction     = "extraction"  // Preferences, summaries
+	CostPurposeTranslation    = "translation" // Search query translation
+	CostPurposeEmbedding      = "embedding"   // Category detection, semantic cache, deduplication
+	CostPurposeComparison     = "comparison"  // Comparison verdict
+	CostPurposeSearch         = "search"      // SerpAPI shopping search
+	CostPurposeProductDetails = "product_details"
+)
+
+// Groupings of the cost summary
+const (
+	CostGroupByUser    = "user"
+	CostGroupBySession = "session"
+	CostGroupByModel   = "model"
+	CostGroupByPurpose = "purpose"
+)
+
+// CostRecord is one paid call to an LLM or search provider. Calls made
+// outside a request (startup, background jobs) have no request ID.
+type CostRecord struct {
+	ID           uuid.UUID  `json:"id"`
+	RequestID    string     `json:"request_id,omitempty"`
+	SessionID    string     `json:"session_id,omitempty"`
+	UserID       *uuid.UUID `json:"user_id,omitempty"`
+	Provider     string     `json:"provider"`
+	Model        string     `json:"model"` // SerpAPI engine for searches
+	Purpose      string     `json:"purpose"`
+	InputTokens  int        `json:"input_tokens"`
+	OutputTokens int        `json:"output_tokens"`
+	Grounded     bool       `json:"grounded"`
+	CostUSD      float64    `json:"cost_usd"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CostFilter selects cost records; zero values match everything
+type CostFilter struct {
+	UserID    *uuid.UUID
+	SessionID string
+	RequestID string
+	Model     string
+	Purpose   string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+	Offset    int
+}
+
+type CostRecordsResponse struct {
+	Items        []CostRecord `json:"items"`
+	Total        int          `json:"total"`
+	TotalCostUSD float64      `json:"total_cost_usd"` // Of all matching records, not just this page
+}
+
+// CostSummaryRow is the total of one group, e.g. one user or one model
+type CostSummaryRow struct {
+	Key          string  `json:"key"` // Empty for calls without a user or session
+	Calls        int     `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	Grounded     int     `json:"grounded"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+type CostSummaryResponse struct {
+	GroupBy      string           `json:"group_by"`
+	Rows         []CostSummaryRow `json:"rows"` // Most expensive first
+	TotalCostUSD float64          `json:"total_cost_usd"`
+}
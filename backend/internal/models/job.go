@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// SCHEDULED JOB MODELS
+// ═══════════════════════════════════════════════════════════
+
+// What started a job run
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual" // POST /api/admin/jobs/:name/run
+)
+
+// Job run statuses
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+	JobRunAbandoned = "abandoned" // The instance running it stopped before it finished
+)
+
+// JobRun is one run of a scheduled job
+type JobRun struct {
+	ID           uuid.UUID  `json:"id"`
+	Job          string     `json:"job"`
+	Trigger      string     `json:"trigger"`
+	TriggeredBy  string     `json:"triggered_by,omitempty"`
+	Instance     string     `json:"instance"`
+	Status       string     `json:"status"`
+	RowsAffected int64      `json:"rows_affected"`
+	Error        string     `json:"error,omitempty"`
+	DurationMS   int64      `json:"duration_ms"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+type JobRunsResponse struct {
+	Items []JobRun `json:"items"`
+	Total int      `json:"total"`
+}
+
+// JobInfo describes a registered job and its state
+type JobInfo struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Schedule       string    `json:"schedule"`
+	TimeoutSeconds int       `json:"timeout_seconds"`
+	NextRunAt      time.Time `json:"next_run_at"`
+	Running        bool      `json:"running"` // On any instance
+	LastRun        *JobRun   `json:"last_run,omitempty"`
+}
+
+type JobsResponse struct {
+	Jobs            []JobInfo `json:"jobs"`
+	SchedulerActive bool      `json:"scheduler_active"` // JOBS_ENABLED on the instance that answered
+}
@@ -21,3 +21,22 @@ type Message struct {
 	SearchInfo   map[string]interface{} `json:"search_info,omitempty" db:"search_info"`
 	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
 }
+
+// ═══════════════════════════════════════════════════════════
+// STREAMING
+// ═══════════════════════════════════════════════════════════
+
+// StreamState is a snapshot of an assistant message being streamed, kept
+// so a client that reconnects mid-response can catch up
+type StreamState struct {
+	MessageID    string        `json:"message_id"`
+	SessionID    string        `json:"session_id"`
+	Status       string        `json:"status"`                  // Last event sent: thinking, searching, products_partial, token, done
+	Output       string        `json:"output,omitempty"`        // Text streamed so far (final text once done)
+	SearchPhrase string        `json:"search_phrase,omitempty"` // Phrase of the running search
+	Products     []ProductCard `json:"products,omitempty"`
+	ResponseType string        `json:"response_type,omitempty"` // Set once done
+	QuickReplies []string      `json:"quick_replies,omitempty"` // Set once done
+	Done         bool          `json:"done"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════
+// PRICE HISTORY MODELS
+// ═══════════════════════════════════════════════════════════
+
+type PriceObservation struct {
+	ProductKey  string    `json:"product_key"`
+	ProductName string    `json:"product_name"`
+	Merchant    string    `json:"merchant,omitempty"`
+	Price       float64   `json:"price"`
+	Currency    string    `json:"currency"`
+	CountryCode string    `json:"country_code"`
+	Source      string    `json:"source"`
+	ObservedAt  time.Time `json:"observed_at"`
+}
+
+type PricePoint struct {
+	Price      float64   `json:"price"`
+	Merchant   string    `json:"merchant,omitempty"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+type PriceStats struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Max    float64 `json:"max"`
+	// Current is the cheapest price seen within a day of the newest observation
+	Current float64 `json:"current"`
+	Count   int     `json:"count"`
+	// Verdict compares Current to the median: good, typical, high or
+	// insufficient_data
+	Verdict string `json:"verdict"`
+}
+
+type PriceHistoryResponse struct {
+	ProductKey  string       `json:"product_key"`
+	Currency    string       `json:"currency"`
+	CountryCode string       `json:"country_code,omitempty"`
+	Days        int          `json:"days"`
+	Points      []PricePoint `json:"points"`
+	Stats       *PriceStats  `json:"stats,omitempty"`
+}
@@ -18,11 +18,15 @@ type ProductCard struct {
 	Description string `json:"description,omitempty"`
 	Badge       string `json:"badge,omitempty"`
 	PageToken   string `json:"page_token"`
+	// ConvertedPrice is Price in the session currency, set when they differ
+	ConvertedPrice string `json:"converted_price,omitempty"`
 }
 
 type ProductDetailsRequest struct {
 	PageToken string `json:"page_token"`
 	Country   string `json:"country"`
+	Currency  string `json:"currency,omitempty"`
+	BrowserID string `json:"browser_id,omitempty"` // Counts lookups of anonymous visitors
 }
 
 type ProductDetailsResponse struct {
@@ -57,6 +61,7 @@ type Offer struct {
 	Price             string   `json:"price"`
 	ExtractedPrice    float64  `json:"extracted_price,omitempty"`
 	Currency          string   `json:"currency,omitempty"`
+	ConvertedPrice    string   `json:"converted_price,omitempty"`
 	Link              string   `json:"link"`
 	Title             string   `json:"title,omitempty"`
 	Availability      string   `json:"availability,omitempty"`
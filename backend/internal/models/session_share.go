@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// SESSION SHARING MODELS
+// ═══════════════════════════════════════════════════════════
+
+// Roles in a shared session
+const (
+	SessionRoleOwner       = "owner"
+	SessionRoleParticipant = "participant"
+)
+
+// SessionInvite is an invite link to a chat session. The token is only
+// returned here; Redis keeps its hash.
+type SessionInvite struct {
+	Token     string    `json:"token"`
+	InviteURL string    `json:"invite_url"`
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SessionParticipant is a user with access to a shared session
+type SessionParticipant struct {
+	UserID   uuid.UUID  `json:"user_id"`
+	Email    string     `json:"email,omitempty"`
+	FullName string     `json:"full_name,omitempty"`
+	Picture  string     `json:"picture,omitempty"`
+	Role     string     `json:"role"`                // "owner" or "participant"
+	Online   bool       `json:"online"`              // Has the session open on a device
+	JoinedAt *time.Time `json:"joined_at,omitempty"` // When the invite was accepted, nil for the owner
+}
+
+type SessionParticipantsResponse struct {
+	SessionID    string               `json:"session_id"`
+	Participants []SessionParticipant `json:"participants"`
+}
+
+type AcceptSessionInviteResponse struct {
+	SessionID string `json:"session_id"`
+	Role      string `json:"role"`
+}
+
+// ═══════════════════════════════════════════════════════════
+// SESSION SNAPSHOTS
+// ═══════════════════════════════════════════════════════════
+
+type CreateSessionSnapshotRequest struct {
+	ExpiresInDays int `json:"expires_in_days,omitempty"` // 0 - never expires
+}
+
+// SessionSnapshot describes a snapshot to its owner
+type SessionSnapshot struct {
+	Slug         string     `json:"slug"`
+	ShareURL     string     `json:"share_url"`
+	SessionID    string     `json:"session_id"`
+	Title        string     `json:"title,omitempty"`
+	MessageCount int        `json:"message_count"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type SessionSnapshotsResponse struct {
+	SessionID string            `json:"session_id"`
+	Snapshots []SessionSnapshot `json:"snapshots"`
+}
+
+// SnapshotMessage is a message frozen in a snapshot. It carries nothing
+// that identifies the user, the session or the browser.
+type SnapshotMessage struct {
+	Role         string        `json:"role"`
+	Content      string        `json:"content"`
+	ResponseType string        `json:"response_type,omitempty"`
+	Products     []ProductCard `json:"products,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// SharedSnapshot is the public, read-only view of a snapshot
+type SharedSnapshot struct {
+	Slug      string            `json:"slug"`
+	Title     string            `json:"title,omitempty"`
+	Messages  []SnapshotMessage `json:"messages"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
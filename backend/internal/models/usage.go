@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// ═══════════════════════════════════════════════════════════
+// USAGE PLAN MODELS
+// ═══════════════════════════════════════════════════════════
+
+// Usage plans. Accounts store free, pro or internal; visitors who are not
+// logged in, and users with an unverified email when
+// RESTRICT_UNVERIFIED_USERS is on, are on the anonymous plan.
+const (
+	PlanAnonymous = "anonymous"
+	PlanFree      = "free"
+	PlanPro       = "pro"
+	PlanInternal  = "internal"
+)
+
+// Usage dimensions, counted per UTC day
+const (
+	UsageSearches       = "searches"
+	UsageMessages       = "messages"
+	UsageGrounding      = "grounding"
+	UsageProductDetails = "product_details"
+)
+
+// UsageQuota is today's use of one dimension. Limit and Remaining are -1
+// when the plan has no limit.
+type UsageQuota struct {
+	Used      int `json:"used"`
+	Limit     int `json:"limit"`
+	Remaining int `json:"remaining"`
+}
+
+// Quota is what is left of the daily allowance of a plan
+type Quota struct {
+	Plan           string     `json:"plan"`
+	Searches       UsageQuota `json:"searches"`
+	Messages       UsageQuota `json:"messages"`
+	Grounding      UsageQuota `json:"grounding"`
+	ProductDetails UsageQuota `json:"product_details"`
+	ResetsAt       time.Time  `json:"resets_at"` // Next midnight UTC
+}
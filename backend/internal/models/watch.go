@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════
+// PRICE WATCH MODELS
+// ═══════════════════════════════════════════════════════════
+
+type Watch struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	PageToken     string     `json:"page_token"`
+	ProductName   string     `json:"product_name"`
+	TargetPrice   float64    `json:"target_price"`
+	Currency      string     `json:"currency"`
+	CountryCode   string     `json:"country_code"`
+	LastPrice     *float64   `json:"last_price,omitempty"`
+	LastMerchant  string     `json:"last_merchant,omitempty"`
+	LastLink      string     `json:"last_link,omitempty"`
+	NotifiedPrice *float64   `json:"notified_price,omitempty"`
+	Active        bool       `json:"active"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type CreateWatchRequest struct {
+	PageToken   string  `json:"page_token"`
+	ProductName string  `json:"product_name"`
+	TargetPrice float64 `json:"target_price"`
+	Currency    string  `json:"currency,omitempty"`
+	Country     string  `json:"country,omitempty"`
+}
+
+type WatchListResponse struct {
+	Items []Watch `json:"items"`
+	Total int     `json:"total"`
+}
+
+// PriceAlert is pushed to the user's devices when a watch reaches its target.
+// Its JSON shape matches the WebSocket response (type/message/watch).
+type PriceAlert struct {
+	Type    string `json:"type"` // "price_alert"
+	Message string `json:"message"`
+	Watch   *Watch `json:"watch"`
+}
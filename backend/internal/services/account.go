@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/apikey"
+	"mylittleprice/ent/chatsession"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/message"
+	"mylittleprice/ent/searchhistory"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/ent/sessionsnapshot"
+	"mylittleprice/ent/user"
+	"mylittleprice/ent/useridentity"
+	"mylittleprice/ent/userpreference"
+	"mylittleprice/ent/watch"
+	"mylittleprice/internal/models"
+)
+
+// exportSearchPageSize is the page size used to collect the whole search
+// history, the largest SearchHistoryService allows
+const exportSearchPageSize = 100
+
+// AccountService implements the data subject rights of a user (GDPR /
+// Swiss FADP): exporting everything stored about them and erasing it.
+type AccountService struct {
+	client        *ent.Client
+	authService   *AuthService
+	sessions      *SessionService
+	messages      *MessageService
+	preferences   *PreferencesService
+	searchHistory *SearchHistoryService
+	watches       *WatchService
+	snapshots     *SessionSnapshotService
+	audit         *AuditService
+}
+
+func NewAccountService(client *ent.Client, authService *AuthService, sessions *SessionService, messages *MessageService, preferences *PreferencesService, searchHistory *SearchHistoryService, watches *WatchService, snapshots *SessionSnapshotService, audit *AuditService) *AccountService {
+	return &AccountService{
+		client:        client,
+		authService:   authService,
+		sessions:      sessions,
+		messages:      messages,
+		preferences:   preferences,
+		searchHistory: searchHistory,
+		watches:       watches,
+		snapshots:     snapshots,
+		audit:         audit,
+	}
+}
+
+// ExportUserData collects the user's account, preferences, sessions with
+// messages, search history, watches, sharing data, sign-ins and the paid
+// calls made for them
+func (s *AccountService) ExportUserData(ctx context.Context, userID uuid.UUID, ip string) (*models.AccountExport, error) {
+	u, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	export := &models.AccountExport{
+		ExportedAt: time.Now(),
+		User:       u,
+	}
+
+	if export.Preferences, err = s.preferences.GetUserPreferences(userID); err != nil {
+		return nil, err
+	}
+
+	if export.Sessions, err = s.exportSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.SearchHistory, err = s.exportSearchHistory(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessionIDs := make([]string, len(export.Sessions))
+	for i, session := range export.Sessions {
+		sessionIDs[i] = session.SessionID
+	}
+	if export.CostRecords, err = s.exportCostRecords(ctx, userID, sessionIDs); err != nil {
+		return nil, err
+	}
+
+	if export.Watches, err = s.watches.ListWatches(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	grants, err := s.client.SessionParticipant.Query().
+		Where(sessionparticipant.UserIDEQ(userID)).
+		Order(ent.Asc(sessionparticipant.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared sessions: %w", err)
+	}
+	export.SharedSessions = make([]models.AccountExportSharedAccess, len(grants))
+	for i, grant := range grants {
+		export.SharedSessions[i] = models.AccountExportSharedAccess{
+			SessionID: grant.SessionID,
+			InvitedBy: grant.InvitedBy,
+			JoinedAt:  grant.CreatedAt,
+		}
+	}
+
+	if export.Snapshots, err = s.snapshots.ListUserSnapshots(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.RefreshTokens, err = s.authService.ListUserRefreshTokens(userID); err != nil {
+		return nil, fmt.Errorf("failed to list refresh tokens: %w", err)
+	}
+
+	keys, err := s.client.APIKey.Query().
+		Where(apikey.UserIDEQ(userID)).
+		Order(ent.Asc(apikey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	export.APIKeys = make([]models.APIKey, len(keys))
+	for i, key := range keys {
+		export.APIKeys[i] = *toAPIKeyModel(key)
+	}
+
+	identities, err := s.client.UserIdentity.Query().
+		Where(useridentity.UserIDEQ(userID)).
+		Order(ent.Asc(useridentity.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+	export.Identities = make([]models.LinkedIdentity, len(identities))
+	for i, identity := range identities {
+		export.Identities[i] = *toLinkedIdentity(identity)
+	}
+
+	s.record(ctx, models.AuditActionAccountExported, userID, ip, map[string]interface{}{
+		"sessions":       len(export.Sessions),
+		"search_history": len(export.SearchHistory),
+	})
+
+	return export, nil
+}
+
+func (s *AccountService) exportSessions(ctx context.Context, userID uuid.UUID) ([]models.AccountExportSession, error) {
+	rows, err := s.client.ChatSession.Query().
+		Where(chatsession.UserIDEQ(userID)).
+		Order(ent.Asc(chatsession.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]models.AccountExportSession, 0, len(rows))
+	for _, row := range rows {
+		messages, err := s.messages.GetMessages(row.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export messages of session %s: %w", row.SessionID, err)
+		}
+		if messages == nil {
+			messages = []*models.Message{}
+		}
+
+		sessions = append(sessions, models.AccountExportSession{
+			SessionID:    row.SessionID,
+			CountryCode:  row.CountryCode,
+			LanguageCode: row.LanguageCode,
+			Currency:     row.Currency,
+			MessageCount: row.MessageCount,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+			Messages:     messages,
+		})
+	}
+	return sessions, nil
+}
+
+func (s *AccountService) exportSearchHistory(ctx context.Context, userID uuid.UUID) ([]models.SearchHistory, error) {
+	history := []models.SearchHistory{}
+	for offset := 0; ; offset += exportSearchPageSize {
+		page, err := s.searchHistory.GetUserSearchHistory(ctx, &userID, nil, exportSearchPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, page.Items...)
+		if !page.HasMore {
+			return history, nil
+		}
+	}
+}
+
+// exportCostRecords lists the paid calls made for the user, including the
+// ones made anonymously in sessions that were linked later
+func (s *AccountService) exportCostRecords(ctx context.Context, userID uuid.UUID, sessionIDs []string) ([]models.CostRecord, error) {
+	rows, err := s.client.CostRecord.Query().
+		Where(costrecord.Or(
+			costrecord.UserIDEQ(userID),
+			costrecord.SessionIDIn(sessionIDs...),
+		)).
+		Order(ent.Asc(costrecord.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cost records: %w", err)
+	}
+
+	records := make([]models.CostRecord, len(rows))
+	for i, row := range rows {
+		records[i] = toCostRecordModel(row)
+	}
+	return records, nil
+}
+
+// DeleteAccount erases the user: their sessions with messages, search
+// history, watches, preferences, sharing data and the account itself are
+// deleted from PostgreSQL in one transaction, then the Redis copies. Cost
+// records lose their user and session in the same transaction. All refresh
+// tokens are revoked. Only an audit entry with the user ID and the counts
+// remains. Email accounts confirm with their password.
+func (s *AccountService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (*models.AccountDeletionSummary, error) {
+	u, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if u.Provider == "email" {
+		if err := s.authService.VerifyPassword(u, password); err != nil {
+			return nil, err
+		}
+	}
+
+	sessionRows, err := s.client.ChatSession.Query().
+		Where(chatsession.UserIDEQ(userID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessionUUIDs := make([]uuid.UUID, len(sessionRows))
+	sessionIDs := make([]string, len(sessionRows))
+	for i, row := range sessionRows {
+		sessionUUIDs[i] = row.ID
+		sessionIDs[i] = row.SessionID
+	}
+
+	// Their Redis mappings are removed after the rows
+	identities, err := s.client.UserIdentity.Query().
+		Where(useridentity.UserIDEQ(userID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	summary := &models.AccountDeletionSummary{}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if err := deleteUserRows(ctx, tx, userID, sessionUUIDs, sessionIDs, summary); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			fmt.Printf("⚠️ Failed to roll back account deletion: %v\n", rbErr)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit account deletion: %w", err)
+	}
+
+	// PostgreSQL is the source of truth; leftovers in Redis expire or are
+	// unreachable without the user
+	if err := s.authService.revokeAllUserRefreshTokens(userID); err != nil {
+		fmt.Printf("⚠️ Failed to revoke refresh tokens of deleted user %s: %v\n", userID.String(), err)
+	}
+	if err := s.authService.deleteUserFromRedis(u); err != nil {
+		fmt.Printf("⚠️ Failed to delete user %s from Redis: %v\n", userID.String(), err)
+	}
+	s.authService.deleteIdentityMappings(identities)
+	for _, sessionID := range sessionIDs {
+		if err := s.sessions.DeleteSession(sessionID); err != nil {
+			fmt.Printf("⚠️ Failed to delete session %s from Redis: %v\n", sessionID, err)
+		}
+	}
+
+	// No IP address: it would be personal data outliving the account
+	s.record(ctx, models.AuditActionAccountDeleted, userID, "", map[string]interface{}{
+		"sessions":       summary.Sessions,
+		"messages":       summary.Messages,
+		"search_history": summary.SearchHistory,
+		"watches":        summary.Watches,
+		"snapshots":      summary.Snapshots,
+		"shared_access":  summary.SharedAccess,
+		"api_keys":       summary.APIKeys,
+		"identities":     summary.Identities,
+		"cost_records":   summary.CostRecords,
+	})
+
+	fmt.Printf("🗑️ Account %s deleted (%d sessions, %d messages)\n", userID.String(), summary.Sessions, summary.Messages)
+	return summary, nil
+}
+
+// deleteUserRows deletes children before parents; the tables reference
+// each other without ON DELETE CASCADE when created by ent
+func deleteUserRows(ctx context.Context, tx *ent.Tx, userID uuid.UUID, sessionUUIDs []uuid.UUID, sessionIDs []string, summary *models.AccountDeletionSummary) error {
+	var err error
+
+	// The user's grants to other sessions and other users' grants to theirs
+	if summary.SharedAccess, err = tx.SessionParticipant.Delete().
+		Where(sessionparticipant.Or(
+			sessionparticipant.UserIDEQ(userID),
+			sessionparticipant.SessionIDIn(sessionIDs...),
+		)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete session participants: %w", err)
+	}
+
+	if summary.Snapshots, err = tx.SessionSnapshot.Delete().
+		Where(sessionsnapshot.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete snapshots: %w", err)
+	}
+
+	if summary.Messages, err = tx.Message.Delete().
+		Where(message.SessionIDIn(sessionUUIDs...)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete messages: %w", err)
+	}
+
+	// Including the searches made anonymously before the sessions were linked
+	if summary.SearchHistory, err = tx.SearchHistory.Delete().
+		Where(searchhistory.Or(
+			searchhistory.UserIDEQ(userID),
+			searchhistory.SessionIDIn(sessionIDs...),
+		)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete search history: %w", err)
+	}
+
+	if summary.Watches, err = tx.Watch.Delete().
+		Where(watch.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete watches: %w", err)
+	}
+
+	if _, err = tx.UserPreference.Delete().
+		Where(userpreference.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+
+	if summary.APIKeys, err = tx.APIKey.Delete().
+		Where(apikey.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete API keys: %w", err)
+	}
+
+	if summary.Identities, err = tx.UserIdentity.Delete().
+		Where(useridentity.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete identities: %w", err)
+	}
+
+	// Cost records keep their amounts for the totals but no longer point
+	// to the user or their sessions
+	if summary.CostRecords, err = tx.CostRecord.Update().
+		Where(costrecord.Or(
+			costrecord.UserIDEQ(userID),
+			costrecord.SessionIDIn(sessionIDs...),
+		)).
+		ClearUserID().
+		ClearSessionID().
+		Save(ctx); err != nil {
+		return fmt.Errorf("failed to anonymize cost records: %w", err)
+	}
+
+	if summary.Sessions, err = tx.ChatSession.Delete().
+		Where(chatsession.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+
+	// Users who never got synced to PostgreSQL have no row
+	if _, err = tx.User.Delete().
+		Where(user.IDEQ(userID)).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AccountService) record(ctx context.Context, action string, userID uuid.UUID, ip string, details map[string]interface{}) {
+	err := s.audit.Record(ctx, &models.AuditEntry{
+		Action:    action,
+		ActorID:   &userID,
+		SubjectID: &userID,
+		IPAddress: ip,
+		Details:   details,
+	})
+	if err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/chatsession"
+	"mylittleprice/ent/searchhistory"
+	"mylittleprice/ent/user"
+	"mylittleprice/internal/models"
+)
+
+var (
+	ErrInboxItemNotFound = errors.New("inbox item not found")
+	ErrUnknownInbox      = errors.New("unknown inbox")
+)
+
+const (
+	// Submission keys are <kind>:<20060102_150405>:<submitter>
+	inboxKeyTimeLayout = "20060102_150405"
+	inboxScanBatch     = 200
+	inboxDefaultLimit  = 50
+	inboxMaxLimit      = 200
+)
+
+// AdminService backs the admin API: the bug report and contact inboxes
+// kept in Redis by the public submission endpoints, and user activity
+type AdminService struct {
+	client *ent.Client
+	redis  *redis.Client
+}
+
+func NewAdminService(client *ent.Client, redis *redis.Client) *AdminService {
+	return &AdminService{
+		client: client,
+		redis:  redis,
+	}
+}
+
+// ListInbox returns the submissions of one inbox matching the filter,
+// newest first. Total counts every match, not just the returned page.
+func (s *AdminService) ListInbox(ctx context.Context, kind string, filter *models.InboxFilter) (*models.InboxListResponse, error) {
+	if err := validateInbox(kind); err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	items := make([]models.InboxItem, 0)
+
+	var cursor uint64
+	for {
+		keys, next, err := s.redis.Scan(ctx, cursor, kind+":*", inboxScanBatch).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s keys: %w", kind, err)
+		}
+
+		if len(keys) > 0 {
+			values, err := s.redis.MGet(ctx, keys...).Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s entries: %w", kind, err)
+			}
+
+			for i, value := range values {
+				raw, ok := value.(string)
+				if !ok {
+					continue // Expired between SCAN and MGET
+				}
+				if query != "" && !strings.Contains(strings.ToLower(raw), query) {
+					continue
+				}
+
+				item, err := parseInboxItem(kind, keys[i], raw)
+				if err != nil {
+					fmt.Printf("⚠️ Skipping malformed %s: %v\n", keys[i], err)
+					continue
+				}
+				if matchesInboxFilter(item, filter) {
+					items = append(items, *item)
+				}
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SubmittedAt.After(items[j].SubmittedAt)
+	})
+
+	limit, offset := pageBounds(filter.Limit, filter.Offset)
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return &models.InboxListResponse{
+		Items: items[offset:end],
+		Total: total,
+	}, nil
+}
+
+// ResolveInboxItem marks a submission as resolved. The entry keeps its
+// original expiry.
+func (s *AdminService) ResolveInboxItem(ctx context.Context, kind, id, resolvedBy string) (*models.InboxItem, error) {
+	if err := validateInbox(kind); err != nil {
+		return nil, err
+	}
+
+	key := inboxKey(kind, id)
+	raw, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrInboxItemNotFound
+		}
+		return nil, fmt.Errorf("failed to load %s: %w", key, err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+
+	data["status"] = models.InboxStatusResolved
+	data["resolved_at"] = time.Now().Format(time.RFC3339)
+	data["resolved_by"] = resolvedBy
+
+	updated, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	// SetXX so an entry that expired in the meantime isn't recreated without a TTL
+	ok, err := s.redis.SetArgs(ctx, key, updated, redis.SetArgs{Mode: "XX", KeepTTL: true}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to update %s: %w", key, err)
+	}
+	if ok != "OK" {
+		return nil, ErrInboxItemNotFound
+	}
+
+	return parseInboxItem(kind, key, string(updated))
+}
+
+// DeleteInboxItem removes a submission
+func (s *AdminService) DeleteInboxItem(ctx context.Context, kind, id string) error {
+	if err := validateInbox(kind); err != nil {
+		return err
+	}
+
+	deleted, err := s.redis.Del(ctx, inboxKey(kind, id)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", id, err)
+	}
+	if deleted == 0 {
+		return ErrInboxItemNotFound
+	}
+	return nil
+}
+
+// ListUsers returns users, newest first, with their chat session and search
+// counts. query matches email or name.
+func (s *AdminService) ListUsers(ctx context.Context, query string, limit, offset int) (*models.AdminUserListResponse, error) {
+	q := s.client.User.Query()
+	if query = strings.TrimSpace(query); query != "" {
+		q = q.Where(user.Or(
+			user.EmailContainsFold(query),
+			user.NameContainsFold(query),
+		))
+	}
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	limit, offset = pageBounds(limit, offset)
+	users, err := q.
+		Order(ent.Desc(user.FieldCreatedAt)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	var sessionCounts, searchCounts []userCount
+	if len(ids) > 0 {
+		err = s.client.ChatSession.Query().
+			Where(chatsession.UserIDIn(ids...)).
+			GroupBy(chatsession.FieldUserID).
+			Aggregate(ent.Count()).
+			Scan(ctx, &sessionCounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count sessions: %w", err)
+		}
+
+		err = s.client.SearchHistory.Query().
+			Where(searchhistory.UserIDIn(ids...)).
+			GroupBy(searchhistory.FieldUserID).
+			Aggregate(ent.Count()).
+			Scan(ctx, &searchCounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count searches: %w", err)
+		}
+	}
+
+	sessionsByUser := countsByUser(sessionCounts)
+	searchesByUser := countsByUser(searchCounts)
+
+	items := make([]models.AdminUser, len(users))
+	for i, u := range users {
+		items[i] = models.AdminUser{
+			ID:            u.ID,
+			Email:         u.Email,
+			FullName:      u.Name,
+			Provider:      u.Provider,
+			Role:          u.Role,
+			Plan:          u.Plan,
+			EmailVerified: u.EmailVerified,
+			SessionCount:  sessionsByUser[u.ID],
+			SearchCount:   searchesByUser[u.ID],
+			CreatedAt:     u.CreatedAt,
+		}
+		if !u.LastLogin.IsZero() {
+			lastLogin := u.LastLogin
+			items[i].LastLoginAt = &lastLogin
+		}
+	}
+
+	return &models.AdminUserListResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+type userCount struct {
+	UserID uuid.UUID `json:"user_id"`
+	Count  int       `json:"count"`
+}
+
+func countsByUser(counts []userCount) map[uuid.UUID]int {
+	result := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		result[c.UserID] = c.Count
+	}
+	return result
+}
+
+func validateInbox(kind string) error {
+	if kind != models.InboxBugReports && kind != models.InboxContactMessages {
+		return fmt.Errorf("%w: %s", ErrUnknownInbox, kind)
+	}
+	return nil
+}
+
+// inboxKey accepts an item ID or the full Redis key (the submission
+// endpoints return the key as the ID)
+func inboxKey(kind, id string) string {
+	return kind + ":" + strings.TrimPrefix(id, kind+":")
+}
+
+// parseInboxItem splits the triage fields added by ResolveInboxItem from
+// the submitted data
+func parseInboxItem(kind, key, raw string) (*models.InboxItem, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimPrefix(key, kind+":")
+	item := &models.InboxItem{
+		ID:     id,
+		Kind:   kind,
+		Status: models.InboxStatusOpen,
+		Data:   data,
+	}
+
+	// Key timestamps are server local time
+	if ts, _, ok := strings.Cut(id, ":"); ok {
+		if submittedAt, err := time.ParseInLocation(inboxKeyTimeLayout, ts, time.Local); err == nil {
+			item.SubmittedAt = submittedAt
+		}
+	}
+
+	if status, ok := data["status"].(string); ok && status != "" {
+		item.Status = status
+	}
+	if resolvedAt, ok := data["resolved_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, resolvedAt); err == nil {
+			item.ResolvedAt = &t
+		}
+	}
+	if resolvedBy, ok := data["resolved_by"].(string); ok {
+		item.ResolvedBy = resolvedBy
+	}
+	delete(data, "status")
+	delete(data, "resolved_at")
+	delete(data, "resolved_by")
+
+	return item, nil
+}
+
+func matchesInboxFilter(item *models.InboxItem, filter *models.InboxFilter) bool {
+	if filter.Status != "" && item.Status != filter.Status {
+		return false
+	}
+	if !filter.Since.IsZero() && item.SubmittedAt.Before(filter.Since) {
+		return false
+	}
+	if !filter.Until.IsZero() && !item.SubmittedAt.Before(filter.Until) {
+		return false
+	}
+	return true
+}
+
+func pageBounds(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = inboxDefaultLimit
+	}
+	if limit > inboxMaxLimit {
+		limit = inboxMaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
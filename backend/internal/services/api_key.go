@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/apikey"
+	"mylittleprice/ent/predicate"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+var (
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyInvalid      = errors.New("invalid, revoked or expired API key")
+	ErrAPIKeyLimit        = errors.New("API key limit reached")
+	ErrAPIKeyInvalidScope = errors.New("invalid API key scope")
+	ErrAPIKeyInvalidName  = errors.New("API key name is required and limited to 100 characters")
+)
+
+const (
+	apiKeyPrefix           = "mlp_"
+	apiKeyDisplayLength    = 12 // "mlp_" and the first 8 characters
+	maxAPIKeyExpiryDays    = 365
+	maxAPIKeyNameLength    = 100
+	apiKeyLastUsedInterval = time.Minute // last_used_at is written at most once per minute
+)
+
+// APIKeyService manages personal API keys. Keys are random, shown once and
+// stored as SHA-256 hashes; a key acts as its owner within its scopes.
+type APIKeyService struct {
+	client      *ent.Client
+	authService *AuthService
+	config      *config.Config
+}
+
+func NewAPIKeyService(client *ent.Client, authService *AuthService, cfg *config.Config) *APIKeyService {
+	return &APIKeyService{
+		client:      client,
+		authService: authService,
+		config:      cfg,
+	}
+}
+
+// CreateKey creates a key for the user. expiresInDays of 0 creates a key
+// that never expires.
+func (s *APIKeyService) CreateKey(ctx context.Context, userID uuid.UUID, req *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > maxAPIKeyNameLength {
+		return nil, ErrAPIKeyInvalidName
+	}
+	scopes, err := normalizeAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.client.APIKey.Query().
+		Where(apikey.UserIDEQ(userID), activeAPIKey()).
+		Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	if active >= s.config.APIKeyMaxPerUser {
+		return nil, ErrAPIKeyLimit
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := s.client.APIKey.Create().
+		SetUserID(userID).
+		SetName(name).
+		SetPrefix(key[:apiKeyDisplayLength]).
+		SetKeyHash(hashAPIKey(key)).
+		SetScopes(scopes)
+	if expiresAt := apiKeyExpiry(req.ExpiresInDays, time.Now()); expiresAt != nil {
+		builder.SetExpiresAt(*expiresAt)
+	}
+
+	created, err := builder.Save(ctx)
+	if err != nil && isForeignKeyError(err) {
+		// User may exist only in Redis so far
+		user, lookupErr := s.authService.GetUserByID(userID)
+		if lookupErr != nil {
+			return nil, fmt.Errorf("failed to fetch user from Redis: %w", lookupErr)
+		}
+		if syncErr := s.authService.SaveUserToPostgres(user); syncErr != nil {
+			return nil, fmt.Errorf("failed to sync user to PostgreSQL: %w", syncErr)
+		}
+		created, err = builder.Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	fmt.Printf("🔑 API key %s created by user %s (scopes: %s)\n", created.Prefix, userID.String(), strings.Join(scopes, ", "))
+	return &models.CreatedAPIKey{
+		APIKey: *toAPIKeyModel(created),
+		Key:    key,
+	}, nil
+}
+
+// ListKeys returns the user's keys that are neither revoked nor expired,
+// newest first
+func (s *APIKeyService) ListKeys(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error) {
+	keys, err := s.client.APIKey.Query().
+		Where(apikey.UserIDEQ(userID), activeAPIKey()).
+		Order(ent.Desc(apikey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	result := make([]models.APIKey, len(keys))
+	for i, key := range keys {
+		result[i] = *toAPIKeyModel(key)
+	}
+	return result, nil
+}
+
+// RevokeKey stops a key of the user from working
+func (s *APIKeyService) RevokeKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	n, err := s.client.APIKey.Update().
+		Where(
+			apikey.IDEQ(keyID),
+			apikey.UserIDEQ(userID),
+			apikey.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if n == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate resolves a key to its owner and scopes
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*models.APIKeyPrincipal, error) {
+	rawKey = strings.TrimSpace(rawKey)
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	key, err := s.client.APIKey.Query().
+		Where(apikey.KeyHashEQ(hashAPIKey(rawKey)), activeAPIKey()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrAPIKeyInvalid
+		}
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	// Scripts call often; a minute is precise enough
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > apiKeyLastUsedInterval {
+		if err := s.client.APIKey.UpdateOneID(key.ID).SetLastUsedAt(time.Now()).Exec(ctx); err != nil {
+			fmt.Printf("⚠️ Failed to update last use of API key %s: %v\n", key.Prefix, err)
+		}
+	}
+
+	return &models.APIKeyPrincipal{
+		KeyID:  key.ID,
+		UserID: key.UserID,
+		Scopes: key.Scopes,
+	}, nil
+}
+
+// activeAPIKey matches keys that are neither revoked nor expired
+func activeAPIKey() predicate.APIKey {
+	return apikey.And(
+		apikey.RevokedAtIsNil(),
+		apikey.Or(
+			apikey.ExpiresAtIsNil(),
+			apikey.ExpiresAtGT(time.Now()),
+		),
+	)
+}
+
+// apiKeyExpiry returns when a key created now expires, nil for keys that
+// never expire. Lifetimes are capped at maxAPIKeyExpiryDays.
+func apiKeyExpiry(expiresInDays int, now time.Time) *time.Time {
+	if expiresInDays <= 0 {
+		return nil
+	}
+	expiresAt := now.AddDate(0, 0, min(expiresInDays, maxAPIKeyExpiryDays))
+	return &expiresAt
+}
+
+func normalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		valid := false
+		for _, known := range models.APIKeyScopes {
+			if scope == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: %q", ErrAPIKeyInvalidScope, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrAPIKeyInvalidScope)
+	}
+	return result, nil
+}
+
+func generateAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func toAPIKeyModel(key *ent.APIKey) *models.APIKey {
+	return &models.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
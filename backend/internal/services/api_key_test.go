@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeAPIKeyScopes(t *testing.T) {
+	tests := []struct {
+		scopes  []string
+		want    []string
+		wantErr bool
+	}{
+		{[]string{"chat"}, []string{"chat"}, false},
+		{[]string{" Chat ", "SEARCH-HISTORY"}, []string{"chat", "search-history"}, false},
+		{[]string{"chat", "preferences", "chat"}, []string{"chat", "preferences"}, false},
+		{[]string{"chat", "search-history", "preferences"}, []string{"chat", "search-history", "preferences"}, false},
+		{nil, nil, true},
+		{[]string{}, nil, true},
+		{[]string{"admin"}, nil, true},
+		{[]string{"chat", "admin"}, nil, true},
+		{[]string{""}, nil, true},
+		{[]string{"*"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeAPIKeyScopes(tt.scopes)
+		if tt.wantErr {
+			if !errors.Is(err, ErrAPIKeyInvalidScope) {
+				t.Errorf("normalizeAPIKeyScopes(%q) error = %v, want ErrAPIKeyInvalidScope", tt.scopes, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeAPIKeyScopes(%q): %v", tt.scopes, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("normalizeAPIKeyScopes(%q) = %q, want %q", tt.scopes, got, tt.want)
+		}
+	}
+}
+
+func TestAPIKeyExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		days int
+		want time.Time // Zero - never expires
+	}{
+		{0, time.Time{}},
+		{-1, time.Time{}},
+		{1, time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)},
+		{30, time.Date(2026, 4, 9, 12, 0, 0, 0, time.UTC)},
+		{365, time.Date(2027, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{366, time.Date(2027, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{100000, time.Date(2027, 3, 10, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got := apiKeyExpiry(tt.days, now)
+		switch {
+		case tt.want.IsZero() && got != nil:
+			t.Errorf("apiKeyExpiry(%d) = %v, want no expiry", tt.days, *got)
+		case !tt.want.IsZero() && (got == nil || !got.Equal(tt.want)):
+			t.Errorf("apiKeyExpiry(%d) = %v, want %v", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, err := generateAPIKey()
+	if err != nil {
+		t.Fatalf("generateAPIKey: %v", err)
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		t.Errorf("key %q lacks the %q prefix", key, apiKeyPrefix)
+	}
+	if len(key) <= apiKeyDisplayLength {
+		t.Errorf("key %q is not longer than its displayed prefix", key)
+	}
+
+	other, err := generateAPIKey()
+	if err != nil {
+		t.Fatalf("generateAPIKey: %v", err)
+	}
+	if key == other {
+		t.Error("two generated keys are equal")
+	}
+	if hashAPIKey(key) != hashAPIKey(key) || hashAPIKey(key) == hashAPIKey(other) {
+		t.Error("key hashes are not stable per key")
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/auditlog"
+	"mylittleprice/internal/models"
+)
+
+// AuditService keeps the audit trail of security and privacy relevant
+// actions in PostgreSQL. Entries never reference users by foreign key, so
+// the record of an account deletion survives it.
+type AuditService struct {
+	client *ent.Client
+}
+
+func NewAuditService(client *ent.Client) *AuditService {
+	return &AuditService{client: client}
+}
+
+// Record stores an entry. Callers log the error rather than fail the
+// action that already happened.
+func (s *AuditService) Record(ctx context.Context, entry *models.AuditEntry) error {
+	builder := s.client.AuditLog.Create().
+		SetAction(entry.Action).
+		SetNillableActorID(entry.ActorID).
+		SetNillableSubjectID(entry.SubjectID)
+	if entry.IPAddress != "" {
+		builder.SetIPAddress(entry.IPAddress)
+	}
+	if len(entry.Details) > 0 {
+		builder.SetDetails(entry.Details)
+	}
+
+	if _, err := builder.Save(ctx); err != nil {
+		return fmt.Errorf("failed to record audit entry %s: %w", entry.Action, err)
+	}
+	return nil
+}
+
+// List returns entries newest first, optionally only one action or the
+// entries about one user
+func (s *AuditService) List(ctx context.Context, action string, subjectID *uuid.UUID, limit, offset int) (*models.AuditLogResponse, error) {
+	q := s.client.AuditLog.Query()
+	if action != "" {
+		q = q.Where(auditlog.ActionEQ(action))
+	}
+	if subjectID != nil {
+		q = q.Where(auditlog.SubjectIDEQ(*subjectID))
+	}
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	limit, offset = pageBounds(limit, offset)
+	rows, err := q.
+		Order(ent.Desc(auditlog.FieldCreatedAt)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	items := make([]models.AuditEntry, len(rows))
+	for i, row := range rows {
+		items[i] = models.AuditEntry{
+			ID:        row.ID,
+			Action:    row.Action,
+			ActorID:   row.ActorID,
+			SubjectID: row.SubjectID,
+			IPAddress: row.IPAddress,
+			Details:   row.Details,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+
+	return &models.AuditLogResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
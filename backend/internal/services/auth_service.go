@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,26 +30,45 @@ var (
 	ErrTokenExpired         = errors.New("reset token has expired")
 	ErrTokenAlreadyUsed     = errors.New("reset token has already been used")
 	ErrResetTokenNotFound   = errors.New("reset token not found")
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrInvalidPlan          = errors.New("invalid plan")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrVerificationInvalid  = errors.New("verification token not found or expired")
 )
 
 type AuthService struct {
-	client      *ent.Client
-	redis       *redis.Client
-	jwtService  *utils.JWTService
-	googleOAuth *GoogleOAuthService
-	ctx         context.Context
+	client          *ent.Client
+	redis           *redis.Client
+	jwtService      *utils.JWTService
+	googleOAuth     *GoogleOAuthService
+	oidc            *OIDCService
+	ctx             context.Context
+	verificationTTL time.Duration
+	totpBox         *utils.SecretBox // Seals TOTP secrets; nil disables 2FA enrolment
 }
 
-func NewAuthService(client *ent.Client, redis *redis.Client, jwtService *utils.JWTService, googleOAuth *GoogleOAuthService) *AuthService {
+func NewAuthService(client *ent.Client, redis *redis.Client, jwtService *utils.JWTService, googleOAuth *GoogleOAuthService, oidc *OIDCService) *AuthService {
 	return &AuthService{
-		client:      client,
-		redis:       redis,
-		jwtService:  jwtService,
-		googleOAuth: googleOAuth,
-		ctx:         context.Background(),
+		client:          client,
+		redis:           redis,
+		jwtService:      jwtService,
+		googleOAuth:     googleOAuth,
+		oidc:            oidc,
+		ctx:             context.Background(),
+		verificationTTL: 24 * time.Hour,
 	}
 }
 
+// SetEmailVerificationTTL sets how long verification links stay valid
+func (s *AuthService) SetEmailVerificationTTL(ttl time.Duration) {
+	s.verificationTTL = ttl
+}
+
+// SetTOTPSecretBox sets the key TOTP secrets are encrypted with at rest
+func (s *AuthService) SetTOTPSecretBox(box *utils.SecretBox) {
+	s.totpBox = box
+}
+
 // UserLookup defines criteria for looking up a user
 type UserLookup struct {
 	ByID         *uuid.UUID
@@ -60,7 +80,7 @@ type UserLookup struct {
 }
 
 // Signup creates a new user account
-func (s *AuthService) Signup(req *models.SignupRequest) (*models.AuthResponse, error) {
+func (s *AuthService) Signup(req *models.SignupRequest, device *models.DeviceInfo) (*models.AuthResponse, error) {
 	// Validate input
 	if err := validateEmail(req.Email); err != nil {
 		return nil, fmt.Errorf("invalid email: %w", err)
@@ -91,6 +111,8 @@ func (s *AuthService) Signup(req *models.SignupRequest) (*models.AuthResponse, e
 		PasswordHash: string(hashedPassword),
 		FullName:     req.FullName,
 		Provider:     "email",
+		Role:         models.UserRoleUser,
+		Plan:         models.PlanFree,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -100,76 +122,54 @@ func (s *AuthService) Signup(req *models.SignupRequest) (*models.AuthResponse, e
 	}
 
 	// Generate tokens
-	return s.generateAuthResponse(user)
+	return s.generateAuthResponse(user, device)
 }
 
-// GoogleLogin authenticates a user via Google OAuth and returns tokens
-func (s *AuthService) GoogleLogin(idToken string) (*models.AuthResponse, error) {
+// GoogleLogin authenticates a user via Google OAuth and returns tokens, or
+// a challenge when the account has two-factor authentication enabled
+func (s *AuthService) GoogleLogin(idToken string, device *models.DeviceInfo) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
 	// Verify the Google ID token
 	googleUser, err := s.googleOAuth.VerifyIDToken(s.ctx, idToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify Google token: %w", err)
-	}
-
-	// Check if user exists by provider ID
-	user, err := s.getUserByProviderID("google", googleUser.Sub)
-	if err != nil && !errors.Is(err, redis.Nil) {
-		return nil, fmt.Errorf("failed to get user: %w", err)
-	}
-
-	// If user doesn't exist, create new account
-	if errors.Is(err, redis.Nil) {
-		user = &models.User{
-			ID:         uuid.New(),
-			Email:      googleUser.Email,
-			FullName:   googleUser.Name,
-			Picture:    googleUser.Picture,
-			Provider:   "google",
-			ProviderID: googleUser.Sub,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
-		}
-
-		if err := s.saveUser(user); err != nil {
-			return nil, fmt.Errorf("failed to save user: %w", err)
-		}
-	}
-
-	// Update last login
-	now := time.Now()
-	user.LastLoginAt = &now
-	user.Picture = googleUser.Picture // Update picture in case it changed
-	user.FullName = googleUser.Name   // Update name in case it changed
-	if err := s.saveUser(user); err != nil {
-		fmt.Printf("Warning: failed to update user info: %v\n", err)
+		return nil, nil, fmt.Errorf("%w: %v", ErrOIDCInvalidToken, err)
 	}
 
-	// Generate tokens
-	return s.generateAuthResponse(user)
+	return s.loginWithIdentity(googleIdentity(googleUser), device)
 }
 
-// Login authenticates a user and returns tokens
-func (s *AuthService) Login(req *models.LoginRequest) (*models.AuthResponse, error) {
+// Login authenticates a user and returns tokens, or a challenge for
+// /api/auth/2fa/verify when the account has two-factor authentication
+// enabled
+func (s *AuthService) Login(req *models.LoginRequest, device *models.DeviceInfo) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
 	// Validate input
 	if err := validateEmail(req.Email); err != nil {
-		return nil, fmt.Errorf("invalid email: %w", err)
+		return nil, nil, fmt.Errorf("invalid email: %w", err)
 	}
 	if req.Password == "" {
-		return nil, fmt.Errorf("password cannot be empty")
+		return nil, nil, fmt.Errorf("password cannot be empty")
 	}
 
 	// Get user by email
 	user, err := s.getUserByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return nil, ErrUserNotFound
+			return nil, nil, ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		return nil, ErrInvalidPassword
+		return nil, nil, ErrInvalidPassword
+	}
+
+	// Second factor required - last login is updated when it is verified
+	challenge, err := s.startTwoFactorChallenge(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
 	}
 
 	// Update last login
@@ -181,11 +181,14 @@ func (s *AuthService) Login(req *models.LoginRequest) (*models.AuthResponse, err
 	}
 
 	// Generate tokens
-	return s.generateAuthResponse(user)
+	authResp, err := s.generateAuthResponse(user, device)
+	return authResp, nil, err
 }
 
-// RefreshAccessToken generates a new access token using a refresh token
-func (s *AuthService) RefreshAccessToken(refreshToken string) (*models.AuthResponse, error) {
+// RefreshAccessToken rotates a refresh token: the token stops working and
+// a new one of the same device session is returned with the access token.
+// Presenting a rotated token again revokes the whole device session.
+func (s *AuthService) RefreshAccessToken(refreshToken string, device *models.DeviceInfo) (*models.AuthResponse, error) {
 	// Validate refresh token
 	tokenHash := s.hashToken(refreshToken)
 	refreshTokenData, err := s.getRefreshToken(tokenHash)
@@ -203,30 +206,64 @@ func (s *AuthService) RefreshAccessToken(refreshToken string) (*models.AuthRespo
 	if time.Now().After(refreshTokenData.ExpiresAt) {
 		return nil, ErrInvalidToken
 	}
+	if refreshTokenData.RotatedAt != nil {
+		s.handleRefreshTokenReuse(refreshTokenData)
+		return nil, ErrRefreshTokenReused
+	}
 
-	// Get user
-	user, err := s.getUserByID(refreshTokenData.UserID)
+	familyID := refreshTokenData.FamilyID
+	if familyID != uuid.Nil {
+		session, err := s.getDeviceSession(familyID)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to get device session: %w", err)
+		}
+		if err != nil || session.revoked || session.userID != refreshTokenData.UserID {
+			return nil, ErrInvalidToken
+		}
+	} else {
+		// Issued before device sessions - continue in a new one
+		familyID = uuid.New()
+	}
+
+	// Only one request can rotate a token; a concurrent second use counts
+	// as reuse
+	now := time.Now().Format(time.RFC3339)
+	key := fmt.Sprintf("refresh_token:%s", tokenHash)
+	rotated, err := s.redis.HSetNX(s.ctx, key, "rotated_at", now).Result()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		s.handleRefreshTokenReuse(refreshTokenData)
+		return nil, ErrRefreshTokenReused
 	}
+	s.redis.HSet(s.ctx, key, "last_used_at", now)
 
-	// Generate new access token (keep same refresh token)
-	accessToken, err := s.jwtService.GenerateAccessToken(user.ID, user.Email)
+	// Get user
+	user, err := s.getUserByID(refreshTokenData.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         s.toUserInfo(user),
-		ExpiresIn:    int64(s.jwtService.GetAccessTTL().Seconds()),
-	}, nil
+	return s.issueTokens(user, familyID, device)
 }
 
-// Logout revokes a refresh token
+// Logout revokes a refresh token and signs its device session out
 func (s *AuthService) Logout(refreshToken string) error {
 	tokenHash := s.hashToken(refreshToken)
+	refreshTokenData, err := s.getRefreshToken(tokenHash)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil // Unknown or expired - nothing to revoke
+		}
+		return err
+	}
+
+	if refreshTokenData.FamilyID != uuid.Nil {
+		if err := s.revokeDeviceSession(refreshTokenData.UserID, refreshTokenData.FamilyID); err != nil {
+			return err
+		}
+	}
 	return s.revokeRefreshToken(tokenHash)
 }
 
@@ -304,39 +341,9 @@ func (s *AuthService) ClaimSessions(userID uuid.UUID, sessionIDs []string) error
 
 // ==================== Private Helper Methods ====================
 
-func (s *AuthService) generateAuthResponse(user *models.User) (*models.AuthResponse, error) {
-	// Generate access token
-	accessToken, err := s.jwtService.GenerateAccessToken(user.ID, user.Email)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
-	// Generate refresh token
-	refreshToken, err := s.jwtService.GenerateRefreshToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
-	}
-
-	// Save refresh token
-	tokenHash := s.hashToken(refreshToken)
-	refreshTokenData := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		TokenHash: tokenHash,
-		ExpiresAt: time.Now().Add(s.jwtService.GetRefreshTTL()),
-		CreatedAt: time.Now(),
-	}
-
-	if err := s.saveRefreshToken(refreshTokenData); err != nil {
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
-	}
-
-	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User:         s.toUserInfo(user),
-		ExpiresIn:    int64(s.jwtService.GetAccessTTL().Seconds()),
-	}, nil
+// generateAuthResponse signs a user in on a new device session
+func (s *AuthService) generateAuthResponse(user *models.User, device *models.DeviceInfo) (*models.AuthResponse, error) {
+	return s.issueTokens(user, uuid.New(), device)
 }
 
 func (s *AuthService) userExists(email string) (bool, error) {
@@ -354,15 +361,18 @@ func (s *AuthService) saveUser(user *models.User) error {
 	// 2. Save to Redis (for fast access)
 	userKey := fmt.Sprintf("user:id:%s", user.ID.String())
 	userData := map[string]interface{}{
-		"id":            user.ID.String(),
-		"email":         user.Email,
-		"password_hash": user.PasswordHash,
-		"full_name":     user.FullName,
-		"picture":       user.Picture,
-		"provider":      user.Provider,
-		"provider_id":   user.ProviderID,
-		"created_at":    user.CreatedAt.Format(time.RFC3339),
-		"updated_at":    user.UpdatedAt.Format(time.RFC3339),
+		"id":             user.ID.String(),
+		"email":          user.Email,
+		"password_hash":  user.PasswordHash,
+		"full_name":      user.FullName,
+		"picture":        user.Picture,
+		"provider":       user.Provider,
+		"provider_id":    user.ProviderID,
+		"role":           user.Role,
+		"plan":           user.Plan,
+		"email_verified": strconv.FormatBool(user.EmailVerified),
+		"created_at":     user.CreatedAt.Format(time.RFC3339),
+		"updated_at":     user.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if user.LastLoginAt != nil {
@@ -409,6 +419,7 @@ func (s *AuthService) SaveUserToPostgres(userModel *models.User) error {
 			SetName(userModel.FullName).
 			SetAvatarURL(userModel.Picture).
 			SetProvider(userModel.Provider).
+			SetEmailVerified(userModel.EmailVerified).
 			SetUpdatedAt(userModel.UpdatedAt)
 
 		// Only set google_id if it's not empty (to avoid unique constraint violation)
@@ -436,6 +447,7 @@ func (s *AuthService) SaveUserToPostgres(userModel *models.User) error {
 			SetName(userModel.FullName).
 			SetAvatarURL(userModel.Picture).
 			SetProvider(userModel.Provider).
+			SetEmailVerified(userModel.EmailVerified).
 			SetCreatedAt(userModel.CreatedAt).
 			SetUpdatedAt(userModel.UpdatedAt)
 
@@ -483,7 +495,10 @@ func (s *AuthService) saveRefreshToken(token *models.RefreshToken) error {
 	tokenData := map[string]interface{}{
 		"id":         token.ID.String(),
 		"user_id":    token.UserID.String(),
+		"family_id":  token.FamilyID.String(),
 		"token_hash": token.TokenHash,
+		"user_agent": token.UserAgent,
+		"ip_address": token.IPAddress,
 		"expires_at": token.ExpiresAt.Format(time.RFC3339),
 		"created_at": token.CreatedAt.Format(time.RFC3339),
 	}
@@ -513,7 +528,13 @@ func (s *AuthService) getRefreshToken(tokenHash string) (*models.RefreshToken, e
 	if userID, err := uuid.Parse(tokenData["user_id"]); err == nil {
 		token.UserID = userID
 	}
+	// Tokens issued before device sessions have no family
+	if familyID, err := uuid.Parse(tokenData["family_id"]); err == nil {
+		token.FamilyID = familyID
+	}
 	token.TokenHash = tokenData["token_hash"]
+	token.UserAgent = tokenData["user_agent"]
+	token.IPAddress = tokenData["ip_address"]
 
 	if expiresAt, err := time.Parse(time.RFC3339, tokenData["expires_at"]); err == nil {
 		token.ExpiresAt = expiresAt
@@ -521,6 +542,16 @@ func (s *AuthService) getRefreshToken(tokenHash string) (*models.RefreshToken, e
 	if createdAt, err := time.Parse(time.RFC3339, tokenData["created_at"]); err == nil {
 		token.CreatedAt = createdAt
 	}
+	if lastUsedAtStr, ok := tokenData["last_used_at"]; ok && lastUsedAtStr != "" {
+		if lastUsedAt, err := time.Parse(time.RFC3339, lastUsedAtStr); err == nil {
+			token.LastUsedAt = &lastUsedAt
+		}
+	}
+	if rotatedAtStr, ok := tokenData["rotated_at"]; ok && rotatedAtStr != "" {
+		if rotatedAt, err := time.Parse(time.RFC3339, rotatedAtStr); err == nil {
+			token.RotatedAt = &rotatedAt
+		}
+	}
 	if revokedAtStr, ok := tokenData["revoked_at"]; ok && revokedAtStr != "" {
 		if revokedAt, err := time.Parse(time.RFC3339, revokedAtStr); err == nil {
 			token.RevokedAt = &revokedAt
@@ -544,15 +575,18 @@ func (s *AuthService) hashToken(token string) string {
 // entUserToModel converts an Ent User entity to a models.User
 func (s *AuthService) entUserToModel(entUser *ent.User) *models.User {
 	userModel := &models.User{
-		ID:           entUser.ID,
-		Email:        entUser.Email,
-		PasswordHash: entUser.PasswordHash,
-		FullName:     entUser.Name,
-		Picture:      entUser.AvatarURL,
-		Provider:     entUser.Provider,
-		ProviderID:   entUser.GoogleID,
-		CreatedAt:    entUser.CreatedAt,
-		UpdatedAt:    entUser.UpdatedAt,
+		ID:            entUser.ID,
+		Email:         entUser.Email,
+		PasswordHash:  entUser.PasswordHash,
+		FullName:      entUser.Name,
+		Picture:       entUser.AvatarURL,
+		Provider:      entUser.Provider,
+		ProviderID:    entUser.GoogleID,
+		Role:          entUser.Role,
+		Plan:          entUser.Plan,
+		EmailVerified: entUser.EmailVerified,
+		CreatedAt:     entUser.CreatedAt,
+		UpdatedAt:     entUser.UpdatedAt,
 	}
 
 	// Handle optional last_login
@@ -568,15 +602,18 @@ func (s *AuthService) syncUserToRedis(user *models.User) error {
 	// 1. Save user data
 	userKey := fmt.Sprintf("user:id:%s", user.ID.String())
 	userData := map[string]interface{}{
-		"id":            user.ID.String(),
-		"email":         user.Email,
-		"password_hash": user.PasswordHash,
-		"full_name":     user.FullName,
-		"picture":       user.Picture,
-		"provider":      user.Provider,
-		"provider_id":   user.ProviderID,
-		"created_at":    user.CreatedAt.Format(time.RFC3339),
-		"updated_at":    user.UpdatedAt.Format(time.RFC3339),
+		"id":             user.ID.String(),
+		"email":          user.Email,
+		"password_hash":  user.PasswordHash,
+		"full_name":      user.FullName,
+		"picture":        user.Picture,
+		"provider":       user.Provider,
+		"provider_id":    user.ProviderID,
+		"role":           user.Role,
+		"plan":           user.Plan,
+		"email_verified": strconv.FormatBool(user.EmailVerified),
+		"created_at":     user.CreatedAt.Format(time.RFC3339),
+		"updated_at":     user.UpdatedAt.Format(time.RFC3339),
 	}
 
 	if user.LastLoginAt != nil {
@@ -620,6 +657,15 @@ func (s *AuthService) getUserWithFallback(userID uuid.UUID) (*models.User, error
 		user.Picture = userData["picture"]
 		user.Provider = userData["provider"]
 		user.ProviderID = userData["provider_id"]
+		user.Role = userData["role"]
+		if user.Role == "" {
+			user.Role = models.UserRoleUser // Cached before roles existed
+		}
+		user.Plan = userData["plan"]
+		if user.Plan == "" {
+			user.Plan = models.PlanFree // Cached before plans existed
+		}
+		user.EmailVerified = userData["email_verified"] == "true"
 
 		if createdAt, parseErr := time.Parse(time.RFC3339, userData["created_at"]); parseErr == nil {
 			user.CreatedAt = createdAt
@@ -745,15 +791,29 @@ func (s *AuthService) getUser(lookup UserLookup) (*models.User, error) {
 		fmt.Printf("⚠️ Redis miss for provider %s:%s, falling back to PostgreSQL\n",
 			lookup.ByProviderID.Provider, lookup.ByProviderID.ID)
 
-		var entUser *ent.User
-		if lookup.ByProviderID.Provider == "google" {
-			entUser, err = s.client.User.Query().
-				Where(user.GoogleIDEQ(lookup.ByProviderID.ID)).
-				Only(s.ctx)
-		} else {
-			return nil, fmt.Errorf("unsupported provider: %s", lookup.ByProviderID.Provider)
+		userID, err = s.getUserIDByIdentity(lookup.ByProviderID.Provider, lookup.ByProviderID.ID)
+		if err == nil {
+			userModel, getUserErr := s.getUserWithFallback(userID)
+			if getUserErr != nil {
+				return nil, getUserErr
+			}
+			providerKey := fmt.Sprintf("user:provider:%s:%s", lookup.ByProviderID.Provider, lookup.ByProviderID.ID)
+			if syncErr := s.redis.Set(s.ctx, providerKey, userID.String(), 0).Err(); syncErr != nil {
+				fmt.Printf("⚠️ Failed to sync provider mapping to Redis: %v\n", syncErr)
+			}
+			return userModel, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to look up identity: %w", err)
 		}
 
+		// Accounts created with Google before identities existed
+		if lookup.ByProviderID.Provider != "google" {
+			return nil, redis.Nil
+		}
+		entUser, err := s.client.User.Query().
+			Where(user.GoogleIDEQ(lookup.ByProviderID.ID)).
+			Only(s.ctx)
 		if err != nil {
 			if ent.IsNotFound(err) {
 				return nil, redis.Nil // Maintain consistent error type
@@ -778,13 +838,113 @@ func (s *AuthService) getUser(lookup UserLookup) (*models.User, error) {
 
 func (s *AuthService) toUserInfo(user *models.User) *models.UserInfo {
 	return &models.UserInfo{
-		ID:        user.ID,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		Picture:   user.Picture,
-		Provider:  user.Provider,
-		CreatedAt: user.CreatedAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		FullName:      user.FullName,
+		Picture:       user.Picture,
+		Provider:      user.Provider,
+		Role:          user.Role,
+		Plan:          user.Plan,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+// ==================== Role Methods ====================
+
+// GetUserRole returns the user's role from PostgreSQL. The Redis copy is
+// only a cache, so authorization decisions never depend on it.
+func (s *AuthService) GetUserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	entUser, err := s.client.User.Query().
+		Where(user.IDEQ(userID)).
+		Select(user.FieldRole).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+	return entUser.Role, nil
+}
+
+// SetUserRole changes the user's role and updates the cached user
+func (s *AuthService) SetUserRole(ctx context.Context, userID uuid.UUID, role string) error {
+	if role != models.UserRoleUser && role != models.UserRoleAdmin {
+		return ErrInvalidRole
+	}
+
+	err := s.client.User.UpdateOneID(userID).
+		SetRole(role).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	// Only touch an existing cache entry - a partial hash would look like a cached user
+	userKey := fmt.Sprintf("user:id:%s", userID.String())
+	if exists, err := s.redis.Exists(ctx, userKey).Result(); err == nil && exists > 0 {
+		if err := s.redis.HSet(ctx, userKey, "role", role).Err(); err != nil {
+			fmt.Printf("⚠️ Failed to update cached role for user %s: %v\n", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// ==================== Plan Methods ====================
+
+// GetUserPlan returns the user's usage plan. It is read on every chat
+// message, so the cached user is tried before PostgreSQL.
+func (s *AuthService) GetUserPlan(ctx context.Context, userID uuid.UUID) (string, error) {
+	userKey := fmt.Sprintf("user:id:%s", userID.String())
+	if plan, err := s.redis.HGet(ctx, userKey, "plan").Result(); err == nil && plan != "" {
+		return plan, nil
+	}
+
+	entUser, err := s.client.User.Query().
+		Where(user.IDEQ(userID)).
+		Select(user.FieldPlan).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user plan: %w", err)
+	}
+	return entUser.Plan, nil
+}
+
+// SetUserPlan changes the user's usage plan and updates the cached user
+func (s *AuthService) SetUserPlan(ctx context.Context, userID uuid.UUID, plan string) error {
+	if plan != models.PlanFree && plan != models.PlanPro && plan != models.PlanInternal {
+		return ErrInvalidPlan
+	}
+
+	err := s.client.User.UpdateOneID(userID).
+		SetPlan(plan).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update user plan: %w", err)
+	}
+
+	// Only touch an existing cache entry - a partial hash would look like a cached user
+	userKey := fmt.Sprintf("user:id:%s", userID.String())
+	if exists, err := s.redis.Exists(ctx, userKey).Result(); err == nil && exists > 0 {
+		if err := s.redis.HSet(ctx, userKey, "plan", plan).Err(); err != nil {
+			fmt.Printf("⚠️ Failed to update cached plan for user %s: %v\n", userID, err)
+		}
 	}
+
+	return nil
 }
 
 // ==================== Password Management Methods ====================
@@ -945,6 +1105,157 @@ func (s *AuthService) ResetPassword(resetToken, newPassword string) error {
 	return nil
 }
 
+// ==================== Email Verification Methods ====================
+
+// CreateEmailVerificationToken generates a verification token for the
+// user's current email and returns the user to send it to. Earlier tokens
+// stay valid until they expire.
+func (s *AuthService) CreateEmailVerificationToken(userID uuid.UUID) (*models.User, string, error) {
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified {
+		return nil, "", ErrEmailAlreadyVerified
+	}
+
+	verificationToken, err := s.jwtService.GenerateRefreshToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	tokenData := &models.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: s.hashToken(verificationToken),
+		ExpiresAt: time.Now().Add(s.verificationTTL),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.saveEmailVerificationToken(tokenData); err != nil {
+		return nil, "", fmt.Errorf("failed to save verification token: %w", err)
+	}
+
+	return user, verificationToken, nil
+}
+
+// VerifyEmail marks the email of the token's user as verified. Using a
+// token again after it worked is not an error.
+func (s *AuthService) VerifyEmail(verificationToken string) (*models.User, error) {
+	tokenHash := s.hashToken(verificationToken)
+
+	tokenData, err := s.getEmailVerificationToken(tokenHash)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrVerificationInvalid
+		}
+		return nil, fmt.Errorf("failed to get verification token: %w", err)
+	}
+	if time.Now().After(tokenData.ExpiresAt) {
+		return nil, ErrVerificationInvalid
+	}
+
+	user, err := s.getUserByID(tokenData.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The token proves ownership of the address it was sent to only
+	if user.Email != tokenData.Email {
+		return nil, ErrVerificationInvalid
+	}
+
+	if tokenData.UsedAt != nil || user.EmailVerified {
+		return user, nil
+	}
+
+	user.EmailVerified = true
+	user.UpdatedAt = time.Now()
+	if err := s.saveUser(user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if err := s.markEmailVerificationTokenAsUsed(tokenHash); err != nil {
+		fmt.Printf("Warning: failed to mark verification token as used: %v\n", err)
+	}
+
+	fmt.Printf("✅ Email verified for user %s\n", user.ID.String())
+	return user, nil
+}
+
+// IsEmailVerified reports whether the user proved their email. Accounts of
+// OAuth providers count as verified; unknown users don't.
+func (s *AuthService) IsEmailVerified(userID uuid.UUID) bool {
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return false
+	}
+	return user.EmailVerified || user.Provider != "email"
+}
+
+// ==================== Email Verification Token Helpers ====================
+
+func (s *AuthService) saveEmailVerificationToken(token *models.EmailVerificationToken) error {
+	key := fmt.Sprintf("email_verification:%s", token.TokenHash)
+	tokenData := map[string]interface{}{
+		"id":         token.ID.String(),
+		"user_id":    token.UserID.String(),
+		"email":      token.Email,
+		"token_hash": token.TokenHash,
+		"expires_at": token.ExpiresAt.Format(time.RFC3339),
+		"created_at": token.CreatedAt.Format(time.RFC3339),
+	}
+
+	ttl := time.Until(token.ExpiresAt)
+	if err := s.redis.HSet(s.ctx, key, tokenData).Err(); err != nil {
+		return err
+	}
+	return s.redis.Expire(s.ctx, key, ttl).Err()
+}
+
+func (s *AuthService) getEmailVerificationToken(tokenHash string) (*models.EmailVerificationToken, error) {
+	key := fmt.Sprintf("email_verification:%s", tokenHash)
+	tokenData, err := s.redis.HGetAll(s.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokenData) == 0 {
+		return nil, redis.Nil
+	}
+
+	token := &models.EmailVerificationToken{}
+	if id, err := uuid.Parse(tokenData["id"]); err == nil {
+		token.ID = id
+	}
+	if userID, err := uuid.Parse(tokenData["user_id"]); err == nil {
+		token.UserID = userID
+	}
+	token.Email = tokenData["email"]
+	token.TokenHash = tokenData["token_hash"]
+
+	if expiresAt, err := time.Parse(time.RFC3339, tokenData["expires_at"]); err == nil {
+		token.ExpiresAt = expiresAt
+	}
+	if createdAt, err := time.Parse(time.RFC3339, tokenData["created_at"]); err == nil {
+		token.CreatedAt = createdAt
+	}
+	if usedAtStr, ok := tokenData["used_at"]; ok && usedAtStr != "" {
+		if usedAt, err := time.Parse(time.RFC3339, usedAtStr); err == nil {
+			token.UsedAt = &usedAt
+		}
+	}
+
+	return token, nil
+}
+
+func (s *AuthService) markEmailVerificationTokenAsUsed(tokenHash string) error {
+	key := fmt.Sprintf("email_verification:%s", tokenHash)
+	now := time.Now().Format(time.RFC3339)
+	return s.redis.HSet(s.ctx, key, "used_at", now).Err()
+}
+
 // ==================== Password Reset Token Helpers ====================
 
 func (s *AuthService) savePasswordResetToken(token *models.PasswordResetToken) error {
@@ -1006,8 +1317,21 @@ func (s *AuthService) markPasswordResetTokenAsUsed(tokenHash string) error {
 }
 
 func (s *AuthService) revokeAllUserRefreshTokens(userID uuid.UUID) error {
-	// In Redis, we need to find all refresh tokens for this user
-	// This is a simplification - in production you might want to maintain a user->tokens index
+	// Device sessions are indexed per user
+	indexKey := fmt.Sprintf("user_refresh_families:%s", userID.String())
+	members, err := s.redis.SMembers(s.ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if familyID, err := uuid.Parse(member); err == nil {
+			if err := s.revokeDeviceSession(userID, familyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Tokens issued before device sessions are only found by scanning
 	pattern := "refresh_token:*"
 	var cursor uint64
 	for {
@@ -1036,3 +1360,83 @@ func (s *AuthService) revokeAllUserRefreshTokens(userID uuid.UUID) error {
 
 	return nil
 }
+
+// ==================== Account Data Methods ====================
+
+// VerifyPassword checks the password of an email account. OAuth accounts
+// have none and return ErrPasswordNotSet.
+func (s *AuthService) VerifyPassword(user *models.User, password string) error {
+	if user.Provider != "email" || user.PasswordHash == "" {
+		return ErrPasswordNotSet
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// ListUserRefreshTokens returns the refresh tokens issued to a user,
+// including revoked ones that haven't expired yet
+func (s *AuthService) ListUserRefreshTokens(userID uuid.UUID) ([]models.RefreshToken, error) {
+	tokens := []models.RefreshToken{}
+	err := s.scanUserKeys("refresh_token:*", userID, func(key string) {
+		token, err := s.getRefreshToken(key[len("refresh_token:"):])
+		if err == nil {
+			tokens = append(tokens, *token)
+		}
+	})
+	return tokens, err
+}
+
+// deleteUserFromRedis removes the cached user and its lookup keys, the
+// user's password reset and email verification tokens, its 2FA state and
+// device sessions. Refresh tokens are revoked separately and expire on
+// their own, like the used TOTP steps.
+func (s *AuthService) deleteUserFromRedis(user *models.User) error {
+	keys := []string{
+		fmt.Sprintf("user:id:%s", user.ID.String()),
+		fmt.Sprintf("user:email:%s", user.Email),
+		fmt.Sprintf("2fa_enrollment:%s", user.ID.String()),
+	}
+	if user.ProviderID != "" {
+		keys = append(keys, fmt.Sprintf("user:provider:%s:%s", user.Provider, user.ProviderID))
+	}
+
+	keys = append(keys, fmt.Sprintf("user_refresh_families:%s", user.ID.String()))
+
+	// Revoked device sessions already left the per-user index
+	for _, pattern := range []string{"password_reset:*", "email_verification:*", "2fa_challenge:*", "refresh_family:*"} {
+		err := s.scanUserKeys(pattern, user.ID, func(key string) {
+			keys = append(keys, key)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.redis.Del(s.ctx, keys...).Err()
+}
+
+// scanUserKeys calls fn for every hash matching pattern whose user_id is
+// the user's
+func (s *AuthService) scanUserKeys(pattern string, userID uuid.UUID, fn func(key string)) error {
+	var cursor uint64
+	for {
+		keys, nextCursor, err := s.redis.Scan(s.ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			owner, err := s.redis.HGet(s.ctx, key, "user_id").Result()
+			if err == nil && owner == userID.String() {
+				fn(key)
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -31,12 +32,58 @@ func NewCacheService(redisClient *redis.Client, cfg *config.Config, embedding *E
 	}
 }
 
-func (c *CacheService) GetSearchResults(cacheKey string) ([]models.ProductCard, error) {
-	data, err := c.redis.Get(c.ctx, cacheKey).Bytes()
+// SearchCacheQuery identifies cached search results: exactly by Key, and
+// by the similarity of Query within the same country, search type and
+// price range
+type SearchCacheQuery struct {
+	Query      string
+	Country    string
+	SearchType string
+	MinPrice   *float64
+	MaxPrice   *float64
+}
+
+// Key returns the Redis key of the cached results
+func (q *SearchCacheQuery) Key() string {
+	key := fmt.Sprintf("search:%s:%s:%s", q.Country, q.SearchType, q.Query)
+	if q.MinPrice != nil {
+		key += fmt.Sprintf(":min%.0f", *q.MinPrice)
+	}
+	if q.MaxPrice != nil {
+		key += fmt.Sprintf(":max%.0f", *q.MaxPrice)
+	}
+	return key
+}
+
+// Scope returns the searches whose results can answer this query
+func (q *SearchCacheQuery) Scope() QueryCacheScope {
+	var bounds []string
+	if q.MinPrice != nil {
+		bounds = append(bounds, fmt.Sprintf("min%.0f", *q.MinPrice))
+	}
+	if q.MaxPrice != nil {
+		bounds = append(bounds, fmt.Sprintf("max%.0f", *q.MaxPrice))
+	}
+	priceRange := "any"
+	if len(bounds) > 0 {
+		priceRange = strings.Join(bounds, "_")
+	}
+
+	return QueryCacheScope{
+		Country:    q.Country,
+		SearchType: q.SearchType,
+		PriceRange: priceRange,
+	}
+}
+
+// GetSearchResults returns the cached results of the query, or of a
+// similar query in the same scope
+func (c *CacheService) GetSearchResults(ctx context.Context, query *SearchCacheQuery) ([]models.ProductCard, error) {
+	data, err := c.redis.Get(ctx, query.Key()).Bytes()
 	if err == redis.Nil {
-		similarKey := c.embedding.FindSimilarCachedQuery(cacheKey, 0.92)
+		similarKey := c.embedding.FindSimilarCachedQuery(ctx, query.Query, query.Scope(), float32(c.config.CacheSemanticThreshold))
 		if similarKey != "" {
-			data, err = c.redis.Get(c.ctx, similarKey).Bytes()
+			data, err = c.redis.Get(ctx, similarKey).Bytes()
 			if err == nil {
 				var cards []models.ProductCard
 				if err := json.Unmarshal(data, &cards); err == nil {
@@ -58,18 +105,26 @@ func (c *CacheService) GetSearchResults(cacheKey string) ([]models.ProductCard,
 	return cards, nil
 }
 
-func (c *CacheService) SetSearchResults(cacheKey string, cards []models.ProductCard, ttl time.Duration) error {
-	dedupedCards := c.deduplicateProducts(cards)
+// SetSearchResults caches the results of the query and indexes the query
+// for semantic lookups for as long as the results are cached
+func (c *CacheService) SetSearchResults(ctx context.Context, query *SearchCacheQuery, cards []models.ProductCard, ttl time.Duration) error {
+	dedupedCards := c.deduplicateProducts(ctx, cards)
 
 	data, err := json.Marshal(dedupedCards)
 	if err != nil {
 		return fmt.Errorf("marshal error: %w", err)
 	}
 
-	return c.redis.Set(c.ctx, cacheKey, data, ttl).Err()
+	cacheKey := query.Key()
+	if err := c.redis.Set(ctx, cacheKey, data, ttl).Err(); err != nil {
+		return err
+	}
+
+	c.embedding.IndexCachedQuery(ctx, query.Query, query.Scope(), cacheKey, ttl)
+	return nil
 }
 
-func (c *CacheService) deduplicateProducts(cards []models.ProductCard) []models.ProductCard {
+func (c *CacheService) deduplicateProducts(ctx context.Context, cards []models.ProductCard) []models.ProductCard {
 	if len(cards) <= 1 {
 		return cards
 	}
@@ -84,7 +139,7 @@ func (c *CacheService) deduplicateProducts(cards []models.ProductCard) []models.
 	// Pre-compute embeddings for all products (O(n) API calls)
 	cardsWithEmbeddings := make([]cardWithEmbedding, 0, len(cards))
 	for _, card := range cards {
-		emb := c.embedding.GetQueryEmbedding(card.Name)
+		emb := c.embedding.GetQueryEmbedding(ctx, card.Name)
 		if emb != nil {
 			cardsWithEmbeddings = append(cardsWithEmbeddings, cardWithEmbedding{
 				card:      card,
@@ -182,58 +237,41 @@ func (c *CacheService) SetGeminiResponse(cacheKey string, response *models.Gemin
 }
 
 // ═══════════════════════════════════════════════════════════
-// ANONYMOUS BROWSER TRACKING
-// Track anonymous search usage across sessions by browser ID
+// STREAMING RESPONSES
+// Snapshots of assistant messages in flight, for resume after reconnect
 // ═══════════════════════════════════════════════════════════
 
-// GetAnonymousSearchCount returns the number of searches used by a browser ID
-func (c *CacheService) GetAnonymousSearchCount(browserID string) (int, error) {
-	if browserID == "" {
-		return 0, nil
-	}
+// streamStateTTL outlives the 60s processing timeout with room for reconnects
+const streamStateTTL = 10 * time.Minute
+
+// GetStreamState returns the snapshot of a streamed message, or nil if unknown
+func (c *CacheService) GetStreamState(messageID string) (*models.StreamState, error) {
+	cacheKey := fmt.Sprintf("stream:%s", messageID)
 
-	cacheKey := fmt.Sprintf("anonymous_searches:%s", browserID)
-	count, err := c.redis.Get(c.ctx, cacheKey).Int()
+	data, err := c.redis.Get(c.ctx, cacheKey).Bytes()
 	if err == redis.Nil {
-		return 0, nil // No searches yet
+		return nil, nil
 	}
 	if err != nil {
-		return 0, fmt.Errorf("redis error: %w", err)
-	}
-
-	return count, nil
-}
-
-// IncrementAnonymousSearchCount increments the search count for a browser ID
-// TTL: 24 hours (searches reset after a day)
-func (c *CacheService) IncrementAnonymousSearchCount(browserID string) error {
-	if browserID == "" {
-		return nil
-	}
-
-	cacheKey := fmt.Sprintf("anonymous_searches:%s", browserID)
-
-	// Increment counter
-	if err := c.redis.Incr(c.ctx, cacheKey).Err(); err != nil {
-		return fmt.Errorf("redis incr error: %w", err)
+		return nil, fmt.Errorf("redis error: %w", err)
 	}
 
-	// Set expiration (24 hours)
-	ttl := 24 * time.Hour
-	if err := c.redis.Expire(c.ctx, cacheKey, ttl).Err(); err != nil {
-		return fmt.Errorf("redis expire error: %w", err)
+	var state models.StreamState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
 	}
 
-	return nil
+	return &state, nil
 }
 
-// ResetAnonymousSearchCount resets the search count for a browser ID
-// This is called when a user authenticates to give them unlimited searches
-func (c *CacheService) ResetAnonymousSearchCount(browserID string) error {
-	if browserID == "" {
-		return nil
+// SetStreamState stores the snapshot of a streamed message
+func (c *CacheService) SetStreamState(state *models.StreamState) error {
+	cacheKey := fmt.Sprintf("stream:%s", state.MessageID)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
 	}
 
-	cacheKey := fmt.Sprintf("anonymous_searches:%s", browserID)
-	return c.redis.Del(c.ctx, cacheKey).Err()
+	return c.redis.Set(c.ctx, cacheKey, data, streamStateTTL).Err()
 }
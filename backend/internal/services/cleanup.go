@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -11,27 +12,26 @@ import (
 	"mylittleprice/ent/message"
 )
 
-// CleanupService handles periodic cleanup of expired data
+// CleanupService deletes expired chat data. It runs as the session_cleanup
+// job of the scheduler.
 type CleanupService struct {
 	client *ent.Client
-	ctx    context.Context
 }
 
 // NewCleanupService creates a new CleanupService
 func NewCleanupService(client *ent.Client) *CleanupService {
 	return &CleanupService{
 		client: client,
-		ctx:    context.Background(),
 	}
 }
 
 // CleanupExpiredSessions removes expired chat sessions from the database
 // Returns the number of sessions deleted
-func (s *CleanupService) CleanupExpiredSessions() (int, error) {
+func (s *CleanupService) CleanupExpiredSessions(ctx context.Context) (int, error) {
 	// Delete sessions that have expired
 	deleted, err := s.client.ChatSession.Delete().
 		Where(chatsession.ExpiresAtLT(time.Now())).
-		Exec(s.ctx)
+		Exec(ctx)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup expired sessions: %w", err)
@@ -46,11 +46,11 @@ func (s *CleanupService) CleanupExpiredSessions() (int, error) {
 
 // CleanupOrphanedMessages removes messages that belong to deleted sessions
 // Returns the number of messages deleted
-func (s *CleanupService) CleanupOrphanedMessages() (int, error) {
+func (s *CleanupService) CleanupOrphanedMessages(ctx context.Context) (int, error) {
 	// Get all session IDs
 	sessions, err := s.client.ChatSession.Query().
 		Select(chatsession.FieldID).
-		All(s.ctx)
+		All(ctx)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to query sessions: %w", err)
@@ -67,7 +67,7 @@ func (s *CleanupService) CleanupOrphanedMessages() (int, error) {
 	// Consider using a more efficient approach for production (e.g., JOIN query)
 	allMessages, err := s.client.Message.Query().
 		Select(message.FieldID, message.FieldSessionID).
-		All(s.ctx)
+		All(ctx)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to query messages: %w", err)
@@ -77,7 +77,7 @@ func (s *CleanupService) CleanupOrphanedMessages() (int, error) {
 	for _, msg := range allMessages {
 		if !validSessionIDs[msg.SessionID.String()] {
 			// This message is orphaned
-			if err := s.client.Message.DeleteOneID(msg.ID).Exec(s.ctx); err != nil {
+			if err := s.client.Message.DeleteOneID(msg.ID).Exec(ctx); err != nil {
 				log.Printf("⚠️ Failed to delete orphaned message %s: %v", msg.ID.String(), err)
 			} else {
 				orphanedCount++
@@ -94,12 +94,12 @@ func (s *CleanupService) CleanupOrphanedMessages() (int, error) {
 
 // CleanupOldMessages removes messages older than a specified duration
 // This helps manage database size for high-volume applications
-func (s *CleanupService) CleanupOldMessages(olderThan time.Duration) (int, error) {
+func (s *CleanupService) CleanupOldMessages(ctx context.Context, olderThan time.Duration) (int, error) {
 	cutoffTime := time.Now().Add(-olderThan)
 
 	deleted, err := s.client.Message.Delete().
 		Where(message.CreatedAtLT(cutoffTime)).
-		Exec(s.ctx)
+		Exec(ctx)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup old messages: %w", err)
@@ -112,53 +112,37 @@ func (s *CleanupService) CleanupOldMessages(olderThan time.Duration) (int, error
 	return deleted, nil
 }
 
-// RunFullCleanup runs all cleanup operations
-// This should be called periodically (e.g., daily via cron job)
-func (s *CleanupService) RunFullCleanup() error {
+// RunFullCleanup runs all cleanup operations and returns the number of
+// rows deleted. A failing step doesn't stop the others; their errors are
+// returned together.
+func (s *CleanupService) RunFullCleanup(ctx context.Context) (int, error) {
 	log.Println("🧹 Starting full cleanup...")
+	var errs []error
 
 	// 1. Cleanup expired sessions
-	sessionsDeleted, err := s.CleanupExpiredSessions()
+	sessionsDeleted, err := s.CleanupExpiredSessions(ctx)
 	if err != nil {
 		log.Printf("⚠️ Error during session cleanup: %v", err)
+		errs = append(errs, err)
 	}
 
 	// 2. Cleanup orphaned messages (messages without sessions)
-	messagesDeleted, err := s.CleanupOrphanedMessages()
+	messagesDeleted, err := s.CleanupOrphanedMessages(ctx)
 	if err != nil {
 		log.Printf("⚠️ Error during orphaned message cleanup: %v", err)
+		errs = append(errs, err)
 	}
 
 	// 3. Cleanup very old messages (older than 90 days)
 	// This is optional and can be configured
-	oldMessagesDeleted, err := s.CleanupOldMessages(90 * 24 * time.Hour)
+	oldMessagesDeleted, err := s.CleanupOldMessages(ctx, 90*24*time.Hour)
 	if err != nil {
 		log.Printf("⚠️ Error during old message cleanup: %v", err)
+		errs = append(errs, err)
 	}
 
 	log.Printf("🧹 Cleanup completed: %d sessions, %d orphaned messages, %d old messages",
 		sessionsDeleted, messagesDeleted, oldMessagesDeleted)
 
-	return nil
-}
-
-// StartPeriodicCleanup starts a background goroutine that runs cleanup periodically
-// interval: how often to run cleanup (e.g., 24 * time.Hour for daily cleanup)
-func (s *CleanupService) StartPeriodicCleanup(interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	go func() {
-		// Run cleanup immediately on start
-		if err := s.RunFullCleanup(); err != nil {
-			log.Printf("❌ Initial cleanup failed: %v", err)
-		}
-
-		// Then run periodically
-		for range ticker.C {
-			if err := s.RunFullCleanup(); err != nil {
-				log.Printf("❌ Periodic cleanup failed: %v", err)
-			}
-		}
-	}()
-
-	log.Printf("🕐 Periodic cleanup started (interval: %v)", interval)
+	return sessionsDeleted + messagesDeleted + oldMessagesDeleted, errors.Join(errs...)
 }
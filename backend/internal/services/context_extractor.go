@@ -8,30 +8,26 @@ import (
 	"strings"
 	"time"
 
-	"google.golang.org/genai"
-
 	"mylittleprice/internal/models"
 )
 
 // ContextExtractorService extracts structured information from conversation history
 // Uses AI to intelligently identify user preferences, requirements, and context
 type ContextExtractorService struct {
-	client    *genai.Client
-	ctx       context.Context
-	modelName string
+	llm *LLMRegistry
 }
 
-// NewContextExtractorService creates a new context extractor
-func NewContextExtractorService(client *genai.Client, modelName string) *ContextExtractorService {
+// NewContextExtractorService creates a new context extractor.
+// Calls go to the provider routed for the extraction task.
+func NewContextExtractorService(llm *LLMRegistry) *ContextExtractorService {
 	return &ContextExtractorService{
-		client:    client,
-		ctx:       context.Background(),
-		modelName: modelName,
+		llm: llm,
 	}
 }
 
 // ExtractUserPreferences analyzes conversation and extracts structured preferences
 func (c *ContextExtractorService) ExtractUserPreferences(
+	ctx context.Context,
 	messages []models.CycleMessage,
 	currentPreferences *models.ConversationPreferences,
 	currency string,
@@ -75,37 +71,23 @@ Rules:
 - Return ONLY valid JSON, no explanations`, conversationText, currentPrefJSON, currency, currency)
 
 	// Use fast model for extraction (token efficiency)
-	temp := float32(0.2) // Low temperature for more deterministic extraction
-	resp, err := c.client.Models.GenerateContent(
-		c.ctx,
-		c.modelName, // Fast model for extraction
-		genai.Text(prompt),
-		&genai.GenerateContentConfig{
-			Temperature:      &temp,
-			ResponseMIMEType: "application/json",
-			MaxOutputTokens:  500, // Small response
-		},
-	)
+	resp, err := c.generate(ctx, &LLMRequest{
+		Prompt:          prompt,
+		Temperature:     0.2, // Low temperature for more deterministic extraction
+		JSON:            true,
+		MaxOutputTokens: 500, // Small response
+	})
 
 	if err != nil {
 		fmt.Printf("⚠️ Failed to extract preferences: %v\n", err)
 		return currentPreferences, err
 	}
 
-	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+	responseText := cleanJSONText(resp.Text)
+	if responseText == "" {
 		return currentPreferences, fmt.Errorf("empty response from preference extraction")
 	}
 
-	// Extract text from response
-	responseText := ""
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if part.Text != "" {
-			responseText += part.Text
-		}
-	}
-
-	responseText = strings.TrimSpace(responseText)
-
 	// Parse JSON response
 	var extracted models.ConversationPreferences
 	if err := json.Unmarshal([]byte(responseText), &extracted); err != nil {
@@ -121,6 +103,7 @@ Rules:
 
 // GenerateConversationSummary creates a compact summary of the conversation
 func (c *ContextExtractorService) GenerateConversationSummary(
+	ctx context.Context,
 	messages []models.CycleMessage,
 	previousSummary string,
 	language string,
@@ -157,35 +140,18 @@ Recent conversation:
 
 Return a clear, concise summary in %s language. Maximum 3 sentences.`, previousSummaryText, conversationText, language)
 
-	temp := float32(0.3) // Low temperature for consistent summaries
-	resp, err := c.client.Models.GenerateContent(
-		c.ctx,
-		c.modelName,
-		genai.Text(prompt),
-		&genai.GenerateContentConfig{
-			Temperature:     &temp,
-			MaxOutputTokens: 200, // Short summary
-		},
-	)
+	resp, err := c.generate(ctx, &LLMRequest{
+		Prompt:          prompt,
+		Temperature:     0.3, // Low temperature for consistent summaries
+		MaxOutputTokens: 200, // Short summary
+	})
 
 	if err != nil {
 		fmt.Printf("⚠️ Failed to generate summary: %v\n", err)
 		return previousSummary, err
 	}
 
-	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
-		return previousSummary, fmt.Errorf("empty response from summary generation")
-	}
-
-	// Extract summary text
-	summary := ""
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if part.Text != "" {
-			summary += part.Text
-		}
-	}
-
-	summary = strings.TrimSpace(summary)
+	summary := strings.TrimSpace(resp.Text)
 
 	if summary == "" {
 		return previousSummary, nil
@@ -239,6 +205,7 @@ func (c *ContextExtractorService) ExtractExclusions(
 
 // UpdateConversationContext updates the full conversation context
 func (c *ContextExtractorService) UpdateConversationContext(
+	ctx context.Context,
 	session *models.ChatSession,
 	newMessages []models.CycleMessage,
 ) error {
@@ -253,26 +220,28 @@ func (c *ContextExtractorService) UpdateConversationContext(
 		}
 	}
 
-	ctx := session.ConversationContext
+	convCtx := session.ConversationContext
 
 	// Extract preferences
 	preferences, err := c.ExtractUserPreferences(
+		ctx,
 		newMessages,
-		&ctx.Preferences,
+		&convCtx.Preferences,
 		session.Currency,
 	)
 	if err == nil {
-		ctx.Preferences = *preferences
+		convCtx.Preferences = *preferences
 	}
 
 	// Generate summary
 	summary, err := c.GenerateConversationSummary(
+		ctx,
 		newMessages,
-		ctx.Summary,
+		convCtx.Summary,
 		session.LanguageCode,
 	)
 	if err == nil {
-		ctx.Summary = summary
+		convCtx.Summary = summary
 	}
 
 	// Extract exclusions
@@ -280,16 +249,16 @@ func (c *ContextExtractorService) UpdateConversationContext(
 	if len(exclusions) > 0 {
 		// Merge with existing exclusions
 		for _, excl := range exclusions {
-			if !slices.Contains(ctx.Exclusions, excl) {
-				ctx.Exclusions = append(ctx.Exclusions, excl)
+			if !slices.Contains(convCtx.Exclusions, excl) {
+				convCtx.Exclusions = append(convCtx.Exclusions, excl)
 			}
 		}
 	}
 
-	ctx.UpdatedAt = time.Now()
+	convCtx.UpdatedAt = time.Now()
 
 	fmt.Printf("🧠 Context updated: summary_len=%d, brands=%d, features=%d, exclusions=%d\n",
-		len(ctx.Summary), len(ctx.Preferences.Brands), len(ctx.Preferences.Features), len(ctx.Exclusions))
+		len(convCtx.Summary), len(convCtx.Preferences.Brands), len(convCtx.Preferences.Features), len(convCtx.Exclusions))
 
 	return nil
 }
@@ -323,6 +292,10 @@ func (c *ContextExtractorService) UpdateLastSearch(
 
 // Helper functions
 
+func (c *ContextExtractorService) generate(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
+	return c.llm.generate(ctx, LLMTaskExtraction, req)
+}
+
 func (c *ContextExtractorService) buildConversationText(messages []models.CycleMessage, maxMessages int) string {
 	var sb strings.Builder
 
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -38,6 +39,7 @@ func NewContextOptimizerService(embedding *EmbeddingService) *ContextOptimizerSe
 
 // DecideContextDepth analyzes the user message and determines optimal context depth
 func (c *ContextOptimizerService) DecideContextDepth(
+	ctx context.Context,
 	userMessage string,
 	session *models.ChatSession,
 ) ContextDepth {
@@ -57,7 +59,7 @@ func (c *ContextOptimizerService) DecideContextDepth(
 	}
 
 	// 3. New category or topic change - FULL context
-	if c.isNewCategory(userMessage, session) {
+	if c.isNewCategory(ctx, userMessage, session) {
 		fmt.Printf("🎯 Context depth: FULL (new category detected)\n")
 		return ContextDepthFull
 	}
@@ -140,7 +142,7 @@ func (c *ContextOptimizerService) isShortQuestion(msg string) bool {
 }
 
 // isNewCategory checks if user is asking about a different product category
-func (c *ContextOptimizerService) isNewCategory(msg string, session *models.ChatSession) bool {
+func (c *ContextOptimizerService) isNewCategory(ctx context.Context, msg string, session *models.ChatSession) bool {
 	// If no category set yet, it's new
 	if session.SearchState.Category == "" {
 		return true
@@ -149,7 +151,7 @@ func (c *ContextOptimizerService) isNewCategory(msg string, session *models.Chat
 	// Detect category using embedding service
 	detectedCategory := ""
 	if c.embedding != nil {
-		detectedCategory = c.embedding.DetectCategory(msg)
+		detectedCategory = c.embedding.DetectCategory(ctx, msg)
 	}
 
 	// If detected category differs significantly from current, it's new
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/costrecord"
+	"mylittleprice/ent/predicate"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/metrics"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/utils"
+)
+
+const (
+	costRecordBuffer     = 1000            // Records waiting to be written; more are dropped
+	costRecordBatchSize  = 100             // Records written in one insert
+	costRecordFlushEvery = 2 * time.Second // Longest a record waits for its batch
+	costCharsPerToken    = 4               // Estimate for embeddings, whose responses carry no token count
+)
+
+var ErrInvalidCostGroup = errors.New("invalid cost grouping")
+
+// CostService prices paid LLM and SerpAPI calls, counts them in Prometheus
+// and stores them in PostgreSQL with the request, session and user in the
+// call's context. Records are written in batches in the background so
+// calls never wait for the database. A nil *CostService records nothing.
+type CostService struct {
+	client  *ent.Client
+	config  *config.Config
+	records chan models.CostRecord
+	done    chan struct{}
+	mu      sync.RWMutex // Guards closed against Record racing Close
+	closed  bool
+
+	unpriced sync.Map // Models already warned about
+}
+
+func NewCostService(client *ent.Client, cfg *config.Config) *CostService {
+	s := &CostService{
+		client:  client,
+		config:  cfg,
+		records: make(chan models.CostRecord, costRecordBuffer),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// RecordLLMCall records a finished generation or embedding call
+func (s *CostService) RecordLLMCall(ctx context.Context, provider, model, purpose string, usage LLMUsage, grounded bool) {
+	if s == nil {
+		return
+	}
+
+	cost := s.llmCost(model, usage.InputTokens, usage.OutputTokens)
+	if grounded {
+		cost += s.config.CostGroundingPrice
+	}
+
+	s.record(ctx, models.CostRecord{
+		Provider:     provider,
+		Model:        model,
+		Purpose:      purpose,
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		Grounded:     grounded,
+		CostUSD:      cost,
+	})
+}
+
+// RecordSearchCall records a successful SerpAPI call of an engine
+func (s *CostService) RecordSearchCall(ctx context.Context, engine, purpose string) {
+	if s == nil {
+		return
+	}
+
+	s.record(ctx, models.CostRecord{
+		Provider: SerpAPIProviderName,
+		Model:    engine,
+		Purpose:  purpose,
+		CostUSD:  s.config.CostSerpAPIPrice,
+	})
+}
+
+// estimateTokens approximates the token count of a text
+func estimateTokens(text string) int {
+	return (len(text) + costCharsPerToken - 1) / costCharsPerToken
+}
+
+// llmCost prices tokens with the price of the model, or of the longest
+// configured name the model starts with
+func (s *CostService) llmCost(model string, inputTokens, outputTokens int) float64 {
+	price, ok := s.config.CostModelPrices[model]
+	if !ok {
+		longest := 0
+		for name, p := range s.config.CostModelPrices {
+			if len(name) > longest && strings.HasPrefix(model, name) {
+				price, ok, longest = p, true, len(name)
+			}
+		}
+	}
+	if !ok {
+		if _, warned := s.unpriced.LoadOrStore(model, true); !warned {
+			fmt.Printf("⚠️ No price configured for model %s, its calls are recorded at $0 (set COST_MODEL_PRICES)\n", model)
+		}
+		return 0
+	}
+
+	return (float64(inputTokens)*price.Input + float64(outputTokens)*price.Output) / 1e6
+}
+
+// record attributes a call to the request in ctx, counts it and queues it
+// for writing
+func (s *CostService) record(ctx context.Context, rec models.CostRecord) {
+	rec.ID = uuid.New()
+	rec.RequestID = utils.RequestIDFromContext(ctx)
+	rec.SessionID = utils.SessionIDFromContext(ctx)
+	if userID, err := uuid.Parse(utils.UserIDFromContext(ctx)); err == nil {
+		rec.UserID = &userID
+	}
+	rec.CreatedAt = time.Now()
+
+	metrics.RecordPaidCall(rec.Provider, rec.Model, rec.Purpose, rec.Grounded, rec.InputTokens, rec.OutputTokens, rec.CostUSD)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.records <- rec:
+	default:
+		fmt.Printf("⚠️ Cost record buffer full, dropping %s %s record\n", rec.Purpose, rec.Model)
+	}
+}
+
+// run writes queued records in batches until Close
+func (s *CostService) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(costRecordFlushEvery)
+	defer ticker.Stop()
+
+	batch := make([]models.CostRecord, 0, costRecordBatchSize)
+	for {
+		select {
+		case rec, ok := <-s.records:
+			if !ok {
+				s.write(batch)
+				return
+			}
+			batch = append(batch, rec)
+			if len(batch) >= costRecordBatchSize {
+				s.write(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.write(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+func (s *CostService) write(batch []models.CostRecord) {
+	if len(batch) == 0 {
+		return
+	}
+
+	builders := make([]*ent.CostRecordCreate, len(batch))
+	for i, rec := range batch {
+		builders[i] = s.client.CostRecord.Create().
+			SetID(rec.ID).
+			SetRequestID(rec.RequestID).
+			SetSessionID(rec.SessionID).
+			SetNillableUserID(rec.UserID).
+			SetProvider(rec.Provider).
+			SetModel(rec.Model).
+			SetPurpose(rec.Purpose).
+			SetInputTokens(rec.InputTokens).
+			SetOutputTokens(rec.OutputTokens).
+			SetGrounded(rec.Grounded).
+			SetCostUsd(rec.CostUSD).
+			SetCreatedAt(rec.CreatedAt)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.client.CostRecord.CreateBulk(builders...).Exec(ctx); err != nil {
+		fmt.Printf("❌ Failed to write %d cost records: %v\n", len(batch), err)
+	}
+}
+
+// Close writes the queued records and stops the writer
+func (s *CostService) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.records)
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// List returns records newest first with the total cost of all matches
+func (s *CostService) List(ctx context.Context, filter *models.CostFilter) (*models.CostRecordsResponse, error) {
+	q := s.client.CostRecord.Query().Where(costPredicates(filter)...)
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count cost records: %w", err)
+	}
+
+	var sums []struct {
+		Sum sql.NullFloat64 `json:"sum"`
+	}
+	if err := q.Clone().Aggregate(ent.Sum(costrecord.FieldCostUsd)).Scan(ctx, &sums); err != nil {
+		return nil, fmt.Errorf("failed to sum cost records: %w", err)
+	}
+
+	limit, offset := pageBounds(filter.Limit, filter.Offset)
+	rows, err := q.
+		Order(ent.Desc(costrecord.FieldCreatedAt)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cost records: %w", err)
+	}
+
+	items := make([]models.CostRecord, len(rows))
+	for i, row := range rows {
+		items[i] = toCostRecordModel(row)
+	}
+
+	response := &models.CostRecordsResponse{
+		Items: items,
+		Total: total,
+	}
+	if len(sums) > 0 {
+		response.TotalCostUSD = sums[0].Sum.Float64
+	}
+	return response, nil
+}
+
+func toCostRecordModel(row *ent.CostRecord) models.CostRecord {
+	return models.CostRecord{
+		ID:           row.ID,
+		RequestID:    row.RequestID,
+		SessionID:    row.SessionID,
+		UserID:       row.UserID,
+		Provider:     row.Provider,
+		Model:        row.Model,
+		Purpose:      row.Purpose,
+		InputTokens:  row.InputTokens,
+		OutputTokens: row.OutputTokens,
+		Grounded:     row.Grounded,
+		CostUSD:      row.CostUsd,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+// costGroupRow is one row of a grouped summary query. Only the column of
+// the grouping is set.
+type costGroupRow struct {
+	UserID       sql.NullString  `json:"user_id"`
+	SessionID    sql.NullString  `json:"session_id"`
+	Model        sql.NullString  `json:"model"`
+	Purpose      sql.NullString  `json:"purpose"`
+	Calls        int             `json:"calls"`
+	InputTokens  sql.NullInt64   `json:"input_tokens"`
+	OutputTokens sql.NullInt64   `json:"output_tokens"`
+	Grounded     int             `json:"grounded"`
+	CostUSD      sql.NullFloat64 `json:"cost_usd"`
+}
+
+// Summary totals the matching records per user, session, model or purpose,
+// most expensive first. filter.Limit caps the number of groups.
+func (s *CostService) Summary(ctx context.Context, groupBy string, filter *models.CostFilter) (*models.CostSummaryResponse, error) {
+	groupField := map[string]string{
+		models.CostGroupByUser:    costrecord.FieldUserID,
+		models.CostGroupBySession: costrecord.FieldSessionID,
+		models.CostGroupByModel:   costrecord.FieldModel,
+		models.CostGroupByPurpose: costrecord.FieldPurpose,
+	}[groupBy]
+	if groupField == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCostGroup, groupBy)
+	}
+
+	var groups []costGroupRow
+	err := s.client.CostRecord.Query().
+		Where(costPredicates(filter)...).
+		GroupBy(groupField).
+		Aggregate(
+			ent.As(ent.Count(), "calls"),
+			ent.As(ent.Sum(costrecord.FieldInputTokens), "input_tokens"),
+			ent.As(ent.Sum(costrecord.FieldOutputTokens), "output_tokens"),
+			func(sel *entsql.Selector) string {
+				return entsql.As(fmt.Sprintf("COUNT(*) FILTER (WHERE %s)", sel.C(costrecord.FieldGrounded)), "grounded")
+			},
+			ent.As(ent.Sum(costrecord.FieldCostUsd), "cost_usd"),
+		).
+		Scan(ctx, &groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize cost records: %w", err)
+	}
+
+	response := &models.CostSummaryResponse{
+		GroupBy: groupBy,
+		Rows:    make([]models.CostSummaryRow, len(groups)),
+	}
+	for i, group := range groups {
+		key := group.UserID
+		switch groupBy {
+		case models.CostGroupBySession:
+			key = group.SessionID
+		case models.CostGroupByModel:
+			key = group.Model
+		case models.CostGroupByPurpose:
+			key = group.Purpose
+		}
+
+		response.Rows[i] = models.CostSummaryRow{
+			Key:          key.String,
+			Calls:        group.Calls,
+			InputTokens:  group.InputTokens.Int64,
+			OutputTokens: group.OutputTokens.Int64,
+			Grounded:     group.Grounded,
+			CostUSD:      group.CostUSD.Float64,
+		}
+		response.TotalCostUSD += group.CostUSD.Float64
+	}
+
+	sort.Slice(response.Rows, func(i, j int) bool {
+		return response.Rows[i].CostUSD > response.Rows[j].CostUSD
+	})
+	limit, _ := pageBounds(filter.Limit, 0)
+	if len(response.Rows) > limit {
+		response.Rows = response.Rows[:limit]
+	}
+
+	return response, nil
+}
+
+// DeleteExpired removes records older than COST_RETENTION_DAYS
+func (s *CostService) DeleteExpired(ctx context.Context) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -s.config.CostRetentionDays)
+	deleted, err := s.client.CostRecord.Delete().
+		Where(costrecord.CreatedAtLT(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired cost records: %w", err)
+	}
+	return deleted, nil
+}
+
+func costPredicates(filter *models.CostFilter) []predicate.CostRecord {
+	var predicates []predicate.CostRecord
+	if filter.UserID != nil {
+		predicates = append(predicates, costrecord.UserIDEQ(*filter.UserID))
+	}
+	if filter.SessionID != "" {
+		predicates = append(predicates, costrecord.SessionIDEQ(filter.SessionID))
+	}
+	if filter.RequestID != "" {
+		predicates = append(predicates, costrecord.RequestIDEQ(filter.RequestID))
+	}
+	if filter.Model != "" {
+		predicates = append(predicates, costrecord.ModelEQ(filter.Model))
+	}
+	if filter.Purpose != "" {
+		predicates = append(predicates, costrecord.PurposeEQ(filter.Purpose))
+	}
+	if !filter.Since.IsZero() {
+		predicates = append(predicates, costrecord.CreatedAtGTE(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		predicates = append(predicates, costrecord.CreatedAtLT(filter.Until))
+	}
+	return predicates
+}
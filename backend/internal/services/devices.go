@@ -0,0 +1,243 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/internal/models"
+)
+
+// Device sessions: every login starts a refresh token family and every
+// refresh rotates the token within it. The family is what users see as a
+// signed-in device. Redis keys:
+//
+//	refresh_family:<family id>          device metadata and the current token hash
+//	user_refresh_families:<user id>     set of the user's family IDs
+
+const maxUserAgentLength = 512
+
+var (
+	ErrRefreshTokenReused = errors.New("refresh token was already used")
+	ErrDeviceNotFound     = errors.New("device session not found")
+)
+
+type deviceSessionData struct {
+	session   models.DeviceSession
+	userID    uuid.UUID
+	tokenHash string
+	revoked   bool
+}
+
+// ListDevices returns the user's active device sessions, most recently
+// used first. currentID marks the device making the request.
+func (s *AuthService) ListDevices(userID, currentID uuid.UUID) ([]models.DeviceSession, error) {
+	indexKey := fmt.Sprintf("user_refresh_families:%s", userID.String())
+	members, err := s.redis.SMembers(s.ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device sessions: %w", err)
+	}
+
+	devices := []models.DeviceSession{}
+	now := time.Now()
+	for _, member := range members {
+		familyID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+
+		data, err := s.getDeviceSession(familyID)
+		if errors.Is(err, redis.Nil) {
+			// Expired - drop it from the index
+			s.redis.SRem(s.ctx, indexKey, member)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get device session: %w", err)
+		}
+		if data.revoked || data.userID != userID || now.After(data.session.ExpiresAt) {
+			continue
+		}
+
+		data.session.Current = familyID == currentID
+		devices = append(devices, data.session)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].LastUsedAt.After(devices[j].LastUsedAt)
+	})
+	return devices, nil
+}
+
+// RevokeDevice signs a device out: its refresh token stops working. Access
+// tokens already issued to it expire on their own.
+func (s *AuthService) RevokeDevice(userID, deviceID uuid.UUID) error {
+	data, err := s.getDeviceSession(deviceID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrDeviceNotFound
+		}
+		return fmt.Errorf("failed to get device session: %w", err)
+	}
+	if data.userID != userID || data.revoked {
+		return ErrDeviceNotFound
+	}
+
+	return s.revokeDeviceSession(userID, deviceID)
+}
+
+// ==================== Device Session Helpers ====================
+
+// issueTokens creates an access token and a new refresh token in a family
+func (s *AuthService) issueTokens(user *models.User, familyID uuid.UUID, device *models.DeviceInfo) (*models.AuthResponse, error) {
+	accessToken, err := s.jwtService.GenerateAccessToken(user.ID, user.Email, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.jwtService.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	refreshTokenData := &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: s.hashToken(refreshToken),
+		ExpiresAt: now.Add(s.jwtService.GetRefreshTTL()),
+		CreatedAt: now,
+	}
+	if device != nil {
+		refreshTokenData.UserAgent = truncateUserAgent(device.UserAgent)
+		refreshTokenData.IPAddress = device.IPAddress
+	}
+
+	if err := s.saveRefreshToken(refreshTokenData); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+	if err := s.saveDeviceSession(refreshTokenData); err != nil {
+		return nil, fmt.Errorf("failed to save device session: %w", err)
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         s.toUserInfo(user),
+		ExpiresIn:    int64(s.jwtService.GetAccessTTL().Seconds()),
+	}, nil
+}
+
+// saveDeviceSession points the family at its newest token and refreshes
+// the device metadata. The family lives as long as its newest token.
+func (s *AuthService) saveDeviceSession(token *models.RefreshToken) error {
+	key := fmt.Sprintf("refresh_family:%s", token.FamilyID.String())
+	indexKey := fmt.Sprintf("user_refresh_families:%s", token.UserID.String())
+	sessionData := map[string]interface{}{
+		"id":           token.FamilyID.String(),
+		"user_id":      token.UserID.String(),
+		"token_hash":   token.TokenHash,
+		"user_agent":   token.UserAgent,
+		"ip_address":   token.IPAddress,
+		"last_used_at": token.CreatedAt.Format(time.RFC3339),
+		"expires_at":   token.ExpiresAt.Format(time.RFC3339),
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(s.ctx, key, sessionData)
+	pipe.HSetNX(s.ctx, key, "created_at", token.CreatedAt.Format(time.RFC3339))
+	pipe.ExpireAt(s.ctx, key, token.ExpiresAt)
+	pipe.SAdd(s.ctx, indexKey, token.FamilyID.String())
+	pipe.ExpireAt(s.ctx, indexKey, token.ExpiresAt)
+	_, err := pipe.Exec(s.ctx)
+	return err
+}
+
+func (s *AuthService) getDeviceSession(familyID uuid.UUID) (*deviceSessionData, error) {
+	key := fmt.Sprintf("refresh_family:%s", familyID.String())
+	sessionData, err := s.redis.HGetAll(s.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessionData) == 0 {
+		return nil, redis.Nil
+	}
+
+	data := &deviceSessionData{
+		session: models.DeviceSession{
+			ID:        familyID,
+			UserAgent: sessionData["user_agent"],
+			IPAddress: sessionData["ip_address"],
+		},
+		tokenHash: sessionData["token_hash"],
+		revoked:   sessionData["revoked_at"] != "",
+	}
+	if userID, err := uuid.Parse(sessionData["user_id"]); err == nil {
+		data.userID = userID
+	}
+	if createdAt, err := time.Parse(time.RFC3339, sessionData["created_at"]); err == nil {
+		data.session.CreatedAt = createdAt
+	}
+	if lastUsedAt, err := time.Parse(time.RFC3339, sessionData["last_used_at"]); err == nil {
+		data.session.LastUsedAt = lastUsedAt
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, sessionData["expires_at"]); err == nil {
+		data.session.ExpiresAt = expiresAt
+	}
+
+	return data, nil
+}
+
+// revokeDeviceSession revokes a family and its current token. Its rotated
+// tokens are already unusable.
+func (s *AuthService) revokeDeviceSession(userID, familyID uuid.UUID) error {
+	key := fmt.Sprintf("refresh_family:%s", familyID.String())
+	data, err := s.getDeviceSession(familyID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.redis.HSet(s.ctx, key, "revoked_at", time.Now().Format(time.RFC3339)).Err(); err != nil {
+		return err
+	}
+	if data.tokenHash != "" {
+		if err := s.revokeRefreshToken(data.tokenHash); err != nil {
+			return err
+		}
+	}
+	return s.redis.SRem(s.ctx, fmt.Sprintf("user_refresh_families:%s", userID.String()), familyID.String()).Err()
+}
+
+// handleRefreshTokenReuse runs when a rotated token comes back. Either the
+// legitimate client or an attacker holds the newer token, so the whole
+// device session is signed out.
+func (s *AuthService) handleRefreshTokenReuse(token *models.RefreshToken) {
+	fmt.Printf("🚨 Refresh token reuse detected for user %s, revoking device session %s\n",
+		token.UserID.String(), token.FamilyID.String())
+
+	var err error
+	if token.FamilyID != uuid.Nil {
+		err = s.revokeDeviceSession(token.UserID, token.FamilyID)
+	} else {
+		err = s.revokeRefreshToken(token.TokenHash)
+	}
+	if err != nil {
+		fmt.Printf("⚠️ Failed to revoke reused refresh token family: %v\n", err)
+	}
+}
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > maxUserAgentLength {
+		return userAgent[:maxUserAgentLength]
+	}
+	return userAgent
+}
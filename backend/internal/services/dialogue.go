@@ -0,0 +1,149 @@
+// backend/internal/services/dialogue.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mylittleprice/internal/models"
+)
+
+// Dialogue is one chat turn on the dialogue tools. Next returns the tool
+// calls of the model; the caller executes the data tools, passes their
+// results to AddResults and calls Next again until a terminal tool is
+// called.
+type Dialogue struct {
+	gemini  *GeminiService
+	req     *LLMRequest
+	pending []LLMToolCall // Calls of the last Next, answered by AddResults
+}
+
+// StartDialogue prepares the turn for a user message. No request is made
+// until Next.
+func (g *GeminiService) StartDialogue(ctx context.Context, userMessage string, session *models.ChatSession) *Dialogue {
+	// Grounding is ALWAYS enabled (configured in shouldUseGrounding method)
+	// This ensures AI always has access to current product data, prices, and models
+	historyMap := convertCycleHistoryToMap(session.CycleState.CycleHistory)
+	useGrounding := g.shouldUseGrounding(userMessage, historyMap, session.SearchState.Category)
+
+	// Dialogue requests declare the tools, so grounding needs a model that
+	// can combine both
+	if useGrounding {
+		if _, route, err := g.llm.ForTask(LLMTaskDialogue); err != nil || !GroundingWithToolsSupported(route.Model) {
+			useGrounding = false
+		}
+	}
+	if useGrounding {
+		fmt.Printf("🌐 Grounding enabled (smart strategy)\n")
+	}
+
+	return &Dialogue{
+		gemini: g,
+		req: &LLMRequest{
+			Prompt:          g.buildDialoguePrompt(ctx, userMessage, session),
+			Temperature:     g.config.GeminiTemperature,
+			MaxOutputTokens: g.config.GeminiMaxOutputTokens,
+			Grounding:       useGrounding,
+			Tools:           DialogueTools(),
+			MaxRetries:      3, // Exponential backoff between attempts
+		},
+	}
+}
+
+// Next asks the model for its next tool calls. A plain text answer is
+// returned as an ask_clarification call. Failed calls can be retried, the
+// dialogue only advances with AddResults. Calls are recorded as costs of
+// the request in ctx.
+func (d *Dialogue) Next(ctx context.Context) ([]LLMToolCall, error) {
+	g := d.gemini
+
+	// If the primary model fails, the route's fallback model is tried (2 attempts)
+	resp, err := g.llm.generateWithFallback(ctx, LLMTaskDialogue, d.req, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Truncated output (MAX_TOKENS) - grounded answers are long, retry without grounding
+	grounded := d.req.Grounding
+	if resp.Truncated && len(resp.ToolCalls) == 0 && grounded {
+		fmt.Printf("⚠️ Response truncated due to MAX_TOKENS, retrying without grounding...\n")
+
+		retryReq := *d.req
+		retryReq.Grounding = false
+		retryReq.MaxRetries = 2
+
+		retryResp, retryErr := g.llm.generateWithFallback(ctx, LLMTaskDialogue, &retryReq, 1)
+		if retryErr == nil && len(retryResp.ToolCalls) > 0 {
+			resp = retryResp
+			grounded = false // Update flag for stats
+			fmt.Printf("✅ Retry without grounding succeeded\n")
+		} else {
+			fmt.Printf("⚠️ Retry without grounding also failed\n")
+		}
+	}
+
+	g.updateTokenStats(resp.Usage, grounded)
+
+	// Log grounding sources (search results) for debugging price/model accuracy
+	if len(resp.GroundingSources) > 0 {
+		fmt.Printf("✅ Grounding metadata found (%d chunks)\n", len(resp.GroundingSources))
+		for i, source := range resp.GroundingSources {
+			if i >= 3 {
+				break
+			}
+			if source.Title != "" {
+				fmt.Printf("   📄 Chunk %d: %s\n", i+1, source.Title)
+				if source.URI != "" {
+					fmt.Printf("      🔗 Source: %s\n", source.URI)
+				}
+			}
+		}
+	}
+
+	calls := resp.ToolCalls
+	if len(calls) == 0 {
+		text := strings.TrimSpace(resp.Text)
+		if text == "" {
+			return nil, fmt.Errorf("no tool call in model response (finish reason: %v)", resp.FinishReason)
+		}
+		fmt.Printf("🔧 Model answered with text instead of a tool call, treating it as %s\n", ToolAskClarification)
+		calls = []LLMToolCall{{
+			Name: ToolAskClarification,
+			Args: map[string]interface{}{"message": text},
+		}}
+	}
+
+	for _, call := range calls {
+		fmt.Printf("🛠️ Tool call: %s %v\n", call.Name, call.Args)
+	}
+
+	d.pending = calls
+	return calls, nil
+}
+
+// AddResults sends the results of the data tool calls returned by the
+// last Next with the following request
+func (d *Dialogue) AddResults(results []LLMToolResult) {
+	d.req.Turns = append(d.req.Turns, LLMTurn{
+		ToolCalls:   d.pending,
+		ToolResults: results,
+	})
+	d.pending = nil
+}
+
+// RequireAnswer restricts the next calls to the terminal tools
+func (d *Dialogue) RequireAnswer() {
+	d.req.AllowedTools = terminalTools
+}
+
+// Grounded reports whether the turn uses Google Search grounding
+func (d *Dialogue) Grounded() bool {
+	return d.req.Grounding
+}
+
+// DisableGrounding runs the turn without Google Search grounding, e.g.
+// once the grounding allowance of the user's plan is spent
+func (d *Dialogue) DisableGrounding() {
+	d.req.Grounding = false
+}
@@ -0,0 +1,201 @@
+// backend/internal/services/dialogue_tools.go
+package services
+
+import "google.golang.org/genai"
+
+// Dialogue tools. The model answers every chat turn with function calls:
+// data tools are executed by ChatProcessor and their results sent back to
+// the model, a terminal tool ends the turn with the message for the user.
+const (
+	ToolSearchProducts    = "search_products"
+	ToolGetProductDetails = "get_product_details"
+	ToolCompareProducts   = "compare_products"
+	ToolGetPriceHistory   = "get_price_history"
+
+	ToolAskClarification = "ask_clarification" // Terminal: question for the user, cycle continues
+	ToolFinishCycle      = "finish_cycle"      // Terminal: final answer, next message starts a new cycle
+)
+
+// Helper functions for creating pointers
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// SearchProductsArgs are the arguments of search_products
+type SearchProductsArgs struct {
+	Query       string   `json:"query"`
+	SearchType  string   `json:"search_type"` // "exact", "parameters" or "category"
+	Category    string   `json:"category"`
+	PriceFilter string   `json:"price_filter,omitempty"` // "cheaper" or "expensive"
+	MinPrice    *float64 `json:"min_price,omitempty"`    // In the session currency
+	MaxPrice    *float64 `json:"max_price,omitempty"`
+}
+
+// GetProductDetailsArgs are the arguments of get_product_details
+type GetProductDetailsArgs struct {
+	PageToken string `json:"page_token"`
+}
+
+// CompareProductsArgs are the arguments of compare_products
+type CompareProductsArgs struct {
+	Products []string `json:"products"` // Names of products shown in search results
+}
+
+// GetPriceHistoryArgs are the arguments of get_price_history
+type GetPriceHistoryArgs struct {
+	Product string `json:"product"` // Product name as shown in search results
+}
+
+// DialogueAnswer is the argument of both terminal tools
+type DialogueAnswer struct {
+	Message      string   `json:"message"`
+	QuickReplies []string `json:"quick_replies,omitempty"`
+	Category     string   `json:"category"`
+}
+
+// IsTerminalTool reports whether a tool call ends the chat turn
+func IsTerminalTool(name string) bool {
+	return name == ToolAskClarification || name == ToolFinishCycle
+}
+
+// terminalTools are the only tools allowed once the round limit is reached
+var terminalTools = []string{ToolAskClarification, ToolFinishCycle}
+
+// DialogueTools returns the declarations of every dialogue tool
+func DialogueTools() []*genai.FunctionDeclaration {
+	return []*genai.FunctionDeclaration{
+		{
+			Name:        ToolSearchProducts,
+			Description: "Search the shops for products. Returns the top results with name, price and page_token. The results are shown to the user.",
+			Parameters: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"query": {
+						Type:        genai.TypeString,
+						Description: "Product name with specifications ONLY, in ENGLISH. DO NOT include country, location, currency, or words like 'price'. Example: 'Samsung Galaxy S25 Ultra Titanium Black', NOT 'Samsung Galaxy S25 Ultra Titanium Black price Switzerland'",
+					},
+					"search_type": {
+						Type:        genai.TypeString,
+						Enum:        []string{"exact", "parameters", "category"},
+						Description: "Type of search to perform",
+					},
+					"category": categorySchema(),
+					"price_filter": {
+						Type:        genai.TypeString,
+						Enum:        []string{"cheaper", "expensive"},
+						Description: "Price filter preference",
+						Nullable:    boolPtr(true),
+					},
+					"min_price": {
+						Type:        genai.TypeNumber,
+						Description: "Minimum price in user's currency (extracted from price range like '30000-40000')",
+						Nullable:    boolPtr(true),
+					},
+					"max_price": {
+						Type:        genai.TypeNumber,
+						Description: "Maximum price in user's currency (extracted from price range like '30000-40000')",
+						Nullable:    boolPtr(true),
+					},
+				},
+				Required:         []string{"query", "search_type", "category"},
+				PropertyOrdering: []string{"query", "search_type", "category", "price_filter", "min_price", "max_price"},
+			},
+		},
+		{
+			Name:        ToolGetProductDetails,
+			Description: "Get specifications, rating and shop offers of one product from the search results",
+			Parameters: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"page_token": {
+						Type:        genai.TypeString,
+						Description: "page_token of the product, exactly as returned by search_products",
+					},
+				},
+				Required: []string{"page_token"},
+			},
+		},
+		{
+			Name:        ToolCompareProducts,
+			Description: "Compare 2-5 products from the search results side by side. The comparison table and a verdict are shown to the user.",
+			Parameters: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"products": {
+						Type:        genai.TypeArray,
+						Items:       &genai.Schema{Type: genai.TypeString},
+						MinItems:    int64Ptr(2),
+						MaxItems:    int64Ptr(5),
+						Description: "Product names exactly as they appeared in the search results",
+					},
+				},
+				Required: []string{"products"},
+			},
+		},
+		{
+			Name:        ToolGetPriceHistory,
+			Description: "Get the prices seen for a product over the last 90 days: min, median, max, the current price and a verdict (good, typical, high or insufficient_data). Use it to tell whether a price is a good deal.",
+			Parameters: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"product": {
+						Type:        genai.TypeString,
+						Description: "Product name exactly as it appeared in the search results",
+					},
+				},
+				Required: []string{"product"},
+			},
+		},
+		{
+			Name:        ToolAskClarification,
+			Description: "Ask the user a question to narrow down what they want. Also used for off-topic messages.",
+			Parameters:  answerSchema("Short helpful question (<400 chars)", 1),
+		},
+		{
+			Name:        ToolFinishCycle,
+			Description: "Give the final answer once the product is found (after search_products or compare_products). The user's next message starts a new search.",
+			Parameters:  answerSchema("Short message presenting the results (<400 chars)", 0),
+		},
+	}
+}
+
+func categorySchema() *genai.Schema {
+	return &genai.Schema{
+		Type:        genai.TypeString,
+		Enum:        []string{"brand_specific", "parametric", "generic_model", "unknown"},
+		Description: "Product category classification",
+	}
+}
+
+// answerSchema is the parameter schema of the terminal tools
+func answerSchema(messageDescription string, minQuickReplies int64) *genai.Schema {
+	quickReplies := &genai.Schema{
+		Type:        genai.TypeArray,
+		Items:       &genai.Schema{Type: genai.TypeString},
+		MaxItems:    int64Ptr(6),
+		Description: "Quick reply options with price ranges (e.g., 'Option A (≈$X)')",
+	}
+	required := []string{"message", "category"}
+	if minQuickReplies > 0 {
+		quickReplies.MinItems = int64Ptr(minQuickReplies)
+		required = append(required, "quick_replies")
+	}
+
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"message": {
+				Type:        genai.TypeString,
+				Description: messageDescription,
+			},
+			"quick_replies": quickReplies,
+			"category":      categorySchema(),
+		},
+		Required:         required,
+		PropertyOrdering: []string{"message", "quick_replies", "category"},
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"html/template"
 	"net/smtp"
+	"time"
 
 	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
 )
 
 type EmailService struct {
@@ -154,6 +156,246 @@ func (s *EmailService) SendPasswordResetEmail(toEmail, resetToken string) error
 	return s.sendEmail(toEmail, subject, body.String())
 }
 
+// SendVerificationEmail sends the link that confirms the address of a new
+// account
+func (s *EmailService) SendVerificationEmail(toEmail, verificationToken string) error {
+	subject := "Confirm Your Email - MyLittlePrice"
+
+	verifyLink := fmt.Sprintf("%s/verify-email?token=%s", s.config.FrontendURL, verificationToken)
+
+	htmlTemplate := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 0 auto;
+            padding: 20px;
+        }
+        .container {
+            background-color: #ffffff;
+            border-radius: 8px;
+            padding: 40px;
+            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
+        }
+        .logo {
+            text-align: center;
+            margin-bottom: 30px;
+        }
+        .logo h1 {
+            color: #6366f1;
+            margin: 0;
+            font-size: 28px;
+        }
+        .button {
+            display: inline-block;
+            background-color: #6366f1;
+            color: white;
+            text-decoration: none;
+            padding: 14px 28px;
+            border-radius: 6px;
+            font-weight: 600;
+            margin: 20px 0;
+        }
+        .footer {
+            margin-top: 40px;
+            padding-top: 20px;
+            border-top: 1px solid #e5e7eb;
+            font-size: 14px;
+            color: #6b7280;
+            text-align: center;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="logo">
+            <h1>🛍️ MyLittlePrice</h1>
+        </div>
+
+        <div class="content">
+            <h2>Confirm Your Email</h2>
+            <p>Hello,</p>
+            <p>Thanks for signing up for MyLittlePrice! Please confirm that this is your email address:</p>
+
+            <div style="text-align: center;">
+                <a href="{{.VerifyLink}}" class="button">Confirm Email</a>
+            </div>
+
+            <p>The link expires in {{.ExpiresIn}}. If you didn't create an account, you can safely ignore this email.</p>
+        </div>
+
+        <div class="footer">
+            <p>If the button doesn't work, copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #6366f1;">{{.VerifyLink}}</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err := template.New("verify_email").Parse(htmlTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var body bytes.Buffer
+	data := struct {
+		VerifyLink string
+		ExpiresIn  string
+	}{
+		VerifyLink: verifyLink,
+		ExpiresIn:  formatExpiry(s.config.EmailVerificationTTL),
+	}
+
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return s.sendEmail(toEmail, subject, body.String())
+}
+
+// formatExpiry renders a link lifetime as "24 hours" or "30 minutes"
+func formatExpiry(ttl time.Duration) string {
+	if hours := int(ttl.Hours()); hours >= 1 {
+		if hours == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d minutes", int(ttl.Minutes()))
+}
+
+// SendPriceAlertEmail notifies a user that a watched product reached the target price
+func (s *EmailService) SendPriceAlertEmail(toEmail string, alert *models.Watch) error {
+	subject := fmt.Sprintf("Price drop: %s - MyLittlePrice", alert.ProductName)
+
+	htmlTemplate := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 0 auto;
+            padding: 20px;
+        }
+        .container {
+            background-color: #ffffff;
+            border-radius: 8px;
+            padding: 40px;
+            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
+        }
+        .logo {
+            text-align: center;
+            margin-bottom: 30px;
+        }
+        .logo h1 {
+            color: #6366f1;
+            margin: 0;
+            font-size: 28px;
+        }
+        .price {
+            font-size: 32px;
+            font-weight: 700;
+            color: #16a34a;
+            text-align: center;
+            margin: 10px 0;
+        }
+        .button {
+            display: inline-block;
+            background-color: #6366f1;
+            color: white;
+            text-decoration: none;
+            padding: 14px 28px;
+            border-radius: 6px;
+            font-weight: 600;
+            margin: 20px 0;
+        }
+        .footer {
+            margin-top: 40px;
+            padding-top: 20px;
+            border-top: 1px solid #e5e7eb;
+            font-size: 14px;
+            color: #6b7280;
+            text-align: center;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="logo">
+            <h1>🛍️ MyLittlePrice</h1>
+        </div>
+
+        <div class="content">
+            <h2>📉 {{.ProductName}} is now cheaper</h2>
+            <p>Good news! The best offer for a product on your watchlist reached your target price of <strong>{{.TargetPrice}}</strong>.</p>
+
+            <div class="price">{{.Price}}</div>
+            {{if .Merchant}}<p style="text-align: center;">at {{.Merchant}}</p>{{end}}
+
+            <div style="text-align: center;">
+                <a href="{{.Link}}" class="button">View Offer</a>
+            </div>
+        </div>
+
+        <div class="footer">
+            <p>You are receiving this email because you added this product to your watchlist on MyLittlePrice.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	tmpl, err := template.New("price_alert").Parse(htmlTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	link := alert.LastLink
+	if link == "" {
+		link = s.config.FrontendURL
+	}
+
+	price := ""
+	if alert.LastPrice != nil {
+		price = fmt.Sprintf("%.2f %s", *alert.LastPrice, alert.Currency)
+	}
+
+	var body bytes.Buffer
+	data := struct {
+		ProductName string
+		TargetPrice string
+		Price       string
+		Merchant    string
+		Link        string
+	}{
+		ProductName: alert.ProductName,
+		TargetPrice: fmt.Sprintf("%.2f %s", alert.TargetPrice, alert.Currency),
+		Price:       price,
+		Merchant:    alert.LastMerchant,
+		Link:        link,
+	}
+
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return s.sendEmail(toEmail, subject, body.String())
+}
+
 // sendEmail sends an email using SMTP
 func (s *EmailService) sendEmail(to, subject, htmlBody string) error {
 	// Check if SMTP is configured
@@ -188,6 +430,6 @@ func (s *EmailService) sendEmail(to, subject, htmlBody string) error {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	fmt.Printf("✅ Password reset email sent to %s\n", to)
+	fmt.Printf("✅ Email \"%s\" sent to %s\n", subject, to)
 	return nil
 }
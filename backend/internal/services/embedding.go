@@ -10,34 +10,38 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
-	"google.golang.org/genai"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"mylittleprice/internal/config"
+	"mylittleprice/internal/tracing"
 )
 
 type EmbeddingService struct {
-	client             *genai.Client
+	llm                *LLMRegistry
 	redis              *redis.Client
 	config             *config.Config
 	ctx                context.Context
 	categoryEmbeddings map[string][]float32
+	queryIndex         queryVectorIndex // Embeddings of cached search queries, nil if CACHE_SEMANTIC_INDEX=off
 	mu                 sync.RWMutex
 }
 
-func NewEmbeddingService(client *genai.Client, redis *redis.Client, cfg *config.Config) *EmbeddingService {
+func NewEmbeddingService(llm *LLMRegistry, redis *redis.Client, cfg *config.Config) *EmbeddingService {
 	s := &EmbeddingService{
-		client:             client,
+		llm:                llm,
 		redis:              redis,
 		config:             cfg,
 		ctx:                context.Background(),
 		categoryEmbeddings: make(map[string][]float32),
 	}
 	s.loadCategoryEmbeddings()
+	s.queryIndex = newQueryVectorIndex(redis, cfg.CacheSemanticIndex, s.cacheNamespace())
 	return s
 }
 
 func (e *EmbeddingService) loadCategoryEmbeddings() {
-	key := "embeddings:categories:v1"
+	key := "embeddings:categories:v1" + e.cacheNamespace()
 	data, err := e.redis.Get(e.ctx, key).Bytes()
 
 	if err == redis.Nil {
@@ -74,40 +78,50 @@ func (e *EmbeddingService) generateCategoryEmbeddings() {
 	}
 
 	for category, text := range categories {
-		embedding := e.getEmbedding(text)
+		embedding := e.getEmbedding(e.ctx, text)
 		if embedding != nil {
 			e.categoryEmbeddings[category] = embedding
 		}
 	}
 }
 
-func (e *EmbeddingService) getEmbedding(text string) []float32 {
-	resp, err := e.client.Models.EmbedContent(
-		e.ctx,
-		e.config.GeminiEmbeddingModel,
-		genai.Text(text),
-		nil,
-	)
-	if err != nil || resp == nil || len(resp.Embeddings) == 0 {
+func (e *EmbeddingService) getEmbedding(ctx context.Context, text string) []float32 {
+	embedding, err := e.llm.embed(ctx, text)
+	if err != nil {
 		return nil
 	}
-	return resp.Embeddings[0].Values
+	return embedding
+}
+
+// cacheNamespace separates cached vectors of different embedding models.
+// Gemini keeps the original keys so existing caches stay valid.
+func (e *EmbeddingService) cacheNamespace() string {
+	_, route, err := e.llm.ForTask(LLMTaskEmbedding)
+	if err != nil || route.Provider == LLMProviderGemini {
+		return ""
+	}
+	return ":" + route.Provider + ":" + route.Model
 }
 
-func (e *EmbeddingService) GetQueryEmbedding(query string) []float32 {
-	cacheKey := fmt.Sprintf("embeddings:query:%s", query)
-	cached, err := e.redis.Get(e.ctx, cacheKey).Bytes()
+func (e *EmbeddingService) GetQueryEmbedding(ctx context.Context, query string) []float32 {
+	ctx, span := tracing.StartChild(ctx, "embedding.lookup", trace.SpanKindInternal)
+	defer span.End()
+
+	cacheKey := fmt.Sprintf("embeddings%s:query:%s", e.cacheNamespace(), query)
+	cached, err := e.redis.Get(ctx, cacheKey).Bytes()
 
 	if err == nil {
 		var embedding []float32
 		if err := json.Unmarshal(cached, &embedding); err != nil {
 			fmt.Printf("⚠️ Failed to unmarshal cached embedding for query '%s': %v\n", query, err)
 		} else {
+			span.SetAttributes(attribute.Bool("embedding.cache_hit", true))
 			return embedding
 		}
 	}
+	span.SetAttributes(attribute.Bool("embedding.cache_hit", false))
 
-	embedding := e.getEmbedding(query)
+	embedding := e.getEmbedding(ctx, query)
 	if embedding != nil {
 		jsonData, err := json.Marshal(embedding)
 		if err != nil {
@@ -115,15 +129,15 @@ func (e *EmbeddingService) GetQueryEmbedding(query string) []float32 {
 			return embedding
 		}
 		ttl := time.Duration(e.config.CacheQueryEmbeddingTTL) * time.Second
-		if err := e.redis.Set(e.ctx, cacheKey, jsonData, ttl).Err(); err != nil {
+		if err := e.redis.Set(ctx, cacheKey, jsonData, ttl).Err(); err != nil {
 			fmt.Printf("⚠️ Failed to cache embedding for query '%s': %v\n", query, err)
 		}
 	}
 	return embedding
 }
 
-func (e *EmbeddingService) DetectCategory(userMessage string) string {
-	queryEmbedding := e.GetQueryEmbedding(userMessage)
+func (e *EmbeddingService) DetectCategory(ctx context.Context, userMessage string) string {
+	queryEmbedding := e.GetQueryEmbedding(ctx, userMessage)
 	if queryEmbedding == nil {
 		return ""
 	}
@@ -148,48 +162,60 @@ func (e *EmbeddingService) DetectCategory(userMessage string) string {
 	return ""
 }
 
-func (e *EmbeddingService) FindSimilarCachedQuery(query string, threshold float32) string {
-	queryEmbedding := e.GetQueryEmbedding(query)
-	if queryEmbedding == nil {
+// FindSimilarCachedQuery returns the cache key of the cached search in the
+// scope whose query is most similar to query, empty if none reaches the
+// threshold
+func (e *EmbeddingService) FindSimilarCachedQuery(ctx context.Context, query string, scope QueryCacheScope, threshold float32) string {
+	if e.queryIndex == nil {
 		return ""
 	}
 
-	pattern := "cache:search:*"
-	iter := e.redis.Scan(e.ctx, 0, pattern, 100).Iterator()
+	ctx, span := tracing.StartChild(ctx, "embedding.similar_query", trace.SpanKindInternal)
+	defer span.End()
 
-	// Limit scan to prevent performance issues with large key sets
-	const maxKeysToCheck = 100
-	keysChecked := 0
+	queryEmbedding := e.GetQueryEmbedding(ctx, query)
+	if queryEmbedding == nil {
+		return ""
+	}
 
-	for iter.Next(e.ctx) {
-		if keysChecked >= maxKeysToCheck {
-			fmt.Printf("⚠️ FindSimilarCachedQuery: Reached max keys limit (%d), stopping scan\n", maxKeysToCheck)
-			break
-		}
+	cacheKey, similarity, err := e.queryIndex.Nearest(ctx, scope, queryEmbedding)
+	if err != nil {
+		span.RecordError(err)
+		fmt.Printf("⚠️ FindSimilarCachedQuery: %v\n", err)
+		return ""
+	}
+	span.SetAttributes(
+		attribute.Float64("embedding.similarity", float64(similarity)),
+		attribute.Bool("embedding.cache_hit", cacheKey != "" && similarity >= threshold),
+	)
+	if cacheKey == "" || similarity < threshold {
+		return ""
+	}
 
-		cacheKey := iter.Val()
-		cachedQuery := cacheKey[len("cache:search:"):]
-		cachedEmbedding := e.GetQueryEmbedding(cachedQuery)
+	fmt.Printf("🧭 Semantic cache hit for '%s' (similarity %.3f)\n", query, similarity)
+	return cacheKey
+}
 
-		if cachedEmbedding != nil {
-			similarity := cosineSimilarity(queryEmbedding, cachedEmbedding)
-			if similarity >= threshold {
-				return cacheKey
-			}
-		}
-		keysChecked++
+// IndexCachedQuery makes a cached search findable by FindSimilarCachedQuery
+// until ttl, the lifetime of the cached results
+func (e *EmbeddingService) IndexCachedQuery(ctx context.Context, query string, scope QueryCacheScope, cacheKey string, ttl time.Duration) {
+	if e.queryIndex == nil {
+		return
 	}
 
-	if err := iter.Err(); err != nil {
-		fmt.Printf("⚠️ FindSimilarCachedQuery: Redis scan error: %v\n", err)
+	queryEmbedding := e.GetQueryEmbedding(ctx, query)
+	if queryEmbedding == nil {
+		return
 	}
 
-	return ""
+	if err := e.queryIndex.Add(ctx, scope, cacheKey, queryEmbedding, ttl); err != nil {
+		fmt.Printf("⚠️ IndexCachedQuery: %v\n", err)
+	}
 }
 
-func (e *EmbeddingService) AreDuplicateProducts(name1, name2 string, threshold float32) bool {
-	emb1 := e.GetQueryEmbedding(name1)
-	emb2 := e.GetQueryEmbedding(name2)
+func (e *EmbeddingService) AreDuplicateProducts(ctx context.Context, name1, name2 string, threshold float32) bool {
+	emb1 := e.GetQueryEmbedding(ctx, name1)
+	emb2 := e.GetQueryEmbedding(ctx, name2)
 
 	if emb1 == nil || emb2 == nil {
 		return false
@@ -0,0 +1,109 @@
+// backend/internal/services/fixture_provider.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"mylittleprice/internal/domain"
+	"mylittleprice/internal/fixtures"
+)
+
+const FixtureProviderName = "fixture"
+
+// FixtureSearchProvider serves SerpAPI shopping and immersive-product
+// responses from JSON cassettes. In record mode it calls the live
+// SerpAPI provider and writes every response to disk.
+type FixtureSearchProvider struct {
+	store *fixtures.Store
+	live  *SerpAPIProvider // Used in record mode only
+}
+
+func NewFixtureSearchProvider(store *fixtures.Store, live *SerpAPIProvider) *FixtureSearchProvider {
+	return &FixtureSearchProvider{
+		store: store,
+		live:  live,
+	}
+}
+
+func (p *FixtureSearchProvider) Name() string {
+	return FixtureProviderName
+}
+
+func (p *FixtureSearchProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		PriceFilter:    true,
+		ProductDetails: true,
+	}
+}
+
+func (p *FixtureSearchProvider) Search(ctx context.Context, query, country string, minPrice, maxPrice *float64) ([]domain.ShoppingItem, int, error) {
+	fmt.Printf("\n📼 Fixture search (%s): %s [%s]\n", p.store.Mode(), query, country)
+
+	data, keyIndex, err := p.fetch(ctx, serpShoppingParameters(query, country, minPrice, maxPrice))
+	if err != nil {
+		return nil, keyIndex, err
+	}
+
+	return parseShoppingResults(data), keyIndex, nil
+}
+
+func (p *FixtureSearchProvider) GetProductDetails(ctx context.Context, pageToken string) (map[string]interface{}, int, error) {
+	return p.fetch(ctx, serpImmersiveParameters(pageToken))
+}
+
+func (p *FixtureSearchProvider) fetch(ctx context.Context, parameter map[string]string) (map[string]interface{}, int, error) {
+	kind := "serpapi/" + parameter["engine"]
+	key := fixtures.Key(canonicalParameters(parameter)...)
+
+	request, err := json.Marshal(parameter)
+	if err != nil {
+		return nil, -1, fmt.Errorf("failed to marshal fixture request: %w", err)
+	}
+
+	if p.store.Mode() == fixtures.ModeRecord && p.live != nil {
+		data, keyIndex, err := p.live.execute(ctx, parameter, "SERP (recording)")
+		if err != nil {
+			return nil, keyIndex, err
+		}
+
+		response, err := json.Marshal(data)
+		if err == nil {
+			cassette := &fixtures.Cassette{Key: key, Request: request, Response: response}
+			if saveErr := p.store.Save(kind, cassette); saveErr != nil {
+				fmt.Printf("   ⚠️ Failed to save cassette: %v\n", saveErr)
+			}
+		}
+
+		return data, keyIndex, nil
+	}
+
+	cassette, err := p.store.Lookup(kind, key, request)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(cassette.ResponseBody(), &data); err != nil {
+		return nil, -1, fmt.Errorf("invalid fixture response for %s: %w", kind, err)
+	}
+
+	return data, -1, nil
+}
+
+// canonicalParameters flattens parameters in key order for stable hashing
+func canonicalParameters(parameter map[string]string) []string {
+	keys := make([]string, 0, len(parameter))
+	for k := range parameter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+parameter[k])
+	}
+	return parts
+}
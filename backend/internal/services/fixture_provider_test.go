@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"mylittleprice/internal/fixtures"
+)
+
+// bundledFixtures replays the cassettes shipped in backend/fixtures
+func bundledFixtures() *fixtures.Store {
+	return fixtures.NewStore("../../fixtures", fixtures.ModeReplay)
+}
+
+func TestFixtureSearchProviderReplay(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFixtureSearchProvider(bundledFixtures(), nil)
+
+	items, _, err := provider.Search(ctx, "wireless headphones", "CH", nil, nil)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(items) == 0 {
+		t.Fatal("Search returned no items")
+	}
+	if items[0].PageToken != "fixture-token-1" {
+		t.Errorf("first item page token = %q, want fixture-token-1", items[0].PageToken)
+	}
+
+	details, _, err := provider.GetProductDetails(ctx, items[0].PageToken)
+	if err != nil {
+		t.Fatalf("GetProductDetails: %v", err)
+	}
+	if _, ok := details["product_results"]; !ok {
+		t.Errorf("product details have no product_results: %v", details)
+	}
+}
+
+func TestGeminiTransportReplay(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := NewGenAIClient(ctx, fixtures.ReplayAPIKey, bundledFixtures())
+	if err != nil {
+		t.Fatalf("NewGenAIClient: %v", err)
+	}
+
+	// Matched by the hand-written translate.json cassette
+	resp, err := client.Models.GenerateContent(ctx, "gemini-2.5-flash",
+		genai.Text("Translate this product search query to English: kabellose Kopfhörer"), nil)
+	if err != nil {
+		t.Fatalf("GenerateContent: %v", err)
+	}
+	if got := resp.Text(); got != "wireless noise cancelling headphones" {
+		t.Errorf("response text = %q, want the translate.json answer", got)
+	}
+	if resp.UsageMetadata == nil || resp.UsageMetadata.TotalTokenCount != 46 {
+		t.Errorf("usage metadata = %+v, want 46 total tokens", resp.UsageMetadata)
+	}
+}
+
+func TestGeminiProviderReplay(t *testing.T) {
+	ctx := context.Background()
+	store := bundledFixtures()
+
+	client, err := NewGenAIClient(ctx, fixtures.ReplayAPIKey, store)
+	if err != nil {
+		t.Fatalf("NewGenAIClient: %v", err)
+	}
+	// Replay mode never touches the key rotator
+	provider := &GeminiProvider{client: client, cassettes: store, currentKeyIndex: -1, ctx: ctx}
+
+	resp, err := provider.Generate(ctx, &LLMRequest{
+		Model:  "gemini-2.5-flash",
+		Prompt: "Compare these products: Product 1, Product 2",
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if resp.Text == "" || len(resp.ToolCalls) != 0 {
+		t.Errorf("comparison response = %+v, want text only", resp)
+	}
+	if resp.Usage.TotalTokens != 430 {
+		t.Errorf("total tokens = %d, want 430", resp.Usage.TotalTokens)
+	}
+
+	resp, err = provider.Generate(ctx, &LLMRequest{
+		Model:  "gemini-2.5-flash",
+		Prompt: "User message: I need headphones",
+		Tools: []*genai.FunctionDeclaration{
+			{Name: "search_products", Description: "Searches products"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Generate with tools: %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "search_products" {
+		t.Fatalf("tool calls = %+v, want one search_products call", resp.ToolCalls)
+	}
+	if query, _ := resp.ToolCalls[0].Args["query"].(string); query == "" {
+		t.Errorf("search_products call has no query: %v", resp.ToolCalls[0].Args)
+	}
+}
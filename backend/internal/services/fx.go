@@ -0,0 +1,355 @@
+// backend/internal/services/fx.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+const (
+	FXSourceStatic = "static"
+	FXSourceHTTP   = "http"
+)
+
+// FXRates is a set of exchange rates relative to Base (Base itself is 1)
+type FXRates struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt string             `json:"date,omitempty"`
+}
+
+// FXRateSource loads exchange rates from somewhere
+type FXRateSource interface {
+	Name() string
+	FetchRates(ctx context.Context) (*FXRates, error)
+}
+
+// StaticRateSource reads rates from a JSON file (offline use)
+type StaticRateSource struct {
+	path string
+}
+
+func NewStaticRateSource(path string) *StaticRateSource {
+	return &StaticRateSource{path: path}
+}
+
+func (s *StaticRateSource) Name() string {
+	return FXSourceStatic
+}
+
+func (s *StaticRateSource) FetchRates(ctx context.Context) (*FXRates, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+	return decodeFXRates(data)
+}
+
+// HTTPRateSource fetches rates from an HTTP endpoint returning
+// {"base": "EUR", "rates": {"CHF": 0.94, ...}} (e.g. frankfurter.app)
+type HTTPRateSource struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPRateSource(url string) *HTTPRateSource {
+	return &HTTPRateSource{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPRateSource) Name() string {
+	return FXSourceHTTP
+}
+
+func (s *HTTPRateSource) FetchRates(ctx context.Context) (*FXRates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rates request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rates endpoint returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates response: %w", err)
+	}
+	return decodeFXRates(data)
+}
+
+func decodeFXRates(data []byte) (*FXRates, error) {
+	var rates FXRates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("invalid rates data: %w", err)
+	}
+
+	rates.Base = strings.ToUpper(rates.Base)
+	if rates.Base == "" || len(rates.Rates) == 0 {
+		return nil, fmt.Errorf("rates data must contain base and rates")
+	}
+
+	normalized := make(map[string]float64, len(rates.Rates)+1)
+	for code, rate := range rates.Rates {
+		if rate > 0 {
+			normalized[strings.ToUpper(code)] = rate
+		}
+	}
+	normalized[rates.Base] = 1
+	rates.Rates = normalized
+
+	return &rates, nil
+}
+
+// FXService converts prices between currencies so results honour the
+// session currency. Rates are refreshed lazily in the background from the
+// configured source; the static file is used as a fallback when the source
+// fails.
+type FXService struct {
+	source   FXRateSource
+	fallback FXRateSource
+	ttl      time.Duration
+
+	mu         sync.RWMutex
+	rates      *FXRates
+	fetchedAt  time.Time
+	refreshing atomic.Bool // A background refresh is in progress
+}
+
+func NewFXService(cfg *config.Config) *FXService {
+	static := NewStaticRateSource(cfg.FXRatesFile)
+
+	var source FXRateSource = static
+	if strings.ToLower(cfg.FXSource) == FXSourceHTTP {
+		source = NewHTTPRateSource(cfg.FXRatesURL)
+	}
+
+	s := &FXService{
+		source:   source,
+		fallback: static,
+		ttl:      cfg.FXRefreshInterval,
+	}
+
+	if err := s.refresh(); err != nil {
+		fmt.Printf("⚠️ FX rates not loaded (%s): %v\n", source.Name(), err)
+	}
+
+	return s
+}
+
+// refresh loads rates from the source, falling back to the static file
+func (s *FXService) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rates, err := s.source.FetchRates(ctx)
+	if err != nil && s.fallback != nil && s.fallback != s.source {
+		fmt.Printf("⚠️ FX source %s failed, using %s: %v\n", s.source.Name(), s.fallback.Name(), err)
+		rates, err = s.fallback.FetchRates(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Don't hammer a failing source - retry after the next TTL either way
+	s.fetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+
+	s.rates = rates
+	fmt.Printf("💱 FX rates loaded: %d currencies (base %s)\n", len(rates.Rates), rates.Base)
+	return nil
+}
+
+func (s *FXService) currentRates() *FXRates {
+	s.mu.RLock()
+	rates, fetchedAt := s.rates, s.fetchedAt
+	s.mu.RUnlock()
+
+	// Stale rates are served until the refresh finishes, so requests never
+	// wait for the source and only one of them starts a refresh
+	if s.ttl > 0 && time.Since(fetchedAt) > s.ttl && s.refreshing.CompareAndSwap(false, true) {
+		go func() {
+			defer s.refreshing.Store(false)
+			if err := s.refresh(); err != nil {
+				fmt.Printf("⚠️ FX rates refresh failed, keeping previous rates: %v\n", err)
+			}
+		}()
+	}
+
+	return rates
+}
+
+// Convert converts amount from one currency to another
+func (s *FXService) Convert(amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return amount, nil
+	}
+
+	rates := s.currentRates()
+	if rates == nil {
+		return 0, fmt.Errorf("no FX rates available")
+	}
+
+	fromRate, ok := rates.Rates[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency: %s", from)
+	}
+	toRate, ok := rates.Rates[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency: %s", to)
+	}
+
+	return amount / fromRate * toRate, nil
+}
+
+// ConvertProductCards sets ConvertedPrice on cards whose price is not in
+// the target currency. SerpAPI prices are in the searched country's currency.
+func (s *FXService) ConvertProductCards(cards []models.ProductCard, country, currency string) {
+	source := localCurrency(country)
+	for i := range cards {
+		cards[i].ConvertedPrice = s.convertPriceString(cards[i].Price, source, currency)
+	}
+}
+
+// ConvertOffers sets ConvertedPrice on offers whose price is not in the
+// target currency
+func (s *FXService) ConvertOffers(offers []models.Offer, country, currency string) {
+	source := localCurrency(country)
+	for i := range offers {
+		offer := &offers[i]
+
+		from := source
+		if offer.Currency != "" {
+			from = offer.Currency
+		}
+
+		if offer.ExtractedPrice > 0 {
+			offer.ConvertedPrice = s.convertAmount(offer.ExtractedPrice, from, currency)
+			continue
+		}
+		offer.ConvertedPrice = s.convertPriceString(offer.Price, from, currency)
+	}
+}
+
+// NormalizePriceRange converts a price range given in the user's currency
+// into the currency of the searched country, so provider price filters apply
+// to the right amounts
+func (s *FXService) NormalizePriceRange(minPrice, maxPrice *float64, currency, country string) (*float64, *float64) {
+	target := localCurrency(country)
+	if currency == "" || strings.EqualFold(currency, target) {
+		return minPrice, maxPrice
+	}
+
+	convert := func(price *float64) *float64 {
+		if price == nil {
+			return nil
+		}
+		converted, err := s.Convert(*price, currency, target)
+		if err != nil {
+			// Better to drop the filter than to filter in the wrong currency
+			fmt.Printf("⚠️ Cannot convert price filter %.2f %s → %s: %v\n", *price, currency, target, err)
+			return nil
+		}
+		return &converted
+	}
+
+	return convert(minPrice), convert(maxPrice)
+}
+
+func (s *FXService) convertPriceString(price, fallbackCurrency, target string) string {
+	amount := ParsePrice(price)
+	if amount <= 0 {
+		return ""
+	}
+	return s.convertAmount(amount, detectPriceCurrency(price, fallbackCurrency), target)
+}
+
+// convertAmount returns a formatted price, or "" if no conversion is needed or possible
+func (s *FXService) convertAmount(amount float64, from, target string) string {
+	if target == "" || strings.EqualFold(from, target) {
+		return ""
+	}
+
+	converted, err := s.Convert(amount, from, target)
+	if err != nil {
+		return ""
+	}
+	return FormatPrice(converted, target)
+}
+
+// ParsePrice extracts a number from strings like "CHF 1'299.00",
+// "$1,299.99", "1.299,00 €", "€1.299" or "12,5 €". A lone "." or ","
+// followed by exactly three digits groups thousands, otherwise it is the
+// decimal separator; with both, the last one is the decimal separator.
+func ParsePrice(price string) float64 {
+	var digits strings.Builder
+	for _, r := range price {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			digits.WriteRune(r)
+		}
+	}
+	// "CHF 49.–" leaves a trailing separator
+	cleaned := strings.Trim(digits.String(), ".,")
+
+	lastDot := strings.LastIndex(cleaned, ".")
+	lastComma := strings.LastIndex(cleaned, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal, grouping := ".", ","
+		if lastComma > lastDot {
+			decimal, grouping = ",", "."
+		}
+		cleaned = strings.ReplaceAll(cleaned, grouping, "")
+		cleaned = strings.Replace(cleaned, decimal, ".", 1)
+	case lastDot >= 0:
+		cleaned = normalizeSeparator(cleaned, ".")
+	case lastComma >= 0:
+		cleaned = normalizeSeparator(cleaned, ",")
+	}
+
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// normalizeSeparator resolves a number with a single kind of separator:
+// repeated, or followed by exactly three digits, it groups thousands
+// ("1.299.000", "1,299"), otherwise it is the decimal point ("12,5")
+func normalizeSeparator(number, sep string) string {
+	integer, fraction, _ := strings.Cut(number, sep)
+	grouped := strings.Count(number, sep) > 1 || (len(fraction) == 3 && integer != "0")
+	if grouped {
+		return strings.ReplaceAll(number, sep, "")
+	}
+	return integer + "." + fraction
+}
+
+// FormatPrice formats an amount as "CHF 1299.00"
+func FormatPrice(amount float64, currency string) string {
+	return fmt.Sprintf("%s %.2f", strings.ToUpper(currency), amount)
+}
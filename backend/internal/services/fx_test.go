@@ -0,0 +1,34 @@
+package services
+
+import "testing"
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		price string
+		want  float64
+	}{
+		{"CHF 1'299.00", 1299},
+		{"$1,299.99", 1299.99},
+		{"1.299,00 €", 1299},
+		{"1.299 €", 1299},
+		{"€1.299", 1299},
+		{"12,5 €", 12.5},
+		{"12,50 €", 12.5},
+		{"$19.99", 19.99},
+		{"1,299", 1299},
+		{"1.299.000 ₴", 1299000},
+		{"1,299,000", 1299000},
+		{"1 299,90 zł", 1299.9},
+		{"CHF 49.–", 49},
+		{"0.125", 0.125},
+		{"$5", 5},
+		{"", 0},
+		{"free", 0},
+	}
+
+	for _, tt := range tests {
+		if got := ParsePrice(tt.price); got != tt.want {
+			t.Errorf("ParsePrice(%q) = %v, want %v", tt.price, got, tt.want)
+		}
+	}
+}
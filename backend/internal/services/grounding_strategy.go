@@ -2,6 +2,7 @@
 package services
 
 import (
+	"context"
 	"strings"
 
 	"mylittleprice/internal/config"
@@ -26,12 +27,13 @@ func NewGroundingStrategy(embedding *EmbeddingService, cfg *config.Config) *Grou
 }
 
 func (gs *GroundingStrategy) ShouldUseGrounding(
+	ctx context.Context,
 	userMessage string,
 	history []map[string]string,
 	category string,
 ) GroundingDecision {
 
-	queryEmbedding := gs.embedding.GetQueryEmbedding(userMessage)
+	queryEmbedding := gs.embedding.GetQueryEmbedding(ctx, userMessage)
 	if queryEmbedding == nil {
 		return GroundingDecision{
 			UseGrounding: false,
@@ -40,7 +42,7 @@ func (gs *GroundingStrategy) ShouldUseGrounding(
 		}
 	}
 
-	if gs.isBrandOnlyQuery(userMessage) {
+	if gs.isBrandOnlyQuery(ctx, userMessage) {
 		return GroundingDecision{
 			UseGrounding: true,
 			Confidence:   float32(gs.config.GeminiBrandQueryConfidence),
@@ -48,9 +50,9 @@ func (gs *GroundingStrategy) ShouldUseGrounding(
 		}
 	}
 
-	freshInfoScore := gs.calculateFreshInfoSimilarity(queryEmbedding)
-	specificProductScore := gs.calculateSpecificProductSimilarity(queryEmbedding)
-	dialogueDriftScore := gs.calculateDialogueDrift(queryEmbedding, history)
+	freshInfoScore := gs.calculateFreshInfoSimilarity(ctx, queryEmbedding)
+	specificProductScore := gs.calculateSpecificProductSimilarity(ctx, queryEmbedding)
+	dialogueDriftScore := gs.calculateDialogueDrift(ctx, queryEmbedding, history)
 	electronicsScore := gs.calculateCategorySimilarity(queryEmbedding, "electronics")
 
 	totalScore := (freshInfoScore * float32(gs.config.GeminiGroundingWeightFreshInfo)) +
@@ -68,7 +70,7 @@ func (gs *GroundingStrategy) ShouldUseGrounding(
 	}
 }
 
-func (gs *GroundingStrategy) isBrandOnlyQuery(userMessage string) bool {
+func (gs *GroundingStrategy) isBrandOnlyQuery(ctx context.Context, userMessage string) bool {
 	msgLower := strings.ToLower(strings.TrimSpace(userMessage))
 	words := strings.Fields(msgLower)
 
@@ -77,14 +79,16 @@ func (gs *GroundingStrategy) isBrandOnlyQuery(userMessage string) bool {
 	}
 
 	brandConcept := gs.embedding.GetQueryEmbedding(
+		ctx,
 		"samsung apple sony xiaomi lg dell hp lenovo asus oppo oneplus realme vivo popular electronics brand manufacturer company",
 	)
 
 	productConcept := gs.embedding.GetQueryEmbedding(
+		ctx,
 		"laptop phone tv computer monitor headphones product type category general question need want",
 	)
 
-	queryEmbedding := gs.embedding.GetQueryEmbedding(userMessage)
+	queryEmbedding := gs.embedding.GetQueryEmbedding(ctx, userMessage)
 
 	if brandConcept == nil || productConcept == nil || queryEmbedding == nil {
 		return false
@@ -96,7 +100,7 @@ func (gs *GroundingStrategy) isBrandOnlyQuery(userMessage string) bool {
 	return brandSimilarity > float32(gs.config.GeminiBrandSimilarityThresh) && brandSimilarity > productSimilarity
 }
 
-func (gs *GroundingStrategy) calculateFreshInfoSimilarity(queryEmbedding []float32) float32 {
+func (gs *GroundingStrategy) calculateFreshInfoSimilarity(ctx context.Context, queryEmbedding []float32) float32 {
 	freshInfoPatterns := []string{
 		"latest newest current recent 2024 2025 model new release updated",
 		"последний новый актуальный свежий модель релиз обновленный",
@@ -105,7 +109,7 @@ func (gs *GroundingStrategy) calculateFreshInfoSimilarity(queryEmbedding []float
 
 	maxSimilarity := float32(0.0)
 	for _, pattern := range freshInfoPatterns {
-		patternEmbedding := gs.embedding.GetQueryEmbedding(pattern)
+		patternEmbedding := gs.embedding.GetQueryEmbedding(ctx, pattern)
 		if patternEmbedding != nil {
 			similarity := cosineSimilarity(queryEmbedding, patternEmbedding)
 			if similarity > maxSimilarity {
@@ -117,7 +121,7 @@ func (gs *GroundingStrategy) calculateFreshInfoSimilarity(queryEmbedding []float
 	return maxSimilarity
 }
 
-func (gs *GroundingStrategy) calculateSpecificProductSimilarity(queryEmbedding []float32) float32 {
+func (gs *GroundingStrategy) calculateSpecificProductSimilarity(ctx context.Context, queryEmbedding []float32) float32 {
 	specificPatterns := []string{
 		"Samsung Galaxy S24 Ultra Apple iPhone 16 Pro Dell XPS 13 Sony TV LG OLED",
 		"specific model number brand name exact product full name",
@@ -126,7 +130,7 @@ func (gs *GroundingStrategy) calculateSpecificProductSimilarity(queryEmbedding [
 
 	maxSimilarity := float32(0.0)
 	for _, pattern := range specificPatterns {
-		patternEmbedding := gs.embedding.GetQueryEmbedding(pattern)
+		patternEmbedding := gs.embedding.GetQueryEmbedding(ctx, pattern)
 		if patternEmbedding != nil {
 			similarity := cosineSimilarity(queryEmbedding, patternEmbedding)
 			if similarity > maxSimilarity {
@@ -138,7 +142,7 @@ func (gs *GroundingStrategy) calculateSpecificProductSimilarity(queryEmbedding [
 	return maxSimilarity
 }
 
-func (gs *GroundingStrategy) calculateDialogueDrift(queryEmbedding []float32, history []map[string]string) float32 {
+func (gs *GroundingStrategy) calculateDialogueDrift(ctx context.Context, queryEmbedding []float32, history []map[string]string) float32 {
 	if len(history) < gs.config.GeminiDialogueHistoryWindow {
 		return 0.0
 	}
@@ -156,7 +160,7 @@ func (gs *GroundingStrategy) calculateDialogueDrift(queryEmbedding []float32, hi
 	}
 
 	combinedHistory := strings.Join(recentMessages, " ")
-	historyEmbedding := gs.embedding.GetQueryEmbedding(combinedHistory)
+	historyEmbedding := gs.embedding.GetQueryEmbedding(ctx, combinedHistory)
 
 	if historyEmbedding == nil {
 		return 0.0
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/useridentity"
+	"mylittleprice/internal/models"
+)
+
+// Linked identities: accounts at Google or a configured OpenID Connect
+// provider that a user can log in with. The Redis key
+// user:provider:<provider>:<subject> maps an identity to its user; the
+// user_identities table is the source of truth. Accounts created with
+// Google before identities existed only have users.google_id until their
+// next Google login.
+
+var (
+	ErrIdentityEmailInUse      = errors.New("an account with this email already exists")
+	ErrIdentityEmailUnverified = errors.New("the provider did not confirm the email address")
+	ErrIdentityLinked          = errors.New("identity is linked to another account")
+	ErrIdentityAlreadyLinked   = errors.New("an account of this provider is already linked")
+	ErrIdentityNotFound        = errors.New("linked identity not found")
+	ErrLastSignInMethod        = errors.New("cannot unlink the only way to sign in")
+)
+
+// OIDCLogin authenticates a user with an ID token of a configured provider
+// and returns tokens, or a challenge when the account has two-factor
+// authentication enabled. Unknown identities get a new account.
+func (s *AuthService) OIDCLogin(provider, idToken, nonce string, device *models.DeviceInfo) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	identity, err := s.verifyExternalIDToken(provider, idToken, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.loginWithIdentity(identity, device)
+}
+
+// ListIdentities returns the identities linked to the user, oldest first
+func (s *AuthService) ListIdentities(userID uuid.UUID) (*models.IdentitiesResponse, error) {
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	rows, err := s.client.UserIdentity.Query().
+		Where(useridentity.UserIDEQ(userID)).
+		Order(ent.Asc(useridentity.FieldCreatedAt)).
+		All(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	identities := make([]models.LinkedIdentity, len(rows))
+	for i, row := range rows {
+		identities[i] = *toLinkedIdentity(row)
+	}
+
+	return &models.IdentitiesResponse{
+		Identities:  identities,
+		HasPassword: userModel.PasswordHash != "",
+	}, nil
+}
+
+// LinkIdentity attaches the identity of an ID token to the user, who can
+// then log in with it. A user has at most one identity per provider.
+func (s *AuthService) LinkIdentity(userID uuid.UUID, provider, idToken, nonce string) (*models.LinkedIdentity, error) {
+	identity, err := s.verifyExternalIDToken(provider, idToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	owner, err := s.getUserByProviderID(identity.Provider, identity.Subject)
+	switch {
+	case err == nil && owner.ID == userID:
+		return nil, ErrIdentityAlreadyLinked
+	case err == nil:
+		return nil, ErrIdentityLinked
+	case !errors.Is(err, redis.Nil):
+		return nil, fmt.Errorf("failed to look up identity: %w", err)
+	}
+
+	if identity.Provider == "google" && userModel.ProviderID != "" {
+		return nil, ErrIdentityAlreadyLinked
+	}
+
+	row, err := s.saveIdentity(userModel, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🔗 Linked %s identity to user %s\n", identity.Provider, userID.String())
+	return toLinkedIdentity(row), nil
+}
+
+// UnlinkIdentity detaches an identity from the user. The last identity of
+// an account without a password cannot be unlinked.
+func (s *AuthService) UnlinkIdentity(userID, identityID uuid.UUID) (*models.LinkedIdentity, error) {
+	row, err := s.client.UserIdentity.Query().
+		Where(
+			useridentity.IDEQ(identityID),
+			useridentity.UserIDEQ(userID),
+		).
+		Only(s.ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	count, err := s.client.UserIdentity.Query().
+		Where(useridentity.UserIDEQ(userID)).
+		Count(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count identities: %w", err)
+	}
+	if count <= 1 && userModel.PasswordHash == "" {
+		return nil, ErrLastSignInMethod
+	}
+
+	if err := s.client.UserIdentity.DeleteOneID(row.ID).Exec(s.ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete identity: %w", err)
+	}
+	s.deleteIdentityMappings([]*ent.UserIdentity{row})
+
+	// Otherwise the Google login would still find the account
+	if row.Provider == "google" && userModel.ProviderID == row.Subject {
+		userModel.ProviderID = ""
+		userModel.UpdatedAt = time.Now()
+		if err := s.saveUser(userModel); err != nil {
+			return nil, fmt.Errorf("failed to clear Google ID: %w", err)
+		}
+	}
+
+	fmt.Printf("🔗 Unlinked %s identity from user %s\n", row.Provider, userID.String())
+	return toLinkedIdentity(row), nil
+}
+
+// ==================== Identity Helpers ====================
+
+// verifyExternalIDToken verifies an ID token of a configured provider.
+// Google works without OIDC configuration through its tokeninfo endpoint.
+func (s *AuthService) verifyExternalIDToken(provider, idToken, nonce string) (*models.ExternalIdentity, error) {
+	if s.oidc.HasProvider(provider) || provider != "google" {
+		return s.oidc.VerifyIDToken(s.ctx, provider, idToken, nonce)
+	}
+
+	googleUser, err := s.googleOAuth.VerifyIDToken(s.ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCInvalidToken, err)
+	}
+	return googleIdentity(googleUser), nil
+}
+
+// loginWithIdentity signs in the user an identity is linked to, creating
+// the account on first login
+func (s *AuthService) loginWithIdentity(identity *models.ExternalIdentity, device *models.DeviceInfo) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	userModel, err := s.getUserByProviderID(identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if errors.Is(err, redis.Nil) {
+		if userModel, err = s.createIdentityUser(identity); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		// Update profile info in case it changed, from the provider the
+		// account was created with only
+		if userModel.Provider == identity.Provider {
+			if identity.Picture != "" {
+				userModel.Picture = identity.Picture
+			}
+			if identity.Name != "" {
+				userModel.FullName = identity.Name
+			}
+			if identity.EmailVerified {
+				userModel.EmailVerified = true
+			}
+		}
+		if err := s.touchIdentity(userModel, identity); err != nil {
+			fmt.Printf("Warning: failed to update identity: %v\n", err)
+		}
+	}
+
+	// Second factor required - last login is updated when it is verified
+	challenge, err := s.startTwoFactorChallenge(userModel.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		if err := s.saveUser(userModel); err != nil {
+			fmt.Printf("Warning: failed to update user info: %v\n", err)
+		}
+		return nil, challenge, nil
+	}
+
+	// Update last login
+	now := time.Now()
+	userModel.LastLoginAt = &now
+	if err := s.saveUser(userModel); err != nil {
+		fmt.Printf("Warning: failed to update user info: %v\n", err)
+	}
+
+	// Generate tokens
+	authResp, err := s.generateAuthResponse(userModel, device)
+	return authResp, nil, err
+}
+
+// createIdentityUser creates the account of a first login. An existing
+// account with the same email is not taken over: its owner signs in and
+// links the identity instead.
+func (s *AuthService) createIdentityUser(identity *models.ExternalIdentity) (*models.User, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrIdentityEmailUnverified
+	}
+
+	_, err := s.getUserByEmail(identity.Email)
+	if err == nil {
+		return nil, ErrIdentityEmailInUse
+	}
+	if !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	now := time.Now()
+	userModel := &models.User{
+		ID:            uuid.New(),
+		Email:         identity.Email,
+		FullName:      identity.Name,
+		Picture:       identity.Picture,
+		Provider:      identity.Provider,
+		Role:          models.UserRoleUser,
+		Plan:          models.PlanFree,
+		EmailVerified: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if identity.Provider == "google" {
+		userModel.ProviderID = identity.Subject // users.google_id
+	}
+
+	if err := s.saveUser(userModel); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+	if _, err := s.saveIdentity(userModel, identity); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("👤 Created user %s with %s\n", userModel.ID.String(), identity.Provider)
+	return userModel, nil
+}
+
+// saveIdentity links an identity to a user in PostgreSQL and Redis
+func (s *AuthService) saveIdentity(userModel *models.User, identity *models.ExternalIdentity) (*ent.UserIdentity, error) {
+	builder := s.client.UserIdentity.Create().
+		SetUserID(userModel.ID).
+		SetProvider(identity.Provider).
+		SetSubject(identity.Subject).
+		SetLastUsedAt(time.Now())
+	if identity.Email != "" {
+		builder.SetEmail(identity.Email)
+	}
+
+	row, err := builder.Save(s.ctx)
+	if err != nil && isForeignKeyError(err) {
+		// User may exist only in Redis so far
+		if syncErr := s.SaveUserToPostgres(userModel); syncErr != nil {
+			return nil, fmt.Errorf("failed to sync user to PostgreSQL: %w", syncErr)
+		}
+		row, err = builder.Save(s.ctx)
+	}
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+
+	providerKey := fmt.Sprintf("user:provider:%s:%s", identity.Provider, identity.Subject)
+	if err := s.redis.Set(s.ctx, providerKey, userModel.ID.String(), 0).Err(); err != nil {
+		fmt.Printf("⚠️ Failed to cache identity mapping: %v\n", err)
+	}
+
+	return row, nil
+}
+
+// touchIdentity records a login with an identity. Accounts created with
+// Google before identities existed get their row here.
+func (s *AuthService) touchIdentity(userModel *models.User, identity *models.ExternalIdentity) error {
+	update := s.client.UserIdentity.Update().
+		Where(
+			useridentity.ProviderEQ(identity.Provider),
+			useridentity.SubjectEQ(identity.Subject),
+			useridentity.UserIDEQ(userModel.ID),
+		).
+		SetLastUsedAt(time.Now())
+	if identity.Email != "" {
+		update.SetEmail(identity.Email)
+	}
+
+	n, err := update.Save(s.ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = s.saveIdentity(userModel, identity)
+	}
+	return err
+}
+
+// getUserIDByIdentity looks an identity up in PostgreSQL
+func (s *AuthService) getUserIDByIdentity(provider, subject string) (uuid.UUID, error) {
+	row, err := s.client.UserIdentity.Query().
+		Where(
+			useridentity.ProviderEQ(provider),
+			useridentity.SubjectEQ(subject),
+		).
+		Only(s.ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return uuid.Nil, redis.Nil // Maintain consistent error type
+		}
+		return uuid.Nil, err
+	}
+	return row.UserID, nil
+}
+
+// deleteIdentityMappings removes the Redis mappings of identities
+func (s *AuthService) deleteIdentityMappings(identities []*ent.UserIdentity) {
+	if len(identities) == 0 {
+		return
+	}
+
+	keys := make([]string, len(identities))
+	for i, identity := range identities {
+		keys[i] = fmt.Sprintf("user:provider:%s:%s", identity.Provider, identity.Subject)
+	}
+	if err := s.redis.Del(s.ctx, keys...).Err(); err != nil {
+		fmt.Printf("⚠️ Failed to delete identity mappings: %v\n", err)
+	}
+}
+
+func googleIdentity(googleUser *models.GoogleUserInfo) *models.ExternalIdentity {
+	return &models.ExternalIdentity{
+		Provider:      "google",
+		Subject:       googleUser.Sub,
+		Email:         googleUser.Email,
+		EmailVerified: googleUser.EmailVerified,
+		Name:          googleUser.Name,
+		Picture:       googleUser.Picture,
+	}
+}
+
+func toLinkedIdentity(row *ent.UserIdentity) *models.LinkedIdentity {
+	return &models.LinkedIdentity{
+		ID:         row.ID,
+		Provider:   row.Provider,
+		Email:      row.Email,
+		CreatedAt:  row.CreatedAt,
+		LastUsedAt: row.LastUsedAt,
+	}
+}
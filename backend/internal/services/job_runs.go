@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/jobrun"
+	"mylittleprice/ent/predicate"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+// JobRunService keeps the run history of scheduled jobs in PostgreSQL. The
+// scheduler derives the next run of a job from it, so schedules survive
+// restarts and are shared by all instances.
+type JobRunService struct {
+	client *ent.Client
+	config *config.Config
+}
+
+func NewJobRunService(client *ent.Client, cfg *config.Config) *JobRunService {
+	return &JobRunService{client: client, config: cfg}
+}
+
+// Start records the start of a run
+func (s *JobRunService) Start(ctx context.Context, job, trigger, triggeredBy, instance string) (*models.JobRun, error) {
+	builder := s.client.JobRun.Create().
+		SetJob(job).
+		SetTrigger(trigger).
+		SetInstance(instance).
+		SetStatus(models.JobRunRunning)
+	if triggeredBy != "" {
+		builder.SetTriggeredBy(triggeredBy)
+	}
+
+	row, err := builder.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record start of job %s: %w", job, err)
+	}
+	return jobRunToModel(row), nil
+}
+
+// Finish records the outcome of a run
+func (s *JobRunService) Finish(ctx context.Context, run *models.JobRun, rowsAffected int64, runErr error) error {
+	now := time.Now()
+	run.FinishedAt = &now
+	run.DurationMS = now.Sub(run.StartedAt).Milliseconds()
+	run.RowsAffected = rowsAffected
+	run.Status = models.JobRunSucceeded
+	if runErr != nil {
+		run.Status = models.JobRunFailed
+		run.Error = runErr.Error()
+	}
+
+	err := s.client.JobRun.UpdateOneID(run.ID).
+		SetStatus(run.Status).
+		SetRowsAffected(rowsAffected).
+		SetError(run.Error).
+		SetDurationMs(run.DurationMS).
+		SetFinishedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record end of job %s: %w", run.Job, err)
+	}
+	return nil
+}
+
+// AbandonRunning marks runs of a job still in status running as abandoned.
+// Only called while holding the job's lock, when no run can be active.
+func (s *JobRunService) AbandonRunning(ctx context.Context, job string) (int, error) {
+	abandoned, err := s.client.JobRun.Update().
+		Where(jobrun.JobEQ(job), jobrun.StatusEQ(models.JobRunRunning)).
+		SetStatus(models.JobRunAbandoned).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to abandon runs of job %s: %w", job, err)
+	}
+	return abandoned, nil
+}
+
+// LastScheduled returns the last run of a job started by its schedule, nil
+// if it never ran
+func (s *JobRunService) LastScheduled(ctx context.Context, job string) (*models.JobRun, error) {
+	return s.last(ctx, jobrun.JobEQ(job), jobrun.TriggerEQ(models.JobTriggerSchedule))
+}
+
+// Last returns the last run of a job however it was started, nil if it
+// never ran
+func (s *JobRunService) Last(ctx context.Context, job string) (*models.JobRun, error) {
+	return s.last(ctx, jobrun.JobEQ(job))
+}
+
+func (s *JobRunService) last(ctx context.Context, predicates ...predicate.JobRun) (*models.JobRun, error) {
+	row, err := s.client.JobRun.Query().
+		Where(predicates...).
+		Order(ent.Desc(jobrun.FieldStartedAt)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load last run: %w", err)
+	}
+	return jobRunToModel(row), nil
+}
+
+// List returns the runs of a job, newest first
+func (s *JobRunService) List(ctx context.Context, job string, limit, offset int) (*models.JobRunsResponse, error) {
+	q := s.client.JobRun.Query().Where(jobrun.JobEQ(job))
+
+	total, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count runs of job %s: %w", job, err)
+	}
+
+	limit, offset = pageBounds(limit, offset)
+	rows, err := q.
+		Order(ent.Desc(jobrun.FieldStartedAt)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs of job %s: %w", job, err)
+	}
+
+	items := make([]models.JobRun, len(rows))
+	for i, row := range rows {
+		items[i] = *jobRunToModel(row)
+	}
+	return &models.JobRunsResponse{Items: items, Total: total}, nil
+}
+
+// DeleteExpired deletes runs older than the history retention
+func (s *JobRunService) DeleteExpired(ctx context.Context) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -s.config.JobHistoryRetentionDays)
+	deleted, err := s.client.JobRun.Delete().
+		Where(jobrun.StartedAtLT(cutoff), jobrun.StatusNEQ(models.JobRunRunning)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired job runs: %w", err)
+	}
+	return deleted, nil
+}
+
+func jobRunToModel(row *ent.JobRun) *models.JobRun {
+	return &models.JobRun{
+		ID:           row.ID,
+		Job:          row.Job,
+		Trigger:      row.Trigger,
+		TriggeredBy:  row.TriggeredBy,
+		Instance:     row.Instance,
+		Status:       row.Status,
+		RowsAffected: row.RowsAffected,
+		Error:        row.Error,
+		DurationMS:   row.DurationMs,
+		StartedAt:    row.StartedAt,
+		FinishedAt:   row.FinishedAt,
+	}
+}
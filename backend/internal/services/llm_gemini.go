@@ -0,0 +1,401 @@
+// backend/internal/services/llm_gemini.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genai"
+
+	"mylittleprice/internal/fixtures"
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+// GeminiProvider is the LLMProvider for the Gemini API. It owns the genai
+// client and rotates API keys on quota errors and when a key's circuit
+// opens.
+type GeminiProvider struct {
+	client          *genai.Client
+	keyRotator      *utils.KeyRotator
+	currentKeyIndex int             // Track current API key index
+	cassettes       *fixtures.Store // Offline fixtures (nil = live)
+	ctx             context.Context
+	mu              sync.RWMutex
+}
+
+func NewGeminiProvider(keyRotator *utils.KeyRotator, cassettes *fixtures.Store) (*GeminiProvider, error) {
+	ctx := context.Background()
+
+	apiKey, keyIndex, err := keyRotator.GetNextKey()
+	if err != nil {
+		// Replay mode never reaches the network, so no real key is needed
+		if !isReplayMode(cassettes) {
+			return nil, fmt.Errorf("failed to get initial API key: %w", err)
+		}
+		apiKey, keyIndex = fixtures.ReplayAPIKey, -1
+	}
+
+	client, err := NewGenAIClient(ctx, apiKey, cassettes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+
+	return &GeminiProvider{
+		client:          client,
+		keyRotator:      keyRotator,
+		currentKeyIndex: keyIndex,
+		cassettes:       cassettes,
+		ctx:             ctx,
+	}, nil
+}
+
+// NewGenAIClient creates a Gemini API client. When a cassette store is
+// given, requests are replayed from (or recorded to) disk.
+func NewGenAIClient(ctx context.Context, apiKey string, cassettes *fixtures.Store) (*genai.Client, error) {
+	clientConfig := &genai.ClientConfig{
+		APIKey:  apiKey,
+		Backend: genai.BackendGeminiAPI,
+	}
+	if cassettes != nil && cassettes.Mode() != fixtures.ModeOff {
+		clientConfig.HTTPClient = fixtures.NewHTTPClient(cassettes, "gemini")
+	}
+	return genai.NewClient(ctx, clientConfig)
+}
+
+func isReplayMode(cassettes *fixtures.Store) bool {
+	return cassettes != nil && cassettes.Mode() == fixtures.ModeReplay
+}
+
+func (p *GeminiProvider) Name() string {
+	return LLMProviderGemini
+}
+
+// Generate performs a Gemini API call with exponential backoff retries.
+// Quota errors rotate the API key before the next attempt.
+func (p *GeminiProvider) Generate(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
+	config := p.buildConfig(req)
+	contents := buildContents(req)
+
+	maxRetries := req.MaxRetries
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			// Exponential backoff: 1s, 2s, 4s, 8s...
+			backoffDuration := time.Duration(1<<uint(attempt-1)) * time.Second
+			fmt.Printf("⏳ Retry attempt %d/%d after %v...\n", attempt+1, maxRetries, backoffDuration)
+			time.Sleep(backoffDuration)
+		}
+
+		p.mu.RLock()
+		client := p.client
+		keyIndex := p.currentKeyIndex
+		p.mu.RUnlock()
+
+		// Execute API call with timeout context
+		spanCtx, span := startLLMSpan(ctx, "llm.generate", LLMProviderGemini, req.Model,
+			attribute.Int("llm.attempt", attempt+1),
+			attribute.Int("llm.key_index", keyIndex),
+		)
+		callCtx, cancel := context.WithTimeout(spanCtx, 30*time.Second)
+		startTime := time.Now()
+		resp, err := client.Models.GenerateContent(callCtx, req.Model, contents, config)
+		elapsed := time.Since(startTime)
+		cancel()
+		tracing.End(span, err)
+
+		if err == nil && resp != nil {
+			p.recordKeyUsage(ctx, keyIndex, nil, elapsed)
+			if attempt > 0 {
+				fmt.Printf("✅ Request succeeded on attempt %d/%d\n", attempt+1, maxRetries)
+			}
+			return toLLMResponse(resp)
+		}
+
+		if err == nil {
+			lastErr = fmt.Errorf("Gemini returned nil response")
+			continue
+		}
+		lastErr = err
+		errMsg := err.Error()
+		p.recordKeyUsage(ctx, keyIndex, err, elapsed)
+
+		// Quota/Rate limit errors - rotate key and retry
+		if isQuotaLLMError(errMsg) {
+			fmt.Printf("⚠️ Quota exceeded, rotating API key...\n")
+			if rotateErr := p.rotateClient(true); rotateErr != nil {
+				fmt.Printf("❌ Key rotation failed: %v\n", rotateErr)
+				// Continue to next retry anyway
+			}
+			continue
+		}
+
+		// Overload/503 and timeout errors - retry with backoff
+		if isRetryableLLMError(errMsg) {
+			fmt.Printf("⚠️ Gemini unavailable or timed out, will retry...\n")
+			continue
+		}
+
+		// Other errors - don't retry
+		fmt.Printf("❌ Non-retryable error: %v\n", err)
+		return nil, fmt.Errorf("Gemini API error: %w", err)
+	}
+
+	if maxRetries > 1 {
+		fmt.Printf("❌ All %d retry attempts failed\n", maxRetries)
+	}
+	return nil, fmt.Errorf("Gemini API failed after %d attempts: %w", maxRetries, lastErr)
+}
+
+// combinedToolsModelPrefixes are the Gemini models that accept Google
+// Search grounding next to function declarations. Older models reject the
+// combination with a 400.
+var combinedToolsModelPrefixes = []string{"gemini-3"}
+
+// GroundingWithToolsSupported reports whether a model can use Google Search
+// grounding in a request that also declares functions
+func GroundingWithToolsSupported(model string) bool {
+	model = strings.ToLower(strings.TrimPrefix(model, "models/"))
+	for _, prefix := range combinedToolsModelPrefixes {
+		if strings.HasPrefix(model, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *GeminiProvider) buildConfig(req *LLMRequest) *genai.GenerateContentConfig {
+	temp := req.Temperature
+	config := &genai.GenerateContentConfig{
+		Temperature:     &temp,
+		MaxOutputTokens: int32(req.MaxOutputTokens),
+	}
+
+	if len(req.Tools) > 0 {
+		config.Tools = []*genai.Tool{
+			{FunctionDeclarations: req.Tools},
+		}
+		// Left out for models that can't combine tools, e.g. when the
+		// dialogue falls back to an older model
+		if req.Grounding && GroundingWithToolsSupported(req.Model) {
+			config.Tools = append(config.Tools, &genai.Tool{GoogleSearch: &genai.GoogleSearch{}})
+		}
+		config.ToolConfig = &genai.ToolConfig{
+			FunctionCallingConfig: &genai.FunctionCallingConfig{
+				Mode:                 genai.FunctionCallingConfigModeAny,
+				AllowedFunctionNames: req.AllowedTools,
+			},
+		}
+		return config
+	}
+
+	if req.Grounding {
+		// When using grounding/tools, we CANNOT use ResponseSchema or ResponseMIMEType
+		// The API returns error: "Unsupported response mime type when response schema is set"
+		config.Tools = []*genai.Tool{
+			{GoogleSearch: &genai.GoogleSearch{}},
+		}
+		return config
+	}
+
+	if req.JSON || req.Schema != nil {
+		config.ResponseMIMEType = "application/json"
+		config.ResponseSchema = req.Schema
+	}
+	return config
+}
+
+// buildContents turns the prompt and previous tool turns into the
+// conversation: model turns hold the calls, user turns the results
+func buildContents(req *LLMRequest) []*genai.Content {
+	contents := genai.Text(req.Prompt)
+
+	for _, turn := range req.Turns {
+		calls := make([]*genai.Part, 0, len(turn.ToolCalls))
+		for _, call := range turn.ToolCalls {
+			calls = append(calls, &genai.Part{
+				FunctionCall: &genai.FunctionCall{
+					ID:   call.ID,
+					Name: call.Name,
+					Args: call.Args,
+				},
+				ThoughtSignature: call.Signature,
+			})
+		}
+		contents = append(contents, genai.NewContentFromParts(calls, genai.RoleModel))
+
+		results := make([]*genai.Part, 0, len(turn.ToolResults))
+		for _, result := range turn.ToolResults {
+			results = append(results, &genai.Part{
+				FunctionResponse: &genai.FunctionResponse{
+					ID:       result.CallID,
+					Name:     result.Name,
+					Response: result.Result,
+				},
+			})
+		}
+		contents = append(contents, genai.NewContentFromParts(results, genai.RoleUser))
+	}
+
+	return contents
+}
+
+func toLLMResponse(resp *genai.GenerateContentResponse) (*LLMResponse, error) {
+	result := &LLMResponse{Usage: toLLMUsage(resp.UsageMetadata)}
+
+	if len(resp.Candidates) == 0 {
+		return nil, fmt.Errorf("no candidates in Gemini response")
+	}
+
+	candidate := resp.Candidates[0]
+	result.FinishReason = string(candidate.FinishReason)
+	result.Truncated = candidate.FinishReason == genai.FinishReasonMaxTokens
+
+	if candidate.GroundingMetadata != nil {
+		for _, chunk := range candidate.GroundingMetadata.GroundingChunks {
+			if chunk.Web != nil {
+				result.GroundingSources = append(result.GroundingSources, LLMSource{
+					Title: chunk.Web.Title,
+					URI:   chunk.Web.URI,
+				})
+			}
+		}
+	}
+
+	// A truncated candidate may have no content - callers decide what to do
+	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
+		if result.Truncated {
+			return result, nil
+		}
+		return nil, fmt.Errorf("no content in Gemini response (finish reason: %v)", candidate.FinishReason)
+	}
+
+	for _, part := range candidate.Content.Parts {
+		if part.FunctionCall != nil {
+			result.ToolCalls = append(result.ToolCalls, LLMToolCall{
+				ID:        part.FunctionCall.ID,
+				Name:      part.FunctionCall.Name,
+				Args:      part.FunctionCall.Args,
+				Signature: part.ThoughtSignature,
+			})
+			continue
+		}
+		if part.Text != "" && !part.Thought {
+			result.Text += part.Text
+		}
+	}
+
+	return result, nil
+}
+
+func toLLMUsage(metadata *genai.GenerateContentResponseUsageMetadata) LLMUsage {
+	if metadata == nil {
+		return LLMUsage{}
+	}
+	usage := LLMUsage{
+		InputTokens: int(metadata.PromptTokenCount),
+		TotalTokens: int(metadata.TotalTokenCount),
+	}
+	if metadata.TotalTokenCount > 0 && metadata.PromptTokenCount > 0 {
+		usage.OutputTokens = int(metadata.TotalTokenCount - metadata.PromptTokenCount)
+	}
+	return usage
+}
+
+// Embed returns the embedding of a text. Quota errors rotate the key for
+// the next call; embeddings are best-effort so there is no retry loop.
+func (p *GeminiProvider) Embed(ctx context.Context, model, text string) ([]float32, error) {
+	p.mu.RLock()
+	client := p.client
+	keyIndex := p.currentKeyIndex
+	p.mu.RUnlock()
+
+	spanCtx, span := startLLMSpan(ctx, "llm.embed", LLMProviderGemini, model, attribute.Int("llm.key_index", keyIndex))
+	startTime := time.Now()
+	resp, err := client.Models.EmbedContent(spanCtx, model, genai.Text(text), nil)
+	tracing.End(span, err)
+	p.recordKeyUsage(ctx, keyIndex, err, time.Since(startTime))
+	if err != nil {
+		if isQuotaLLMError(err.Error()) {
+			if rotateErr := p.rotateClient(true); rotateErr != nil {
+				fmt.Printf("❌ Key rotation failed: %v\n", rotateErr)
+			}
+		}
+		return nil, fmt.Errorf("Gemini embedding error: %w", err)
+	}
+
+	if resp == nil || len(resp.Embeddings) == 0 {
+		return nil, fmt.Errorf("empty Gemini embedding response")
+	}
+	return resp.Embeddings[0].Values, nil
+}
+
+// recordKeyUsage feeds the outcome of a call into the key's health. Quota
+// errors are handled by rotating the key, and calls the caller cancelled
+// say nothing about the key. When failures open the key's circuit, the
+// client moves to another key.
+func (p *GeminiProvider) recordKeyUsage(ctx context.Context, keyIndex int, err error, elapsed time.Duration) {
+	if isReplayMode(p.cassettes) || ctx.Err() != nil {
+		return
+	}
+	if err != nil && isQuotaLLMError(err.Error()) {
+		return
+	}
+
+	if recordErr := p.keyRotator.RecordUsage(keyIndex, err == nil, elapsed); recordErr != nil {
+		fmt.Printf("   ⚠️ Failed to record Gemini key usage: %v\n", recordErr)
+		return
+	}
+
+	if err != nil && !p.keyRotator.IsKeyAvailable(keyIndex) {
+		p.mu.RLock()
+		stillCurrent := p.currentKeyIndex == keyIndex
+		p.mu.RUnlock()
+		if stillCurrent {
+			if rotateErr := p.rotateClient(false); rotateErr != nil {
+				fmt.Printf("❌ Key rotation failed: %v\n", rotateErr)
+			}
+		}
+	}
+}
+
+func (p *GeminiProvider) rotateClient(markCurrentAsExhausted bool) error {
+	// Cassette replays don't depend on the key, rotating would only fail
+	if isReplayMode(p.cassettes) {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Mark current key as exhausted if requested
+	if markCurrentAsExhausted {
+		fmt.Printf("   ⚠️ Marking Gemini key %d as exhausted\n", p.currentKeyIndex)
+		if err := p.keyRotator.MarkKeyAsExhausted(p.currentKeyIndex); err != nil {
+			fmt.Printf("   ⚠️ Failed to mark key as exhausted: %v\n", err)
+		}
+	}
+
+	apiKey, keyIndex, err := p.keyRotator.GetNextKey()
+	if err != nil {
+		return fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	client, err := NewGenAIClient(p.ctx, apiKey, p.cassettes)
+	if err != nil {
+		return fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+
+	p.client = client
+	p.currentKeyIndex = keyIndex
+	fmt.Printf("   🔄 Gemini API key rotated to key %d\n", keyIndex)
+	return nil
+}
@@ -0,0 +1,379 @@
+// backend/internal/services/llm_openai.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genai"
+
+	"mylittleprice/internal/fixtures"
+	"mylittleprice/internal/tracing"
+)
+
+// OpenAIProvider is an LLMProvider for OpenAI-compatible HTTP APIs
+// (/chat/completions and /embeddings), e.g. vLLM, llama.cpp server or
+// Ollama. Grounding is not supported and is ignored. Tool calls need a
+// server with function calling enabled.
+type OpenAIProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewOpenAIProvider(baseURL, apiKey string, cassettes *fixtures.Store) *OpenAIProvider {
+	client := &http.Client{Timeout: 60 * time.Second}
+	if cassettes != nil && cassettes.Mode() != fixtures.ModeOff {
+		client = fixtures.NewHTTPClient(cassettes, "openai")
+		client.Timeout = 60 * time.Second
+	}
+
+	return &OpenAIProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+func (p *OpenAIProvider) Name() string {
+	return LLMProviderOpenAI
+}
+
+type openAIChatRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []openAIMessage        `json:"messages"`
+	Temperature    float32                `json:"temperature"`
+	MaxTokens      int                    `json:"max_tokens,omitempty"`
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+	Tools          []openAITool           `json:"tools,omitempty"`
+	ToolChoice     string                 `json:"tool_choice,omitempty"`
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAITool struct {
+	Type     string             `json:"type"` // Always "function"
+	Function openAIFunctionSpec `json:"function"`
+}
+
+type openAIFunctionSpec struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"` // JSON encoded
+	} `json:"function"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// openAIStatusError is a non-200 response from the API
+type openAIStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *openAIStatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Generate calls /chat/completions, retrying rate limits and server errors
+// with exponential backoff
+func (p *OpenAIProvider) Generate(ctx context.Context, req *LLMRequest) (*LLMResponse, error) {
+	messages, err := openAIMessages(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body := openAIChatRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxOutputTokens,
+	}
+
+	switch {
+	case len(req.Tools) > 0:
+		body.Tools = openAITools(req.Tools, req.AllowedTools)
+		body.ToolChoice = "required"
+	case req.Schema != nil:
+		body.ResponseFormat = map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   "response",
+				"schema": schemaToJSONSchema(req.Schema),
+			},
+		}
+	case req.JSON:
+		body.ResponseFormat = map[string]interface{}{"type": "json_object"}
+	}
+
+	maxRetries := req.MaxRetries
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoffDuration := time.Duration(1<<uint(attempt-1)) * time.Second
+			fmt.Printf("⏳ Retry attempt %d/%d after %v...\n", attempt+1, maxRetries, backoffDuration)
+			time.Sleep(backoffDuration)
+		}
+
+		var resp openAIChatResponse
+		spanCtx, span := startLLMSpan(ctx, "llm.generate", LLMProviderOpenAI, req.Model, attribute.Int("llm.attempt", attempt+1))
+		err := p.post(spanCtx, "/chat/completions", body, &resp)
+		tracing.End(span, err)
+		if err == nil {
+			return openAIToLLMResponse(&resp)
+		}
+
+		lastErr = err
+
+		// Rate limits and server errors are retried, other API errors are not
+		retryable := isRetryableLLMError(err.Error())
+		var statusErr *openAIStatusError
+		if errors.As(err, &statusErr) {
+			retryable = statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+		}
+		if !retryable {
+			return nil, fmt.Errorf("OpenAI-compatible API error: %w", err)
+		}
+		fmt.Printf("⚠️ OpenAI-compatible API error, will retry: %v\n", err)
+	}
+
+	return nil, fmt.Errorf("OpenAI-compatible API failed after %d attempts: %w", maxRetries, lastErr)
+}
+
+// openAIMessages builds the chat: the prompt, then one assistant message
+// with the tool calls and one tool message per result for every turn
+func openAIMessages(req *LLMRequest) ([]openAIMessage, error) {
+	messages := []openAIMessage{{Role: "user", Content: req.Prompt}}
+
+	for _, turn := range req.Turns {
+		assistant := openAIMessage{Role: "assistant"}
+		for _, call := range turn.ToolCalls {
+			args, err := json.Marshal(call.Args)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s arguments: %w", call.Name, err)
+			}
+
+			var toolCall openAIToolCall
+			toolCall.ID = call.ID
+			toolCall.Type = "function"
+			toolCall.Function.Name = call.Name
+			toolCall.Function.Arguments = string(args)
+			assistant.ToolCalls = append(assistant.ToolCalls, toolCall)
+		}
+		messages = append(messages, assistant)
+
+		for _, result := range turn.ToolResults {
+			content, err := json.Marshal(result.Result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s result: %w", result.Name, err)
+			}
+			messages = append(messages, openAIMessage{
+				Role:       "tool",
+				Content:    string(content),
+				ToolCallID: result.CallID,
+			})
+		}
+	}
+
+	return messages, nil
+}
+
+func openAITools(declarations []*genai.FunctionDeclaration, allowed []string) []openAITool {
+	tools := make([]openAITool, 0, len(declarations))
+	for _, declaration := range declarations {
+		if len(allowed) > 0 && !slices.Contains(allowed, declaration.Name) {
+			continue
+		}
+		tools = append(tools, openAITool{
+			Type: "function",
+			Function: openAIFunctionSpec{
+				Name:        declaration.Name,
+				Description: declaration.Description,
+				Parameters:  schemaToJSONSchema(declaration.Parameters),
+			},
+		})
+	}
+	return tools
+}
+
+func openAIToLLMResponse(resp *openAIChatResponse) (*LLMResponse, error) {
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in OpenAI-compatible response")
+	}
+
+	choice := resp.Choices[0]
+	result := &LLMResponse{
+		Text:         choice.Message.Content,
+		Truncated:    choice.FinishReason == "length",
+		FinishReason: choice.FinishReason,
+		Usage: LLMUsage{
+			InputTokens:  resp.Usage.PromptTokens,
+			OutputTokens: resp.Usage.CompletionTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	}
+
+	for _, toolCall := range choice.Message.ToolCalls {
+		var args map[string]interface{}
+		if toolCall.Function.Arguments != "" {
+			if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
+				return nil, fmt.Errorf("invalid %s arguments in OpenAI-compatible response: %w", toolCall.Function.Name, err)
+			}
+		}
+		result.ToolCalls = append(result.ToolCalls, LLMToolCall{
+			ID:   toolCall.ID,
+			Name: toolCall.Function.Name,
+			Args: args,
+		})
+	}
+
+	return result, nil
+}
+
+// Embed calls /embeddings
+func (p *OpenAIProvider) Embed(ctx context.Context, model, text string) ([]float32, error) {
+	body := map[string]interface{}{
+		"model": model,
+		"input": text,
+	}
+
+	var resp openAIEmbeddingResponse
+	spanCtx, span := startLLMSpan(ctx, "llm.embed", LLMProviderOpenAI, model)
+	err := p.post(spanCtx, "/embeddings", body, &resp)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI-compatible embedding error: %w", err)
+	}
+
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("empty OpenAI-compatible embedding response")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+func (p *OpenAIProvider) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body := string(data)
+		if len(body) > 300 {
+			body = body[:300]
+		}
+		return &openAIStatusError{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// schemaToJSONSchema translates a genai schema into standard JSON Schema
+func schemaToJSONSchema(schema *genai.Schema) map[string]interface{} {
+	if schema == nil {
+		return nil
+	}
+
+	out := map[string]interface{}{}
+
+	if schema.Type != "" {
+		schemaType := strings.ToLower(string(schema.Type))
+		if schema.Nullable != nil && *schema.Nullable {
+			out["type"] = []string{schemaType, "null"}
+		} else {
+			out["type"] = schemaType
+		}
+	}
+	if schema.Description != "" {
+		out["description"] = schema.Description
+	}
+	if len(schema.Enum) > 0 {
+		out["enum"] = schema.Enum
+	}
+	if len(schema.Required) > 0 {
+		out["required"] = schema.Required
+	}
+	if schema.MinItems != nil {
+		out["minItems"] = *schema.MinItems
+	}
+	if schema.MaxItems != nil {
+		out["maxItems"] = *schema.MaxItems
+	}
+	if schema.Items != nil {
+		out["items"] = schemaToJSONSchema(schema.Items)
+	}
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]interface{}, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = schemaToJSONSchema(property)
+		}
+		out["properties"] = properties
+	}
+
+	return out
+}
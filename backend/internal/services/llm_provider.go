@@ -0,0 +1,422 @@
+// backend/internal/services/llm_provider.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genai"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/fixtures"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+// LLM tasks - each task can be routed to its own provider via config
+const (
+	LLMTaskDialogue    = "dialogue"    // Main chat turn (universal prompt), comparison verdicts
+	LLMTaskExtraction  = "extraction"  // Preferences and conversation summaries
+	LLMTaskTranslation = "translation" // Search query translation
+	LLMTaskEmbedding   = "embedding"   // Category detection, semantic cache
+)
+
+const (
+	LLMProviderGemini = "gemini"
+	LLMProviderOpenAI = "openai"
+)
+
+// LLMProvider is a text generation and embedding backend. Providers own
+// their transport concerns (API keys, rotation, retries); callers only
+// describe what they need.
+type LLMProvider interface {
+	// Name is the registry identifier (e.g. "gemini")
+	Name() string
+
+	// Generate runs a single prompt and returns the text of the first candidate
+	Generate(ctx context.Context, req *LLMRequest) (*LLMResponse, error)
+
+	// Embed returns the embedding vector of a text
+	Embed(ctx context.Context, model, text string) ([]float32, error)
+}
+
+// LLMRequest describes a generation call
+type LLMRequest struct {
+	Model           string
+	Prompt          string
+	Temperature     float32
+	MaxOutputTokens int
+
+	// JSON asks for a JSON-only response; Schema additionally constrains it.
+	// Schema uses the genai schema types as the description language -
+	// other providers translate it to their own format.
+	JSON   bool
+	Schema *genai.Schema
+
+	// Grounding enables web search grounding where the provider supports it.
+	// Providers that can't combine grounding with JSON mode ignore JSON/Schema.
+	Grounding bool
+
+	// Tools are functions the model must call instead of answering with
+	// text; JSON/Schema are ignored when set. AllowedTools restricts the
+	// call to some of them (empty = any).
+	Tools        []*genai.FunctionDeclaration
+	AllowedTools []string
+
+	// Turns continue a tool conversation after Prompt: the calls the model
+	// made and the results returned for them, oldest first
+	Turns []LLMTurn
+
+	// MaxRetries is the number of attempts for retryable errors (default 1)
+	MaxRetries int
+
+	// Purpose labels the call in cost records (default: the task)
+	Purpose string
+}
+
+// LLMResponse is the provider-neutral result of a generation call
+type LLMResponse struct {
+	Text             string
+	Truncated        bool   // Output hit MaxOutputTokens
+	FinishReason     string // Provider-specific, for logging
+	Usage            LLMUsage
+	GroundingSources []LLMSource
+	ToolCalls        []LLMToolCall // Only when LLMRequest.Tools is set
+}
+
+// LLMToolCall is a function call requested by the model
+type LLMToolCall struct {
+	ID   string // Provider call ID, empty if the provider doesn't use them
+	Name string
+	Args map[string]interface{}
+
+	// Signature is Gemini's thought signature; it has to be sent back with
+	// the call in the next turn
+	Signature []byte
+}
+
+// DecodeArgs decodes the call arguments into v
+func (c *LLMToolCall) DecodeArgs(v interface{}) error {
+	data, err := json.Marshal(c.Args)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid %s arguments: %w", c.Name, err)
+	}
+	return nil
+}
+
+// LLMToolResult is the outcome of a tool call, sent back to the model
+type LLMToolResult struct {
+	CallID string
+	Name   string
+	Result map[string]interface{}
+}
+
+// LLMTurn is one round of a tool conversation
+type LLMTurn struct {
+	ToolCalls   []LLMToolCall
+	ToolResults []LLMToolResult
+}
+
+// LLMUsage is token usage reported by the provider (zero if unknown)
+type LLMUsage struct {
+	InputTokens  int
+	OutputTokens int
+	TotalTokens  int
+}
+
+// LLMSource is a web source used for grounding
+type LLMSource struct {
+	Title string
+	URI   string
+}
+
+// LLMRoute is the provider and models configured for one task
+type LLMRoute struct {
+	Provider      string `json:"provider"`
+	Model         string `json:"model"`
+	FallbackModel string `json:"fallback_model,omitempty"` // Tried when Model fails (same provider)
+}
+
+// LLMRoutesFromConfig builds task routes from config. Models follow the
+// provider: Gemini tasks keep the GEMINI_* models, OpenAI-compatible tasks
+// use OPENAI_*.
+func LLMRoutesFromConfig(cfg *config.Config) map[string]LLMRoute {
+	route := func(provider, geminiModel, geminiFallback, openAIModel string) LLMRoute {
+		provider = strings.ToLower(provider)
+		if provider == LLMProviderOpenAI {
+			return LLMRoute{Provider: provider, Model: openAIModel}
+		}
+		return LLMRoute{Provider: provider, Model: geminiModel, FallbackModel: geminiFallback}
+	}
+
+	return map[string]LLMRoute{
+		LLMTaskDialogue:    route(cfg.LLMDialogueProvider, cfg.GeminiModel, cfg.GeminiFallbackModel, cfg.OpenAIModel),
+		LLMTaskExtraction:  route(cfg.LLMExtractionProvider, cfg.GeminiFallbackModel, "", cfg.OpenAIModel), // Lightweight model is enough
+		LLMTaskTranslation: route(cfg.LLMTranslationProvider, cfg.GeminiModel, cfg.GeminiFallbackModel, cfg.OpenAIModel),
+		LLMTaskEmbedding:   route(cfg.LLMEmbeddingProvider, cfg.GeminiEmbeddingModel, "", cfg.OpenAIEmbeddingModel),
+	}
+}
+
+// NewLLMRegistryFromConfig builds the registry with the providers used by
+// at least one task and checks every task is routed to one of them
+func NewLLMRegistryFromConfig(cfg *config.Config, geminiKeys *utils.KeyRotator, cassettes *fixtures.Store) (*LLMRegistry, error) {
+	registry := NewLLMRegistry(LLMRoutesFromConfig(cfg))
+
+	if cfg.UsesLLMProvider(LLMProviderGemini) {
+		gemini, err := NewGeminiProvider(geminiKeys, cassettes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Gemini provider: %w", err)
+		}
+		registry.Register(gemini)
+	}
+
+	if cfg.UsesLLMProvider(LLMProviderOpenAI) {
+		registry.Register(NewOpenAIProvider(cfg.OpenAIBaseURL, cfg.OpenAIAPIKey, cassettes))
+	}
+
+	if err := registry.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to configure LLM providers: %w", err)
+	}
+	return registry, nil
+}
+
+// LLMRegistry holds the registered providers and selects one per task
+type LLMRegistry struct {
+	providers map[string]LLMProvider
+	routes    map[string]LLMRoute
+	costs     *CostService // Records paid calls, nil if not set
+	mu        sync.RWMutex
+}
+
+func NewLLMRegistry(routes map[string]LLMRoute) *LLMRegistry {
+	normalized := make(map[string]LLMRoute, len(routes))
+	for task, route := range routes {
+		route.Provider = strings.ToLower(route.Provider)
+		normalized[task] = route
+	}
+
+	return &LLMRegistry{
+		providers: make(map[string]LLMProvider),
+		routes:    normalized,
+	}
+}
+
+// Register adds (or replaces) a provider under its Name()
+func (r *LLMRegistry) Register(provider LLMProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[strings.ToLower(provider.Name())] = provider
+}
+
+// SetCostRecorder records every successful call of the registry's helpers
+// with costs
+func (r *LLMRegistry) SetCostRecorder(costs *CostService) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.costs = costs
+}
+
+// recordCost records a successful generation on a route's provider
+func (r *LLMRegistry) recordCost(ctx context.Context, route LLMRoute, purpose string, req *LLMRequest, resp *LLMResponse) {
+	r.mu.RLock()
+	costs := r.costs
+	r.mu.RUnlock()
+	costs.RecordLLMCall(ctx, route.Provider, req.Model, purpose, resp.Usage, req.Grounding)
+}
+
+// costPurpose is the purpose a task's request is recorded under
+func costPurpose(task string, req *LLMRequest) string {
+	if req.Purpose != "" {
+		return req.Purpose
+	}
+	return task
+}
+
+// Get returns a provider by name
+func (r *LLMRegistry) Get(name string) (LLMProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[strings.ToLower(name)]
+	return provider, ok
+}
+
+// ForTask returns the provider and route configured for a task
+func (r *LLMRegistry) ForTask(task string) (LLMProvider, LLMRoute, error) {
+	r.mu.RLock()
+	route, ok := r.routes[task]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, LLMRoute{}, fmt.Errorf("no LLM route configured for task %q", task)
+	}
+
+	provider, ok := r.Get(route.Provider)
+	if !ok {
+		return nil, route, fmt.Errorf("LLM provider %q for task %q is not registered", route.Provider, task)
+	}
+	return provider, route, nil
+}
+
+// Validate checks that every routed task has a registered provider
+func (r *LLMRegistry) Validate() error {
+	r.mu.RLock()
+	tasks := make([]string, 0, len(r.routes))
+	for task := range r.routes {
+		tasks = append(tasks, task)
+	}
+	r.mu.RUnlock()
+	sort.Strings(tasks)
+
+	for _, task := range tasks {
+		if _, _, err := r.ForTask(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names returns registered provider names in sorted order
+func (r *LLMRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.names()
+}
+
+// names lists the providers; the caller holds r.mu. RWMutex read locks
+// must not be taken recursively.
+func (r *LLMRegistry) names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetStats returns registry configuration for the stats endpoints
+func (r *LLMRegistry) GetStats() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routes := make(map[string]LLMRoute, len(r.routes))
+	for task, route := range r.routes {
+		routes[task] = route
+	}
+
+	return map[string]interface{}{
+		"providers": r.names(),
+		"routes":    routes,
+	}
+}
+
+// generateWithFallback runs req on the task's model and, if that fails,
+// once more on the route's fallback model
+func (r *LLMRegistry) generateWithFallback(ctx context.Context, task string, req *LLMRequest, fallbackRetries int) (*LLMResponse, error) {
+	provider, route, err := r.ForTask(task)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Model = route.Model
+	resp, err := provider.Generate(ctx, req)
+	if err == nil {
+		r.recordCost(ctx, route, costPurpose(task, req), req, resp)
+	}
+	if err == nil || route.FallbackModel == "" || route.FallbackModel == route.Model {
+		return resp, err
+	}
+
+	fmt.Printf("⚠️ %s model (%s) failed, trying fallback model (%s)\n", task, route.Model, route.FallbackModel)
+
+	fallbackReq := *req
+	fallbackReq.Model = route.FallbackModel
+	fallbackReq.MaxRetries = fallbackRetries
+	resp, fallbackErr := provider.Generate(ctx, &fallbackReq)
+	if fallbackErr != nil {
+		fmt.Printf("❌ Fallback model also failed: %v\n", fallbackErr)
+		return nil, fmt.Errorf("both primary and fallback models failed: %w", fallbackErr)
+	}
+	r.recordCost(ctx, route, models.CostPurposeFallback, &fallbackReq, resp)
+
+	fmt.Printf("✅ Fallback model succeeded\n")
+	return resp, nil
+}
+
+// generate runs req once on the task's model
+func (r *LLMRegistry) generate(ctx context.Context, task string, req *LLMRequest) (*LLMResponse, error) {
+	provider, route, err := r.ForTask(task)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Model = route.Model
+	resp, err := provider.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r.recordCost(ctx, route, costPurpose(task, req), req, resp)
+	return resp, nil
+}
+
+// embed embeds text with the provider configured for embeddings
+func (r *LLMRegistry) embed(ctx context.Context, text string) ([]float32, error) {
+	provider, route, err := r.ForTask(LLMTaskEmbedding)
+	if err != nil {
+		return nil, err
+	}
+
+	embedding, err := provider.Embed(ctx, route.Model, text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Embedding responses carry no usage, so input tokens are estimated
+	r.mu.RLock()
+	costs := r.costs
+	r.mu.RUnlock()
+	costs.RecordLLMCall(ctx, route.Provider, route.Model, models.CostPurposeEmbedding, LLMUsage{InputTokens: estimateTokens(text)}, false)
+	return embedding, nil
+}
+
+// startLLMSpan starts the span of one call to a provider. Calls made
+// outside a traced request don't start traces of their own.
+func startLLMSpan(ctx context.Context, name, provider, model string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("gen_ai.system", provider),
+		attribute.String("gen_ai.request.model", model),
+	)
+	return tracing.StartChild(ctx, name, trace.SpanKindClient, attrs...)
+}
+
+// isRetryableLLMError classifies errors worth retrying (overload, timeouts)
+func isRetryableLLMError(errMsg string) bool {
+	return strings.Contains(errMsg, "503") ||
+		strings.Contains(errMsg, "UNAVAILABLE") ||
+		strings.Contains(errMsg, "overloaded") ||
+		strings.Contains(errMsg, "timeout") ||
+		strings.Contains(errMsg, "deadline exceeded")
+}
+
+// isQuotaLLMError classifies quota / rate limit errors
+func isQuotaLLMError(errMsg string) bool {
+	return strings.Contains(errMsg, "quota") ||
+		strings.Contains(errMsg, "429") ||
+		strings.Contains(errMsg, "RESOURCE_EXHAUSTED")
+}
+
+// cleanJSONText strips markdown code fences from JSON model output
+func cleanJSONText(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.Trim(text, "`")
+	text = strings.TrimPrefix(text, "json")
+	return strings.TrimSpace(text)
+}
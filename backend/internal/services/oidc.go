@@ -0,0 +1,463 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+const (
+	oidcDiscoveryTTL     = 24 * time.Hour
+	oidcDefaultJWKSTTL   = time.Hour
+	oidcMinJWKSTTL       = 5 * time.Minute
+	oidcJWKSRefetchDelay = time.Minute // Unknown key IDs refetch the JWKS at most this often
+	oidcClockSkew        = time.Minute
+	oidcMaxResponseSize  = 1 << 20
+
+	// Microsoft's multi-tenant discovery document lists this placeholder
+	// instead of a tenant ID; tokens carry the tenant in the "tid" claim
+	oidcTenantPlaceholder = "{tenantid}"
+)
+
+var (
+	ErrOIDCUnknownProvider     = errors.New("unknown identity provider")
+	ErrOIDCInvalidToken        = errors.New("invalid ID token")
+	ErrOIDCProviderUnavailable = errors.New("identity provider unavailable")
+)
+
+// oidcSigningMethods are the asymmetric algorithms accepted for ID tokens.
+// HMAC would need the client secret and "none" is never acceptable.
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// OIDCService validates ID tokens of the configured OpenID Connect
+// providers. Discovery documents and signing keys are fetched on first use
+// and cached.
+type OIDCService struct {
+	providers map[string]*oidcProvider
+	names     []string
+	client    *http.Client
+}
+
+type oidcProvider struct {
+	config config.OIDCProviderConfig
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	discoveredAt  time.Time
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+	keysExpireAt  time.Time
+}
+
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// oidcClaims are the ID token claims used for login
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty string    `json:"azp,omitempty"`
+	Nonce           string    `json:"nonce,omitempty"`
+	Email           string    `json:"email,omitempty"`
+	EmailVerified   claimBool `json:"email_verified,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	GivenName       string    `json:"given_name,omitempty"`
+	FamilyName      string    `json:"family_name,omitempty"`
+	Picture         string    `json:"picture,omitempty"`
+	TenantID        string    `json:"tid,omitempty"`
+}
+
+// claimBool accepts true and "true": Apple sends booleans as strings
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	*b = claimBool(value == "true")
+	return nil
+}
+
+func NewOIDCService(cfg *config.Config) *OIDCService {
+	s := &OIDCService{
+		providers: make(map[string]*oidcProvider, len(cfg.OIDCProviders)),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+	for _, providerConfig := range cfg.OIDCProviders {
+		s.providers[providerConfig.Name] = &oidcProvider{config: providerConfig}
+		s.names = append(s.names, providerConfig.Name)
+	}
+	sort.Strings(s.names)
+	return s
+}
+
+// HasProvider reports whether a provider is configured
+func (s *OIDCService) HasProvider(name string) bool {
+	_, ok := s.providers[name]
+	return ok
+}
+
+// Providers lists the configured providers for login buttons
+func (s *OIDCService) Providers() []models.OIDCProviderInfo {
+	providers := make([]models.OIDCProviderInfo, 0, len(s.names))
+	for _, name := range s.names {
+		providerConfig := s.providers[name].config
+		providers = append(providers, models.OIDCProviderInfo{
+			Name:        providerConfig.Name,
+			DisplayName: providerConfig.DisplayName,
+			Issuer:      providerConfig.Issuer,
+			ClientID:    providerConfig.ClientIDs[0],
+		})
+	}
+	return providers
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of
+// an ID token and returns the identity it asserts. nonce must match the
+// token's nonce claim when either is set.
+func (s *OIDCService) VerifyIDToken(ctx context.Context, providerName, idToken, nonce string) (*models.ExternalIdentity, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrOIDCUnknownProvider
+	}
+	if err := validateIDToken(idToken); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCInvalidToken, err)
+	}
+
+	discovery, err := s.getDiscovery(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyErr error
+	claims := &oidcClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims,
+		func(token *jwt.Token) (any, error) {
+			key, err := s.getSigningKey(ctx, p, discovery, token)
+			keyErr = err
+			return key, err
+		},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithAudience(p.config.ClientIDs...),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if errors.Is(keyErr, ErrOIDCProviderUnavailable) {
+		return nil, keyErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCInvalidToken, err)
+	}
+
+	if !issuerMatches(discovery.Issuer, claims) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrOIDCInvalidToken, claims.Issuer)
+	}
+	// With several audiences the token must have been issued to us
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != "" && !containsString(p.config.ClientIDs, claims.AuthorizedParty) {
+		return nil, fmt.Errorf("%w: token was issued to %q", ErrOIDCInvalidToken, claims.AuthorizedParty)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCInvalidToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrOIDCInvalidToken)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = strings.TrimSpace(claims.GivenName + " " + claims.FamilyName)
+	}
+
+	return &models.ExternalIdentity{
+		Provider:      p.config.Name,
+		Subject:       claims.Subject,
+		Email:         strings.TrimSpace(claims.Email),
+		EmailVerified: claims.Email != "" && (bool(claims.EmailVerified) || p.config.TrustEmail),
+		Name:          name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// getDiscovery returns the cached discovery document, refreshing it once a
+// day. A stale document is kept while the provider is unreachable.
+func (s *OIDCService) getDiscovery(ctx context.Context, p *oidcProvider) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && time.Since(p.discoveredAt) < oidcDiscoveryTTL {
+		return p.discovery, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	var discovery oidcDiscovery
+	if _, err := s.fetchJSON(ctx, discoveryURL, &discovery); err != nil {
+		if p.discovery != nil {
+			fmt.Printf("⚠️ Failed to refresh OIDC discovery of %s, keeping the cached one: %v\n", p.config.Name, err)
+			return p.discovery, nil
+		}
+		return nil, fmt.Errorf("%w: %s discovery: %v", ErrOIDCProviderUnavailable, p.config.Name, err)
+	}
+
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: %s discovery has no jwks_uri", ErrOIDCProviderUnavailable, p.config.Name)
+	}
+	if discovery.Issuer != p.config.Issuer && !strings.Contains(discovery.Issuer, oidcTenantPlaceholder) {
+		return nil, fmt.Errorf("%w: %s discovery issuer %q does not match %q",
+			ErrOIDCProviderUnavailable, p.config.Name, discovery.Issuer, p.config.Issuer)
+	}
+
+	p.discovery = &discovery
+	p.discoveredAt = time.Now()
+	fmt.Printf("🔐 Loaded OIDC discovery for %s (%s)\n", p.config.Name, discovery.Issuer)
+	return p.discovery, nil
+}
+
+// getSigningKey finds the key a token was signed with. Providers rotate
+// keys, so an unknown key ID refetches the JWKS, at most once a minute.
+func (s *OIDCService) getSigningKey(ctx context.Context, p *oidcProvider, discovery *oidcDiscovery, token *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	key, found := p.lookupKey(kid)
+	stale := now.After(p.keysExpireAt)
+	if (!found || stale) && (p.keys == nil || now.Sub(p.keysFetchedAt) > oidcJWKSRefetchDelay) {
+		if err := s.refreshKeys(ctx, p, discovery.JWKSURI); err != nil {
+			if p.keys == nil {
+				return nil, err
+			}
+			fmt.Printf("⚠️ Failed to refresh OIDC keys of %s, keeping the cached ones: %v\n", p.config.Name, err)
+		}
+		key, found = p.lookupKey(kid)
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	// The key type must fit the algorithm
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+			return key, nil
+		}
+		if _, ok := token.Method.(*jwt.SigningMethodRSAPSS); ok {
+			return key, nil
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing key %q does not fit algorithm %s", kid, token.Method.Alg())
+}
+
+// lookupKey finds a key by ID. Tokens without a key ID are accepted when
+// the provider publishes a single key.
+func (p *oidcProvider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (s *OIDCService) refreshKeys(ctx context.Context, p *oidcProvider, jwksURI string) error {
+	p.keysFetchedAt = time.Now()
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	header, err := s.fetchJSON(ctx, jwksURI, &jwks)
+	if err != nil {
+		return fmt.Errorf("%w: %s keys: %v", ErrOIDCProviderUnavailable, p.config.Name, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			fmt.Printf("⚠️ Skipping OIDC key %q of %s: %v\n", jwk.Kid, p.config.Name, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: %s publishes no usable signing keys", ErrOIDCProviderUnavailable, p.config.Name)
+	}
+
+	p.keys = keys
+	p.keysExpireAt = p.keysFetchedAt.Add(cacheMaxAge(header.Get("Cache-Control")))
+	fmt.Printf("🔐 Loaded %d OIDC signing keys for %s\n", len(keys), p.config.Name)
+	return nil
+}
+
+func (s *OIDCService) fetchJSON(ctx context.Context, url string, v any) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponseSize)).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return resp.Header, nil
+}
+
+// publicKey converts an RSA or EC JSON Web Key
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		exponent := 0
+		for _, b := range e {
+			exponent = exponent<<8 | int(b)
+		}
+		modulus := new(big.Int).SetBytes(n)
+		if modulus.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA key shorter than 2048 bits")
+		}
+		return &rsa.PublicKey{N: modulus, E: exponent}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch k.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		size := (curve.Params().BitSize + 7) / 8
+		if errX != nil || errY != nil || len(x) != size || len(y) != size {
+			return nil, fmt.Errorf("invalid coordinates")
+		}
+
+		// Parsing the uncompressed point rejects points off the curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid point: %w", err)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// issuerMatches compares the token issuer with the discovered one, filling
+// in the tenant for multi-tenant issuers. Google also issues tokens with
+// its issuer without the scheme.
+func issuerMatches(expected string, claims *oidcClaims) bool {
+	if strings.Contains(expected, oidcTenantPlaceholder) {
+		if claims.TenantID == "" {
+			return false
+		}
+		expected = strings.ReplaceAll(expected, oidcTenantPlaceholder, claims.TenantID)
+	}
+	if claims.Issuer == expected {
+		return true
+	}
+	return expected == "https://accounts.google.com" && claims.Issuer == "accounts.google.com"
+}
+
+// cacheMaxAge returns the max-age of a Cache-Control header, bounded so a
+// provider can neither pin its keys for long nor make us refetch them on
+// every login
+func cacheMaxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+		if err != nil {
+			break
+		}
+		ttl := time.Duration(seconds) * time.Second
+		if ttl < oidcMinJWKSTTL {
+			return oidcMinJWKSTTL
+		}
+		if ttl > oidcDiscoveryTTL {
+			return oidcDiscoveryTTL
+		}
+		return ttl
+	}
+	return oidcDefaultJWKSTTL
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
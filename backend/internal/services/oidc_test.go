@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"mylittleprice/internal/config"
+)
+
+const testOIDCClientID = "web-client"
+
+// newTestOIDCProvider serves a discovery document and a JWKS with one RSA
+// key and returns an OIDCService for it
+func newTestOIDCProvider(t *testing.T, key *rsa.PrivateKey, trustEmail bool) (*OIDCService, string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   server.URL,
+			"jwks_uri": server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "key-1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	service := NewOIDCService(&config.Config{
+		OIDCProviders: []config.OIDCProviderConfig{{
+			Name:       "test",
+			Issuer:     server.URL,
+			ClientIDs:  []string{testOIDCClientID, "ios-client"},
+			TrustEmail: trustEmail,
+		}},
+	})
+	return service, server.URL
+}
+
+func signTestIDToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	service, issuer := newTestOIDCProvider(t, key, false)
+	now := time.Now()
+
+	claims := func(changes map[string]any) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":            issuer,
+			"sub":            "subject-1",
+			"aud":            testOIDCClientID,
+			"exp":            now.Add(time.Hour).Unix(),
+			"iat":            now.Unix(),
+			"nonce":          "nonce-1",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"name":           "Test User",
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	rs256 := func(changes map[string]any) string {
+		return signTestIDToken(t, jwt.SigningMethodRS256, key, "key-1", claims(changes))
+	}
+
+	tests := []struct {
+		name         string
+		token        string
+		nonce        string
+		wantErr      error
+		wantVerified bool
+	}{
+		{"valid", rs256(nil), "nonce-1", nil, true},
+		{"second client ID", rs256(map[string]any{"aud": "ios-client"}), "nonce-1", nil, true},
+		{"email_verified as string", rs256(map[string]any{"email_verified": "true"}), "nonce-1", nil, true},
+		{"unverified email", rs256(map[string]any{"email_verified": false}), "nonce-1", nil, false},
+		{"no email", rs256(map[string]any{"email": nil}), "nonce-1", nil, false},
+		{"several audiences, issued to us", rs256(map[string]any{"aud": []string{"other", testOIDCClientID}, "azp": testOIDCClientID}), "nonce-1", nil, true},
+		{"several audiences, issued to another client", rs256(map[string]any{"aud": []string{"other", testOIDCClientID}, "azp": "other"}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"expiry within clock skew", rs256(map[string]any{"exp": now.Add(-30 * time.Second).Unix()}), "nonce-1", nil, true},
+		{"expired", rs256(map[string]any{"exp": now.Add(-time.Hour).Unix()}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"no expiry", rs256(map[string]any{"exp": nil}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"issued in the future", rs256(map[string]any{"iat": now.Add(time.Hour).Unix()}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"wrong audience", rs256(map[string]any{"aud": "other"}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"wrong issuer", rs256(map[string]any{"iss": "https://evil.example.com"}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"wrong nonce", rs256(nil), "nonce-2", ErrOIDCInvalidToken, false},
+		{"nonce expected but missing", rs256(map[string]any{"nonce": nil}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"unexpected nonce", rs256(nil), "", ErrOIDCInvalidToken, false},
+		{"no subject", rs256(map[string]any{"sub": nil}), "nonce-1", ErrOIDCInvalidToken, false},
+		{"signed with another key", signTestIDToken(t, jwt.SigningMethodRS256, otherKey, "key-1", claims(nil)), "nonce-1", ErrOIDCInvalidToken, false},
+		{"unknown key ID", signTestIDToken(t, jwt.SigningMethodRS256, key, "key-2", claims(nil)), "nonce-1", ErrOIDCInvalidToken, false},
+		{"HMAC", signTestIDToken(t, jwt.SigningMethodHS256, []byte("client-secret-of-sufficient-length"), "key-1", claims(nil)), "nonce-1", ErrOIDCInvalidToken, false},
+		{"not a JWT", "not-a-jwt", "nonce-1", ErrOIDCInvalidToken, false},
+	}
+
+	for _, tt := range tests {
+		identity, err := service.VerifyIDToken(context.Background(), "test", tt.token, tt.nonce)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if identity.Provider != "test" || identity.Subject != "subject-1" {
+			t.Errorf("%s: identity = %+v, want subject-1 of test", tt.name, identity)
+		}
+		if identity.EmailVerified != tt.wantVerified {
+			t.Errorf("%s: email verified = %v, want %v", tt.name, identity.EmailVerified, tt.wantVerified)
+		}
+	}
+
+	if _, err := service.VerifyIDToken(context.Background(), "unknown", rs256(nil), "nonce-1"); !errors.Is(err, ErrOIDCUnknownProvider) {
+		t.Errorf("unknown provider: error = %v, want ErrOIDCUnknownProvider", err)
+	}
+}
+
+func TestVerifyIDTokenTrustEmail(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	service, issuer := newTestOIDCProvider(t, key, true)
+
+	token := signTestIDToken(t, jwt.SigningMethodRS256, key, "key-1", jwt.MapClaims{
+		"iss":         issuer,
+		"sub":         "subject-1",
+		"aud":         testOIDCClientID,
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"email":       "user@example.com",
+		"given_name":  "Test",
+		"family_name": "User",
+	})
+	identity, err := service.VerifyIDToken(context.Background(), "test", token, "")
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if !identity.EmailVerified {
+		t.Error("email of a trusted provider is not verified")
+	}
+	if identity.Name != "Test User" {
+		t.Errorf("name = %q, want the given and family name", identity.Name)
+	}
+}
+
+func TestIssuerMatches(t *testing.T) {
+	const microsoft = "https://login.microsoftonline.com/{tenantid}/v2.0"
+
+	tests := []struct {
+		expected string
+		issuer   string
+		tenant   string
+		want     bool
+	}{
+		{"https://id.example.com", "https://id.example.com", "", true},
+		{"https://id.example.com", "https://id.example.com/", "", false},
+		{"https://id.example.com", "https://other.example.com", "", false},
+		{"https://accounts.google.com", "accounts.google.com", "", true},
+		{"https://id.example.com", "id.example.com", "", false},
+		{microsoft, "https://login.microsoftonline.com/tenant-1/v2.0", "tenant-1", true},
+		{microsoft, "https://login.microsoftonline.com/tenant-1/v2.0", "tenant-2", false},
+		{microsoft, "https://login.microsoftonline.com/{tenantid}/v2.0", "", false},
+	}
+
+	for _, tt := range tests {
+		claims := &oidcClaims{TenantID: tt.tenant}
+		claims.Issuer = tt.issuer
+		if got := issuerMatches(tt.expected, claims); got != tt.want {
+			t.Errorf("issuerMatches(%q, iss %q, tid %q) = %v, want %v", tt.expected, tt.issuer, tt.tenant, got, tt.want)
+		}
+	}
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		want         time.Duration
+	}{
+		{"", oidcDefaultJWKSTTL},
+		{"no-cache", oidcDefaultJWKSTTL},
+		{"public, max-age=7200", 2 * time.Hour},
+		{"Max-Age=3600, must-revalidate", time.Hour},
+		{"max-age=10", oidcMinJWKSTTL},
+		{"max-age=31536000", oidcDiscoveryTTL},
+		{"max-age=soon", oidcDefaultJWKSTTL},
+	}
+
+	for _, tt := range tests {
+		if got := cacheMaxAge(tt.cacheControl); got != tt.want {
+			t.Errorf("cacheMaxAge(%q) = %v, want %v", tt.cacheControl, got, tt.want)
+		}
+	}
+}
+
+func TestJSONWebKeyPublicKey(t *testing.T) {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	shortKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ecX, ecY := ecKey.X.FillBytes(make([]byte, 32)), ecKey.Y.FillBytes(make([]byte, 32))
+	offCurveY := new(big.Int).Add(ecKey.Y, big.NewInt(1)).FillBytes(make([]byte, 32))
+	exponent := encode(big.NewInt(int64(rsaKey.E)).Bytes())
+
+	tests := []struct {
+		name    string
+		key     jsonWebKey
+		wantErr bool
+	}{
+		{"RSA", jsonWebKey{Kty: "RSA", N: encode(rsaKey.N.Bytes()), E: exponent}, false},
+		{"short RSA", jsonWebKey{Kty: "RSA", N: encode(shortKey.N.Bytes()), E: exponent}, true},
+		{"RSA without exponent", jsonWebKey{Kty: "RSA", N: encode(rsaKey.N.Bytes())}, true},
+		{"EC P-256", jsonWebKey{Kty: "EC", Crv: "P-256", X: encode(ecX), Y: encode(ecY)}, false},
+		{"EC point off the curve", jsonWebKey{Kty: "EC", Crv: "P-256", X: encode(ecX), Y: encode(offCurveY)}, true},
+		{"EC wrong coordinate size", jsonWebKey{Kty: "EC", Crv: "P-384", X: encode(ecX), Y: encode(ecY)}, true},
+		{"EC unknown curve", jsonWebKey{Kty: "EC", Crv: "secp256k1", X: encode(ecX), Y: encode(ecY)}, true},
+		{"symmetric", jsonWebKey{Kty: "oct"}, true},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.key.publicKey()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/priceobservation"
+	"mylittleprice/internal/domain"
+	"mylittleprice/internal/models"
+)
+
+const (
+	PriceSourceSearch         = "search"
+	PriceSourceProductDetails = "product_details"
+
+	maxPriceHistoryDays   = 365
+	maxPriceHistoryPoints = 1000
+	maxProductKeyLength   = 255
+	maxMerchantLength     = 255 // merchant is VARCHAR(255)
+
+	// Window of the price history the dialogue sees
+	dialoguePriceHistoryDays = 90
+)
+
+// PriceHistoryService stores every price we see in search results and
+// product details, and aggregates it into per-product price series
+type PriceHistoryService struct {
+	client *ent.Client
+}
+
+func NewPriceHistoryService(client *ent.Client) *PriceHistoryService {
+	return &PriceHistoryService{
+		client: client,
+	}
+}
+
+// RecordSearchResults stores one observation per product card.
+// Cards carry only a price string, so unless the string names a currency
+// we assume the local currency of the searched country.
+func (s *PriceHistoryService) RecordSearchResults(ctx context.Context, products []models.ProductCard, country string) (int, error) {
+	currency := localCurrency(country)
+	now := time.Now()
+	observations := make([]models.PriceObservation, 0, len(products))
+
+	for _, product := range products {
+		price := ParsePrice(product.Price)
+		if price <= 0 {
+			continue
+		}
+
+		observations = append(observations, models.PriceObservation{
+			ProductName: product.Name,
+			Merchant:    product.Description, // Cards keep the merchant in Description
+			Price:       price,
+			Currency:    detectPriceCurrency(product.Price, currency),
+			CountryCode: country,
+			Source:      PriceSourceSearch,
+			ObservedAt:  now,
+		})
+	}
+
+	return s.save(ctx, observations)
+}
+
+// RecordProductDetails stores one observation per offer of a
+// google_immersive_product response
+func (s *PriceHistoryService) RecordProductDetails(ctx context.Context, productData map[string]interface{}, country string) (int, error) {
+	title, offers := parseProductOffers(productData)
+	if title == "" {
+		return 0, nil
+	}
+
+	currency := localCurrency(country)
+	now := time.Now()
+	observations := make([]models.PriceObservation, 0, len(offers))
+	for _, offer := range offers {
+		offerCurrency := offer.Currency
+		if offerCurrency == "" {
+			offerCurrency = currency
+		}
+
+		observations = append(observations, models.PriceObservation{
+			ProductName: title,
+			Merchant:    offer.Merchant,
+			Price:       offer.Price,
+			Currency:    offerCurrency,
+			CountryCode: country,
+			Source:      PriceSourceProductDetails,
+			ObservedAt:  now,
+		})
+	}
+
+	return s.save(ctx, observations)
+}
+
+// GetPriceHistory returns the price series of a product over the last
+// days, with min/median/max. If currency is empty, the currency of the
+// most recent observation is used.
+func (s *PriceHistoryService) GetPriceHistory(ctx context.Context, product, currency, country string, days int) (*models.PriceHistoryResponse, error) {
+	productKey := NormalizeProductKey(product)
+	if productKey == "" {
+		return nil, fmt.Errorf("product is required")
+	}
+
+	if days <= 0 || days > maxPriceHistoryDays {
+		days = maxPriceHistoryDays
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	response := &models.PriceHistoryResponse{
+		ProductKey:  productKey,
+		Currency:    currency,
+		CountryCode: country,
+		Days:        days,
+		Points:      []models.PricePoint{},
+	}
+
+	query := s.client.PriceObservation.Query().
+		Where(
+			priceobservation.ProductKeyEQ(productKey),
+			priceobservation.ObservedAtGTE(time.Now().AddDate(0, 0, -days)),
+		)
+	if country != "" {
+		query = query.Where(priceobservation.CountryCodeEQ(country))
+	}
+
+	if currency == "" {
+		latest, err := query.Clone().
+			Order(ent.Desc(priceobservation.FieldObservedAt)).
+			First(ctx)
+		if ent.IsNotFound(err) {
+			return response, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest observation: %w", err)
+		}
+		currency = latest.Currency
+		response.Currency = currency
+	}
+
+	rows, err := query.
+		Where(priceobservation.CurrencyEQ(currency)).
+		Order(ent.Desc(priceobservation.FieldObservedAt)).
+		Limit(maxPriceHistoryPoints).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+	if len(rows) == 0 {
+		return response, nil
+	}
+
+	// Oldest first for charting
+	points := make([]models.PricePoint, len(rows))
+	for i, row := range rows {
+		points[len(rows)-1-i] = models.PricePoint{
+			Price:      row.Price,
+			Merchant:   row.Merchant,
+			ObservedAt: row.ObservedAt,
+		}
+	}
+
+	response.Points = points
+	response.Stats = computePriceStats(points)
+	return response, nil
+}
+
+// GetDialoguePriceHistory returns the price history the assistant uses to
+// judge a price: the last 90 days in the currency of the latest observation
+func (s *PriceHistoryService) GetDialoguePriceHistory(ctx context.Context, product, country string) (*models.PriceHistoryResponse, error) {
+	return s.GetPriceHistory(ctx, product, "", country, dialoguePriceHistoryDays)
+}
+
+func (s *PriceHistoryService) save(ctx context.Context, observations []models.PriceObservation) (int, error) {
+	builders := make([]*ent.PriceObservationCreate, 0, len(observations))
+	for _, o := range observations {
+		productKey := NormalizeProductKey(o.ProductName)
+		if productKey == "" || o.Currency == "" {
+			continue
+		}
+
+		builder := s.client.PriceObservation.Create().
+			SetProductKey(productKey).
+			SetProductName(o.ProductName).
+			SetPrice(o.Price).
+			SetCurrency(strings.ToUpper(o.Currency)).
+			SetSource(o.Source).
+			SetObservedAt(o.ObservedAt)
+
+		if o.Merchant != "" {
+			// Search cards keep the merchant in a free-form description
+			builder.SetMerchant(truncateRunes(o.Merchant, maxMerchantLength))
+		}
+		if o.CountryCode != "" {
+			builder.SetCountryCode(strings.ToUpper(o.CountryCode))
+		}
+
+		builders = append(builders, builder)
+	}
+
+	if len(builders) == 0 {
+		return 0, nil
+	}
+
+	if _, err := s.client.PriceObservation.CreateBulk(builders...).Save(ctx); err != nil {
+		return 0, fmt.Errorf("failed to save price observations: %w", err)
+	}
+	return len(builders), nil
+}
+
+// computePriceStats aggregates a chronologically ordered series
+func computePriceStats(points []models.PricePoint) *models.PriceStats {
+	prices := make([]float64, len(points))
+	for i, p := range points {
+		prices[i] = p.Price
+	}
+	sort.Float64s(prices)
+
+	median := prices[len(prices)/2]
+	if len(prices)%2 == 0 {
+		median = (prices[len(prices)/2-1] + prices[len(prices)/2]) / 2
+	}
+
+	// Cheapest offer seen around the newest observation
+	newest := points[len(points)-1].ObservedAt
+	current := points[len(points)-1].Price
+	for _, p := range points {
+		if newest.Sub(p.ObservedAt) <= 24*time.Hour && p.Price < current {
+			current = p.Price
+		}
+	}
+
+	stats := &models.PriceStats{
+		Min:     prices[0],
+		Median:  median,
+		Max:     prices[len(prices)-1],
+		Current: current,
+		Count:   len(prices),
+	}
+
+	switch {
+	case len(prices) < 3:
+		stats.Verdict = "insufficient_data"
+	case current <= median*0.95:
+		stats.Verdict = "good"
+	case current >= median*1.05:
+		stats.Verdict = "high"
+	default:
+		stats.Verdict = "typical"
+	}
+
+	return stats
+}
+
+// NormalizeProductKey turns a product name into a stable grouping key:
+// lowercase letters and digits separated by single spaces
+func NormalizeProductKey(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+
+	key := []rune(b.String())
+	if len(key) > maxProductKeyLength {
+		key = key[:maxProductKeyLength]
+	}
+	return strings.TrimSpace(string(key))
+}
+
+// localCurrency returns the currency SerpAPI prices are shown in for a country
+func localCurrency(country string) string {
+	return string(domain.GetCurrencyForCountry(domain.CountryCode(strings.ToUpper(country))))
+}
+
+// detectPriceCurrency reads an explicit currency from a price string such
+// as "CHF 199.00" or "€199", otherwise returns fallback
+func detectPriceCurrency(price, fallback string) string {
+	upper := strings.ToUpper(price)
+	switch {
+	case strings.Contains(upper, "CHF"):
+		return "CHF"
+	case strings.Contains(upper, "€"), strings.Contains(upper, "EUR"):
+		return "EUR"
+	case strings.Contains(upper, "£"), strings.Contains(upper, "GBP"):
+		return "GBP"
+	case strings.Contains(upper, "USD"), strings.Contains(upper, "US$"):
+		return "USD"
+	}
+	return strings.ToUpper(fallback)
+}
@@ -33,6 +33,10 @@ type BroadcastMessage struct {
 	SessionID string       `json:"session_id"` // Session ID
 	Type      string       `json:"type"`       // Message type
 	Payload   interface{}  `json:"payload"`    // Message payload
+
+	// Session broadcasts: connections of this user skip the message
+	// (they already got it on the user channel)
+	ExcludeUserID *uuid.UUID `json:"exclude_user_id,omitempty"`
 }
 
 // NewPubSubService creates a new PubSubService
@@ -82,7 +86,10 @@ func (s *PubSubService) listen(channel string, pubsub *redis.PubSub) {
 		case <-s.ctx.Done():
 			log.Printf("🔕 Server %s stopped listening to channel: %s", s.serverID[:8], channel)
 			return
-		case msg := <-ch:
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
 			if msg == nil {
 				continue
 			}
@@ -147,12 +154,14 @@ func (s *PubSubService) BroadcastToUser(userID uuid.UUID, msgType string, payloa
 	return s.Publish(channel, msg)
 }
 
-// BroadcastToSession broadcasts a message to all servers for a specific session
-func (s *PubSubService) BroadcastToSession(sessionID string, msgType string, payload interface{}) error {
+// BroadcastToSession broadcasts a message to all servers for a specific session.
+// excludeUserID is optional, see BroadcastMessage.ExcludeUserID.
+func (s *PubSubService) BroadcastToSession(sessionID string, msgType string, payload interface{}, excludeUserID *uuid.UUID) error {
 	msg := &BroadcastMessage{
-		SessionID: sessionID,
-		Type:      msgType,
-		Payload:   payload,
+		SessionID:     sessionID,
+		Type:          msgType,
+		Payload:       payload,
+		ExcludeUserID: excludeUserID,
 	}
 
 	channel := fmt.Sprintf("session:%s", sessionID)
@@ -177,6 +186,38 @@ func (s *PubSubService) SubscribeToAllUsers(handler BroadcastHandler) error {
 	return s.Subscribe("users:broadcast", handler)
 }
 
+// SubscribeToUserChannels subscribes to every per-user channel (user:*)
+// so messages published with BroadcastToUser reach this server's connections
+func (s *PubSubService) SubscribeToUserChannels(handler BroadcastHandler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pattern := "user:*"
+	s.handlers[pattern] = handler
+
+	pubsub := s.redis.PSubscribe(s.ctx, pattern)
+	go s.listen(pattern, pubsub)
+
+	log.Printf("🔔 Server %s subscribed to pattern: %s", s.serverID[:8], pattern)
+	return nil
+}
+
+// SubscribeToSessionChannels subscribes to every per-session channel (session:*)
+// so messages published with BroadcastToSession reach this server's connections
+func (s *PubSubService) SubscribeToSessionChannels(handler BroadcastHandler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pattern := "session:*"
+	s.handlers[pattern] = handler
+
+	pubsub := s.redis.PSubscribe(s.ctx, pattern)
+	go s.listen(pattern, pubsub)
+
+	log.Printf("🔔 Server %s subscribed to pattern: %s", s.serverID[:8], pattern)
+	return nil
+}
+
 // BroadcastToAllUsers broadcasts a message to all users (all servers)
 func (s *PubSubService) BroadcastToAllUsers(userID uuid.UUID, msgType string, payload interface{}) error {
 	msg := &BroadcastMessage{
@@ -0,0 +1,318 @@
+// backend/internal/services/query_index.go
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// QueryCacheScope limits semantic cache hits to searches of the same
+// country, search type and price range
+type QueryCacheScope struct {
+	Country    string
+	SearchType string
+	PriceRange string // "any", "min100", "max500" or "min100_max500"
+}
+
+func (s QueryCacheScope) key() string {
+	return s.Country + "|" + s.SearchType + "|" + s.PriceRange
+}
+
+// queryVectorIndex finds cached searches by the embedding of their query.
+// Entries expire with the cached results they point to.
+type queryVectorIndex interface {
+	Add(ctx context.Context, scope QueryCacheScope, cacheKey string, vector []float32, ttl time.Duration) error
+	// Nearest returns the cache key of the most similar query in the scope,
+	// empty if there is none
+	Nearest(ctx context.Context, scope QueryCacheScope, vector []float32) (string, float32, error)
+}
+
+// newQueryVectorIndex returns the index selected by CACHE_SEMANTIC_INDEX,
+// nil when semantic cache hits are off. "auto" uses Redis vector search
+// when the server has the query engine (Redis 8, Redis Stack).
+func newQueryVectorIndex(redisClient *redis.Client, mode, namespace string) queryVectorIndex {
+	switch mode {
+	case "off":
+		return nil
+	case "memory":
+		return newMemoryQueryIndex()
+	case "redis":
+		return newRedisQueryIndex(redisClient, namespace)
+	}
+
+	if err := redisClient.Do(context.Background(), "FT._LIST").Err(); err != nil {
+		fmt.Printf("⚠️ Redis vector search not available (%v), semantic cache uses the in-memory index\n", err)
+		return newMemoryQueryIndex()
+	}
+	return newRedisQueryIndex(redisClient, namespace)
+}
+
+// ═══════════════════════════════════════════════════════════
+// REDIS VECTOR INDEX
+// Hashes with the query embedding, indexed with FT.CREATE (HNSW, cosine)
+// ═══════════════════════════════════════════════════════════
+
+type redisQueryIndex struct {
+	redis  *redis.Client
+	name   string // Index name
+	prefix string // Key prefix of the indexed hashes
+	mu     sync.Mutex
+	dim    int // Vector size the index was created with, 0 until then
+}
+
+func newRedisQueryIndex(redisClient *redis.Client, namespace string) *redisQueryIndex {
+	// The namespace separates embedding models, whose vectors differ in size
+	return &redisQueryIndex{
+		redis:  redisClient,
+		name:   "idx:querycache" + namespace,
+		prefix: "querycache" + namespace + ":doc:",
+	}
+}
+
+func (r *redisQueryIndex) Add(ctx context.Context, scope QueryCacheScope, cacheKey string, vector []float32, ttl time.Duration) error {
+	if err := r.ensureIndex(ctx, len(vector)); err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(cacheKey))
+	key := r.prefix + hex.EncodeToString(hash[:])
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"cache_key":   cacheKey,
+		"country":     tagValue(scope.Country),
+		"search_type": tagValue(scope.SearchType),
+		"price":       tagValue(scope.PriceRange),
+		"vector":      vectorBytes(vector),
+	})
+	pipe.Expire(ctx, key, ttl) // Expired hashes leave the index
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index query: %w", err)
+	}
+	return nil
+}
+
+func (r *redisQueryIndex) Nearest(ctx context.Context, scope QueryCacheScope, vector []float32) (string, float32, error) {
+	if err := r.ensureIndex(ctx, len(vector)); err != nil {
+		return "", 0, err
+	}
+
+	query := fmt.Sprintf("(@country:{%s} @search_type:{%s} @price:{%s})=>[KNN 1 @vector $vec AS distance]",
+		tagValue(scope.Country), tagValue(scope.SearchType), tagValue(scope.PriceRange))
+
+	// Sent with Do: typed FT.SEARCH replies need RESP2 or UnstableResp3
+	reply, err := r.redis.Do(ctx, "FT.SEARCH", r.name, query,
+		"PARAMS", 2, "vec", vectorBytes(vector),
+		"RETURN", 2, "cache_key", "distance",
+		"DIALECT", 2,
+	).Result()
+	if err != nil {
+		return "", 0, fmt.Errorf("vector search failed: %w", err)
+	}
+
+	fields := firstSearchResult(reply)
+	if fields["cache_key"] == "" {
+		return "", 0, nil
+	}
+	distance, err := strconv.ParseFloat(fields["distance"], 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid vector distance %q", fields["distance"])
+	}
+
+	// Cosine distance is 1 - similarity
+	return fields["cache_key"], 1 - float32(distance), nil
+}
+
+// ensureIndex creates the index once the vector size is known
+func (r *redisQueryIndex) ensureIndex(ctx context.Context, dim int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dim == dim {
+		return nil
+	}
+	if r.dim != 0 {
+		return fmt.Errorf("embedding size changed from %d to %d, index %s needs to be dropped", r.dim, dim, r.name)
+	}
+
+	err := r.redis.FTCreate(ctx, r.name,
+		&redis.FTCreateOptions{OnHash: true, Prefix: []interface{}{r.prefix}},
+		&redis.FieldSchema{FieldName: "country", FieldType: redis.SearchFieldTypeTag},
+		&redis.FieldSchema{FieldName: "search_type", FieldType: redis.SearchFieldTypeTag},
+		&redis.FieldSchema{FieldName: "price", FieldType: redis.SearchFieldTypeTag},
+		&redis.FieldSchema{
+			FieldName: "vector",
+			FieldType: redis.SearchFieldTypeVector,
+			VectorArgs: &redis.FTVectorArgs{
+				HNSWOptions: &redis.FTHNSWOptions{Type: "FLOAT32", Dim: dim, DistanceMetric: "COSINE"},
+			},
+		},
+	).Err()
+	if err != nil && !strings.Contains(err.Error(), "Index already exists") {
+		return fmt.Errorf("failed to create vector index %s: %w", r.name, err)
+	}
+
+	r.dim = dim
+	fmt.Printf("🧭 Semantic cache vector index %s ready (%d dimensions)\n", r.name, dim)
+	return nil
+}
+
+// firstSearchResult returns the returned fields of the first FT.SEARCH
+// result, from a RESP2 array or a RESP3 map
+func firstSearchResult(reply interface{}) map[string]string {
+	fields := make(map[string]string)
+
+	switch v := reply.(type) {
+	case []interface{}:
+		// [total, id, [field, value, ...], ...]
+		if len(v) >= 3 {
+			if values, ok := v[2].([]interface{}); ok {
+				for i := 0; i+1 < len(values); i += 2 {
+					fields[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+				}
+			}
+		}
+	case map[interface{}]interface{}:
+		// {results: [{id, extra_attributes: {field: value}}], ...}
+		results, _ := v["results"].([]interface{})
+		if len(results) > 0 {
+			if result, ok := results[0].(map[interface{}]interface{}); ok {
+				if attributes, ok := result["extra_attributes"].(map[interface{}]interface{}); ok {
+					for name, value := range attributes {
+						fields[fmt.Sprint(name)] = fmt.Sprint(value)
+					}
+				}
+			}
+		}
+	}
+
+	return fields
+}
+
+// tagValue makes a value safe to use unescaped in a TAG query
+func tagValue(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "none"
+	}
+	return b.String()
+}
+
+func vectorBytes(vector []float32) []byte {
+	b := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+	return b
+}
+
+// ═══════════════════════════════════════════════════════════
+// IN-MEMORY VECTOR INDEX
+// Fallback without Redis vector search: exact search within a scope
+// ═══════════════════════════════════════════════════════════
+
+const (
+	memoryQueryIndexMaxPerScope   = 1000 // Oldest entries of a scope are dropped beyond this
+	memoryQueryIndexSweepInterval = time.Minute
+)
+
+type memoryQueryIndex struct {
+	mu        sync.Mutex
+	scopes    map[string][]memoryQueryEntry // Oldest first
+	lastSweep time.Time
+}
+
+type memoryQueryEntry struct {
+	cacheKey  string
+	vector    []float32
+	expiresAt time.Time
+}
+
+func newMemoryQueryIndex() *memoryQueryIndex {
+	return &memoryQueryIndex{
+		scopes:    make(map[string][]memoryQueryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *memoryQueryIndex) Add(_ context.Context, scope QueryCacheScope, cacheKey string, vector []float32, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastSweep) > memoryQueryIndexSweepInterval {
+		m.sweep(now)
+	}
+
+	key := scope.key()
+	entries := make([]memoryQueryEntry, 0, len(m.scopes[key])+1)
+	for _, entry := range m.scopes[key] {
+		if entry.cacheKey != cacheKey && now.Before(entry.expiresAt) {
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, memoryQueryEntry{
+		cacheKey:  cacheKey,
+		vector:    vector,
+		expiresAt: now.Add(ttl),
+	})
+	if len(entries) > memoryQueryIndexMaxPerScope {
+		entries = entries[len(entries)-memoryQueryIndexMaxPerScope:]
+	}
+	m.scopes[key] = entries
+	return nil
+}
+
+func (m *memoryQueryIndex) Nearest(_ context.Context, scope QueryCacheScope, vector []float32) (string, float32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	bestKey := ""
+	bestSimilarity := float32(-1)
+	for _, entry := range m.scopes[scope.key()] {
+		if now.After(entry.expiresAt) {
+			continue
+		}
+		if similarity := cosineSimilarity(vector, entry.vector); similarity > bestSimilarity {
+			bestKey = entry.cacheKey
+			bestSimilarity = similarity
+		}
+	}
+	return bestKey, bestSimilarity, nil
+}
+
+// sweep drops expired entries of all scopes
+func (m *memoryQueryIndex) sweep(now time.Time) {
+	for key, entries := range m.scopes {
+		live := entries[:0]
+		for _, entry := range entries {
+			if now.Before(entry.expiresAt) {
+				live = append(live, entry)
+			}
+		}
+		if len(live) == 0 {
+			delete(m.scopes, key)
+		} else {
+			m.scopes[key] = live
+		}
+	}
+	m.lastSweep = now
+}
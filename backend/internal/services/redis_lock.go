@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// errLockBusy is returned by lockKey when another holder kept the lock
+var errLockBusy = errors.New("lock is held by another request")
+
+// releaseLockScript deletes a lock only while it still holds our token
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// lockKey takes a short Redis lock and returns the function releasing it.
+// A held lock is retried for about a second; the lock expires after ttl if
+// the instance dies while holding it.
+func lockKey(ctx context.Context, client *redis.Client, key string, ttl time.Duration) (func(), error) {
+	token := uuid.New().String()
+
+	for attempt := 0; attempt < 20; attempt++ {
+		acquired, err := client.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return func() {
+				releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				releaseLockScript.Run(releaseCtx, client, []string{key}, token)
+			}, nil
+		}
+
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, errLockBusy
+}
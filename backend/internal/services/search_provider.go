@@ -0,0 +1,172 @@
+// backend/internal/services/search_provider.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"mylittleprice/internal/domain"
+)
+
+// ProductSearchProvider is a shopping source SerpService can query.
+// Providers return normalized ShoppingItems; relevance validation and
+// card conversion stay in SerpService so every source behaves the same.
+type ProductSearchProvider interface {
+	// Name is the registry identifier (e.g. "serpapi")
+	Name() string
+
+	// Capabilities describes what the provider supports
+	Capabilities() ProviderCapabilities
+
+	// Search returns normalized shopping items for a query. The int is the
+	// API key index used (-1 when the provider is not key-rotated). Paid
+	// calls are recorded as costs of the request in ctx.
+	Search(ctx context.Context, query, country string, minPrice, maxPrice *float64) ([]domain.ShoppingItem, int, error)
+
+	// GetProductDetails returns raw immersive-product data for a page token
+	// in the SerpAPI google_immersive_product shape (consumed by FormatProductDetails).
+	GetProductDetails(ctx context.Context, pageToken string) (map[string]interface{}, int, error)
+}
+
+// ProviderCapabilities describes optional features of a search provider
+type ProviderCapabilities struct {
+	PriceFilter    bool     `json:"price_filter"`
+	ProductDetails bool     `json:"product_details"`
+	Countries      []string `json:"countries,omitempty"` // Empty = all countries
+}
+
+// SupportsCountry reports whether the provider can serve the given country
+func (c ProviderCapabilities) SupportsCountry(country string) bool {
+	if len(c.Countries) == 0 {
+		return true
+	}
+	for _, cc := range c.Countries {
+		if strings.EqualFold(cc, country) {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchProviderRegistry selects a ProductSearchProvider per country
+type SearchProviderRegistry struct {
+	providers        map[string]ProductSearchProvider
+	defaultProvider  string
+	countryProviders map[string]string // country code -> provider name
+	mu               sync.RWMutex
+}
+
+func NewSearchProviderRegistry(defaultProvider string, countryProviders map[string]string) *SearchProviderRegistry {
+	overrides := make(map[string]string, len(countryProviders))
+	for country, name := range countryProviders {
+		overrides[strings.ToUpper(country)] = strings.ToLower(name)
+	}
+
+	return &SearchProviderRegistry{
+		providers:        make(map[string]ProductSearchProvider),
+		defaultProvider:  strings.ToLower(defaultProvider),
+		countryProviders: overrides,
+	}
+}
+
+// Register adds (or replaces) a provider under its Name()
+func (r *SearchProviderRegistry) Register(provider ProductSearchProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[strings.ToLower(provider.Name())] = provider
+}
+
+// Get returns a provider by name
+func (r *SearchProviderRegistry) Get(name string) (ProductSearchProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[strings.ToLower(name)]
+	return provider, ok
+}
+
+// Default returns the configured default provider
+func (r *SearchProviderRegistry) Default() (ProductSearchProvider, error) {
+	provider, ok := r.Get(r.defaultProvider)
+	if !ok {
+		return nil, fmt.Errorf("search provider %q is not registered", r.defaultProvider)
+	}
+	return provider, nil
+}
+
+// ForCountry returns the provider configured for a country, falling back
+// to the default provider when there is no override or the override
+// does not support that country.
+func (r *SearchProviderRegistry) ForCountry(country string) (ProductSearchProvider, error) {
+	r.mu.RLock()
+	name, hasOverride := r.countryProviders[strings.ToUpper(country)]
+	r.mu.RUnlock()
+
+	if hasOverride {
+		if provider, ok := r.Get(name); ok && provider.Capabilities().SupportsCountry(country) {
+			return provider, nil
+		}
+		fmt.Printf("   ⚠️ Search provider %q for %s unavailable, using default %q\n", name, country, r.defaultProvider)
+	}
+
+	return r.Default()
+}
+
+// ForToken returns the provider that issued a page token. Tokens from
+// non-default providers are namespaced as "<provider>:<token>" (see
+// QualifyPageToken); unprefixed tokens belong to the default provider.
+func (r *SearchProviderRegistry) ForToken(pageToken string) (ProductSearchProvider, string, error) {
+	if idx := strings.Index(pageToken, ":"); idx > 0 {
+		if provider, ok := r.Get(pageToken[:idx]); ok {
+			return provider, pageToken[idx+1:], nil
+		}
+	}
+
+	provider, err := r.Default()
+	if err != nil {
+		return nil, "", err
+	}
+	return provider, pageToken, nil
+}
+
+// QualifyPageToken namespaces a provider's page token so product details
+// can be routed back to it. Default-provider tokens are left untouched
+// to keep existing cache keys and client state valid.
+func (r *SearchProviderRegistry) QualifyPageToken(providerName, pageToken string) string {
+	if pageToken == "" || strings.EqualFold(providerName, r.defaultProvider) {
+		return pageToken
+	}
+	return strings.ToLower(providerName) + ":" + pageToken
+}
+
+// Names returns registered provider names in sorted order
+func (r *SearchProviderRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetStats returns registry configuration for the stats endpoints
+func (r *SearchProviderRegistry) GetStats() map[string]interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providers := make(map[string]ProviderCapabilities, len(r.providers))
+	for name, provider := range r.providers {
+		providers[name] = provider.Capabilities()
+	}
+
+	return map[string]interface{}{
+		"default":           r.defaultProvider,
+		"country_overrides": r.countryProviders,
+		"providers":         providers,
+	}
+}
@@ -2,21 +2,23 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
-	g "github.com/serpapi/google-search-results-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"mylittleprice/internal/config"
 	"mylittleprice/internal/domain"
 	"mylittleprice/internal/models"
-	"mylittleprice/internal/utils"
+	"mylittleprice/internal/tracing"
 )
 
 type SerpService struct {
-	keyRotator *utils.KeyRotator
-	config     *config.Config
+	providers *SearchProviderRegistry
+	config    *config.Config
 }
 
 type SearchResult struct {
@@ -26,171 +28,67 @@ type SearchResult struct {
 	AlternativeHint string
 }
 
-func NewSerpService(keyRotator *utils.KeyRotator, cfg *config.Config) *SerpService {
+func NewSerpService(providers *SearchProviderRegistry, cfg *config.Config) *SerpService {
 	return &SerpService{
-		keyRotator: keyRotator,
-		config:     cfg,
+		providers: providers,
+		config:    cfg,
 	}
 }
 
-func (s *SerpService) SearchProducts(query, searchType, country string, minPrice, maxPrice *float64) ([]models.ProductCard, int, error) {
+// GetProviders returns the search provider registry
+func (s *SerpService) GetProviders() *SearchProviderRegistry {
+	return s.providers
+}
+
+func (s *SerpService) SearchProducts(ctx context.Context, query, searchType, country string, minPrice, maxPrice *float64) ([]models.ProductCard, int, error) {
+	ctx, span := tracing.StartChild(ctx, "serp.search_products", trace.SpanKindInternal,
+		attribute.String("search.type", searchType),
+		attribute.String("search.country", country),
+	)
+	cards, keyIndex, err := s.searchProducts(ctx, query, searchType, country, minPrice, maxPrice)
+	span.SetAttributes(attribute.Int("search.results", len(cards)))
+	tracing.End(span, err)
+	return cards, keyIndex, err
+}
+
+func (s *SerpService) searchProducts(ctx context.Context, query, searchType, country string, minPrice, maxPrice *float64) ([]models.ProductCard, int, error) {
 	// Validate input
 	if err := validateSearchQuery(query); err != nil {
 		return nil, -1, fmt.Errorf("invalid search query: %w", err)
 	}
 
-	// Try up to total number of keys + 2 (for network retries)
-	maxRetries := s.keyRotator.GetTotalKeys() + 1
-	var lastErr error
-	var lastKeyIndex int = -1
-	var lastWasQuotaError bool = false
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Only apply backoff for network errors, not quota errors
-		if attempt > 0 && !lastWasQuotaError {
-			// Exponential backoff: 500ms, 1s, 2s
-			backoffDuration := time.Duration(500*(1<<uint(attempt-1))) * time.Millisecond
-			if backoffDuration > 2*time.Second {
-				backoffDuration = 2 * time.Second
-			}
-			fmt.Printf("   ⏳ SERP retry attempt %d/%d after %v...\n", attempt+1, maxRetries+1, backoffDuration)
-			time.Sleep(backoffDuration)
-		} else if attempt > 0 && lastWasQuotaError {
-			fmt.Printf("   🔄 Trying next key (attempt %d/%d)...\n", attempt+1, maxRetries+1)
-		}
-
-		apiKey, keyIndex, err := s.keyRotator.GetNextKey()
-		if err != nil {
-			return nil, -1, fmt.Errorf("failed to get API key: %w", err)
-		}
-		lastKeyIndex = keyIndex
-		lastWasQuotaError = false
-
-		// ✅ ЛОГИРУЕМ ОРИГИНАЛЬНЫЙ ЗАПРОС
-		if attempt == 0 {
-			fmt.Printf("\n🔍 SERP API Request:\n")
-			fmt.Printf("   Original Query: %s\n", query)
-			fmt.Printf("   Type: %s\n", searchType)
-			fmt.Printf("   Country: %s\n", country)
-			fmt.Printf("   Language: %s\n", getLanguageForCountry(country))
-			if minPrice != nil || maxPrice != nil {
-				fmt.Printf("   Price Range: %v - %v\n", minPrice, maxPrice)
-			}
-		}
-		fmt.Printf("   Key Index: %d (attempt %d)\n", keyIndex, attempt+1)
-
-		parameter := map[string]string{
-			"engine": "google_shopping",
-			"q":      query,
-			"gl":     country,
-			"hl":     getLanguageForCountry(country),
-		}
-
-		// Add price range filters if provided
-		if minPrice != nil {
-			parameter["min_price"] = fmt.Sprintf("%.0f", *minPrice)
-		}
-		if maxPrice != nil {
-			parameter["max_price"] = fmt.Sprintf("%.0f", *maxPrice)
-		}
-
-		search := g.NewGoogleSearch(parameter, apiKey)
-
-		startTime := time.Now()
-		data, err := search.GetJSON()
-		elapsed := time.Since(startTime)
-
-		if err != nil {
-			lastErr = err
-			fmt.Printf("   ❌ SERP API Error (%.2fs, attempt %d/%d): %v\n", elapsed.Seconds(), attempt+1, maxRetries+1, err)
-
-			// Check if error is retryable
-			errMsg := err.Error()
-			isQuotaError := strings.Contains(errMsg, "run out of searches") ||
-				strings.Contains(errMsg, "quota exceeded") ||
-				strings.Contains(errMsg, "limit exceeded") ||
-				strings.Contains(errMsg, "rate limit")
-
-			isNetworkError := strings.Contains(errMsg, "timeout") ||
-				strings.Contains(errMsg, "503") ||
-				strings.Contains(errMsg, "502") ||
-				strings.Contains(errMsg, "500")
-
-			if isQuotaError {
-				// Mark this key as exhausted
-				fmt.Printf("   ⚠️ Quota error detected for key %d\n", keyIndex)
-				if markErr := s.keyRotator.MarkKeyAsExhausted(keyIndex); markErr != nil {
-					fmt.Printf("   ⚠️ Failed to mark key as exhausted: %v\n", markErr)
-				}
-				// Try next key immediately (don't wait for backoff)
-				lastWasQuotaError = true
-				if attempt < maxRetries {
-					continue
-				}
-			} else if isNetworkError {
-				// Retryable network error - continue to next attempt
-				if attempt < maxRetries {
-					continue
-				}
-			}
-
-			// Non-retryable error or last attempt
-			return nil, keyIndex, fmt.Errorf("SERP API error: %w", err)
-		}
-
-		// Success!
-		if attempt > 0 {
-			fmt.Printf("   ✅ SERP request succeeded on attempt %d\n", attempt+1)
-		}
-		fmt.Printf("   ⏱️ Response time: %.2fs\n", elapsed.Seconds())
-
-		shoppingItems := []domain.ShoppingItem{}
-
-		if shoppingResults, ok := data["shopping_results"].([]interface{}); ok {
-			fmt.Printf("   📦 Raw results: %d products\n", len(shoppingResults))
-
-			for _, item := range shoppingResults {
-				if itemMap, ok := item.(map[string]interface{}); ok {
-					shoppingItem := domain.ShoppingItem{
-						Position:    getIntFromInterface(itemMap["position"]),
-						Title:       getStringFromInterface(itemMap["title"]),
-						Link:        getStringFromInterface(itemMap["link"]),
-						ProductLink: getStringFromInterface(itemMap["product_link"]),
-						ProductID:   getStringFromInterface(itemMap["product_id"]),
-						Thumbnail:   getStringFromInterface(itemMap["thumbnail"]),
-						Price:       getStringFromInterface(itemMap["price"]),
-						Merchant:    getStringFromInterface(itemMap["source"]),
-						Rating:      getFloat32FromInterface(itemMap["rating"]),
-						Reviews:     getIntFromInterface(itemMap["reviews"]),
-						SerpAPILink: getStringFromInterface(itemMap["serpapi_product_api"]),
-						PageToken:   getStringFromInterface(itemMap["immersive_product_page_token"]),
-					}
-					shoppingItems = append(shoppingItems, shoppingItem)
-				}
-			}
-		} else {
-			fmt.Printf("   ⚠️ No shopping_results in response\n")
-		}
+	provider, err := s.providers.ForCountry(country)
+	if err != nil {
+		return nil, -1, err
+	}
 
-		result := s.validateRelevance(query, shoppingItems, searchType)
+	if !provider.Capabilities().PriceFilter && (minPrice != nil || maxPrice != nil) {
+		fmt.Printf("   ⚠️ Provider %s does not support price filters, ignoring range\n", provider.Name())
+		minPrice, maxPrice = nil, nil
+	}
 
-		if !result.IsRelevant {
-			fmt.Printf("   ⚠️ No relevant results for '%s' (score: %.2f)\n", query, result.RelevanceScore)
-			return nil, keyIndex, fmt.Errorf("no relevant products found")
-		}
+	fmt.Printf("   Provider: %s, Type: %s\n", provider.Name(), searchType)
 
-		cards := s.convertToProductCards(result.Products, searchType)
+	shoppingItems, keyIndex, err := provider.Search(ctx, query, country, minPrice, maxPrice)
+	if err != nil {
+		return nil, keyIndex, err
+	}
 
-		fmt.Printf("   ✅ Found %d relevant products (score: %.2f)\n\n", len(cards), result.RelevanceScore)
+	result := s.validateRelevance(query, shoppingItems, searchType)
 
-		return cards, keyIndex, nil
+	if !result.IsRelevant {
+		fmt.Printf("   ⚠️ No relevant results for '%s' (score: %.2f)\n", query, result.RelevanceScore)
+		return nil, keyIndex, fmt.Errorf("no relevant products found")
 	}
 
-	// All retries failed
-	if lastErr != nil {
-		return nil, lastKeyIndex, fmt.Errorf("SERP API failed after %d retries: %w", maxRetries+1, lastErr)
+	cards := s.convertToProductCards(result.Products, searchType)
+	for i := range cards {
+		cards[i].PageToken = s.providers.QualifyPageToken(provider.Name(), cards[i].PageToken)
 	}
-	return nil, lastKeyIndex, fmt.Errorf("SERP API failed after %d retries", maxRetries+1)
+
+	fmt.Printf("   ✅ Found %d relevant products (score: %.2f)\n\n", len(cards), result.RelevanceScore)
+
+	return cards, keyIndex, nil
 }
 
 func (s *SerpService) validateRelevance(query string, items []domain.ShoppingItem, searchType string) SearchResult {
@@ -417,86 +315,18 @@ func isCommonWord(word string) bool {
 	return false
 }
 
-func (s *SerpService) GetProductDetailsByToken(pageToken string) (map[string]interface{}, int, error) {
-	maxRetries := s.keyRotator.GetTotalKeys() + 1
-	var lastErr error
-	var lastKeyIndex int = -1
-	var lastWasQuotaError bool = false
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 && !lastWasQuotaError {
-			backoffDuration := time.Duration(500*(1<<uint(attempt-1))) * time.Millisecond
-			if backoffDuration > 2*time.Second {
-				backoffDuration = 2 * time.Second
-			}
-			fmt.Printf("   ⏳ Product details retry attempt %d/%d after %v...\n", attempt+1, maxRetries+1, backoffDuration)
-			time.Sleep(backoffDuration)
-		} else if attempt > 0 && lastWasQuotaError {
-			fmt.Printf("   🔄 Trying next key for product details (attempt %d/%d)...\n", attempt+1, maxRetries+1)
-		}
-
-		apiKey, keyIndex, err := s.keyRotator.GetNextKey()
-		if err != nil {
-			return nil, -1, fmt.Errorf("failed to get API key: %w", err)
-		}
-		lastKeyIndex = keyIndex
-		lastWasQuotaError = false
-
-		parameter := map[string]string{
-			"engine":      "google_immersive_product",
-			"page_token":  pageToken,
-			"more_stores": "true",
-		}
-
-		search := g.NewGoogleSearch(parameter, apiKey)
-		startTime := time.Now()
-		data, err := search.GetJSON()
-		elapsed := time.Since(startTime)
-
-		if err != nil {
-			lastErr = err
-			fmt.Printf("   ❌ Product details error (%.2fs, attempt %d/%d): %v\n", elapsed.Seconds(), attempt+1, maxRetries+1, err)
-
-			errMsg := err.Error()
-			isQuotaError := strings.Contains(errMsg, "run out of searches") ||
-				strings.Contains(errMsg, "quota exceeded") ||
-				strings.Contains(errMsg, "limit exceeded") ||
-				strings.Contains(errMsg, "rate limit")
-
-			isNetworkError := strings.Contains(errMsg, "timeout") ||
-				strings.Contains(errMsg, "503") ||
-				strings.Contains(errMsg, "502") ||
-				strings.Contains(errMsg, "500")
-
-			if isQuotaError {
-				fmt.Printf("   ⚠️ Quota error detected for key %d\n", keyIndex)
-				if markErr := s.keyRotator.MarkKeyAsExhausted(keyIndex); markErr != nil {
-					fmt.Printf("   ⚠️ Failed to mark key as exhausted: %v\n", markErr)
-				}
-				lastWasQuotaError = true
-				if attempt < maxRetries {
-					continue
-				}
-			} else if isNetworkError {
-				if attempt < maxRetries {
-					continue
-				}
-			}
-
-			return nil, keyIndex, fmt.Errorf("SERP API error: %w", err)
-		}
-
-		// Success!
-		if attempt > 0 {
-			fmt.Printf("   ✅ Product details request succeeded on attempt %d\n", attempt+1)
-		}
-		return data, keyIndex, nil
+// GetProductDetailsByToken fetches product details from the provider that issued the token
+func (s *SerpService) GetProductDetailsByToken(ctx context.Context, pageToken string) (map[string]interface{}, int, error) {
+	provider, token, err := s.providers.ForToken(pageToken)
+	if err != nil {
+		return nil, -1, err
 	}
 
-	if lastErr != nil {
-		return nil, lastKeyIndex, fmt.Errorf("product details failed after %d retries: %w", maxRetries+1, lastErr)
+	if !provider.Capabilities().ProductDetails {
+		return nil, -1, fmt.Errorf("search provider %s does not support product details", provider.Name())
 	}
-	return nil, lastKeyIndex, fmt.Errorf("product details failed after %d retries", maxRetries+1)
+
+	return provider.GetProductDetails(ctx, token)
 }
 
 func (s *SerpService) convertToProductCards(items []domain.ShoppingItem, searchType string) []models.ProductCard {
@@ -592,34 +422,33 @@ func getLanguageForCountry(country string) string {
 	return "en"
 }
 
-func (s *SerpService) GetProductByPageToken(pageToken string) (map[string]interface{}, int, error) {
-	return s.GetProductDetailsByToken(pageToken)
+func (s *SerpService) GetProductByPageToken(ctx context.Context, pageToken string) (map[string]interface{}, int, error) {
+	return s.GetProductDetailsByToken(ctx, pageToken)
 }
 
-func (s *SerpService) SearchWithCache(query, searchType, country string, minPrice, maxPrice *float64, cacheService *CacheService) ([]models.ProductCard, int, error) {
-	// Build cache key including price range
-	cacheKey := fmt.Sprintf("search:%s:%s:%s", country, searchType, query)
-	if minPrice != nil {
-		cacheKey += fmt.Sprintf(":min%.0f", *minPrice)
-	}
-	if maxPrice != nil {
-		cacheKey += fmt.Sprintf(":max%.0f", *maxPrice)
+func (s *SerpService) SearchWithCache(ctx context.Context, query, searchType, country string, minPrice, maxPrice *float64, cacheService *CacheService) ([]models.ProductCard, int, error) {
+	cacheQuery := &SearchCacheQuery{
+		Query:      query,
+		Country:    country,
+		SearchType: searchType,
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
 	}
 
 	if cacheService != nil {
-		if cached, err := cacheService.GetSearchResults(cacheKey); err == nil && cached != nil {
+		if cached, err := cacheService.GetSearchResults(ctx, cacheQuery); err == nil && cached != nil {
 			return cached, -1, nil
 		}
 	}
 
-	cards, keyIndex, err := s.SearchProducts(query, searchType, country, minPrice, maxPrice)
+	cards, keyIndex, err := s.SearchProducts(ctx, query, searchType, country, minPrice, maxPrice)
 	if err != nil {
 		return nil, keyIndex, err
 	}
 
 	if cacheService != nil {
 		ttl := time.Duration(s.config.CacheSerpTTL) * time.Second
-		_ = cacheService.SetSearchResults(cacheKey, cards, ttl)
+		_ = cacheService.SetSearchResults(ctx, cacheQuery, cards, ttl)
 	}
 
 	return cards, keyIndex, nil
@@ -657,3 +486,18 @@ func getFloat32FromInterface(val interface{}) float32 {
 		return 0
 	}
 }
+
+func getFloat64FromInterface(val interface{}) float64 {
+	switch v := val.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
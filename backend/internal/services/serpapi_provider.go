@@ -0,0 +1,248 @@
+// backend/internal/services/serpapi_provider.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	g "github.com/serpapi/google-search-results-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"mylittleprice/internal/domain"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/tracing"
+	"mylittleprice/internal/utils"
+)
+
+const SerpAPIProviderName = "serpapi"
+
+// SerpAPIProvider queries SerpAPI's google_shopping and
+// google_immersive_product engines with key rotation and retries
+type SerpAPIProvider struct {
+	keyRotator *utils.KeyRotator
+	costs      *CostService
+}
+
+func NewSerpAPIProvider(keyRotator *utils.KeyRotator, costs *CostService) *SerpAPIProvider {
+	return &SerpAPIProvider{
+		keyRotator: keyRotator,
+		costs:      costs,
+	}
+}
+
+func (p *SerpAPIProvider) Name() string {
+	return SerpAPIProviderName
+}
+
+func (p *SerpAPIProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		PriceFilter:    true,
+		ProductDetails: true,
+	}
+}
+
+func (p *SerpAPIProvider) Search(ctx context.Context, query, country string, minPrice, maxPrice *float64) ([]domain.ShoppingItem, int, error) {
+	// ✅ ЛОГИРУЕМ ОРИГИНАЛЬНЫЙ ЗАПРОС
+	fmt.Printf("\n🔍 SERP API Request:\n")
+	fmt.Printf("   Original Query: %s\n", query)
+	fmt.Printf("   Country: %s\n", country)
+	fmt.Printf("   Language: %s\n", getLanguageForCountry(country))
+	if minPrice != nil || maxPrice != nil {
+		fmt.Printf("   Price Range: %v - %v\n", minPrice, maxPrice)
+	}
+
+	data, keyIndex, err := p.execute(ctx, serpShoppingParameters(query, country, minPrice, maxPrice), "SERP")
+	if err != nil {
+		return nil, keyIndex, err
+	}
+
+	return parseShoppingResults(data), keyIndex, nil
+}
+
+func (p *SerpAPIProvider) GetProductDetails(ctx context.Context, pageToken string) (map[string]interface{}, int, error) {
+	return p.execute(ctx, serpImmersiveParameters(pageToken), "Product details")
+}
+
+func serpShoppingParameters(query, country string, minPrice, maxPrice *float64) map[string]string {
+	parameter := map[string]string{
+		"engine": "google_shopping",
+		"q":      query,
+		"gl":     country,
+		"hl":     getLanguageForCountry(country),
+	}
+
+	// Add price range filters if provided
+	if minPrice != nil {
+		parameter["min_price"] = fmt.Sprintf("%.0f", *minPrice)
+	}
+	if maxPrice != nil {
+		parameter["max_price"] = fmt.Sprintf("%.0f", *maxPrice)
+	}
+
+	return parameter
+}
+
+// serpCostPurpose is the purpose searches of a SerpAPI engine are recorded
+// under
+func serpCostPurpose(engine string) string {
+	if engine == "google_immersive_product" {
+		return models.CostPurposeProductDetails
+	}
+	return models.CostPurposeSearch
+}
+
+func serpImmersiveParameters(pageToken string) map[string]string {
+	return map[string]string{
+		"engine":      "google_immersive_product",
+		"page_token":  pageToken,
+		"more_stores": "true",
+	}
+}
+
+// parseShoppingResults normalizes a google_shopping response
+func parseShoppingResults(data map[string]interface{}) []domain.ShoppingItem {
+	shoppingItems := []domain.ShoppingItem{}
+
+	if shoppingResults, ok := data["shopping_results"].([]interface{}); ok {
+		fmt.Printf("   📦 Raw results: %d products\n", len(shoppingResults))
+
+		for _, item := range shoppingResults {
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				shoppingItem := domain.ShoppingItem{
+					Position:    getIntFromInterface(itemMap["position"]),
+					Title:       getStringFromInterface(itemMap["title"]),
+					Link:        getStringFromInterface(itemMap["link"]),
+					ProductLink: getStringFromInterface(itemMap["product_link"]),
+					ProductID:   getStringFromInterface(itemMap["product_id"]),
+					Thumbnail:   getStringFromInterface(itemMap["thumbnail"]),
+					Price:       getStringFromInterface(itemMap["price"]),
+					Merchant:    getStringFromInterface(itemMap["source"]),
+					Rating:      getFloat32FromInterface(itemMap["rating"]),
+					Reviews:     getIntFromInterface(itemMap["reviews"]),
+					SerpAPILink: getStringFromInterface(itemMap["serpapi_product_api"]),
+					PageToken:   getStringFromInterface(itemMap["immersive_product_page_token"]),
+				}
+				shoppingItems = append(shoppingItems, shoppingItem)
+			}
+		}
+	} else {
+		fmt.Printf("   ⚠️ No shopping_results in response\n")
+	}
+
+	return shoppingItems
+}
+
+// execute runs a SerpAPI request, rotating keys on quota errors and
+// backing off on network errors. Every attempt is recorded with the key
+// rotator, which weighs keys by health; successful searches are recorded
+// as costs of the request in ctx.
+func (p *SerpAPIProvider) execute(ctx context.Context, parameter map[string]string, label string) (map[string]interface{}, int, error) {
+	// Try up to total number of keys + 2 (for network retries)
+	maxRetries := p.keyRotator.GetTotalKeys() + 1
+	var lastErr error
+	var lastKeyIndex int = -1
+	var lastWasQuotaError bool = false
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		// Only apply backoff for network errors, not quota errors
+		if attempt > 0 && !lastWasQuotaError {
+			// Exponential backoff: 500ms, 1s, 2s
+			backoffDuration := time.Duration(500*(1<<uint(attempt-1))) * time.Millisecond
+			if backoffDuration > 2*time.Second {
+				backoffDuration = 2 * time.Second
+			}
+			fmt.Printf("   ⏳ %s retry attempt %d/%d after %v...\n", label, attempt+1, maxRetries+1, backoffDuration)
+			time.Sleep(backoffDuration)
+		} else if attempt > 0 && lastWasQuotaError {
+			fmt.Printf("   🔄 Trying next key for %s (attempt %d/%d)...\n", label, attempt+1, maxRetries+1)
+		}
+
+		apiKey, keyIndex, err := p.keyRotator.GetNextKey()
+		if err != nil {
+			return nil, -1, fmt.Errorf("failed to get API key: %w", err)
+		}
+		lastKeyIndex = keyIndex
+		lastWasQuotaError = false
+
+		fmt.Printf("   Key Index: %d (attempt %d)\n", keyIndex, attempt+1)
+
+		search := g.NewGoogleSearch(parameter, apiKey)
+
+		_, span := tracing.StartChild(ctx, "serpapi.search", trace.SpanKindClient,
+			attribute.String("serpapi.engine", parameter["engine"]),
+			attribute.Int("serpapi.key_index", keyIndex),
+			attribute.Int("serpapi.attempt", attempt+1),
+		)
+		startTime := time.Now()
+		data, err := search.GetJSON()
+		elapsed := time.Since(startTime)
+		tracing.End(span, err)
+
+		if err != nil {
+			lastErr = err
+			fmt.Printf("   ❌ %s error (%.2fs, attempt %d/%d): %v\n", label, elapsed.Seconds(), attempt+1, maxRetries+1, err)
+
+			// Check if error is retryable
+			errMsg := err.Error()
+			isQuotaError := strings.Contains(errMsg, "run out of searches") ||
+				strings.Contains(errMsg, "quota exceeded") ||
+				strings.Contains(errMsg, "limit exceeded") ||
+				strings.Contains(errMsg, "rate limit")
+
+			isNetworkError := strings.Contains(errMsg, "timeout") ||
+				strings.Contains(errMsg, "503") ||
+				strings.Contains(errMsg, "502") ||
+				strings.Contains(errMsg, "500")
+
+			if !isQuotaError {
+				// Quota errors don't count against the key's health
+				if recordErr := p.keyRotator.RecordUsage(keyIndex, false, elapsed); recordErr != nil {
+					fmt.Printf("   ⚠️ Failed to record key usage: %v\n", recordErr)
+				}
+			}
+
+			if isQuotaError {
+				// Mark this key as exhausted
+				fmt.Printf("   ⚠️ Quota error detected for key %d\n", keyIndex)
+				if markErr := p.keyRotator.MarkKeyAsExhausted(keyIndex); markErr != nil {
+					fmt.Printf("   ⚠️ Failed to mark key as exhausted: %v\n", markErr)
+				}
+				// Try next key immediately (don't wait for backoff)
+				lastWasQuotaError = true
+				if attempt < maxRetries {
+					continue
+				}
+			} else if isNetworkError {
+				// Retryable network error - continue to next attempt
+				if attempt < maxRetries {
+					continue
+				}
+			}
+
+			// Non-retryable error or last attempt
+			return nil, keyIndex, fmt.Errorf("SERP API error: %w", err)
+		}
+
+		// Success!
+		if recordErr := p.keyRotator.RecordUsage(keyIndex, true, elapsed); recordErr != nil {
+			fmt.Printf("   ⚠️ Failed to record key usage: %v\n", recordErr)
+		}
+		if attempt > 0 {
+			fmt.Printf("   ✅ %s request succeeded on attempt %d\n", label, attempt+1)
+		}
+		fmt.Printf("   ⏱️ Response time: %.2fs\n", elapsed.Seconds())
+
+		p.costs.RecordSearchCall(ctx, parameter["engine"], serpCostPurpose(parameter["engine"]))
+
+		return data, keyIndex, nil
+	}
+
+	// All retries failed
+	if lastErr != nil {
+		return nil, lastKeyIndex, fmt.Errorf("%s failed after %d retries: %w", label, maxRetries+1, lastErr)
+	}
+	return nil, lastKeyIndex, fmt.Errorf("%s failed after %d retries", label, maxRetries+1)
+}
@@ -23,11 +23,10 @@ type SessionService struct {
 	cycleService *CycleService
 	ctx          context.Context
 	ttl          time.Duration
-	maxMsgs      int
 	maxSearches  int
 }
 
-func NewSessionService(redisClient *redis.Client, client *ent.Client, cycleService *CycleService, sessionTTL int, maxMessages int) *SessionService {
+func NewSessionService(redisClient *redis.Client, client *ent.Client, cycleService *CycleService, sessionTTL int) *SessionService {
 	return &SessionService{
 		redis:        redisClient,
 		client:       client,
@@ -35,7 +34,6 @@ func NewSessionService(redisClient *redis.Client, client *ent.Client, cycleServi
 		cycleService: cycleService,
 		ctx:          context.Background(),
 		ttl:          time.Duration(sessionTTL) * time.Second,
-		maxMsgs:      maxMessages,
 		maxSearches:  999999,
 	}
 }
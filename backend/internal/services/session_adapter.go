@@ -1,12 +1,35 @@
 package services
 
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
 // SessionAdapter adapts SessionService for middleware use
 // This avoids circular dependencies by returning generic interface
 type SessionAdapter struct {
 	*SessionService
+	Shares *SessionShareService
 }
 
-// GetSession wraps SessionService.GetSession to return interface{}
+// GetSession returns the session fields read by the ownership validator
 func (a *SessionAdapter) GetSession(sessionID string) (interface{}, error) {
-	return a.SessionService.GetSession(sessionID)
+	session, err := a.SessionService.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"SessionID": session.SessionID,
+	}
+	if session.UserID != nil {
+		fields["UserID"] = session.UserID.String()
+	}
+	return fields, nil
+}
+
+// HasSessionGrant reports whether the user was invited to the session
+func (a *SessionAdapter) HasSessionGrant(sessionID string, userID uuid.UUID) bool {
+	return a.Shares.IsParticipant(context.Background(), sessionID, userID)
 }
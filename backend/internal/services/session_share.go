@@ -0,0 +1,430 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/sessionparticipant"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+var (
+	ErrSessionNotShareable   = errors.New("session not found or not owned by the user")
+	ErrInviteNotFound        = errors.New("invite not found or expired")
+	ErrParticipantLimit      = errors.New("participant limit reached")
+	ErrParticipantNotFound   = errors.New("participant not found")
+	ErrParticipantNotAllowed = errors.New("only the owner can remove other participants")
+)
+
+const (
+	sessionInviteKeyPrefix        = "session_invite:"
+	sessionPresenceKeyPrefix      = "session_presence:"
+	sessionParticipantsLockPrefix = "session_participants_lock:"
+
+	// Held while a participant is added; expires on its own if the
+	// instance dies in between
+	sessionParticipantsLockTTL = 10 * time.Second
+
+	// A connection counts as present until it has been silent this long.
+	// Clients keep presence by pinging while the session is open.
+	sessionPresenceTTL = 2 * time.Minute
+)
+
+// SessionShareService manages shared chat sessions: invite links, the
+// participant grants created by accepting them, and who currently has the
+// session open. The owner is the session's user; participants are stored
+// in PostgreSQL, invites and presence in Redis.
+type SessionShareService struct {
+	client      *ent.Client
+	redis       *redis.Client
+	sessions    *SessionService
+	authService *AuthService
+	config      *config.Config
+}
+
+func NewSessionShareService(client *ent.Client, redis *redis.Client, sessions *SessionService, authService *AuthService, cfg *config.Config) *SessionShareService {
+	return &SessionShareService{
+		client:      client,
+		redis:       redis,
+		sessions:    sessions,
+		authService: authService,
+		config:      cfg,
+	}
+}
+
+// sessionInvite is the Redis value of an invite
+type sessionInvite struct {
+	SessionID string    `json:"session_id"`
+	InvitedBy uuid.UUID `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateInvite creates an invite link to a session owned by the user.
+// Each link can be accepted once.
+func (s *SessionShareService) CreateInvite(ctx context.Context, sessionID string, ownerID uuid.UUID) (*models.SessionInvite, error) {
+	owner, err := s.sessionOwner(sessionID)
+	if err != nil || owner == nil || *owner != ownerID {
+		return nil, ErrSessionNotShareable
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	now := time.Now()
+	data, err := json.Marshal(&sessionInvite{
+		SessionID: sessionID,
+		InvitedBy: ownerID,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode invite: %w", err)
+	}
+
+	ttl := s.config.SessionInviteTTL
+	if err := s.redis.Set(ctx, inviteKey(token), data, ttl).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save invite: %w", err)
+	}
+
+	fmt.Printf("✉️ Invite created for session %s by user %s\n", sessionID, ownerID.String())
+
+	return &models.SessionInvite{
+		Token:     token,
+		InviteURL: fmt.Sprintf("%s/invite/%s", s.config.FrontendURL, token),
+		SessionID: sessionID,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// AcceptInvite grants the user access to the invited session and returns
+// the session ID. The invite is taken from Redis before anything else, so
+// concurrent accepts of one invite grant access once. Accepting an invite
+// to a session the user already has access to only consumes the invite;
+// the owner opening their own link keeps it.
+func (s *SessionShareService) AcceptInvite(ctx context.Context, token string, userID uuid.UUID) (*models.AcceptSessionInviteResponse, error) {
+	key := inviteKey(strings.TrimSpace(token))
+	raw, ttl, err := s.takeInvite(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var invite sessionInvite
+	if err := json.Unmarshal(raw, &invite); err != nil {
+		return nil, fmt.Errorf("failed to parse invite: %w", err)
+	}
+
+	// The owner may have lost the session since the invite was created
+	owner, err := s.sessionOwner(invite.SessionID)
+	if err != nil || owner == nil || *owner != invite.InvitedBy {
+		return nil, ErrInviteNotFound
+	}
+
+	response := &models.AcceptSessionInviteResponse{
+		SessionID: invite.SessionID,
+		Role:      models.SessionRoleParticipant,
+	}
+
+	if *owner == userID {
+		s.restoreInvite(ctx, key, raw, ttl)
+		response.Role = models.SessionRoleOwner
+		return response, nil
+	}
+
+	if err := s.addParticipant(ctx, &invite, userID); err != nil {
+		// Not the invitee's fault - the link stays usable
+		s.restoreInvite(ctx, key, raw, ttl)
+		return nil, err
+	}
+
+	fmt.Printf("👥 User %s joined session %s\n", userID.String(), invite.SessionID)
+	return response, nil
+}
+
+// addParticipant grants the user access to the session unless the
+// participant limit is reached. Grants of a session are serialized, so
+// concurrent accepts of different invites can't exceed the limit.
+func (s *SessionShareService) addParticipant(ctx context.Context, invite *sessionInvite, userID uuid.UUID) error {
+	unlock, err := s.lockParticipants(ctx, invite.SessionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.IsParticipant(ctx, invite.SessionID, userID) {
+		return nil
+	}
+
+	count, err := s.client.SessionParticipant.Query().
+		Where(sessionparticipant.SessionIDEQ(invite.SessionID)).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count participants: %w", err)
+	}
+	if count >= s.config.SessionMaxParticipants {
+		return ErrParticipantLimit
+	}
+
+	builder := s.client.SessionParticipant.Create().
+		SetSessionID(invite.SessionID).
+		SetUserID(userID).
+		SetInvitedBy(invite.InvitedBy)
+
+	_, err = builder.Save(ctx)
+	if err != nil && isForeignKeyError(err) {
+		// User may exist only in Redis so far
+		user, lookupErr := s.authService.GetUserByID(userID)
+		if lookupErr != nil {
+			return fmt.Errorf("failed to fetch user from Redis: %w", lookupErr)
+		}
+		if syncErr := s.authService.SaveUserToPostgres(user); syncErr != nil {
+			return fmt.Errorf("failed to sync user to PostgreSQL: %w", syncErr)
+		}
+		_, err = builder.Save(ctx)
+	}
+	if err != nil && !ent.IsConstraintError(err) { // Constraint: already a participant
+		return fmt.Errorf("failed to add participant: %w", err)
+	}
+	return nil
+}
+
+var (
+	// takeInviteScript deletes an invite and returns it with its remaining TTL
+	takeInviteScript = redis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if not value then
+	return false
+end
+local ttl = redis.call("PTTL", KEYS[1])
+redis.call("DEL", KEYS[1])
+return {value, ttl}`)
+)
+
+// takeInvite removes an invite from Redis and returns it with the time it
+// had left
+func (s *SessionShareService) takeInvite(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	result, err := takeInviteScript.Run(ctx, s.redis, []string{key}).Slice()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, 0, ErrInviteNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to load invite: %w", err)
+	}
+
+	value, _ := result[0].(string)
+	ttlMS, _ := result[1].(int64)
+	return []byte(value), time.Duration(ttlMS) * time.Millisecond, nil
+}
+
+// restoreInvite puts back an invite that was taken but not used
+func (s *SessionShareService) restoreInvite(ctx context.Context, key string, raw []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	if err := s.redis.SetNX(ctx, key, raw, ttl).Err(); err != nil {
+		fmt.Printf("⚠️ Failed to restore invite: %v\n", err)
+	}
+}
+
+// lockParticipants takes a short Redis lock on the participants of a
+// session and returns the function releasing it
+func (s *SessionShareService) lockParticipants(ctx context.Context, sessionID string) (func(), error) {
+	unlock, err := lockKey(ctx, s.redis, sessionParticipantsLockPrefix+sessionID, sessionParticipantsLockTTL)
+	if errors.Is(err, errLockBusy) {
+		return nil, errors.New("participants of the session are being updated, try again")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock participants: %w", err)
+	}
+	return unlock, nil
+}
+
+// HasAccess reports whether the user owns the session or was invited to it
+func (s *SessionShareService) HasAccess(ctx context.Context, sessionID string, userID uuid.UUID) bool {
+	if owner, err := s.sessionOwner(sessionID); err == nil && owner != nil && *owner == userID {
+		return true
+	}
+	return s.IsParticipant(ctx, sessionID, userID)
+}
+
+// IsParticipant reports whether the user accepted an invite to the session
+func (s *SessionShareService) IsParticipant(ctx context.Context, sessionID string, userID uuid.UUID) bool {
+	exists, err := s.client.SessionParticipant.Query().
+		Where(
+			sessionparticipant.SessionIDEQ(sessionID),
+			sessionparticipant.UserIDEQ(userID),
+		).
+		Exist(ctx)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to check participant %s of session %s: %v\n", userID.String(), sessionID, err)
+		return false
+	}
+	return exists
+}
+
+// IsShared reports whether anyone was invited to the session
+func (s *SessionShareService) IsShared(ctx context.Context, sessionID string) bool {
+	exists, err := s.client.SessionParticipant.Query().
+		Where(sessionparticipant.SessionIDEQ(sessionID)).
+		Exist(ctx)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to check participants of session %s: %v\n", sessionID, err)
+		return false
+	}
+	return exists
+}
+
+// ListParticipants returns the owner followed by the invited users in the
+// order they joined, with their presence
+func (s *SessionShareService) ListParticipants(ctx context.Context, sessionID string) ([]models.SessionParticipant, error) {
+	owner, err := s.sessionOwner(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.client.SessionParticipant.Query().
+		Where(sessionparticipant.SessionIDEQ(sessionID)).
+		Order(ent.Asc(sessionparticipant.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	online, err := s.onlineUsers(ctx, sessionID)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load presence of session %s: %v\n", sessionID, err)
+	}
+
+	participants := make([]models.SessionParticipant, 0, len(rows)+1)
+	if owner != nil {
+		participants = append(participants, s.participant(*owner, models.SessionRoleOwner, nil, online))
+	}
+	for _, row := range rows {
+		joinedAt := row.CreatedAt
+		participants = append(participants, s.participant(row.UserID, models.SessionRoleParticipant, &joinedAt, online))
+	}
+
+	return participants, nil
+}
+
+// RemoveParticipant revokes a participant's access. The owner can remove
+// anyone, participants only themselves.
+func (s *SessionShareService) RemoveParticipant(ctx context.Context, sessionID string, actorID, userID uuid.UUID) error {
+	if actorID != userID {
+		owner, err := s.sessionOwner(sessionID)
+		if err != nil || owner == nil || *owner != actorID {
+			return ErrParticipantNotAllowed
+		}
+	}
+
+	deleted, err := s.client.SessionParticipant.Delete().
+		Where(
+			sessionparticipant.SessionIDEQ(sessionID),
+			sessionparticipant.UserIDEQ(userID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+	if deleted == 0 {
+		return ErrParticipantNotFound
+	}
+
+	fmt.Printf("👥 User %s left session %s\n", userID.String(), sessionID)
+	return nil
+}
+
+// SetPresence marks one connection of a user as viewing the session or
+// not. Calling it again with present=true keeps the connection present.
+func (s *SessionShareService) SetPresence(ctx context.Context, sessionID string, userID uuid.UUID, connID string, present bool) error {
+	key := sessionPresenceKeyPrefix + sessionID
+	member := userID.String() + ":" + connID
+
+	if !present {
+		return s.redis.ZRem(ctx, key, member).Err()
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(time.Now().Unix()), Member: member})
+	pipe.Expire(ctx, key, sessionPresenceTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// onlineUsers returns the users with a present connection
+func (s *SessionShareService) onlineUsers(ctx context.Context, sessionID string) (map[uuid.UUID]bool, error) {
+	key := sessionPresenceKeyPrefix + sessionID
+	cutoff := time.Now().Add(-sessionPresenceTTL).Unix()
+
+	// Connections of crashed servers are never removed explicitly
+	if err := s.redis.ZRemRangeByScore(ctx, key, "-inf", fmt.Sprintf("(%d", cutoff)).Err(); err != nil {
+		return nil, err
+	}
+
+	members, err := s.redis.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	online := make(map[uuid.UUID]bool, len(members))
+	for _, member := range members {
+		id, _, _ := strings.Cut(member, ":")
+		if userID, err := uuid.Parse(id); err == nil {
+			online[userID] = true
+		}
+	}
+	return online, nil
+}
+
+// GetParticipant describes a user of the session, e.g. the author of a
+// mirrored message
+func (s *SessionShareService) GetParticipant(sessionID string, userID uuid.UUID) *models.SessionParticipant {
+	role := models.SessionRoleParticipant
+	if owner, err := s.sessionOwner(sessionID); err == nil && owner != nil && *owner == userID {
+		role = models.SessionRoleOwner
+	}
+	participant := s.participant(userID, role, nil, map[uuid.UUID]bool{userID: true})
+	return &participant
+}
+
+func (s *SessionShareService) participant(userID uuid.UUID, role string, joinedAt *time.Time, online map[uuid.UUID]bool) models.SessionParticipant {
+	participant := models.SessionParticipant{
+		UserID:   userID,
+		Role:     role,
+		Online:   online[userID],
+		JoinedAt: joinedAt,
+	}
+	if user, err := s.authService.GetUserByID(userID); err == nil {
+		participant.Email = user.Email
+		participant.FullName = user.FullName
+		participant.Picture = user.Picture
+	}
+	return participant
+}
+
+// sessionOwner returns the user of a session, nil for anonymous sessions
+func (s *SessionShareService) sessionOwner(sessionID string) (*uuid.UUID, error) {
+	session, err := s.sessions.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return session.UserID, nil
+}
+
+func inviteKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return sessionInviteKeyPrefix + hex.EncodeToString(hash[:])
+}
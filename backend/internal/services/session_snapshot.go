@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/sessionsnapshot"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found, revoked or expired")
+	ErrSnapshotEmpty    = errors.New("session has no messages to share")
+)
+
+const (
+	// Longest expiry a snapshot can be created with
+	maxSnapshotExpiryDays = 365
+
+	snapshotTitleMaxRunes = 80
+)
+
+var (
+	snapshotEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// International numbers only - prices and quantities have no leading "+"
+	snapshotPhonePattern = regexp.MustCompile(`\+\d[\d\s().\-]{6,}\d`)
+)
+
+// SessionSnapshotService freezes chat sessions into read-only snapshots
+// that can be opened by anyone with the link. Only the session's owner can
+// share it and revoke the snapshot later.
+type SessionSnapshotService struct {
+	client      *ent.Client
+	sessions    *SessionService
+	messages    *MessageService
+	authService *AuthService
+	config      *config.Config
+}
+
+func NewSessionSnapshotService(client *ent.Client, sessions *SessionService, messages *MessageService, authService *AuthService, cfg *config.Config) *SessionSnapshotService {
+	return &SessionSnapshotService{
+		client:      client,
+		sessions:    sessions,
+		messages:    messages,
+		authService: authService,
+		config:      cfg,
+	}
+}
+
+// CreateSnapshot copies the current messages of a session owned by the
+// user. expiresInDays of 0 creates a snapshot that never expires.
+func (s *SessionSnapshotService) CreateSnapshot(ctx context.Context, sessionID string, ownerID uuid.UUID, expiresInDays int) (*models.SessionSnapshot, error) {
+	if err := s.checkOwner(sessionID, ownerID); err != nil {
+		return nil, err
+	}
+	if expiresInDays > maxSnapshotExpiryDays {
+		expiresInDays = maxSnapshotExpiryDays
+	}
+
+	messages, err := s.messages.GetMessages(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+
+	frozen := make([]models.SnapshotMessage, 0, len(messages))
+	title := ""
+	for _, msg := range messages {
+		if msg == nil || strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		snapshotMsg := sanitizeSnapshotMessage(msg)
+		if title == "" && snapshotMsg.Role == "user" {
+			title = snapshotTitle(snapshotMsg.Content)
+		}
+		frozen = append(frozen, snapshotMsg)
+	}
+	if len(frozen) == 0 {
+		return nil, ErrSnapshotEmpty
+	}
+
+	data, err := json.Marshal(frozen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	slug, err := generateSnapshotSlug()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := s.client.SessionSnapshot.Create().
+		SetSlug(slug).
+		SetSessionID(sessionID).
+		SetUserID(ownerID).
+		SetTitle(title).
+		SetMessages(data).
+		SetMessageCount(len(frozen))
+	if expiresInDays > 0 {
+		builder.SetExpiresAt(time.Now().AddDate(0, 0, expiresInDays))
+	}
+
+	snapshot, err := builder.Save(ctx)
+	if err != nil && isForeignKeyError(err) {
+		// User may exist only in Redis so far
+		user, lookupErr := s.authService.GetUserByID(ownerID)
+		if lookupErr != nil {
+			return nil, fmt.Errorf("failed to fetch user from Redis: %w", lookupErr)
+		}
+		if syncErr := s.authService.SaveUserToPostgres(user); syncErr != nil {
+			return nil, fmt.Errorf("failed to sync user to PostgreSQL: %w", syncErr)
+		}
+		snapshot, err = builder.Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	fmt.Printf("📸 Snapshot %s of session %s created by user %s (%d messages)\n", slug, sessionID, ownerID.String(), len(frozen))
+	return s.toModel(snapshot), nil
+}
+
+// GetSharedSnapshot returns the public view of a snapshot that is neither
+// revoked nor expired
+func (s *SessionSnapshotService) GetSharedSnapshot(ctx context.Context, slug string) (*models.SharedSnapshot, error) {
+	snapshot, err := s.client.SessionSnapshot.Query().
+		Where(
+			sessionsnapshot.SlugEQ(strings.TrimSpace(slug)),
+			sessionsnapshot.RevokedAtIsNil(),
+			sessionsnapshot.Or(
+				sessionsnapshot.ExpiresAtIsNil(),
+				sessionsnapshot.ExpiresAtGT(time.Now()),
+			),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to load snapshot: %w", err)
+	}
+
+	var messages []models.SnapshotMessage
+	if err := json.Unmarshal(snapshot.Messages, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	return &models.SharedSnapshot{
+		Slug:      snapshot.Slug,
+		Title:     snapshot.Title,
+		Messages:  messages,
+		ExpiresAt: snapshot.ExpiresAt,
+		CreatedAt: snapshot.CreatedAt,
+	}, nil
+}
+
+// ListSnapshots returns the snapshots the owner made of a session, newest
+// first, including revoked and expired ones
+func (s *SessionSnapshotService) ListSnapshots(ctx context.Context, sessionID string, ownerID uuid.UUID) ([]models.SessionSnapshot, error) {
+	rows, err := s.client.SessionSnapshot.Query().
+		Where(
+			sessionsnapshot.UserIDEQ(ownerID),
+			sessionsnapshot.SessionIDEQ(sessionID),
+		).
+		Order(ent.Desc(sessionsnapshot.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	snapshots := make([]models.SessionSnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, *s.toModel(row))
+	}
+	return snapshots, nil
+}
+
+// ListUserSnapshots returns every snapshot the user made, newest first
+func (s *SessionSnapshotService) ListUserSnapshots(ctx context.Context, ownerID uuid.UUID) ([]models.SessionSnapshot, error) {
+	rows, err := s.client.SessionSnapshot.Query().
+		Where(sessionsnapshot.UserIDEQ(ownerID)).
+		Order(ent.Desc(sessionsnapshot.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	snapshots := make([]models.SessionSnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, *s.toModel(row))
+	}
+	return snapshots, nil
+}
+
+// RevokeSnapshot makes a snapshot unavailable. Revoking twice is a no-op.
+func (s *SessionSnapshotService) RevokeSnapshot(ctx context.Context, sessionID, slug string, ownerID uuid.UUID) error {
+	updated, err := s.client.SessionSnapshot.Update().
+		Where(
+			sessionsnapshot.SlugEQ(slug),
+			sessionsnapshot.SessionIDEQ(sessionID),
+			sessionsnapshot.UserIDEQ(ownerID),
+			sessionsnapshot.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke snapshot: %w", err)
+	}
+	if updated == 0 {
+		exists, err := s.client.SessionSnapshot.Query().
+			Where(
+				sessionsnapshot.SlugEQ(slug),
+				sessionsnapshot.SessionIDEQ(sessionID),
+				sessionsnapshot.UserIDEQ(ownerID),
+			).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check snapshot: %w", err)
+		}
+		if !exists {
+			return ErrSnapshotNotFound
+		}
+		return nil
+	}
+
+	fmt.Printf("📸 Snapshot %s of session %s revoked\n", slug, sessionID)
+	return nil
+}
+
+// checkOwner allows only the session's user; participants of a shared
+// session can't publish it
+func (s *SessionSnapshotService) checkOwner(sessionID string, userID uuid.UUID) error {
+	session, err := s.sessions.GetSession(sessionID)
+	if err != nil || session.UserID == nil || *session.UserID != userID {
+		return ErrSessionNotShareable
+	}
+	return nil
+}
+
+func (s *SessionSnapshotService) toModel(snapshot *ent.SessionSnapshot) *models.SessionSnapshot {
+	return &models.SessionSnapshot{
+		Slug:         snapshot.Slug,
+		ShareURL:     fmt.Sprintf("%s/shared/%s", s.config.FrontendURL, snapshot.Slug),
+		SessionID:    snapshot.SessionID,
+		Title:        snapshot.Title,
+		MessageCount: snapshot.MessageCount,
+		ExpiresAt:    snapshot.ExpiresAt,
+		RevokedAt:    snapshot.RevokedAt,
+		CreatedAt:    snapshot.CreatedAt,
+	}
+}
+
+// sanitizeSnapshotMessage keeps only what a reader of the conversation
+// sees. IDs, search metadata and quick replies are dropped; emails and phone
+// numbers typed into the chat are masked.
+func sanitizeSnapshotMessage(msg *models.Message) models.SnapshotMessage {
+	snapshotMsg := models.SnapshotMessage{
+		Role:         msg.Role,
+		Content:      redactPersonalData(msg.Content),
+		ResponseType: msg.ResponseType,
+		CreatedAt:    msg.CreatedAt,
+	}
+	if len(msg.Products) > 0 {
+		snapshotMsg.Products = make([]models.ProductCard, len(msg.Products))
+		copy(snapshotMsg.Products, msg.Products)
+	}
+	return snapshotMsg
+}
+
+func redactPersonalData(text string) string {
+	text = snapshotEmailPattern.ReplaceAllString(text, "[email hidden]")
+	return snapshotPhonePattern.ReplaceAllString(text, "[phone hidden]")
+}
+
+func snapshotTitle(content string) string {
+	title := strings.Join(strings.Fields(content), " ")
+	runes := []rune(title)
+	if len(runes) > snapshotTitleMaxRunes {
+		return string(runes[:snapshotTitleMaxRunes-1]) + "…"
+	}
+	return title
+}
+
+// generateSnapshotSlug returns 128 random bits, URL-safe
+func generateSnapshotSlug() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate snapshot slug: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/ent"
+	"mylittleprice/ent/user"
+	"mylittleprice/internal/models"
+	"mylittleprice/internal/utils"
+)
+
+// Optional TOTP two-factor authentication. The secret, encrypted with
+// TOTP_ENCRYPTION_KEY, and the hashed recovery codes live in PostgreSQL
+// only - the Redis user cache never sees them. Redis keeps the short-lived
+// state:
+//
+//	2fa_enrollment:<user id>       secret waiting for the first code
+//	2fa_challenge:<token hash>     login waiting for the second factor
+//	2fa_used_step:<user id>:<step> time step of an accepted code, against replays
+
+const (
+	twoFactorIssuer         = "MyLittlePrice"
+	twoFactorEnrollmentTTL  = 10 * time.Minute
+	twoFactorChallengeTTL   = 5 * time.Minute
+	twoFactorMaxAttempts    = 5
+	twoFactorRecoveryCodes  = 10
+	twoFactorRecoveryLength = 10 // Characters, shown as two groups of five
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNoEnrollment   = errors.New("no pending two-factor enrolment")
+	ErrTwoFactorInvalidCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorChallenge      = errors.New("two-factor challenge not found or expired")
+	ErrTwoFactorUnavailable    = errors.New("two-factor authentication is not configured")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GetTwoFactorStatus reports whether 2FA is on and how many recovery codes
+// are left
+func (s *AuthService) GetTwoFactorStatus(userID uuid.UUID) (*models.TwoFactorStatus, error) {
+	entUser, err := s.client.User.Get(s.ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return &models.TwoFactorStatus{}, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &models.TwoFactorStatus{
+		Enabled:                entUser.TotpEnabled,
+		RecoveryCodesRemaining: len(entUser.TotpRecoveryCodes),
+	}, nil
+}
+
+// BeginTwoFactorEnrollment generates a secret for the user's authenticator
+// app. Nothing changes until ConfirmTwoFactorEnrollment gets a valid code;
+// starting again replaces the pending secret.
+func (s *AuthService) BeginTwoFactorEnrollment(userID uuid.UUID) (*models.TwoFactorEnrollment, error) {
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	enabled, err := s.isTwoFactorEnabled(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if s.totpBox == nil {
+		return nil, ErrTwoFactorUnavailable
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	key := fmt.Sprintf("2fa_enrollment:%s", userID.String())
+	if err := s.redis.Set(s.ctx, key, secret, twoFactorEnrollmentTTL).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save enrolment: %w", err)
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(twoFactorIssuer, userModel.Email, secret),
+		ExpiresAt:  time.Now().Add(twoFactorEnrollmentTTL),
+	}, nil
+}
+
+// ConfirmTwoFactorEnrollment turns 2FA on once the app produced a valid
+// code. It revokes every refresh token of the user and returns new tokens
+// for the current device with the recovery codes, which are never shown
+// again.
+func (s *AuthService) ConfirmTwoFactorEnrollment(userID uuid.UUID, code string, device *models.DeviceInfo) (*models.TwoFactorEnabledResponse, error) {
+	key := fmt.Sprintf("2fa_enrollment:%s", userID.String())
+	secret, err := s.redis.Get(s.ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrTwoFactorNoEnrollment
+		}
+		return nil, fmt.Errorf("failed to get enrolment: %w", err)
+	}
+
+	step, ok := utils.ValidateTOTPCode(secret, code, time.Now())
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Users created before PostgreSQL sync may only exist in Redis
+	if _, err := s.client.User.Get(s.ctx, userID); ent.IsNotFound(err) {
+		if err := s.SaveUserToPostgres(userModel); err != nil {
+			return nil, fmt.Errorf("failed to sync user to PostgreSQL: %w", err)
+		}
+	}
+
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	if s.totpBox == nil {
+		return nil, ErrTwoFactorUnavailable
+	}
+	sealedSecret, err := s.totpBox.Seal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	err = s.client.User.UpdateOneID(userID).
+		Where(user.TotpEnabled(false)).
+		SetTotpEnabled(true).
+		SetTotpSecret(sealedSecret).
+		SetTotpRecoveryCodes(hashes).
+		SetUpdatedAt(time.Now()).
+		Exec(s.ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrTwoFactorAlreadyEnabled
+		}
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	s.redis.Del(s.ctx, key)
+	s.markTOTPStepUsed(userID, step)
+
+	if err := s.revokeAllUserRefreshTokens(userID); err != nil {
+		fmt.Printf("⚠️ Failed to revoke refresh tokens after enabling 2FA for user %s: %v\n", userID.String(), err)
+	}
+
+	authResp, err := s.generateAuthResponse(userModel, device)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🔐 Two-factor authentication enabled for user %s\n", userID.String())
+	return &models.TwoFactorEnabledResponse{
+		AuthResponse:  *authResp,
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableTwoFactor turns 2FA off after a valid TOTP or recovery code. Like
+// enabling, it revokes every refresh token and returns new tokens for the
+// current device.
+func (s *AuthService) DisableTwoFactor(userID uuid.UUID, code string, device *models.DeviceInfo) (*models.AuthResponse, error) {
+	if err := s.checkSecondFactor(userID, code); err != nil {
+		return nil, err
+	}
+
+	err := s.client.User.UpdateOneID(userID).
+		SetTotpEnabled(false).
+		ClearTotpSecret().
+		ClearTotpRecoveryCodes().
+		SetUpdatedAt(time.Now()).
+		Exec(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	if err := s.revokeAllUserRefreshTokens(userID); err != nil {
+		fmt.Printf("⚠️ Failed to revoke refresh tokens after disabling 2FA for user %s: %v\n", userID.String(), err)
+	}
+
+	userModel, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	fmt.Printf("🔓 Two-factor authentication disabled for user %s\n", userID.String())
+	return s.generateAuthResponse(userModel, device)
+}
+
+// VerifyTwoFactorChallenge completes a login that returned a challenge.
+// A challenge allows a few attempts and works once.
+func (s *AuthService) VerifyTwoFactorChallenge(challengeToken, code string, device *models.DeviceInfo) (*models.AuthResponse, error) {
+	key := fmt.Sprintf("2fa_challenge:%s", s.hashToken(challengeToken))
+
+	attempts, err := s.redis.HIncrBy(s.ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attempt: %w", err)
+	}
+	challenge, err := s.redis.HGetAll(s.ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	// Unknown or expired tokens leave a bare counter behind; drop it
+	userID, err := uuid.Parse(challenge["user_id"])
+	if err != nil || attempts > twoFactorMaxAttempts {
+		s.redis.Del(s.ctx, key)
+		return nil, ErrTwoFactorChallenge
+	}
+
+	if err := s.checkSecondFactor(userID, code); err != nil {
+		return nil, err
+	}
+
+	// Two requests with valid codes must not both get tokens
+	deleted, err := s.redis.Del(s.ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume challenge: %w", err)
+	}
+	if deleted == 0 {
+		return nil, ErrTwoFactorChallenge
+	}
+
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	user.LastLoginAt = &now
+	if err := s.saveUser(user); err != nil {
+		fmt.Printf("Warning: failed to update last login time: %v\n", err)
+	}
+
+	return s.generateAuthResponse(user, device)
+}
+
+// ==================== Two-Factor Helpers ====================
+
+// startTwoFactorChallenge returns a challenge for users with 2FA on and nil
+// for the others. The flag is read from PostgreSQL, not the Redis cache.
+func (s *AuthService) startTwoFactorChallenge(userID uuid.UUID) (*models.TwoFactorChallenge, error) {
+	enabled, err := s.isTwoFactorEnabled(userID)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	challengeToken, err := s.jwtService.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+
+	key := fmt.Sprintf("2fa_challenge:%s", s.hashToken(challengeToken))
+	if err := s.redis.HSet(s.ctx, key, map[string]interface{}{
+		"user_id":  userID.String(),
+		"attempts": 0,
+	}).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save challenge: %w", err)
+	}
+	if err := s.redis.Expire(s.ctx, key, twoFactorChallengeTTL).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save challenge: %w", err)
+	}
+
+	return &models.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+		ExpiresIn:         int64(twoFactorChallengeTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) isTwoFactorEnabled(userID uuid.UUID) (bool, error) {
+	entUser, err := s.client.User.Get(s.ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check two-factor status: %w", err)
+	}
+	return entUser.TotpEnabled, nil
+}
+
+// checkSecondFactor accepts a current TOTP code, each time step once, or an
+// unused recovery code, which it uses up
+func (s *AuthService) checkSecondFactor(userID uuid.UUID, code string) error {
+	entUser, err := s.client.User.Get(s.ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrTwoFactorNotEnabled
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !entUser.TotpEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	secret, err := s.openTOTPSecret(entUser)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := utils.ValidateTOTPCode(secret, code, time.Now()); ok {
+		if !s.markTOTPStepUsed(userID, step) {
+			return ErrTwoFactorInvalidCode
+		}
+		return nil
+	}
+
+	return s.useRecoveryCode(entUser, code)
+}
+
+// openTOTPSecret decrypts the user's TOTP secret. Secrets stored before
+// encryption are read as they are and encrypted in place.
+func (s *AuthService) openTOTPSecret(entUser *ent.User) (string, error) {
+	if !utils.IsSealed(entUser.TotpSecret) {
+		if s.totpBox != nil {
+			s.sealTOTPSecret(entUser)
+		}
+		return entUser.TotpSecret, nil
+	}
+
+	if s.totpBox == nil {
+		return "", ErrTwoFactorUnavailable
+	}
+	secret, err := s.totpBox.Open(entUser.TotpSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	return secret, nil
+}
+
+// sealTOTPSecret encrypts a secret stored in plaintext. It only writes if
+// the secret is unchanged, so a concurrent disable or re-enrolment wins.
+func (s *AuthService) sealTOTPSecret(entUser *ent.User) {
+	sealed, err := s.totpBox.Seal(entUser.TotpSecret)
+	if err == nil {
+		err = s.client.User.Update().
+			Where(user.ID(entUser.ID), user.TotpSecret(entUser.TotpSecret)).
+			SetTotpSecret(sealed).
+			Exec(s.ctx)
+	}
+	if err != nil {
+		fmt.Printf("⚠️ Failed to encrypt TOTP secret of user %s: %v\n", entUser.ID.String(), err)
+	}
+}
+
+// markTOTPStepUsed records the time step of an accepted code and reports
+// false if the step was used before. One SET NX decides between concurrent
+// logins; a failed write counts as used.
+func (s *AuthService) markTOTPStepUsed(userID uuid.UUID, step int64) bool {
+	key := fmt.Sprintf("2fa_used_step:%s:%d", userID.String(), step)
+	ttl := time.Duration(2*utils.TOTPSkew+1) * utils.TOTPPeriod
+
+	marked, err := s.redis.SetNX(s.ctx, key, "1", ttl).Result()
+	if err != nil {
+		fmt.Printf("⚠️ Failed to record 2FA step for user %s: %v\n", userID.String(), err)
+		return false
+	}
+	return marked
+}
+
+func (s *AuthService) useRecoveryCode(entUser *ent.User, code string) error {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != twoFactorRecoveryLength {
+		return ErrTwoFactorInvalidCode
+	}
+	codeHash := s.hashToken(normalized)
+
+	remaining := make([]string, 0, len(entUser.TotpRecoveryCodes))
+	found := false
+	for _, hash := range entUser.TotpRecoveryCodes {
+		if hash == codeHash && !found {
+			found = true
+			continue
+		}
+		remaining = append(remaining, hash)
+	}
+	if !found {
+		return ErrTwoFactorInvalidCode
+	}
+
+	// Conditional on updated_at, so concurrent requests can't use the same
+	// code twice
+	n, err := s.client.User.Update().
+		Where(user.IDEQ(entUser.ID), user.UpdatedAtEQ(entUser.UpdatedAt)).
+		SetTotpRecoveryCodes(remaining).
+		SetUpdatedAt(time.Now()).
+		Save(s.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if n == 0 {
+		return ErrTwoFactorInvalidCode
+	}
+
+	fmt.Printf("🔑 Recovery code used by user %s (%d left)\n", entUser.ID.String(), len(remaining))
+	return nil
+}
+
+// generateRecoveryCodes returns the codes to show and the hashes to store
+func (s *AuthService) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, twoFactorRecoveryCodes)
+	hashes := make([]string, 0, twoFactorRecoveryCodes)
+	for i := 0; i < twoFactorRecoveryCodes; i++ {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:twoFactorRecoveryLength]
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, s.hashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts codes typed with or without the dash, in
+// any case
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	s := &AuthService{}
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("generateRecoveryCodes: %v", err)
+	}
+	if len(codes) != twoFactorRecoveryCodes || len(hashes) != twoFactorRecoveryCodes {
+		t.Fatalf("got %d codes and %d hashes, want %d of each", len(codes), len(hashes), twoFactorRecoveryCodes)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		if len(code) != twoFactorRecoveryLength+1 || code[5] != '-' {
+			t.Errorf("code %q is not two groups of five", code)
+		}
+		if code != strings.ToLower(code) {
+			t.Errorf("code %q is not lowercase", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q generated twice", code)
+		}
+		seen[code] = true
+
+		// The stored hash must match the code however the user types it
+		for _, typed := range []string{code, strings.ToUpper(code), strings.ReplaceAll(code, "-", ""), " " + code + " "} {
+			if got := s.hashToken(normalizeRecoveryCode(typed)); got != hashes[i] {
+				t.Errorf("typed code %q doesn't match the stored hash", typed)
+			}
+		}
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+		{" abcde fghij ", "abcdefghij"},
+		{"ab-cd-ef-gh-ij", "abcdefghij"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRecoveryCode(tt.code); got != tt.want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	PromptIDUniversal = "UniversalPrompt v1.0.1"
+	PromptIDUniversal = "UniversalPrompt v2.0.0"
 	MaxIterations     = 6
 )
 
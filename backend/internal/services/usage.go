@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+// Counters outlive their day so late requests around midnight still find them
+const usageCounterTTL = 48 * time.Hour
+
+// UsageService enforces the daily allowances of usage plans. Counters live
+// in Redis under usage:<subject>:<dimension>:<UTC date>. Redis errors fail
+// open: a visitor is never blocked because usage can't be counted.
+type UsageService struct {
+	redis       *redis.Client
+	authService *AuthService
+	config      *config.Config
+}
+
+func NewUsageService(redisClient *redis.Client, authService *AuthService, cfg *config.Config) *UsageService {
+	return &UsageService{
+		redis:       redisClient,
+		authService: authService,
+		config:      cfg,
+	}
+}
+
+// UsageSubject is who usage is counted for
+type UsageSubject struct {
+	Key        string // "user:<id>" or "browser:<id>"; empty for anonymous visitors without a browser ID, who can't be counted
+	Plan       string
+	Unverified bool // Signed in with an unverified email while RESTRICT_UNVERIFIED_USERS is on
+}
+
+// Subject returns the plan of a visitor and the key their usage is counted
+// under. Users whose plan can't be read are treated as free users.
+func (s *UsageService) Subject(ctx context.Context, userID *uuid.UUID, browserID string) *UsageSubject {
+	if userID == nil {
+		subject := &UsageSubject{Plan: models.PlanAnonymous}
+		if browserID != "" {
+			subject.Key = "browser:" + browserID
+		}
+		return subject
+	}
+
+	subject := &UsageSubject{Key: "user:" + userID.String()}
+	if s.config.RestrictUnverifiedUsers && !s.authService.IsEmailVerified(*userID) {
+		subject.Plan = models.PlanAnonymous
+		subject.Unverified = true
+		return subject
+	}
+
+	plan, err := s.authService.GetUserPlan(ctx, *userID)
+	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			fmt.Printf("⚠️ Failed to get plan of user %s: %v\n", userID.String(), err)
+		}
+		plan = models.PlanFree
+	}
+	subject.Plan = plan
+	return subject
+}
+
+// Limit returns the daily limit of a plan in a dimension, -1 if unlimited
+func (s *UsageService) Limit(plan, dimension string) int {
+	limits := s.planLimits(plan)
+	switch dimension {
+	case models.UsageSearches:
+		return limits.DailySearches
+	case models.UsageMessages:
+		return limits.DailyMessages
+	case models.UsageGrounding:
+		return limits.DailyGrounding
+	case models.UsageProductDetails:
+		return limits.DailyProductDetails
+	}
+	return -1
+}
+
+// WSMessagesPerMinute returns the WebSocket message rate of a plan, -1 if
+// unlimited
+func (s *UsageService) WSMessagesPerMinute(plan string) int {
+	return s.planLimits(plan).WSMessagesPerMinute
+}
+
+// Allow reports whether the subject has allowance left in a dimension
+// without using it
+func (s *UsageService) Allow(ctx context.Context, subject *UsageSubject, dimension string) bool {
+	limit := s.Limit(subject.Plan, dimension)
+	if subject.Key == "" || limit < 0 {
+		return true
+	}
+
+	used, err := s.used(ctx, subject.Key, dimension, usageDay(time.Now()))
+	if err != nil {
+		fmt.Printf("⚠️ Failed to read %s usage of %s: %v\n", dimension, subject.Key, err)
+		return true
+	}
+	return used < limit
+}
+
+// Consume uses one unit of the subject's allowance in a dimension. It
+// returns false, without counting, once the daily limit is reached.
+func (s *UsageService) Consume(ctx context.Context, subject *UsageSubject, dimension string) bool {
+	return s.ConsumeN(ctx, subject, dimension, 1)
+}
+
+// ConsumeN uses n units at once, e.g. one product details lookup per
+// compared product. It returns false, without counting any, unless all n
+// fit in today's allowance.
+func (s *UsageService) ConsumeN(ctx context.Context, subject *UsageSubject, dimension string, n int) bool {
+	if subject.Key == "" || n <= 0 {
+		return true
+	}
+
+	key := usageKey(subject.Key, dimension, usageDay(time.Now()))
+	count, err := s.increment(ctx, key, n)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to count %s usage of %s: %v\n", dimension, subject.Key, err)
+		return true
+	}
+
+	limit := s.Limit(subject.Plan, dimension)
+	if limit >= 0 && count > int64(limit) {
+		// Over the limit - give the units back so the counter shows real use
+		if err := s.redis.DecrBy(ctx, key, int64(n)).Err(); err != nil {
+			fmt.Printf("⚠️ Failed to correct %s usage of %s: %v\n", dimension, subject.Key, err)
+		}
+		return false
+	}
+	return true
+}
+
+// Record counts one unit of use regardless of the limit, for work that is
+// checked with Allow up front and only counted when it succeeded
+func (s *UsageService) Record(ctx context.Context, subject *UsageSubject, dimension string) {
+	if subject.Key == "" {
+		return
+	}
+
+	if _, err := s.increment(ctx, usageKey(subject.Key, dimension, usageDay(time.Now())), 1); err != nil {
+		fmt.Printf("⚠️ Failed to count %s usage of %s: %v\n", dimension, subject.Key, err)
+	}
+}
+
+// Quota returns today's use and remaining allowance of every dimension
+func (s *UsageService) Quota(ctx context.Context, subject *UsageSubject) *models.Quota {
+	now := time.Now().UTC()
+	day := usageDay(now)
+
+	dimensions := []string{models.UsageSearches, models.UsageMessages, models.UsageGrounding, models.UsageProductDetails}
+	used := make([]int, len(dimensions))
+	if subject.Key != "" {
+		keys := make([]string, len(dimensions))
+		for i, dimension := range dimensions {
+			keys[i] = usageKey(subject.Key, dimension, day)
+		}
+		values, err := s.redis.MGet(ctx, keys...).Result()
+		if err != nil {
+			fmt.Printf("⚠️ Failed to read usage of %s: %v\n", subject.Key, err)
+		}
+		for i, value := range values {
+			if str, ok := value.(string); ok {
+				used[i], _ = strconv.Atoi(str)
+			}
+		}
+	}
+
+	quota := func(i int) models.UsageQuota {
+		limit := s.Limit(subject.Plan, dimensions[i])
+		q := models.UsageQuota{Used: used[i], Limit: limit, Remaining: -1}
+		if limit >= 0 {
+			q.Remaining = max(limit-used[i], 0)
+		}
+		return q
+	}
+
+	return &models.Quota{
+		Plan:           subject.Plan,
+		Searches:       quota(0),
+		Messages:       quota(1),
+		Grounding:      quota(2),
+		ProductDetails: quota(3),
+		ResetsAt:       time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// planLimits returns the limits of a plan. Unknown plans, e.g. one removed
+// from the code while users still have it, get the free plan.
+func (s *UsageService) planLimits(plan string) config.PlanLimits {
+	if limits, ok := s.config.Plans[plan]; ok {
+		return limits
+	}
+	return s.config.Plans[models.PlanFree]
+}
+
+func (s *UsageService) used(ctx context.Context, subjectKey, dimension, day string) (int, error) {
+	count, err := s.redis.Get(ctx, usageKey(subjectKey, dimension, day)).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
+
+func (s *UsageService) increment(ctx context.Context, key string, n int) (int64, error) {
+	pipe := s.redis.Pipeline()
+	incrCmd := pipe.IncrBy(ctx, key, int64(n))
+	pipe.Expire(ctx, key, usageCounterTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incrCmd.Val(), nil
+}
+
+func usageKey(subjectKey, dimension, day string) string {
+	return fmt.Sprintf("usage:%s:%s:%s", subjectKey, dimension, day)
+}
+
+// usageDay is the UTC date allowances are counted for
+func usageDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+func newTestUsageService() *UsageService {
+	return NewUsageService(nil, nil, &config.Config{
+		Plans: map[string]config.PlanLimits{
+			models.PlanAnonymous: {DailySearches: 3, DailyMessages: 30, DailyGrounding: 10, DailyProductDetails: 20, WSMessagesPerMinute: 20},
+			models.PlanFree:      {DailySearches: 50, DailyMessages: 200, DailyGrounding: 100, DailyProductDetails: 100, WSMessagesPerMinute: 50},
+			models.PlanPro:       {DailySearches: 500, DailyMessages: 2000, DailyGrounding: 1000, DailyProductDetails: 1000, WSMessagesPerMinute: 120},
+			models.PlanInternal:  {DailySearches: -1, DailyMessages: -1, DailyGrounding: -1, DailyProductDetails: -1, WSMessagesPerMinute: -1},
+		},
+	})
+}
+
+func TestUsageLimit(t *testing.T) {
+	s := newTestUsageService()
+
+	tests := []struct {
+		plan      string
+		dimension string
+		want      int
+	}{
+		{models.PlanAnonymous, models.UsageSearches, 3},
+		{models.PlanAnonymous, models.UsageProductDetails, 20},
+		{models.PlanFree, models.UsageMessages, 200},
+		{models.PlanFree, models.UsageGrounding, 100},
+		{models.PlanPro, models.UsageSearches, 500},
+		{models.PlanPro, models.UsageProductDetails, 1000},
+		{models.PlanInternal, models.UsageSearches, -1},
+		{models.PlanInternal, models.UsageMessages, -1},
+		// Plans removed from the config fall back to the free plan
+		{"enterprise", models.UsageSearches, 50},
+		{"", models.UsageMessages, 200},
+		// Dimensions without a limit
+		{models.PlanAnonymous, "uploads", -1},
+	}
+
+	for _, tt := range tests {
+		if got := s.Limit(tt.plan, tt.dimension); got != tt.want {
+			t.Errorf("Limit(%q, %q) = %d, want %d", tt.plan, tt.dimension, got, tt.want)
+		}
+	}
+}
+
+func TestUsageWSMessagesPerMinute(t *testing.T) {
+	s := newTestUsageService()
+
+	tests := []struct {
+		plan string
+		want int
+	}{
+		{models.PlanAnonymous, 20},
+		{models.PlanFree, 50},
+		{models.PlanPro, 120},
+		{models.PlanInternal, -1},
+		{"enterprise", 50},
+	}
+
+	for _, tt := range tests {
+		if got := s.WSMessagesPerMinute(tt.plan); got != tt.want {
+			t.Errorf("WSMessagesPerMinute(%q) = %d, want %d", tt.plan, got, tt.want)
+		}
+	}
+}
+
+func TestUsageAnonymousSubject(t *testing.T) {
+	s := newTestUsageService()
+	ctx := context.Background()
+
+	subject := s.Subject(ctx, nil, "browser-1")
+	if subject.Plan != models.PlanAnonymous || subject.Key != "browser:browser-1" {
+		t.Errorf("Subject(nil, browser-1) = %+v, want the anonymous plan counted per browser", subject)
+	}
+
+	// Visitors without a browser ID can't be counted and are never refused
+	subject = s.Subject(ctx, nil, "")
+	if subject.Plan != models.PlanAnonymous || subject.Key != "" {
+		t.Errorf("Subject(nil, \"\") = %+v, want the anonymous plan without a key", subject)
+	}
+	if !s.Allow(ctx, subject, models.UsageSearches) || !s.Consume(ctx, subject, models.UsageSearches) {
+		t.Error("a subject without a key was refused")
+	}
+}
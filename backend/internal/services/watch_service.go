@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"mylittleprice/ent"
+	"mylittleprice/ent/watch"
+	"mylittleprice/internal/config"
+	"mylittleprice/internal/models"
+)
+
+var (
+	ErrWatchNotFound     = errors.New("watch not found")
+	ErrWatchLimitReached = errors.New("watch limit reached")
+	ErrInvalidWatch      = errors.New("invalid watch")
+)
+
+const (
+	watchLimitLockPrefix = "watch_limit_lock:"
+
+	// Held while a watch is created or re-activated; expires on its own if
+	// the instance dies in between
+	watchLimitLockTTL = 10 * time.Second
+)
+
+type WatchService struct {
+	client      *ent.Client
+	redis       *redis.Client
+	authService *AuthService
+	config      *config.Config
+}
+
+func NewWatchService(client *ent.Client, redisClient *redis.Client, authService *AuthService, cfg *config.Config) *WatchService {
+	return &WatchService{
+		client:      client,
+		redis:       redisClient,
+		authService: authService,
+		config:      cfg,
+	}
+}
+
+// BestOffer is a priced offer from a product details response
+type BestOffer struct {
+	Price    float64
+	Currency string
+	Merchant string
+	Link     string
+}
+
+// CreateWatch creates a watch, or updates the target of an existing
+// watch for the same product
+func (s *WatchService) CreateWatch(ctx context.Context, userID uuid.UUID, req *models.CreateWatchRequest) (*models.Watch, error) {
+	req.PageToken = strings.TrimSpace(req.PageToken)
+	req.ProductName = strings.TrimSpace(req.ProductName)
+
+	if req.PageToken == "" {
+		return nil, fmt.Errorf("%w: page_token is required", ErrInvalidWatch)
+	}
+	if req.ProductName == "" {
+		return nil, fmt.Errorf("%w: product_name is required", ErrInvalidWatch)
+	}
+	if req.TargetPrice <= 0 {
+		return nil, fmt.Errorf("%w: target_price must be positive", ErrInvalidWatch)
+	}
+	req.ProductName = truncateRunes(req.ProductName, 500)
+
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = s.config.DefaultCurrency
+	}
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	if country == "" {
+		country = s.config.DefaultCountry
+	}
+
+	// The active watches are counted before one is added; concurrent
+	// requests of the user must not both pass the limit
+	unlock, err := s.lockWatchLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Same product already watched - just move the target
+	existing, err := s.findWatch(ctx, userID, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return s.retargetWatch(ctx, existing, req.TargetPrice, currency)
+	}
+
+	if err := s.checkWatchLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	builder := s.client.Watch.Create().
+		SetUserID(userID).
+		SetPageToken(req.PageToken).
+		SetProductName(req.ProductName).
+		SetTargetPrice(req.TargetPrice).
+		SetCurrency(currency).
+		SetCountryCode(country)
+
+	created, err := builder.Save(ctx)
+	if err != nil && isForeignKeyError(err) {
+		// User may exist only in Redis so far
+		user, lookupErr := s.authService.GetUserByID(userID)
+		if lookupErr != nil {
+			return nil, fmt.Errorf("failed to fetch user from Redis: %w", lookupErr)
+		}
+		if syncErr := s.authService.SaveUserToPostgres(user); syncErr != nil {
+			return nil, fmt.Errorf("failed to sync user to PostgreSQL: %w", syncErr)
+		}
+		created, err = builder.Save(ctx)
+	}
+	if err != nil && ent.IsConstraintError(err) {
+		// A concurrent request created the watch first (unique user_id, page_token)
+		existing, findErr := s.findWatch(ctx, userID, req.PageToken)
+		if findErr == nil && existing != nil {
+			return s.retargetWatch(ctx, existing, req.TargetPrice, currency)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watch: %w", err)
+	}
+
+	fmt.Printf("👀 Watch created for user %s: %s (target %.2f %s)\n", userID.String(), req.ProductName, req.TargetPrice, currency)
+	return entWatchToModel(created), nil
+}
+
+// findWatch returns the user's watch of a product, nil if there is none
+func (s *WatchService) findWatch(ctx context.Context, userID uuid.UUID, pageToken string) (*ent.Watch, error) {
+	existing, err := s.client.Watch.Query().
+		Where(watch.UserIDEQ(userID), watch.PageTokenEQ(pageToken)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing watch: %w", err)
+	}
+	return existing, nil
+}
+
+// retargetWatch moves the target of an existing watch and re-activates
+// it, which counts against the limit like a new watch
+func (s *WatchService) retargetWatch(ctx context.Context, existing *ent.Watch, targetPrice float64, currency string) (*models.Watch, error) {
+	if !existing.Active {
+		if err := s.checkWatchLimit(ctx, existing.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := existing.Update().
+		SetTargetPrice(targetPrice).
+		SetCurrency(currency).
+		SetActive(true).
+		ClearNotifiedPrice().
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update watch: %w", err)
+	}
+	return entWatchToModel(updated), nil
+}
+
+// lockWatchLimit takes a short Redis lock on the watch limit of a user and
+// returns the function releasing it
+func (s *WatchService) lockWatchLimit(ctx context.Context, userID uuid.UUID) (func(), error) {
+	unlock, err := lockKey(ctx, s.redis, watchLimitLockPrefix+userID.String(), watchLimitLockTTL)
+	if errors.Is(err, errLockBusy) {
+		return nil, errors.New("watches are being updated, try again")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock watches: %w", err)
+	}
+	return unlock, nil
+}
+
+// checkWatchLimit fails with ErrWatchLimitReached when the user has
+// PRICE_WATCH_MAX_PER_USER active watches. Callers hold lockWatchLimit.
+func (s *WatchService) checkWatchLimit(ctx context.Context, userID uuid.UUID) error {
+	count, err := s.client.Watch.Query().
+		Where(watch.UserIDEQ(userID), watch.ActiveEQ(true)).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count watches: %w", err)
+	}
+	if count >= s.config.PriceWatchMaxPerUser {
+		return ErrWatchLimitReached
+	}
+	return nil
+}
+
+// ListWatches returns a user's watches, newest first
+func (s *WatchService) ListWatches(ctx context.Context, userID uuid.UUID) ([]models.Watch, error) {
+	items, err := s.client.Watch.Query().
+		Where(watch.UserIDEQ(userID)).
+		Order(ent.Desc(watch.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watches: %w", err)
+	}
+
+	watches := make([]models.Watch, len(items))
+	for i, item := range items {
+		watches[i] = *entWatchToModel(item)
+	}
+	return watches, nil
+}
+
+// DeleteWatch removes a watch owned by the user
+func (s *WatchService) DeleteWatch(ctx context.Context, userID, watchID uuid.UUID) error {
+	deleted, err := s.client.Watch.Delete().
+		Where(watch.IDEQ(watchID), watch.UserIDEQ(userID)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete watch: %w", err)
+	}
+	if deleted == 0 {
+		return ErrWatchNotFound
+	}
+	return nil
+}
+
+// GetDueWatches returns active watches not checked since checkedBefore
+func (s *WatchService) GetDueWatches(ctx context.Context, checkedBefore time.Time, limit int) ([]models.Watch, error) {
+	items, err := s.client.Watch.Query().
+		Where(
+			watch.ActiveEQ(true),
+			watch.Or(
+				watch.LastCheckedAtIsNil(),
+				watch.LastCheckedAtLT(checkedBefore),
+			),
+		).
+		Order(ent.Asc(watch.FieldLastCheckedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due watches: %w", err)
+	}
+
+	watches := make([]models.Watch, len(items))
+	for i, item := range items {
+		watches[i] = *entWatchToModel(item)
+	}
+	return watches, nil
+}
+
+// RecordPriceCheck stores the latest best offer (nil if none was found)
+// and, when notified is true, marks the alert as sent
+func (s *WatchService) RecordPriceCheck(ctx context.Context, watchID uuid.UUID, offer *BestOffer, notified bool) error {
+	now := time.Now()
+	update := s.client.Watch.UpdateOneID(watchID).
+		SetLastCheckedAt(now)
+
+	if offer != nil {
+		update.SetLastPrice(offer.Price).
+			SetLastMerchant(offer.Merchant).
+			SetLastLink(offer.Link)
+	}
+	if notified && offer != nil {
+		update.SetNotifiedPrice(offer.Price).
+			SetNotifiedAt(now)
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record price check: %w", err)
+	}
+	return nil
+}
+
+// ShouldNotify reports whether the offer reaches the watch target and is
+// cheaper than the last price we alerted about
+func ShouldNotify(w *models.Watch, offer *BestOffer) bool {
+	if offer == nil || offer.Price > w.TargetPrice {
+		return false
+	}
+	return w.NotifiedPrice == nil || offer.Price < *w.NotifiedPrice
+}
+
+// FindBestOffer returns the cheapest offer in a google_immersive_product
+// response. Offers in a different currency than the watch are skipped.
+func FindBestOffer(productData map[string]interface{}, currency string) *BestOffer {
+	_, offers := parseProductOffers(productData)
+
+	var best *BestOffer
+	for i := range offers {
+		offer := &offers[i]
+		if offer.Currency != "" && currency != "" && offer.Currency != currency {
+			continue
+		}
+		if best == nil || offer.Price < best.Price {
+			best = offer
+		}
+	}
+
+	return best
+}
+
+// parseProductOffers returns the product title and every priced offer in a
+// google_immersive_product response (stores, falling back to sellers)
+func parseProductOffers(productData map[string]interface{}) (string, []BestOffer) {
+	productResults, ok := productData["product_results"].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+
+	items, ok := productResults["stores"].([]interface{})
+	if !ok {
+		items, _ = productResults["sellers"].([]interface{})
+	}
+
+	offers := make([]BestOffer, 0, len(items))
+	for _, item := range items {
+		offerMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		price := getFloat64FromInterface(offerMap["extracted_price"])
+		if price <= 0 {
+			price = ParsePrice(getStringFromInterface(offerMap["price"]))
+		}
+		if price <= 0 {
+			continue
+		}
+
+		offers = append(offers, BestOffer{
+			Price:    price,
+			Currency: strings.ToUpper(getStringFromInterface(offerMap["currency"])),
+			Merchant: getStringFromInterface(offerMap["name"]),
+			Link:     getStringFromInterface(offerMap["link"]),
+		})
+	}
+
+	return getStringFromInterface(productResults["title"]), offers
+}
+
+func entWatchToModel(w *ent.Watch) *models.Watch {
+	return &models.Watch{
+		ID:            w.ID,
+		UserID:        w.UserID,
+		PageToken:     w.PageToken,
+		ProductName:   w.ProductName,
+		TargetPrice:   w.TargetPrice,
+		Currency:      w.Currency,
+		CountryCode:   w.CountryCode,
+		LastPrice:     w.LastPrice,
+		LastMerchant:  w.LastMerchant,
+		LastLink:      w.LastLink,
+		NotifiedPrice: w.NotifiedPrice,
+		Active:        w.Active,
+		LastCheckedAt: w.LastCheckedAt,
+		NotifiedAt:    w.NotifiedAt,
+		CreatedAt:     w.CreatedAt,
+	}
+}
+
+// truncateRunes cuts s to at most max characters without splitting a
+// multi-byte character, which PostgreSQL would reject
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLength caps the SQL recorded on spans; arguments are never
+// recorded
+const maxStatementLength = 2000
+
+// Driver is an ent driver that traces the queries made within a traced
+// request
+type Driver struct {
+	dialect.Driver
+}
+
+// NewDriver wraps an ent driver with tracing
+func NewDriver(drv dialect.Driver) *Driver {
+	return &Driver{Driver: drv}
+}
+
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query)
+	err := d.Driver.Exec(ctx, query, args, v)
+	End(span, err)
+	return err
+}
+
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query)
+	err := d.Driver.Query(ctx, query, args, v)
+	End(span, err)
+	return err
+}
+
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// BeginTx starts a transaction with options, as ent's client.BeginTx
+// expects of its driver
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver %T does not support transaction options", d.Driver)
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// Tx traces the queries of an ent transaction
+type Tx struct {
+	dialect.Tx
+}
+
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query)
+	err := t.Tx.Exec(ctx, query, args, v)
+	End(span, err)
+	return err
+}
+
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, query)
+	err := t.Tx.Query(ctx, query, args, v)
+	End(span, err)
+	return err
+}
+
+// startQuery names the span after the SQL verb, e.g. "db SELECT"
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	operation = strings.ToUpper(operation)
+
+	statement := query
+	if len(statement) > maxStatementLength {
+		statement = statement[:maxStatementLength]
+	}
+
+	return StartChild(ctx, "db "+operation, trace.SpanKindClient,
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(statement),
+	)
+}
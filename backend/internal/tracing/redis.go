@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook traces Redis commands and pipelines made within a traced
+// request. Keys and arguments are not recorded.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		name := strings.ToUpper(cmd.Name())
+		ctx, span := StartChild(ctx, "redis "+name, trace.SpanKindClient,
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(name),
+		)
+		err := next(ctx, cmd)
+		End(span, redisError(err))
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, strings.ToUpper(cmd.Name()))
+		}
+
+		ctx, span := StartChild(ctx, "redis pipeline", trace.SpanKindClient,
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(strings.Join(names, " ")),
+			attribute.Int("db.operation.batch.size", len(cmds)),
+		)
+		err := next(ctx, cmds)
+		End(span, redisError(err))
+		return err
+	}
+}
+
+// redisError drops redis.Nil, which only means the key doesn't exist
+func redisError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
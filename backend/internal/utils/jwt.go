@@ -16,8 +16,9 @@ var (
 )
 
 type TokenClaims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email"`
+	DeviceID string    `json:"sid,omitempty"` // Refresh token family the token was issued for
 	jwt.RegisteredClaims
 }
 
@@ -37,12 +38,14 @@ func NewJWTService(accessSecret, refreshSecret string, accessTTL, refreshTTL tim
 	}
 }
 
-// GenerateAccessToken creates a short-lived JWT access token
-func (j *JWTService) GenerateAccessToken(userID uuid.UUID, email string) (string, error) {
+// GenerateAccessToken creates a short-lived JWT access token for a device
+// session
+func (j *JWTService) GenerateAccessToken(userID uuid.UUID, email string, deviceID uuid.UUID) (string, error) {
 	now := time.Now()
 	claims := TokenClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:   userID,
+		Email:    email,
+		DeviceID: deviceID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.accessTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -3,32 +3,187 @@ package utils
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// KeyRotator manages API key rotation using Redis
+const (
+	keyHealthBucket        = time.Hour        // Recent usage is counted per hour, the current and previous hour make up a key's health
+	keyHealthRefresh       = 10 * time.Second // How long health scores are reused before they are read from Redis again
+	keyFailureMemory       = 24 * time.Hour   // Consecutive failures of a key that stopped being used are forgotten after this
+	minKeyHealthScore      = 0.05             // Unhealthy keys keep a little traffic so they can recover
+	minKeyLatencyFactor    = 0.25             // A slow key loses at most this much of its score to latency
+	keyHealthPriorSuccess  = 2                // Virtual successes: unused keys start healthy, one failure doesn't sink a key
+	circuitStateClosed     = "closed"
+	circuitStateOpen       = "open"
+	circuitStateHalfOpen   = "half_open"
+	defaultCircuitFailures = 3
+	defaultCircuitCooldown = time.Minute
+)
+
+// KeyRotatorConfig holds the reset window and circuit breaker settings of a
+// rotator
+type KeyRotatorConfig struct {
+	ResetWindow     string        // When exhausted keys get their quota back, see ParseKeyResetWindow
+	CircuitFailures int           // Consecutive non-quota failures that open a key's circuit
+	CircuitCooldown time.Duration // How long an open circuit skips a key before one probe request is let through
+}
+
+// KeyResetWindow is when the quota of an API key resets
+type KeyResetWindow struct {
+	Monthly bool
+	Day     int // Day of the month monthly quotas reset on
+}
+
+// ParseKeyResetWindow parses "daily" (midnight UTC), "monthly" (the 1st of
+// the month, UTC) or "monthly:<day>" for quotas that reset on the billing
+// day, e.g. "monthly:15"
+func ParseKeyResetWindow(value string) (KeyResetWindow, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "daily":
+		return KeyResetWindow{}, nil
+	case "monthly":
+		return KeyResetWindow{Monthly: true, Day: 1}, nil
+	}
+
+	if day, ok := strings.CutPrefix(value, "monthly:"); ok {
+		d, err := strconv.Atoi(day)
+		if err != nil || d < 1 || d > 31 {
+			return KeyResetWindow{}, fmt.Errorf("invalid reset day %q, must be 1-31", day)
+		}
+		return KeyResetWindow{Monthly: true, Day: d}, nil
+	}
+
+	return KeyResetWindow{}, fmt.Errorf("invalid reset window %q, must be daily, monthly or monthly:<day>", value)
+}
+
+// Next returns the first reset after t
+func (w KeyResetWindow) Next(t time.Time) time.Time {
+	t = t.UTC()
+	if !w.Monthly {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+
+	reset := monthlyReset(t.Year(), t.Month(), w.Day)
+	if !reset.After(t) {
+		reset = monthlyReset(t.Year(), t.Month()+1, w.Day)
+	}
+	return reset
+}
+
+func (w KeyResetWindow) String() string {
+	switch {
+	case !w.Monthly:
+		return "daily"
+	case w.Day == 1:
+		return "monthly"
+	default:
+		return fmt.Sprintf("monthly:%d", w.Day)
+	}
+}
+
+// monthlyReset returns the reset day of a month, the last day of months
+// that are too short
+func monthlyReset(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return time.Date(year, month, min(day, lastDay), 0, 0, 0, 0, time.UTC)
+}
+
+// KeyStats is the usage, health and state of one API key
+type KeyStats struct {
+	KeyIndex                int        `json:"key_index"`
+	TotalUsage              int64      `json:"total_usage"`
+	SuccessCount            int64      `json:"success_count"`
+	FailureCount            int64      `json:"failure_count"`
+	AvgResponseTimeMs       int64      `json:"avg_response_time_ms,omitempty"`
+	RecentRequests          int64      `json:"recent_requests"` // Current and previous hour
+	RecentSuccessRate       float64    `json:"recent_success_rate"`
+	RecentAvgResponseTimeMs int64      `json:"recent_avg_response_time_ms,omitempty"` // Successful requests only
+	HealthScore             float64    `json:"health_score"`
+	SelectionWeight         float64    `json:"selection_weight"` // Share of new requests the key gets
+	Exhausted               bool       `json:"exhausted"`
+	ExhaustedUntil          *time.Time `json:"exhausted_until,omitempty"`
+	CircuitState            string     `json:"circuit_state"`
+	ConsecutiveFailures     int64      `json:"consecutive_failures"`
+	CircuitRetryAt          *time.Time `json:"circuit_retry_at,omitempty"` // When an open circuit lets a probe through
+}
+
+// KeyRotatorStats is the state of all keys of a service
+type KeyRotatorStats struct {
+	Service                string     `json:"service"`
+	ResetWindow            string     `json:"reset_window"`
+	NextReset              time.Time  `json:"next_reset"`
+	CircuitFailures        int        `json:"circuit_failures"`
+	CircuitCooldownSeconds int        `json:"circuit_cooldown_seconds"`
+	TotalKeys              int        `json:"total_keys"`
+	AvailableKeys          int        `json:"available_keys"`
+	Keys                   []KeyStats `json:"keys"`
+}
+
+// KeyRotator manages API key rotation using Redis. Keys are picked at
+// random weighted by their health, computed from the success rate and
+// response time of recent requests. Keys whose quota is used up are skipped
+// until the provider's reset window; keys that keep failing otherwise have
+// their circuit opened and get a single probe request after a cooldown.
 type KeyRotator struct {
 	keys        []string
 	serviceName string
 	redis       *redis.Client
+	config      KeyRotatorConfig
+	resetWindow KeyResetWindow
 	mu          sync.Mutex
 	ctx         context.Context
+
+	health   []keyHealth // Cached recent usage, refreshed every keyHealthRefresh
+	healthAt time.Time
+}
+
+// keyHealth is the recent usage of a key
+type keyHealth struct {
+	success   int64
+	failures  int64
+	latencyMs int64 // Total response time of successful requests
+}
+
+// keyState is the rotation state of a key
+type keyState struct {
+	exhaustedTTL time.Duration // Negative when the key isn't exhausted
+	openTTL      time.Duration // Negative when the circuit isn't open
+	failures     int64
 }
 
 // NewKeyRotator creates a new key rotator instance
-func NewKeyRotator(ctx context.Context, serviceName string, keys []string, redisClient *redis.Client) *KeyRotator {
+func NewKeyRotator(ctx context.Context, serviceName string, keys []string, redisClient *redis.Client, config KeyRotatorConfig) *KeyRotator {
+	resetWindow, err := ParseKeyResetWindow(config.ResetWindow)
+	if err != nil {
+		fmt.Printf("⚠️ %s key rotator: %v, using daily resets\n", serviceName, err)
+	}
+	if config.CircuitFailures < 1 {
+		config.CircuitFailures = defaultCircuitFailures
+	}
+	if config.CircuitCooldown <= 0 {
+		config.CircuitCooldown = defaultCircuitCooldown
+	}
+
 	return &KeyRotator{
 		keys:        keys,
 		serviceName: serviceName,
 		redis:       redisClient,
+		config:      config,
+		resetWindow: resetWindow,
 		ctx:         ctx,
 	}
 }
 
-// GetNextKey returns the next API key in rotation, skipping exhausted keys
+// GetNextKey returns an API key, skipping exhausted keys and keys with an
+// open circuit. A key whose circuit cooldown has passed is returned to one
+// caller as a probe; otherwise keys are picked weighted by health.
 func (kr *KeyRotator) GetNextKey() (string, int, error) {
 	kr.mu.Lock()
 	defer kr.mu.Unlock()
@@ -37,61 +192,85 @@ func (kr *KeyRotator) GetNextKey() (string, int, error) {
 		return "", -1, fmt.Errorf("no API keys available for %s", kr.serviceName)
 	}
 
-	// Try to find an available key
-	maxAttempts := len(kr.keys)
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		// Get current index from Redis
-		counterKey := fmt.Sprintf("keyrotator:%s:counter", kr.serviceName)
+	states, err := kr.readStates()
+	if err != nil {
+		// Fallback to first key if Redis fails
+		return kr.keys[0], 0, fmt.Errorf("redis error, using first key: %w", err)
+	}
 
-		// Increment and get the counter (atomic operation)
-		counter, err := kr.redis.Incr(kr.ctx, counterKey).Result()
-		if err != nil {
-			// Fallback to first key if Redis fails
-			return kr.keys[0], 0, fmt.Errorf("redis error, using first key: %w", err)
+	// Half-open keys get one probe at a time, shared across instances
+	for index, state := range states {
+		if kr.circuitState(state) != circuitStateHalfOpen || state.exhaustedTTL >= 0 {
+			continue
 		}
+		acquired, err := kr.redis.SetNX(kr.ctx, kr.circuitKey(index, "probe"), "1", kr.config.CircuitCooldown).Result()
+		if err == nil && acquired {
+			fmt.Printf("   🩺 Probing %s key %d after circuit cooldown\n", kr.serviceName, index)
+			return kr.keys[index], index, nil
+		}
+	}
 
-		// Calculate index using modulo
-		index := int(counter-1) % len(kr.keys)
+	weights := kr.selectionWeights(states, kr.healthScores())
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total == 0 {
+		for _, state := range states {
+			if state.exhaustedTTL < 0 {
+				return "", -1, fmt.Errorf("all API keys of %s are exhausted or failing", kr.serviceName)
+			}
+		}
+		return "", -1, fmt.Errorf("all API keys are exhausted for %s", kr.serviceName)
+	}
 
-		// Check if this key is exhausted
-		if !kr.isKeyExhausted(index) {
+	pick := rand.Float64() * total
+	for index, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+		if pick < weight {
 			return kr.keys[index], index, nil
 		}
-
-		// Key is exhausted, try next one
-		fmt.Printf("   ⏭️  Key %d is exhausted, trying next key...\n", index)
+		pick -= weight
 	}
 
-	// All keys are exhausted
+	// Rounding left the pick past the last weight
+	for index := len(weights) - 1; index >= 0; index-- {
+		if weights[index] > 0 {
+			return kr.keys[index], index, nil
+		}
+	}
 	return "", -1, fmt.Errorf("all API keys are exhausted for %s", kr.serviceName)
 }
 
-// isKeyExhausted checks if a key has been marked as exhausted (quota exceeded)
-func (kr *KeyRotator) isKeyExhausted(keyIndex int) bool {
-	exhaustedKey := fmt.Sprintf("keyrotator:%s:exhausted:%d", kr.serviceName, keyIndex)
-	exists, err := kr.redis.Exists(kr.ctx, exhaustedKey).Result()
-	if err != nil {
-		// If Redis fails, assume key is available
+// IsKeyAvailable reports whether a key is neither exhausted nor behind an
+// open circuit, for callers that keep using a key across requests
+func (kr *KeyRotator) IsKeyAvailable(keyIndex int) bool {
+	if keyIndex < 0 || keyIndex >= len(kr.keys) {
 		return false
 	}
-	return exists > 0
+
+	pipe := kr.redis.Pipeline()
+	exhausted := pipe.Exists(kr.ctx, kr.exhaustedKey(keyIndex))
+	open := pipe.Exists(kr.ctx, kr.circuitKey(keyIndex, "open"))
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		// If Redis fails, assume key is available
+		return true
+	}
+	return exhausted.Val() == 0 && open.Val() == 0
 }
 
-// MarkKeyAsExhausted marks a key as exhausted (quota exceeded) until end of day
+// MarkKeyAsExhausted marks a key as exhausted (quota exceeded) until the
+// provider's quota resets
 func (kr *KeyRotator) MarkKeyAsExhausted(keyIndex int) error {
-	exhaustedKey := fmt.Sprintf("keyrotator:%s:exhausted:%d", kr.serviceName, keyIndex)
-
-	// Calculate TTL until end of day (UTC)
-	now := time.Now().UTC()
-	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.UTC)
-	ttl := endOfDay.Sub(now)
-
-	// If less than 1 minute left, set for 24 hours
-	if ttl < time.Minute {
-		ttl = 24 * time.Hour
+	if keyIndex < 0 || keyIndex >= len(kr.keys) {
+		return fmt.Errorf("invalid key index: %d", keyIndex)
 	}
 
-	err := kr.redis.Set(kr.ctx, exhaustedKey, "1", ttl).Err()
+	ttl := time.Until(kr.resetWindow.Next(time.Now()))
+
+	err := kr.redis.Set(kr.ctx, kr.exhaustedKey(keyIndex), "1", ttl).Err()
 	if err != nil {
 		return fmt.Errorf("failed to mark key as exhausted: %w", err)
 	}
@@ -108,9 +287,17 @@ func (kr *KeyRotator) GetKeyByIndex(index int) (string, error) {
 	return kr.keys[index], nil
 }
 
-// RecordUsage records API key usage for analytics
+// RecordUsage records the outcome of a request made with a key. It feeds the
+// key's health and circuit breaker, so quota errors should be reported with
+// MarkKeyAsExhausted instead of as failures.
 func (kr *KeyRotator) RecordUsage(keyIndex int, success bool, responseTime time.Duration) error {
-	usageKey := fmt.Sprintf("keyrotator:%s:usage:%d", kr.serviceName, keyIndex)
+	// Replayed requests don't use a real key
+	if keyIndex < 0 || keyIndex >= len(kr.keys) {
+		return nil
+	}
+
+	usageKey := kr.usageKey(keyIndex)
+	healthKey := kr.healthKey(keyIndex, time.Now())
 
 	// Increment usage counter
 	pipe := kr.redis.Pipeline()
@@ -119,78 +306,358 @@ func (kr *KeyRotator) RecordUsage(keyIndex int, success bool, responseTime time.
 	// Record success/failure
 	if success {
 		pipe.Incr(kr.ctx, fmt.Sprintf("%s:success", usageKey))
+		pipe.HIncrBy(kr.ctx, healthKey, "success", 1)
+		// Health compares the latency of successful requests only, failures
+		// are often fast
+		pipe.HIncrBy(kr.ctx, healthKey, "latency_ms", responseTime.Milliseconds())
 	} else {
 		pipe.Incr(kr.ctx, fmt.Sprintf("%s:failures", usageKey))
+		pipe.HIncrBy(kr.ctx, healthKey, "failures", 1)
 	}
 
 	// Record response time (milliseconds)
 	pipe.HIncrBy(kr.ctx, fmt.Sprintf("%s:response_times", usageKey), "total", responseTime.Milliseconds())
 	pipe.HIncrBy(kr.ctx, fmt.Sprintf("%s:response_times", usageKey), "count", 1)
+	pipe.Expire(kr.ctx, healthKey, 2*keyHealthBucket)
 
-	_, err := pipe.Exec(kr.ctx)
-	return err
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		return err
+	}
+
+	if success {
+		return kr.closeCircuit(keyIndex)
+	}
+	return kr.recordFailure(keyIndex)
 }
 
-// GetKeyStats returns usage statistics for a specific key
-func (kr *KeyRotator) GetKeyStats(keyIndex int) (map[string]interface{}, error) {
-	usageKey := fmt.Sprintf("keyrotator:%s:usage:%d", kr.serviceName, keyIndex)
+// closeCircuit forgets a key's failures after a successful request
+func (kr *KeyRotator) closeCircuit(keyIndex int) error {
+	pipe := kr.redis.Pipeline()
+	pipe.Del(kr.ctx, kr.circuitKey(keyIndex, "failures"))
+	pipe.Del(kr.ctx, kr.circuitKey(keyIndex, "open"))
+	probe := pipe.Del(kr.ctx, kr.circuitKey(keyIndex, "probe"))
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		return fmt.Errorf("failed to close circuit: %w", err)
+	}
+
+	if probe.Val() > 0 {
+		fmt.Printf("   ✅ %s key %d recovered, circuit closed\n", kr.serviceName, keyIndex)
+	}
+	return nil
+}
+
+// recordFailure counts a consecutive failure and opens the circuit once
+// there are enough of them. A failed probe opens it again right away.
+func (kr *KeyRotator) recordFailure(keyIndex int) error {
+	failuresKey := kr.circuitKey(keyIndex, "failures")
 
-	// Get all stats
 	pipe := kr.redis.Pipeline()
-	totalUsage := pipe.Get(kr.ctx, usageKey)
-	successCount := pipe.Get(kr.ctx, fmt.Sprintf("%s:success", usageKey))
-	failureCount := pipe.Get(kr.ctx, fmt.Sprintf("%s:failures", usageKey))
-	responseTimes := pipe.HGetAll(kr.ctx, fmt.Sprintf("%s:response_times", usageKey))
+	failures := pipe.Incr(kr.ctx, failuresKey)
+	pipe.Expire(kr.ctx, failuresKey, keyFailureMemory)
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		return fmt.Errorf("failed to record key failure: %w", err)
+	}
+
+	if failures.Val() < int64(kr.config.CircuitFailures) {
+		return nil
+	}
+
+	pipe = kr.redis.Pipeline()
+	pipe.Set(kr.ctx, kr.circuitKey(keyIndex, "open"), "1", kr.config.CircuitCooldown)
+	pipe.Del(kr.ctx, kr.circuitKey(keyIndex, "probe"))
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		return fmt.Errorf("failed to open circuit: %w", err)
+	}
+
+	fmt.Printf("   🔌 %s key %d circuit opened after %d consecutive failures (retry in %v)\n",
+		kr.serviceName, keyIndex, failures.Val(), kr.config.CircuitCooldown)
+	return nil
+}
+
+// GetKeyStats returns usage statistics for a specific key
+func (kr *KeyRotator) GetKeyStats(keyIndex int) (*KeyStats, error) {
+	if keyIndex < 0 || keyIndex >= len(kr.keys) {
+		return nil, fmt.Errorf("invalid key index: %d", keyIndex)
+	}
 
-	_, err := pipe.Exec(kr.ctx)
-	if err != nil && err != redis.Nil {
+	stats, err := kr.GetAllStats()
+	if err != nil {
 		return nil, err
 	}
+	return &stats.Keys[keyIndex], nil
+}
 
-	stats := map[string]interface{}{
-		"key_index":     keyIndex,
-		"total_usage":   totalUsage.Val(),
-		"success_count": successCount.Val(),
-		"failure_count": failureCount.Val(),
+// GetAllStats returns the usage, health and circuit state of all keys
+func (kr *KeyRotator) GetAllStats() (*KeyRotatorStats, error) {
+	now := time.Now()
+	stats := &KeyRotatorStats{
+		Service:                kr.serviceName,
+		ResetWindow:            kr.resetWindow.String(),
+		NextReset:              kr.resetWindow.Next(now),
+		CircuitFailures:        kr.config.CircuitFailures,
+		CircuitCooldownSeconds: int(kr.config.CircuitCooldown.Seconds()),
+		TotalKeys:              len(kr.keys),
+		Keys:                   make([]KeyStats, len(kr.keys)),
+	}
+	if len(kr.keys) == 0 {
+		return stats, nil
 	}
 
-	// Calculate average response time
-	rtMap, _ := responseTimes.Result()
-	if total, ok := rtMap["total"]; ok {
-		if count, ok := rtMap["count"]; ok {
-			if countInt, _ := time.ParseDuration(count + "ms"); countInt > 0 {
-				totalInt, _ := time.ParseDuration(total + "ms")
-				avg := totalInt / countInt
-				stats["avg_response_time_ms"] = avg.Milliseconds()
-			}
+	kr.mu.Lock()
+	states, err := kr.readStates()
+	if err != nil {
+		kr.mu.Unlock()
+		return nil, err
+	}
+	kr.healthAt = time.Time{} // Stats always show current health
+	health := kr.healthScores()
+	scores := kr.scores(health)
+	weights := kr.selectionWeights(states, health)
+	kr.mu.Unlock()
+
+	pipe := kr.redis.Pipeline()
+	totals := make([]*redis.StringCmd, len(kr.keys))
+	successes := make([]*redis.StringCmd, len(kr.keys))
+	failures := make([]*redis.StringCmd, len(kr.keys))
+	responseTimes := make([]*redis.MapStringStringCmd, len(kr.keys))
+	for i := range kr.keys {
+		usageKey := kr.usageKey(i)
+		totals[i] = pipe.Get(kr.ctx, usageKey)
+		successes[i] = pipe.Get(kr.ctx, fmt.Sprintf("%s:success", usageKey))
+		failures[i] = pipe.Get(kr.ctx, fmt.Sprintf("%s:failures", usageKey))
+		responseTimes[i] = pipe.HGetAll(kr.ctx, fmt.Sprintf("%s:response_times", usageKey))
+	}
+	if _, err := pipe.Exec(kr.ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	for i := range kr.keys {
+		key := KeyStats{
+			KeyIndex:            i,
+			HealthScore:         roundScore(scores[i]),
+			CircuitState:        kr.circuitState(states[i]),
+			ConsecutiveFailures: states[i].failures,
 		}
+		key.TotalUsage, _ = totals[i].Int64()
+		key.SuccessCount, _ = successes[i].Int64()
+		key.FailureCount, _ = failures[i].Int64()
+
+		// Calculate average response time
+		rtMap := responseTimes[i].Val()
+		total, _ := strconv.ParseInt(rtMap["total"], 10, 64)
+		count, _ := strconv.ParseInt(rtMap["count"], 10, 64)
+		if count > 0 {
+			key.AvgResponseTimeMs = total / count
+		}
+
+		recent := health[i]
+		key.RecentRequests = recent.success + recent.failures
+		key.RecentSuccessRate = 1
+		if key.RecentRequests > 0 {
+			key.RecentSuccessRate = roundScore(float64(recent.success) / float64(key.RecentRequests))
+		}
+		if recent.success > 0 {
+			key.RecentAvgResponseTimeMs = recent.latencyMs / recent.success
+		}
+
+		if totalWeight > 0 {
+			key.SelectionWeight = roundScore(weights[i] / totalWeight)
+		}
+		if states[i].exhaustedTTL >= 0 {
+			key.Exhausted = true
+			until := now.Add(states[i].exhaustedTTL).UTC()
+			key.ExhaustedUntil = &until
+		}
+		if states[i].openTTL >= 0 {
+			retryAt := now.Add(states[i].openTTL).UTC()
+			key.CircuitRetryAt = &retryAt
+		}
+		if !key.Exhausted && key.CircuitState != circuitStateOpen {
+			stats.AvailableKeys++
+		}
+
+		stats.Keys[i] = key
 	}
 
 	return stats, nil
 }
 
-// GetAllStats returns statistics for all keys
-func (kr *KeyRotator) GetAllStats() ([]map[string]interface{}, error) {
-	stats := make([]map[string]interface{}, len(kr.keys))
+// readStates returns the exhaustion and circuit state of every key
+func (kr *KeyRotator) readStates() ([]keyState, error) {
+	pipe := kr.redis.Pipeline()
+	exhausted := make([]*redis.DurationCmd, len(kr.keys))
+	open := make([]*redis.DurationCmd, len(kr.keys))
+	failures := make([]*redis.StringCmd, len(kr.keys))
+	for i := range kr.keys {
+		exhausted[i] = pipe.PTTL(kr.ctx, kr.exhaustedKey(i))
+		open[i] = pipe.PTTL(kr.ctx, kr.circuitKey(i, "open"))
+		failures[i] = pipe.Get(kr.ctx, kr.circuitKey(i, "failures"))
+	}
+	if _, err := pipe.Exec(kr.ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
 
+	states := make([]keyState, len(kr.keys))
 	for i := range kr.keys {
-		keyStats, err := kr.GetKeyStats(i)
-		if err != nil {
-			return nil, err
+		states[i].exhaustedTTL = ttlOrMissing(exhausted[i].Val())
+		states[i].openTTL = ttlOrMissing(open[i].Val())
+		states[i].failures, _ = failures[i].Int64()
+	}
+	return states, nil
+}
+
+func (kr *KeyRotator) circuitState(state keyState) string {
+	switch {
+	case state.openTTL >= 0:
+		return circuitStateOpen
+	case state.failures >= int64(kr.config.CircuitFailures):
+		return circuitStateHalfOpen
+	default:
+		return circuitStateClosed
+	}
+}
+
+// healthScores returns the recent usage of every key, read from the current
+// and previous hour. Callers hold kr.mu.
+func (kr *KeyRotator) healthScores() []keyHealth {
+	now := time.Now()
+	if kr.health != nil && now.Sub(kr.healthAt) < keyHealthRefresh {
+		return kr.health
+	}
+
+	pipe := kr.redis.Pipeline()
+	current := make([]*redis.MapStringStringCmd, len(kr.keys))
+	previous := make([]*redis.MapStringStringCmd, len(kr.keys))
+	for i := range kr.keys {
+		current[i] = pipe.HGetAll(kr.ctx, kr.healthKey(i, now))
+		previous[i] = pipe.HGetAll(kr.ctx, kr.healthKey(i, now.Add(-keyHealthBucket)))
+	}
+
+	health := make([]keyHealth, len(kr.keys))
+	if _, err := pipe.Exec(kr.ctx); err != nil {
+		// Without health data every key is equally healthy
+		return health
+	}
+
+	for i := range kr.keys {
+		for _, bucket := range []map[string]string{current[i].Val(), previous[i].Val()} {
+			success, _ := strconv.ParseInt(bucket["success"], 10, 64)
+			failures, _ := strconv.ParseInt(bucket["failures"], 10, 64)
+			latency, _ := strconv.ParseInt(bucket["latency_ms"], 10, 64)
+			health[i].success += success
+			health[i].failures += failures
+			health[i].latencyMs += latency
 		}
-		stats[i] = keyStats
 	}
 
-	return stats, nil
+	kr.health = health
+	kr.healthAt = now
+	return health
 }
 
-// ResetCounter resets the rotation counter (useful for testing)
+// scores turns recent usage into health scores between minKeyHealthScore
+// and 1: the smoothed success rate, scaled down for keys slower than the
+// fastest one
+func (kr *KeyRotator) scores(health []keyHealth) []float64 {
+	fastest := 0.0
+	for _, h := range health {
+		if h.success > 0 && h.latencyMs > 0 {
+			avg := float64(h.latencyMs) / float64(h.success)
+			if fastest == 0 || avg < fastest {
+				fastest = avg
+			}
+		}
+	}
+
+	scores := make([]float64, len(health))
+	for i, h := range health {
+		requests := h.success + h.failures
+		score := float64(h.success+keyHealthPriorSuccess) / float64(requests+keyHealthPriorSuccess)
+		if h.success > 0 && h.latencyMs > 0 && fastest > 0 {
+			avg := float64(h.latencyMs) / float64(h.success)
+			score *= max(fastest/avg, minKeyLatencyFactor)
+		}
+		scores[i] = max(score, minKeyHealthScore)
+	}
+	return scores
+}
+
+// selectionWeights returns the health scores of keys that can be picked,
+// zero for exhausted keys and keys whose circuit isn't closed
+func (kr *KeyRotator) selectionWeights(states []keyState, health []keyHealth) []float64 {
+	weights := kr.scores(health)
+	for i, state := range states {
+		if state.exhaustedTTL >= 0 || kr.circuitState(state) != circuitStateClosed {
+			weights[i] = 0
+		}
+	}
+	return weights
+}
+
+// ResetCounter clears the recent usage and circuit state of all keys
+// (useful for testing)
 func (kr *KeyRotator) ResetCounter() error {
-	counterKey := fmt.Sprintf("keyrotator:%s:counter", kr.serviceName)
-	return kr.redis.Del(kr.ctx, counterKey).Err()
+	now := time.Now()
+	var keys []string
+	for i := range kr.keys {
+		keys = append(keys,
+			kr.healthKey(i, now),
+			kr.healthKey(i, now.Add(-keyHealthBucket)),
+			kr.circuitKey(i, "failures"),
+			kr.circuitKey(i, "open"),
+			kr.circuitKey(i, "probe"),
+		)
+	}
+
+	kr.mu.Lock()
+	kr.health = nil
+	kr.mu.Unlock()
+
+	if len(keys) == 0 {
+		return nil
+	}
+	return kr.redis.Del(kr.ctx, keys...).Err()
 }
 
 // GetTotalKeys returns the number of available keys
 func (kr *KeyRotator) GetTotalKeys() int {
 	return len(kr.keys)
 }
+
+func (kr *KeyRotator) usageKey(keyIndex int) string {
+	return fmt.Sprintf("keyrotator:%s:usage:%d", kr.serviceName, keyIndex)
+}
+
+func (kr *KeyRotator) exhaustedKey(keyIndex int) string {
+	return fmt.Sprintf("keyrotator:%s:exhausted:%d", kr.serviceName, keyIndex)
+}
+
+func (kr *KeyRotator) circuitKey(keyIndex int, name string) string {
+	return fmt.Sprintf("keyrotator:%s:circuit:%d:%s", kr.serviceName, keyIndex, name)
+}
+
+// healthKey is the recent usage hash of a key for the hour of t
+func (kr *KeyRotator) healthKey(keyIndex int, t time.Time) string {
+	return fmt.Sprintf("keyrotator:%s:health:%d:%d", kr.serviceName, keyIndex, t.Unix()/int64(keyHealthBucket.Seconds()))
+}
+
+// ttlOrMissing maps the PTTL of a flag key to a negative duration when the
+// key is missing and to zero when it has no expiry
+func ttlOrMissing(ttl time.Duration) time.Duration {
+	switch ttl {
+	case -2:
+		return -1
+	case -1:
+		return 0
+	}
+	return ttl
+}
+
+func roundScore(value float64) float64 {
+	return float64(int(value*1000+0.5)) / 1000
+}
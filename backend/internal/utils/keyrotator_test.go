@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKeyResetWindow(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    KeyResetWindow
+		wantErr bool
+	}{
+		{"", KeyResetWindow{}, false},
+		{"daily", KeyResetWindow{}, false},
+		{" Daily ", KeyResetWindow{}, false},
+		{"monthly", KeyResetWindow{Monthly: true, Day: 1}, false},
+		{"monthly:15", KeyResetWindow{Monthly: true, Day: 15}, false},
+		{"MONTHLY:31", KeyResetWindow{Monthly: true, Day: 31}, false},
+		{"monthly:0", KeyResetWindow{}, true},
+		{"monthly:32", KeyResetWindow{}, true},
+		{"monthly:x", KeyResetWindow{}, true},
+		{"weekly", KeyResetWindow{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseKeyResetWindow(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseKeyResetWindow(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseKeyResetWindow(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestKeyResetWindowNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	cet := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		name   string
+		window KeyResetWindow
+		t      time.Time
+		want   time.Time
+	}{
+		{"daily", KeyResetWindow{}, date(2026, 3, 10, 15), date(2026, 3, 11, 0)},
+		{"daily at midnight", KeyResetWindow{}, date(2026, 3, 10, 0), date(2026, 3, 11, 0)},
+		{"daily end of year", KeyResetWindow{}, date(2026, 12, 31, 23), date(2027, 1, 1, 0)},
+		{"daily uses UTC", KeyResetWindow{}, time.Date(2026, 3, 11, 0, 30, 0, 0, cet), date(2026, 3, 11, 0)},
+		{"monthly", KeyResetWindow{Monthly: true, Day: 1}, date(2026, 3, 10, 15), date(2026, 4, 1, 0)},
+		{"monthly on reset", KeyResetWindow{Monthly: true, Day: 1}, date(2026, 4, 1, 0), date(2026, 5, 1, 0)},
+		{"billing day ahead", KeyResetWindow{Monthly: true, Day: 15}, date(2026, 3, 10, 15), date(2026, 3, 15, 0)},
+		{"billing day passed", KeyResetWindow{Monthly: true, Day: 15}, date(2026, 3, 20, 15), date(2026, 4, 15, 0)},
+		{"billing day in december", KeyResetWindow{Monthly: true, Day: 15}, date(2026, 12, 20, 0), date(2027, 1, 15, 0)},
+		{"short month", KeyResetWindow{Monthly: true, Day: 31}, date(2026, 2, 10, 0), date(2026, 2, 28, 0)},
+		{"after short month", KeyResetWindow{Monthly: true, Day: 31}, date(2026, 2, 28, 0), date(2026, 3, 31, 0)},
+		{"leap year", KeyResetWindow{Monthly: true, Day: 30}, date(2028, 2, 1, 0), date(2028, 2, 29, 0)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.window.Next(tt.t); !got.Equal(tt.want) {
+			t.Errorf("%s: Next(%v) = %v, want %v", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"log/slog"
 	"os"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ContextKey represents a key for storing values in context
@@ -116,7 +118,7 @@ func extractContextAttrs(ctx context.Context, args []any) []any {
 		return args
 	}
 
-	attrs := make([]any, 0, len(args)+3)
+	attrs := make([]any, 0, len(args)+5)
 
 	// Extract request_id from context
 	if reqID := ctx.Value(RequestIDKey); reqID != nil {
@@ -139,6 +141,14 @@ func extractContextAttrs(ctx context.Context, args []any) []any {
 		}
 	}
 
+	// Link the log line to the trace it was written in
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs,
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
 	return append(attrs, args...)
 }
 
@@ -156,3 +166,21 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 func WithSessionID(ctx context.Context, sessionID string) context.Context {
 	return context.WithValue(ctx, SessionIDKey, sessionID)
 }
+
+// RequestIDFromContext returns the request ID of a context, empty if none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+// UserIDFromContext returns the user ID of a context, empty if none
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(UserIDKey).(string)
+	return userID
+}
+
+// SessionIDFromContext returns the session ID of a context, empty if none
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(SessionIDKey).(string)
+	return sessionID
+}
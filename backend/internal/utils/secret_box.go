@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix marks values sealed by a SecretBox. Values without it were
+// stored before encryption and are read as they are.
+const sealedPrefix = "enc:v1:"
+
+// SecretBox encrypts short secrets, such as TOTP secrets, for storage with
+// AES-256-GCM. Sealed values are "enc:v1:" followed by the base64 of the
+// nonce and the ciphertext.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox creates a box from a hex-encoded 32-byte key
+func NewSecretBox(hexKey string) (*SecretBox, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("key is not hex: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+// Seal encrypts plaintext with a random nonce
+func (b *SecretBox) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a sealed value. Values stored before encryption are
+// returned unchanged.
+func (b *SecretBox) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	raw, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("sealed value is not base64: %w", err)
+	}
+	if len(raw) < b.aead.NonceSize() {
+		return "", errors.New("sealed value is too short")
+	}
+
+	nonce, ciphertext := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt sealed value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether a stored value was sealed by a SecretBox
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
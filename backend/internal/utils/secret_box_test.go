@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSecretBoxKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestNewSecretBox(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{testSecretBoxKey, false},
+		{" " + testSecretBoxKey + "\n", false},
+		{"", true},
+		{"not-hex", true},
+		{testSecretBoxKey[:32], true},   // 16 bytes
+		{testSecretBoxKey + "20", true}, // 33 bytes
+	}
+
+	for _, tt := range tests {
+		if _, err := NewSecretBox(tt.key); (err != nil) != tt.wantErr {
+			t.Errorf("NewSecretBox(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSecretBoxSealOpen(t *testing.T) {
+	box, err := NewSecretBox(testSecretBoxKey)
+	if err != nil {
+		t.Fatalf("NewSecretBox: %v", err)
+	}
+
+	secret := "JBSWY3DPEHPK3PXP"
+	sealed, err := box.Seal(secret)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if !IsSealed(sealed) || strings.Contains(sealed, secret) {
+		t.Errorf("Seal(%q) = %q, want an enc:v1: value without the secret", secret, sealed)
+	}
+	if again, _ := box.Seal(secret); again == sealed {
+		t.Error("Seal returned the same value twice, want a fresh nonce")
+	}
+
+	if got, err := box.Open(sealed); err != nil || got != secret {
+		t.Errorf("Open(Seal(%q)) = %q, %v", secret, got, err)
+	}
+
+	// Secrets stored before encryption are read as they are
+	if got, err := box.Open(secret); err != nil || got != secret {
+		t.Errorf("Open(%q) = %q, %v, want the value unchanged", secret, got, err)
+	}
+
+	otherBox, _ := NewSecretBox(strings.Repeat("ab", 32))
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if tampered == sealed {
+		tampered = sealed[:len(sealed)-2] + "BB"
+	}
+	failures := map[string]func() (string, error){
+		"other key":  func() (string, error) { return otherBox.Open(sealed) },
+		"tampered":   func() (string, error) { return box.Open(tampered) },
+		"not base64": func() (string, error) { return box.Open(sealedPrefix + "%%%") },
+		"short":      func() (string, error) { return box.Open(sealedPrefix + "AAAA") },
+	}
+	for name, open := range failures {
+		if got, err := open(); err == nil {
+			t.Errorf("%s: Open = %q, want an error", name, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 test key of RFC 6238 appendix B in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B, last 6 of the 8 digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := GenerateTOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateTOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	if _, err := GenerateTOTPCode("not base32!", 1); err == nil {
+		t.Error("GenerateTOTPCode accepted an invalid secret")
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := TOTPStep(now)
+	code := func(step int64) string {
+		c, err := GenerateTOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode: %v", err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", rfc6238Secret, code(step), step, true},
+		{"previous step", rfc6238Secret, code(step - 1), step - 1, true},
+		{"next step", rfc6238Secret, code(step + 1), step + 1, true},
+		{"two steps ago", rfc6238Secret, code(step - 2), 0, false},
+		{"two steps ahead", rfc6238Secret, code(step + 2), 0, false},
+		{"spaces", rfc6238Secret, " 005 924 ", step, true},
+		{"lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code(step), step, true},
+		{"wrong code", rfc6238Secret, "123456", 0, false},
+		{"too short", rfc6238Secret, "00592", 0, false},
+		{"too long", rfc6238Secret, "0059240", 0, false},
+		{"empty", rfc6238Secret, "", 0, false},
+		{"invalid secret", "not base32!", code(step), 0, false},
+	}
+
+	for _, tt := range tests {
+		gotStep, gotOK := ValidateTOTPCode(tt.secret, tt.code, now)
+		if gotOK != tt.wantOK || gotStep != tt.wantStep {
+			t.Errorf("%s: ValidateTOTPCode(%q) = %d, %v, want %d, %v",
+				tt.name, tt.code, gotStep, gotOK, tt.wantStep, tt.wantOK)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("secret length = %d, want 32 (160 bits in base32)", len(secret))
+	}
+	if _, err := GenerateTOTPCode(secret, 1); err != nil {
+		t.Errorf("generated secret is not usable: %v", err)
+	}
+}
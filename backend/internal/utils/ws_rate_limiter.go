@@ -111,17 +111,28 @@ func (rl *WSRateLimiter) CheckConnection(clientID string) (bool, string, time.Du
 // CheckUser checks if a user is allowed to send a message
 // Returns (allowed bool, reason string, retryAfter time.Duration)
 func (rl *WSRateLimiter) CheckUser(userID uuid.UUID) (bool, string, time.Duration) {
+	return rl.CheckUserLimit(userID, rl.userMaxMsg)
+}
+
+// CheckUserLimit checks a user against maxMsg messages per window instead
+// of the configured limit, e.g. the limit of the user's plan. A negative
+// maxMsg is unlimited.
+func (rl *WSRateLimiter) CheckUserLimit(userID uuid.UUID, maxMsg int) (bool, string, time.Duration) {
+	if maxMsg < 0 {
+		return true, "", 0
+	}
+
 	rl.userMu.Lock()
 	bucket, exists := rl.userLimits[userID]
 	if !exists {
 		bucket = &rateLimitBucket{
-			messages: make([]time.Time, 0, rl.userMaxMsg+rl.userBurstAllow),
+			messages: make([]time.Time, 0, maxMsg+rl.userBurstAllow),
 		}
 		rl.userLimits[userID] = bucket
 	}
 	rl.userMu.Unlock()
 
-	return rl.checkBucket(bucket, rl.userMaxMsg, rl.userWindow, rl.userBurstAllow, "user")
+	return rl.checkBucket(bucket, maxMsg, rl.userWindow, rl.userBurstAllow, "user")
 }
 
 // checkBucket performs the actual rate limit check
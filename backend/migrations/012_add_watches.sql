@@ -0,0 +1,30 @@
+-- migrations/012_add_watches.sql
+-- Price-drop watchlist: users watch a product until its best offer reaches a target price
+
+CREATE TABLE IF NOT EXISTS watches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    page_token TEXT NOT NULL,
+    product_name VARCHAR(500) NOT NULL,
+    target_price DOUBLE PRECISION NOT NULL CHECK (target_price > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'CHF',
+    country_code VARCHAR(2) NOT NULL DEFAULT 'CH',
+    last_price DOUBLE PRECISION,
+    last_merchant VARCHAR(255),
+    last_link TEXT,
+    notified_price DOUBLE PRECISION,
+    active BOOLEAN NOT NULL DEFAULT true,
+    last_checked_at TIMESTAMP,
+    notified_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Listing a user's watches
+CREATE INDEX IF NOT EXISTS idx_watches_user_id_created_at ON watches(user_id, created_at);
+
+-- Price watch job: active watches due for a re-check
+CREATE INDEX IF NOT EXISTS idx_watches_active_last_checked_at ON watches(active, last_checked_at);
+
+COMMENT ON COLUMN watches.notified_price IS
+'Best offer price at the time of the last alert. A new alert is only sent when the price drops further.';
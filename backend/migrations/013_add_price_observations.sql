@@ -0,0 +1,21 @@
+-- migrations/013_add_price_observations.sql
+-- Price history: every price seen in search results and product details
+
+CREATE TABLE IF NOT EXISTS price_observations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_key VARCHAR(255) NOT NULL,
+    product_name TEXT NOT NULL,
+    merchant VARCHAR(255),
+    price DOUBLE PRECISION NOT NULL CHECK (price > 0),
+    currency VARCHAR(3) NOT NULL,
+    country_code VARCHAR(2) NOT NULL DEFAULT 'US',
+    source VARCHAR(32) NOT NULL DEFAULT 'search',
+    observed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Price series lookup: one product in one currency, ordered by date
+CREATE INDEX IF NOT EXISTS idx_price_observations_product_key_currency_observed_at
+    ON price_observations(product_key, currency, observed_at);
+
+COMMENT ON COLUMN price_observations.product_key IS
+'Normalized product name (lowercase letters and digits separated by single spaces). Page tokens are not stable across searches.';
@@ -0,0 +1,7 @@
+-- migrations/014_add_user_roles.sql
+-- User roles: admins can read the bug report and contact inboxes (/api/admin/*)
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'user';
+
+COMMENT ON COLUMN users.role IS
+'"user" or "admin". There is no signup path to admin: promote the first admin with UPDATE users SET role = ''admin'' WHERE email = ''...''; later admins via PUT /api/admin/users/:id/role.';
@@ -0,0 +1,19 @@
+-- migrations/015_add_session_participants.sql
+-- Shared chat sessions: users who accepted an invite link to another user's session
+
+CREATE TABLE IF NOT EXISTS session_participants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id TEXT NOT NULL REFERENCES chat_sessions(session_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One grant per user and session; also the access check lookup
+CREATE UNIQUE INDEX IF NOT EXISTS idx_session_participants_session_id_user_id ON session_participants(session_id, user_id);
+
+-- Listing the sessions shared with a user
+CREATE INDEX IF NOT EXISTS idx_session_participants_user_id ON session_participants(user_id);
+
+COMMENT ON TABLE session_participants IS
+'Ownership grants checked by SessionOwnershipValidator. The session owner is chat_sessions.user_id and has no row here. Invite tokens live in Redis (session_invite:<token hash>).';
@@ -0,0 +1,21 @@
+-- migrations/016_add_session_snapshots.sql
+-- Shareable read-only snapshots of chat sessions, served publicly by slug
+
+CREATE TABLE IF NOT EXISTS session_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug TEXT NOT NULL UNIQUE,
+    session_id TEXT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT,
+    messages JSONB NOT NULL,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Listing the snapshots of a session for its owner
+CREATE INDEX IF NOT EXISTS idx_session_snapshots_user_id_session_id ON session_snapshots(user_id, session_id);
+
+COMMENT ON TABLE session_snapshots IS
+'Frozen copies of chat sessions. session_id has no foreign key on purpose: a snapshot stays available after the session is cleaned up. messages holds role, content and product cards only - no user data, session or message IDs.';
@@ -0,0 +1,21 @@
+-- migrations/017_add_audit_log.sql
+-- Audit trail of security and privacy relevant actions (account export and deletion)
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    action TEXT NOT NULL,
+    actor_id UUID,
+    subject_id UUID,
+    ip_address TEXT,
+    details JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Admin audit log filtered by action
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action_created_at ON audit_logs(action, created_at);
+
+-- History of one user
+CREATE INDEX IF NOT EXISTS idx_audit_logs_subject_id_created_at ON audit_logs(subject_id, created_at);
+
+COMMENT ON TABLE audit_logs IS
+'No foreign keys on purpose: entries must survive the deletion of the users they mention. Store IDs and counts only, never personal data.';
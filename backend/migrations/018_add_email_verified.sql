@@ -0,0 +1,10 @@
+-- migrations/018_add_email_verified.sql
+-- Email verification for password signups
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Google only signs in users with a verified email
+UPDATE users SET email_verified = TRUE WHERE provider = 'google';
+
+COMMENT ON COLUMN users.email_verified IS
+'Set by POST /api/auth/verify-email. Verification tokens live in Redis (email_verification:<token hash>). With RESTRICT_UNVERIFIED_USERS=true unverified accounts get the anonymous search limit.';
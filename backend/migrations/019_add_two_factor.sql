@@ -0,0 +1,11 @@
+-- migrations/019_add_two_factor.sql
+-- Optional TOTP two-factor authentication
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_recovery_codes JSONB;
+
+COMMENT ON COLUMN users.totp_secret IS
+'Base32 TOTP secret, set when enrolment is confirmed. Pending enrolments live in Redis (2fa_enrollment:<user id>).';
+COMMENT ON COLUMN users.totp_recovery_codes IS
+'SHA-256 hashes of the unused recovery codes. A code is removed when it is used.';
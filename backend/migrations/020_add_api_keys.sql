@@ -0,0 +1,21 @@
+-- migrations/020_add_api_keys.sql
+-- Personal API keys for programmatic access (Authorization: ApiKey <key>)
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes JSONB NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Listing the keys of a user
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id_created_at ON api_keys(user_id, created_at);
+
+COMMENT ON TABLE api_keys IS
+'Personal API keys. key_hash is the SHA-256 of the key, which is shown once at creation. scopes: chat, search-history, preferences.';
@@ -0,0 +1,29 @@
+-- migrations/021_add_user_identities.sql
+-- External identities (Google and OpenID Connect providers) linked to users
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+
+-- An identity belongs to one user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_subject ON user_identities(provider, subject);
+
+-- One account per provider and user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_user_id_provider ON user_identities(user_id, provider);
+
+-- Existing Google accounts. Rows missing here are also created on the next
+-- Google login.
+INSERT INTO user_identities (user_id, provider, subject, email, created_at)
+SELECT id, 'google', google_id, email, created_at
+FROM users
+WHERE google_id IS NOT NULL AND google_id <> ''
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE user_identities IS
+'Accounts at external identity providers linked to users. subject is the provider''s sub claim. users.google_id is kept for accounts created with Google.';
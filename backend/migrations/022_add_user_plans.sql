@@ -0,0 +1,7 @@
+-- migrations/022_add_user_plans.sql
+-- Usage plans: daily allowances of searches, messages, grounding and product details
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS plan VARCHAR(32) NOT NULL DEFAULT 'free';
+
+COMMENT ON COLUMN users.plan IS
+'"free", "pro" or "internal". Limits of each plan are configured with PLAN_<NAME>_* variables; change a plan with PUT /api/admin/users/:id/plan.';
@@ -0,0 +1,32 @@
+-- migrations/023_add_cost_records.sql
+-- Cost accounting: one row per paid LLM or SerpAPI call, attributed to request, session and user
+
+CREATE TABLE IF NOT EXISTS cost_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id TEXT,
+    session_id TEXT,
+    user_id UUID,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    purpose TEXT NOT NULL,
+    input_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    grounded BOOLEAN NOT NULL DEFAULT FALSE,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Cost of one user over time
+CREATE INDEX IF NOT EXISTS idx_cost_records_user_id_created_at ON cost_records(user_id, created_at);
+
+-- Cost of one session
+CREATE INDEX IF NOT EXISTS idx_cost_records_session_id ON cost_records(session_id);
+
+-- Calls of one request
+CREATE INDEX IF NOT EXISTS idx_cost_records_request_id ON cost_records(request_id);
+
+-- Summaries over a time range and retention cleanup
+CREATE INDEX IF NOT EXISTS idx_cost_records_created_at ON cost_records(created_at);
+
+COMMENT ON TABLE cost_records IS
+'No foreign keys on purpose: totals must survive deleted sessions and accounts. cost_usd is computed with the price table configured when the call was made (COST_MODEL_PRICES, COST_GROUNDING_PRICE, COST_SERPAPI_PRICE).';
@@ -0,0 +1,25 @@
+-- migrations/024_add_job_runs.sql
+-- Scheduled jobs: one row per run with its outcome
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job TEXT NOT NULL,
+    trigger TEXT NOT NULL,
+    triggered_by TEXT,
+    instance TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'running',
+    rows_affected BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- History of one job and its last scheduled run
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started_at ON job_runs(job, started_at);
+
+-- Retention cleanup
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at);
+
+COMMENT ON TABLE job_runs IS
+'The next scheduled run of a job follows from its last run with trigger = ''schedule'', so missed runs are caught up once after a restart. Runs left in status running by a crashed instance are marked abandoned when the job next starts.';
@@ -0,0 +1,11 @@
+-- migrations/025_add_watches_unique_product.sql
+-- One watch per user and product, so concurrent creates cannot duplicate it
+
+-- Keep the most recently updated of existing duplicates
+DELETE FROM watches w
+USING watches newer
+WHERE w.user_id = newer.user_id
+  AND w.page_token = newer.page_token
+  AND (w.updated_at, w.id) < (newer.updated_at, newer.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watches_user_id_page_token ON watches(user_id, page_token);